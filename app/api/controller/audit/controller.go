@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+type Controller struct {
+	auditEventStore store.AuditEventStore
+}
+
+func NewController(
+	auditEventStore store.AuditEventStore,
+) *Controller {
+	return &Controller{
+		auditEventStore: auditEventStore,
+	}
+}
+
+func checkAdminAndFilter(session *auth.Session, filter *types.AuditEventFilter) error {
+	if !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+
+	if filter.Since > 0 && filter.Until > 0 && filter.Since > filter.Until {
+		return usererror.BadRequest("The 'since' time must not be after the 'until' time.")
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// exportBatchSize is the number of audit events read from the store at once during export.
+const exportBatchSize = 100
+
+// List returns a page of the audit log, the most recent events first.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, int64, error) {
+	if err := checkAdminAndFilter(session, filter); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.auditEventStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	events, err := c.auditEventStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, count, nil
+}
+
+// Export writes all audit events matching the filter to the writer as newline delimited JSON,
+// the most recent events first. Pagination of the filter is ignored.
+func (c *Controller) Export(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditEventFilter,
+	w io.Writer,
+) error {
+	if err := checkAdminAndFilter(session, filter); err != nil {
+		return err
+	}
+
+	batchFilter := *filter
+	batchFilter.Page = 1
+	batchFilter.Size = exportBatchSize
+	batchFilter.BeforeID = 0
+
+	enc := json.NewEncoder(w)
+
+	for {
+		events, err := c.auditEventStore.List(ctx, &batchFilter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events for export: %w", err)
+		}
+
+		for _, event := range events {
+			if err = enc.Encode(event); err != nil {
+				return fmt.Errorf("failed to write audit event: %w", err)
+			}
+		}
+
+		if len(events) < exportBatchSize {
+			return nil
+		}
+
+		batchFilter.BeforeID = events[len(events)-1].ID
+	}
+}
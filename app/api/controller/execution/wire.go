@@ -16,6 +16,7 @@ package execution
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
@@ -35,6 +36,7 @@ func ProvideController(
 	authorizer authz.Authorizer,
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
+	checkEvents *checkevents.Reporter,
 	canceler canceler.Canceler,
 	commitService commit.Service,
 	triggerer triggerer.Triggerer,
@@ -42,6 +44,6 @@ func ProvideController(
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 ) *Controller {
-	return NewController(tx, authorizer, executionStore, checkStore,
+	return NewController(tx, authorizer, executionStore, checkStore, checkEvents,
 		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore)
 }
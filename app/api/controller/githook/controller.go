@@ -24,6 +24,8 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/app/services/signature"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
@@ -64,6 +66,10 @@ type Controller struct {
 	urlProvider       url.Provider
 	protectionManager *protection.Manager
 	resourceLimiter   limiter.ResourceLimiter
+	lfsLockStore      store.LFSLockStore
+	mirrorStore       store.RepoMirrorStore
+	signatureService  *signature.Service
+	secretScanService *secretscan.Service
 }
 
 func NewController(
@@ -76,6 +82,10 @@ func NewController(
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
 	limiter limiter.ResourceLimiter,
+	lfsLockStore store.LFSLockStore,
+	mirrorStore store.RepoMirrorStore,
+	signatureService *signature.Service,
+	secretScanService *secretscan.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:        authorizer,
@@ -87,6 +97,10 @@ func NewController(
 		urlProvider:       urlProvider,
 		protectionManager: protectionManager,
 		resourceLimiter:   limiter,
+		lfsLockStore:      lfsLockStore,
+		mirrorStore:       mirrorStore,
+		signatureService:  signatureService,
+		secretScanService: secretScanService,
 	}
 }
 
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/auth"
 	events "github.com/harness/gitness/app/events/git"
@@ -38,6 +39,9 @@ const (
 
 	// gitReferenceNamePrefixTag is the prefix of pull req references.
 	gitReferenceNamePullReq = "refs/pullreq/"
+
+	// gitReferenceNameMergeQueue is the prefix of merge queue references.
+	gitReferenceNameMergeQueue = "refs/mergequeue/"
 )
 
 // PostReceive executes the post-receive hook for a git repository.
@@ -60,9 +64,20 @@ func (c *Controller) PostReceive(
 	// handle branch updates related to PRs - best effort
 	c.handlePRMessaging(ctx, repo, in.PostReceiveInput, &out)
 
+	// replicate the changes to the push mirrors of the repository - best effort
+	c.schedulePushMirrors(ctx, repo)
+
 	return out, nil
 }
 
+// schedulePushMirrors schedules the sync of all push mirrors of the repository.
+func (c *Controller) schedulePushMirrors(ctx context.Context, repo *types.Repository) {
+	err := c.mirrorStore.ScheduleSyncByRepo(ctx, repo.ID, enum.MirrorDirectionPush, time.Now().UnixMilli())
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to schedule sync of push mirrors")
+	}
+}
+
 // reportReferenceEvents is reporting reference events to the event system.
 // NOTE: keep best effort for now as it doesn't change the outcome of the git operation.
 // TODO: in the future we might want to think about propagating errors so user is aware of events not being triggered.
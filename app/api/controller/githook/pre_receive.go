@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -66,6 +67,16 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
+	// pull mirrors are kept in sync with the remote repository, any other push is rejected.
+	isPullMirror, err := c.isPullMirror(ctx, repo.ID)
+	if err != nil {
+		return hook.Output{}, err
+	}
+	if isPullMirror {
+		output.Error = ptr.String(usererror.ErrPullMirrorReadOnly.Error())
+		return output, nil
+	}
+
 	if c.blockPullReqRefUpdate(refUpdates) {
 		output.Error = ptr.String(usererror.ErrPullReqRefsCantBeModified.Error())
 		return output, nil
@@ -82,17 +93,46 @@ func (c *Controller) PreReceive(
 		Metadata:  nil,
 	}
 
-	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+	err = c.checkProtectionRules(ctx, dummySession, repo, in, refUpdates, &output)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 	}
 
+	if output.Error != nil {
+		return output, nil
+	}
+
+	err = c.checkLFSLocks(ctx, repo, principal.ID, in, &output)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to check LFS locks: %w", err)
+	}
+
+	if output.Error != nil {
+		return output, nil
+	}
+
+	err = c.checkSecrets(ctx, repo, principal.ID, in, &output)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to check secrets: %w", err)
+	}
+
 	return output, nil
 }
 
+// isPullMirror returns true if the repository has an enabled pull mirror.
+func (c *Controller) isPullMirror(ctx context.Context, repoID int64) (bool, error) {
+	mirrors, err := c.mirrorStore.List(ctx, repoID, enum.MirrorDirectionPull)
+	if err != nil {
+		return false, fmt.Errorf("failed to list pull mirrors of the repository: %w", err)
+	}
+
+	return slices.ContainsFunc(mirrors, func(m *types.RepoMirror) bool { return m.Enabled }), nil
+}
+
 func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 	fn := func(ref string) bool {
-		return strings.HasPrefix(ref, gitReferenceNamePullReq)
+		return strings.HasPrefix(ref, gitReferenceNamePullReq) ||
+			strings.HasPrefix(ref, gitReferenceNameMergeQueue)
 	}
 
 	return slices.ContainsFunc(refUpdates.other.created, fn) ||
@@ -104,6 +144,7 @@ func (c *Controller) checkProtectionRules(
 	ctx context.Context,
 	session *auth.Session,
 	repo *types.Repository,
+	in types.GithookPreReceiveInput,
 	refUpdates changedRefs,
 	output *hook.Output,
 ) error {
@@ -146,6 +187,50 @@ func (c *Controller) checkProtectionRules(
 	checkAction(protection.RefActionDelete, protection.RefTypeBranch, refUpdates.branches.deleted)
 	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, refUpdates.branches.updated)
 
+	checkAction(protection.RefActionCreate, protection.RefTypeTag, refUpdates.tags.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeTag, refUpdates.tags.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeTag, refUpdates.tags.updated)
+
+	// checkPush verifies the new commits of every updated reference. Commits pushed to
+	// a tag or to any other reference are verified as well, against the push rules only.
+	checkPush := func(refUpdate hook.ReferenceUpdate) {
+		if errCheckAction != nil || refUpdate.New == types.NilSHA {
+			return
+		}
+
+		readParams := git.ReadParams{
+			RepoUID:             repo.GitUID,
+			AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+		}
+
+		pushInfo := protection.NewGitPushInfo(c.git, c.principalStore, c.signatureService,
+			repo, readParams, refUpdate.New)
+
+		var branchName string
+		if strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) {
+			branchName = refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+		}
+
+		violations, err := protectionRules.PushVerify(ctx, protection.PushVerifyInput{
+			Actor:       &session.Principal,
+			AllowBypass: true,
+			IsRepoOwner: isRepoOwner,
+			Repo:        repo,
+			BranchName:  branchName,
+			Push:        pushInfo,
+		})
+		if err != nil {
+			errCheckAction = fmt.Errorf("failed to verify push rules for git push: %w", err)
+			return
+		}
+
+		ruleViolations = append(ruleViolations, violations...)
+	}
+
+	for _, refUpdate := range in.RefUpdates {
+		checkPush(refUpdate)
+	}
+
 	if errCheckAction != nil {
 		return errCheckAction
 	}
@@ -154,16 +239,24 @@ func (c *Controller) checkProtectionRules(
 
 	for _, ruleViolation := range ruleViolations {
 		criticalViolation = criticalViolation || ruleViolation.IsCritical()
+		ruleName := fmt.Sprintf("%q", ruleViolation.Rule.Identifier)
+		if ruleViolation.Rule.SpacePath != "" {
+			ruleName = fmt.Sprintf("%q of space %q", ruleViolation.Rule.Identifier, ruleViolation.Rule.SpacePath)
+		}
 		for _, violation := range ruleViolation.Violations {
-			message := fmt.Sprintf("Rule %q violation: %s", ruleViolation.Rule.Identifier, violation.Message)
+			message := fmt.Sprintf("Rule %s violation: %s", ruleName, violation.Message)
 			output.Messages = append(output.Messages, message)
 		}
 	}
 
 	if criticalViolation {
 		output.Error = ptr.String("Blocked by protection rules.")
+		return nil
 	}
 
+	c.protectionManager.RecordMonitorViolations(ctx, repo.ID, session.Principal.ID,
+		enum.RuleViolationActionPush, ruleViolations)
+
 	return nil
 }
 
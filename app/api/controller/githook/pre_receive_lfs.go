@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+)
+
+// lfsLockPathBatchSize is the maximum number of paths checked for LFS locks in a single query.
+const lfsLockPathBatchSize = 500
+
+// checkLFSLocks blocks the push if it changes files that are locked by other users.
+func (c *Controller) checkLFSLocks(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) error {
+	lockCount, err := c.lfsLockStore.Count(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count LFS locks: %w", err)
+	}
+
+	if lockCount == 0 {
+		return nil
+	}
+
+	revisions := make([]string, 0, len(in.RefUpdates))
+	for _, refUpdate := range in.RefUpdates {
+		if refUpdate.New == types.NilSHA || !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) {
+			continue
+		}
+		revisions = append(revisions, refUpdate.New)
+	}
+
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	changedPaths, err := c.git.ListChangedPaths(ctx, &git.ListChangedPathsParams{
+		ReadParams: git.ReadParams{
+			RepoUID:             repo.GitUID,
+			AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+		},
+		Revisions: revisions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list changed paths: %w", err)
+	}
+
+	var blocked bool
+
+	paths := changedPaths.Paths
+	for len(paths) > 0 {
+		batch := paths
+		if len(batch) > lfsLockPathBatchSize {
+			batch = batch[:lfsLockPathBatchSize]
+		}
+		paths = paths[len(batch):]
+
+		locks, err := c.lfsLockStore.ListByPaths(ctx, repo.ID, batch)
+		if err != nil {
+			return fmt.Errorf("failed to list LFS locks: %w", err)
+		}
+
+		for _, lock := range locks {
+			if lock.CreatedBy == principalID {
+				continue
+			}
+
+			blocked = true
+			output.Messages = append(output.Messages,
+				fmt.Sprintf("File %q is locked by another user (lock %d).", lock.Path, lock.ID))
+		}
+	}
+
+	if blocked {
+		output.Error = ptr.String("Changing files that are locked by other users is not allowed.")
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+)
+
+type fakeGit struct {
+	git.Interface
+	changedPaths []string
+	commits      []git.Commit
+	revisions    []string
+}
+
+func (g *fakeGit) ListChangedPaths(
+	_ context.Context,
+	params *git.ListChangedPathsParams,
+) (git.ListChangedPathsOutput, error) {
+	g.revisions = params.Revisions
+	return git.ListChangedPathsOutput{Paths: g.changedPaths}, nil
+}
+
+type fakeLFSLockStore struct {
+	store.LFSLockStore
+	locks []*types.LFSLock
+}
+
+func (s *fakeLFSLockStore) Count(_ context.Context, repoID int64) (int64, error) {
+	var count int64
+	for _, lock := range s.locks {
+		if lock.RepoID == repoID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *fakeLFSLockStore) ListByPaths(_ context.Context, repoID int64, paths []string) ([]*types.LFSLock, error) {
+	var locks []*types.LFSLock
+	for _, lock := range s.locks {
+		for _, path := range paths {
+			if lock.RepoID == repoID && lock.Path == path {
+				locks = append(locks, lock)
+			}
+		}
+	}
+	return locks, nil
+}
+
+func TestController_checkLFSLocks(t *testing.T) {
+	const (
+		repoID      = 1
+		principalID = 10
+		otherID     = 20
+		sha         = "1111111111111111111111111111111111111111"
+	)
+
+	locks := []*types.LFSLock{
+		{ID: 1, RepoID: repoID, Path: "own.bin", CreatedBy: principalID},
+		{ID: 2, RepoID: repoID, Path: "other.bin", CreatedBy: otherID},
+		{ID: 3, RepoID: 2, Path: "foreign.bin", CreatedBy: otherID},
+	}
+
+	branchUpdate := []hook.ReferenceUpdate{{Ref: "refs/heads/main", Old: types.NilSHA, New: sha}}
+
+	tests := []struct {
+		name          string
+		locks         []*types.LFSLock
+		refUpdates    []hook.ReferenceUpdate
+		changedPaths  []string
+		wantBlocked   bool
+		wantRevisions []string
+	}{
+		{
+			name:         "no locks",
+			refUpdates:   branchUpdate,
+			changedPaths: []string{"other.bin"},
+		},
+		{
+			name:          "file locked by the pusher",
+			locks:         locks,
+			refUpdates:    branchUpdate,
+			changedPaths:  []string{"own.bin", "readme.md"},
+			wantRevisions: []string{sha},
+		},
+		{
+			name:          "file locked by another user",
+			locks:         locks,
+			refUpdates:    branchUpdate,
+			changedPaths:  []string{"own.bin", "other.bin"},
+			wantBlocked:   true,
+			wantRevisions: []string{sha},
+		},
+		{
+			name:          "file locked in another repository",
+			locks:         locks,
+			refUpdates:    branchUpdate,
+			changedPaths:  []string{"foreign.bin"},
+			wantRevisions: []string{sha},
+		},
+		{
+			name:  "branch deletion and tags are ignored",
+			locks: locks,
+			refUpdates: []hook.ReferenceUpdate{
+				{Ref: "refs/heads/feature", Old: sha, New: types.NilSHA},
+				{Ref: "refs/tags/v1", Old: types.NilSHA, New: sha},
+			},
+			changedPaths: []string{"other.bin"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitFake := &fakeGit{changedPaths: test.changedPaths}
+			c := &Controller{
+				git:          gitFake,
+				lfsLockStore: &fakeLFSLockStore{locks: test.locks},
+			}
+
+			in := types.GithookPreReceiveInput{
+				PreReceiveInput: hook.PreReceiveInput{RefUpdates: test.refUpdates},
+			}
+			output := hook.Output{}
+
+			err := c.checkLFSLocks(context.Background(), &types.Repository{ID: repoID}, principalID, in, &output)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if blocked := output.Error != nil; blocked != test.wantBlocked {
+				t.Errorf("blocked: got=%t want=%t (messages=%v)", blocked, test.wantBlocked, output.Messages)
+			}
+
+			if test.wantBlocked && len(output.Messages) != 1 {
+				t.Errorf("messages: got=%v want a single message", output.Messages)
+			}
+
+			if !reflect.DeepEqual(gitFake.revisions, test.wantRevisions) {
+				t.Errorf("revisions: got=%v want=%v", gitFake.revisions, test.wantRevisions)
+			}
+		})
+	}
+}
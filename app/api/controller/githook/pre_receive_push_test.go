@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeAuthorizer struct {
+	authz.Authorizer
+}
+
+func (fakeAuthorizer) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return false, nil
+}
+
+type fakeRuleStore struct {
+	store.RuleStore
+	rules []types.RuleInfoInternal
+}
+
+func (s fakeRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	return s.rules, nil
+}
+
+func (g *fakeGit) ListNewCommits(
+	_ context.Context,
+	params *git.ListNewCommitsParams,
+) (git.ListNewCommitsOutput, error) {
+	g.revisions = params.Revisions
+	return git.ListNewCommitsOutput{Commits: g.commits}, nil
+}
+
+func TestController_checkProtectionRules_Push(t *testing.T) {
+	const newSHA = "1111111111111111111111111111111111111111"
+
+	definition, err := protection.ToJSON(&protection.Push{
+		Push: protection.DefPushPolicy{CommitMessagePatterns: []string{"^JIRA-[0-9]+"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal rule definition: %v", err)
+	}
+
+	rule := types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			ID:         1,
+			Identifier: "messages",
+			Type:       protection.TypePush,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    (&protection.Pattern{Default: true}).JSON(),
+		Definition: definition,
+	}
+
+	tests := []struct {
+		name          string
+		ref           string
+		newSHA        string
+		message       string
+		wantBlocked   bool
+		wantRevisions []string
+	}{
+		{
+			name:          "bad commit pushed to a tag",
+			ref:           "refs/tags/v1.0",
+			newSHA:        newSHA,
+			message:       "fix",
+			wantBlocked:   true,
+			wantRevisions: []string{newSHA},
+		},
+		{
+			name:          "bad commit pushed to a custom ref",
+			ref:           "refs/custom/ref",
+			newSHA:        newSHA,
+			message:       "fix",
+			wantBlocked:   true,
+			wantRevisions: []string{newSHA},
+		},
+		{
+			name:          "good commit pushed to a tag",
+			ref:           "refs/tags/v1.0",
+			newSHA:        newSHA,
+			message:       "JIRA-1 fix",
+			wantRevisions: []string{newSHA},
+		},
+		{
+			name:          "bad commit pushed to the protected branch",
+			ref:           "refs/heads/main",
+			newSHA:        newSHA,
+			message:       "fix",
+			wantBlocked:   true,
+			wantRevisions: []string{newSHA},
+		},
+		{
+			// no rule applies, so the commits aren't even listed
+			name:    "bad commit pushed to an unprotected branch",
+			ref:     "refs/heads/feature",
+			newSHA:  newSHA,
+			message: "fix",
+		},
+		{
+			name:   "tag deleted",
+			ref:    "refs/tags/v1.0",
+			newSHA: types.NilSHA,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protectionManager, err := protection.ProvideManager(fakeRuleStore{rules: []types.RuleInfoInternal{rule}}, nil)
+			if err != nil {
+				t.Fatalf("failed to create protection manager: %v", err)
+			}
+
+			gitFake := &fakeGit{commits: []git.Commit{{SHA: newSHA, Message: test.message}}}
+
+			c := &Controller{
+				authorizer:        fakeAuthorizer{},
+				protectionManager: protectionManager,
+				git:               gitFake,
+			}
+
+			repo := &types.Repository{ID: 1, GitUID: "repo", Path: "space/repo", DefaultBranch: "main"}
+			in := types.GithookPreReceiveInput{
+				PreReceiveInput: hook.PreReceiveInput{
+					RefUpdates: []hook.ReferenceUpdate{{Ref: test.ref, Old: types.NilSHA, New: test.newSHA}},
+				},
+			}
+
+			session := &auth.Session{Principal: types.Principal{ID: 2, UID: "user", Type: enum.PrincipalTypeUser}}
+
+			output := hook.Output{}
+			err = c.checkProtectionRules(context.Background(), session, repo, in,
+				groupRefsByAction(in.RefUpdates), &output)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if blocked := output.Error != nil; blocked != test.wantBlocked {
+				t.Errorf("blocked: got=%t want=%t, messages=%v", blocked, test.wantBlocked, output.Messages)
+			}
+
+			if !reflect.DeepEqual(gitFake.revisions, test.wantRevisions) {
+				t.Errorf("revisions: got=%v want=%v", gitFake.revisions, test.wantRevisions)
+			}
+		})
+	}
+}
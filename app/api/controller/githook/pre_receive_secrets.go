@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+const (
+	// pushOptionSecretsBypass is the push option used to push detected secrets with a justification,
+	// e.g. git push -o secrets.bypass="test fixture".
+	pushOptionSecretsBypass = "secrets.bypass="
+
+	// maxSecretFindingMessages is the maximum number of secret findings reported back to the client.
+	maxSecretFindingMessages = 20
+)
+
+// checkSecrets scans the lines added by the pushed commits for secrets.
+// Depending on the secret scanning mode the push is either blocked or the findings are recorded.
+func (c *Controller) checkSecrets(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) error {
+	revisions := make([]string, 0, len(in.RefUpdates))
+	for _, refUpdate := range in.RefUpdates {
+		if refUpdate.New == types.NilSHA {
+			continue
+		}
+		revisions = append(revisions, refUpdate.New)
+	}
+
+	result, err := c.secretScanService.ScanPush(ctx, repo, git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}, revisions)
+	if err != nil {
+		return fmt.Errorf("failed to scan pushed commits for secrets: %w", err)
+	}
+
+	if len(result.Findings) == 0 {
+		return nil
+	}
+
+	justification, err := getSecretsBypassJustification(in.PushOptions)
+	if err != nil {
+		output.Error = ptr.String(err.Error())
+		return nil
+	}
+
+	for i, finding := range result.Findings {
+		if i == maxSecretFindingMessages {
+			output.Messages = append(output.Messages,
+				fmt.Sprintf("... and %d more.", len(result.Findings)-maxSecretFindingMessages))
+			break
+		}
+
+		output.Messages = append(output.Messages, fmt.Sprintf(
+			"Secret found in %s:%d (rule %q, commit %s, fingerprint %s).",
+			finding.Path, finding.Line, finding.Rule, finding.CommitSHA, finding.Fingerprint))
+	}
+
+	if result.IsBlocked(justification) {
+		output.Messages = append(output.Messages,
+			fmt.Sprintf("To push the secrets anyway, provide a justification: git push -o %s\"<reason>\"",
+				pushOptionSecretsBypass))
+		output.Error = ptr.String("Blocked by secret scanning.")
+		return nil
+	}
+
+	c.secretScanService.RecordFindings(ctx, repo.ID, principalID, enum.SecretFindingSourcePush,
+		"", justification, result.Findings)
+
+	return nil
+}
+
+// getSecretsBypassJustification returns the justification for pushing secrets from the push options.
+func getSecretsBypassJustification(pushOptions []string) (string, error) {
+	for _, option := range pushOptions {
+		if justification, ok := strings.CutPrefix(option, pushOptionSecretsBypass); ok {
+			return secretscan.SanitizeBypassJustification(justification)
+		}
+	}
+
+	return "", nil
+}
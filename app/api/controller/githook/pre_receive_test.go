@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git/hook"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	if s.repo == nil || s.repo.ID != id {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return s.repo, nil
+}
+
+type fakeMirrorStore struct {
+	store.RepoMirrorStore
+	mirrors []*types.RepoMirror
+}
+
+func (s *fakeMirrorStore) List(
+	_ context.Context,
+	repoID int64,
+	direction enum.MirrorDirection,
+) ([]*types.RepoMirror, error) {
+	var mirrors []*types.RepoMirror
+	for _, m := range s.mirrors {
+		if m.RepoID == repoID && m.Direction == direction {
+			mirrors = append(mirrors, m)
+		}
+	}
+	return mirrors, nil
+}
+
+func TestController_PreReceive_PullMirrorReadOnly(t *testing.T) {
+	const repoID = 1
+
+	tests := []struct {
+		name      string
+		mirrors   []*types.RepoMirror
+		internal  bool
+		wantError string
+	}{
+		{
+			name:      "push to pull mirror",
+			mirrors:   []*types.RepoMirror{{RepoID: repoID, Direction: enum.MirrorDirectionPull, Enabled: true}},
+			wantError: usererror.ErrPullMirrorReadOnly.Error(),
+		},
+		{
+			name:     "internal update of pull mirror",
+			mirrors:  []*types.RepoMirror{{RepoID: repoID, Direction: enum.MirrorDirectionPull, Enabled: true}},
+			internal: true,
+		},
+		{
+			name: "push to repository with a disabled pull mirror",
+			mirrors: []*types.RepoMirror{
+				{RepoID: repoID, Direction: enum.MirrorDirectionPull, Enabled: false},
+				{RepoID: repoID, Direction: enum.MirrorDirectionPush, Enabled: true},
+			},
+			// the pull request ref check runs after the mirror check.
+			wantError: usererror.ErrPullReqRefsCantBeModified.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				repoStore:       &fakeRepoStore{repo: &types.Repository{ID: repoID, DefaultBranch: "main"}},
+				mirrorStore:     &fakeMirrorStore{mirrors: test.mirrors},
+				resourceLimiter: limiter.Unlimited{},
+			}
+
+			in := types.GithookPreReceiveInput{
+				GithookInputBase: types.GithookInputBase{RepoID: repoID, Internal: test.internal},
+				PreReceiveInput: hook.PreReceiveInput{
+					RefUpdates: []hook.ReferenceUpdate{{
+						Ref: "refs/pullreq/1/head",
+						Old: types.NilSHA,
+						New: "1111111111111111111111111111111111111111",
+					}},
+				},
+			}
+
+			output, err := c.PreReceive(context.Background(), nil, in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var gotError string
+			if output.Error != nil {
+				gotError = *output.Error
+			}
+
+			if gotError != test.wantError {
+				t.Errorf("got=%q want=%q", gotError, test.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AssigneeAddInput struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+func (in *AssigneeAddInput) sanitize() error {
+	if in.AssigneeID <= 0 {
+		return usererror.BadRequest("A valid assignee ID must be provided.")
+	}
+
+	return nil
+}
+
+// AssigneeAdd assigns a principal to the issue. The assignee must have access to the repository.
+func (c *Controller) AssigneeAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *AssigneeAddInput,
+) (*types.Issue, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	assignee, err := c.principalStore.Find(ctx, in.AssigneeID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequest("Assignee not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assignee: %w", err)
+	}
+
+	// TODO: To check the assignee's access to the repo we create a dummy session object. Fix it.
+	if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
+		Principal: *assignee,
+		Metadata:  nil,
+	}, repo, enum.PermissionRepoView, false); err != nil {
+		log.Ctx(ctx).Info().Msgf("Assignee principal: %s access error: %s", assignee.UID, err)
+		return nil, usererror.BadRequest("The assignee doesn't have enough permissions for the repository.")
+	}
+
+	err = c.assigneeStore.Assign(ctx, &types.IssueAssignee{
+		IssueID:     issue.ID,
+		PrincipalID: assignee.ID,
+		Created:     time.Now().UnixMilli(),
+		CreatedBy:   session.Principal.ID,
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the principal is already assigned to the issue
+		if err = c.issueService.Attach(ctx, issue); err != nil {
+			return nil, err
+		}
+		return issue, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign principal to issue: %w", err)
+	}
+
+	issue = c.writeSystemActivity(ctx, session, issue, &types.IssueActivityPayloadAssignee{
+		Action:   enum.AssigneeActionAssigned,
+		Assignee: assignee.ToPrincipalInfo(),
+	})
+
+	return c.changed(ctx, repo, issue)
+}
+
+// AssigneeDelete removes a principal from the issue assignees.
+func (c *Controller) AssigneeDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	assigneeID int64,
+) (*types.Issue, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	err = c.assigneeStore.Unassign(ctx, issue.ID, assigneeID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("The principal is not assigned to the issue.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unassign principal from issue: %w", err)
+	}
+
+	var assigneeInfo *types.PrincipalInfo
+	if assignee, errFind := c.principalStore.Find(ctx, assigneeID); errFind == nil {
+		assigneeInfo = assignee.ToPrincipalInfo()
+	}
+
+	issue = c.writeSystemActivity(ctx, session, issue, &types.IssueActivityPayloadAssignee{
+		Action:   enum.AssigneeActionUnassigned,
+		Assignee: assigneeInfo,
+	})
+
+	return c.changed(ctx, repo, issue)
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_errors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestController_Assignee(t *testing.T) {
+	c, f := setupController(t)
+	ctx := context.Background()
+	session := testSession(testAuthorID)
+
+	issue := createIssue(t, c)
+
+	assigned, err := c.AssigneeAdd(ctx, session, "space/repo", issue.Number,
+		&AssigneeAddInput{AssigneeID: testAssigneeID})
+	if err != nil {
+		t.Fatalf("failed to assign issue: %v", err)
+	}
+
+	if len(assigned.Assignees) != 1 || assigned.Assignees[0].ID != testAssigneeID {
+		t.Errorf("got=%v want assignee %d", assigned.Assignees, testAssigneeID)
+	}
+
+	// assigning the same principal again doesn't write an activity.
+	if _, err = c.AssigneeAdd(ctx, session, "space/repo", issue.Number,
+		&AssigneeAddInput{AssigneeID: testAssigneeID}); err != nil {
+		t.Fatalf("failed to assign issue: %v", err)
+	}
+
+	for _, test := range []struct {
+		name       string
+		assigneeID int64
+	}{
+		{name: "assignee without access", assigneeID: testNoAccessID},
+		{name: "unknown assignee", assigneeID: 100},
+		{name: "invalid assignee", assigneeID: 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := c.AssigneeAdd(ctx, session, "space/repo", issue.Number,
+				&AssigneeAddInput{AssigneeID: test.assigneeID})
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+				t.Errorf("got=%v want status=%d", err, http.StatusBadRequest)
+			}
+		})
+	}
+
+	unassigned, err := c.AssigneeDelete(ctx, session, "space/repo", issue.Number, testAssigneeID)
+	if err != nil {
+		t.Fatalf("failed to unassign issue: %v", err)
+	}
+
+	if len(unassigned.Assignees) != 0 {
+		t.Errorf("got=%v want no assignees", unassigned.Assignees)
+	}
+
+	_, err = c.AssigneeDelete(ctx, session, "space/repo", issue.Number, testAssigneeID)
+	if !gitness_errors.IsNotFound(err) {
+		t.Errorf("got=%v want not found", err)
+	}
+
+	want := []enum.AssigneeAction{enum.AssigneeActionAssigned, enum.AssigneeActionUnassigned}
+	if len(f.activityStore.payloads) != len(want) {
+		t.Fatalf("activities: got=%d want=%d", len(f.activityStore.payloads), len(want))
+	}
+	for i, payload := range f.activityStore.payloads {
+		p := payload.(*types.IssueActivityPayloadAssignee)
+		if p.Action != want[i] || p.Assignee == nil || p.Assignee.ID != testAssigneeID {
+			t.Errorf("activity %d: got=%+v want %s of %d", i, p, want[i], testAssigneeID)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CommentCreateInput struct {
+	// ParentID is set only for replies
+	ParentID int64 `json:"parent_id"`
+	// Text is comment text
+	Text string `json:"text"`
+}
+
+func (in *CommentCreateInput) sanitize() error {
+	if in.ParentID < 0 {
+		return usererror.BadRequest("A valid parent ID must be provided.")
+	}
+
+	if in.Text == "" {
+		return usererror.BadRequest("Comment text can't be empty.")
+	}
+
+	return nil
+}
+
+// CommentCreate creates a new issue comment (issue activity, type=comment).
+func (c *Controller) CommentCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *CommentCreateInput,
+) (*types.IssueActivity, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var issue *types.Issue
+	var act *types.IssueActivity
+
+	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		var err error
+
+		issue, err = c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+		if err != nil {
+			return fmt.Errorf("failed to find issue by number: %w", err)
+		}
+
+		act = getCommentActivity(session, issue, in)
+
+		if in.ParentID != 0 {
+			var parentAct *types.IssueActivity
+
+			parentAct, err = c.checkIsReplyable(ctx, issue, in.ParentID)
+			if err != nil {
+				return err
+			}
+
+			act.ParentID = &parentAct.ID
+			_ = act.SetPayload(types.IssueActivityPayloadComment{})
+
+			err = c.writeReplyActivity(ctx, parentAct, act)
+		} else {
+			_ = act.SetPayload(types.IssueActivityPayloadComment{})
+			err = c.writeActivity(ctx, issue, act)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write issue comment: %w", err)
+		}
+
+		issue.CommentCount++
+
+		err = c.issueStore.Update(ctx, issue)
+		if err != nil {
+			return fmt.Errorf("failed to increment issue comment counter: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = c.changed(ctx, repo, issue); err != nil {
+		return nil, err
+	}
+
+	if !act.IsReply() {
+		c.eventReporter.CommentCreated(ctx, &issueevents.CommentCreatedPayload{
+			Base:       eventBase(issue, &session.Principal),
+			ActivityID: act.ID,
+		})
+	}
+
+	return act, nil
+}
+
+func (c *Controller) checkIsReplyable(
+	ctx context.Context,
+	issue *types.Issue,
+	parentID int64,
+) (*types.IssueActivity, error) {
+	// make sure the parent comment exists, belongs to the same issue and isn't itself a reply
+	parentAct, err := c.activityStore.Find(ctx, parentID)
+	if errors.Is(err, store.ErrResourceNotFound) || parentAct == nil {
+		return nil, usererror.BadRequest("Parent issue activity not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent issue activity: %w", err)
+	}
+
+	if parentAct.IssueID != issue.ID || parentAct.RepoID != issue.RepoID {
+		return nil, usererror.BadRequest("Parent issue activity doesn't belong to the same issue.")
+	}
+
+	if !parentAct.IsReplyable() {
+		return nil, usererror.BadRequest("Can't create a reply to the specified entry.")
+	}
+
+	return parentAct, nil
+}
+
+// writeReplyActivity updates the parent activity's reply sequence number (using the optimistic locking mechanism),
+// sets the correct Order and SubOrder values and writes the activity to the database.
+// Even if the writing fails, the updating of the sequence number can succeed.
+func (c *Controller) writeReplyActivity(ctx context.Context, parent, act *types.IssueActivity) error {
+	parentUpd, err := c.activityStore.UpdateOptLock(ctx, parent, func(act *types.IssueActivity) error {
+		act.ReplySeq++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get issue activity number: %w", err)
+	}
+
+	*parent = *parentUpd // update the parent issue activity object
+
+	act.Order = parentUpd.Order
+	act.SubOrder = parentUpd.ReplySeq
+
+	err = c.activityStore.Create(ctx, act)
+	if err != nil {
+		return fmt.Errorf("failed to create issue activity: %w", err)
+	}
+
+	return nil
+}
+
+func getCommentActivity(session *auth.Session, issue *types.Issue, in *CommentCreateInput) *types.IssueActivity {
+	now := time.Now().UnixMilli()
+	act := &types.IssueActivity{
+		ID:        0, // Will be populated in the data layer
+		Version:   0,
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+		Edited:    now,
+		Deleted:   nil,
+		ParentID:  nil, // Will be filled in CommentCreate
+		RepoID:    issue.RepoID,
+		IssueID:   issue.ID,
+		Order:     0, // Will be filled in writeActivity/writeReplyActivity
+		SubOrder:  0, // Will be filled in writeReplyActivity
+		ReplySeq:  0,
+		Type:      enum.IssueActivityTypeComment,
+		Kind:      enum.IssueActivityKindComment,
+		Text:      in.Text,
+		Metadata:  nil,
+		Author:    *session.Principal.ToPrincipalInfo(),
+	}
+
+	return act
+}
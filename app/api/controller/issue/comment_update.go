@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CommentUpdateInput struct {
+	Text string `json:"text"`
+}
+
+func (in *CommentUpdateInput) sanitize() error {
+	if in.Text == "" {
+		return usererror.BadRequest("Comment text can't be empty.")
+	}
+
+	return nil
+}
+
+// CommentUpdate updates an issue comment.
+func (c *Controller) CommentUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	commentID int64,
+	in *CommentUpdateInput,
+) (*types.IssueActivity, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	act, err := c.getCommentCheckEditAccess(ctx, session, issue, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	if in.Text == act.Text {
+		return act, nil
+	}
+
+	act, err = c.activityStore.UpdateOptLock(ctx, act, func(act *types.IssueActivity) error {
+		act.Edited = time.Now().UnixMilli()
+		act.Text = in.Text
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	if _, err = c.changed(ctx, repo, issue); err != nil {
+		return nil, err
+	}
+
+	return act, nil
+}
+
+// CommentDelete deletes an issue comment.
+func (c *Controller) CommentDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	commentID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var issue *types.Issue
+
+	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		var err error
+
+		issue, err = c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+		if err != nil {
+			return fmt.Errorf("failed to find issue by number: %w", err)
+		}
+
+		act, err := c.getCommentCheckEditAccess(ctx, session, issue, commentID)
+		if err != nil {
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		act.Deleted = &now
+
+		err = c.activityStore.Update(ctx, act)
+		if err != nil {
+			return fmt.Errorf("failed to mark comment as deleted: %w", err)
+		}
+
+		issue.CommentCount--
+
+		err = c.issueStore.Update(ctx, issue)
+		if err != nil {
+			return fmt.Errorf("failed to decrement issue comment counter: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.changed(ctx, repo, issue)
+
+	return err
+}
+
+func (c *Controller) getCommentCheckEditAccess(ctx context.Context,
+	session *auth.Session, issue *types.Issue, commentID int64,
+) (*types.IssueActivity, error) {
+	if commentID <= 0 {
+		return nil, usererror.BadRequest("A valid comment ID must be provided.")
+	}
+
+	comment, err := c.activityStore.Find(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find comment by ID: %w", err)
+	}
+
+	if comment.Deleted != nil || comment.RepoID != issue.RepoID || comment.IssueID != issue.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	if comment.Kind == enum.IssueActivityKindSystem || comment.Type != enum.IssueActivityTypeComment {
+		return nil, usererror.BadRequest("Only comments can be edited.")
+	}
+
+	if comment.CreatedBy != session.Principal.ID {
+		return nil, usererror.BadRequest("Only own comments may be updated.")
+	}
+
+	return comment, nil
+}
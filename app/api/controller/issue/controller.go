@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	issuesvc "github.com/harness/gitness/app/services/issue"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Controller struct {
+	tx             dbtx.Transactor
+	authorizer     authz.Authorizer
+	repoStore      store.RepoStore
+	principalStore store.PrincipalStore
+	issueStore     store.IssueStore
+	activityStore  store.IssueActivityStore
+	assigneeStore  store.IssueAssigneeStore
+	milestoneStore store.MilestoneStore
+	issueService   *issuesvc.Service
+	labelService   *label.Service
+	eventReporter  *issueevents.Reporter
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	issueStore store.IssueStore,
+	activityStore store.IssueActivityStore,
+	assigneeStore store.IssueAssigneeStore,
+	milestoneStore store.MilestoneStore,
+	issueService *issuesvc.Service,
+	labelService *label.Service,
+	eventReporter *issueevents.Reporter,
+) *Controller {
+	return &Controller{
+		tx:             tx,
+		authorizer:     authorizer,
+		repoStore:      repoStore,
+		principalStore: principalStore,
+		issueStore:     issueStore,
+		activityStore:  activityStore,
+		assigneeStore:  assigneeStore,
+		milestoneStore: milestoneStore,
+		issueService:   issueService,
+		labelService:   labelService,
+		eventReporter:  eventReporter,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, reqPermission enum.Permission,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.Importing {
+		return nil, usererror.BadRequest("Repository import is in progress.")
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, false); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, nil
+}
+
+// getIssueCheckModifyAccess returns the issue if the principal is allowed to modify it:
+// the author of the issue can modify it with the view permission, others need the push permission.
+func (c *Controller) getIssueCheckModifyAccess(ctx context.Context,
+	session *auth.Session, repoRef string, issueNum int64,
+) (*types.Repository, *types.Issue, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	if issue.CreatedBy == session.Principal.ID {
+		return repo, issue, nil
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush, false); err != nil {
+		return nil, nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, issue, nil
+}
+
+// writeActivity updates the issue's activity sequence number (using the optimistic locking mechanism),
+// sets the correct Order value and writes the activity to the database.
+// Even if the writing fails, the updating of the sequence number can succeed.
+func (c *Controller) writeActivity(ctx context.Context, issue *types.Issue, act *types.IssueActivity) error {
+	issueUpd, err := c.issueStore.UpdateActivitySeq(ctx, issue)
+	if err != nil {
+		return fmt.Errorf("failed to get issue activity number: %w", err)
+	}
+
+	*issue = *issueUpd // update the issue object
+
+	act.Order = issueUpd.ActivitySeq
+
+	err = c.activityStore.Create(ctx, act)
+	if err != nil {
+		return fmt.Errorf("failed to create issue activity: %w", err)
+	}
+
+	return nil
+}
+
+// writeSystemActivity writes the system activity of an issue change.
+// Failures are logged only, because the issue is already changed.
+func (c *Controller) writeSystemActivity(
+	ctx context.Context,
+	session *auth.Session,
+	issue *types.Issue,
+	payload types.IssueActivityPayload,
+) *types.Issue {
+	issueUpd, err := c.issueStore.UpdateActivitySeq(ctx, issue)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to update issue activity sequence after %s", payload.ActivityType())
+		return issue
+	}
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, issueUpd, session.Principal.ID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write issue activity after %s", payload.ActivityType())
+	}
+
+	return issueUpd
+}
+
+// changed attaches the details to the issue and notifies the clients about the change.
+func (c *Controller) changed(
+	ctx context.Context,
+	repo *types.Repository,
+	issue *types.Issue,
+) (*types.Issue, error) {
+	if err := c.issueService.Attach(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	c.issueService.Publish(ctx, repo, issue)
+
+	return issue, nil
+}
+
+func eventBase(issue *types.Issue, principal *types.Principal) issueevents.Base {
+	return issueevents.Base{
+		IssueID:     issue.ID,
+		RepoID:      issue.RepoID,
+		PrincipalID: principal.ID,
+		Number:      issue.Number,
+	}
+}
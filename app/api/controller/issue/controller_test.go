@@ -0,0 +1,408 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	issuesvc "github.com/harness/gitness/app/services/issue"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testRepoID      = 1
+	testSpaceID     = 2
+	testAuthorID    = 3
+	testAssigneeID  = 4
+	testNoAccessID  = 5
+	testMilestoneID = 6
+	testReaderID    = 7
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+}
+
+func (fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) error {
+	return nil
+}
+
+// fakeAuthorizer grants all permissions to all principals except the denied permission of a principal.
+type fakeAuthorizer struct {
+	authz.Authorizer
+	denied map[int64]enum.Permission
+}
+
+func (a fakeAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	denied, ok := a.denied[session.Principal.ID]
+	return !ok || denied != permission, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *fakeRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	repo := *s.repo
+	return &repo, nil
+}
+
+func (s *fakeRepoStore) UpdateOptLock(
+	_ context.Context,
+	_ *types.Repository,
+	mutateFn func(repo *types.Repository) error,
+) (*types.Repository, error) {
+	repo := *s.repo
+	if err := mutateFn(&repo); err != nil {
+		return nil, err
+	}
+	s.repo = &repo
+	updated := repo
+	return &updated, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	principal, ok := s.principals[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return principal, nil
+}
+
+type fakeIssueStore struct {
+	store.IssueStore
+	issues map[int64]*types.Issue
+}
+
+func (s *fakeIssueStore) Find(_ context.Context, id int64) (*types.Issue, error) {
+	issue, ok := s.issues[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	i := *issue
+	return &i, nil
+}
+
+func (s *fakeIssueStore) FindByNumber(_ context.Context, repoID, number int64) (*types.Issue, error) {
+	for _, issue := range s.issues {
+		if issue.RepoID == repoID && issue.Number == number {
+			i := *issue
+			return &i, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeIssueStore) Create(_ context.Context, issue *types.Issue) error {
+	for _, i := range s.issues {
+		if i.RepoID == issue.RepoID && i.Number == issue.Number {
+			return gitness_store.ErrDuplicate
+		}
+	}
+	issue.ID = int64(len(s.issues) + 1)
+	i := *issue
+	s.issues[issue.ID] = &i
+	return nil
+}
+
+func (s *fakeIssueStore) UpdateOptLock(
+	_ context.Context,
+	issue *types.Issue,
+	mutateFn func(issue *types.Issue) error,
+) (*types.Issue, error) {
+	i := *s.issues[issue.ID]
+	if err := mutateFn(&i); err != nil {
+		return nil, err
+	}
+	i.Version++
+	s.issues[i.ID] = &i
+	updated := i
+	return &updated, nil
+}
+
+func (s *fakeIssueStore) UpdateActivitySeq(ctx context.Context, issue *types.Issue) (*types.Issue, error) {
+	return s.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		issue.ActivitySeq++
+		return nil
+	})
+}
+
+type fakeActivityStore struct {
+	store.IssueActivityStore
+	payloads []types.IssueActivityPayload
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	_ context.Context,
+	_ *types.Issue,
+	_ int64,
+	payload types.IssueActivityPayload,
+) (*types.IssueActivity, error) {
+	s.payloads = append(s.payloads, payload)
+	return &types.IssueActivity{}, nil
+}
+
+type fakeAssigneeStore struct {
+	store.IssueAssigneeStore
+	principals fakePrincipalStore
+	assignees  map[int64][]int64
+}
+
+func (s *fakeAssigneeStore) Assign(_ context.Context, assignee *types.IssueAssignee) error {
+	for _, id := range s.assignees[assignee.IssueID] {
+		if id == assignee.PrincipalID {
+			return gitness_store.ErrDuplicate
+		}
+	}
+	s.assignees[assignee.IssueID] = append(s.assignees[assignee.IssueID], assignee.PrincipalID)
+	return nil
+}
+
+func (s *fakeAssigneeStore) Unassign(_ context.Context, issueID, principalID int64) error {
+	ids := s.assignees[issueID]
+	for i, id := range ids {
+		if id == principalID {
+			s.assignees[issueID] = append(ids[:i], ids[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeAssigneeStore) ListInfo(
+	_ context.Context,
+	issueIDs []int64,
+) (map[int64][]*types.PrincipalInfo, error) {
+	result := make(map[int64][]*types.PrincipalInfo)
+	for _, issueID := range issueIDs {
+		for _, id := range s.assignees[issueID] {
+			result[issueID] = append(result[issueID], s.principals.principals[id].ToPrincipalInfo())
+		}
+	}
+	return result, nil
+}
+
+type fakeMilestoneStore struct {
+	store.MilestoneStore
+	milestones map[int64]*types.Milestone
+}
+
+func (s fakeMilestoneStore) Find(_ context.Context, id int64) (*types.Milestone, error) {
+	milestone, ok := s.milestones[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return milestone, nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+}
+
+func (s fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	space, ok := s.spaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return space, nil
+}
+
+type fakeLabelStore struct {
+	store.LabelStore
+	labels map[int64]*types.Label
+}
+
+func (s fakeLabelStore) Find(_ context.Context, id int64) (*types.Label, error) {
+	lbl, ok := s.labels[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return lbl, nil
+}
+
+type fakeIssueLabelStore struct {
+	store.IssueLabelStore
+	labelStore fakeLabelStore
+	assigned   map[int64][]int64
+}
+
+func (s *fakeIssueLabelStore) Assign(_ context.Context, issueLabel *types.IssueLabel) error {
+	s.assigned[issueLabel.IssueID] = append(s.assigned[issueLabel.IssueID], issueLabel.LabelID)
+	return nil
+}
+
+func (s *fakeIssueLabelStore) Unassign(_ context.Context, issueID, labelID int64) error {
+	ids := s.assigned[issueID]
+	for i, id := range ids {
+		if id == labelID {
+			s.assigned[issueID] = append(ids[:i], ids[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeIssueLabelStore) ListInfo(_ context.Context, issueIDs []int64) (map[int64][]*types.LabelInfo, error) {
+	result := make(map[int64][]*types.LabelInfo)
+	for _, issueID := range issueIDs {
+		for _, id := range s.assigned[issueID] {
+			result[issueID] = append(result[issueID], s.labelStore.labels[id].ToLabelInfo())
+		}
+	}
+	return result, nil
+}
+
+type fakes struct {
+	repoStore     *fakeRepoStore
+	issueStore    *fakeIssueStore
+	activityStore *fakeActivityStore
+	assignees     *fakeAssigneeStore
+	issueLabels   *fakeIssueLabelStore
+}
+
+// setupController returns a controller for the repository "space/repo" of a space with a parent space.
+// The labels "kind:bug" and "kind:feature" are defined in the parent space and "urgent" in the space.
+// The principal testNoAccessID can't view the repository and testReaderID can't push to it.
+func setupController(t *testing.T) (*Controller, *fakes) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+
+	eventReporter, err := issueevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create issue event reporter: %v", err)
+	}
+
+	pullreqReaderFactory, err := pullreqevents.NewReaderFactory(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reader factory: %v", err)
+	}
+
+	principals := fakePrincipalStore{principals: map[int64]*types.Principal{
+		testAuthorID:   {ID: testAuthorID, UID: "author", Type: enum.PrincipalTypeUser},
+		testAssigneeID: {ID: testAssigneeID, UID: "assignee", Type: enum.PrincipalTypeUser},
+		testNoAccessID: {ID: testNoAccessID, UID: "no-access", Type: enum.PrincipalTypeUser},
+		testReaderID:   {ID: testReaderID, UID: "reader", Type: enum.PrincipalTypeUser},
+	}}
+
+	labels := fakeLabelStore{labels: map[int64]*types.Label{
+		1: {ID: 1, SpaceID: 1, Key: "kind", Value: "bug"},
+		2: {ID: 2, SpaceID: 1, Key: "kind", Value: "feature"},
+		3: {ID: 3, SpaceID: testSpaceID, Key: "urgent"},
+		4: {ID: 4, SpaceID: 99, Key: "other space"},
+	}}
+
+	f := &fakes{
+		repoStore: &fakeRepoStore{repo: &types.Repository{
+			ID:            testRepoID,
+			ParentID:      testSpaceID,
+			Path:          "space/repo",
+			PullReqSeq:    5,
+			Identifier:    "repo",
+			GitUID:        "repo-uid",
+			DefaultBranch: "main",
+		}},
+		issueStore:    &fakeIssueStore{issues: map[int64]*types.Issue{}},
+		activityStore: &fakeActivityStore{},
+		assignees:     &fakeAssigneeStore{principals: principals, assignees: map[int64][]int64{}},
+		issueLabels:   &fakeIssueLabelStore{labelStore: labels, assigned: map[int64][]int64{}},
+	}
+
+	milestones := fakeMilestoneStore{milestones: map[int64]*types.Milestone{
+		testMilestoneID: {ID: testMilestoneID, RepoID: testRepoID, Title: "v1", State: enum.MilestoneStateOpen},
+		7:               {ID: 7, RepoID: 99, Title: "other repo", State: enum.MilestoneStateOpen},
+	}}
+
+	spaces := fakeSpaceStore{spaces: map[int64]*types.Space{
+		1:           {ID: 1},
+		testSpaceID: {ID: testSpaceID, ParentID: 1},
+	}}
+
+	labelService := label.NewService(fakeTx{}, spaces, labels, nil, f.issueLabels)
+
+	issueService, err := issuesvc.NewService(ctx,
+		issuesvc.Config{EventReaderName: "test", Concurrency: 1},
+		fakeTx{}, nil, f.repoStore, nil, f.issueStore, f.activityStore, f.assignees, milestones,
+		labelService, eventReporter, fakeStreamer{}, pullreqReaderFactory)
+	if err != nil {
+		t.Fatalf("failed to create issue service: %v", err)
+	}
+
+	c := NewController(fakeTx{}, fakeAuthorizer{denied: map[int64]enum.Permission{
+		testNoAccessID: enum.PermissionRepoView,
+		testReaderID:   enum.PermissionRepoPush,
+	}},
+		f.repoStore, principals, f.issueStore, f.activityStore, f.assignees, milestones,
+		issueService, labelService, eventReporter)
+
+	return c, f
+}
+
+func testSession(principalID int64) *auth.Session {
+	return &auth.Session{Principal: types.Principal{ID: principalID, Type: enum.PrincipalTypeUser}}
+}
+
+// createIssue creates an open issue authored by testAuthorID.
+func createIssue(t *testing.T, c *Controller) *types.Issue {
+	t.Helper()
+
+	issue, err := c.Create(context.Background(), testSession(testAuthorID), "space/repo",
+		&CreateInput{Title: "Crash on start"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	return issue
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func (in *CreateInput) sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	if in.Title == "" {
+		return usererror.BadRequest("Issue title can't be empty.")
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+
+	return nil
+}
+
+// Create creates a new issue in the repository.
+// Issues take their number from the same sequence as the pull requests of the repository.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.Issue, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		repo.PullReqSeq++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire PullReqSeq number: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	issue := &types.Issue{
+		Number:      repo.PullReqSeq,
+		RepoID:      repo.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+		Edited:      now,
+		State:       enum.IssueStateOpen,
+		Title:       in.Title,
+		Description: in.Description,
+		Author:      *session.Principal.ToPrincipalInfo(),
+	}
+
+	if err = c.issueStore.Create(ctx, issue); err != nil {
+		return nil, fmt.Errorf("issue creation failed: %w", err)
+	}
+
+	c.eventReporter.Created(ctx, &issueevents.CreatedPayload{
+		Base: eventBase(issue, &session.Principal),
+	})
+
+	return c.changed(ctx, repo, issue)
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestController_Create(t *testing.T) {
+	c, f := setupController(t)
+	ctx := context.Background()
+
+	// the repository has five pull requests, so the first issue gets the number six.
+	first, err := c.Create(ctx, testSession(testAuthorID), "space/repo", &CreateInput{Title: " Crash on start "})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	if first.Number != 6 || f.repoStore.repo.PullReqSeq != 6 {
+		t.Errorf("got number=%d seq=%d want number=6 seq=6", first.Number, f.repoStore.repo.PullReqSeq)
+	}
+	if first.Title != "Crash on start" || first.State != enum.IssueStateOpen || first.Author.ID != testAuthorID {
+		t.Errorf("got=%+v want open issue %q by %d", first, "Crash on start", testAuthorID)
+	}
+	if first.Assignees == nil || first.Labels == nil {
+		t.Errorf("expected the issue details to be attached")
+	}
+
+	// a pull request created in between takes the next number of the shared sequence.
+	f.repoStore.repo.PullReqSeq++
+
+	second, err := c.Create(ctx, testSession(testAuthorID), "space/repo", &CreateInput{Title: "Typo"})
+	if err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	if second.Number != 8 || f.repoStore.repo.PullReqSeq != 8 {
+		t.Errorf("got number=%d seq=%d want number=8 seq=8", second.Number, f.repoStore.repo.PullReqSeq)
+	}
+
+	_, err = c.Create(ctx, testSession(testAuthorID), "space/repo", &CreateInput{Title: "  "})
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Errorf("got=%v want status=%d", err, http.StatusBadRequest)
+	}
+	if f.repoStore.repo.PullReqSeq != 8 {
+		t.Errorf("expected an invalid issue to not take a number, got seq=%d", f.repoStore.repo.PullReqSeq)
+	}
+}
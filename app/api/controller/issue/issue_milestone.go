@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MilestoneSetInput struct {
+	// MilestoneID is the milestone of the issue, zero removes the issue from its milestone.
+	MilestoneID int64 `json:"milestone_id"`
+}
+
+// MilestoneSet sets or clears the milestone of the issue.
+func (c *Controller) MilestoneSet(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *MilestoneSetInput,
+) (*types.Issue, error) {
+	if in.MilestoneID < 0 {
+		return nil, usererror.BadRequest("A valid milestone ID must be provided.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	if err = c.issueService.Attach(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	var milestone *types.Milestone
+	if in.MilestoneID != 0 {
+		milestone, err = c.findMilestone(ctx, repo, in.MilestoneID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if (milestone == nil && issue.MilestoneID == nil) ||
+		(milestone != nil && issue.MilestoneID != nil && *issue.MilestoneID == milestone.ID) {
+		return issue, nil
+	}
+
+	payload := &types.IssueActivityPayloadMilestone{
+		Old: issue.Milestone,
+	}
+
+	issue, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		issue.MilestoneID = nil
+		if milestone != nil {
+			issue.MilestoneID = &milestone.ID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue milestone: %w", err)
+	}
+
+	if milestone != nil {
+		payload.New = milestone.ToMilestoneInfo()
+	}
+
+	issue = c.writeSystemActivity(ctx, session, issue, payload)
+
+	return c.changed(ctx, repo, issue)
+}
+
+// findMilestone returns the milestone of the repository.
+func (c *Controller) findMilestone(
+	ctx context.Context,
+	repo *types.Repository,
+	milestoneID int64,
+) (*types.Milestone, error) {
+	milestone, err := c.milestoneStore.Find(ctx, milestoneID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) || (err == nil && milestone.RepoID != repo.ID) {
+		return nil, errors.NotFound("Milestone not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find milestone: %w", err)
+	}
+
+	return milestone, nil
+}
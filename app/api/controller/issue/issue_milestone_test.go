@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"testing"
+
+	gitness_errors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+)
+
+func TestController_MilestoneSet(t *testing.T) {
+	c, f := setupController(t)
+	ctx := context.Background()
+	session := testSession(testAuthorID)
+
+	issue := createIssue(t, c)
+
+	issue, err := c.MilestoneSet(ctx, session, "space/repo", issue.Number,
+		&MilestoneSetInput{MilestoneID: testMilestoneID})
+	if err != nil {
+		t.Fatalf("failed to set milestone: %v", err)
+	}
+	if issue.Milestone == nil || issue.Milestone.ID != testMilestoneID || issue.Milestone.Title != "v1" {
+		t.Errorf("got=%+v want milestone %d", issue.Milestone, testMilestoneID)
+	}
+
+	// setting the same milestone again doesn't write an activity.
+	if _, err = c.MilestoneSet(ctx, session, "space/repo", issue.Number,
+		&MilestoneSetInput{MilestoneID: testMilestoneID}); err != nil {
+		t.Fatalf("failed to set milestone: %v", err)
+	}
+
+	// milestones of other repositories can't be used.
+	_, err = c.MilestoneSet(ctx, session, "space/repo", issue.Number, &MilestoneSetInput{MilestoneID: 7})
+	if !gitness_errors.IsNotFound(err) {
+		t.Errorf("got=%v want not found", err)
+	}
+
+	issue, err = c.MilestoneSet(ctx, session, "space/repo", issue.Number, &MilestoneSetInput{})
+	if err != nil {
+		t.Fatalf("failed to clear milestone: %v", err)
+	}
+	if issue.Milestone != nil || issue.MilestoneID != nil {
+		t.Errorf("got=%+v want no milestone", issue.Milestone)
+	}
+
+	if len(f.activityStore.payloads) != 2 {
+		t.Fatalf("activities: got=%d want=2", len(f.activityStore.payloads))
+	}
+
+	set := f.activityStore.payloads[0].(*types.IssueActivityPayloadMilestone)
+	if set.Old != nil || set.New == nil || set.New.ID != testMilestoneID {
+		t.Errorf("set activity: got=%+v", set)
+	}
+
+	cleared := f.activityStore.payloads[1].(*types.IssueActivityPayloadMilestone)
+	if cleared.Old == nil || cleared.Old.ID != testMilestoneID || cleared.New != nil {
+		t.Errorf("clear activity: got=%+v", cleared)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestController_State(t *testing.T) {
+	c, f := setupController(t)
+	ctx := context.Background()
+
+	issue := createIssue(t, c)
+
+	// the author can close the issue without the push permission.
+	closed, err := c.State(ctx, testSession(testAuthorID), "space/repo", issue.Number,
+		&StateInput{State: enum.IssueStateClosed})
+	if err != nil {
+		t.Fatalf("failed to close issue: %v", err)
+	}
+
+	if closed.State != enum.IssueStateClosed || closed.Closed == nil ||
+		closed.ClosedBy == nil || *closed.ClosedBy != testAuthorID {
+		t.Errorf("got state=%s closed_by=%v want closed by %d", closed.State, closed.ClosedBy, testAuthorID)
+	}
+
+	// closing a closed issue doesn't change it.
+	_, err = c.State(ctx, testSession(testAuthorID), "space/repo", issue.Number,
+		&StateInput{State: enum.IssueStateClosed})
+	if err != nil {
+		t.Fatalf("failed to close issue: %v", err)
+	}
+
+	// others need the push permission.
+	_, err = c.State(ctx, testSession(testReaderID), "space/repo", issue.Number,
+		&StateInput{State: enum.IssueStateOpen})
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Errorf("got=%v want=%v", err, apiauth.ErrNotAuthorized)
+	}
+
+	reopened, err := c.State(ctx, testSession(testAssigneeID), "space/repo", issue.Number,
+		&StateInput{State: enum.IssueStateOpen})
+	if err != nil {
+		t.Fatalf("failed to reopen issue: %v", err)
+	}
+
+	if reopened.State != enum.IssueStateOpen || reopened.Closed != nil || reopened.ClosedBy != nil {
+		t.Errorf("got state=%s closed=%v closed_by=%v want open", reopened.State, reopened.Closed, reopened.ClosedBy)
+	}
+
+	want := []types.IssueActivityPayload{
+		&types.IssueActivityPayloadStateChange{Old: enum.IssueStateOpen, New: enum.IssueStateClosed},
+		&types.IssueActivityPayloadStateChange{Old: enum.IssueStateClosed, New: enum.IssueStateOpen},
+	}
+	if len(f.activityStore.payloads) != len(want) {
+		t.Fatalf("activities: got=%d want=%d", len(f.activityStore.payloads), len(want))
+	}
+	for i, payload := range f.activityStore.payloads {
+		if *payload.(*types.IssueActivityPayloadStateChange) != *want[i].(*types.IssueActivityPayloadStateChange) {
+			t.Errorf("activity %d: got=%+v want=%+v", i, payload, want[i])
+		}
+	}
+
+	_, err = c.State(ctx, testSession(testAuthorID), "space/repo", issue.Number,
+		&StateInput{State: "merged"})
+	if err == nil {
+		t.Errorf("expected an invalid state to be rejected")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+type UpdateInput struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+}
+
+func (in *UpdateInput) sanitize() error {
+	if in.Title != nil {
+		*in.Title = strings.TrimSpace(*in.Title)
+		if *in.Title == "" {
+			return usererror.BadRequest("Issue title can't be empty.")
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+	}
+
+	return nil
+}
+
+// Update updates the title and the description of the issue.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *UpdateInput,
+) (*types.Issue, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, issue, err := c.getIssueCheckModifyAccess(ctx, session, repoRef, issueNum)
+	if err != nil {
+		return nil, err
+	}
+
+	titleChanged := in.Title != nil && *in.Title != issue.Title
+	descriptionChanged := in.Description != nil && *in.Description != issue.Description
+
+	if !titleChanged && !descriptionChanged {
+		if err = c.issueService.Attach(ctx, issue); err != nil {
+			return nil, err
+		}
+		return issue, nil
+	}
+
+	oldTitle := issue.Title
+
+	issue, err = c.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		if in.Title != nil {
+			issue.Title = *in.Title
+		}
+		if in.Description != nil {
+			issue.Description = *in.Description
+		}
+		issue.Edited = time.Now().UnixMilli()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	if titleChanged {
+		issue = c.writeSystemActivity(ctx, session, issue, &types.IssueActivityPayloadTitleChange{
+			Old: oldTitle,
+			New: issue.Title,
+		})
+	}
+
+	return c.changed(ctx, repo, issue)
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LabelAssignInput struct {
+	LabelID int64 `json:"label_id"`
+}
+
+func (in *LabelAssignInput) sanitize() error {
+	if in.LabelID <= 0 {
+		return usererror.BadRequest("A valid label ID must be provided.")
+	}
+
+	return nil
+}
+
+// LabelAssign assigns a label to the issue. The label must be defined in the parent space of
+// the repository or in any of its ancestors. A scoped label replaces the assigned label with the same key.
+func (c *Controller) LabelAssign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	in *LabelAssignInput,
+) (*types.Issue, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	out, err := c.labelService.AssignToIssue(ctx, session.Principal.ID, repo, issue, in.LabelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !out.Assigned {
+		if err = c.issueService.Attach(ctx, issue); err != nil {
+			return nil, err
+		}
+		return issue, nil
+	}
+
+	payload := &types.IssueActivityPayloadLabel{
+		Action: enum.LabelActionAssigned,
+		Label:  out.Label.ToLabelInfo(),
+	}
+	if out.Replaced != nil {
+		payload.Action = enum.LabelActionReassigned
+		payload.OldLabel = out.Replaced
+	}
+
+	issue = c.writeSystemActivity(ctx, session, issue, payload)
+
+	return c.changed(ctx, repo, issue)
+}
+
+// LabelUnassign removes a label from the issue.
+func (c *Controller) LabelUnassign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	issueNum int64,
+	labelID int64,
+) (*types.Issue, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	issue, err := c.issueStore.FindByNumber(ctx, repo.ID, issueNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find issue by number: %w", err)
+	}
+
+	label, err := c.labelService.UnassignFromIssue(ctx, issue, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	issue = c.writeSystemActivity(ctx, session, issue, &types.IssueActivityPayloadLabel{
+		Action: enum.LabelActionUnassigned,
+		Label:  label,
+	})
+
+	return c.changed(ctx, repo, issue)
+}
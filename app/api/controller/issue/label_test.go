@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"testing"
+
+	gitness_errors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestController_Label(t *testing.T) {
+	c, f := setupController(t)
+	ctx := context.Background()
+	session := testSession(testAuthorID)
+
+	issue := createIssue(t, c)
+
+	labelIDs := func(issue *types.Issue) []int64 {
+		ids := make([]int64, len(issue.Labels))
+		for i, lbl := range issue.Labels {
+			ids[i] = lbl.ID
+		}
+		return ids
+	}
+
+	// labels of the space of the repository and of its ancestors can be assigned.
+	for _, labelID := range []int64{3, 1} {
+		issue, err := c.LabelAssign(ctx, session, "space/repo", issue.Number, &LabelAssignInput{LabelID: labelID})
+		if err != nil {
+			t.Fatalf("failed to assign label %d: %v", labelID, err)
+		}
+		if ids := labelIDs(issue); ids[len(ids)-1] != labelID {
+			t.Errorf("got=%v want label %d assigned", ids, labelID)
+		}
+	}
+
+	// a scoped label replaces the assigned label with the same key.
+	issue, err := c.LabelAssign(ctx, session, "space/repo", issue.Number, &LabelAssignInput{LabelID: 2})
+	if err != nil {
+		t.Fatalf("failed to assign label: %v", err)
+	}
+	if ids := labelIDs(issue); len(ids) != 2 || ids[0] != 3 || ids[1] != 2 {
+		t.Errorf("got=%v want=[3 2]", ids)
+	}
+
+	_, err = c.LabelAssign(ctx, session, "space/repo", issue.Number, &LabelAssignInput{LabelID: 4})
+	if !gitness_errors.IsNotFound(err) {
+		t.Errorf("label of another space: got=%v want not found", err)
+	}
+
+	issue, err = c.LabelUnassign(ctx, session, "space/repo", issue.Number, 3)
+	if err != nil {
+		t.Fatalf("failed to unassign label: %v", err)
+	}
+	if ids := labelIDs(issue); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("got=%v want=[2]", ids)
+	}
+
+	_, err = c.LabelUnassign(ctx, session, "space/repo", issue.Number, 3)
+	if !gitness_errors.IsNotFound(err) {
+		t.Errorf("unassigned label: got=%v want not found", err)
+	}
+
+	want := []struct {
+		action enum.LabelAction
+		label  int64
+		old    int64
+	}{
+		{action: enum.LabelActionAssigned, label: 3},
+		{action: enum.LabelActionAssigned, label: 1},
+		{action: enum.LabelActionReassigned, label: 2, old: 1},
+		{action: enum.LabelActionUnassigned, label: 3},
+	}
+	if len(f.activityStore.payloads) != len(want) {
+		t.Fatalf("activities: got=%d want=%d", len(f.activityStore.payloads), len(want))
+	}
+	for i, payload := range f.activityStore.payloads {
+		p := payload.(*types.IssueActivityPayloadLabel)
+		var old int64
+		if p.OldLabel != nil {
+			old = p.OldLabel.ID
+		}
+		if p.Action != want[i].action || p.Label.ID != want[i].label || old != want[i].old {
+			t.Errorf("activity %d: got=%s %d (old %d) want=%s %d (old %d)",
+				i, p.Action, p.Label.ID, old, want[i].action, want[i].label, want[i].old)
+		}
+	}
+}
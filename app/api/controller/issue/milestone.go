@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxMilestoneTitleLength = 256
+
+type MilestoneCreateInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DueDate     *int64 `json:"due_date"`
+}
+
+type MilestoneUpdateInput struct {
+	Title       *string              `json:"title"`
+	Description *string              `json:"description"`
+	State       *enum.MilestoneState `json:"state"`
+	DueDate     *int64               `json:"due_date"`
+
+	// ClearDueDate removes the due date of the milestone.
+	ClearDueDate bool `json:"clear_due_date"`
+}
+
+// MilestoneCreate creates a new milestone in the repository.
+func (c *Controller) MilestoneCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MilestoneCreateInput,
+) (*types.Milestone, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	milestone := &types.Milestone{
+		RepoID:      repo.ID,
+		Title:       in.Title,
+		Description: in.Description,
+		State:       enum.MilestoneStateOpen,
+		DueDate:     in.DueDate,
+		Created:     now,
+		Updated:     now,
+		CreatedBy:   session.Principal.ID,
+	}
+
+	if err = sanitizeMilestone(milestone); err != nil {
+		return nil, err
+	}
+
+	err = c.milestoneStore.Create(ctx, milestone)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Milestone %q already exists in the repository.", milestone.Title)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create milestone: %w", err)
+	}
+
+	return milestone, nil
+}
+
+// MilestoneList returns the milestones of the repository.
+func (c *Controller) MilestoneList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.MilestoneFilter,
+) ([]*types.Milestone, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var list []*types.Milestone
+	var count int64
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		list, err = c.milestoneStore.List(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list milestones: %w", err)
+		}
+
+		count, err = c.milestoneStore.Count(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count milestones: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, count, nil
+}
+
+// MilestoneUpdate updates the milestone. Nil values are left unchanged.
+func (c *Controller) MilestoneUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+	in *MilestoneUpdateInput,
+) (*types.Milestone, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	milestone, err := c.findMilestone(ctx, repo, milestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Title != nil {
+		milestone.Title = *in.Title
+	}
+	if in.Description != nil {
+		milestone.Description = *in.Description
+	}
+	if in.State != nil {
+		milestone.State = *in.State
+	}
+	if in.DueDate != nil {
+		milestone.DueDate = in.DueDate
+	}
+	if in.ClearDueDate {
+		milestone.DueDate = nil
+	}
+
+	if err = sanitizeMilestone(milestone); err != nil {
+		return nil, err
+	}
+
+	milestone.Updated = time.Now().UnixMilli()
+
+	err = c.milestoneStore.Update(ctx, milestone)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Milestone %q already exists in the repository.", milestone.Title)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	return milestone, nil
+}
+
+// MilestoneDelete deletes the milestone. The issues of the milestone are left without a milestone.
+func (c *Controller) MilestoneDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	milestoneID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	milestone, err := c.findMilestone(ctx, repo, milestoneID)
+	if err != nil {
+		return err
+	}
+
+	if err = c.milestoneStore.Delete(ctx, milestone.ID); err != nil {
+		return fmt.Errorf("failed to delete milestone: %w", err)
+	}
+
+	return nil
+}
+
+func sanitizeMilestone(milestone *types.Milestone) error {
+	milestone.Title = strings.TrimSpace(milestone.Title)
+	milestone.Description = strings.TrimSpace(milestone.Description)
+
+	if milestone.Title == "" {
+		return usererror.BadRequest("Milestone title can't be empty.")
+	}
+	if len(milestone.Title) > maxMilestoneTitleLength {
+		return usererror.BadRequestf("Milestone title can be at most %d characters long.", maxMilestoneTitleLength)
+	}
+	if err := check.ForControlCharacters(milestone.Title); err != nil {
+		return err
+	}
+
+	if err := check.Description(milestone.Description); err != nil {
+		return err
+	}
+
+	state, ok := milestone.State.Sanitize()
+	if !ok || state == "" {
+		return usererror.BadRequest("Milestone state must be either open or closed.")
+	}
+	milestone.State = state
+
+	if milestone.DueDate != nil && *milestone.DueDate <= 0 {
+		return usererror.BadRequest("Milestone due date must be a positive timestamp.")
+	}
+
+	return nil
+}
@@ -25,18 +25,21 @@ type Controller struct {
 	authorizer authz.Authorizer
 	repoCtrl   *repo.Controller
 	searcher   keywordsearch.Searcher
+	indexer    keywordsearch.Indexer
 	spaceCtrl  *space.Controller
 }
 
 func NewController(
 	authorizer authz.Authorizer,
 	searcher keywordsearch.Searcher,
+	indexer keywordsearch.Indexer,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
 ) *Controller {
 	return &Controller{
 		authorizer: authorizer,
 		searcher:   searcher,
+		indexer:    indexer,
 		repoCtrl:   repoCtrl,
 		spaceCtrl:  spaceCtrl,
 	}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ReindexInput struct {
+	// RepoPath is the path of the repository to reindex.
+	RepoPath string `json:"repo_path"`
+
+	// SpacePath is the path of the space whose repositories are reindexed.
+	SpacePath string `json:"space_path"`
+}
+
+type ReindexOutput struct {
+	// RepoCount is the number of repositories that got reindexed.
+	RepoCount int `json:"repo_count"`
+}
+
+// Reindex rebuilds the keyword search index of a repository or of all repositories of a space.
+func (c *Controller) Reindex(
+	ctx context.Context,
+	session *auth.Session,
+	in *ReindexInput,
+) (*ReindexOutput, error) {
+	if (in.RepoPath == "") == (in.SpacePath == "") {
+		return nil, usererror.BadRequest("Either a repo path or a space path has to be provided.")
+	}
+
+	var repos []*types.Repository
+	if in.RepoPath != "" {
+		repo, err := c.repoCtrl.Find(ctx, session, in.RepoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository: %w", err)
+		}
+		repos = []*types.Repository{repo}
+	} else {
+		filter := &types.RepoFilter{
+			Page:  1,
+			Size:  int(math.MaxInt),
+			Query: "",
+			Order: enum.OrderAsc,
+			Sort:  enum.RepoAttrNone,
+		}
+
+		var err error
+		repos, _, err = c.spaceCtrl.ListRepositories(ctx, session, in.SpacePath, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list space repositories: %w", err)
+		}
+	}
+
+	count := 0
+	for _, repo := range repos {
+		if repo.Importing {
+			continue
+		}
+
+		if err := c.indexer.Index(ctx, repo); err != nil {
+			return nil, fmt.Errorf("failed to reindex repository %q: %w", repo.Path, err)
+		}
+
+		count++
+	}
+
+	return &ReindexOutput{RepoCount: count}, nil
+}
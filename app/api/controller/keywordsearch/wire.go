@@ -31,8 +31,9 @@ var WireSet = wire.NewSet(
 func ProvideController(
 	authorizer authz.Authorizer,
 	searcher keywordsearch.Searcher,
+	indexer keywordsearch.Indexer,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
 ) *Controller {
-	return NewController(authorizer, searcher, repoCtrl, spaceCtrl)
+	return NewController(authorizer, searcher, indexer, repoCtrl, spaceCtrl)
 }
@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	OperationDownload = "download"
+	OperationUpload   = "upload"
+
+	TransferBasic  = "basic"
+	HashAlgoSHA256 = "sha256"
+
+	// maxBatchSize is the maximum number of objects in a single batch request.
+	maxBatchSize = 1000
+)
+
+// Reference is the git reference of an LFS request.
+type Reference struct {
+	Name string `json:"name"`
+}
+
+// Pointer identifies an LFS object.
+type Pointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// BatchRequest is the request of the LFS batch API.
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md
+type BatchRequest struct {
+	Operation string     `json:"operation"`
+	Transfers []string   `json:"transfers,omitempty"`
+	Ref       *Reference `json:"ref,omitempty"`
+	Objects   []Pointer  `json:"objects"`
+	HashAlgo  string     `json:"hash_algo,omitempty"`
+}
+
+// BatchResponse is the response of the LFS batch API.
+type BatchResponse struct {
+	Transfer string           `json:"transfer,omitempty"`
+	Objects  []ObjectResponse `json:"objects"`
+	HashAlgo string           `json:"hash_algo,omitempty"`
+}
+
+// ObjectResponse contains the actions the client has to execute to transfer an LFS object.
+type ObjectResponse struct {
+	Pointer
+	Actions map[string]Action `json:"actions,omitempty"`
+	Error   *ObjectError      `json:"error,omitempty"`
+}
+
+// Action describes how the client can transfer an LFS object.
+type Action struct {
+	Href      string            `json:"href"`
+	Header    map[string]string `json:"header,omitempty"`
+	ExpiresIn int               `json:"expires_in,omitempty"`
+}
+
+// ObjectError describes why an LFS object can't be transferred.
+type ObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Batch returns the actions required to download or upload the requested LFS objects.
+func (c *Controller) Batch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *BatchRequest,
+) (*BatchResponse, error) {
+	var permission enum.Permission
+	switch in.Operation {
+	case OperationDownload:
+		permission = enum.PermissionRepoView
+	case OperationUpload:
+		permission = enum.PermissionRepoPush
+	default:
+		return nil, usererror.BadRequestf("Unsupported operation %q.", in.Operation)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, permission, in.Operation == OperationDownload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if len(in.Transfers) > 0 && !slices.Contains(in.Transfers, TransferBasic) {
+		return nil, usererror.UnprocessableEntityf("Only the %q transfer adapter is supported.", TransferBasic)
+	}
+
+	if in.HashAlgo != "" && in.HashAlgo != HashAlgoSHA256 {
+		return nil, usererror.UnprocessableEntityf("Only the %q hash algorithm is supported.", HashAlgoSHA256)
+	}
+
+	if len(in.Objects) > maxBatchSize {
+		return nil, usererror.RequestTooLargef("A batch request can contain at most %d objects.", maxBatchSize)
+	}
+
+	oids := make([]string, 0, len(in.Objects))
+	for _, obj := range in.Objects {
+		if validateOID(obj.OID) == nil {
+			oids = append(oids, obj.OID)
+		}
+	}
+
+	existing, err := c.findObjects(ctx, repo, oids)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]ObjectResponse, len(in.Objects))
+	for i, obj := range in.Objects {
+		objects[i] = ObjectResponse{Pointer: obj}
+
+		if err := validateOID(obj.OID); err != nil || obj.Size < 0 {
+			objects[i].Error = &ObjectError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: "Invalid object ID or size.",
+			}
+			continue
+		}
+
+		_, exists := existing[obj.OID]
+
+		switch {
+		case in.Operation == OperationDownload && !exists:
+			objects[i].Error = &ObjectError{
+				Code:    http.StatusNotFound,
+				Message: "Object does not exist.",
+			}
+		case in.Operation == OperationDownload:
+			objects[i].Actions = map[string]Action{
+				OperationDownload: {Href: c.objectURL(repo, obj.OID)},
+			}
+		case !exists && obj.Size > c.maxObjectSize:
+			objects[i].Error = &ObjectError{
+				Code:    http.StatusRequestEntityTooLarge,
+				Message: fmt.Sprintf("Object exceeds the maximum allowed size of %d bytes.", c.maxObjectSize),
+			}
+		case !exists:
+			objects[i].Actions = map[string]Action{
+				OperationUpload: {Href: c.uploadURL(repo, obj.OID, obj.Size)},
+			}
+		}
+	}
+
+	return &BatchResponse{
+		Transfer: TransferBasic,
+		Objects:  objects,
+		HashAlgo: HashAlgoSHA256,
+	}, nil
+}
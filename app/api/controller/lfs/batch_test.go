@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestController_Batch(t *testing.T) {
+	upstreamOID := testOID("upstream")
+	forkOID := testOID("fork")
+	missingOID := testOID("missing")
+
+	tests := []struct {
+		name        string
+		repoRef     string
+		denied      []enum.Permission
+		in          BatchRequest
+		wantStatus  int
+		wantErr     error
+		wantActions []string
+		wantErrors  []int
+	}{
+		{
+			name:       "unsupported operation",
+			repoRef:    "space/fork",
+			in:         BatchRequest{Operation: "delete"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unsupported transfer",
+			repoRef:    "space/fork",
+			in:         BatchRequest{Operation: OperationDownload, Transfers: []string{"ssh"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "unsupported hash algorithm",
+			repoRef:    "space/fork",
+			in:         BatchRequest{Operation: OperationDownload, HashAlgo: "sha512"},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "upload without push permission",
+			repoRef: "space/fork",
+			denied:  []enum.Permission{enum.PermissionRepoPush},
+			in:      BatchRequest{Operation: OperationUpload, Objects: []Pointer{{OID: missingOID, Size: 1}}},
+			wantErr: apiauth.ErrNotAuthorized,
+		},
+		{
+			name:    "download objects of the fork and its upstream",
+			repoRef: "space/fork",
+			in: BatchRequest{
+				Operation: OperationDownload,
+				Transfers: []string{TransferBasic},
+				Objects: []Pointer{
+					{OID: forkOID, Size: 4},
+					{OID: upstreamOID, Size: 8},
+					{OID: missingOID, Size: 7},
+					{OID: "invalid", Size: 1},
+				},
+			},
+			wantActions: []string{OperationDownload, OperationDownload, "", ""},
+			wantErrors:  []int{0, 0, http.StatusNotFound, http.StatusUnprocessableEntity},
+		},
+		{
+			name:    "objects of the fork aren't visible in the upstream",
+			repoRef: "space/upstream",
+			in: BatchRequest{
+				Operation: OperationDownload,
+				Objects:   []Pointer{{OID: forkOID, Size: 4}, {OID: upstreamOID, Size: 8}},
+			},
+			wantActions: []string{"", OperationDownload},
+			wantErrors:  []int{http.StatusNotFound, 0},
+		},
+		{
+			name:    "upload only missing objects",
+			repoRef: "space/fork",
+			in: BatchRequest{
+				Operation: OperationUpload,
+				Objects: []Pointer{
+					{OID: upstreamOID, Size: 8},
+					{OID: missingOID, Size: 7},
+					{OID: missingOID, Size: -1},
+					{OID: missingOID, Size: testMaxObjectSize + 1},
+				},
+			},
+			wantActions: []string{"", OperationUpload, "", ""},
+			wantErrors:  []int{0, 0, http.StatusUnprocessableEntity, http.StatusRequestEntityTooLarge},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, objectStore, _ := setupController(test.denied...)
+			objectStore.objects[testUpstreamRepoID] = map[string]*types.LFSObject{
+				upstreamOID: {RepoID: testUpstreamRepoID, OID: upstreamOID, Size: 8},
+			}
+			objectStore.objects[testForkRepoID] = map[string]*types.LFSObject{
+				forkOID: {RepoID: testForkRepoID, OID: forkOID, Size: 4},
+			}
+
+			out, err := c.Batch(context.Background(), testSession(), test.repoRef, &test.in)
+
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Errorf("got=%v want=%v", err, test.wantErr)
+				}
+				return
+			}
+
+			if test.wantStatus != 0 {
+				var uErr *usererror.Error
+				if !errors.As(err, &uErr) || uErr.Status != test.wantStatus {
+					t.Errorf("got=%v want status=%d", err, test.wantStatus)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(out.Objects) != len(test.wantActions) {
+				t.Fatalf("object count: got=%d want=%d", len(out.Objects), len(test.wantActions))
+			}
+
+			for i, obj := range out.Objects {
+				var gotAction string
+				for op, action := range obj.Actions {
+					gotAction = op
+					wantHref := "https://git.example.com/git/" + test.repoRef + ".git/info/lfs/objects/" + obj.OID
+					if op == OperationUpload {
+						wantHref += "?size=" + strconv.FormatInt(obj.Size, 10)
+					}
+					if action.Href != wantHref {
+						t.Errorf("object %d href: got=%s want=%s", i, action.Href, wantHref)
+					}
+				}
+				if gotAction != test.wantActions[i] {
+					t.Errorf("object %d action: got=%q want=%q", i, gotAction, test.wantActions[i])
+				}
+
+				var gotCode int
+				if obj.Error != nil {
+					gotCode = obj.Error.Code
+				}
+				if gotCode != test.wantErrors[i] {
+					t.Errorf("object %d error code: got=%d want=%d", i, gotCode, test.wantErrors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestController_Batch_TooManyObjects(t *testing.T) {
+	c, _, _ := setupController()
+
+	in := &BatchRequest{
+		Operation: OperationDownload,
+		Objects:   make([]Pointer, maxBatchSize+1),
+	}
+
+	_, err := c.Batch(context.Background(), testSession(), "space/fork", in)
+
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusRequestEntityTooLarge {
+		t.Errorf("got=%v want status=%d", err, http.StatusRequestEntityTooLarge)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// lfsObjectBucketPathFmt is the path of an LFS object in the blob store.
+	// Objects are content addressed, so they are shared between all repositories.
+	lfsObjectBucketPathFmt = "lfs/%s/%s/%s"
+
+	// maxForkDepth limits the number of upstream repositories searched for LFS objects of a fork.
+	maxForkDepth = 16
+)
+
+var oidRegex = regexp.MustCompile("^[0-9a-f]{64}$")
+
+type Controller struct {
+	authorizer         authz.Authorizer
+	repoStore          store.RepoStore
+	principalInfoCache store.PrincipalInfoCache
+	lfsObjectStore     store.LFSObjectStore
+	lfsLockStore       store.LFSLockStore
+	blobStore          blob.Store
+	urlProvider        url.Provider
+	tmpDir             string
+	maxObjectSize      int64
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	principalInfoCache store.PrincipalInfoCache,
+	lfsObjectStore store.LFSObjectStore,
+	lfsLockStore store.LFSLockStore,
+	blobStore blob.Store,
+	urlProvider url.Provider,
+	tmpDir string,
+	maxObjectSize int64,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		repoStore:          repoStore,
+		principalInfoCache: principalInfoCache,
+		lfsObjectStore:     lfsObjectStore,
+		lfsLockStore:       lfsLockStore,
+		blobStore:          blobStore,
+		urlProvider:        urlProvider,
+		tmpDir:             tmpDir,
+		maxObjectSize:      maxObjectSize,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+	orPublic bool,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, orPublic); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return repo, nil
+}
+
+// findObjects returns the LFS objects of the repository with the provided OIDs.
+// Objects that aren't found in a fork are looked up in its upstream repositories.
+func (c *Controller) findObjects(
+	ctx context.Context,
+	repo *types.Repository,
+	oids []string,
+) (map[string]*types.LFSObject, error) {
+	found := make(map[string]*types.LFSObject, len(oids))
+
+	for i := 0; i < maxForkDepth && len(oids) > 0; i++ {
+		objects, err := c.lfsObjectStore.FindMany(ctx, repo.ID, oids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find LFS objects: %w", err)
+		}
+
+		for _, obj := range objects {
+			found[obj.OID] = obj
+		}
+
+		if repo.ForkID == 0 {
+			break
+		}
+
+		missing := make([]string, 0, len(oids)-len(objects))
+		for _, oid := range oids {
+			if _, ok := found[oid]; !ok {
+				missing = append(missing, oid)
+			}
+		}
+		oids = missing
+
+		repo, err = c.repoStore.Find(ctx, repo.ForkID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find upstream repository: %w", err)
+		}
+	}
+
+	return found, nil
+}
+
+func (c *Controller) objectURL(repo *types.Repository, oid string) string {
+	return c.urlProvider.GenerateGITCloneURL(repo.Path) + "/info/lfs/objects/" + oid
+}
+
+// uploadURL returns the upload URL of an LFS object. It includes the size declared
+// in the batch request, which the uploaded content is verified against.
+func (c *Controller) uploadURL(repo *types.Repository, oid string, size int64) string {
+	return c.objectURL(repo, oid) + "?" + request.QueryParamLFSObjectSize + "=" + strconv.FormatInt(size, 10)
+}
+
+func getLFSObjectBucketPath(oid string) string {
+	return fmt.Sprintf(lfsObjectBucketPathFmt, oid[0:2], oid[2:4], oid)
+}
+
+func validateOID(oid string) error {
+	if !oidRegex.MatchString(oid) {
+		return usererror.BadRequestf("Invalid object ID %q.", oid)
+	}
+
+	return nil
+}
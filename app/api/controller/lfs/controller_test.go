@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeAuthorizer struct {
+	authz.Authorizer
+	denied map[enum.Permission]bool
+}
+
+func (a *fakeAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	return !a.denied[permission], nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	repo, ok := s.repos[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+func (s *fakeRepoStore) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	for _, repo := range s.repos {
+		if repo.Path == repoRef {
+			return repo, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type fakeLFSObjectStore struct {
+	objects map[int64]map[string]*types.LFSObject
+}
+
+func (s *fakeLFSObjectStore) Find(_ context.Context, repoID int64, oid string) (*types.LFSObject, error) {
+	obj, ok := s.objects[repoID][oid]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return obj, nil
+}
+
+func (s *fakeLFSObjectStore) FindMany(_ context.Context, repoID int64, oids []string) ([]*types.LFSObject, error) {
+	var objects []*types.LFSObject
+	for _, oid := range oids {
+		if obj, ok := s.objects[repoID][oid]; ok {
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+func (s *fakeLFSObjectStore) Create(_ context.Context, obj *types.LFSObject) error {
+	if s.objects[obj.RepoID] == nil {
+		s.objects[obj.RepoID] = map[string]*types.LFSObject{}
+	}
+	if _, ok := s.objects[obj.RepoID][obj.OID]; ok {
+		return gitness_store.ErrDuplicate
+	}
+	s.objects[obj.RepoID][obj.OID] = obj
+	return nil
+}
+
+type fakeLFSLockStore struct {
+	store.LFSLockStore
+	locks []*types.LFSLock
+}
+
+func (s *fakeLFSLockStore) List(
+	_ context.Context,
+	repoID int64,
+	filter *types.LFSLockFilter,
+) ([]*types.LFSLock, error) {
+	var locks []*types.LFSLock
+	for _, lock := range s.locks {
+		if lock.RepoID != repoID || lock.ID <= filter.AfterID {
+			continue
+		}
+		if len(locks) == filter.Limit {
+			break
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
+type fakePrincipalInfoCache struct {
+	store.PrincipalInfoCache
+}
+
+func (c *fakePrincipalInfoCache) Map(_ context.Context, ids []int64) (map[int64]*types.PrincipalInfo, error) {
+	m := make(map[int64]*types.PrincipalInfo, len(ids))
+	for _, id := range ids {
+		m[id] = &types.PrincipalInfo{ID: id, DisplayName: "user"}
+	}
+	return m, nil
+}
+
+type fakeBlobStore struct {
+	blob.Store
+	files map[string][]byte
+}
+
+func (s *fakeBlobStore) Upload(_ context.Context, file io.Reader, filePath string) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	s.files[filePath] = data
+	return nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GenerateGITCloneURL(repoPath string) string {
+	return "https://git.example.com/git/" + repoPath + ".git"
+}
+
+const (
+	testUpstreamRepoID = 1
+	testForkRepoID     = 2
+	testPrincipalID    = 42
+
+	testMaxObjectSize = 64
+)
+
+// setupController returns a controller with an upstream repository and its fork.
+func setupController(denied ...enum.Permission) (*Controller, *fakeLFSObjectStore, *fakeBlobStore) {
+	authorizer := &fakeAuthorizer{denied: map[enum.Permission]bool{}}
+	for _, permission := range denied {
+		authorizer.denied[permission] = true
+	}
+
+	repoStore := &fakeRepoStore{repos: map[int64]*types.Repository{
+		testUpstreamRepoID: {ID: testUpstreamRepoID, Path: "space/upstream"},
+		testForkRepoID:     {ID: testForkRepoID, Path: "space/fork", ForkID: testUpstreamRepoID},
+	}}
+	objectStore := &fakeLFSObjectStore{objects: map[int64]map[string]*types.LFSObject{}}
+	blobStore := &fakeBlobStore{files: map[string][]byte{}}
+
+	c := NewController(authorizer, repoStore, &fakePrincipalInfoCache{}, objectStore,
+		&fakeLFSLockStore{}, blobStore, fakeURLProvider{}, "", testMaxObjectSize)
+
+	return c, objectStore, blobStore
+}
+
+func testSession() *auth.Session {
+	return &auth.Session{Principal: types.Principal{ID: testPrincipalID}}
+}
+
+func testOID(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestGetLFSObjectBucketPath(t *testing.T) {
+	oid := testOID("content")
+	want := "lfs/" + oid[0:2] + "/" + oid[2:4] + "/" + oid
+	if got := getLFSObjectBucketPath(oid); got != want {
+		t.Errorf("got=%s want=%s", got, want)
+	}
+}
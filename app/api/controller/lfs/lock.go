@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	lockListDefaultLimit = 100
+	lockListMaxLimit     = 1000
+)
+
+// Lock is an LFS file lock as returned by the LFS locking API.
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/api/locking.md
+type Lock struct {
+	ID       string     `json:"id"`
+	Path     string     `json:"path"`
+	LockedAt time.Time  `json:"locked_at"`
+	Owner    *LockOwner `json:"owner,omitempty"`
+}
+
+// LockOwner is the owner of an LFS file lock.
+type LockOwner struct {
+	Name string `json:"name"`
+}
+
+// LockExistsError is returned when a lock is requested for a path that is locked already.
+type LockExistsError struct {
+	Lock Lock
+}
+
+func (e *LockExistsError) Error() string {
+	return fmt.Sprintf("path %q is already locked", e.Lock.Path)
+}
+
+func (c *Controller) mapToLocks(ctx context.Context, locks []*types.LFSLock) ([]Lock, error) {
+	principalIDs := make([]int64, len(locks))
+	for i, lock := range locks {
+		principalIDs[i] = lock.CreatedBy
+	}
+
+	principals, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load lock owners: %w", err)
+	}
+
+	result := make([]Lock, len(locks))
+	for i, lock := range locks {
+		result[i] = mapToLock(lock, principals[lock.CreatedBy])
+	}
+
+	return result, nil
+}
+
+func mapToLock(lock *types.LFSLock, owner *types.PrincipalInfo) Lock {
+	l := Lock{
+		ID:       strconv.FormatInt(lock.ID, 10),
+		Path:     lock.Path,
+		LockedAt: time.UnixMilli(lock.Created).UTC(),
+	}
+
+	if owner != nil {
+		l.Owner = &LockOwner{Name: owner.DisplayName}
+	}
+
+	return l
+}
+
+func sanitizeLockPath(path string) (string, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "/")
+	if path == "" {
+		return "", usererror.BadRequest("A valid path must be provided.")
+	}
+
+	return path, nil
+}
+
+func parseLockID(id string) (int64, error) {
+	lockID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || lockID <= 0 {
+		return 0, usererror.BadRequestf("Invalid lock ID %q.", id)
+	}
+
+	return lockID, nil
+}
+
+// parseLockCursor parses the cursor of a lock list request.
+// The cursor is the ID of the last returned lock.
+func parseLockCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	return parseLockID(cursor)
+}
+
+func sanitizeLockLimit(limit int) int {
+	if limit <= 0 {
+		return lockListDefaultLimit
+	}
+
+	if limit > lockListMaxLimit {
+		return lockListMaxLimit
+	}
+
+	return limit
+}
+
+// nextLockCursor returns the cursor for the next page, or an empty string if there are no more locks.
+func nextLockCursor(locks []*types.LFSLock, limit int) string {
+	if len(locks) < limit {
+		return ""
+	}
+
+	return strconv.FormatInt(locks[len(locks)-1].ID, 10)
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LockCreateInput struct {
+	Path string     `json:"path"`
+	Ref  *Reference `json:"ref,omitempty"`
+}
+
+type LockResponse struct {
+	Lock Lock `json:"lock"`
+}
+
+// LockCreate locks a file of the repository.
+// Locked files can be changed only by the owner of the lock.
+func (c *Controller) LockCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *LockCreateInput,
+) (*LockResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	path, err := sanitizeLockPath(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &types.LFSLock{
+		RepoID:    repo.ID,
+		Path:      path,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+	}
+	if in.Ref != nil {
+		lock.Ref = in.Ref.Name
+	}
+
+	err = c.lfsLockStore.Create(ctx, lock)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, c.lockExistsError(ctx, repo.ID, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LFS lock: %w", err)
+	}
+
+	return &LockResponse{
+		Lock: mapToLock(lock, session.Principal.ToPrincipalInfo()),
+	}, nil
+}
+
+// lockExistsError returns the error with the existing lock of the path.
+func (c *Controller) lockExistsError(ctx context.Context, repoID int64, path string) error {
+	existing, err := c.lfsLockStore.ListByPaths(ctx, repoID, []string{path})
+	if err != nil {
+		return fmt.Errorf("failed to find existing LFS lock: %w", err)
+	}
+	if len(existing) == 0 {
+		return fmt.Errorf("existing LFS lock for path %q not found", path)
+	}
+
+	locks, err := c.mapToLocks(ctx, existing[:1])
+	if err != nil {
+		return err
+	}
+
+	return &LockExistsError{Lock: locks[0]}
+}
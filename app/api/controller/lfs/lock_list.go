@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LockListInput struct {
+	ID     string
+	Path   string
+	Cursor string
+	Limit  int
+}
+
+type LockListResponse struct {
+	Locks      []Lock `json:"locks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// LockList lists the LFS file locks of the repository.
+func (c *Controller) LockList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *LockListInput,
+) (*LockListResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var locks []*types.LFSLock
+	var nextCursor string
+
+	if in.ID != "" {
+		locks, err = c.findLock(ctx, repo.ID, in.ID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var afterID int64
+		afterID, err = parseLockCursor(in.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := sanitizeLockLimit(in.Limit)
+
+		locks, err = c.lfsLockStore.List(ctx, repo.ID, &types.LFSLockFilter{
+			Path:    in.Path,
+			AfterID: afterID,
+			Limit:   limit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list LFS locks: %w", err)
+		}
+
+		nextCursor = nextLockCursor(locks, limit)
+	}
+
+	result, err := c.mapToLocks(ctx, locks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockListResponse{
+		Locks:      result,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// findLock returns the lock with the provided ID as a list, or an empty list if the lock doesn't exist.
+func (c *Controller) findLock(ctx context.Context, repoID int64, id string) ([]*types.LFSLock, error) {
+	lockID, err := parseLockID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := c.lfsLockStore.Find(ctx, repoID, lockID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return []*types.LFSLock{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find LFS lock: %w", err)
+	}
+
+	return []*types.LFSLock{lock}, nil
+}
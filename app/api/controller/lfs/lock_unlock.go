@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LockUnlockInput struct {
+	Force bool       `json:"force,omitempty"`
+	Ref   *Reference `json:"ref,omitempty"`
+}
+
+// LockUnlock removes an LFS file lock.
+// Locks of other users can be removed only with the force flag and the permission to edit the repository.
+func (c *Controller) LockUnlock(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	id string,
+	in *LockUnlockInput,
+) (*LockResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	lockID, err := parseLockID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := c.lfsLockStore.Find(ctx, repo.ID, lockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find LFS lock: %w", err)
+	}
+
+	if lock.CreatedBy != session.Principal.ID {
+		if !in.Force {
+			return nil, usererror.Forbidden("The lock is owned by another user.")
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+			return nil, fmt.Errorf("failed to verify authorization to force unlock: %w", err)
+		}
+	}
+
+	locks, err := c.mapToLocks(ctx, []*types.LFSLock{lock})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.lfsLockStore.Delete(ctx, repo.ID, lock.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete LFS lock: %w", err)
+	}
+
+	return &LockResponse{
+		Lock: locks[0],
+	}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LockVerifyInput struct {
+	Cursor string     `json:"cursor,omitempty"`
+	Limit  int        `json:"limit,omitempty"`
+	Ref    *Reference `json:"ref,omitempty"`
+}
+
+type LockVerifyResponse struct {
+	Ours       []Lock `json:"ours"`
+	Theirs     []Lock `json:"theirs"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// LockVerify lists the LFS file locks of the repository grouped by their owner.
+// It's used by git LFS clients before a push to find locked files that can't be pushed.
+func (c *Controller) LockVerify(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *LockVerifyInput,
+) (*LockVerifyResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	afterID, err := parseLockCursor(in.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := sanitizeLockLimit(in.Limit)
+
+	locks, err := c.lfsLockStore.List(ctx, repo.ID, &types.LFSLockFilter{
+		AfterID: afterID,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LFS locks: %w", err)
+	}
+
+	result, err := c.mapToLocks(ctx, locks)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &LockVerifyResponse{
+		Ours:       []Lock{},
+		Theirs:     []Lock{},
+		NextCursor: nextLockCursor(locks, limit),
+	}
+
+	for i, lock := range locks {
+		if lock.CreatedBy == session.Principal.ID {
+			out.Ours = append(out.Ours, result[i])
+		} else {
+			out.Theirs = append(out.Theirs, result[i])
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestController_LockVerify(t *testing.T) {
+	c, _, _ := setupController()
+	c.lfsLockStore = &fakeLFSLockStore{locks: []*types.LFSLock{
+		{ID: 1, RepoID: testForkRepoID, Path: "a.bin", CreatedBy: testPrincipalID},
+		{ID: 2, RepoID: testForkRepoID, Path: "b.bin", CreatedBy: 7},
+		{ID: 3, RepoID: testUpstreamRepoID, Path: "c.bin", CreatedBy: 7},
+		{ID: 4, RepoID: testForkRepoID, Path: "d.bin", CreatedBy: 7},
+	}}
+
+	// first page
+	out, err := c.LockVerify(context.Background(), testSession(), "space/fork", &LockVerifyInput{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(out.Ours) != 1 || out.Ours[0].ID != "1" {
+		t.Errorf("ours: got=%+v want=[1]", out.Ours)
+	}
+	if len(out.Theirs) != 1 || out.Theirs[0].ID != "2" {
+		t.Errorf("theirs: got=%+v want=[2]", out.Theirs)
+	}
+	if out.NextCursor != "2" {
+		t.Errorf("cursor: got=%q want=%q", out.NextCursor, "2")
+	}
+
+	// second page
+	out, err = c.LockVerify(context.Background(), testSession(), "space/fork",
+		&LockVerifyInput{Limit: 2, Cursor: out.NextCursor})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(out.Ours) != 0 {
+		t.Errorf("ours: got=%+v want=[]", out.Ours)
+	}
+	if len(out.Theirs) != 1 || out.Theirs[0].ID != "4" || out.Theirs[0].Owner == nil {
+		t.Errorf("theirs: got=%+v want=[4]", out.Theirs)
+	}
+	if out.NextCursor != "" {
+		t.Errorf("cursor: got=%q want=%q", out.NextCursor, "")
+	}
+}
+
+func TestController_LockVerify_RequiresPush(t *testing.T) {
+	c, _, _ := setupController(enum.PermissionRepoPush)
+
+	_, err := c.LockVerify(context.Background(), testSession(), "space/fork", &LockVerifyInput{})
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Errorf("got=%v want=%v", err, apiauth.ErrNotAuthorized)
+	}
+}
+
+func TestController_LockVerify_InvalidCursor(t *testing.T) {
+	c, _, _ := setupController()
+
+	_, err := c.LockVerify(context.Background(), testSession(), "space/fork", &LockVerifyInput{Cursor: "abc"})
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Upload stores the content of an LFS object.
+// The content is verified against the object ID and the size declared in the batch request
+// before it's stored in the blob store.
+func (c *Controller) Upload(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+	size int64,
+	file io.Reader,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = validateOID(oid); err != nil {
+		return err
+	}
+
+	if size < 0 {
+		return usererror.BadRequestf("Invalid object size %d.", size)
+	}
+	if size > c.maxObjectSize {
+		return usererror.RequestTooLargef("Object exceeds the maximum allowed size of %d bytes.", c.maxObjectSize)
+	}
+
+	_, err = c.lfsObjectStore.Find(ctx, repo.ID, oid)
+	if err == nil {
+		// the object has been uploaded already
+		return nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find LFS object: %w", err)
+	}
+
+	// The content is buffered in a temporary file, because objects in the blob store
+	// are shared between repositories and mustn't be overwritten with unverified content.
+	tmpFile, err := os.CreateTemp(c.tmpDir, "lfs-upload-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = tmpFile.Close()
+		if rErr := os.Remove(tmpFile.Name()); rErr != nil {
+			log.Ctx(ctx).Warn().Err(rErr).Msg("failed to remove temporary LFS upload file")
+		}
+	}()
+
+	// Read at most one byte more than declared to detect content that's larger than the declared size.
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmpFile, hash), io.LimitReader(file, size+1))
+	if err != nil {
+		return fmt.Errorf("failed to read LFS object: %w", err)
+	}
+
+	if n != size {
+		return usererror.UnprocessableEntityf("The size of the object doesn't match the declared size of %d bytes.", size)
+	}
+
+	if hex.EncodeToString(hash.Sum(nil)) != oid {
+		return usererror.UnprocessableEntityf("The content of the object doesn't match the object ID %q.", oid)
+	}
+
+	if _, err = tmpFile.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind temporary file: %w", err)
+	}
+
+	err = c.blobStore.Upload(ctx, tmpFile, getLFSObjectBucketPath(oid))
+	if err != nil {
+		return fmt.Errorf("failed to upload LFS object: %w", err)
+	}
+
+	err = c.lfsObjectStore.Create(ctx, &types.LFSObject{
+		RepoID:    repo.ID,
+		OID:       oid,
+		Size:      size,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+	})
+	if err != nil && !errors.Is(err, gitness_store.ErrDuplicate) {
+		return fmt.Errorf("failed to create LFS object: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+func TestController_Upload(t *testing.T) {
+	const content = "lfs object content"
+	oid := testOID(content)
+
+	tests := []struct {
+		name       string
+		oid        string
+		size       int64
+		content    string
+		existing   bool
+		wantStatus int
+		wantStored bool
+	}{
+		{
+			name:       "content matches the object ID",
+			oid:        oid,
+			size:       int64(len(content)),
+			content:    content,
+			wantStored: true,
+		},
+		{
+			name:       "content doesn't match the object ID",
+			oid:        oid,
+			size:       int64(len("tampered content")),
+			content:    "tampered content",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid object ID",
+			oid:        "not-an-oid",
+			size:       int64(len(content)),
+			content:    content,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "content is larger than the declared size",
+			oid:        oid,
+			size:       int64(len(content)) - 1,
+			content:    content,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "content is smaller than the declared size",
+			oid:        oid,
+			size:       int64(len(content)) + 1,
+			content:    content,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "declared size exceeds the maximum object size",
+			oid:        oid,
+			size:       testMaxObjectSize + 1,
+			content:    content,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "negative declared size",
+			oid:        oid,
+			size:       -1,
+			content:    content,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "object exists already",
+			oid:      oid,
+			size:     int64(len("ignored")),
+			content:  "ignored",
+			existing: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, objectStore, blobStore := setupController()
+			if test.existing {
+				objectStore.objects[testForkRepoID] = map[string]*types.LFSObject{
+					test.oid: {RepoID: testForkRepoID, OID: test.oid},
+				}
+			}
+
+			err := c.Upload(context.Background(), testSession(), "space/fork", test.oid,
+				test.size, strings.NewReader(test.content))
+
+			if test.wantStatus != 0 {
+				var uErr *usererror.Error
+				if !errors.As(err, &uErr) || uErr.Status != test.wantStatus {
+					t.Errorf("got=%v want status=%d", err, test.wantStatus)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !test.wantStored {
+				if len(blobStore.files) != 0 {
+					t.Errorf("expected no blob to be uploaded, got %d", len(blobStore.files))
+				}
+				return
+			}
+
+			data, ok := blobStore.files[getLFSObjectBucketPath(test.oid)]
+			if !ok || string(data) != test.content {
+				t.Errorf("blob: got=%q want=%q", data, test.content)
+			}
+
+			obj, err := objectStore.Find(context.Background(), testForkRepoID, test.oid)
+			if err != nil {
+				t.Fatalf("failed to find the created object: %v", err)
+			}
+			if obj.Size != int64(len(test.content)) || obj.CreatedBy != testPrincipalID {
+				t.Errorf("got=%+v want size=%d created_by=%d", obj, len(test.content), testPrincipalID)
+			}
+		})
+	}
+}
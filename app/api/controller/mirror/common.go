@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/harness/gitness/types/check"
+)
+
+const (
+	// mirrorMaxURLLength defines the max allowed length of a mirror remote URL.
+	mirrorMaxURLLength = 2048
+	// mirrorMaxCredentialLength defines the max allowed length of a mirror username or password.
+	mirrorMaxCredentialLength = 4096
+)
+
+// checkURL validates the remote url of a mirror.
+func checkURL(rawURL string, allowLoopback bool, allowPrivateNetwork bool) error {
+	if len(rawURL) > mirrorMaxURLLength {
+		return check.NewValidationErrorf("The remote URL of a mirror can be at most %d characters long.",
+			mirrorMaxURLLength)
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return check.NewValidationErrorf("The provided remote url is invalid: %s", err)
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return check.NewValidationError("The scheme of a remote URL must be either http or https.")
+	}
+
+	if parsedURL.User != nil {
+		return check.NewValidationError("Credentials have to be provided via username and password, " +
+			"not as part of the remote URL.")
+	}
+
+	host := parsedURL.Hostname()
+	if host == "" {
+		return check.NewValidationError("The remote URL of a mirror has to have a non-empty host.")
+	}
+
+	if !allowLoopback && host == "localhost" {
+		return check.NewValidationError("localhost is not allowed.")
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if !allowLoopback && ip.IsLoopback() {
+			return check.NewValidationError("Loopback IP addresses are not allowed.")
+		}
+
+		if !allowPrivateNetwork && ip.IsPrivate() {
+			return check.NewValidationError("Private IP addresses are not allowed.")
+		}
+	}
+
+	return nil
+}
+
+// checkCredential validates the username or password of a mirror.
+func checkCredential(credential string) error {
+	if len(credential) > mirrorMaxCredentialLength {
+		return check.NewValidationErrorf("The credentials of a mirror can be at most %d characters long.",
+			mirrorMaxCredentialLength)
+	}
+
+	return nil
+}
+
+// checkInterval validates the sync interval (in seconds) of a mirror.
+func checkInterval(interval int64, minInterval time.Duration) error {
+	if interval < int64(minInterval/time.Second) {
+		return check.NewValidationErrorf("The interval of a mirror has to be at least %d seconds.",
+			int64(minInterval/time.Second))
+	}
+
+	return nil
+}
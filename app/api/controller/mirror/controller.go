@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	config      mirror.Config
+	authorizer  authz.Authorizer
+	repoStore   store.RepoStore
+	mirrorStore store.RepoMirrorStore
+	encrypter   encrypt.Encrypter
+}
+
+func NewController(
+	config mirror.Config,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	mirrorStore store.RepoMirrorStore,
+	encrypter encrypt.Encrypter,
+) *Controller {
+	return &Controller{
+		config:      config,
+		authorizer:  authorizer,
+		repoStore:   repoStore,
+		mirrorStore: mirrorStore,
+		encrypter:   encrypter,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session, repoRef string, reqPermission enum.Permission) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, false); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return repo, nil
+}
+
+func (c *Controller) getMirrorVerifyOwnership(
+	ctx context.Context,
+	repoID int64,
+	mirrorID int64,
+) (*types.RepoMirror, error) {
+	m, err := c.mirrorStore.Find(ctx, mirrorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find mirror: %w", err)
+	}
+
+	// ensure the mirror actually belongs to the repo
+	if m.RepoID != repoID {
+		return nil, fmt.Errorf("mirror doesn't belong to requested repo. Returning error %w", usererror.ErrNotFound)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Direction enum.MirrorDirection `json:"direction"`
+	RemoteURL string               `json:"remote_url"`
+	Username  string               `json:"username"`
+	Password  string               `json:"password"`
+	Interval  int64                `json:"interval"`
+	Enabled   bool                 `json:"enabled"`
+}
+
+// Create creates a new pull or push mirror for a repository.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateInput,
+) (*types.RepoMirror, error) {
+	if err := c.sanitizeCreateInput(in); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedPassword, err := mirror.EncryptPassword(c.encrypter, in.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	m := &types.RepoMirror{
+		RepoID:         repo.ID,
+		Direction:      in.Direction,
+		RemoteURL:      in.RemoteURL,
+		Username:       in.Username,
+		Password:       encryptedPassword,
+		Interval:       in.Interval,
+		Enabled:        in.Enabled,
+		CreatedBy:      session.Principal.ID,
+		Created:        now,
+		Updated:        now,
+		LastSync:       nil,
+		LastSyncStatus: enum.MirrorSyncStatusPending,
+		LastSyncError:  "",
+		NextSync:       now, // sync new mirrors right away
+	}
+
+	err = c.mirrorStore.Create(ctx, m)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("The repository already has a pull mirror.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store mirror: %w", err)
+	}
+
+	return m, nil
+}
+
+func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
+	direction, ok := in.Direction.Sanitize()
+	if !ok || direction == "" {
+		return check.NewValidationErrorf("The mirror direction must be either %q or %q.",
+			enum.MirrorDirectionPull, enum.MirrorDirectionPush)
+	}
+	in.Direction = direction
+
+	if in.Interval == 0 {
+		in.Interval = int64(c.config.DefaultInterval / time.Second)
+	}
+
+	if err := checkURL(in.RemoteURL, c.config.AllowLoopback, c.config.AllowPrivateNetwork); err != nil {
+		return err
+	}
+	if err := checkCredential(in.Username); err != nil {
+		return err
+	}
+	if err := checkCredential(in.Password); err != nil {
+		return err
+	}
+	if err := checkInterval(in.Interval, c.config.MinInterval); err != nil { //nolint:revive
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Sync schedules an immediate sync of a mirror of a repository.
+func (c *Controller) Sync(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	mirrorID int64,
+) (*types.RepoMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := c.getMirrorVerifyOwnership(ctx, repo.ID, mirrorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.Enabled {
+		return nil, usererror.BadRequest("The mirror is disabled.")
+	}
+
+	now := time.Now().UnixMilli()
+	if err = c.mirrorStore.ScheduleSync(ctx, m.ID, now); err != nil {
+		return nil, fmt.Errorf("failed to schedule mirror sync: %w", err)
+	}
+
+	m.NextSync = now
+
+	return m, nil
+}
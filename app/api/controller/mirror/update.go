@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateInput struct {
+	RemoteURL *string `json:"remote_url"`
+	Username  *string `json:"username"`
+	Password  *string `json:"password"`
+	Interval  *int64  `json:"interval"`
+	Enabled   *bool   `json:"enabled"`
+}
+
+// Update updates an existing mirror of a repository.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	mirrorID int64,
+	in *UpdateInput,
+) (*types.RepoMirror, error) {
+	if err := c.sanitizeUpdateInput(in); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := c.getMirrorVerifyOwnership(ctx, repo.ID, mirrorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// update mirror struct (only for values that are provided)
+	if in.RemoteURL != nil {
+		m.RemoteURL = *in.RemoteURL
+	}
+	if in.Username != nil {
+		m.Username = *in.Username
+	}
+	if in.Password != nil {
+		m.Password, err = mirror.EncryptPassword(c.encrypter, *in.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if in.Interval != nil {
+		m.Interval = *in.Interval
+	}
+	if in.Enabled != nil {
+		m.Enabled = *in.Enabled
+	}
+
+	// sync the mirror right away to give immediate feedback on the updated configuration.
+	now := time.Now().UnixMilli()
+	m.Updated = now
+	m.NextSync = now
+
+	if err = c.mirrorStore.Update(ctx, m); err != nil {
+		return nil, fmt.Errorf("failed to update mirror: %w", err)
+	}
+
+	return m, nil
+}
+
+func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
+	if in.RemoteURL != nil {
+		if err := checkURL(*in.RemoteURL, c.config.AllowLoopback, c.config.AllowPrivateNetwork); err != nil {
+			return err
+		}
+	}
+	if in.Username != nil {
+		if err := checkCredential(*in.Username); err != nil {
+			return err
+		}
+	}
+	if in.Password != nil {
+		if err := checkCredential(*in.Password); err != nil {
+			return err
+		}
+	}
+	if in.Interval != nil {
+		if err := checkInterval(*in.Interval, c.config.MinInterval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -46,6 +46,9 @@ type CommentCreateInput struct {
 	LineStartNew    bool   `json:"line_start_new"`
 	LineEnd         int    `json:"line_end"`
 	LineEndNew      bool   `json:"line_end_new"`
+
+	// Pending adds the comment to the review in progress, the comment is published when the review is submitted.
+	Pending bool `json:"pending"`
 }
 
 func (in *CommentCreateInput) IsReply() bool {
@@ -109,8 +112,18 @@ func (c *Controller) CommentCreate(
 		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
 	}
 
+	if in.Pending && pr.CreatedBy == session.Principal.ID {
+		return nil, usererror.BadRequest("Can't add pending review comments to own pull requests.")
+	}
+
 	var cut git.DiffCutOutput
+	var suggestion *types.CodeCommentSuggestion
 	if in.IsCodeComment() {
+		suggestion, err = parseCodeCommentSuggestion(in.Text, in.LineStartNew && in.LineEndNew)
+		if err != nil {
+			return nil, err
+		}
+
 		// fetch code snippet from git for code comments
 		cut, err = c.fetchDiffCut(ctx, repo, in)
 		if err != nil {
@@ -141,6 +154,7 @@ func (c *Controller) CommentCreate(
 				Lines:        cut.Lines,
 				LineStartNew: in.LineStartNew,
 				LineEndNew:   in.LineEndNew,
+				Suggestion:   suggestion,
 			})
 
 			err = c.writeActivity(ctx, pr, act)
@@ -148,13 +162,14 @@ func (c *Controller) CommentCreate(
 		case in.ParentID != 0:
 			var parentAct *types.PullReqActivity
 
-			parentAct, err = c.checkIsReplyable(ctx, pr, in.ParentID)
+			parentAct, err = c.checkIsReplyable(ctx, session, pr, in.ParentID)
 			if err != nil {
 				return err
 			}
 
 			act.ParentID = &parentAct.ID
 			act.Kind = parentAct.Kind
+			act.Pending = act.Pending || parentAct.Pending // replies to pending comments are pending too
 			_ = act.SetPayload(types.PullRequestActivityPayloadComment{})
 
 			err = c.writeReplyActivity(ctx, parentAct, act)
@@ -166,9 +181,12 @@ func (c *Controller) CommentCreate(
 			return fmt.Errorf("failed to write pull request comment: %w", err)
 		}
 
-		pr.CommentCount++
-		if act.IsBlocking() {
-			pr.UnresolvedCount++
+		// pending comments are counted when the review is submitted
+		if !act.Pending {
+			pr.CommentCount++
+			if act.IsBlocking() {
+				pr.UnresolvedCount++
+			}
 		}
 
 		err = c.pullreqStore.Update(ctx, pr)
@@ -189,6 +207,11 @@ func (c *Controller) CommentCreate(
 		c.migrateCodeComment(ctx, repo, pr, in, act.AsCodeComment(), cut)
 	}
 
+	if act.Pending {
+		// pending comments are published all at once when the review is submitted
+		return act, nil
+	}
+
 	if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
@@ -213,6 +236,7 @@ func (c *Controller) CommentCreate(
 
 func (c *Controller) checkIsReplyable(
 	ctx context.Context,
+	session *auth.Session,
 	pr *types.PullReq,
 	parentID int64,
 ) (*types.PullReqActivity, error) {
@@ -225,6 +249,10 @@ func (c *Controller) checkIsReplyable(
 		return nil, fmt.Errorf("failed to find parent pull request activity: %w", err)
 	}
 
+	if parentAct.Pending && parentAct.CreatedBy != session.Principal.ID {
+		return nil, usererror.BadRequest("Parent pull request activity not found.")
+	}
+
 	if parentAct.PullReqID != pr.ID || parentAct.RepoID != pr.TargetRepoID {
 		return nil, usererror.BadRequest("Parent pull request activity doesn't belong to the same pull request.")
 	}
@@ -292,6 +320,7 @@ func getCommentActivity(session *auth.Session, pr *types.PullReq, in *CommentCre
 		Updated:    now,
 		Edited:     now,
 		Deleted:    nil,
+		Pending:    in.Pending,
 		ParentID:   nil, // Will be filled in CommentCreate
 		RepoID:     pr.TargetRepoID,
 		PullReqID:  pr.ID,
@@ -69,9 +69,11 @@ func (c *Controller) CommentDelete(
 			return fmt.Errorf("failed to mark comment as deleted: %w", err)
 		}
 
-		pr.CommentCount--
-		if isBlocking {
-			pr.UnresolvedCount--
+		if !act.Pending {
+			pr.CommentCount--
+			if isBlocking {
+				pr.UnresolvedCount--
+			}
 		}
 
 		err = c.pullreqStore.Update(ctx, pr)
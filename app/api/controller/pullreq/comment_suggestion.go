@@ -0,0 +1,397 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxSuggestionFileSize is the maximum size of a file that suggestions can be applied to.
+const maxSuggestionFileSize = 10 * 1024 * 1024
+
+type CommentApplySuggestionsInput struct {
+	// CommentIDs are the IDs of the code comments which suggestions are applied in a single commit.
+	CommentIDs []int64 `json:"comment_ids"`
+
+	// SourceSHA is optional, if provided the suggestions are applied only if the source branch is on this commit.
+	SourceSHA string `json:"source_sha"`
+
+	Title   string `json:"title"`
+	Message string `json:"message"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *CommentApplySuggestionsInput) sanitize() error {
+	if len(in.CommentIDs) == 0 {
+		return usererror.BadRequest("At least one code comment must be provided.")
+	}
+
+	ids := make(map[int64]struct{}, len(in.CommentIDs))
+	for _, id := range in.CommentIDs {
+		if _, ok := ids[id]; ok {
+			return usererror.BadRequestf("Code comment %d is provided more than once.", id)
+		}
+		ids[id] = struct{}{}
+	}
+
+	if in.Title == "" {
+		if len(in.CommentIDs) == 1 {
+			in.Title = "Apply suggestion from code review"
+		} else {
+			in.Title = fmt.Sprintf("Apply %d suggestions from code review", len(in.CommentIDs))
+		}
+	}
+
+	return nil
+}
+
+type CommentApplySuggestionsOutput struct {
+	CommitID string `json:"commit_id,omitempty"`
+
+	DryRunRules    bool                   `json:"dry_run_rules,omitempty"`
+	RuleViolations []types.RuleViolations `json:"rule_violations,omitempty"`
+}
+
+// CommentApplySuggestions applies the suggestions of the code comments as a single commit on the source branch.
+//
+//nolint:gocognit,funlen // refactor if needed
+func (c *Controller) CommentApplySuggestions(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *CommentApplySuggestionsInput,
+) (CommentApplySuggestionsOutput, []types.RuleViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return CommentApplySuggestionsOutput{}, nil, err
+	}
+
+	targetRepo, sourceRepo, pr, err := c.getSourceBranchUpdateAccess(ctx, session, repoRef, prNum, in.DryRunRules)
+	if err != nil {
+		return CommentApplySuggestionsOutput{}, nil, err
+	}
+
+	if in.SourceSHA != "" && in.SourceSHA != pr.SourceSHA {
+		return CommentApplySuggestionsOutput{}, nil,
+			usererror.BadRequest("The source branch of the pull request has been updated.")
+	}
+
+	comments := make([]*types.PullReqActivity, len(in.CommentIDs))
+	suggestions := make(map[string][]codecomments.Suggestion)
+	for i, commentID := range in.CommentIDs {
+		comment, err := c.getCommentCheckModifyAccess(ctx, session, pr, commentID)
+		if err != nil {
+			return CommentApplySuggestionsOutput{}, nil, err
+		}
+
+		suggestion, err := getCodeCommentSuggestion(comment)
+		if err != nil {
+			return CommentApplySuggestionsOutput{}, nil, err
+		}
+
+		if comment.CodeComment.Outdated || comment.CodeComment.SourceSHA != pr.SourceSHA {
+			return CommentApplySuggestionsOutput{}, nil,
+				usererror.BadRequestf("Suggestion of code comment %d is outdated.", commentID)
+		}
+
+		cc := comment.CodeComment
+		suggestions[cc.Path] = append(suggestions[cc.Path], codecomments.Suggestion{
+			LineStart: cc.LineNew,
+			LineEnd:   cc.LineNew + cc.SpanNew - 1,
+			Content:   suggestion.Content,
+		})
+
+		comments[i] = comment
+	}
+
+	paths := make([]string, 0, len(suggestions))
+	for path := range suggestions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	readParams := git.CreateReadParams(targetRepo)
+
+	actions := make([]git.CommitFileAction, len(paths))
+	pushFiles := make([]protection.PushFile, len(paths))
+	for i, path := range paths {
+		blobSHA, content, err := c.getSuggestionFile(ctx, readParams, pr.SourceSHA, path)
+		if err != nil {
+			return CommentApplySuggestionsOutput{}, nil, err
+		}
+
+		content, err = codecomments.ApplySuggestions(content, suggestions[path])
+		if err != nil {
+			return CommentApplySuggestionsOutput{}, nil, err
+		}
+
+		actions[i] = git.CommitFileAction{
+			Action:  git.UpdateAction,
+			Path:    path,
+			Payload: content,
+			SHA:     blobSHA,
+		}
+		pushFiles[i] = protection.PushFile{Path: path, Size: int64(len(content))}
+	}
+
+	signer, err := c.signatureService.Signer(ctx, sourceRepo.ParentID)
+	if err != nil {
+		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to get commit signer: %w", err)
+	}
+
+	author := identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	committer := identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+
+	message := in.Title
+	if in.Message != "" {
+		message += "\n\n" + in.Message
+	}
+
+	violations, err := c.verifySourceBranchUpdate(ctx, session, sourceRepo, pr,
+		protection.NewCommitPushInfo(c.principalStore, protection.PushCommit{
+			Message:        message,
+			AuthorEmail:    author.Email,
+			CommitterEmail: committer.Email,
+			ParentSHAs:     []string{pr.SourceSHA},
+		}, pushFiles, signer != nil),
+		in.BypassRules)
+	if err != nil {
+		return CommentApplySuggestionsOutput{}, nil, err
+	}
+
+	if in.DryRunRules {
+		return CommentApplySuggestionsOutput{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return CommentApplySuggestionsOutput{}, violations, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+	if err != nil {
+		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	writeParams.Signer = signer
+
+	now := time.Now()
+	commit, err := c.git.CommitFiles(ctx, &git.CommitFilesParams{
+		WriteParams:   writeParams,
+		Title:         in.Title,
+		Message:       in.Message,
+		Branch:        pr.SourceBranch,
+		Actions:       actions,
+		Committer:     committer,
+		CommitterDate: &now,
+		Author:        author,
+		AuthorDate:    &now,
+	})
+	if err != nil {
+		return CommentApplySuggestionsOutput{}, nil, err
+	}
+
+	c.protectionManager.RecordMonitorViolations(ctx, sourceRepo.ID, session.Principal.ID,
+		enum.RuleViolationActionPush, violations)
+
+	for _, comment := range comments {
+		_, err = c.activityStore.UpdateOptLock(ctx, comment, func(act *types.PullReqActivity) error {
+			payload, err := act.GetPayload()
+			if err != nil {
+				return fmt.Errorf("failed to get code comment payload: %w", err)
+			}
+
+			codeCommentPayload, ok := payload.(*types.PullRequestActivityPayloadCodeComment)
+			if !ok || codeCommentPayload.Suggestion == nil {
+				return nil
+			}
+
+			appliedAt := now.UnixMilli()
+			codeCommentPayload.Suggestion.Applied = &appliedAt
+			codeCommentPayload.Suggestion.AppliedBy = &session.Principal.ID
+			codeCommentPayload.Suggestion.AppliedSHA = commit.CommitID
+
+			return act.SetPayload(codeCommentPayload)
+		})
+		if err != nil {
+			// non-critical error, the commit is already on the source branch
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to mark suggestion of code comment %d as applied", comment.ID)
+		}
+	}
+
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return CommentApplySuggestionsOutput{
+		CommitID:       commit.CommitID,
+		RuleViolations: violations,
+	}, nil, nil
+}
+
+// getSuggestionFile returns the blob SHA and the content of the file the suggestions are applied to.
+func (c *Controller) getSuggestionFile(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commitSHA string,
+	path string,
+) (string, []byte, error) {
+	node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     commitSHA,
+		Path:       path,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get file %q: %w", path, err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob || node.Node.Mode == git.TreeNodeModeSymlink {
+		return "", nil, usererror.BadRequestf("Suggestions can be applied only to files, %q is not a file.", path)
+	}
+
+	blob, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  maxSuggestionFileSize,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get content of file %q: %w", path, err)
+	}
+
+	defer func() {
+		if err := blob.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if blob.Size > maxSuggestionFileSize {
+		return "", nil, usererror.BadRequestf("File %q is too large to apply suggestions.", path)
+	}
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read content of file %q: %w", path, err)
+	}
+
+	return node.Node.SHA, content, nil
+}
+
+// getCodeCommentSuggestion returns the suggestion of the code comment that is not yet applied.
+func getCodeCommentSuggestion(comment *types.PullReqActivity) (*types.CodeCommentSuggestion, error) {
+	if !comment.IsValidCodeComment() {
+		return nil, usererror.BadRequestf("Comment %d is not a code comment.", comment.ID)
+	}
+
+	if comment.Pending {
+		return nil, usererror.BadRequestf("Code comment %d is pending, the review must be submitted first.", comment.ID)
+	}
+
+	payload, err := comment.GetPayload()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code comment payload: %w", err)
+	}
+
+	codeCommentPayload, ok := payload.(*types.PullRequestActivityPayloadCodeComment)
+	if !ok || codeCommentPayload.Suggestion == nil {
+		return nil, usererror.BadRequestf("Code comment %d doesn't contain a suggestion.", comment.ID)
+	}
+
+	if codeCommentPayload.Suggestion.Applied != nil {
+		return nil, usererror.BadRequestf("Suggestion of code comment %d is already applied.", comment.ID)
+	}
+
+	return codeCommentPayload.Suggestion, nil
+}
+
+// parseCodeCommentSuggestion returns the suggestion from the code comment text, or nil if there's none.
+// Suggestions are allowed only for code comments on the lines of the source branch.
+func parseCodeCommentSuggestion(text string, allowed bool) (*types.CodeCommentSuggestion, error) {
+	content, ok, err := codecomments.ParseSuggestion(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, nil //nolint:nilnil // no suggestion in the text
+	}
+
+	if !allowed {
+		return nil, usererror.BadRequest("Suggestions are allowed only on the lines of the source branch.")
+	}
+
+	return &types.CodeCommentSuggestion{Content: content}, nil
+}
+
+// isSuggestionAllowed returns true if the code comment is on the lines of the source branch.
+func isSuggestionAllowed(act *types.PullReqActivity) bool {
+	payload, err := act.GetPayload()
+	if err != nil {
+		return false
+	}
+
+	codeCommentPayload, ok := payload.(*types.PullRequestActivityPayloadCodeComment)
+
+	return ok && codeCommentPayload.LineStartNew && codeCommentPayload.LineEndNew
+}
+
+// updateCodeCommentSuggestion replaces the suggestion in the code comment payload.
+// An applied suggestion can't be changed.
+func updateCodeCommentSuggestion(act *types.PullReqActivity, suggestion *types.CodeCommentSuggestion) error {
+	payload, err := act.GetPayload()
+	if errors.Is(err, types.ErrNoPayload) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get code comment payload: %w", err)
+	}
+
+	codeCommentPayload, ok := payload.(*types.PullRequestActivityPayloadCodeComment)
+	if !ok {
+		return nil
+	}
+
+	if old := codeCommentPayload.Suggestion; old != nil && old.Applied != nil {
+		if suggestion == nil || suggestion.Content != old.Content {
+			return usererror.BadRequest("Applied suggestion can't be changed.")
+		}
+		return nil
+	}
+
+	codeCommentPayload.Suggestion = suggestion
+
+	return act.SetPayload(codeCommentPayload)
+}
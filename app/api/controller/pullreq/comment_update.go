@@ -71,11 +71,24 @@ func (c *Controller) CommentUpdate(
 		return act, nil
 	}
 
+	var suggestion *types.CodeCommentSuggestion
+	if act.IsValidCodeComment() {
+		suggestion, err = parseCodeCommentSuggestion(in.Text, isSuggestionAllowed(act))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	act, err = c.activityStore.UpdateOptLock(ctx, act, func(act *types.PullReqActivity) error {
 		now := time.Now().UnixMilli()
 		act.Edited = now
 		act.Text = in.Text
-		return nil
+
+		if !act.IsValidCodeComment() {
+			return nil
+		}
+
+		return updateCodeCommentSuggestion(act, suggestion)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update comment: %w", err)
@@ -19,14 +19,19 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/signature"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -34,6 +39,7 @@ import (
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/lock"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -61,6 +67,10 @@ type Controller struct {
 	protectionManager   *protection.Manager
 	sseStreamer         sse.Streamer
 	codeOwners          *codeowners.Service
+	mergeQueue          *mergequeue.Service
+	signatureService    *signature.Service
+	labelService        *label.Service
+	auditService        *audit.Service
 }
 
 func NewController(
@@ -85,6 +95,10 @@ func NewController(
 	protectionManager *protection.Manager,
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
+	mergeQueue *mergequeue.Service,
+	signatureService *signature.Service,
+	labelService *label.Service,
+	auditService *audit.Service,
 ) *Controller {
 	return &Controller{
 		tx:                  tx,
@@ -108,6 +122,10 @@ func NewController(
 		protectionManager:   protectionManager,
 		sseStreamer:         sseStreamer,
 		codeOwners:          codeowners,
+		mergeQueue:          mergeQueue,
+		signatureService:    signatureService,
+		labelService:        labelService,
+		auditService:        auditService,
 	}
 }
 
@@ -137,6 +155,70 @@ func (c *Controller) verifyBranchExistence(ctx context.Context,
 	return ref.SHA, nil
 }
 
+// verifyForkRelation checks that the source repository is a fork of the target repository or vice versa.
+// Pull requests can be opened only between repositories of the same fork network.
+func (c *Controller) verifyForkRelation(ctx context.Context,
+	sourceRepo, targetRepo *types.Repository,
+) error {
+	isAncestor := func(repo *types.Repository, ancestorID int64) (bool, error) {
+		// limit the depth to protect against cycles
+		const maxDepth = 16
+		for i := 0; i < maxDepth && repo.ForkID != 0; i++ {
+			if repo.ForkID == ancestorID {
+				return true, nil
+			}
+
+			var err error
+			repo, err = c.repoStore.Find(ctx, repo.ForkID)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return false, nil
+			}
+			if err != nil {
+				return false, fmt.Errorf("failed to find upstream repository: %w", err)
+			}
+		}
+		return false, nil
+	}
+
+	ok, err := isAncestor(sourceRepo, targetRepo.ID)
+	if err != nil || ok {
+		return err
+	}
+
+	ok, err = isAncestor(targetRepo, sourceRepo.ID)
+	if err != nil || ok {
+		return err
+	}
+
+	return usererror.BadRequest("The source repository must be a fork of the target repository or vice versa.")
+}
+
+// fetchSourceCommits makes the commits of the source repository available in the target repository.
+// It's a no-op for pull requests whose source and target repository are the same.
+func (c *Controller) fetchSourceCommits(ctx context.Context,
+	session *auth.Session, sourceRepo, targetRepo *types.Repository, sha string,
+) error {
+	if sourceRepo.ID == targetRepo.ID {
+		return nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []string{sha},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch commits from the source repository: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) getRepoCheckAccess(ctx context.Context,
 	session *auth.Session, repoRef string, reqPermission enum.Permission,
 ) (*types.Repository, error) {
@@ -161,7 +243,7 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 }
 
 func (c *Controller) getCommentCheckModifyAccess(ctx context.Context,
-	pr *types.PullReq, commentID int64,
+	session *auth.Session, pr *types.PullReq, commentID int64,
 ) (*types.PullReqActivity, error) {
 	if commentID <= 0 {
 		return nil, usererror.BadRequest("A valid comment ID must be provided.")
@@ -180,6 +262,10 @@ func (c *Controller) getCommentCheckModifyAccess(ctx context.Context,
 		return nil, usererror.ErrNotFound
 	}
 
+	if comment.Pending && comment.CreatedBy != session.Principal.ID {
+		return nil, usererror.ErrNotFound // pending comments are visible only to the author
+	}
+
 	if comment.Kind == enum.PullReqActivityKindSystem {
 		return nil, usererror.BadRequest("Can't update a comment created by the system.")
 	}
@@ -194,7 +280,7 @@ func (c *Controller) getCommentCheckModifyAccess(ctx context.Context,
 func (c *Controller) getCommentCheckEditAccess(ctx context.Context,
 	session *auth.Session, pr *types.PullReq, commentID int64,
 ) (*types.PullReqActivity, error) {
-	comment, err := c.getCommentCheckModifyAccess(ctx, pr, commentID)
+	comment, err := c.getCommentCheckModifyAccess(ctx, session, pr, commentID)
 	if err != nil {
 		return nil, err
 	}
@@ -207,9 +293,9 @@ func (c *Controller) getCommentCheckEditAccess(ctx context.Context,
 }
 
 func (c *Controller) getCommentCheckChangeStatusAccess(ctx context.Context,
-	pr *types.PullReq, commentID int64,
+	session *auth.Session, pr *types.PullReq, commentID int64,
 ) (*types.PullReqActivity, error) {
-	comment, err := c.getCommentCheckModifyAccess(ctx, pr, commentID)
+	comment, err := c.getCommentCheckModifyAccess(ctx, session, pr, commentID)
 	if err != nil {
 		return nil, err
 	}
@@ -218,6 +304,10 @@ func (c *Controller) getCommentCheckChangeStatusAccess(ctx context.Context,
 		return nil, usererror.BadRequest("Can't change status of replies.")
 	}
 
+	if comment.Pending {
+		return nil, usererror.BadRequest("Can't change status of pending comments.")
+	}
+
 	return comment, nil
 }
 
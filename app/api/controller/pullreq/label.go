@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type LabelAssignInput struct {
+	LabelID int64 `json:"label_id"`
+}
+
+func (in *LabelAssignInput) sanitize() error {
+	if in.LabelID <= 0 {
+		return usererror.BadRequest("A valid label ID must be provided.")
+	}
+
+	return nil
+}
+
+// LabelList returns the labels assigned to the pull request.
+func (c *Controller) LabelList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) ([]*types.LabelInfo, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if err = c.labelService.Attach(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	if pr.Labels == nil {
+		return []*types.LabelInfo{}, nil
+	}
+
+	return pr.Labels, nil
+}
+
+// LabelAssign assigns a label to the pull request. The label must be defined in the parent space of
+// the repository or in any of its ancestors. A scoped label replaces the assigned label with the same key.
+func (c *Controller) LabelAssign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *LabelAssignInput,
+) (*types.PullReq, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	out, err := c.labelService.Assign(ctx, session.Principal.ID, repo, pr, in.LabelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Assigned {
+		payload := &types.PullRequestActivityPayloadLabel{
+			Action: enum.LabelActionAssigned,
+			Label:  out.Label.ToLabelInfo(),
+		}
+
+		var replacedLabelID *int64
+		if out.Replaced != nil {
+			payload.Action = enum.LabelActionReassigned
+			payload.OldLabel = out.Replaced
+			replacedLabelID = &out.Replaced.ID
+		}
+
+		pr = c.writeLabelActivity(ctx, session, pr, payload)
+
+		c.eventReporter.LabelAssigned(ctx, &pullreqevents.LabelAssignedPayload{
+			Base:            eventBase(pr, &session.Principal),
+			LabelID:         out.Label.ID,
+			ReplacedLabelID: replacedLabelID,
+		})
+	}
+
+	return c.labelsChanged(ctx, repo, pr, out.Assigned)
+}
+
+// LabelUnassign removes a label from the pull request.
+func (c *Controller) LabelUnassign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	labelID int64,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	label, err := c.labelService.Unassign(ctx, pr, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr = c.writeLabelActivity(ctx, session, pr, &types.PullRequestActivityPayloadLabel{
+		Action: enum.LabelActionUnassigned,
+		Label:  label,
+	})
+
+	c.eventReporter.LabelUnassigned(ctx, &pullreqevents.LabelUnassignedPayload{
+		Base:    eventBase(pr, &session.Principal),
+		LabelID: label.ID,
+	})
+
+	return c.labelsChanged(ctx, repo, pr, true)
+}
+
+// writeLabelActivity writes the pull request activity of a label change.
+// Failures are logged only, because the labels are already changed.
+func (c *Controller) writeLabelActivity(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+	payload *types.PullRequestActivityPayloadLabel,
+) *types.PullReq {
+	prUpd, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to update pull request activity sequence after label change")
+		return pr
+	}
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, prUpd, session.Principal.ID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after label change")
+	}
+
+	return prUpd
+}
+
+// labelsChanged attaches the labels to the pull request and, if they changed, notifies the clients.
+func (c *Controller) labelsChanged(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	changed bool,
+) (*types.PullReq, error) {
+	if err := c.labelService.Attach(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	if !changed {
+		return pr, nil
+	}
+
+	if err := c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return pr, nil
+}
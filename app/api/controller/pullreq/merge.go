@@ -17,6 +17,7 @@ package pullreq
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
@@ -25,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/contextutil"
@@ -40,6 +42,8 @@ import (
 type MergeInput struct {
 	Method      enum.MergeMethod `json:"method"`
 	SourceSHA   string           `json:"source_sha"`
+	Title       string           `json:"title"`
+	Message     string           `json:"message"`
 	BypassRules bool             `json:"bypass_rules"`
 	DryRun      bool             `json:"dry_run"`
 }
@@ -62,6 +66,9 @@ func (in *MergeInput) sanitize() error {
 		in.Method = method
 	}
 
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
 	return nil
 }
 
@@ -74,8 +81,6 @@ func (in *MergeInput) sanitize() error {
 // return allowed merge methods. Rules can limit allowed merge methods.
 //
 // If the pull request has been successfully merged the function will return the SHA of the merge commit.
-//
-//nolint:gocognit,gocyclo,cyclop
 func (c *Controller) Merge(
 	ctx context.Context,
 	session *auth.Session,
@@ -97,6 +102,25 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
 
+	return c.MergeNoAuth(ctx, session, targetRepo, pullreqNum, in)
+}
+
+// MergeNoAuth merges a pull request without checking the access of the session's principal to the repository.
+// The function is used by the auto-merge service, which merges pull requests on behalf of the principal
+// who enabled auto-merge.
+//
+//nolint:gocognit,gocyclo,cyclop
+func (c *Controller) MergeNoAuth(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.Repository,
+	pullreqNum int64,
+	in *MergeInput,
+) (*types.MergeResponse, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
 	// the max time we give a merge to succeed
 	const timeout = 3 * time.Minute
 
@@ -152,17 +176,22 @@ func (c *Controller) Merge(
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
 
-		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 		}
 	}
 
+	targetWriteParams.Signer, err = c.signatureService.Signer(ctx, targetRepo.ParentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get commit signer: %w", err)
+	}
+
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, targetRepo)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
@@ -195,11 +224,22 @@ func (c *Controller) Merge(
 		Method:       in.Method,
 		CheckResults: checkResults,
 		CodeOwners:   codeOwnerWithApproval,
+		Commits: protection.NewGitCommitVerifier(c.git, c.signatureService, targetRepo,
+			git.CreateReadParams(sourceRepo), pr.SourceSHA, pr.MergeBaseSHA),
+		Ancestry: protection.NewGitAncestryChecker(c.git, git.CreateReadParams(targetRepo)),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
+	// the source branch of a pull request from a fork is deleted only if the user is allowed to push to the fork.
+	if ruleOut.DeleteSourceBranch && sourceRepo.ID != targetRepo.ID {
+		errAuth := apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush, false)
+		if errAuth != nil {
+			ruleOut.DeleteSourceBranch = false
+		}
+	}
+
 	// we want to complete the merge independent of request cancel - start with new, time restricted context.
 	// TODO: This is a small change to reduce likelihood of dirty state.
 	// We still require a proper solution to handle an application crash or very slow execution times
@@ -262,11 +302,12 @@ func (c *Controller) Merge(
 
 		// With in.DryRun=true this function never returns types.MergeViolations
 		out := &types.MergeResponse{
-			DryRun:         true,
-			BranchDeleted:  ruleOut.DeleteSourceBranch,
-			AllowedMethods: ruleOut.AllowedMethods,
-			ConflictFiles:  pr.MergeConflicts,
-			RuleViolations: violations,
+			DryRun:              true,
+			BranchDeleted:       ruleOut.DeleteSourceBranch,
+			AllowedMethods:      ruleOut.AllowedMethods,
+			ConflictFiles:       pr.MergeConflicts,
+			RuleViolations:      violations,
+			FastForwardPossible: pr.MergeTargetSHA != nil && *pr.MergeTargetSHA == pr.MergeBaseSHA,
 		}
 
 		return out, nil, nil
@@ -281,31 +322,37 @@ func (c *Controller) Merge(
 	var author *git.Identity
 
 	switch in.Method {
-	case enum.MergeMethodMerge:
+	case enum.MergeMethodMerge, enum.MergeMethodRebaseMerge:
 		author = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
 	case enum.MergeMethodSquash:
 		author = identityFromPrincipalInfo(pr.Author)
 	case enum.MergeMethodRebase:
 		author = nil // Not important for the rebase merge: the author info in the commits will be preserved.
+	case enum.MergeMethodFastForward:
+		author = nil // Not important for the fast-forward merge: no commits are created.
 	}
 
 	var committer *git.Identity
 
 	switch in.Method {
-	case enum.MergeMethodMerge, enum.MergeMethodSquash:
+	case enum.MergeMethodMerge, enum.MergeMethodSquash, enum.MergeMethodRebaseMerge, enum.MergeMethodRebase:
 		committer = identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
-	case enum.MergeMethodRebase:
-		committer = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	case enum.MergeMethodFastForward:
+		committer = nil // Not important for the fast-forward merge: no commits are created.
 	}
 
-	var mergeTitle string
+	mergeTitle := in.Title
 
 	switch in.Method {
-	case enum.MergeMethodMerge:
-		mergeTitle = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodMerge, enum.MergeMethodRebaseMerge:
+		if mergeTitle == "" {
+			mergeTitle = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+		}
 	case enum.MergeMethodSquash:
-		mergeTitle = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-	case enum.MergeMethodRebase:
+		if mergeTitle == "" {
+			mergeTitle = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+		}
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
 		mergeTitle = "" // Not used.
 	}
 
@@ -320,7 +367,7 @@ func (c *Controller) Merge(
 		HeadRepoUID:     sourceRepo.GitUID,
 		HeadBranch:      pr.SourceBranch,
 		Title:           mergeTitle,
-		Message:         "",
+		Message:         in.Message,
 		Committer:       committer,
 		CommitterDate:   &now,
 		Author:          author,
@@ -366,6 +413,11 @@ func (c *Controller) Merge(
 
 	log.Ctx(ctx).Debug().Msgf("successfully merged PR")
 
+	c.protectionManager.RecordMonitorViolations(ctx, targetRepo.ID, session.Principal.ID,
+		enum.RuleViolationActionMerge, violations)
+
+	c.auditBypassedViolations(ctx, session, targetRepo, pr, violations)
+
 	var activitySeqMerge, activitySeqBranchDeleted int64
 	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.State = enum.PullReqStateMerged
@@ -385,6 +437,9 @@ func (c *Controller) Merge(
 		pr.MergeConflicts = nil
 		pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
 
+		// auto-merge is done once the pull request is merged
+		pr.AutoMerge = nil
+
 		// update sequence for PR activities
 		pr.ActivitySeq++
 		activitySeqMerge = pr.ActivitySeq
@@ -454,3 +509,37 @@ func (c *Controller) Merge(
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// auditBypassedViolations records in the audit log the rules that have been bypassed to merge the pull request.
+func (c *Controller) auditBypassedViolations(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pr *types.PullReq,
+	violations []types.RuleViolations,
+) {
+	var bypassed []types.RuleViolations
+	for i := range violations {
+		if violations[i].Bypassed && len(violations[i].Violations) > 0 {
+			bypassed = append(bypassed, violations[i])
+		}
+	}
+
+	if len(bypassed) == 0 {
+		return
+	}
+
+	c.auditService.Log(ctx, audit.Event{
+		ActorID:      session.Principal.ID,
+		Action:       enum.AuditActionBypass,
+		ResourceType: enum.AuditResourceTypePullReq,
+		Resource:     fmt.Sprintf("%s#%d", repo.Path, pr.Number),
+		SpaceID:      repo.ParentID,
+		RepoID:       repo.ID,
+		After: struct {
+			RuleViolations []types.RuleViolations `json:"rule_violations"`
+		}{
+			RuleViolations: bypassed,
+		},
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MergeQueueEnqueueInput struct {
+	Method    enum.MergeMethod `json:"method"`
+	SourceSHA string           `json:"source_sha"`
+}
+
+func (in *MergeQueueEnqueueInput) sanitize() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("source SHA must be provided")
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	return nil
+}
+
+// MergeQueueEnqueue adds a pull request to the merge queue of its target branch.
+// If the pull request doesn't satisfy the protection rules, the rule violations are returned.
+func (c *Controller) MergeQueueEnqueue(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *MergeQueueEnqueueInput,
+) (*types.PullReq, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, nil,
+			usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	_, violations, err := c.mergeQueue.Enqueue(ctx, &session.Principal, repo, pr, in.Method)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(violations) > 0 {
+		return nil, &types.MergeViolations{RuleViolations: violations}, nil
+	}
+
+	// the pull request might have been merged or ejected already.
+	pr, err = c.pullreqStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if err = c.mergeQueue.Attach(ctx, pr); err != nil {
+		return nil, nil, err
+	}
+
+	return pr, nil, nil
+}
+
+// MergeQueueDequeue removes a pull request from the merge queue of its target branch.
+func (c *Controller) MergeQueueDequeue(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if err = c.mergeQueue.Dequeue(ctx, &session.Principal, repo, pr); err != nil {
+		return nil, err
+	}
+
+	pr, err = c.pullreqStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if err = c.mergeQueue.Attach(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// MergeQueueList returns the merge queue of a target branch.
+// If no branch is provided, the merge queue of the default branch is returned.
+func (c *Controller) MergeQueueList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	return c.mergeQueue.List(ctx, repo.ID, branch)
+}
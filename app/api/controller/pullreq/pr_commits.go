@@ -57,6 +57,8 @@ func (c *Controller) Commits(
 		return nil, err
 	}
 
+	verifier := c.signatureService.NewVerifier(repo)
+
 	commits := make([]types.Commit, len(output.Commits))
 	for i := range output.Commits {
 		var commit *types.Commit
@@ -64,6 +66,12 @@ func (c *Controller) Commits(
 		if err != nil {
 			return nil, fmt.Errorf("failed to map commit: %w", err)
 		}
+
+		commit.Verification, err = verifier.VerifyCommit(ctx, &output.Commits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify commit signature: %w", err)
+		}
+
 		commits[i] = *commit
 	}
 
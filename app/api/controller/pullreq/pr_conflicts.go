@@ -0,0 +1,244 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ConflictsOutput struct {
+	SourceSHA    string                       `json:"source_sha"`
+	TargetSHA    string                       `json:"target_sha"`
+	MergeBaseSHA string                       `json:"merge_base_sha"`
+	Files        []gittypes.MergeConflictFile `json:"files"`
+}
+
+// Conflicts returns the files that conflict when the target branch is merged into the source branch
+// of the pull request, with the base, ours (source) and theirs (target) content and the conflicting hunks.
+func (c *Controller) Conflicts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (ConflictsOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return ConflictsOutput{}, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return ConflictsOutput{}, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return ConflictsOutput{}, usererror.BadRequest("Pull request must be open")
+	}
+
+	out, err := c.git.MergeConflicts(ctx, &git.MergeConflictsParams{
+		ReadParams: git.CreateReadParams(repo),
+		Ours:       pr.SourceSHA,
+		Theirs:     pr.TargetBranch,
+	})
+	if err != nil {
+		return ConflictsOutput{}, fmt.Errorf("failed to get merge conflicts: %w", err)
+	}
+
+	return ConflictsOutput{
+		SourceSHA:    out.OursSHA,
+		TargetSHA:    out.TheirsSHA,
+		MergeBaseSHA: out.MergeBaseSHA,
+		Files:        out.Files,
+	}, nil
+}
+
+// ResolvedFile holds the resolved content of a conflicting file.
+type ResolvedFile struct {
+	Path     string                   `json:"path"`
+	Content  string                   `json:"content"`
+	Encoding enum.ContentEncodingType `json:"encoding"`
+
+	// Delete resolves the conflict by removing the file.
+	Delete bool `json:"delete"`
+}
+
+type ResolveConflictsInput struct {
+	// SourceSHA is optional, if provided the resolution fails if the source branch is on a different commit.
+	SourceSHA string `json:"source_sha"`
+
+	Title   string         `json:"title"`
+	Message string         `json:"message"`
+	Files   []ResolvedFile `json:"files"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *ResolveConflictsInput) sanitize() ([]gittypes.MergeConflictResolution, error) {
+	if len(in.Files) == 0 {
+		return nil, usererror.BadRequest("Resolved files are required")
+	}
+
+	resolutions := make([]gittypes.MergeConflictResolution, len(in.Files))
+	for i, file := range in.Files {
+		if file.Path == "" {
+			return nil, usererror.BadRequest("Path of the resolved file is required")
+		}
+
+		var content []byte
+		switch file.Encoding {
+		case enum.ContentEncodingTypeBase64:
+			var err error
+			content, err = base64.StdEncoding.DecodeString(file.Content)
+			if err != nil {
+				return nil, usererror.BadRequestf("Invalid base64 content of the resolved file %q", file.Path)
+			}
+		case enum.ContentEncodingTypeUTF8:
+			fallthrough
+		default:
+			content = []byte(file.Content)
+		}
+
+		resolutions[i] = gittypes.MergeConflictResolution{
+			Path:    file.Path,
+			Content: content,
+			Delete:  file.Delete,
+		}
+	}
+
+	return resolutions, nil
+}
+
+type ResolveConflictsOutput struct {
+	SHA string `json:"sha,omitempty"`
+
+	DryRunRules    bool                   `json:"dry_run_rules,omitempty"`
+	RuleViolations []types.RuleViolations `json:"rule_violations,omitempty"`
+}
+
+// ResolveConflicts merges the target branch into the source branch of the pull request
+// using the provided resolved content of the conflicting files.
+// Every resolution is validated against the actual conflicts before the merge commit is created.
+func (c *Controller) ResolveConflicts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *ResolveConflictsInput,
+) (ResolveConflictsOutput, []types.RuleViolations, error) {
+	resolutions, err := in.sanitize()
+	if err != nil {
+		return ResolveConflictsOutput{}, nil, err
+	}
+
+	targetRepo, sourceRepo, pr, err := c.getSourceBranchUpdateAccess(ctx, session, repoRef, pullreqNum, in.DryRunRules)
+	if err != nil {
+		return ResolveConflictsOutput{}, nil, err
+	}
+
+	signer, err := c.signatureService.Signer(ctx, sourceRepo.ParentID)
+	if err != nil {
+		return ResolveConflictsOutput{}, nil, fmt.Errorf("failed to get commit signer: %w", err)
+	}
+
+	author := identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	committer := identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+
+	title := strings.TrimSpace(in.Title)
+	if title == "" {
+		title = fmt.Sprintf("Merge branch '%s' into %s", pr.TargetBranch, pr.SourceBranch)
+	}
+
+	message := title
+	if in.Message != "" {
+		message += "\n\n" + strings.TrimSpace(in.Message)
+	}
+
+	pushCommit, err := c.sourceBranchMergeCommit(ctx, targetRepo, pr, author, committer, message)
+	if err != nil {
+		return ResolveConflictsOutput{}, nil, err
+	}
+
+	pushFiles := make([]protection.PushFile, len(resolutions))
+	for i, resolution := range resolutions {
+		pushFiles[i] = protection.PushFile{Path: resolution.Path, Size: int64(len(resolution.Content))}
+	}
+
+	violations, err := c.verifySourceBranchUpdate(ctx, session, sourceRepo, pr,
+		protection.NewCommitPushInfo(c.principalStore, *pushCommit, pushFiles, signer != nil), in.BypassRules)
+	if err != nil {
+		return ResolveConflictsOutput{}, nil, err
+	}
+
+	if in.DryRunRules {
+		return ResolveConflictsOutput{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return ResolveConflictsOutput{}, violations, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+	if err != nil {
+		return ResolveConflictsOutput{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	writeParams.Signer = signer
+
+	now := time.Now()
+	out, err := c.git.ResolveConflicts(ctx, &git.ResolveConflictsParams{
+		WriteParams:       writeParams,
+		Branch:            pr.SourceBranch,
+		BranchExpectedSHA: in.SourceSHA,
+		BaseRepoUID:       targetRepo.GitUID,
+		BaseBranch:        pr.TargetBranch,
+		Resolutions:       resolutions,
+		Title:             title,
+		Message:           in.Message,
+		Committer:         committer,
+		CommitterDate:     &now,
+		Author:            author,
+		AuthorDate:        &now,
+	})
+	if err != nil {
+		return ResolveConflictsOutput{}, nil, fmt.Errorf("failed to resolve conflicts: %w", err)
+	}
+
+	c.protectionManager.RecordMonitorViolations(ctx, sourceRepo.ID, session.Principal.ID,
+		enum.RuleViolationActionPush, violations)
+
+	return ResolveConflictsOutput{
+		SHA:            out.CommitSHA,
+		RuleViolations: violations,
+	}, nil, nil
+}
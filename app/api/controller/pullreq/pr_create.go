@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
@@ -53,21 +54,40 @@ func (c *Controller) Create(
 		return nil, usererror.BadRequest("pull request title can't be empty")
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	// Pull requests from a fork require only read access to the target repository,
+	// but the author must be allowed to push to the source repository.
+	targetRepoPermission := enum.PermissionRepoPush
+	if in.SourceRepoRef != "" {
+		targetRepoPermission = enum.PermissionRepoView
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, targetRepoPermission)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access access to target repo: %w", err)
 	}
 
 	sourceRepo := targetRepo
 	if in.SourceRepoRef != "" {
-		sourceRepo, err = c.getRepoCheckAccess(ctx, session, in.SourceRepoRef, enum.PermissionRepoView)
+		sourceRepo, err = c.getRepoCheckAccess(ctx, session, in.SourceRepoRef, enum.PermissionRepoPush)
 		if err != nil {
 			return nil, fmt.Errorf("failed to acquire access access to source repo: %w", err)
 		}
 	}
 
-	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
-		return nil, usererror.BadRequest("target and source branch can't be the same")
+	if sourceRepo.ID == targetRepo.ID {
+		if in.TargetBranch == in.SourceBranch {
+			return nil, usererror.BadRequest("target and source branch can't be the same")
+		}
+
+		if targetRepoPermission != enum.PermissionRepoPush {
+			// source repo ref pointed to the target repo: same repo pull requests require push access.
+			if err = apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo,
+				enum.PermissionRepoPush, false); err != nil {
+				return nil, fmt.Errorf("failed to acquire access access to target repo: %w", err)
+			}
+		}
+	} else if err = c.verifyForkRelation(ctx, sourceRepo, targetRepo); err != nil {
+		return nil, err
 	}
 
 	var sourceSHA string
@@ -84,9 +104,14 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	// all pull request operations (diff, merge, code comments) happen in the target repository.
+	if err = c.fetchSourceCommits(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+		return nil, err
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA,
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
@@ -62,5 +62,13 @@ func (c *Controller) Find(
 		pr.Stats.DiffStats = types.NewDiffStats(output.Commits, output.FilesChanged)
 	}
 
+	if err = c.mergeQueue.Attach(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	if err = c.labelService.Attach(ctx, pr); err != nil {
+		return nil, err
+	}
+
 	return pr, nil
 }
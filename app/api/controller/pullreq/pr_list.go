@@ -74,5 +74,9 @@ func (c *Controller) List(
 		return nil, 0, err
 	}
 
+	if err = c.labelService.Attach(ctx, list...); err != nil {
+		return nil, 0, err
+	}
+
 	return list, count, nil
 }
@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RevertInput struct {
+	// Title is the title of the pull request with the revert.
+	// If not provided, the title is composed from the title of the reverted pull request.
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	// RevertBranch is the name of the new branch containing the revert.
+	// If not provided, the name is composed from the number of the reverted pull request.
+	RevertBranch string `json:"revert_branch"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+// Revert creates a new branch reverting the changes of a merged pull request
+// and opens a new pull request with the branch.
+func (c *Controller) Revert(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *RevertInput,
+) (*types.PullReq, []types.RuleViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to the repository: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || pr.MergeTargetSHA == nil {
+		return nil, nil, usererror.BadRequest("Only merged pull requests can be reverted.")
+	}
+
+	if in.RevertBranch == "" {
+		in.RevertBranch = fmt.Sprintf("revert-pr-%d", pr.Number)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   protection.RefActionCreate,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{in.RevertBranch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	writeParams.Signer, err = c.signatureService.Signer(ctx, repo.ParentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get commit signer: %w", err)
+	}
+
+	// all commits the merge added to the target branch are reverted,
+	// for merge commits the changes are taken relative to the target branch.
+	// The commits are created without creating the branch, so that the push rules can be verified for them.
+	now := time.Now()
+	out, err := c.git.Revert(ctx, &git.PickParams{
+		WriteParams:   writeParams,
+		Revision:      *pr.MergeTargetSHA + ".." + *pr.MergeSHA,
+		Mainline:      1,
+		Branch:        pr.TargetBranch,
+		NewBranch:     in.RevertBranch,
+		SkipRefUpdate: true,
+		Committer:     identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()),
+		CommitterDate: &now,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to revert pull request: %w", err)
+	}
+
+	if len(out.ConflictFiles) > 0 {
+		return nil, nil, usererror.ConflictWithPayload(
+			fmt.Sprintf("Pull request can't be reverted because commit %s conflicts with the target branch.",
+				out.ConflictCommitSHA),
+			map[string]any{
+				"type":                "pick conflict",
+				"conflict_commit_sha": out.ConflictCommitSHA,
+				"conflict_files":      out.ConflictFiles,
+			},
+		)
+	}
+
+	pushViolations, err := protectionRules.PushVerify(ctx, protection.PushVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		BranchName:  in.RevertBranch,
+		Push: protection.NewGitPushInfo(c.git, c.principalStore, c.signatureService,
+			repo, git.CreateReadParams(repo), out.CommitSHA),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify push rules: %w", err)
+	}
+
+	violations = append(violations, pushViolations...)
+
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        in.RevertBranch,
+		OldValue:    types.NilSHA,
+		NewValue:    out.CommitSHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create branch with the revert: %w", err)
+	}
+
+	c.protectionManager.RecordMonitorViolations(ctx, repo.ID, session.Principal.ID,
+		enum.RuleViolationActionPush, violations)
+
+	title := strings.TrimSpace(in.Title)
+	if title == "" {
+		title = fmt.Sprintf("Revert \"%s\"", pr.Title)
+	}
+
+	description := strings.TrimSpace(in.Description)
+	if description == "" {
+		description = fmt.Sprintf("Reverts #%d", pr.Number)
+	}
+
+	revertPR, err := c.Create(ctx, session, repoRef, &CreateInput{
+		Title:        title,
+		Description:  description,
+		SourceBranch: in.RevertBranch,
+		TargetBranch: pr.TargetBranch,
+	})
+	if err != nil {
+		// the branch is useless without the pull request.
+		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+			WriteParams: writeParams,
+			BranchName:  in.RevertBranch,
+		})
+		if errDelete != nil {
+			log.Ctx(ctx).Warn().Err(errDelete).Msgf("failed to delete branch %q with the revert", in.RevertBranch)
+		}
+
+		return nil, nil, fmt.Errorf("failed to create pull request with the revert: %w", err)
+	}
+
+	return revertPR, nil, nil
+}
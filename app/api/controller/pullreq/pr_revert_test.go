@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signature"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeAuthorizer struct {
+	authz.Authorizer
+}
+
+func (fakeAuthorizer) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return true, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	return &types.Repository{
+		ID:            1,
+		ParentID:      1,
+		Identifier:    "repo",
+		Path:          "space/repo",
+		GitUID:        "repo-uid",
+		DefaultBranch: "main",
+	}, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	pr *types.PullReq
+}
+
+func (s fakePullReqStore) FindByNumber(context.Context, int64, int64) (*types.PullReq, error) {
+	return s.pr, nil
+}
+
+type fakeRuleStore struct {
+	store.RuleStore
+	rules []types.RuleInfoInternal
+}
+
+func (s fakeRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	return s.rules, nil
+}
+
+type fakeServerSigningKeyStore struct {
+	store.ServerSigningKeyStore
+}
+
+func (fakeServerSigningKeyStore) List(context.Context) ([]*types.ServerSigningKey, error) {
+	return nil, nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GetInternalAPIURL() string {
+	return "http://localhost:3000"
+}
+
+var errBranchNotFound = errors.New("branch not found")
+
+type fakeRevertGit struct {
+	git.Interface
+	pickParams      *git.PickParams
+	refUpdates      []git.UpdateRefParams
+	deletedBranches []string
+}
+
+func (g *fakeRevertGit) Revert(_ context.Context, params *git.PickParams) (git.PickOutput, error) {
+	g.pickParams = params
+	return git.PickOutput{BranchSHA: testBranchSHA, CommitSHA: testCommitSHA, CommitCount: 1}, nil
+}
+
+func (g *fakeRevertGit) ListNewCommits(context.Context, *git.ListNewCommitsParams) (git.ListNewCommitsOutput, error) {
+	return git.ListNewCommitsOutput{Commits: []git.Commit{{SHA: testCommitSHA, Message: "Revert \"feature\""}}}, nil
+}
+
+func (g *fakeRevertGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	g.refUpdates = append(g.refUpdates, params)
+	return nil
+}
+
+// GetRef fails so that the creation of the pull request with the revert fails.
+func (g *fakeRevertGit) GetRef(context.Context, git.GetRefParams) (git.GetRefResponse, error) {
+	return git.GetRefResponse{}, errBranchNotFound
+}
+
+func (g *fakeRevertGit) DeleteBranch(_ context.Context, params *git.DeleteBranchParams) error {
+	g.deletedBranches = append(g.deletedBranches, params.BranchName)
+	return nil
+}
+
+const (
+	testBranchSHA = "1111111111111111111111111111111111111111"
+	testCommitSHA = "2222222222222222222222222222222222222222"
+)
+
+func TestController_Revert(t *testing.T) {
+	session := &auth.Session{Principal: types.Principal{ID: 2, UID: "user", Type: enum.PrincipalTypeUser}}
+
+	mergeSHA := "3333333333333333333333333333333333333333"
+	mergeTargetSHA := "4444444444444444444444444444444444444444"
+
+	messageRule := func(pattern string) types.RuleInfoInternal {
+		definition, err := protection.ToJSON(&protection.Push{
+			Push: protection.DefPushPolicy{CommitMessagePatterns: []string{pattern}},
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal rule definition: %v", err)
+		}
+
+		return types.RuleInfoInternal{
+			RuleInfo: types.RuleInfo{
+				ID:         1,
+				Identifier: "messages",
+				Type:       protection.TypePush,
+				State:      enum.RuleStateActive,
+			},
+			Pattern:    (&protection.Pattern{Include: []string{"*"}}).JSON(),
+			Definition: definition,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		rules          []types.RuleInfoInternal
+		wantViolations bool
+		wantBranch     bool
+	}{
+		{
+			name:       "branch is deleted when pull request creation fails",
+			wantBranch: true,
+		},
+		{
+			name:           "push rule violated",
+			rules:          []types.RuleInfoInternal{messageRule("^JIRA-[0-9]+")},
+			wantViolations: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bootstrap.SetSystemServicePrincipal(&types.Principal{ID: 1, UID: "gitness", Email: "system@gitness.io"})
+
+			protectionManager, err := protection.ProvideManager(fakeRuleStore{rules: test.rules}, nil)
+			if err != nil {
+				t.Fatalf("failed to create protection manager: %v", err)
+			}
+
+			gitFake := &fakeRevertGit{}
+			c := &Controller{
+				urlProvider: fakeURLProvider{},
+				authorizer:  fakeAuthorizer{},
+				repoStore:   fakeRepoStore{},
+				pullreqStore: fakePullReqStore{pr: &types.PullReq{
+					Number:         1,
+					Title:          "feature",
+					State:          enum.PullReqStateMerged,
+					TargetBranch:   "main",
+					MergeSHA:       &mergeSHA,
+					MergeTargetSHA: &mergeTargetSHA,
+				}},
+				git:               gitFake,
+				protectionManager: protectionManager,
+				signatureService: signature.NewService("gitness", nil, nil, nil, nil, nil,
+					fakeServerSigningKeyStore{}),
+			}
+
+			_, violations, err := c.Revert(context.Background(), session, "space/repo", 1, &RevertInput{})
+			if test.wantViolations {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(violations) == 0 {
+					t.Errorf("expected push rule violations")
+				}
+				if len(gitFake.refUpdates) != 0 {
+					t.Errorf("expected the branch not to be created, got=%+v", gitFake.refUpdates)
+				}
+				return
+			}
+
+			if !errors.Is(err, errBranchNotFound) {
+				t.Fatalf("got=%v want=%v", err, errBranchNotFound)
+			}
+
+			if gitFake.pickParams == nil || !gitFake.pickParams.SkipRefUpdate {
+				t.Errorf("expected the commits to be created without creating the branch")
+			}
+
+			if len(gitFake.refUpdates) != 1 ||
+				gitFake.refUpdates[0].Name != "revert-pr-1" ||
+				gitFake.refUpdates[0].OldValue != types.NilSHA ||
+				gitFake.refUpdates[0].NewValue != testCommitSHA {
+				t.Errorf("expected the branch revert-pr-1 to be created, got=%+v", gitFake.refUpdates)
+			}
+
+			if len(gitFake.deletedBranches) != 1 || gitFake.deletedBranches[0] != "revert-pr-1" {
+				t.Errorf("expected the branch revert-pr-1 to be deleted, got=%v", gitFake.deletedBranches)
+			}
+		})
+	}
+}
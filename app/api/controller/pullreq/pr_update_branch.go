@@ -0,0 +1,299 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateBranchInput struct {
+	// Method is either "merge" (the target branch is merged into the source branch, default)
+	// or "rebase" (the source branch is rebased on top of the target branch).
+	Method enum.MergeMethod `json:"method"`
+
+	// SourceSHA is optional, if provided the update fails if the source branch is on a different commit.
+	SourceSHA string `json:"source_sha"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *UpdateBranchInput) sanitize() error {
+	switch in.Method {
+	case "":
+		in.Method = enum.MergeMethodMerge
+	case enum.MergeMethodMerge, enum.MergeMethodRebase:
+	default:
+		return usererror.BadRequestf("Unsupported update branch method: %s", in.Method)
+	}
+
+	return nil
+}
+
+type UpdateBranchOutput struct {
+	SHA string `json:"sha,omitempty"`
+
+	DryRunRules    bool                   `json:"dry_run_rules,omitempty"`
+	RuleViolations []types.RuleViolations `json:"rule_violations,omitempty"`
+}
+
+// UpdateBranch brings the latest changes of the target branch to the source branch of a pull request.
+// The source branch is updated as if the principal pushed to it, so the push protection rules
+// of the source branch apply and the pull request gets the usual branch update activity.
+func (c *Controller) UpdateBranch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *UpdateBranchInput,
+) (UpdateBranchOutput, []types.RuleViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return UpdateBranchOutput{}, nil, err
+	}
+
+	targetRepo, sourceRepo, pr, err := c.getSourceBranchUpdateAccess(ctx, session, repoRef, pullreqNum, in.DryRunRules)
+	if err != nil {
+		return UpdateBranchOutput{}, nil, err
+	}
+
+	signer, err := c.signatureService.Signer(ctx, sourceRepo.ParentID)
+	if err != nil {
+		return UpdateBranchOutput{}, nil, fmt.Errorf("failed to get commit signer: %w", err)
+	}
+
+	author := identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	committer := identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo())
+	title := fmt.Sprintf("Merge branch '%s' into %s", pr.TargetBranch, pr.SourceBranch)
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+	if err != nil {
+		return UpdateBranchOutput{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	writeParams.Signer = signer
+
+	// The commits are created without updating the branch, so that the push rules can be verified for them.
+	now := time.Now()
+	out, err := c.git.UpdateBranch(ctx, &git.UpdateBranchParams{
+		WriteParams:       writeParams,
+		Branch:            pr.SourceBranch,
+		BranchExpectedSHA: in.SourceSHA,
+		BaseRepoUID:       targetRepo.GitUID,
+		BaseBranch:        pr.TargetBranch,
+		Method:            gitenum.MergeMethod(in.Method),
+		SkipRefUpdate:     true,
+		Title:             title,
+		Committer:         committer,
+		CommitterDate:     &now,
+		Author:            author,
+		AuthorDate:        &now,
+	})
+	if err != nil {
+		return UpdateBranchOutput{}, nil, fmt.Errorf("failed to update source branch: %w", err)
+	}
+
+	if len(out.ConflictFiles) > 0 {
+		return UpdateBranchOutput{}, nil, usererror.ConflictWithPayload(
+			"Source branch can't be updated because of conflicts with the target branch.",
+			map[string]any{
+				"type":           "update branch conflict",
+				"conflict_files": out.ConflictFiles,
+			},
+		)
+	}
+
+	// The merge creates a single merge commit, the rebase recreates the commits of the source branch
+	// on top of the target branch, so all of them are new.
+	var push protection.PushInfo
+	switch in.Method {
+	case enum.MergeMethodRebase:
+		push = protection.NewGitPushInfo(c.git, c.principalStore, c.signatureService,
+			sourceRepo, git.CreateReadParams(sourceRepo), out.CommitSHA, "^"+out.BaseSHA)
+	default:
+		push = protection.NewCommitPushInfo(c.principalStore, protection.PushCommit{
+			SHA:            out.CommitSHA,
+			Message:        title,
+			AuthorEmail:    author.Email,
+			CommitterEmail: committer.Email,
+			ParentSHAs:     []string{out.BranchSHA, out.BaseSHA},
+		}, nil, signer != nil)
+	}
+
+	violations, err := c.verifySourceBranchUpdate(ctx, session, sourceRepo, pr, push, in.BypassRules)
+	if err != nil {
+		return UpdateBranchOutput{}, nil, err
+	}
+
+	if in.DryRunRules {
+		return UpdateBranchOutput{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return UpdateBranchOutput{}, violations, nil
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        pr.SourceBranch,
+		OldValue:    out.BranchSHA,
+		NewValue:    out.CommitSHA,
+	})
+	if err != nil {
+		return UpdateBranchOutput{}, nil, fmt.Errorf("failed to update source branch: %w", err)
+	}
+
+	c.protectionManager.RecordMonitorViolations(ctx, sourceRepo.ID, session.Principal.ID,
+		enum.RuleViolationActionPush, violations)
+
+	return UpdateBranchOutput{
+		SHA:            out.CommitSHA,
+		RuleViolations: violations,
+	}, nil, nil
+}
+
+// getSourceBranchUpdateAccess returns the target and the source repository and the pull request
+// if the principal is allowed to update the source branch of the open pull request.
+func (c *Controller) getSourceBranchUpdateAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	dryRun bool,
+) (*types.Repository, *types.Repository, *types.PullReq, error) {
+	requiredPermission := enum.PermissionRepoPush
+	if dryRun {
+		requiredPermission = enum.PermissionRepoView
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, requiredPermission, false); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to acquire access to source repo: %w", err)
+	}
+
+	return targetRepo, sourceRepo, pr, nil
+}
+
+// sourceBranchMergeCommit returns the merge commit that merges the target branch into the source branch,
+// used to verify the push rules of the source branch before the commit is created.
+func (c *Controller) sourceBranchMergeCommit(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	author *git.Identity,
+	committer *git.Identity,
+	message string,
+) (*protection.PushCommit, error) {
+	targetBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(targetRepo),
+		BranchName: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	return &protection.PushCommit{
+		Message:        message,
+		AuthorEmail:    author.Email,
+		CommitterEmail: committer.Email,
+		ParentSHAs:     []string{pr.SourceSHA, targetBranch.Branch.SHA},
+	}, nil
+}
+
+// verifySourceBranchUpdate verifies the protection rules of the pull request source branch
+// for an update made by the principal, including the push rules for the new commits.
+func (c *Controller) verifySourceBranchUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	push protection.PushInfo,
+	bypassRules bool,
+) ([]types.RuleViolations, error) {
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, sourceRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, sourceRepo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: bypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        sourceRepo,
+		RefAction:   protection.RefActionUpdate,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{pr.SourceBranch},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	pushViolations, err := protectionRules.PushVerify(ctx, protection.PushVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: bypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        sourceRepo,
+		BranchName:  pr.SourceBranch,
+		Push:        push,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify push rules: %w", err)
+	}
+
+	return append(violations, pushViolations...), nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signature"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeUpdateBranchGit struct {
+	git.Interface
+	commits       []git.Commit
+	updateParams  *git.UpdateBranchParams
+	newCommitRevs []string
+	refUpdates    []git.UpdateRefParams
+}
+
+func (g *fakeUpdateBranchGit) UpdateBranch(
+	_ context.Context,
+	params *git.UpdateBranchParams,
+) (git.UpdateBranchOutput, error) {
+	g.updateParams = params
+	return git.UpdateBranchOutput{
+		BranchSHA: testBranchSHA,
+		BaseSHA:   testBaseSHA,
+		CommitSHA: testCommitSHA,
+	}, nil
+}
+
+func (g *fakeUpdateBranchGit) ListNewCommits(
+	_ context.Context,
+	params *git.ListNewCommitsParams,
+) (git.ListNewCommitsOutput, error) {
+	g.newCommitRevs = params.Revisions
+	return git.ListNewCommitsOutput{Commits: g.commits}, nil
+}
+
+func (g *fakeUpdateBranchGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	g.refUpdates = append(g.refUpdates, params)
+	return nil
+}
+
+const testBaseSHA = "5555555555555555555555555555555555555555"
+
+func TestController_UpdateBranch(t *testing.T) {
+	session := &auth.Session{Principal: types.Principal{ID: 2, UID: "user", Type: enum.PrincipalTypeUser}}
+
+	// the rule requires commit messages to reference an issue.
+	definition, err := protection.ToJSON(&protection.Push{
+		Push: protection.DefPushPolicy{CommitMessagePatterns: []string{"^JIRA-[0-9]+"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal rule definition: %v", err)
+	}
+
+	rule := types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			ID:         1,
+			Identifier: "messages",
+			Type:       protection.TypePush,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    (&protection.Pattern{Include: []string{"*"}}).JSON(),
+		Definition: definition,
+	}
+
+	tests := []struct {
+		name           string
+		method         enum.MergeMethod
+		dryRun         bool
+		commits        []git.Commit
+		wantRevs       []string
+		wantViolations bool
+		wantRefUpdate  bool
+	}{
+		{
+			name:           "merge commit violates the rule",
+			method:         enum.MergeMethodMerge,
+			wantViolations: true,
+		},
+		{
+			name:          "rebased commits satisfy the rule",
+			method:        enum.MergeMethodRebase,
+			commits:       []git.Commit{{SHA: testCommitSHA, Message: "JIRA-1 feature"}},
+			wantRevs:      []string{testCommitSHA, "^" + testBaseSHA},
+			wantRefUpdate: true,
+		},
+		{
+			name:           "rebased commits violate the rule",
+			method:         enum.MergeMethodRebase,
+			commits:        []git.Commit{{SHA: testCommitSHA, Message: "feature"}},
+			wantRevs:       []string{testCommitSHA, "^" + testBaseSHA},
+			wantViolations: true,
+		},
+		{
+			name:     "dry run",
+			method:   enum.MergeMethodRebase,
+			dryRun:   true,
+			commits:  []git.Commit{{SHA: testCommitSHA, Message: "feature"}},
+			wantRevs: []string{testCommitSHA, "^" + testBaseSHA},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bootstrap.SetSystemServicePrincipal(&types.Principal{ID: 1, UID: "gitness", Email: "system@gitness.io"})
+
+			protectionManager, err := protection.ProvideManager(fakeRuleStore{rules: []types.RuleInfoInternal{rule}}, nil)
+			if err != nil {
+				t.Fatalf("failed to create protection manager: %v", err)
+			}
+
+			gitFake := &fakeUpdateBranchGit{commits: test.commits}
+			c := &Controller{
+				urlProvider: fakeURLProvider{},
+				authorizer:  fakeAuthorizer{},
+				repoStore:   fakeRepoStore{},
+				pullreqStore: fakePullReqStore{pr: &types.PullReq{
+					Number:       1,
+					State:        enum.PullReqStateOpen,
+					SourceRepoID: 1,
+					TargetRepoID: 1,
+					SourceBranch: "feature",
+					TargetBranch: "main",
+				}},
+				git:               gitFake,
+				protectionManager: protectionManager,
+				signatureService: signature.NewService("gitness", nil, nil, nil, nil, nil,
+					fakeServerSigningKeyStore{}),
+			}
+
+			out, violations, err := c.UpdateBranch(context.Background(), session, "space/repo", 1,
+				&UpdateBranchInput{Method: test.method, DryRunRules: test.dryRun})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if gitFake.updateParams == nil || !gitFake.updateParams.SkipRefUpdate ||
+				gitFake.updateParams.Method != gitenum.MergeMethod(test.method) {
+				t.Errorf("expected the %s without updating the branch, got=%+v", test.method, gitFake.updateParams)
+			}
+
+			if !reflect.DeepEqual(gitFake.newCommitRevs, test.wantRevs) {
+				t.Errorf("new commit revisions: got=%v want=%v", gitFake.newCommitRevs, test.wantRevs)
+			}
+
+			if got := len(violations) > 0; got != test.wantViolations {
+				t.Errorf("violations: got=%v want=%t", violations, test.wantViolations)
+			}
+
+			if test.dryRun && (!out.DryRunRules || len(out.RuleViolations) == 0) {
+				t.Errorf("expected the push rule violations in the dry run output, got=%+v", out)
+			}
+
+			if !test.wantRefUpdate {
+				if len(gitFake.refUpdates) != 0 {
+					t.Errorf("expected no ref update, got=%+v", gitFake.refUpdates)
+				}
+				return
+			}
+
+			want := []git.UpdateRefParams{{
+				Type:     gitenum.RefTypeBranch,
+				Name:     "feature",
+				OldValue: testBranchSHA,
+				NewValue: testCommitSHA,
+			}}
+			got := gitFake.refUpdates
+			for i := range got {
+				got[i].WriteParams = git.WriteParams{}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ref updates: got=%+v want=%+v", got, want)
+			}
+		})
+	}
+}
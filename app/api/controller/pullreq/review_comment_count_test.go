@@ -0,0 +1,331 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+}
+
+func (fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) error {
+	return nil
+}
+
+// fakeCounterPullReqStore keeps a single pull request in memory.
+type fakeCounterPullReqStore struct {
+	store.PullReqStore
+	pr types.PullReq
+}
+
+func (s *fakeCounterPullReqStore) FindByNumber(context.Context, int64, int64) (*types.PullReq, error) {
+	pr := s.pr
+	return &pr, nil
+}
+
+func (s *fakeCounterPullReqStore) Update(_ context.Context, pr *types.PullReq) error {
+	pr.Version++
+	s.pr = *pr
+	return nil
+}
+
+func (s *fakeCounterPullReqStore) UpdateOptLock(
+	ctx context.Context,
+	_ *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	pr := s.pr
+	if err := mutateFn(&pr); err != nil {
+		return nil, err
+	}
+	if err := s.Update(ctx, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+func (s *fakeCounterPullReqStore) UpdateActivitySeq(ctx context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	return s.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++
+		return nil
+	})
+}
+
+// fakeActivityStore keeps pull request activities in memory.
+type fakeActivityStore struct {
+	store.PullReqActivityStore
+	acts []*types.PullReqActivity
+}
+
+func (s *fakeActivityStore) Find(_ context.Context, id int64) (*types.PullReqActivity, error) {
+	for _, act := range s.acts {
+		if act.ID == id {
+			actCopy := *act
+			return &actCopy, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeActivityStore) Create(_ context.Context, act *types.PullReqActivity) error {
+	act.ID = int64(len(s.acts) + 1)
+	actCopy := *act
+	s.acts = append(s.acts, &actCopy)
+	return nil
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	context.Context,
+	*types.PullReq,
+	int64,
+	types.PullReqActivityPayload,
+) (*types.PullReqActivity, error) {
+	return &types.PullReqActivity{}, nil
+}
+
+func (s *fakeActivityStore) Update(_ context.Context, act *types.PullReqActivity) error {
+	for i := range s.acts {
+		if s.acts[i].ID == act.ID {
+			act.Version++
+			actCopy := *act
+			s.acts[i] = &actCopy
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeActivityStore) ListPending(_ context.Context, prID, principalID int64) ([]*types.PullReqActivity, error) {
+	var pending []*types.PullReqActivity
+	for _, act := range s.acts {
+		if act.PullReqID == prID && act.CreatedBy == principalID && act.Pending {
+			actCopy := *act
+			pending = append(pending, &actCopy)
+		}
+	}
+	return pending, nil
+}
+
+func (s *fakeActivityStore) DeletePending(_ context.Context, prID, principalID int64) (int64, error) {
+	var count int64
+	acts := s.acts[:0]
+	for _, act := range s.acts {
+		if act.PullReqID == prID && act.CreatedBy == principalID && act.Pending {
+			count++
+			continue
+		}
+		acts = append(acts, act)
+	}
+	s.acts = acts
+	return count, nil
+}
+
+func (s *fakeActivityStore) CountUnresolved(_ context.Context, prID int64) (int, error) {
+	var count int
+	for _, act := range s.acts {
+		if act.PullReqID == prID && act.IsBlocking() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type fakeReviewStore struct {
+	store.PullReqReviewStore
+}
+
+func (fakeReviewStore) Create(context.Context, *types.PullReqReview) error {
+	return nil
+}
+
+type fakeReviewerStore struct {
+	store.PullReqReviewerStore
+}
+
+func (fakeReviewerStore) Find(context.Context, int64, int64) (*types.PullReqReviewer, error) {
+	return &types.PullReqReviewer{}, nil
+}
+
+func (fakeReviewerStore) Update(context.Context, *types.PullReqReviewer) error {
+	return nil
+}
+
+type fakeReviewGit struct {
+	git.Interface
+}
+
+func (fakeReviewGit) GetCommit(context.Context, *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	return &git.GetCommitOutput{Commit: git.Commit{SHA: testCommitSHA}}, nil
+}
+
+func TestController_PendingCommentCounters(t *testing.T) {
+	const (
+		authorID   = 1
+		reviewerID = 2
+	)
+
+	session := &auth.Session{Principal: types.Principal{ID: reviewerID, UID: "reviewer", Type: enum.PrincipalTypeUser}}
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+
+	eventReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %v", err)
+	}
+
+	type counters struct {
+		comments   int
+		unresolved int
+	}
+
+	tests := []struct {
+		name string
+		// published is the number of comments created before the pending one.
+		published int
+		finish    func(c *Controller) error
+		want      counters
+		resolve   bool
+		wantAfter counters
+	}{
+		{
+			name: "submit",
+			finish: func(c *Controller) error {
+				_, err := c.ReviewSubmit(context.Background(), session, "space/repo", 1, &ReviewSubmitInput{
+					CommitSHA: testCommitSHA,
+					Decision:  enum.PullReqReviewDecisionChangeReq,
+				})
+				return err
+			},
+			want:      counters{comments: 1, unresolved: 1},
+			resolve:   true,
+			wantAfter: counters{comments: 1, unresolved: 0},
+		},
+		{
+			name: "discard",
+			finish: func(c *Controller) error {
+				return c.ReviewDiscard(context.Background(), session, "space/repo", 1)
+			},
+			want: counters{comments: 0, unresolved: 0},
+		},
+		{
+			name:      "discard with published comments",
+			published: 2,
+			finish: func(c *Controller) error {
+				return c.ReviewDiscard(context.Background(), session, "space/repo", 1)
+			},
+			want: counters{comments: 2, unresolved: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			pullreqStore := &fakeCounterPullReqStore{pr: types.PullReq{
+				ID:           1,
+				Number:       1,
+				CreatedBy:    authorID,
+				State:        enum.PullReqStateOpen,
+				SourceRepoID: 1,
+				TargetRepoID: 1,
+			}}
+			activityStore := &fakeActivityStore{}
+
+			c := &Controller{
+				tx:            fakeTx{},
+				authorizer:    fakeAuthorizer{},
+				repoStore:     fakeRepoStore{},
+				pullreqStore:  pullreqStore,
+				activityStore: activityStore,
+				reviewStore:   fakeReviewStore{},
+				reviewerStore: fakeReviewerStore{},
+				git:           fakeReviewGit{},
+				eventReporter: eventReporter,
+				sseStreamer:   fakeStreamer{},
+			}
+
+			for i := 0; i < test.published; i++ {
+				_, err := c.CommentCreate(ctx, session, "space/repo", 1, &CommentCreateInput{Text: "published"})
+				if err != nil {
+					t.Fatalf("failed to create comment: %v", err)
+				}
+			}
+
+			act, err := c.CommentCreate(ctx, session, "space/repo", 1, &CommentCreateInput{
+				Text:    "pending",
+				Pending: true,
+			})
+			if err != nil {
+				t.Fatalf("failed to create pending comment: %v", err)
+			}
+
+			got := counters{comments: pullreqStore.pr.CommentCount, unresolved: pullreqStore.pr.UnresolvedCount}
+			if want := (counters{comments: test.published, unresolved: test.published}); got != want {
+				t.Errorf("counters after the pending comment: got=%+v want=%+v", got, want)
+			}
+
+			if err = test.finish(c); err != nil {
+				t.Fatalf("failed to finish the review: %v", err)
+			}
+
+			got = counters{comments: pullreqStore.pr.CommentCount, unresolved: pullreqStore.pr.UnresolvedCount}
+			if got != test.want {
+				t.Errorf("counters after the review: got=%+v want=%+v", got, test.want)
+			}
+
+			if !test.resolve {
+				return
+			}
+
+			_, err = c.CommentStatus(ctx, session, "space/repo", 1, act.ID, &CommentStatusInput{
+				Status: enum.PullReqCommentStatusResolved,
+			})
+			if err != nil {
+				t.Fatalf("failed to resolve comment: %v", err)
+			}
+
+			got = counters{comments: pullreqStore.pr.CommentCount, unresolved: pullreqStore.pr.UnresolvedCount}
+			if got != test.wantAfter {
+				t.Errorf("counters after resolving the comment: got=%+v want=%+v", got, test.wantAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReviewDiscard discards the review in progress by deleting all pending comments of the principal.
+func (c *Controller) ReviewDiscard(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var pr *types.PullReq
+	var count int64
+
+	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
+		pr, err = c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request by number: %w", err)
+		}
+
+		count, err = c.activityStore.DeletePending(ctx, pr.ID, session.Principal.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete pending comments: %w", err)
+		}
+
+		if count == 0 {
+			return nil
+		}
+
+		// Pending comments are not included in the counters, but the unresolved count is
+		// recalculated here anyway to get it back in sync if it ever drifted.
+		unresolvedCount, err := c.activityStore.CountUnresolved(ctx, pr.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count unresolved comments: %w", err)
+		}
+
+		if pr.UnresolvedCount == unresolvedCount {
+			return nil
+		}
+
+		pr.UnresolvedCount = unresolvedCount
+
+		err = c.pullreqStore.Update(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to update pull request's unresolved comment count: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Debug().Msgf("discarded %d pending comments of pull request %d", count, pr.ID)
+
+	return nil
+}
@@ -91,6 +91,7 @@ func (c *Controller) ReviewSubmit(
 	commitSHA := commit.Commit.SHA
 
 	var review *types.PullReqReview
+	var commentIDs []int64
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
 		now := time.Now().UnixMilli()
@@ -108,10 +109,17 @@ func (c *Controller) ReviewSubmit(
 		if err != nil {
 			return err
 		}
+
+		commentIDs, err = c.publishPendingComments(ctx, session, pr)
+		if err != nil {
+			return err
+		}
+
 		c.eventReporter.ReviewSubmitted(ctx, &events.ReviewSubmittedPayload{
 			Base:       eventBase(pr, &session.Principal),
 			Decision:   review.Decision,
 			ReviewerID: review.CreatedBy,
+			CommentIDs: commentIDs,
 		})
 
 		_, err = c.updateReviewer(ctx, session, pr, review, commitSHA)
@@ -121,6 +129,12 @@ func (c *Controller) ReviewSubmit(
 		return nil, err
 	}
 
+	if len(commentIDs) > 0 {
+		if err = c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+		}
+	}
+
 	err = func() error {
 		if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
 			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
@@ -141,6 +155,56 @@ func (c *Controller) ReviewSubmit(
 	return review, nil
 }
 
+// publishPendingComments makes the pending comments of the principal visible to everyone
+// and updates the comment counters of the pull request. It returns IDs of the published comments.
+func (c *Controller) publishPendingComments(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+) ([]int64, error) {
+	pending, err := c.activityStore.ListPending(ctx, pr.ID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending comments: %w", err)
+	}
+
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	var commentCount, unresolvedCount int
+	commentIDs := make([]int64, 0, len(pending))
+	for _, act := range pending {
+		act.Pending = false
+
+		if err = c.activityStore.Update(ctx, act); err != nil {
+			return nil, fmt.Errorf("failed to publish pending comment: %w", err)
+		}
+
+		if act.Deleted != nil {
+			continue
+		}
+
+		commentIDs = append(commentIDs, act.ID)
+		commentCount++
+		if act.IsBlocking() {
+			unresolvedCount++
+		}
+	}
+
+	prUpd, err := c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.CommentCount += commentCount
+		pr.UnresolvedCount += unresolvedCount
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request comment counters: %w", err)
+	}
+
+	*pr = *prUpd
+
+	return commentIDs, nil
+}
+
 // updateReviewer updates pull request reviewer object.
 func (c *Controller) updateReviewer(ctx context.Context, session *auth.Session,
 	pr *types.PullReq, review *types.PullReqReview, sha string) (*types.PullReqReviewer, error) {
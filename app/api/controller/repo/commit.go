@@ -15,15 +15,20 @@
 package repo
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/secretscan"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
@@ -54,6 +59,10 @@ type CommitFilesOptions struct {
 
 	DryRunRules bool `json:"dry_run_rules"`
 	BypassRules bool `json:"bypass_rules"`
+
+	// SecretsBypassJustification allows committing detected secrets
+	// if the repository blocks secrets. The justification is recorded with the findings.
+	SecretsBypassJustification string `json:"secrets_bypass_justification"`
 }
 
 func (c *Controller) CommitFiles(ctx context.Context,
@@ -84,6 +93,34 @@ func (c *Controller) CommitFiles(ctx context.Context,
 	} else {
 		refAction = protection.RefActionUpdate
 		branchName = in.Branch
+		if branchName == "" {
+			// git commits to the default branch if the branch isn't specified
+			branchName = repo.DefaultBranch
+		}
+	}
+
+	actions := make([]git.CommitFileAction, len(in.Actions))
+	for i, action := range in.Actions {
+		var rawPayload []byte
+		switch action.Encoding {
+		case enum.ContentEncodingTypeBase64:
+			rawPayload, err = base64.StdEncoding.DecodeString(action.Payload)
+			if err != nil {
+				return types.CommitFilesResponse{}, nil, errors.Internal(err, "failed to decode base64 payload")
+			}
+		case enum.ContentEncodingTypeUTF8:
+			fallthrough
+		default:
+			// by default we treat content as is
+			rawPayload = []byte(action.Payload)
+		}
+
+		actions[i] = git.CommitFileAction{
+			Action:  action.Action,
+			Path:    action.Path,
+			Payload: rawPayload,
+			SHA:     action.SHA,
+		}
 	}
 
 	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
@@ -99,10 +136,49 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
+	author := identityFromPrincipal(session.Principal)
+	committer := identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal)
+
+	signer, err := c.signatureService.Signer(ctx, repo.ParentID)
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to get commit signer: %w", err)
+	}
+
+	pushCommit := protection.PushCommit{
+		Message:        commitMessage(in.Title, in.Message),
+		AuthorEmail:    author.Email,
+		CommitterEmail: committer.Email,
+	}
+
+	pushViolations, err := rules.PushVerify(ctx, protection.PushVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		BranchName:  branchName,
+		Push:        protection.NewCommitPushInfo(c.principalStore, pushCommit, pushFiles(actions), signer != nil),
+	})
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify push rules: %w", err)
+	}
+
+	violations = append(violations, pushViolations...)
+
+	secretsBypassJustification, err := secretscan.SanitizeBypassJustification(in.SecretsBypassJustification)
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	secrets, err := c.secretScanService.ScanFiles(ctx, repo, secretScanFiles(actions))
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to scan files for secrets: %w", err)
+	}
+
 	if in.DryRunRules {
 		return types.CommitFilesResponse{
 			DryRunRules:    true,
 			RuleViolations: violations,
+			SecretFindings: secrets.Findings,
 		}, nil, nil
 	}
 
@@ -110,28 +186,9 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return types.CommitFilesResponse{}, violations, nil
 	}
 
-	actions := make([]git.CommitFileAction, len(in.Actions))
-	for i, action := range in.Actions {
-		var rawPayload []byte
-		switch action.Encoding {
-		case enum.ContentEncodingTypeBase64:
-			rawPayload, err = base64.StdEncoding.DecodeString(action.Payload)
-			if err != nil {
-				return types.CommitFilesResponse{}, nil, errors.Internal(err, "failed to decode base64 payload")
-			}
-		case enum.ContentEncodingTypeUTF8:
-			fallthrough
-		default:
-			// by default we treat content as is
-			rawPayload = []byte(action.Payload)
-		}
-
-		actions[i] = git.CommitFileAction{
-			Action:  action.Action,
-			Path:    action.Path,
-			Payload: rawPayload,
-			SHA:     action.SHA,
-		}
+	if secrets.IsBlocked(secretsBypassJustification) {
+		return types.CommitFilesResponse{}, nil, usererror.NewWithPayload(http.StatusUnprocessableEntity,
+			"Blocked by secret scanning.", map[string]any{"secret_findings": secrets.Findings})
 	}
 
 	// Create internal write params. Note: This will skip the pre-commit protection rules check.
@@ -140,6 +197,8 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
 
+	writeParams.Signer = signer
+
 	now := time.Now()
 	commit, err := c.git.CommitFiles(ctx, &git.CommitFilesParams{
 		WriteParams:   writeParams,
@@ -148,18 +207,74 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		Branch:        in.Branch,
 		NewBranch:     in.NewBranch,
 		Actions:       actions,
-		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		Committer:     committer,
 		CommitterDate: &now,
-		Author:        identityFromPrincipal(session.Principal),
+		Author:        author,
 		AuthorDate:    &now,
 	})
 	if err != nil {
 		return types.CommitFilesResponse{}, nil, err
 	}
 
+	c.protectionManager.RecordMonitorViolations(ctx, repo.ID, session.Principal.ID,
+		enum.RuleViolationActionPush, violations)
+
+	c.secretScanService.RecordFindings(ctx, repo.ID, session.Principal.ID, enum.SecretFindingSourceCommit,
+		commit.CommitID, secretsBypassJustification, secrets.Findings)
+
 	return types.CommitFilesResponse{
 		CommitID:       commit.CommitID,
 		RuleViolations: violations,
+		SecretFindings: secrets.Findings,
 		Stats:          commit.Stats,
 	}, nil, nil
 }
+
+// commitMessage returns the message of the commit the same way the git layer composes it.
+func commitMessage(title, message string) string {
+	result := strings.TrimSpace(title)
+	if len(message) > 0 {
+		result += "\n\n" + strings.TrimSpace(message)
+	}
+
+	return result
+}
+
+// pushFiles returns the files changed by the commit actions, used to verify push rules.
+func pushFiles(actions []git.CommitFileAction) []protection.PushFile {
+	files := make([]protection.PushFile, 0, len(actions))
+	for _, action := range actions {
+		switch action.Action {
+		case git.CreateAction, git.UpdateAction:
+			files = append(files, protection.PushFile{Path: action.Path, Size: int64(len(action.Payload))})
+		case git.DeleteAction:
+			files = append(files, protection.PushFile{Path: action.Path})
+		case git.MoveAction:
+			// the payload of the move action is the new path, optionally followed by the new content.
+			newPath, content, _ := bytes.Cut(action.Payload, []byte{0})
+			files = append(files,
+				protection.PushFile{Path: action.Path},
+				protection.PushFile{Path: string(newPath), Size: int64(len(content))})
+		}
+	}
+
+	return files
+}
+
+// secretScanFiles returns the files with new content of the commit actions, used to scan for secrets.
+func secretScanFiles(actions []git.CommitFileAction) []secretscan.File {
+	files := make([]secretscan.File, 0, len(actions))
+	for _, action := range actions {
+		switch action.Action {
+		case git.CreateAction, git.UpdateAction:
+			files = append(files, secretscan.File{Path: action.Path, Content: action.Payload})
+		case git.MoveAction:
+			newPath, content, _ := bytes.Cut(action.Payload, []byte{0})
+			files = append(files, secretscan.File{Path: string(newPath), Content: content})
+		case git.DeleteAction:
+			// deleted files have no content that could contain secrets.
+		}
+	}
+
+	return files
+}
@@ -26,10 +26,15 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/app/services/signature"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
@@ -47,23 +52,29 @@ type Controller struct {
 	defaultBranch                 string
 	publicResourceCreationEnabled bool
 
-	tx                 dbtx.Transactor
-	urlProvider        url.Provider
-	authorizer         authz.Authorizer
-	repoStore          store.RepoStore
-	spaceStore         store.SpaceStore
-	pipelineStore      store.PipelineStore
-	principalStore     store.PrincipalStore
-	ruleStore          store.RuleStore
-	principalInfoCache store.PrincipalInfoCache
-	protectionManager  *protection.Manager
-	git                git.Interface
-	importer           *importer.Repository
-	codeOwners         *codeowners.Service
-	eventReporter      *repoevents.Reporter
-	indexer            keywordsearch.Indexer
-	resourceLimiter    limiter.ResourceLimiter
-	mtxManager         lock.MutexManager
+	tx                dbtx.Transactor
+	urlProvider       url.Provider
+	authorizer        authz.Authorizer
+	repoStore         store.RepoStore
+	spaceStore        store.SpaceStore
+	pipelineStore     store.PipelineStore
+	principalStore    store.PrincipalStore
+	pullreqStore      store.PullReqStore
+	reviewerStore     store.PullReqReviewerStore
+	checkStore        store.CheckStore
+	protectionManager *protection.Manager
+	rulesService      *rules.Service
+	git               git.Interface
+	importer          *importer.Repository
+	codeOwners        *codeowners.Service
+	eventReporter     *repoevents.Reporter
+	indexer           keywordsearch.Indexer
+	resourceLimiter   limiter.ResourceLimiter
+	mtxManager        lock.MutexManager
+	signatureService  *signature.Service
+	labelService      *label.Service
+	secretScanService *secretscan.Service
+	auditService      *audit.Service
 }
 
 func NewController(
@@ -75,9 +86,11 @@ func NewController(
 	spaceStore store.SpaceStore,
 	pipelineStore store.PipelineStore,
 	principalStore store.PrincipalStore,
-	ruleStore store.RuleStore,
-	principalInfoCache store.PrincipalInfoCache,
+	pullreqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	checkStore store.CheckStore,
 	protectionManager *protection.Manager,
+	rulesService *rules.Service,
 	git git.Interface,
 	importer *importer.Repository,
 	codeOwners *codeowners.Service,
@@ -85,6 +98,10 @@ func NewController(
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
 	mtxManager lock.MutexManager,
+	signatureService *signature.Service,
+	labelService *label.Service,
+	secretScanService *secretscan.Service,
+	auditService *audit.Service,
 ) *Controller {
 	return &Controller{
 		defaultBranch:                 config.Git.DefaultBranch,
@@ -96,9 +113,11 @@ func NewController(
 		spaceStore:                    spaceStore,
 		pipelineStore:                 pipelineStore,
 		principalStore:                principalStore,
-		ruleStore:                     ruleStore,
-		principalInfoCache:            principalInfoCache,
+		pullreqStore:                  pullreqStore,
+		reviewerStore:                 reviewerStore,
+		checkStore:                    checkStore,
 		protectionManager:             protectionManager,
+		rulesService:                  rulesService,
 		git:                           git,
 		importer:                      importer,
 		codeOwners:                    codeOwners,
@@ -106,6 +125,10 @@ func NewController(
 		indexer:                       indexer,
 		resourceLimiter:               limiter,
 		mtxManager:                    mtxManager,
+		signatureService:              signatureService,
+		labelService:                  labelService,
+		secretScanService:             secretScanService,
+		auditService:                  auditService,
 	}
 }
 
@@ -164,22 +187,3 @@ func (c *Controller) fetchRules(
 
 	return protectionRules, isRepoOwner, nil
 }
-
-func (c *Controller) getRuleUsers(ctx context.Context, r *types.Rule) (map[int64]*types.PrincipalInfo, error) {
-	rule, err := c.protectionManager.FromJSON(r.Type, r.Definition, false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse json rule definition: %w", err)
-	}
-
-	userIDs, err := rule.UserIDs()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user ID from rule: %w", err)
-	}
-
-	userMap, err := c.principalInfoCache.Map(ctx, userIDs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get principal infos: %w", err)
-	}
-
-	return userMap, nil
-}
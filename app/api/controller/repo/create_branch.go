@@ -88,6 +88,9 @@ func (c *Controller) CreateBranch(ctx context.Context,
 		return nil, nil, err
 	}
 
+	c.protectionManager.RecordMonitorViolations(ctx, repo.ID, session.Principal.ID,
+		enum.RuleViolationActionRefChange, violations)
+
 	branch, err := mapBranch(rpcOut.Branch)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to map branch: %w", err)
@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
@@ -83,19 +84,27 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
 
+	writeParams.Signer, err = c.signatureService.Signer(ctx, repo.ParentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tag signer: %w", err)
+	}
+
 	now := time.Now()
 	rpcOut, err := c.git.CreateCommitTag(ctx, &git.CreateCommitTagParams{
 		WriteParams: writeParams,
 		Name:        in.Name,
 		Target:      in.Target,
 		Message:     in.Message,
-		Tagger:      identityFromPrincipal(session.Principal),
+		Tagger:      identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
 		TaggerDate:  &now,
 	})
 	if err != nil {
 		return nil, nil, err
 	}
 
+	c.protectionManager.RecordMonitorViolations(ctx, repo.ID, session.Principal.ID,
+		enum.RuleViolationActionRefChange, violations)
+
 	commitTag, err := mapCommitTag(rpcOut.CommitTag)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to map tag received from service output: %w", err)
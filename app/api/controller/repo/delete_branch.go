@@ -81,5 +81,8 @@ func (c *Controller) DeleteBranch(ctx context.Context,
 		return nil, err
 	}
 
+	c.protectionManager.RecordMonitorViolations(ctx, repo.ID, session.Principal.ID,
+		enum.RuleViolationActionRefChange, violations)
+
 	return nil, nil
 }
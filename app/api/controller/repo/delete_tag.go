@@ -72,5 +72,8 @@ func (c *Controller) DeleteTag(ctx context.Context,
 		return nil, err
 	}
 
+	c.protectionManager.RecordMonitorViolations(ctx, repo.ID, session.Principal.ID,
+		enum.RuleViolationActionRefChange, violations)
+
 	return nil, nil
 }
@@ -50,5 +50,10 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
+	commit.Verification, err = c.signatureService.VerifyCommit(ctx, repo, &rpcCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signature: %w", err)
+	}
+
 	return commit, nil
 }
@@ -25,13 +25,14 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
-// GitServicePack executes the service pack part of git's smart http protocol (receive-/upload-pack).
+// GitServicePack executes the service pack part of git's smart http and ssh protocol (receive-/upload-pack).
 func (c *Controller) GitServicePack(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	service enum.GitServiceType,
 	gitProtocol string,
+	statelessRPC bool,
 	r io.Reader,
 	w io.Writer,
 ) error {
@@ -50,10 +51,11 @@ func (c *Controller) GitServicePack(
 
 	params := &git.ServicePackParams{
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
-		Service:     string(service),
-		Data:        r,
-		Options:     nil,
-		GitProtocol: gitProtocol,
+		Service:      string(service),
+		Data:         r,
+		Options:      nil,
+		GitProtocol:  gitProtocol,
+		StatelessRPC: statelessRPC,
 	}
 
 	// setup read/writeparams depending on whether it's a write operation
@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/signature"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -33,6 +34,9 @@ type CommitTag struct {
 	Message     string           `json:"message,omitempty"`
 	Tagger      *types.Signature `json:"tagger,omitempty"`
 	Commit      *types.Commit    `json:"commit,omitempty"`
+
+	// Verification is the result of the verification of the tag signature (only for annotated tags).
+	Verification *types.SignatureVerification `json:"verification,omitempty"`
 }
 
 // ListCommitTags lists the commit tags of a repo.
@@ -60,12 +64,19 @@ func (c *Controller) ListCommitTags(ctx context.Context,
 		return nil, err
 	}
 
+	verifier := c.signatureService.NewVerifier(repo)
+
 	tags := make([]CommitTag, len(rpcOut.Tags))
 	for i := range rpcOut.Tags {
 		tags[i], err = mapCommitTag(rpcOut.Tags[i])
 		if err != nil {
 			return nil, fmt.Errorf("failed to map CommitTag: %w", err)
 		}
+
+		err = verifyCommitTag(ctx, verifier, &rpcOut.Tags[i], &tags[i])
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return tags, nil
@@ -85,6 +96,27 @@ func mapToRPCTagSortOption(o enum.TagSortOption) git.TagSortOption {
 	}
 }
 
+// verifyCommitTag sets the verification of the annotated tag and of the commit it points to.
+func verifyCommitTag(ctx context.Context, verifier *signature.Verifier, t *git.CommitTag, tag *CommitTag) error {
+	var err error
+
+	if t.IsAnnotated {
+		tag.Verification, err = verifier.VerifyTag(ctx, t)
+		if err != nil {
+			return fmt.Errorf("failed to verify tag signature: %w", err)
+		}
+	}
+
+	if t.Commit != nil && tag.Commit != nil {
+		tag.Commit.Verification, err = verifier.VerifyCommit(ctx, t.Commit)
+		if err != nil {
+			return fmt.Errorf("failed to verify commit signature: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func mapCommitTag(t git.CommitTag) (CommitTag, error) {
 	var commit *types.Commit
 	if t.Commit != nil {
@@ -27,6 +27,9 @@ import (
 type MergeCheck struct {
 	Mergeable     bool     `json:"mergeable"`
 	ConflictFiles []string `json:"conflict_files,omitempty"`
+
+	// FastForwardPossible is true if the base can be fast-forwarded to the head.
+	FastForwardPossible bool `json:"fast_forward_possible"`
 }
 
 func (c *Controller) MergeCheck(
@@ -61,12 +64,14 @@ func (c *Controller) MergeCheck(
 	}
 	if len(mergeOutput.ConflictFiles) > 0 {
 		return MergeCheck{
-			Mergeable:     false,
-			ConflictFiles: mergeOutput.ConflictFiles,
+			Mergeable:           false,
+			ConflictFiles:       mergeOutput.ConflictFiles,
+			FastForwardPossible: mergeOutput.FastForwardPossible,
 		}, nil
 	}
 
 	return MergeCheck{
-		Mergeable: true,
+		Mergeable:           true,
+		FastForwardPossible: mergeOutput.FastForwardPossible,
 	}, nil
 }
@@ -0,0 +1,222 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PickInput holds the data for the cherry-pick and the revert operations.
+type PickInput struct {
+	// Revision is a commit or a commit range in the "start..end" form.
+	Revision string `json:"revision"`
+	// Mainline is the parent number (starting from 1) of merge commits
+	// the changes of merge commits are taken relative to. The default is 1.
+	Mainline int `json:"mainline"`
+
+	// Branch is the branch the new commits are created on.
+	Branch string `json:"branch"`
+	// NewBranch is optional, if provided the new commits are created on a new branch created from the Branch.
+	NewBranch string `json:"new_branch"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+// PickOutput is the result of the cherry-pick and the revert operations.
+type PickOutput struct {
+	CommitSHA   string `json:"commit_sha,omitempty"`
+	CommitCount int    `json:"commit_count"`
+
+	DryRunRules    bool                   `json:"dry_run_rules,omitempty"`
+	RuleViolations []types.RuleViolations `json:"rule_violations,omitempty"`
+}
+
+// CherryPick applies the changes introduced by a commit (or a commit range) on a branch.
+func (c *Controller) CherryPick(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PickInput,
+) (PickOutput, []types.RuleViolations, error) {
+	return c.pick(ctx, session, repoRef, in, c.git.CherryPick)
+}
+
+// Revert creates commits on a branch reverting the changes introduced by a commit (or a commit range).
+func (c *Controller) Revert(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PickInput,
+) (PickOutput, []types.RuleViolations, error) {
+	return c.pick(ctx, session, repoRef, in, c.git.Revert)
+}
+
+func (c *Controller) pick(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PickInput,
+	pickFn func(ctx context.Context, params *git.PickParams) (git.PickOutput, error),
+) (PickOutput, []types.RuleViolations, error) {
+	if in.Revision == "" {
+		return PickOutput{}, nil, usererror.BadRequest("Revision is required.")
+	}
+	if in.Branch == "" {
+		return PickOutput{}, nil, usererror.BadRequest("Branch is required.")
+	}
+
+	requiredPermission := enum.PermissionRepoPush
+	if in.DryRunRules {
+		requiredPermission = enum.PermissionRepoView
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, requiredPermission, false)
+	if err != nil {
+		return PickOutput{}, nil, err
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return PickOutput{}, nil, err
+	}
+
+	refAction := protection.RefActionUpdate
+	branchName := in.Branch
+	if in.NewBranch != "" {
+		refAction = protection.RefActionCreate
+		branchName = in.NewBranch
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branchName},
+	})
+	if err != nil {
+		return PickOutput{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if !in.DryRunRules && protection.IsCritical(violations) {
+		return PickOutput{}, violations, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return PickOutput{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	writeParams.Signer, err = c.signatureService.Signer(ctx, repo.ParentID)
+	if err != nil {
+		return PickOutput{}, nil, fmt.Errorf("failed to get commit signer: %w", err)
+	}
+
+	// The commits are created without updating the branch, so that the push rules can be verified for them.
+	now := time.Now()
+	out, err := pickFn(ctx, &git.PickParams{
+		WriteParams:   writeParams,
+		Revision:      in.Revision,
+		Mainline:      in.Mainline,
+		Branch:        in.Branch,
+		NewBranch:     in.NewBranch,
+		SkipRefUpdate: true,
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		CommitterDate: &now,
+	})
+	if err != nil {
+		return PickOutput{}, nil, err
+	}
+
+	if len(out.ConflictFiles) > 0 {
+		return PickOutput{}, nil, pickConflictError(out)
+	}
+
+	pushViolations, err := rules.PushVerify(ctx, protection.PushVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		BranchName:  branchName,
+		Push: protection.NewGitPushInfo(c.git, c.principalStore, c.signatureService,
+			repo, git.CreateReadParams(repo), out.CommitSHA),
+	})
+	if err != nil {
+		return PickOutput{}, nil, fmt.Errorf("failed to verify push rules: %w", err)
+	}
+
+	violations = append(violations, pushViolations...)
+
+	if in.DryRunRules {
+		return PickOutput{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return PickOutput{}, violations, nil
+	}
+
+	oldSHA := out.BranchSHA
+	if in.NewBranch != "" {
+		oldSHA = types.NilSHA
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        branchName,
+		OldValue:    oldSHA,
+		NewValue:    out.CommitSHA,
+	})
+	if err != nil {
+		return PickOutput{}, nil, fmt.Errorf("failed to update branch: %w", err)
+	}
+
+	c.protectionManager.RecordMonitorViolations(ctx, repo.ID, session.Principal.ID,
+		enum.RuleViolationActionPush, violations)
+
+	return PickOutput{
+		CommitSHA:      out.CommitSHA,
+		CommitCount:    out.CommitCount,
+		RuleViolations: violations,
+	}, nil, nil
+}
+
+// pickConflictError returns the user facing error for a cherry-pick or a revert that failed because of conflicts.
+func pickConflictError(out git.PickOutput) error {
+	return usererror.ConflictWithPayload(
+		fmt.Sprintf("Commit %s can't be applied because of conflicts.", out.ConflictCommitSHA),
+		map[string]any{
+			"type":                "pick conflict",
+			"conflict_commit_sha": out.ConflictCommitSHA,
+			"conflict_files":      out.ConflictFiles,
+		},
+	)
+}
@@ -0,0 +1,279 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signature"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeAuthorizer struct {
+	authz.Authorizer
+}
+
+func (fakeAuthorizer) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return true, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	return &types.Repository{
+		ID:            1,
+		ParentID:      1,
+		Identifier:    "repo",
+		Path:          "space/repo",
+		GitUID:        "repo-uid",
+		DefaultBranch: "main",
+	}, nil
+}
+
+type fakeRuleStore struct {
+	store.RuleStore
+	rules []types.RuleInfoInternal
+}
+
+func (s fakeRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	return s.rules, nil
+}
+
+type fakeServerSigningKeyStore struct {
+	store.ServerSigningKeyStore
+}
+
+func (fakeServerSigningKeyStore) List(context.Context) ([]*types.ServerSigningKey, error) {
+	return nil, nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GetInternalAPIURL() string {
+	return "http://localhost:3000"
+}
+
+type fakePickGit struct {
+	git.Interface
+	out        git.PickOutput
+	commits    []git.Commit
+	pickParams *git.PickParams
+	refUpdates []git.UpdateRefParams
+}
+
+func (g *fakePickGit) CherryPick(_ context.Context, params *git.PickParams) (git.PickOutput, error) {
+	g.pickParams = params
+	return g.out, nil
+}
+
+func (g *fakePickGit) Revert(_ context.Context, params *git.PickParams) (git.PickOutput, error) {
+	g.pickParams = params
+	return g.out, nil
+}
+
+func (g *fakePickGit) ListNewCommits(context.Context, *git.ListNewCommitsParams) (git.ListNewCommitsOutput, error) {
+	return git.ListNewCommitsOutput{Commits: g.commits}, nil
+}
+
+func (g *fakePickGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	g.refUpdates = append(g.refUpdates, params)
+	return nil
+}
+
+const (
+	testBranchSHA = "1111111111111111111111111111111111111111"
+	testCommitSHA = "2222222222222222222222222222222222222222"
+)
+
+// commitMessageRule returns a push rule that requires the commit messages to match the pattern.
+func commitMessageRule(t *testing.T, pattern string) types.RuleInfoInternal {
+	t.Helper()
+
+	definition, err := protection.ToJSON(&protection.Push{
+		Push: protection.DefPushPolicy{CommitMessagePatterns: []string{pattern}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal rule definition: %v", err)
+	}
+
+	return types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			ID:         1,
+			Identifier: "messages",
+			Type:       protection.TypePush,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    (&protection.Pattern{Default: true}).JSON(),
+		Definition: definition,
+	}
+}
+
+func setupPickController(t *testing.T, gitFake *fakePickGit, rules ...types.RuleInfoInternal) *Controller {
+	t.Helper()
+
+	bootstrap.SetSystemServicePrincipal(&types.Principal{ID: 1, UID: "gitness", Email: "system@gitness.io"})
+
+	protectionManager, err := protection.ProvideManager(fakeRuleStore{rules: rules}, nil)
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %v", err)
+	}
+
+	return &Controller{
+		urlProvider:       fakeURLProvider{},
+		authorizer:        fakeAuthorizer{},
+		repoStore:         fakeRepoStore{},
+		git:               gitFake,
+		protectionManager: protectionManager,
+		signatureService: signature.NewService("gitness", nil, nil, nil, nil, nil,
+			fakeServerSigningKeyStore{}),
+	}
+}
+
+func TestController_CherryPick(t *testing.T) {
+	session := &auth.Session{Principal: types.Principal{ID: 2, UID: "user", Type: enum.PrincipalTypeUser}}
+
+	tests := []struct {
+		name           string
+		in             PickInput
+		out            git.PickOutput
+		rules          []types.RuleInfoInternal
+		wantRefUpdate  *git.UpdateRefParams
+		wantViolations bool
+		wantErr        bool
+	}{
+		{
+			name: "cherry-pick on the branch",
+			in:   PickInput{Revision: "abc", Branch: "main"},
+			out:  git.PickOutput{BranchSHA: testBranchSHA, CommitSHA: testCommitSHA, CommitCount: 1},
+			wantRefUpdate: &git.UpdateRefParams{
+				Name:     "main",
+				OldValue: testBranchSHA,
+				NewValue: testCommitSHA,
+			},
+		},
+		{
+			name: "cherry-pick on a new branch",
+			in:   PickInput{Revision: "abc", Branch: "main", NewBranch: "feature"},
+			out:  git.PickOutput{BranchSHA: testBranchSHA, CommitSHA: testCommitSHA, CommitCount: 1},
+			wantRefUpdate: &git.UpdateRefParams{
+				Name:     "feature",
+				OldValue: types.NilSHA,
+				NewValue: testCommitSHA,
+			},
+		},
+		{
+			name:  "push rule satisfied",
+			in:    PickInput{Revision: "abc", Branch: "main"},
+			out:   git.PickOutput{BranchSHA: testBranchSHA, CommitSHA: testCommitSHA, CommitCount: 1},
+			rules: []types.RuleInfoInternal{commitMessageRule(t, "^picked")},
+			wantRefUpdate: &git.UpdateRefParams{
+				Name:     "main",
+				OldValue: testBranchSHA,
+				NewValue: testCommitSHA,
+			},
+		},
+		{
+			name:           "push rule violated",
+			in:             PickInput{Revision: "abc", Branch: "main"},
+			out:            git.PickOutput{BranchSHA: testBranchSHA, CommitSHA: testCommitSHA, CommitCount: 1},
+			rules:          []types.RuleInfoInternal{commitMessageRule(t, "^JIRA-[0-9]+")},
+			wantViolations: true,
+		},
+		{
+			name:  "dry run",
+			in:    PickInput{Revision: "abc", Branch: "main", DryRunRules: true},
+			out:   git.PickOutput{BranchSHA: testBranchSHA, CommitSHA: testCommitSHA, CommitCount: 1},
+			rules: []types.RuleInfoInternal{commitMessageRule(t, "^JIRA-[0-9]+")},
+		},
+		{
+			name: "conflicts",
+			in:   PickInput{Revision: "abc", Branch: "main"},
+			out: git.PickOutput{
+				BranchSHA:         testBranchSHA,
+				ConflictCommitSHA: testCommitSHA,
+				ConflictFiles:     []string{"file.txt"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitFake := &fakePickGit{
+				out:     test.out,
+				commits: []git.Commit{{SHA: testCommitSHA, Message: "picked commit"}},
+			}
+			c := setupPickController(t, gitFake, test.rules...)
+
+			out, violations, err := c.CherryPick(context.Background(), session, "space/repo", &test.in)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if gitFake.pickParams == nil || !gitFake.pickParams.SkipRefUpdate {
+				t.Errorf("expected the commits to be created without updating the branch")
+			}
+
+			if got := len(violations) > 0; got != test.wantViolations {
+				t.Errorf("violations: got=%v want=%t", violations, test.wantViolations)
+			}
+
+			if test.in.DryRunRules && (!out.DryRunRules || len(out.RuleViolations) == 0) {
+				t.Errorf("expected the push rule violations in the dry run output, got=%+v", out)
+			}
+
+			if test.wantRefUpdate == nil {
+				if len(gitFake.refUpdates) != 0 {
+					t.Errorf("expected no ref update, got=%+v", gitFake.refUpdates)
+				}
+				return
+			}
+
+			if len(gitFake.refUpdates) != 1 {
+				t.Fatalf("expected a single ref update, got=%+v", gitFake.refUpdates)
+			}
+
+			got := gitFake.refUpdates[0]
+			if got.Name != test.wantRefUpdate.Name ||
+				got.OldValue != test.wantRefUpdate.OldValue ||
+				got.NewValue != test.wantRefUpdate.NewValue {
+				t.Errorf("ref update: got=%+v want=%+v", got, test.wantRefUpdate)
+			}
+		})
+	}
+}
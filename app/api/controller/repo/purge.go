@@ -23,8 +23,10 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -59,14 +61,38 @@ func (c *Controller) PurgeNoAuth(
 	session *auth.Session,
 	repo *types.Repository,
 ) error {
+	// forks borrow git objects of the repository, they need their own copy before the repository is removed.
+	if err := c.detachForks(ctx, session, repo); err != nil {
+		return fmt.Errorf("failed to detach forks of the repository: %w", err)
+	}
+
 	if err := c.repoStore.Purge(ctx, repo.ID, repo.Deleted); err != nil {
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
 
+	if repo.ForkID != 0 {
+		c.decrementNumForks(ctx, repo.ForkID)
+	}
+
+	c.auditService.Log(ctx, audit.Event{
+		ActorID:      session.Principal.ID,
+		Action:       enum.AuditActionPurge,
+		ResourceType: enum.AuditResourceTypeRepository,
+		Resource:     repo.Path,
+		SpaceID:      repo.ParentID,
+		RepoID:       repo.ID,
+		Before:       repo,
+	})
+
 	if err := c.deleteGitRepository(ctx, session, repo); err != nil {
 		return fmt.Errorf("failed to delete git repository: %w", err)
 	}
 
+	if err := c.indexer.Delete(ctx, repo.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).
+			Msg("failed to delete keyword search index of repo")
+	}
+
 	c.eventReporter.Deleted(
 		ctx,
 		&repoevents.DeletedPayload{
@@ -105,3 +131,51 @@ func (c *Controller) deleteGitRepository(
 	}
 	return nil
 }
+
+func (c *Controller) detachForks(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) error {
+	forks, err := c.repoStore.ListForks(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, fork)
+		if err != nil {
+			return fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+
+		err = c.git.DetachRepository(ctx, &git.DetachRepositoryParams{
+			WriteParams: writeParams,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to detach fork %s: %w", fork.GitUID, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) decrementNumForks(ctx context.Context, upstreamID int64) {
+	upstream, err := c.repoStore.Find(ctx, upstreamID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find upstream repository")
+		return
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, upstream, func(r *types.Repository) error {
+		if r.NumForks > 0 {
+			r.NumForks--
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update number of forks of the upstream repository")
+	}
+}
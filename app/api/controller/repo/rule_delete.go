@@ -16,13 +16,13 @@ package repo
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types/enum"
 )
 
 // RuleDelete deletes a protection rule by identifier.
+// Rules inherited from the parent spaces can't be deleted.
 func (c *Controller) RuleDelete(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
@@ -33,14 +33,9 @@ func (c *Controller) RuleDelete(ctx context.Context,
 		return err
 	}
 
-	r, err := c.ruleStore.FindByIdentifier(ctx, nil, &repo.ID, identifier)
+	err = c.rulesService.Delete(ctx, session.Principal.ID, nil, &repo.ID, identifier)
 	if err != nil {
-		return fmt.Errorf("failed to find repository-level protection rule by identifier: %w", err)
-	}
-
-	err = c.ruleStore.Delete(ctx, r.ID)
-	if err != nil {
-		return fmt.Errorf("failed to delete repository-level protection rule: %w", err)
+		return c.inheritedRuleError(ctx, repo, identifier, err)
 	}
 
 	return nil
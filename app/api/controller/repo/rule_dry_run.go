@@ -0,0 +1,265 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RuleDryRunInput holds the rule and the operation that is evaluated by the protection rule dry-run.
+// Either an existing rule or a rule draft must be provided,
+// and either a pull request whose merge is evaluated or a ref change.
+type RuleDryRunInput struct {
+	// RuleIdentifier is the identifier of a rule of the repository or of its parent spaces.
+	RuleIdentifier string `json:"rule_identifier"`
+	// Rule is a draft of a rule that isn't stored.
+	Rule *RuleCreateInput `json:"rule"`
+
+	PullReqNumber int64 `json:"pullreq_number"`
+	// MergeMethod is the merge method of the pull request. If empty, the rule is evaluated for all methods.
+	MergeMethod enum.MergeMethod `json:"merge_method"`
+
+	RefChange *RuleDryRunRefChange `json:"ref_change"`
+}
+
+// RuleDryRunRefChange describes a hypothetical change of branches or tags.
+type RuleDryRunRefChange struct {
+	// Type is the type of the refs, either "branch" or "tag".
+	Type string `json:"type"`
+	// Action is the change of the refs, one of "create", "update" or "delete".
+	Action string   `json:"action"`
+	Names  []string `json:"names"`
+}
+
+type RuleDryRunOutput struct {
+	Rule types.RuleInfo `json:"rule"`
+
+	// AllowedMethods and RequiredChecks are set only if a merge of a pull request is evaluated.
+	AllowedMethods []enum.MergeMethod `json:"allowed_methods,omitempty"`
+	RequiredChecks []string           `json:"required_checks,omitempty"`
+
+	RuleViolations []types.RuleViolations `json:"rule_violations"`
+}
+
+var (
+	ruleDryRunRefTypes = map[string]protection.RefType{
+		"branch": protection.RefTypeBranch,
+		"tag":    protection.RefTypeTag,
+	}
+
+	ruleDryRunRefActions = map[string]protection.RefAction{
+		"create": protection.RefActionCreate,
+		"update": protection.RefActionUpdate,
+		"delete": protection.RefActionDelete,
+	}
+)
+
+func (in *RuleDryRunInput) sanitize() error {
+	if (in.RuleIdentifier == "") == (in.Rule == nil) {
+		return usererror.BadRequest("Either an existing rule or a rule draft must be provided.")
+	}
+
+	if (in.PullReqNumber == 0) == (in.RefChange == nil) {
+		return usererror.BadRequest("Either a pull request or a ref change must be provided.")
+	}
+
+	if in.MergeMethod != "" {
+		method, ok := in.MergeMethod.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Unsupported merge method: %s", in.MergeMethod)
+		}
+
+		in.MergeMethod = method
+	}
+
+	if in.RefChange == nil {
+		return nil
+	}
+
+	if _, ok := ruleDryRunRefTypes[in.RefChange.Type]; !ok {
+		return usererror.BadRequestf("Unsupported ref type: %q", in.RefChange.Type)
+	}
+
+	if _, ok := ruleDryRunRefActions[in.RefChange.Action]; !ok {
+		return usererror.BadRequestf("Unsupported ref action: %q", in.RefChange.Action)
+	}
+
+	if len(in.RefChange.Names) == 0 {
+		return usererror.BadRequest("Names of the changed refs must be provided.")
+	}
+
+	return nil
+}
+
+// RuleDryRun evaluates a protection rule without enforcing it. It returns the violations the rule would produce
+// if a pull request would be merged or if a branch or a tag would be changed.
+func (c *Controller) RuleDryRun(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RuleDryRunInput,
+) (*RuleDryRunOutput, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := c.dryRunRule(ctx, repo, in)
+	if err != nil {
+		return nil, err
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	protectionRules := c.protectionManager.ForRules([]types.RuleInfoInternal{rule})
+
+	out := &RuleDryRunOutput{
+		Rule: rule.RuleInfo,
+	}
+
+	if in.RefChange != nil {
+		out.RuleViolations, err = protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+			Actor:       &session.Principal,
+			IsRepoOwner: isRepoOwner,
+			Repo:        repo,
+			RefAction:   ruleDryRunRefActions[in.RefChange.Action],
+			RefType:     ruleDryRunRefTypes[in.RefChange.Type],
+			RefNames:    in.RefChange.Names,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify protection rule: %w", err)
+		}
+	} else {
+		err = c.dryRunMerge(ctx, session, repo, isRepoOwner, protectionRules, in, out)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if out.RuleViolations == nil {
+		out.RuleViolations = []types.RuleViolations{}
+	}
+
+	return out, nil
+}
+
+// dryRunRule returns the rule evaluated by the dry-run, either the rule draft or an existing rule.
+func (c *Controller) dryRunRule(
+	ctx context.Context,
+	repo *types.Repository,
+	in *RuleDryRunInput,
+) (types.RuleInfoInternal, error) {
+	if in.Rule != nil {
+		return c.rulesService.Draft(in.Rule)
+	}
+
+	r, err := c.findRule(ctx, repo, in.RuleIdentifier)
+	if err != nil {
+		return types.RuleInfoInternal{}, err
+	}
+
+	return types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			SpacePath:  r.SpacePath,
+			RepoPath:   r.RepoPath,
+			ID:         r.ID,
+			Identifier: r.Identifier,
+			Type:       r.Type,
+			State:      r.State,
+		},
+		Pattern:    r.Pattern,
+		Definition: r.Definition,
+	}, nil
+}
+
+// dryRunMerge evaluates the rule for merging of the pull request.
+func (c *Controller) dryRunMerge(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	isRepoOwner bool,
+	protectionRules protection.Protection,
+	in *RuleDryRunInput,
+	out *RuleDryRunOutput,
+) error {
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, in.PullReqNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load list of reviewers: %w", err)
+	}
+
+	checkResults, err := c.checkStore.ListResults(ctx, repo.ID, pr.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:        &session.Principal,
+		IsRepoOwner:  isRepoOwner,
+		TargetRepo:   repo,
+		SourceRepo:   sourceRepo,
+		PullReq:      pr,
+		Reviewers:    reviewers,
+		Method:       in.MergeMethod,
+		CheckResults: checkResults,
+		CodeOwners:   codeOwnerWithApproval,
+		Commits: protection.NewGitCommitVerifier(c.git, c.signatureService, repo,
+			git.CreateReadParams(sourceRepo), pr.SourceSHA, pr.MergeBaseSHA),
+		Ancestry: protection.NewGitAncestryChecker(c.git, git.CreateReadParams(repo)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify protection rule: %w", err)
+	}
+
+	out.AllowedMethods = ruleOut.AllowedMethods
+	out.RequiredChecks = ruleOut.RequiredChecks
+	out.RuleViolations = violations
+
+	return nil
+}
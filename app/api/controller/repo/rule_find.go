@@ -16,14 +16,18 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
 // RuleFind returns the protection rule by identifier.
+// If the repository has no such rule, the rule inherited from the parent spaces is returned.
 func (c *Controller) RuleFind(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
@@ -34,15 +38,45 @@ func (c *Controller) RuleFind(ctx context.Context,
 		return nil, err
 	}
 
-	r, err := c.ruleStore.FindByIdentifier(ctx, nil, &repo.ID, identifier)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find repository-level protection rule by identifier: %w", err)
-	}
+	return c.findRule(ctx, repo, identifier)
+}
 
-	r.Users, err = c.getRuleUsers(ctx, r)
+// findRule returns the protection rule of the repository,
+// or if the repository has no such rule, the rule inherited from the parent spaces.
+func (c *Controller) findRule(
+	ctx context.Context,
+	repo *types.Repository,
+	identifier string,
+) (*types.Rule, error) {
+	r, err := c.rulesService.Find(ctx, nil, &repo.ID, identifier)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		r, err = c.rulesService.FindInherited(ctx, repo.ParentID, identifier)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	return r, nil
 }
+
+// inheritedRuleError converts the not found error of a repository-level rule into a forbidden error
+// if a rule with the same identifier is inherited from one of the parent spaces.
+func (c *Controller) inheritedRuleError(
+	ctx context.Context,
+	repo *types.Repository,
+	identifier string,
+	err error,
+) error {
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return err
+	}
+
+	r, errFind := c.rulesService.FindInherited(ctx, repo.ParentID, identifier)
+	if errFind != nil {
+		return err
+	}
+
+	return usererror.Forbidden(fmt.Sprintf(
+		"Protection rule %q is inherited from space %q and can only be changed in that space.",
+		r.Identifier, r.SpacePath))
+}
@@ -16,15 +16,14 @@ package repo
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/harness/gitness/app/auth"
-	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
-// RuleList returns protection rules for a repository.
+// RuleList returns protection rules for a repository,
+// and optionally the rules inherited from the parent spaces.
 func (c *Controller) RuleList(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
@@ -35,37 +34,13 @@ func (c *Controller) RuleList(ctx context.Context,
 		return nil, 0, err
 	}
 
-	var list []types.Rule
-	var count int64
-
-	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
-		list, err = c.ruleStore.List(ctx, nil, &repo.ID, filter)
-		if err != nil {
-			return fmt.Errorf("failed to list repository-level protection rules: %w", err)
-		}
-
-		if filter.Page == 1 && len(list) < filter.Size {
-			count = int64(len(list))
-			return nil
-		}
-
-		count, err = c.ruleStore.Count(ctx, nil, &repo.ID, filter)
-		if err != nil {
-			return fmt.Errorf("failed to count repository-level protection rules: %w", err)
-		}
-
-		return nil
-	}, dbtx.TxDefaultReadOnly)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	for i := range list {
-		list[i].Users, err = c.getRuleUsers(ctx, &list[i])
+	var spaceIDs []int64
+	if filter.Inherited {
+		spaceIDs, err = c.rulesService.SpaceIDs(ctx, repo.ParentID, true)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	return list, count, nil
+	return c.rulesService.List(ctx, spaceIDs, &repo.ID, filter)
 }
@@ -0,0 +1,211 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// fakeScopedRuleStore holds protection rules defined on the spaces and on the repository.
+type fakeScopedRuleStore struct {
+	fakeRuleStore
+	defined []types.Rule
+	deleted []int64
+}
+
+func (s *fakeScopedRuleStore) FindByIdentifier(
+	_ context.Context,
+	spaceID, repoID *int64,
+	identifier string,
+) (*types.Rule, error) {
+	for _, r := range s.defined {
+		if r.Identifier != identifier {
+			continue
+		}
+		if spaceID != nil && r.SpaceID != nil && *spaceID == *r.SpaceID ||
+			repoID != nil && r.RepoID != nil && *repoID == *r.RepoID {
+			return &r, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeScopedRuleStore) Delete(_ context.Context, id int64) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func (fakeRepoStore) Find(ctx context.Context, _ int64) (*types.Repository, error) {
+	return fakeRepoStore{}.FindByRef(ctx, "")
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	return &types.Space{ID: id, Path: "space"}, nil
+}
+
+type fakePrincipalInfoCache struct {
+	store.PrincipalInfoCache
+}
+
+func (fakePrincipalInfoCache) Map(context.Context, []int64) (map[int64]*types.PrincipalInfo, error) {
+	return map[int64]*types.PrincipalInfo{}, nil
+}
+
+type fakeAuditEventStore struct {
+	store.AuditEventStore
+}
+
+func (fakeAuditEventStore) Create(context.Context, *types.AuditEvent) error {
+	return nil
+}
+
+func TestController_InheritedRules(t *testing.T) {
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Type: enum.PrincipalTypeUser}}
+
+	spaceID := int64(1)
+	repoID := int64(1)
+	newRule := func(id int64, identifier string, spaceID, repoID *int64) types.Rule {
+		return types.Rule{
+			ID:         id,
+			SpaceID:    spaceID,
+			RepoID:     repoID,
+			Identifier: identifier,
+			Type:       protection.TypeBranch,
+			State:      enum.RuleStateActive,
+			Definition: json.RawMessage("{}"),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		call        func(c *Controller) (*types.Rule, error)
+		wantID      int64
+		wantStatus  int
+		wantErr     error
+		wantDeleted []int64
+	}{
+		{
+			name: "find own",
+			call: func(c *Controller) (*types.Rule, error) {
+				return c.RuleFind(context.Background(), session, "space/repo", "own")
+			},
+			wantID: 2,
+		},
+		{
+			name: "find inherited",
+			call: func(c *Controller) (*types.Rule, error) {
+				return c.RuleFind(context.Background(), session, "space/repo", "inherited")
+			},
+			wantID: 1,
+		},
+		{
+			name: "update inherited",
+			call: func(c *Controller) (*types.Rule, error) {
+				description := "updated"
+				return c.RuleUpdate(context.Background(), session, "space/repo", "inherited",
+					&RuleUpdateInput{Description: &description})
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "delete inherited",
+			call: func(c *Controller) (*types.Rule, error) {
+				return nil, c.RuleDelete(context.Background(), session, "space/repo", "inherited")
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "delete unknown",
+			call: func(c *Controller) (*types.Rule, error) {
+				return nil, c.RuleDelete(context.Background(), session, "space/repo", "unknown")
+			},
+			wantErr: gitness_store.ErrResourceNotFound,
+		},
+		{
+			name: "delete own",
+			call: func(c *Controller) (*types.Rule, error) {
+				return nil, c.RuleDelete(context.Background(), session, "space/repo", "own")
+			},
+			wantDeleted: []int64{2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ruleStore := &fakeScopedRuleStore{defined: []types.Rule{
+				newRule(1, "inherited", &spaceID, nil),
+				newRule(2, "own", nil, &repoID),
+			}}
+
+			protectionManager, err := protection.ProvideManager(ruleStore, nil)
+			if err != nil {
+				t.Fatalf("failed to create protection manager: %v", err)
+			}
+
+			c := &Controller{
+				authorizer: fakeAuthorizer{},
+				repoStore:  fakeRepoStore{},
+				rulesService: rules.NewService(nil, ruleStore, fakeSpaceStore{}, fakeRepoStore{}, nil,
+					fakePrincipalInfoCache{}, protectionManager, audit.NewService(fakeAuditEventStore{})),
+			}
+
+			r, err := test.call(c)
+
+			var uErr *usererror.Error
+			switch {
+			case test.wantStatus != 0:
+				if !errors.As(err, &uErr) || uErr.Status != test.wantStatus {
+					t.Fatalf("error: got=%v want status=%d", err, test.wantStatus)
+				}
+				return
+			case test.wantErr != nil:
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("error: got=%v want=%v", err, test.wantErr)
+				}
+				return
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if test.wantID != 0 && (r == nil || r.ID != test.wantID) {
+				t.Errorf("rule: got=%+v want ID=%d", r, test.wantID)
+			}
+
+			if !slices.Equal(ruleStore.deleted, test.wantDeleted) {
+				t.Errorf("deleted rules: got=%v want=%v", ruleStore.deleted, test.wantDeleted)
+			}
+		})
+	}
+}
@@ -16,122 +16,31 @@ package repo
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 
-	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
-	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/types"
-	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
 
-type RuleUpdateInput struct {
-	// TODO [CODE-1363]: remove after identifier migration.
-	UID         *string             `json:"uid" deprecated:"true"`
-	Identifier  *string             `json:"identifier"`
-	State       *enum.RuleState     `json:"state"`
-	Description *string             `json:"description"`
-	Pattern     *protection.Pattern `json:"pattern"`
-	Definition  *json.RawMessage    `json:"definition"`
-}
-
-// sanitize validates and sanitizes the update rule input data.
-func (in *RuleUpdateInput) sanitize() error {
-	// TODO [CODE-1363]: remove after identifier migration.
-	if in.Identifier == nil {
-		in.Identifier = in.UID
-	}
-
-	if in.Identifier != nil {
-		if err := check.Identifier(*in.Identifier); err != nil {
-			return err
-		}
-	}
-
-	if in.State != nil {
-		state, ok := in.State.Sanitize()
-		if !ok {
-			return usererror.BadRequest("rule state is invalid")
-		}
-
-		in.State = &state
-	}
-
-	if in.Pattern != nil {
-		if err := in.Pattern.Validate(); err != nil {
-			return usererror.BadRequestf("invalid pattern: %s", err)
-		}
-	}
-
-	if in.Definition != nil && len(*in.Definition) == 0 {
-		return usererror.BadRequest("rule definition missing")
-	}
-
-	return nil
-}
-
-func (in *RuleUpdateInput) isEmpty() bool {
-	return in.Identifier == nil && in.State == nil && in.Description == nil && in.Pattern == nil && in.Definition == nil
-}
+type RuleUpdateInput = rules.UpdateInput
 
 // RuleUpdate updates an existing protection rule for a repository.
+// Rules inherited from the parent spaces can't be updated.
 func (c *Controller) RuleUpdate(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	identifier string,
 	in *RuleUpdateInput,
 ) (*types.Rule, error) {
-	if err := in.sanitize(); err != nil {
-		return nil, err
-	}
-
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
 	if err != nil {
 		return nil, err
 	}
 
-	r, err := c.ruleStore.FindByIdentifier(ctx, nil, &repo.ID, identifier)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get a repository rule by its identifier: %w", err)
-	}
-
-	if in.isEmpty() {
-		r.Users, err = c.getRuleUsers(ctx, r)
-		if err != nil {
-			return nil, err
-		}
-		return r, nil
-	}
-
-	if in.Identifier != nil {
-		r.Identifier = *in.Identifier
-	}
-	if in.State != nil {
-		r.State = *in.State
-	}
-	if in.Description != nil {
-		r.Description = *in.Description
-	}
-	if in.Pattern != nil {
-		r.Pattern = in.Pattern.JSON()
-	}
-	if in.Definition != nil {
-		r.Definition, err = c.protectionManager.SanitizeJSON(r.Type, *in.Definition)
-		if err != nil {
-			return nil, usererror.BadRequestf("invalid rule definition: %s", err.Error())
-		}
-	}
-
-	r.Users, err = c.getRuleUsers(ctx, r)
-	if err != nil {
-		return nil, err
-	}
-
-	err = c.ruleStore.Update(ctx, r)
+	r, err := c.rulesService.Update(ctx, session.Principal.ID, nil, &repo.ID, identifier, in)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update repository-level protection rule: %w", err)
+		return nil, c.inheritedRuleError(ctx, repo, identifier, err)
 	}
 
 	return r, nil
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RuleViolationList returns the violations of a protection rule in monitor state
+// that have been recorded in the repository. The rule can be inherited from the parent spaces.
+func (c *Controller) RuleViolationList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	identifier string,
+	filter *types.RuleMonitorViolationFilter,
+) ([]*types.RuleMonitorViolation, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r, err := c.findRule(ctx, repo, identifier)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.rulesService.ListMonitorViolations(ctx, r.ID, repo.ID, filter)
+}
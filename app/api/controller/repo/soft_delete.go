@@ -22,6 +22,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -58,7 +59,24 @@ func (c *Controller) SoftDelete(ctx context.Context, session *auth.Session, repo
 		return c.PurgeNoAuth(ctx, session, repo)
 	}
 
-	return c.SoftDeleteNoAuth(ctx, repo, time.Now().UnixMilli())
+	repoBefore := *repo
+
+	if err = c.SoftDeleteNoAuth(ctx, repo, time.Now().UnixMilli()); err != nil {
+		return err
+	}
+
+	c.auditService.Log(ctx, audit.Event{
+		ActorID:      session.Principal.ID,
+		Action:       enum.AuditActionDelete,
+		ResourceType: enum.AuditResourceTypeRepository,
+		Resource:     repo.Path,
+		SpaceID:      repo.ParentID,
+		RepoID:       repo.ID,
+		Before:       repoBefore,
+		After:        repo,
+	})
+
+	return nil
 }
 
 func (c *Controller) SoftDeleteNoAuth(
@@ -18,10 +18,15 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/app/services/signature"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
@@ -46,9 +51,11 @@ func ProvideController(
 	spaceStore store.SpaceStore,
 	pipelineStore store.PipelineStore,
 	principalStore store.PrincipalStore,
-	ruleStore store.RuleStore,
-	principalInfoCache store.PrincipalInfoCache,
+	pullreqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	checkStore store.CheckStore,
 	protectionManager *protection.Manager,
+	rulesService *rules.Service,
 	rpcClient git.Interface,
 	importer *importer.Repository,
 	codeOwners *codeowners.Service,
@@ -56,10 +63,15 @@ func ProvideController(
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
 	mtxManager lock.MutexManager,
+	signatureService *signature.Service,
+	labelService *label.Service,
+	secretScanService *secretscan.Service,
+	auditService *audit.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer, repoStore,
 		spaceStore, pipelineStore,
-		principalStore, ruleStore, principalInfoCache, protectionManager,
-		rpcClient, importer, codeOwners, reporeporter, indexer, limiter, mtxManager)
+		principalStore, pullreqStore, reviewerStore, checkStore, protectionManager, rulesService,
+		rpcClient, importer, codeOwners, reporeporter, indexer, limiter, mtxManager, signatureService,
+		labelService, secretScanService, auditService)
 }
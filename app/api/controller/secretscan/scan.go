@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// StartScan starts a scan of the whole history of a repository for secrets.
+func (c *Controller) StartScan(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.SecretScan, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.secretScanService.StartScan(ctx, session.Principal.ID, repo)
+}
+
+// FindScan returns a secret scan of a repository.
+func (c *Controller) FindScan(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	scanID int64,
+) (*types.SecretScan, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.secretScanService.FindScan(ctx, repo.ID, scanID)
+}
+
+// ListScans lists the secret scans of a repository.
+func (c *Controller) ListScans(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pagination types.Pagination,
+) ([]*types.SecretScan, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.secretScanService.ListScans(ctx, repo.ID, pagination)
+}
+
+// ListFindings lists the secret findings of a repository.
+func (c *Controller) ListFindings(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.SecretFindingFilter,
+) ([]*types.SecretFinding, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.secretScanService.ListFindings(ctx, repo.ID, filter)
+}
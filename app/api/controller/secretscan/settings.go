@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+// RepoSettingsOutput holds the secret scanning settings of a repository.
+type RepoSettingsOutput struct {
+	// Settings are the settings of the repository. They're nil if the repository inherits all settings.
+	Settings *types.SecretScanSettings `json:"settings"`
+
+	// Effective is the configuration used to scan the repository,
+	// including the settings inherited from the parent spaces and the built-in rules.
+	Effective types.SecretScanConfig `json:"effective"`
+}
+
+// FindRepoSettings returns the secret scanning settings of a repository.
+func (c *Controller) FindRepoSettings(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*RepoSettingsOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := c.secretScanService.FindSettings(ctx, nil, &repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	effective, err := c.secretScanService.EffectiveConfig(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get effective secret scan config: %w", err)
+	}
+
+	return &RepoSettingsOutput{
+		Settings:  settings,
+		Effective: effective,
+	}, nil
+}
+
+// UpdateRepoSettings creates or replaces the secret scanning settings of a repository.
+func (c *Controller) UpdateRepoSettings(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *secretscan.SettingsInput,
+) (*types.SecretScanSettings, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.secretScanService.UpdateSettings(ctx, session.Principal.ID, nil, ptr.Int64(repo.ID), in)
+}
+
+// DeleteRepoSettings deletes the secret scanning settings of a repository.
+func (c *Controller) DeleteRepoSettings(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	return c.secretScanService.DeleteSettings(ctx, nil, &repo.ID)
+}
+
+// FindSpaceSettings returns the secret scanning settings of a space.
+// It returns nil if the space inherits all settings.
+func (c *Controller) FindSpaceSettings(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SecretScanSettings, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.secretScanService.FindSettings(ctx, &space.ID, nil)
+}
+
+// UpdateSpaceSettings creates or replaces the secret scanning settings of a space.
+func (c *Controller) UpdateSpaceSettings(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *secretscan.SettingsInput,
+) (*types.SecretScanSettings, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.secretScanService.UpdateSettings(ctx, session.Principal.ID, ptr.Int64(space.ID), nil, in)
+}
+
+// DeleteSpaceSettings deletes the secret scanning settings of a space.
+func (c *Controller) DeleteSpaceSettings(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	return c.secretScanService.DeleteSettings(ctx, &space.ID, nil)
+}
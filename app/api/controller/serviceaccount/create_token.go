@@ -21,6 +21,7 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -68,6 +69,23 @@ func (c *Controller) CreateToken(
 		return nil, err
 	}
 
+	event := audit.Event{
+		ActorID:      session.Principal.ID,
+		Action:       enum.AuditActionCreate,
+		ResourceType: enum.AuditResourceTypeToken,
+		Resource:     sa.UID + "/" + token.Identifier,
+		After:        token,
+	}
+
+	switch sa.ParentType {
+	case enum.ParentResourceTypeSpace:
+		event.SpaceID = sa.ParentID
+	case enum.ParentResourceTypeRepo:
+		event.RepoID = sa.ParentID
+	}
+
+	c.auditService.Log(ctx, event)
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LabelDefineInput struct {
+	Key string `json:"key"`
+	// Value makes the label a scoped label. Only one scoped label with the same key can be assigned to a pull request.
+	Value       string `json:"value"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type LabelUpdateInput struct {
+	Key         *string `json:"key"`
+	Value       *string `json:"value"`
+	Color       *string `json:"color"`
+	Description *string `json:"description"`
+}
+
+// LabelDefine defines a new label in the space.
+func (c *Controller) LabelDefine(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *LabelDefineInput,
+) (*types.Label, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.labelService.Define(ctx, space.ID, session.Principal.ID, in.Key, in.Value, in.Color, in.Description)
+}
+
+// LabelList lists the labels defined in the space, and optionally the labels inherited from the parent spaces.
+func (c *Controller) LabelList(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.LabelFilter,
+) ([]*types.Label, int64, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.labelService.List(ctx, space.ID, filter)
+}
+
+// LabelUpdate updates a label defined in the space.
+func (c *Controller) LabelUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	labelID int64,
+	in *LabelUpdateInput,
+) (*types.Label, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	lbl, err := c.labelService.Find(ctx, space.ID, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	return c.labelService.Update(ctx, lbl, in.Key, in.Value, in.Color, in.Description)
+}
+
+// LabelDelete deletes a label defined in the space. The label is removed from all pull requests.
+func (c *Controller) LabelDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	labelID int64,
+) error {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	lbl, err := c.labelService.Find(ctx, space.ID, labelID)
+	if err != nil {
+		return fmt.Errorf("failed to find label: %w", err)
+	}
+
+	return c.labelService.Delete(ctx, lbl)
+}
+
+// getSpaceCheckAuth fetches the space and checks if the user has the required permission on it.
+func (c *Controller) getSpaceCheckAuth(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+		return nil, fmt.Errorf("auth check failed: %w", err)
+	}
+
+	return space, nil
+}
@@ -22,6 +22,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -100,6 +101,15 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, fmt.Errorf("failed to create new membership: %w", err)
 	}
 
+	c.auditService.Log(ctx, audit.Event{
+		ActorID:      session.Principal.ID,
+		Action:       enum.AuditActionCreate,
+		ResourceType: enum.AuditResourceTypeSpaceMembership,
+		Resource:     user.UID,
+		SpaceID:      space.ID,
+		After:        membership,
+	})
+
 	result := &types.MembershipUser{
 		Membership: membership,
 		Principal:  *user.ToPrincipalInfo(),
@@ -20,6 +20,7 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -44,13 +45,29 @@ func (c *Controller) MembershipDelete(ctx context.Context,
 		return fmt.Errorf("failed to find user by uid: %w", err)
 	}
 
-	err = c.membershipStore.Delete(ctx, types.MembershipKey{
+	key := types.MembershipKey{
 		SpaceID:     space.ID,
 		PrincipalID: user.ID,
-	})
+	}
+
+	membership, err := c.membershipStore.FindUser(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to find membership for delete: %w", err)
+	}
+
+	err = c.membershipStore.Delete(ctx, key)
 	if err != nil {
 		return fmt.Errorf("failed to delete user membership: %w", err)
 	}
 
+	c.auditService.Log(ctx, audit.Event{
+		ActorID:      session.Principal.ID,
+		Action:       enum.AuditActionDelete,
+		ResourceType: enum.AuditResourceTypeSpaceMembership,
+		Resource:     user.UID,
+		SpaceID:      space.ID,
+		Before:       membership.Membership,
+	})
+
 	return nil
 }
@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RuleCreateInput = rules.CreateInput
+
+type RuleUpdateInput = rules.UpdateInput
+
+// RuleCreate creates a new protection rule for a space.
+// The rule applies to all repositories in the space and in its subspaces.
+func (c *Controller) RuleCreate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *RuleCreateInput,
+) (*types.Rule, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.rulesService.Create(ctx, session.Principal.ID, &space.ID, nil, in)
+}
+
+// RuleList returns protection rules of a space, and optionally the rules inherited from the parent spaces.
+func (c *Controller) RuleList(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.RuleFilter,
+) ([]types.Rule, int64, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	spaceIDs, err := c.rulesService.SpaceIDs(ctx, space.ID, filter.Inherited)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c.rulesService.List(ctx, spaceIDs, nil, filter)
+}
+
+// RuleFind returns a protection rule of a space by identifier.
+func (c *Controller) RuleFind(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) (*types.Rule, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.rulesService.Find(ctx, &space.ID, nil, identifier)
+}
+
+// RuleUpdate updates an existing protection rule of a space.
+func (c *Controller) RuleUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *RuleUpdateInput,
+) (*types.Rule, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.rulesService.Update(ctx, session.Principal.ID, &space.ID, nil, identifier, in)
+}
+
+// RuleDelete deletes a protection rule of a space by identifier.
+func (c *Controller) RuleDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) error {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	return c.rulesService.Delete(ctx, session.Principal.ID, &space.ID, nil, identifier)
+}
@@ -0,0 +1,308 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// fakeAuthorizer grants only the allowed permissions and records all checked ones.
+type fakeAuthorizer struct {
+	authz.Authorizer
+	allowed []enum.Permission
+	checked []enum.Permission
+}
+
+func (a *fakeAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	a.checked = append(a.checked, permission)
+	return slices.Contains(a.allowed, permission), nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+// fakeSpaceStore holds a chain of spaces: root (1) -> child (2) -> leaf (3).
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+var testSpaces = map[int64]*types.Space{
+	1: {ID: 1, Identifier: "root", Path: "root"},
+	2: {ID: 2, ParentID: 1, Identifier: "child", Path: "root/child"},
+	3: {ID: 3, ParentID: 2, Identifier: "leaf", Path: "root/child/leaf"},
+}
+
+func (fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	space, ok := testSpaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return space, nil
+}
+
+func (fakeSpaceStore) FindByRef(_ context.Context, spaceRef string) (*types.Space, error) {
+	for _, space := range testSpaces {
+		if space.Path == spaceRef {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// fakeRuleStore keeps the space-level protection rules in memory.
+type fakeRuleStore struct {
+	store.RuleStore
+	rules        []types.Rule
+	listSpaceIDs []int64
+}
+
+func (s *fakeRuleStore) FindByIdentifier(
+	_ context.Context,
+	spaceID, _ *int64,
+	identifier string,
+) (*types.Rule, error) {
+	for _, r := range s.rules {
+		if spaceID != nil && r.SpaceID != nil && *spaceID == *r.SpaceID && r.Identifier == identifier {
+			return &r, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeRuleStore) Create(_ context.Context, r *types.Rule) error {
+	r.ID = int64(len(s.rules) + 1)
+	s.rules = append(s.rules, *r)
+	return nil
+}
+
+func (s *fakeRuleStore) Update(_ context.Context, r *types.Rule) error {
+	for i := range s.rules {
+		if s.rules[i].ID == r.ID {
+			s.rules[i] = *r
+		}
+	}
+	return nil
+}
+
+func (s *fakeRuleStore) Delete(_ context.Context, id int64) error {
+	for i := range s.rules {
+		if s.rules[i].ID == id {
+			s.rules = append(s.rules[:i], s.rules[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *fakeRuleStore) List(
+	_ context.Context,
+	spaceIDs []int64,
+	_ *int64,
+	_ *types.RuleFilter,
+) ([]types.Rule, error) {
+	s.listSpaceIDs = spaceIDs
+
+	var list []types.Rule
+	for _, r := range s.rules {
+		if r.SpaceID != nil && slices.Contains(spaceIDs, *r.SpaceID) {
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+type fakePrincipalInfoCache struct {
+	store.PrincipalInfoCache
+}
+
+func (fakePrincipalInfoCache) Map(context.Context, []int64) (map[int64]*types.PrincipalInfo, error) {
+	return map[int64]*types.PrincipalInfo{}, nil
+}
+
+type fakeAuditEventStore struct {
+	store.AuditEventStore
+}
+
+func (fakeAuditEventStore) Create(context.Context, *types.AuditEvent) error {
+	return nil
+}
+
+func newRuleTestController(t *testing.T, allowed ...enum.Permission) (*Controller, *fakeRuleStore, *fakeAuthorizer) {
+	t.Helper()
+
+	parentID := int64(1)
+	ruleStore := &fakeRuleStore{rules: []types.Rule{{
+		ID:         1,
+		SpaceID:    &parentID,
+		Identifier: "root-rule",
+		Type:       protection.TypeBranch,
+		State:      enum.RuleStateActive,
+		Definition: json.RawMessage("{}"),
+	}}}
+
+	protectionManager, err := protection.ProvideManager(ruleStore, nil)
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %v", err)
+	}
+
+	authorizer := &fakeAuthorizer{allowed: allowed}
+
+	c := &Controller{
+		authorizer: authorizer,
+		spaceStore: fakeSpaceStore{},
+		rulesService: rules.NewService(fakeTx{}, ruleStore, fakeSpaceStore{}, nil, nil,
+			fakePrincipalInfoCache{}, protectionManager, audit.NewService(fakeAuditEventStore{})),
+	}
+
+	return c, ruleStore, authorizer
+}
+
+func TestController_Rules(t *testing.T) {
+	ctx := context.Background()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Type: enum.PrincipalTypeUser}}
+
+	c, ruleStore, authorizer := newRuleTestController(t, enum.PermissionSpaceView, enum.PermissionSpaceEdit)
+
+	r, err := c.RuleCreate(ctx, session, "root/child/leaf", &RuleCreateInput{
+		Identifier: "leaf-rule",
+		State:      enum.RuleStateActive,
+		Pattern:    protection.Pattern{Default: true},
+		Definition: json.RawMessage("{}"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create rule: %v", err)
+	}
+
+	if r.SpaceID == nil || *r.SpaceID != 3 || r.RepoID != nil || r.SpacePath != "root/child/leaf" {
+		t.Errorf("created rule: got=%v/%v/%s want=3/nil/root/child/leaf", r.SpaceID, r.RepoID, r.SpacePath)
+	}
+
+	for _, inherited := range []bool{false, true} {
+		list, count, err := c.RuleList(ctx, session, "root/child/leaf", &types.RuleFilter{
+			ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 10}},
+			Inherited:       inherited,
+		})
+		if err != nil {
+			t.Fatalf("failed to list rules: %v", err)
+		}
+
+		wantSpaceIDs, wantCount := []int64{3}, int64(1)
+		if inherited {
+			wantSpaceIDs, wantCount = []int64{3, 2, 1}, 2
+		}
+
+		if !slices.Equal(ruleStore.listSpaceIDs, wantSpaceIDs) || count != wantCount || int64(len(list)) != count {
+			t.Errorf("list inherited=%t: got=%v (count=%d) want=%v (count=%d)",
+				inherited, ruleStore.listSpaceIDs, count, wantSpaceIDs, wantCount)
+		}
+	}
+
+	if _, err = c.RuleFind(ctx, session, "root/child/leaf", "root-rule"); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("find of a parent space rule: got=%v want=%v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	description := "updated"
+	r, err = c.RuleUpdate(ctx, session, "root/child/leaf", "leaf-rule", &RuleUpdateInput{Description: &description})
+	if err != nil {
+		t.Fatalf("failed to update rule: %v", err)
+	}
+
+	if r.Description != description {
+		t.Errorf("description: got=%s want=%s", r.Description, description)
+	}
+
+	if r, err = c.RuleFind(ctx, session, "root", "root-rule"); err != nil || r.ID != 1 {
+		t.Errorf("find: got=%+v, %v want ID=1", r, err)
+	}
+
+	if err = c.RuleDelete(ctx, session, "root/child/leaf", "leaf-rule"); err != nil {
+		t.Fatalf("failed to delete rule: %v", err)
+	}
+
+	if len(ruleStore.rules) != 1 || ruleStore.rules[0].Identifier != "root-rule" {
+		t.Errorf("rules after delete: got=%v", ruleStore.rules)
+	}
+
+	wantChecked := []enum.Permission{
+		enum.PermissionSpaceEdit, // create
+		enum.PermissionSpaceView, // list
+		enum.PermissionSpaceView, // list inherited
+		enum.PermissionSpaceView, // find
+		enum.PermissionSpaceEdit, // update
+		enum.PermissionSpaceView, // find
+		enum.PermissionSpaceEdit, // delete
+	}
+	if !slices.Equal(authorizer.checked, wantChecked) {
+		t.Errorf("checked permissions: got=%v want=%v", authorizer.checked, wantChecked)
+	}
+}
+
+func TestController_Rules_ViewOnly(t *testing.T) {
+	ctx := context.Background()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Type: enum.PrincipalTypeUser}}
+
+	c, ruleStore, _ := newRuleTestController(t, enum.PermissionSpaceView)
+
+	if _, err := c.RuleFind(ctx, session, "root", "root-rule"); err != nil {
+		t.Errorf("failed to find rule: %v", err)
+	}
+
+	_, err := c.RuleCreate(ctx, session, "root", &RuleCreateInput{
+		Identifier: "rule",
+		Definition: json.RawMessage("{}"),
+	})
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Errorf("create: got=%v want=%v", err, apiauth.ErrNotAuthorized)
+	}
+
+	description := "updated"
+	_, err = c.RuleUpdate(ctx, session, "root", "root-rule", &RuleUpdateInput{Description: &description})
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Errorf("update: got=%v want=%v", err, apiauth.ErrNotAuthorized)
+	}
+
+	if err = c.RuleDelete(ctx, session, "root", "root-rule"); !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Errorf("delete: got=%v want=%v", err, apiauth.ErrNotAuthorized)
+	}
+
+	if len(ruleStore.rules) != 1 || ruleStore.rules[0].Description != "" {
+		t.Errorf("rules must not change: got=%v", ruleStore.rules)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/signature"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type SigningKeySetInput struct {
+	// PrivateKey is an armored GPG private key or an SSH private key in the OpenSSH format.
+	PrivateKey string `json:"private_key"`
+}
+
+// SigningKeyFind returns the key the server uses to sign commits and tags in repositories of the space.
+func (c *Controller) SigningKeyFind(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.ServerSigningKey, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	return c.signatureService.FindServerKey(ctx, &space.ID)
+}
+
+// SigningKeySet sets the key the server uses to sign commits and tags in repositories of the space.
+func (c *Controller) SigningKeySet(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *SigningKeySetInput,
+) (*types.ServerSigningKey, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	parsed, err := signature.ParsePrivateKey(in.PrivateKey)
+	if err != nil {
+		return nil, usererror.BadRequest(err.Error())
+	}
+
+	key, err := c.signatureService.SetServerKey(ctx, &space.ID, session.Principal.ID, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set space signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+// SigningKeyDelete deletes the key the server uses to sign commits and tags in repositories of the space.
+func (c *Controller) SigningKeyDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return err
+	}
+
+	return c.signatureService.DeleteServerKey(ctx, &space.ID)
+}
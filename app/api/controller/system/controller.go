@@ -17,19 +17,26 @@ package system
 import (
 	"context"
 
+	"github.com/harness/gitness/app/services/signature"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 )
 
 type Controller struct {
-	principalStore store.PrincipalStore
-	config         *types.Config
+	principalStore   store.PrincipalStore
+	signatureService *signature.Service
+	config           *types.Config
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+func NewController(
+	principalStore store.PrincipalStore,
+	signatureService *signature.Service,
+	config *types.Config,
+) *Controller {
 	return &Controller{
-		principalStore: principalStore,
-		config:         config,
+		principalStore:   principalStore,
+		signatureService: signatureService,
+		config:           config,
 	}
 }
 
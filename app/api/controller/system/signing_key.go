@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/signature"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type SigningKeySetInput struct {
+	// PrivateKey is an armored GPG private key or an SSH private key in the OpenSSH format.
+	PrivateKey string `json:"private_key"`
+}
+
+// SigningKey returns the instance-level key the server uses to sign commits and tags.
+// It returns nil if no signing key is configured.
+func (c *Controller) SigningKey(ctx context.Context) (*types.ServerSigningKey, error) {
+	key, err := c.signatureService.FindServerKey(ctx, nil)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil // no signing key is configured
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find server signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+// SigningKeySet sets the instance-level key the server uses to sign commits and tags.
+func (c *Controller) SigningKeySet(
+	ctx context.Context,
+	session *auth.Session,
+	in *SigningKeySetInput,
+) (*types.ServerSigningKey, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	parsed, err := signature.ParsePrivateKey(in.PrivateKey)
+	if err != nil {
+		return nil, usererror.BadRequest(err.Error())
+	}
+
+	key, err := c.signatureService.SetServerKey(ctx, nil, session.Principal.ID, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set server signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+// SigningKeyDelete deletes the instance-level key the server uses to sign commits and tags.
+func (c *Controller) SigningKeyDelete(ctx context.Context, session *auth.Session) error {
+	if !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+
+	return c.signatureService.DeleteServerKey(ctx, nil)
+}
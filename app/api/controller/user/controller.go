@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -34,6 +35,9 @@ type Controller struct {
 	principalStore    store.PrincipalStore
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
+	publicKeyStore    store.PublicKeyStore
+	signingKeyStore   store.SigningKeyStore
+	auditService      *audit.Service
 }
 
 func NewController(
@@ -43,6 +47,9 @@ func NewController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	publicKeyStore store.PublicKeyStore,
+	signingKeyStore store.SigningKeyStore,
+	auditService *audit.Service,
 ) *Controller {
 	return &Controller{
 		tx:                tx,
@@ -51,6 +58,9 @@ func NewController(
 		principalStore:    principalStore,
 		tokenStore:        tokenStore,
 		membershipStore:   membershipStore,
+		publicKeyStore:    publicKeyStore,
+		signingKeyStore:   signingKeyStore,
+		auditService:      auditService,
 	}
 }
 
@@ -21,6 +21,7 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -69,6 +70,14 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
+	c.auditService.Log(ctx, audit.Event{
+		ActorID:      session.Principal.ID,
+		Action:       enum.AuditActionCreate,
+		ResourceType: enum.AuditResourceTypeToken,
+		Resource:     user.UID + "/" + token.Identifier,
+		After:        token,
+	})
+
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
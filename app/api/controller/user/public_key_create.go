@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/ssh"
+)
+
+type CreatePublicKeyInput struct {
+	Identifier string `json:"identifier"`
+	Content    string `json:"content"`
+}
+
+/*
+ * CreatePublicKey adds a new SSH public key to a user.
+ */
+func (c *Controller) CreatePublicKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *CreatePublicKeyInput,
+) (*types.PublicKey, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = check.Identifier(in.Identifier); err != nil {
+		return nil, err
+	}
+
+	key, comment, err := parsePublicKey(in.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := &types.PublicKey{
+		PrincipalID: user.ID,
+		Created:     time.Now().UnixMilli(),
+		LastUsed:    nil,
+		Identifier:  in.Identifier,
+		Type:        key.Type(),
+		Content:     strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+		Fingerprint: ssh.FingerprintSHA256(key),
+		Comment:     comment,
+	}
+
+	err = c.publicKeyStore.Create(ctx, publicKey)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("A public key with the same identifier or content already exists.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public key: %w", err)
+	}
+
+	return publicKey, nil
+}
+
+// parsePublicKey parses a public key in the authorized_keys format.
+func parsePublicKey(content string) (ssh.PublicKey, string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, "", usererror.BadRequest("Public key content is required.")
+	}
+
+	key, comment, _, rest, err := ssh.ParseAuthorizedKey([]byte(content))
+	if err != nil {
+		return nil, "", usererror.BadRequestf("Invalid public key: %s", err)
+	}
+
+	if len(rest) > 0 {
+		return nil, "", usererror.BadRequest("Only a single public key can be provided.")
+	}
+
+	switch key.Type() {
+	case ssh.KeyAlgoRSA, ssh.KeyAlgoED25519,
+		ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521,
+		ssh.KeyAlgoSKED25519, ssh.KeyAlgoSKECDSA256:
+	default:
+		return nil, "", usererror.BadRequestf("Unsupported public key type %q.", key.Type())
+	}
+
+	return key, comment, nil
+}
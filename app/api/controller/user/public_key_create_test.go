@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func marshalTestKey(t *testing.T, pub any) string {
+	t.Helper()
+
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to create ssh public key: %v", err)
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+func TestParsePublicKey(t *testing.T) {
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+
+	edContent := marshalTestKey(t, edPub)
+
+	tests := []struct {
+		name        string
+		content     string
+		wantType    string
+		wantComment string
+		wantErr     bool
+	}{
+		{
+			name:        "ed25519 with comment",
+			content:     "  " + edContent + " user@laptop\n",
+			wantType:    ssh.KeyAlgoED25519,
+			wantComment: "user@laptop",
+		},
+		{
+			name:     "ecdsa",
+			content:  marshalTestKey(t, &ecKey.PublicKey),
+			wantType: ssh.KeyAlgoECDSA256,
+		},
+		{
+			name:     "rsa",
+			content:  marshalTestKey(t, &rsaKey.PublicKey),
+			wantType: ssh.KeyAlgoRSA,
+		},
+		{
+			name:    "empty",
+			content: " ",
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			content: "ssh-ed25519 not-base64",
+			wantErr: true,
+		},
+		{
+			name:    "multiple keys",
+			content: edContent + "\n" + edContent,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, comment, err := parsePublicKey(test.content)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if key.Type() != test.wantType {
+				t.Errorf("type: got=%s want=%s", key.Type(), test.wantType)
+			}
+			if comment != test.wantComment {
+				t.Errorf("comment: got=%q want=%q", comment, test.wantComment)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -55,6 +56,8 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		}
 	}
 
+	userBefore := *user
+
 	user.Admin = request.Admin
 	user.Updated = time.Now().UnixMilli()
 
@@ -63,5 +66,14 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	c.auditService.Log(ctx, audit.Event{
+		ActorID:      session.Principal.ID,
+		Action:       enum.AuditActionUpdate,
+		ResourceType: enum.AuditResourceTypeUser,
+		Resource:     user.UID,
+		Before:       userBefore,
+		After:        user,
+	})
+
 	return user, nil
 }
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/url"
+)
+
+// renderError renders errors of the LFS API.
+// Unauthenticated requests are challenged for basic auth, as git LFS clients use the git credentials.
+func renderError(w http.ResponseWriter, urlProvider url.Provider, err error) {
+	if errors.Is(err, apiauth.ErrNotAuthenticated) {
+		w.Header().Add("WWW-Authenticate", fmt.Sprintf(`Basic realm="%s"`, urlProvider.GetAPIHostname()))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var errLockExists *lfs.LockExistsError
+	if errors.As(err, &errLockExists) {
+		render.JSON(w, http.StatusConflict, struct {
+			Lock    lfs.Lock `json:"lock"`
+			Message string   `json:"message"`
+		}{
+			Lock:    errLockExists.Lock,
+			Message: "The path is already locked.",
+		})
+		return
+	}
+
+	render.TranslatedUserError(w, err)
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/git"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleArchive streams the archive of the repository tree at the requested git reference.
+func HandleArchive(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		gitRef, format, err := parseArchivePath(request.GetOptionalRemainderFromPath(r))
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		prefix := request.GetArchivePrefixFromQuery(r)
+		paths := request.GetArchivePathsFromQuery(r)
+
+		fileName := fmt.Sprintf("%s-%s.%s", path.Base(repoRef), strings.ReplaceAll(gitRef, "/", "-"), format)
+		w.Header().Set("Content-Type", archiveContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+		aw := &archiveWriter{ResponseWriter: w}
+		err = repoCtrl.Archive(ctx, session, repoRef, gitRef, format, prefix, paths, aw)
+		if err != nil && aw.written {
+			// the status and part of the archive have been sent already, the error can only be logged.
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to stream archive of repo %q", repoRef)
+			return
+		}
+		if err != nil {
+			w.Header().Del("Content-Disposition")
+			render.TranslatedUserError(w, err)
+			return
+		}
+	}
+}
+
+// archiveWriter tracks whether any part of the archive has been written to the response.
+type archiveWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *archiveWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(p)
+}
+
+// parseArchivePath parses the git reference and the archive format of the path (e.g. "main.tar.gz").
+func parseArchivePath(archivePath string) (string, git.ArchiveFormat, error) {
+	for _, format := range git.ArchiveFormats {
+		gitRef, ok := strings.CutSuffix(archivePath, "."+string(format))
+		if ok && gitRef != "" {
+			return gitRef, format, nil
+		}
+	}
+
+	return "", "", usererror.BadRequestf(
+		"Archive path has to be of the form '{git_ref}.{format}' with format being one of %v.", git.ArchiveFormats)
+}
+
+func archiveContentType(format git.ArchiveFormat) string {
+	switch format {
+	case git.ArchiveFormatTarGz, git.ArchiveFormatTgz:
+		return "application/gzip"
+	case git.ArchiveFormatZip:
+		return "application/zip"
+	case git.ArchiveFormatTar:
+		return "application/x-tar"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/git"
+)
+
+func TestParseArchivePath(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantRef    string
+		wantFormat git.ArchiveFormat
+		wantErr    bool
+	}{
+		{
+			name:       "tar",
+			path:       "main.tar",
+			wantRef:    "main",
+			wantFormat: git.ArchiveFormatTar,
+		},
+		{
+			name:       "tar.gz",
+			path:       "main.tar.gz",
+			wantRef:    "main",
+			wantFormat: git.ArchiveFormatTarGz,
+		},
+		{
+			name:       "tgz",
+			path:       "v1.0.tgz",
+			wantRef:    "v1.0",
+			wantFormat: git.ArchiveFormatTgz,
+		},
+		{
+			name:       "zip with slashes in the ref",
+			path:       "feature/archive.zip",
+			wantRef:    "feature/archive",
+			wantFormat: git.ArchiveFormatZip,
+		},
+		{
+			name:       "ref with a format-like suffix",
+			path:       "release.tar.zip",
+			wantRef:    "release.tar",
+			wantFormat: git.ArchiveFormatZip,
+		},
+		{
+			name:    "missing ref",
+			path:    ".zip",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			path:    "main.rar",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			path:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitRef, format, err := parseArchivePath(test.path)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got ref=%q format=%q", gitRef, format)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if gitRef != test.wantRef {
+				t.Errorf("ref: got=%q want=%q", gitRef, test.wantRef)
+			}
+			if format != test.wantFormat {
+				t.Errorf("format: got=%q want=%q", format, test.wantFormat)
+			}
+		})
+	}
+}
+
+func TestArchiveWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	aw := &archiveWriter{ResponseWriter: rec}
+
+	if aw.written {
+		t.Errorf("expected nothing to be written initially")
+	}
+
+	if _, err := aw.Write([]byte("data")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !aw.written {
+		t.Errorf("expected write to be tracked")
+	}
+	if rec.Body.String() != "data" {
+		t.Errorf("got=%q want=%q", rec.Body.String(), "data")
+	}
+}
@@ -20,11 +20,21 @@ import (
 	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type ConfigOutput struct {
 	UserSignupAllowed             bool `json:"user_signup_allowed"`
 	PublicResourceCreationEnabled bool `json:"public_resource_creation_enabled"`
+
+	// SigningKey is the public part of the key the server uses to sign commits and tags.
+	SigningKey *SigningKeyOutput `json:"signing_key,omitempty"`
+}
+
+type SigningKeyOutput struct {
+	Type        enum.SigningKeyType `json:"type"`
+	PublicKey   string              `json:"public_key"`
+	Fingerprint string              `json:"fingerprint"`
 }
 
 // HandleGetConfig returns an http.HandlerFunc that processes an http.Request
@@ -39,9 +49,24 @@ func HandleGetConfig(config *types.Config, sysCtrl *system.Controller) http.Hand
 			return
 		}
 
-		render.JSON(w, http.StatusOK, ConfigOutput{
+		signingKey, err := sysCtrl.SigningKey(ctx)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		out := ConfigOutput{
 			UserSignupAllowed:             userSignupAllowed,
 			PublicResourceCreationEnabled: config.PublicResourceCreationEnabled,
-		})
+		}
+		if signingKey != nil {
+			out.SigningKey = &SigningKeyOutput{
+				Type:        signingKey.Type,
+				PublicKey:   signingKey.PublicKey,
+				Fingerprint: signingKey.Fingerprint,
+			}
+		}
+
+		render.JSON(w, http.StatusOK, out)
 	}
 }
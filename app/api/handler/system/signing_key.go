@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+// HandleFindSigningKey returns an http.HandlerFunc that returns
+// the instance-level key used to sign commits and tags.
+func HandleFindSigningKey(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		key, err := sysCtrl.SigningKey(ctx)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		if key == nil {
+			render.TranslatedUserError(w, usererror.ErrNotFound)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, key)
+	}
+}
+
+// HandleSetSigningKey returns an http.HandlerFunc that sets
+// the instance-level key used to sign commits and tags.
+func HandleSetSigningKey(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		in := new(system.SigningKeySetInput)
+		err := json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		key, err := sysCtrl.SigningKeySet(ctx, session, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, key)
+	}
+}
+
+// HandleDeleteSigningKey returns an http.HandlerFunc that deletes
+// the instance-level key used to sign commits and tags.
+func HandleDeleteSigningKey(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		err := sysCtrl.SigningKeyDelete(ctx, session)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.DeleteSuccessful(w)
+	}
+}
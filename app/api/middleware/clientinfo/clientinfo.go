@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientinfo
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/services/audit"
+)
+
+// Handler returns an http.HandlerFunc middleware that stores the client IP address
+// and the user agent of the request in the context, so they can be recorded in the audit log.
+// The forwarding headers are honored only for the requests coming from one of the trusted proxies,
+// which are IP addresses or CIDR ranges. Invalid entries are ignored, they're rejected when the config is loaded.
+func Handler(trustedProxies []string) func(http.Handler) http.Handler {
+	networks, _ := ParseTrustedProxies(trustedProxies)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := audit.WithClientInfo(r.Context(), resolveIP(r, networks), r.UserAgent())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ParseTrustedProxies parses the IP addresses and CIDR ranges of the trusted proxies.
+// It returns the successfully parsed ones along with an error for the first invalid entry.
+func ParseTrustedProxies(trustedProxies []string) ([]*net.IPNet, error) {
+	var errInvalid error
+	networks := make([]*net.IPNet, 0, len(trustedProxies))
+
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				if errInvalid == nil {
+					errInvalid = fmt.Errorf("invalid trusted proxy IP address %q", proxy)
+				}
+				continue
+			}
+
+			bits := 8 * len(ip.To16())
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*len(ip4)
+			}
+
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			if errInvalid == nil {
+				errInvalid = fmt.Errorf("invalid trusted proxy CIDR range %q: %w", proxy, err)
+			}
+			continue
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, errInvalid
+}
+
+// resolveIP is a helper function that evaluates the http.Request
+// and returns the client IP address. If the request comes from a trusted proxy, it is able
+// to detect, using the X-Forwarded-For and X-Real-IP headers, the original address.
+// The X-Forwarded-For addresses are evaluated from the closest one and the first address
+// that doesn't belong to a trusted proxy is the client address, so clients can't spoof it.
+func resolveIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	remoteIP := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		remoteIP = host
+	}
+
+	if !isTrusted(net.ParseIP(remoteIP), trustedProxies) {
+		return remoteIP
+	}
+
+	if forwardedFor := r.Header.Values("X-Forwarded-For"); len(forwardedFor) > 0 {
+		addresses := strings.Split(strings.Join(forwardedFor, ","), ",")
+
+		closest := remoteIP
+		for i := len(addresses) - 1; i >= 0; i-- {
+			address := strings.TrimSpace(addresses[i])
+
+			ip := net.ParseIP(address)
+			if ip == nil {
+				return closest
+			}
+
+			if !isTrusted(ip, trustedProxies) {
+				return address
+			}
+
+			closest = address
+		}
+
+		return closest
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+
+	return remoteIP
+}
+
+// isTrusted returns true if the IP address belongs to any of the trusted proxies.
+func isTrusted(ip net.IP, trustedProxies []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientinfo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestResolveIP(t *testing.T) {
+	trustedProxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"})
+	if err != nil {
+		t.Fatalf("failed to parse trusted proxies: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string][]string
+		want       string
+	}{
+		{
+			name:       "direct",
+			remoteAddr: "203.0.113.7:5000",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "untrusted-forwarded-for",
+			remoteAddr: "203.0.113.7:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "untrusted-real-ip",
+			remoteAddr: "203.0.113.7:5000",
+			headers:    map[string][]string{"X-Real-IP": {"198.51.100.1"}},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted-forwarded-for",
+			remoteAddr: "10.1.2.3:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "trusted-forwarded-for-spoofed",
+			remoteAddr: "10.1.2.3:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1, 198.51.100.1"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "trusted-forwarded-for-chain",
+			remoteAddr: "192.168.1.1:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.1.1.1, 198.51.100.1", "10.0.0.2"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "trusted-forwarded-for-all-trusted",
+			remoteAddr: "10.1.2.3:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"10.0.0.5, 10.0.0.2"}},
+			want:       "10.0.0.5",
+		},
+		{
+			name:       "trusted-forwarded-for-invalid",
+			remoteAddr: "10.1.2.3:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1, unknown, 10.0.0.2"}},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "trusted-real-ip",
+			remoteAddr: "10.1.2.3:5000",
+			headers:    map[string][]string{"X-Real-IP": {"198.51.100.1"}},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "trusted-ipv6",
+			remoteAddr: "[fd00::1]:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"2001:db8::1"}},
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "single-ip-proxy-mismatch",
+			remoteAddr: "192.168.1.2:5000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1"}},
+			want:       "192.168.1.2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &http.Request{RemoteAddr: test.remoteAddr, Header: http.Header{}}
+			for key, values := range test.headers {
+				for _, value := range values {
+					r.Header.Add(key, value)
+				}
+			}
+
+			if got := resolveIP(r, trustedProxies); got != test.want {
+				t.Errorf("got=%s want=%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveIP_NoTrustedProxies(t *testing.T) {
+	r := &http.Request{RemoteAddr: "10.1.2.3:5000", Header: http.Header{}}
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+	r.Header.Set("X-Real-IP", "198.51.100.1")
+
+	if got, want := resolveIP(r, nil), "10.1.2.3"; got != want {
+		t.Errorf("got=%s want=%s", got, want)
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxies   []string
+		wantCount int
+		wantErr   bool
+	}{
+		{name: "empty", proxies: nil, wantCount: 0},
+		{name: "valid", proxies: []string{"10.0.0.0/8", " 127.0.0.1 ", "::1", ""}, wantCount: 3},
+		{name: "invalid-ip", proxies: []string{"10.0.0.1", "localhost"}, wantCount: 1, wantErr: true},
+		{name: "invalid-cidr", proxies: []string{"10.0.0.0/33"}, wantCount: 0, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			networks, err := ParseTrustedProxies(test.proxies)
+			if (err != nil) != test.wantErr {
+				t.Errorf("error: got=%v want error=%t", err, test.wantErr)
+			}
+
+			if len(networks) != test.wantCount {
+				t.Errorf("networks: got=%v want count=%d", networks, test.wantCount)
+			}
+		})
+	}
+}
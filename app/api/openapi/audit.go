@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type (
+	// auditEventFilterRequest contains the audit log query parameters.
+	auditEventFilterRequest struct {
+		SpaceID int64 `query:"space_id"`
+		RepoID  int64 `query:"repo_id"`
+		ActorID int64 `query:"actor_id"`
+		Since   int64 `query:"since"`
+		Until   int64 `query:"until"`
+	}
+
+	// auditEventListRequest is the request for listing audit events.
+	auditEventListRequest struct {
+		auditEventFilterRequest
+
+		// include pagination request
+		paginationRequest
+	}
+)
+
+func auditOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListAuditEvents"})
+	_ = reflector.SetRequest(&opList, new(auditEventListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.AuditEvent), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit-events", opList)
+
+	opExport := openapi3.Operation{}
+	opExport.WithTags("admin")
+	opExport.WithMapOfAnything(map[string]interface{}{"operationId": "adminExportAuditEvents"})
+	_ = reflector.SetRequest(&opExport, new(auditEventFilterRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opExport, http.StatusOK, "application/x-ndjson")
+	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit-events/export", opExport)
+}
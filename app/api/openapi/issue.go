@@ -0,0 +1,519 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/issue"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type createIssueRequest struct {
+	repoRequest
+	issue.CreateInput
+}
+
+type issueRequest struct {
+	repoRequest
+	Number int64 `path:"issue_number"`
+}
+
+type updateIssueRequest struct {
+	issueRequest
+	issue.UpdateInput
+}
+
+type stateIssueRequest struct {
+	issueRequest
+	issue.StateInput
+}
+
+type commentCreateIssueRequest struct {
+	issueRequest
+	issue.CommentCreateInput
+}
+
+type issueCommentRequest struct {
+	issueRequest
+	ID int64 `path:"issue_comment_id"`
+}
+
+type commentUpdateIssueRequest struct {
+	issueCommentRequest
+	issue.CommentUpdateInput
+}
+
+type assigneeAddIssueRequest struct {
+	issueRequest
+	issue.AssigneeAddInput
+}
+
+type assigneeDeleteIssueRequest struct {
+	issueRequest
+	AssigneeID int64 `path:"assignee_id"`
+}
+
+type labelAssignIssueRequest struct {
+	issueRequest
+	issue.LabelAssignInput
+}
+
+type labelUnassignIssueRequest struct {
+	issueRequest
+	LabelID int64 `path:"label_id"`
+}
+
+type milestoneSetIssueRequest struct {
+	issueRequest
+	issue.MilestoneSetInput
+}
+
+type createMilestoneRequest struct {
+	repoRequest
+	issue.MilestoneCreateInput
+}
+
+type milestoneRequest struct {
+	repoRequest
+	ID int64 `path:"milestone_id"`
+}
+
+type updateMilestoneRequest struct {
+	milestoneRequest
+	issue.MilestoneUpdateInput
+}
+
+var queryParameterQueryIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the issues are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCreatedByIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCreatedBy,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The principal ID who created the issues."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterAssigneeIDIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAssigneeID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The principal ID the issues must be assigned to."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterMilestoneIDIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMilestoneID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The ID of the milestone the issues must belong to."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterLabelIDIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLabelID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The IDs of the labels the issues must have assigned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterStateIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the issues to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.IssueState("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterSortIssue = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The data by which the issues are sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.IssueSortNumber),
+				Enum:    enum.IssueSort("").Enum(),
+			},
+		},
+	},
+}
+
+var queryParameterKindIssueActivity = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamKind,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The kind of the issue activity to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.IssueActivityKind("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterTypeIssueActivity = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the issue activity to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.IssueActivityType("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterStateMilestone = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the milestones to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.MilestoneState("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func issueOperations(reflector *openapi3.Reflector) {
+	createIssue := openapi3.Operation{}
+	createIssue.WithTags("issue")
+	createIssue.WithMapOfAnything(map[string]interface{}{"operationId": "createIssue"})
+	_ = reflector.SetRequest(&createIssue, new(createIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&createIssue, new(types.Issue), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/issues", createIssue)
+
+	listIssues := openapi3.Operation{}
+	listIssues.WithTags("issue")
+	listIssues.WithMapOfAnything(map[string]interface{}{"operationId": "listIssues"})
+	listIssues.WithParameters(
+		queryParameterStateIssue, queryParameterQueryIssue, queryParameterCreatedByIssue,
+		queryParameterAssigneeIDIssue, queryParameterMilestoneIDIssue, queryParameterLabelIDIssue,
+		queryParameterOrder, queryParameterSortIssue, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listIssues, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listIssues, new([]types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listIssues, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listIssues, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listIssues, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listIssues, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listIssues, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/issues", listIssues)
+
+	getIssue := openapi3.Operation{}
+	getIssue.WithTags("issue")
+	getIssue.WithMapOfAnything(map[string]interface{}{"operationId": "getIssue"})
+	_ = reflector.SetRequest(&getIssue, new(issueRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/issues/{issue_number}", getIssue)
+
+	updateIssue := openapi3.Operation{}
+	updateIssue.WithTags("issue")
+	updateIssue.WithMapOfAnything(map[string]interface{}{"operationId": "updateIssue"})
+	_ = reflector.SetRequest(&updateIssue, new(updateIssueRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/issues/{issue_number}", updateIssue)
+
+	stateIssue := openapi3.Operation{}
+	stateIssue.WithTags("issue")
+	stateIssue.WithMapOfAnything(map[string]interface{}{"operationId": "stateIssue"})
+	_ = reflector.SetRequest(&stateIssue, new(stateIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&stateIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stateIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/issues/{issue_number}/state", stateIssue)
+
+	listIssueActivities := openapi3.Operation{}
+	listIssueActivities.WithTags("issue")
+	listIssueActivities.WithMapOfAnything(map[string]interface{}{"operationId": "listIssueActivities"})
+	listIssueActivities.WithParameters(
+		queryParameterKindIssueActivity, queryParameterTypeIssueActivity,
+		queryParameterAfter, queryParameterBeforePullRequestActivity, queryParameterLimit)
+	_ = reflector.SetRequest(&listIssueActivities, new(issueRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new([]types.IssueActivity), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listIssueActivities, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/issues/{issue_number}/activities", listIssueActivities)
+
+	commentCreateIssue := openapi3.Operation{}
+	commentCreateIssue.WithTags("issue")
+	commentCreateIssue.WithMapOfAnything(map[string]interface{}{"operationId": "commentCreateIssue"})
+	_ = reflector.SetRequest(&commentCreateIssue, new(commentCreateIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(types.IssueActivity), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&commentCreateIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/issues/{issue_number}/comments", commentCreateIssue)
+
+	commentUpdateIssue := openapi3.Operation{}
+	commentUpdateIssue.WithTags("issue")
+	commentUpdateIssue.WithMapOfAnything(map[string]interface{}{"operationId": "commentUpdateIssue"})
+	_ = reflector.SetRequest(&commentUpdateIssue, new(commentUpdateIssueRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(types.IssueActivity), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&commentUpdateIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/issues/{issue_number}/comments/{issue_comment_id}", commentUpdateIssue)
+
+	commentDeleteIssue := openapi3.Operation{}
+	commentDeleteIssue.WithTags("issue")
+	commentDeleteIssue.WithMapOfAnything(map[string]interface{}{"operationId": "commentDeleteIssue"})
+	_ = reflector.SetRequest(&commentDeleteIssue, new(issueCommentRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&commentDeleteIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/issues/{issue_number}/comments/{issue_comment_id}", commentDeleteIssue)
+
+	assigneeAddIssue := openapi3.Operation{}
+	assigneeAddIssue.WithTags("issue")
+	assigneeAddIssue.WithMapOfAnything(map[string]interface{}{"operationId": "assigneeAddIssue"})
+	_ = reflector.SetRequest(&assigneeAddIssue, new(assigneeAddIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&assigneeAddIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/issues/{issue_number}/assignees", assigneeAddIssue)
+
+	assigneeDeleteIssue := openapi3.Operation{}
+	assigneeDeleteIssue.WithTags("issue")
+	assigneeDeleteIssue.WithMapOfAnything(map[string]interface{}{"operationId": "assigneeDeleteIssue"})
+	_ = reflector.SetRequest(&assigneeDeleteIssue, new(assigneeDeleteIssueRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&assigneeDeleteIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/issues/{issue_number}/assignees/{assignee_id}", assigneeDeleteIssue)
+
+	labelAssignIssue := openapi3.Operation{}
+	labelAssignIssue.WithTags("issue")
+	labelAssignIssue.WithMapOfAnything(map[string]interface{}{"operationId": "labelAssignIssue"})
+	_ = reflector.SetRequest(&labelAssignIssue, new(labelAssignIssueRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&labelAssignIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&labelAssignIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&labelAssignIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&labelAssignIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&labelAssignIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&labelAssignIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/issues/{issue_number}/labels", labelAssignIssue)
+
+	labelUnassignIssue := openapi3.Operation{}
+	labelUnassignIssue.WithTags("issue")
+	labelUnassignIssue.WithMapOfAnything(map[string]interface{}{"operationId": "labelUnassignIssue"})
+	_ = reflector.SetRequest(&labelUnassignIssue, new(labelUnassignIssueRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&labelUnassignIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&labelUnassignIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&labelUnassignIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&labelUnassignIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&labelUnassignIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&labelUnassignIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/issues/{issue_number}/labels/{label_id}", labelUnassignIssue)
+
+	milestoneSetIssue := openapi3.Operation{}
+	milestoneSetIssue.WithTags("issue")
+	milestoneSetIssue.WithMapOfAnything(map[string]interface{}{"operationId": "milestoneSetIssue"})
+	_ = reflector.SetRequest(&milestoneSetIssue, new(milestoneSetIssueRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&milestoneSetIssue, new(types.Issue), http.StatusOK)
+	_ = reflector.SetJSONResponse(&milestoneSetIssue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&milestoneSetIssue, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&milestoneSetIssue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&milestoneSetIssue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&milestoneSetIssue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/issues/{issue_number}/milestone", milestoneSetIssue)
+
+	listMilestones := openapi3.Operation{}
+	listMilestones.WithTags("issue")
+	listMilestones.WithMapOfAnything(map[string]interface{}{"operationId": "listMilestones"})
+	listMilestones.WithParameters(queryParameterStateMilestone, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listMilestones, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listMilestones, new([]types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listMilestones, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listMilestones, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listMilestones, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listMilestones, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listMilestones, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/milestones", listMilestones)
+
+	createMilestone := openapi3.Operation{}
+	createMilestone.WithTags("issue")
+	createMilestone.WithMapOfAnything(map[string]interface{}{"operationId": "createMilestone"})
+	_ = reflector.SetRequest(&createMilestone, new(createMilestoneRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&createMilestone, new(types.Milestone), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&createMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&createMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&createMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&createMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/milestones", createMilestone)
+
+	updateMilestone := openapi3.Operation{}
+	updateMilestone.WithTags("issue")
+	updateMilestone.WithMapOfAnything(map[string]interface{}{"operationId": "updateMilestone"})
+	_ = reflector.SetRequest(&updateMilestone, new(updateMilestoneRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/milestones/{milestone_id}", updateMilestone)
+
+	deleteMilestone := openapi3.Operation{}
+	deleteMilestone.WithTags("issue")
+	deleteMilestone.WithMapOfAnything(map[string]interface{}{"operationId": "deleteMilestone"})
+	_ = reflector.SetRequest(&deleteMilestone, new(milestoneRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteMilestone, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&deleteMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&deleteMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/milestones/{milestone_id}", deleteMilestone)
+}
@@ -67,7 +67,11 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	secretOperations(&reflector)
 	resourceOperations(&reflector)
 	pullReqOperations(&reflector)
+	issueOperations(&reflector)
 	webhookOperations(&reflector)
+	mirrorOperations(&reflector)
+	secretScanOperations(&reflector)
+	auditOperations(&reflector)
 	checkOperations(&reflector)
 	uploadOperations(&reflector)
 
@@ -66,11 +66,41 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type revertPullReq struct {
+	pullReqRequest
+	pullreq.RevertInput
+}
+
+type updateBranchPullReq struct {
+	pullReqRequest
+	pullreq.UpdateBranchInput
+}
+
+type resolveConflictsPullReq struct {
+	pullReqRequest
+	pullreq.ResolveConflictsInput
+}
+
+type mergeQueueEnqueuePullReq struct {
+	pullReqRequest
+	pullreq.MergeQueueEnqueueInput
+}
+
+type autoMergeEnablePullReq struct {
+	pullReqRequest
+	pullreq.AutoMergeInput
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
 }
 
+type commentApplySuggestionsPullReqRequest struct {
+	pullReqRequest
+	pullreq.CommentApplySuggestionsInput
+}
+
 type pullReqCommentRequest struct {
 	pullReqRequest
 	ID int64 `path:"pullreq_comment_id"`
@@ -104,6 +134,16 @@ type reviewerAddPullReqRequest struct {
 	pullreq.ReviewerAddInput
 }
 
+type labelAssignPullReqRequest struct {
+	pullReqRequest
+	pullreq.LabelAssignInput
+}
+
+type labelUnassignPullReqRequest struct {
+	pullReqRequest
+	LabelID int64 `path:"label_id"`
+}
+
 type reviewSubmitPullReqRequest struct {
 	pullreq.ReviewSubmitInput
 	pullReqRequest
@@ -203,6 +243,25 @@ var queryParameterCreatedByPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterLabelIDPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLabelID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The IDs of the labels the pull requests must have assigned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+	},
+}
+
 var queryParameterStatePullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamState,
@@ -315,6 +374,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 		queryParameterStatePullRequest, queryParameterSourceRepoRefPullRequest,
 		queryParameterSourceBranchPullRequest, queryParameterTargetBranchPullRequest,
 		queryParameterQueryPullRequest, queryParameterCreatedByPullRequest,
+		queryParameterLabelIDPullRequest,
 		queryParameterOrder, queryParameterSortPullRequest,
 		queryParameterPage, queryParameterLimit)
 	_ = reflector.SetRequest(&listPullReq, new(listPullReqRequest), http.MethodGet)
@@ -385,6 +445,23 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments", commentCreatePullReq)
 
+	commentApplySuggestionsPullReq := openapi3.Operation{}
+	commentApplySuggestionsPullReq.WithTags("pullreq")
+	commentApplySuggestionsPullReq.WithMapOfAnything(
+		map[string]interface{}{"operationId": "commentApplySuggestionsPullReq"})
+	_ = reflector.SetRequest(&commentApplySuggestionsPullReq,
+		new(commentApplySuggestionsPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&commentApplySuggestionsPullReq,
+		new(pullreq.CommentApplySuggestionsOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentApplySuggestionsPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentApplySuggestionsPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentApplySuggestionsPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentApplySuggestionsPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&commentApplySuggestionsPullReq, new(types.RulesViolations),
+		http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/apply-suggestions", commentApplySuggestionsPullReq)
+
 	commentUpdatePullReq := openapi3.Operation{}
 	commentUpdatePullReq.WithTags("pullreq")
 	commentUpdatePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentUpdatePullReq"})
@@ -469,6 +546,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviews", reviewSubmit)
 
+	reviewDiscard := openapi3.Operation{}
+	reviewDiscard.WithTags("pullreq")
+	reviewDiscard.WithMapOfAnything(map[string]interface{}{"operationId": "reviewDiscardPullReq"})
+	_ = reflector.SetRequest(&reviewDiscard, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&reviewDiscard, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&reviewDiscard, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reviewDiscard, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewDiscard, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewDiscard, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviews/pending", reviewDiscard)
+
 	mergePullReqOp := openapi3.Operation{}
 	mergePullReqOp.WithTags("pullreq")
 	mergePullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergePullReqOp"})
@@ -484,6 +573,162 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	revertPullReqOp := openapi3.Operation{}
+	revertPullReqOp.WithTags("pullreq")
+	revertPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "revertPullReqOp"})
+	_ = reflector.SetRequest(&revertPullReqOp, new(revertPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(types.PullReq), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/revert", revertPullReqOp)
+
+	updateBranchPullReqOp := openapi3.Operation{}
+	updateBranchPullReqOp.WithTags("pullreq")
+	updateBranchPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "updateBranchPullReqOp"})
+	_ = reflector.SetRequest(&updateBranchPullReqOp, new(updateBranchPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(pullreq.UpdateBranchOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&updateBranchPullReqOp, new(types.RulesViolations),
+		http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/update-branch", updateBranchPullReqOp)
+
+	conflictsPullReqOp := openapi3.Operation{}
+	conflictsPullReqOp.WithTags("pullreq")
+	conflictsPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "conflictsPullReq"})
+	_ = reflector.SetRequest(&conflictsPullReqOp, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&conflictsPullReqOp, new(pullreq.ConflictsOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&conflictsPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&conflictsPullReqOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&conflictsPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&conflictsPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&conflictsPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/conflicts", conflictsPullReqOp)
+
+	resolveConflictsPullReqOp := openapi3.Operation{}
+	resolveConflictsPullReqOp.WithTags("pullreq")
+	resolveConflictsPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "resolveConflictsPullReq"})
+	_ = reflector.SetRequest(&resolveConflictsPullReqOp, new(resolveConflictsPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(pullreq.ResolveConflictsOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(types.RulesViolations),
+		http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/conflicts/resolve", resolveConflictsPullReqOp)
+
+	mergeQueueEnqueue := openapi3.Operation{}
+	mergeQueueEnqueue.WithTags("pullreq")
+	mergeQueueEnqueue.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueEnqueuePullReq"})
+	_ = reflector.SetRequest(&mergeQueueEnqueue, new(mergeQueueEnqueuePullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueue, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueue, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueue, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueEnqueue)
+
+	mergeQueueDequeue := openapi3.Operation{}
+	mergeQueueDequeue.WithTags("pullreq")
+	mergeQueueDequeue.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueDequeuePullReq"})
+	_ = reflector.SetRequest(&mergeQueueDequeue, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeue, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeue, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeue, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeue, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeue, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueDequeue)
+
+	autoMergeEnable := openapi3.Operation{}
+	autoMergeEnable.WithTags("pullreq")
+	autoMergeEnable.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeEnablePullReq"})
+	_ = reflector.SetRequest(&autoMergeEnable, new(autoMergeEnablePullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&autoMergeEnable, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeEnable, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&autoMergeEnable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeEnable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeEnable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", autoMergeEnable)
+
+	autoMergeDisable := openapi3.Operation{}
+	autoMergeDisable.WithTags("pullreq")
+	autoMergeDisable.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeDisablePullReq"})
+	_ = reflector.SetRequest(&autoMergeDisable, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&autoMergeDisable, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeDisable, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&autoMergeDisable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeDisable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeDisable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", autoMergeDisable)
+
+	labelList := openapi3.Operation{}
+	labelList.WithTags("pullreq")
+	labelList.WithMapOfAnything(map[string]interface{}{"operationId": "labelListPullReq"})
+	_ = reflector.SetRequest(&labelList, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&labelList, new([]types.LabelInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&labelList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&labelList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&labelList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&labelList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels", labelList)
+
+	labelAssign := openapi3.Operation{}
+	labelAssign.WithTags("pullreq")
+	labelAssign.WithMapOfAnything(map[string]interface{}{"operationId": "labelAssignPullReq"})
+	_ = reflector.SetRequest(&labelAssign, new(labelAssignPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&labelAssign, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&labelAssign, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&labelAssign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&labelAssign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&labelAssign, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels", labelAssign)
+
+	labelUnassign := openapi3.Operation{}
+	labelUnassign.WithTags("pullreq")
+	labelUnassign.WithMapOfAnything(map[string]interface{}{"operationId": "labelUnassignPullReq"})
+	_ = reflector.SetRequest(&labelUnassign, new(labelUnassignPullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&labelUnassign, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&labelUnassign, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&labelUnassign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&labelUnassign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&labelUnassign, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/labels/{label_id}", labelUnassign)
+
+	mergeQueueList := openapi3.Operation{}
+	mergeQueueList.WithTags("pullreq")
+	mergeQueueList.WithMapOfAnything(map[string]interface{}{"operationId": "listMergeQueue"})
+	mergeQueueList.WithParameters(queryParameterBranch)
+	_ = reflector.SetRequest(&mergeQueueList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergeQueueList, new([]types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/merge-queue", mergeQueueList)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
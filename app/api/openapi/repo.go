@@ -54,11 +54,22 @@ type moveRepoRequest struct {
 	repo.MoveInput
 }
 
+type forkRepoRequest struct {
+	repoRequest
+	repo.ForkInput
+}
+
 type getContentRequest struct {
 	repoRequest
 	Path string `path:"path"`
 }
 
+type archiveRequest struct {
+	repoRequest
+	GitRef string `path:"git_ref"`
+	Format string `path:"format" enum:"tar,tar.gz,tgz,zip"`
+}
+
 type pathsDetailsRequest struct {
 	repoRequest
 	repo.PathsDetailsInput
@@ -74,6 +85,11 @@ type commitFilesRequest struct {
 	repo.CommitFilesOptions
 }
 
+type pickRequest struct {
+	repoRequest
+	repo.PickInput
+}
+
 // contentType is a plugin for repo.ContentType to allow using oneof.
 type contentType string
 
@@ -173,14 +189,14 @@ type codeOwnersValidate struct {
 type ruleType string
 
 func (ruleType) Enum() []interface{} {
-	return []interface{}{protection.TypeBranch}
+	return []interface{}{protection.TypeBranch, protection.TypeTag, protection.TypePush}
 }
 
 // ruleDefinition is a plugin for types.Rule Definition to allow using oneof.
 type ruleDefinition struct{}
 
 func (ruleDefinition) JSONSchemaOneOf() []interface{} {
-	return []interface{}{protection.Branch{}}
+	return []interface{}{protection.Branch{}, protection.Tag{}, protection.Push{}}
 }
 
 type rule struct {
@@ -215,6 +231,39 @@ var queryParameterGitRef = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterArchivePrefix = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPrefix,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The path prefix prepended to every file in the archive."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterArchivePaths = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPath,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The paths the archive is restricted to."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
 var queryParameterPath = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamPath,
@@ -443,6 +492,49 @@ var queryParameterSortRuleList = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterInheritedRuleList = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamInherited,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Whether to include the protection rules inherited from the parent spaces."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterSinceRuleViolations = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSince,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only the violations recorded at or after this time (in unix milliseconds) are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterUntilRuleViolations = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamUntil,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only the violations recorded at or before this time (in unix milliseconds) are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
 var queryParameterBypassRules = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamBypassRules,
@@ -567,6 +659,18 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMove, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/move", opMove)
 
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepository"})
+	_ = reflector.SetRequest(&opFork, new(forkRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(types.Repository), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("repository")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryServiceAccounts"})
@@ -578,6 +682,18 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opServiceAccounts, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/service-accounts", opServiceAccounts)
 
+	opLabels := openapi3.Operation{}
+	opLabels.WithTags("repository")
+	opLabels.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryLabels"})
+	opLabels.WithParameters(queryParameterQueryLabel, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opLabels, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLabels, []types.Label{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLabels, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabels, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabels, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabels, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/labels", opLabels)
+
 	opGetContent := openapi3.Operation{}
 	opGetContent.WithTags("repository")
 	opGetContent.WithMapOfAnything(map[string]interface{}{"operationId": "getContent"})
@@ -615,6 +731,19 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw/{path}", opGetRaw)
 
+	opArchive := openapi3.Operation{}
+	opArchive.WithTags("repository")
+	opArchive.WithMapOfAnything(map[string]interface{}{"operationId": "archive"})
+	opArchive.WithParameters(queryParameterArchivePrefix, queryParameterArchivePaths)
+	_ = reflector.SetRequest(&opArchive, new(archiveRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opArchive, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/archive/{git_ref}.{format}", opArchive)
+
 	opGetBlame := openapi3.Operation{}
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
@@ -768,6 +897,34 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCommitFiles, new(types.RulesViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits", opCommitFiles)
 
+	opCherryPick := openapi3.Operation{}
+	opCherryPick.WithTags("repository")
+	opCherryPick.WithMapOfAnything(map[string]interface{}{"operationId": "cherryPick"})
+	_ = reflector.SetRequest(&opCherryPick, new(pickRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCherryPick, repo.PickOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/cherry-pick", opCherryPick)
+
+	opRevert := openapi3.Operation{}
+	opRevert.WithTags("repository")
+	opRevert.WithMapOfAnything(map[string]interface{}{"operationId": "revert"})
+	_ = reflector.SetRequest(&opRevert, new(pickRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRevert, repo.PickOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRevert, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRevert, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/revert", opRevert)
+
 	opDiff := openapi3.Operation{}
 	opDiff.WithTags("repository")
 	opDiff.WithMapOfAnything(map[string]interface{}{"operationId": "rawDiff"})
@@ -876,7 +1033,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opRuleList.WithTags("repository")
 	opRuleList.WithMapOfAnything(map[string]interface{}{"operationId": "ruleList"})
 	opRuleList.WithParameters(
-		queryParameterQueryRuleList,
+		queryParameterQueryRuleList, queryParameterInheritedRuleList,
 		queryParameterOrder, queryParameterSortRuleList,
 		queryParameterPage, queryParameterLimit)
 	_ = reflector.SetRequest(&opRuleList, &struct {
@@ -903,6 +1060,39 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRuleGet, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/rules/{rule_identifier}", opRuleGet)
 
+	opRuleViolationList := openapi3.Operation{}
+	opRuleViolationList.WithTags("repository")
+	opRuleViolationList.WithMapOfAnything(map[string]interface{}{"operationId": "ruleViolationList"})
+	opRuleViolationList.WithParameters(
+		queryParameterSinceRuleViolations, queryParameterUntilRuleViolations,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opRuleViolationList, &struct {
+		repoRequest
+		Identifier string `path:"rule_identifier"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRuleViolationList, []types.RuleMonitorViolation{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRuleViolationList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRuleViolationList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRuleViolationList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRuleViolationList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/rules/{rule_identifier}/violations", opRuleViolationList)
+
+	opRuleDryRun := openapi3.Operation{}
+	opRuleDryRun.WithTags("repository")
+	opRuleDryRun.WithMapOfAnything(map[string]interface{}{"operationId": "ruleDryRun"})
+	_ = reflector.SetRequest(&opRuleDryRun, &struct {
+		repoRequest
+		repo.RuleDryRunInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRuleDryRun, new(repo.RuleDryRunOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRuleDryRun, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRuleDryRun, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRuleDryRun, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRuleDryRun, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRuleDryRun, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/rules-dry-run", opRuleDryRun)
+
 	opCodeOwnerValidate := openapi3.Operation{}
 	opCodeOwnerValidate.WithTags("repository")
 	opCodeOwnerValidate.WithMapOfAnything(map[string]interface{}{"operationId": "codeOwnersValidate"})
@@ -0,0 +1,197 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	controllersecretscan "github.com/harness/gitness/app/api/controller/secretscan"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type updateRepoSecretScanSettingsRequest struct {
+	repoRequest
+	secretscan.SettingsInput
+}
+
+type updateSpaceSecretScanSettingsRequest struct {
+	spaceRequest
+	secretscan.SettingsInput
+}
+
+type secretScanRequest struct {
+	repoRequest
+	ID int64 `path:"secret_scan_id"`
+}
+
+var queryParameterSecretScanID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamScanID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only the secrets found by this secret scan are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterSecretFindingSource = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSource,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only the secrets found by this source are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.SecretFindingSource("").Enum(),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func secretScanOperations(reflector *openapi3.Reflector) {
+	findRepoSettings := openapi3.Operation{}
+	findRepoSettings.WithTags("secret_scanning")
+	findRepoSettings.WithMapOfAnything(map[string]interface{}{"operationId": "findRepoSecretScanSettings"})
+	_ = reflector.SetRequest(&findRepoSettings, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&findRepoSettings, new(controllersecretscan.RepoSettingsOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&findRepoSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&findRepoSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&findRepoSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&findRepoSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/secret-scanning/settings", findRepoSettings)
+
+	updateRepoSettings := openapi3.Operation{}
+	updateRepoSettings.WithTags("secret_scanning")
+	updateRepoSettings.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoSecretScanSettings"})
+	_ = reflector.SetRequest(&updateRepoSettings, new(updateRepoSecretScanSettingsRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&updateRepoSettings, new(types.SecretScanSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateRepoSettings, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateRepoSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateRepoSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateRepoSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateRepoSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/secret-scanning/settings", updateRepoSettings)
+
+	deleteRepoSettings := openapi3.Operation{}
+	deleteRepoSettings.WithTags("secret_scanning")
+	deleteRepoSettings.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoSecretScanSettings"})
+	_ = reflector.SetRequest(&deleteRepoSettings, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteRepoSettings, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteRepoSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteRepoSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteRepoSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&deleteRepoSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/secret-scanning/settings", deleteRepoSettings)
+
+	findSpaceSettings := openapi3.Operation{}
+	findSpaceSettings.WithTags("secret_scanning")
+	findSpaceSettings.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceSecretScanSettings"})
+	_ = reflector.SetRequest(&findSpaceSettings, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&findSpaceSettings, new(types.SecretScanSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&findSpaceSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&findSpaceSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&findSpaceSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&findSpaceSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/secret-scanning/settings", findSpaceSettings)
+
+	updateSpaceSettings := openapi3.Operation{}
+	updateSpaceSettings.WithTags("secret_scanning")
+	updateSpaceSettings.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceSecretScanSettings"})
+	_ = reflector.SetRequest(&updateSpaceSettings, new(updateSpaceSecretScanSettingsRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&updateSpaceSettings, new(types.SecretScanSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updateSpaceSettings, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updateSpaceSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updateSpaceSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updateSpaceSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updateSpaceSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/secret-scanning/settings",
+		updateSpaceSettings)
+
+	deleteSpaceSettings := openapi3.Operation{}
+	deleteSpaceSettings.WithTags("secret_scanning")
+	deleteSpaceSettings.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceSecretScanSettings"})
+	_ = reflector.SetRequest(&deleteSpaceSettings, new(spaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&deleteSpaceSettings, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&deleteSpaceSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&deleteSpaceSettings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&deleteSpaceSettings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&deleteSpaceSettings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/secret-scanning/settings",
+		deleteSpaceSettings)
+
+	startScan := openapi3.Operation{}
+	startScan.WithTags("secret_scanning")
+	startScan.WithMapOfAnything(map[string]interface{}{"operationId": "startSecretScan"})
+	_ = reflector.SetRequest(&startScan, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&startScan, new(types.SecretScan), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&startScan, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&startScan, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&startScan, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&startScan, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&startScan, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/secret-scanning/scans", startScan)
+
+	listScans := openapi3.Operation{}
+	listScans.WithTags("secret_scanning")
+	listScans.WithMapOfAnything(map[string]interface{}{"operationId": "listSecretScans"})
+	listScans.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listScans, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listScans, new([]types.SecretScan), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listScans, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listScans, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listScans, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listScans, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/secret-scanning/scans", listScans)
+
+	findScan := openapi3.Operation{}
+	findScan.WithTags("secret_scanning")
+	findScan.WithMapOfAnything(map[string]interface{}{"operationId": "findSecretScan"})
+	_ = reflector.SetRequest(&findScan, new(secretScanRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&findScan, new(types.SecretScan), http.StatusOK)
+	_ = reflector.SetJSONResponse(&findScan, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&findScan, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&findScan, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&findScan, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&findScan, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/secret-scanning/scans/{secret_scan_id}",
+		findScan)
+
+	listFindings := openapi3.Operation{}
+	listFindings.WithTags("secret_scanning")
+	listFindings.WithMapOfAnything(map[string]interface{}{"operationId": "listSecretFindings"})
+	listFindings.WithParameters(queryParameterSecretScanID, queryParameterSecretFindingSource,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&listFindings, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listFindings, new([]types.SecretFinding), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listFindings, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/secret-scanning/findings", listFindings)
+}
@@ -148,6 +148,35 @@ var queryParameterSortMembershipUsers = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQueryLabel = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the label keys are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterInheritedLabel = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamInherited,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Whether to include the labels inherited from the parent spaces."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 //nolint:funlen // api spec generation no need for checking func complexity
 func spaceOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
@@ -383,4 +412,184 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/members", opMembershipList)
+
+	opSigningKeyFind := openapi3.Operation{}
+	opSigningKeyFind.WithTags("space")
+	opSigningKeyFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSpaceSigningKey"})
+	_ = reflector.SetRequest(&opSigningKeyFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(types.ServerSigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/signing-key", opSigningKeyFind)
+
+	opSigningKeySet := openapi3.Operation{}
+	opSigningKeySet.WithTags("space")
+	opSigningKeySet.WithMapOfAnything(map[string]interface{}{"operationId": "setSpaceSigningKey"})
+	_ = reflector.SetRequest(&opSigningKeySet, &struct {
+		spaceRequest
+		space.SigningKeySetInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(types.ServerSigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/signing-key", opSigningKeySet)
+
+	opSigningKeyDelete := openapi3.Operation{}
+	opSigningKeyDelete.WithTags("space")
+	opSigningKeyDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceSigningKey"})
+	_ = reflector.SetRequest(&opSigningKeyDelete, new(spaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/signing-key", opSigningKeyDelete)
+
+	opLabelList := openapi3.Operation{}
+	opLabelList.WithTags("space")
+	opLabelList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceLabels"})
+	opLabelList.WithParameters(queryParameterQueryLabel, queryParameterInheritedLabel,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opLabelList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLabelList, new([]types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/labels", opLabelList)
+
+	opLabelDefine := openapi3.Operation{}
+	opLabelDefine.WithTags("space")
+	opLabelDefine.WithMapOfAnything(map[string]interface{}{"operationId": "defineSpaceLabel"})
+	_ = reflector.SetRequest(&opLabelDefine, &struct {
+		spaceRequest
+		space.LabelDefineInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(types.Label), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opLabelDefine, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/labels", opLabelDefine)
+
+	opLabelUpdate := openapi3.Operation{}
+	opLabelUpdate.WithTags("space")
+	opLabelUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceLabel"})
+	_ = reflector.SetRequest(&opLabelUpdate, &struct {
+		spaceRequest
+		LabelID int64 `path:"label_id"`
+		space.LabelUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(types.Label), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opLabelUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/labels/{label_id}", opLabelUpdate)
+
+	opLabelDelete := openapi3.Operation{}
+	opLabelDelete.WithTags("space")
+	opLabelDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceLabel"})
+	_ = reflector.SetRequest(&opLabelDelete, &struct {
+		spaceRequest
+		LabelID int64 `path:"label_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opLabelDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLabelDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/labels/{label_id}", opLabelDelete)
+
+	opRuleAdd := openapi3.Operation{}
+	opRuleAdd.WithTags("space")
+	opRuleAdd.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleAdd"})
+	_ = reflector.SetRequest(&opRuleAdd, struct {
+		spaceRequest
+		space.RuleCreateInput
+
+		// overshadow "definition"
+		Type       ruleType       `json:"type"`
+		Definition ruleDefinition `json:"definition"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRuleAdd, rule{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRuleAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRuleAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRuleAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRuleAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/rules", opRuleAdd)
+
+	opRuleDelete := openapi3.Operation{}
+	opRuleDelete.WithTags("space")
+	opRuleDelete.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleDelete"})
+	_ = reflector.SetRequest(&opRuleDelete, struct {
+		spaceRequest
+		RuleIdentifier string `path:"rule_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRuleDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRuleDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRuleDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRuleDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRuleDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/rules/{rule_identifier}", opRuleDelete)
+
+	opRuleUpdate := openapi3.Operation{}
+	opRuleUpdate.WithTags("space")
+	opRuleUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleUpdate"})
+	_ = reflector.SetRequest(&opRuleUpdate, &struct {
+		spaceRequest
+		Identifier string `path:"rule_identifier"`
+		space.RuleUpdateInput
+
+		// overshadow Type and Definition to enable oneof.
+		Type       ruleType       `json:"type"`
+		Definition ruleDefinition `json:"definition"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opRuleUpdate, rule{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRuleUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRuleUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRuleUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRuleUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/rules/{rule_identifier}", opRuleUpdate)
+
+	opRuleList := openapi3.Operation{}
+	opRuleList.WithTags("space")
+	opRuleList.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleList"})
+	opRuleList.WithParameters(
+		queryParameterQueryRuleList, queryParameterInheritedRuleList,
+		queryParameterOrder, queryParameterSortRuleList,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opRuleList, &struct {
+		spaceRequest
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRuleList, []rule{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRuleList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRuleList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRuleList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRuleList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/rules", opRuleList)
+
+	opRuleGet := openapi3.Operation{}
+	opRuleGet.WithTags("space")
+	opRuleGet.WithMapOfAnything(map[string]interface{}{"operationId": "spaceRuleGet"})
+	_ = reflector.SetRequest(&opRuleGet, &struct {
+		spaceRequest
+		Identifier string `path:"rule_identifier"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRuleGet, rule{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRuleGet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRuleGet, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRuleGet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRuleGet, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/rules/{rule_identifier}", opRuleGet)
 }
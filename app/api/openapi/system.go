@@ -17,8 +17,10 @@ package openapi
 import (
 	"net/http"
 
+	controllersystem "github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/handler/system"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
 
 	"github.com/swaggest/openapi-go/openapi3"
 )
@@ -34,4 +36,34 @@ func buildSystem(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/config", opGetConfig)
+
+	opSigningKeyFind := openapi3.Operation{}
+	opSigningKeyFind.WithTags("admin")
+	opSigningKeyFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindSigningKey"})
+	_ = reflector.SetRequest(&opSigningKeyFind, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(types.ServerSigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/signing-key", opSigningKeyFind)
+
+	opSigningKeySet := openapi3.Operation{}
+	opSigningKeySet.WithTags("admin")
+	opSigningKeySet.WithMapOfAnything(map[string]interface{}{"operationId": "adminSetSigningKey"})
+	_ = reflector.SetRequest(&opSigningKeySet, new(controllersystem.SigningKeySetInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(types.ServerSigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSigningKeySet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/admin/signing-key", opSigningKeySet)
+
+	opSigningKeyDelete := openapi3.Operation{}
+	opSigningKeyDelete.WithTags("admin")
+	opSigningKeyDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteSigningKey"})
+	_ = reflector.SetRequest(&opSigningKeyDelete, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/signing-key", opSigningKeyDelete)
 }
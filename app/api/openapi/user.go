@@ -31,6 +31,37 @@ type createTokenRequest struct {
 	user.CreateTokenInput
 }
 
+type createPublicKeyRequest struct {
+	user.CreatePublicKeyInput
+}
+
+type deletePublicKeyRequest struct {
+	Identifier string `path:"public_key_identifier"`
+}
+
+type createSigningKeyRequest struct {
+	user.CreateSigningKeyInput
+}
+
+type deleteSigningKeyRequest struct {
+	Identifier string `path:"signing_key_identifier"`
+}
+
+var queryParameterSigningKeyType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the signing keys to return."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.SigningKeyType("").Enum(),
+			},
+		},
+	},
+}
+
 var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -88,6 +119,62 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opToken, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/token", opToken)
 
+	opListKeys := openapi3.Operation{}
+	opListKeys.WithTags("user")
+	opListKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listPublicKeys"})
+	_ = reflector.SetRequest(&opListKeys, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListKeys, new([]types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opListKeys)
+
+	opCreateKey := openapi3.Operation{}
+	opCreateKey.WithTags("user")
+	opCreateKey.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
+	_ = reflector.SetRequest(&opCreateKey, new(createPublicKeyRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateKey, new(types.PublicKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateKey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreateKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/keys", opCreateKey)
+
+	opDeleteKey := openapi3.Operation{}
+	opDeleteKey.WithTags("user")
+	opDeleteKey.WithMapOfAnything(map[string]interface{}{"operationId": "deletePublicKey"})
+	_ = reflector.SetRequest(&opDeleteKey, new(deletePublicKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/keys/{public_key_identifier}", opDeleteKey)
+
+	opListSigningKeys := openapi3.Operation{}
+	opListSigningKeys.WithTags("user")
+	opListSigningKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listSigningKeys"})
+	opListSigningKeys.WithParameters(queryParameterSigningKeyType)
+	_ = reflector.SetRequest(&opListSigningKeys, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSigningKeys, new([]types.SigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSigningKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/signing-keys", opListSigningKeys)
+
+	opCreateSigningKey := openapi3.Operation{}
+	opCreateSigningKey.WithTags("user")
+	opCreateSigningKey.WithMapOfAnything(map[string]interface{}{"operationId": "createSigningKey"})
+	_ = reflector.SetRequest(&opCreateSigningKey, new(createSigningKeyRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateSigningKey, new(types.SigningKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateSigningKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateSigningKey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreateSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/signing-keys", opCreateSigningKey)
+
+	opDeleteSigningKey := openapi3.Operation{}
+	opDeleteSigningKey.WithTags("user")
+	opDeleteSigningKey.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSigningKey"})
+	_ = reflector.SetRequest(&opDeleteSigningKey, new(deleteSigningKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/user/signing-keys/{signing_key_identifier}", opDeleteSigningKey)
+
 	opMemberSpaces := openapi3.Operation{}
 	opMemberSpaces.WithTags("user")
 	opMemberSpaces.WithMapOfAnything(map[string]interface{}{"operationId": "membershipSpaces"})
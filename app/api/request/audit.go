@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	QueryParamSpaceID = "space_id"
+	QueryParamActorID = "actor_id"
+)
+
+// ParseAuditEventFilter extracts the audit event query parameters from the url.
+func ParseAuditEventFilter(r *http.Request) (*types.AuditEventFilter, error) {
+	spaceID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSpaceID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	repoID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamRepoID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamActorID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	since, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSince, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	until, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamUntil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AuditEventFilter{
+		Pagination: ParsePaginationFromRequest(r),
+		SpaceID:    spaceID,
+		RepoID:     repoID,
+		ActorID:    actorID,
+		Since:      since,
+		Until:      until,
+	}, nil
+}
@@ -38,6 +38,7 @@ const (
 	QueryParamCommitter     = "committer"
 	QueryParamInternal      = "internal"
 	QueryParamService       = "service"
+	QueryParamPrefix        = "prefix"
 	HeaderParamGitProtocol  = "Git-Protocol"
 )
 
@@ -49,6 +50,17 @@ func GetIncludeCommitFromQueryOrDefault(r *http.Request, deflt bool) (bool, erro
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeCommit, deflt)
 }
 
+// GetArchivePrefixFromQuery returns the optional path prefix of the files of an archive.
+func GetArchivePrefixFromQuery(r *http.Request) string {
+	return QueryParamOrDefault(r, QueryParamPrefix, "")
+}
+
+// GetArchivePathsFromQuery returns the optional list of paths an archive is restricted to.
+func GetArchivePathsFromQuery(r *http.Request) []string {
+	paths, _ := QueryParamList(r, QueryParamPath)
+	return paths
+}
+
 func GetCommitSHAFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamCommitSHA)
 }
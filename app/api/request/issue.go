@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamIssueNumber    = "issue_number"
+	PathParamIssueCommentID = "issue_comment_id"
+	PathParamAssigneeID     = "assignee_id"
+	PathParamMilestoneID    = "milestone_id"
+
+	QueryParamAssigneeID  = "assignee_id"
+	QueryParamMilestoneID = "milestone_id"
+)
+
+func GetIssueNumberFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamIssueNumber)
+}
+
+func GetIssueCommentIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamIssueCommentID)
+}
+
+func GetAssigneeIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamAssigneeID)
+}
+
+func GetMilestoneIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamMilestoneID)
+}
+
+// ParseSortIssue extracts the issue sort parameter from the url.
+func ParseSortIssue(r *http.Request) enum.IssueSort {
+	result, _ := enum.IssueSort(r.URL.Query().Get(QueryParamSort)).Sanitize()
+	return result
+}
+
+// parseIssueStates extracts the issue states from the url.
+func parseIssueStates(r *http.Request) []enum.IssueState {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	m := make(map[enum.IssueState]struct{}) // use map to eliminate duplicates
+	for _, s := range strStates {
+		if state, ok := enum.IssueState(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+
+	states := make([]enum.IssueState, 0, len(m))
+	for s := range m {
+		states = append(states, s)
+	}
+
+	return states
+}
+
+// ParseIssueFilter extracts the issue query parameters from the url.
+func ParseIssueFilter(r *http.Request) (*types.IssueFilter, error) {
+	// created_by is optional, skipped if set to 0
+	createdBy, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamCreatedBy, 0)
+	if err != nil {
+		return nil, err
+	}
+	// assignee_id is optional, skipped if set to 0
+	assigneeID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAssigneeID, 0)
+	if err != nil {
+		return nil, err
+	}
+	// milestone_id is optional, skipped if set to 0
+	milestoneID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamMilestoneID, 0)
+	if err != nil {
+		return nil, err
+	}
+	labelIDs, err := parseLabelIDs(r)
+	if err != nil {
+		return nil, err
+	}
+	return &types.IssueFilter{
+		Page:        ParsePage(r),
+		Size:        ParseLimit(r),
+		Query:       ParseQuery(r),
+		CreatedBy:   createdBy,
+		States:      parseIssueStates(r),
+		AssigneeID:  assigneeID,
+		MilestoneID: milestoneID,
+		LabelIDs:    labelIDs,
+		Sort:        ParseSortIssue(r),
+		Order:       ParseOrder(r),
+	}, nil
+}
+
+// ParseIssueActivityFilter extracts the issue activity query parameters from the url.
+func ParseIssueActivityFilter(r *http.Request) (*types.IssueActivityFilter, error) {
+	// after is optional, skipped if set to 0
+	after, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAfter, 0)
+	if err != nil {
+		return nil, err
+	}
+	// before is optional, skipped if set to 0
+	before, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamBefore, 0)
+	if err != nil {
+		return nil, err
+	}
+	// limit is optional, skipped if set to 0
+	limit, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &types.IssueActivityFilter{
+		After:  after,
+		Before: before,
+		Limit:  int(limit),
+		Types:  parseIssueActivityTypes(r),
+		Kinds:  parseIssueActivityKinds(r),
+	}, nil
+}
+
+// parseIssueActivityKinds extracts the issue activity kinds from the url.
+func parseIssueActivityKinds(r *http.Request) []enum.IssueActivityKind {
+	m := make(map[enum.IssueActivityKind]struct{}) // use map to eliminate duplicates
+	for _, s := range r.URL.Query()[QueryParamKind] {
+		if kind, ok := enum.IssueActivityKind(s).Sanitize(); ok {
+			m[kind] = struct{}{}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	kinds := make([]enum.IssueActivityKind, 0, len(m))
+	for k := range m {
+		kinds = append(kinds, k)
+	}
+
+	return kinds
+}
+
+// parseIssueActivityTypes extracts the issue activity types from the url.
+func parseIssueActivityTypes(r *http.Request) []enum.IssueActivityType {
+	m := make(map[enum.IssueActivityType]struct{}) // use map to eliminate duplicates
+	for _, s := range r.URL.Query()[QueryParamType] {
+		if t, ok := enum.IssueActivityType(s).Sanitize(); ok {
+			m[t] = struct{}{}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	activityTypes := make([]enum.IssueActivityType, 0, len(m))
+	for t := range m {
+		activityTypes = append(activityTypes, t)
+	}
+
+	return activityTypes
+}
+
+// parseMilestoneStates extracts the milestone states from the url.
+func parseMilestoneStates(r *http.Request) []enum.MilestoneState {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	m := make(map[enum.MilestoneState]struct{}) // use map to eliminate duplicates
+	for _, s := range strStates {
+		if state, ok := enum.MilestoneState(s).Sanitize(); ok {
+			m[state] = struct{}{}
+		}
+	}
+
+	states := make([]enum.MilestoneState, 0, len(m))
+	for s := range m {
+		states = append(states, s)
+	}
+
+	return states
+}
+
+// ParseMilestoneFilter extracts the milestone query parameters from the url.
+func ParseMilestoneFilter(r *http.Request) *types.MilestoneFilter {
+	return &types.MilestoneFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		States:          parseMilestoneStates(r),
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	PathParamLFSObjectID = "lfs_object_id"
+	PathParamLFSLockID   = "lfs_lock_id"
+
+	QueryParamLFSLockID     = "id"
+	QueryParamLFSObjectSize = "size"
+	QueryParamCursor        = "cursor"
+)
+
+// GetLFSObjectIDFromPath extracts the LFS object ID from the url.
+func GetLFSObjectIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamLFSObjectID)
+}
+
+// GetLFSLockIDFromPath extracts the LFS lock ID from the url.
+func GetLFSLockIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamLFSLockID)
+}
+
+// GetLFSObjectSizeFromQuery extracts the declared size of an LFS object from the url.
+func GetLFSObjectSizeFromQuery(r *http.Request) (int64, error) {
+	value, err := QueryParamOrError(r, QueryParamLFSObjectSize)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size < 0 {
+		return 0, usererror.BadRequestf("Parameter '%s' must be a non-negative integer.", QueryParamLFSObjectSize)
+	}
+
+	return size, nil
+}
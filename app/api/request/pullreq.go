@@ -70,6 +70,10 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 	if err != nil {
 		return nil, err
 	}
+	labelIDs, err := parseLabelIDs(r)
+	if err != nil {
+		return nil, err
+	}
 	return &types.PullReqFilter{
 		Page:          ParsePage(r),
 		Size:          ParseLimit(r),
@@ -81,6 +85,7 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		States:        parsePullReqStates(r),
 		Sort:          ParseSortPullReq(r),
 		Order:         ParseOrder(r),
+		LabelIDs:      labelIDs,
 	}, nil
 }
 
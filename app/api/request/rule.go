@@ -28,13 +28,38 @@ const (
 )
 
 // ParseRuleFilter extracts the protection rule query parameters from the url.
-func ParseRuleFilter(r *http.Request) *types.RuleFilter {
+func ParseRuleFilter(r *http.Request) (*types.RuleFilter, error) {
+	inherited, err := QueryParamAsBoolOrDefault(r, QueryParamInherited, false)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.RuleFilter{
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
 		States:          parseRuleStates(r),
 		Sort:            parseRuleSort(r),
 		Order:           ParseOrder(r),
+		Inherited:       inherited,
+	}, nil
+}
+
+// ParseRuleMonitorViolationFilter extracts the recorded protection rule violation query parameters from the url.
+func ParseRuleMonitorViolationFilter(r *http.Request) (*types.RuleMonitorViolationFilter, error) {
+	since, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSince, 0)
+	if err != nil {
+		return nil, err
 	}
+
+	until, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamUntil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RuleMonitorViolationFilter{
+		Pagination: ParsePaginationFromRequest(r),
+		Since:      since,
+		Until:      until,
+	}, nil
 }
 
 // parseRuleStates extracts the protection rule states from the url.
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamSecretScanID = "secret_scan_id"
+
+	QueryParamScanID = "scan_id"
+	QueryParamSource = "source"
+)
+
+func GetSecretScanIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamSecretScanID)
+}
+
+// ParseSecretFindingFilter extracts the secret finding query parameters from the url.
+func ParseSecretFindingFilter(r *http.Request) (*types.SecretFindingFilter, error) {
+	scanID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamScanID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var source enum.SecretFindingSource
+	if s := r.URL.Query().Get(QueryParamSource); s != "" {
+		var ok bool
+		source, ok = enum.SecretFindingSource(s).Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Invalid secret finding source %q.", s)
+		}
+	}
+
+	return &types.SecretFindingFilter{
+		Pagination: ParsePaginationFromRequest(r),
+		ScanID:     scanID,
+		Source:     source,
+	}, nil
+}
@@ -70,6 +70,9 @@ var (
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
+	// ErrPullMirrorReadOnly is returned if a user tries to push to a repository that is a pull mirror.
+	ErrPullMirrorReadOnly = New(http.StatusBadRequest, "The repository is a pull mirror and is read-only")
+
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
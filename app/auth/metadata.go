@@ -46,3 +46,12 @@ type MembershipMetadata struct {
 func (m *MembershipMetadata) ImpactsAuthorization() bool {
 	return true
 }
+
+// PublicKeyMetadata contains information about the ssh public key that was used during auth.
+type PublicKeyMetadata struct {
+	PublicKeyID int64
+}
+
+func (m *PublicKeyMetadata) ImpactsAuthorization() bool {
+	return false
+}
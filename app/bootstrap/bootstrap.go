@@ -41,6 +41,12 @@ func NewSystemServiceSession() *auth.Session {
 	}
 }
 
+// SetSystemServicePrincipal sets the principal representing gitness.
+// It's set during bootstrap and only has to be called explicitly outside of it (e.g. in tests).
+func SetSystemServicePrincipal(principal *types.Principal) {
+	systemServicePrincipal = principal
+}
+
 // pipelineServicePrincipal is the principal that is used during
 // pipeline executions for calling gitness APIs.
 var pipelineServicePrincipal *types.Principal
@@ -20,6 +20,8 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/app/services/signature"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
@@ -52,6 +54,10 @@ func ProvideController(
 	protectionManager *protection.Manager,
 	githookFactory hook.ClientFactory,
 	limiter limiter.ResourceLimiter,
+	lfsLockStore store.LFSLockStore,
+	mirrorStore store.RepoMirrorStore,
+	signatureService *signature.Service,
+	secretScanService *secretscan.Service,
 ) *githook.Controller {
 	ctrl := githook.NewController(
 		authorizer,
@@ -62,7 +68,11 @@ func ProvideController(
 		pullreqStore,
 		urlProvider,
 		protectionManager,
-		limiter)
+		limiter,
+		lfsLockStore,
+		mirrorStore,
+		signatureService,
+		secretScanService)
 
 	// TODO: improve wiring if possible
 	if fct, ok := githookFactory.(*ControllerClientFactory); ok {
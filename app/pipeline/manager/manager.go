@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -133,6 +134,7 @@ type Manager struct {
 	Pipelines        store.PipelineStore
 	urlProvider      urlprovider.Provider
 	Checks           store.CheckStore
+	CheckEvents      *checkevents.Reporter
 	// Converter  store.ConvertService
 	SSEStreamer sse.Streamer
 	// Globals    store.GlobalSecretStore
@@ -161,6 +163,7 @@ func New(
 	logStore store.LogStore,
 	logStream livelog.LogStream,
 	checkStore store.CheckStore,
+	checkEvReporter *checkevents.Reporter,
 	repoStore store.RepoStore,
 	scheduler scheduler.Scheduler,
 	secretStore store.SecretStore,
@@ -179,6 +182,7 @@ func New(
 		Logs:             logStore,
 		Logz:             logStream,
 		Checks:           checkStore,
+		CheckEvents:      checkEvReporter,
 		Repos:            repoStore,
 		Scheduler:        scheduler,
 		Secrets:          secretStore,
@@ -442,6 +446,7 @@ func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 	s := &setup{
 		Executions:  m.Executions,
 		Checks:      m.Checks,
+		CheckEvents: m.CheckEvents,
 		Pipelines:   m.Pipelines,
 		SSEStreamer: m.SSEStreamer,
 		Repos:       m.Repos,
@@ -459,6 +464,7 @@ func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 		Executions:  m.Executions,
 		Pipelines:   m.Pipelines,
 		Checks:      m.Checks,
+		CheckEvents: m.CheckEvents,
 		SSEStreamer: m.SSEStreamer,
 		Logs:        m.Logz,
 		Repos:       m.Repos,
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -32,6 +33,7 @@ import (
 type setup struct {
 	Executions  store.ExecutionStore
 	Checks      store.CheckStore
+	CheckEvents *checkevents.Reporter
 	SSEStreamer sse.Streamer
 	Pipelines   store.PipelineStore
 	Repos       store.RepoStore
@@ -98,7 +100,7 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 	// try to write to the checks store - if not, log an error and continue
-	err = checks.Write(ctx, s.Checks, execution, pipeline)
+	err = checks.Write(ctx, s.Checks, s.CheckEvents, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: could not write to checks store")
 	}
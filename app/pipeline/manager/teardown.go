@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
@@ -36,6 +37,7 @@ import (
 type teardown struct {
 	Executions  store.ExecutionStore
 	Checks      store.CheckStore
+	CheckEvents *checkevents.Reporter
 	Pipelines   store.PipelineStore
 	SSEStreamer sse.Streamer
 	Logs        livelog.LogStream
@@ -180,7 +182,7 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 	// try to write to the checks store - if not, log an error and continue
-	err = checks.Write(ctx, t.Checks, execution, pipeline)
+	err = checks.Write(ctx, t.Checks, t.CheckEvents, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: could not write to checks store")
 	}
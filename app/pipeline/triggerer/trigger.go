@@ -21,6 +21,7 @@ import (
 	"runtime/debug"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
@@ -82,6 +83,7 @@ type Triggerer interface {
 type triggerer struct {
 	executionStore   store.ExecutionStore
 	checkStore       store.CheckStore
+	checkEvReporter  *checkevents.Reporter
 	stageStore       store.StageStore
 	tx               dbtx.Transactor
 	pipelineStore    store.PipelineStore
@@ -97,6 +99,7 @@ type triggerer struct {
 func New(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
+	checkEvReporter *checkevents.Reporter,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
 	tx dbtx.Transactor,
@@ -111,6 +114,7 @@ func New(
 	return &triggerer{
 		executionStore:   executionStore,
 		checkStore:       checkStore,
+		checkEvReporter:  checkEvReporter,
 		stageStore:       stageStore,
 		scheduler:        scheduler,
 		urlProvider:      urlProvider,
@@ -355,7 +359,7 @@ func (t *triggerer) Trigger(
 	}
 
 	// try to write to check store. log on failure but don't error out the execution
-	err = checks.Write(ctx, t.checkStore, execution, pipeline)
+	err = checks.Write(ctx, t.checkStore, t.checkEvReporter, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: could not write to check store")
 	}
@@ -571,7 +575,7 @@ func (t *triggerer) createExecutionWithError(
 	}
 
 	// try to write to check store, log on failure
-	err = checks.Write(ctx, t.checkStore, execution, pipeline)
+	err = checks.Write(ctx, t.checkStore, t.checkEvReporter, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: failed to update check")
 	}
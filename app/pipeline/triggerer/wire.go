@@ -15,6 +15,7 @@
 package triggerer
 
 import (
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
@@ -34,6 +35,7 @@ var WireSet = wire.NewSet(
 func ProvideTriggerer(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
+	checkEvReporter *checkevents.Reporter,
 	stageStore store.StageStore,
 	tx dbtx.Transactor,
 	pipelineStore store.PipelineStore,
@@ -45,7 +47,7 @@ func ProvideTriggerer(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 ) Triggerer {
-	return New(executionStore, checkStore, stageStore, pipelineStore,
+	return New(executionStore, checkStore, checkEvReporter, stageStore, pipelineStore,
 		tx, repoStore, urlProvider, scheduler, fileService, converterService,
 		templateStore, pluginStore)
 }
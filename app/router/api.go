@@ -19,18 +19,22 @@ import (
 	"fmt"
 	"net/http"
 
+	controlleraudit "github.com/harness/gitness/app/api/controller/audit"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
+	"github.com/harness/gitness/app/api/controller/issue"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/mirror"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/secret"
+	"github.com/harness/gitness/app/api/controller/secretscan"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/controller/system"
@@ -40,12 +44,15 @@ import (
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
+	handleraudit "github.com/harness/gitness/app/api/handler/audit"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
+	handlerissue "github.com/harness/gitness/app/api/handler/issue"
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
+	handlermirror "github.com/harness/gitness/app/api/handler/mirror"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
@@ -53,6 +60,7 @@ import (
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	"github.com/harness/gitness/app/api/handler/resource"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
+	handlersecretscan "github.com/harness/gitness/app/api/handler/secretscan"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
 	handlersystem "github.com/harness/gitness/app/api/handler/system"
@@ -64,6 +72,7 @@ import (
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
 	"github.com/harness/gitness/app/api/middleware/address"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	"github.com/harness/gitness/app/api/middleware/clientinfo"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
@@ -106,13 +115,17 @@ func NewAPIHandler(
 	templateCtrl *template.Controller,
 	pluginCtrl *plugin.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
+	mirrorCtrl *mirror.Controller,
+	secretScanCtrl *secretscan.Controller,
 	githookCtrl *controllergithook.Controller,
 	saCtrl *serviceaccount.Controller,
 	userCtrl *user.Controller,
 	principalCtrl principal.Controller,
 	checkCtrl *check.Controller,
 	sysCtrl *system.Controller,
+	auditCtrl *controlleraudit.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
 ) APIHandler {
@@ -129,6 +142,7 @@ func NewAPIHandler(
 	r.Use(logging.HLogRequestIDHandler())
 	r.Use(logging.HLogAccessLogHandler())
 	r.Use(address.Handler("", ""))
+	r.Use(clientinfo.Handler(config.Server.HTTP.TrustedProxies))
 
 	// configure cors middleware
 	r.Use(corsHandler(config))
@@ -138,9 +152,9 @@ func NewAPIHandler(
 
 	r.Route("/v1", func(r chi.Router) {
 		setupRoutesV1(r, appCtx, config, repoCtrl, executionCtrl, triggerCtrl, logCtrl, pipelineCtrl,
-			connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
-			webhookCtrl, githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, uploadCtrl,
-			searchCtrl)
+			connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl, issueCtrl,
+			webhookCtrl, mirrorCtrl, secretScanCtrl, githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl,
+			sysCtrl, auditCtrl, uploadCtrl, searchCtrl)
 	})
 
 	// wrap router in terminatedPath encoder.
@@ -175,19 +189,23 @@ func setupRoutesV1(r chi.Router,
 	secretCtrl *secret.Controller,
 	spaceCtrl *space.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
+	mirrorCtrl *mirror.Controller,
+	secretScanCtrl *secretscan.Controller,
 	githookCtrl *controllergithook.Controller,
 	saCtrl *serviceaccount.Controller,
 	userCtrl *user.Controller,
 	principalCtrl principal.Controller,
 	checkCtrl *check.Controller,
 	sysCtrl *system.Controller,
+	auditCtrl *controlleraudit.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
 ) {
-	setupSpaces(r, appCtx, spaceCtrl)
-	setupRepos(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, pullreqCtrl, webhookCtrl, checkCtrl,
-		uploadCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, secretScanCtrl)
+	setupRepos(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, pullreqCtrl, issueCtrl, webhookCtrl,
+		mirrorCtrl, secretScanCtrl, checkCtrl, uploadCtrl)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
@@ -195,7 +213,7 @@ func setupRoutesV1(r chi.Router,
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, sysCtrl, auditCtrl)
 	setupAccount(r, userCtrl, sysCtrl, config)
 	setupSystem(r, config, sysCtrl)
 	setupResources(r)
@@ -204,7 +222,12 @@ func setupRoutesV1(r chi.Router,
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
-func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controller) {
+func setupSpaces(
+	r chi.Router,
+	appCtx context.Context,
+	spaceCtrl *space.Controller,
+	secretScanCtrl *secretscan.Controller,
+) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerspace.HandleCreate(spaceCtrl))
@@ -229,6 +252,37 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 			r.Post("/export", handlerspace.HandleExport(spaceCtrl))
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
 
+			r.Route("/signing-key", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleFindSigningKey(spaceCtrl))
+				r.Put("/", handlerspace.HandleSetSigningKey(spaceCtrl))
+				r.Delete("/", handlerspace.HandleDeleteSigningKey(spaceCtrl))
+			})
+
+			r.Route("/labels", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleListLabels(spaceCtrl))
+				r.Post("/", handlerspace.HandleDefineLabel(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleUpdateLabel(spaceCtrl))
+					r.Delete("/", handlerspace.HandleDeleteLabel(spaceCtrl))
+				})
+			})
+
+			r.Route("/rules", func(r chi.Router) {
+				r.Post("/", handlerspace.HandleRuleCreate(spaceCtrl))
+				r.Get("/", handlerspace.HandleRuleList(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamRuleIdentifier), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleRuleUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleRuleDelete(spaceCtrl))
+					r.Get("/", handlerspace.HandleRuleFind(spaceCtrl))
+				})
+			})
+
+			r.Route("/secret-scanning/settings", func(r chi.Router) {
+				r.Get("/", handlersecretscan.HandleSpaceSettingsFind(secretScanCtrl))
+				r.Put("/", handlersecretscan.HandleSpaceSettingsUpdate(secretScanCtrl))
+				r.Delete("/", handlersecretscan.HandleSpaceSettingsDelete(secretScanCtrl))
+			})
+
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
 				r.Post("/", handlerspace.HandleMembershipAdd(spaceCtrl))
@@ -248,7 +302,10 @@ func setupRepos(r chi.Router,
 	triggerCtrl *trigger.Controller,
 	logCtrl *logs.Controller,
 	pullreqCtrl *pullreq.Controller,
+	issueCtrl *issue.Controller,
 	webhookCtrl *webhook.Controller,
+	mirrorCtrl *mirror.Controller,
+	secretScanCtrl *secretscan.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
 ) {
@@ -265,7 +322,9 @@ func setupRepos(r chi.Router,
 			r.Post("/restore", handlerrepo.HandleRestore(repoCtrl))
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
+			r.Post("/fork", handlerrepo.HandleFork(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
+			r.Get("/labels", handlerrepo.HandleListLabels(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
 
@@ -288,12 +347,18 @@ func setupRepos(r chi.Router,
 				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
 			})
 
+			r.Route("/archive", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleArchive(repoCtrl))
+			})
+
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
 
 				r.Post("/calculate-divergence", handlerrepo.HandleCalculateCommitDivergence(repoCtrl))
 				r.Post("/", handlerrepo.HandleCommitFiles(repoCtrl))
+				r.Post("/cherry-pick", handlerrepo.HandleCherryPick(repoCtrl))
+				r.Post("/revert", handlerrepo.HandleRevert(repoCtrl))
 
 				// per commit operations
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
@@ -333,10 +398,18 @@ func setupRepos(r chi.Router,
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
 
+			r.Get("/merge-queue", handlerpullreq.HandleMergeQueueList(pullreqCtrl))
+
 			SetupPullReq(r, pullreqCtrl)
 
+			SetupIssue(r, issueCtrl)
+
 			SetupWebhook(r, webhookCtrl)
 
+			SetupMirror(r, mirrorCtrl)
+
+			SetupSecretScanning(r, secretScanCtrl)
+
 			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
 
 			SetupChecks(r, checkCtrl)
@@ -482,6 +555,49 @@ func SetupGitHooks(r chi.Router, githookCtrl *controllergithook.Controller) {
 	})
 }
 
+func SetupIssue(r chi.Router, issueCtrl *issue.Controller) {
+	r.Route("/issues", func(r chi.Router) {
+		r.Post("/", handlerissue.HandleCreate(issueCtrl))
+		r.Get("/", handlerissue.HandleList(issueCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamIssueNumber), func(r chi.Router) {
+			r.Get("/", handlerissue.HandleFind(issueCtrl))
+			r.Patch("/", handlerissue.HandleUpdate(issueCtrl))
+			r.Post("/state", handlerissue.HandleState(issueCtrl))
+			r.Put("/milestone", handlerissue.HandleMilestoneSet(issueCtrl))
+			r.Get("/activities", handlerissue.HandleActivityList(issueCtrl))
+			r.Route("/comments", func(r chi.Router) {
+				r.Post("/", handlerissue.HandleCommentCreate(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamIssueCommentID), func(r chi.Router) {
+					r.Patch("/", handlerissue.HandleCommentUpdate(issueCtrl))
+					r.Delete("/", handlerissue.HandleCommentDelete(issueCtrl))
+				})
+			})
+			r.Route("/assignees", func(r chi.Router) {
+				r.Post("/", handlerissue.HandleAssigneeAdd(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamAssigneeID), func(r chi.Router) {
+					r.Delete("/", handlerissue.HandleAssigneeDelete(issueCtrl))
+				})
+			})
+			r.Route("/labels", func(r chi.Router) {
+				r.Post("/", handlerissue.HandleLabelAssign(issueCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Delete("/", handlerissue.HandleLabelUnassign(issueCtrl))
+				})
+			})
+		})
+	})
+
+	r.Route("/milestones", func(r chi.Router) {
+		r.Get("/", handlerissue.HandleMilestoneList(issueCtrl))
+		r.Post("/", handlerissue.HandleMilestoneCreate(issueCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamMilestoneID), func(r chi.Router) {
+			r.Patch("/", handlerissue.HandleMilestoneUpdate(issueCtrl))
+			r.Delete("/", handlerissue.HandleMilestoneDelete(issueCtrl))
+		})
+	})
+}
+
 func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
@@ -494,6 +610,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Get("/activities", handlerpullreq.HandleListActivities(pullreqCtrl))
 			r.Route("/comments", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleCommentCreate(pullreqCtrl))
+				r.Post("/apply-suggestions", handlerpullreq.HandleCommentApplySuggestions(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqCommentID), func(r chi.Router) {
 					r.Patch("/", handlerpullreq.HandleCommentUpdate(pullreqCtrl))
 					r.Delete("/", handlerpullreq.HandleCommentDelete(pullreqCtrl))
@@ -507,10 +624,32 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 					r.Delete("/", handlerpullreq.HandleReviewerDelete(pullreqCtrl))
 				})
 			})
+			r.Route("/labels", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleLabelList(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleLabelAssign(pullreqCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamLabelID), func(r chi.Router) {
+					r.Delete("/", handlerpullreq.HandleLabelUnassign(pullreqCtrl))
+				})
+			})
 			r.Route("/reviews", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
+				r.Delete("/pending", handlerpullreq.HandleReviewDiscard(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Post("/revert", handlerpullreq.HandleRevert(pullreqCtrl))
+			r.Post("/update-branch", handlerpullreq.HandleUpdateBranch(pullreqCtrl))
+			r.Route("/conflicts", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleConflicts(pullreqCtrl))
+				r.Post("/resolve", handlerpullreq.HandleResolveConflicts(pullreqCtrl))
+			})
+			r.Route("/merge-queue", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleMergeQueueEnqueue(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeQueueDequeue(pullreqCtrl))
+			})
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 
@@ -548,6 +687,38 @@ func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 	})
 }
 
+func SetupMirror(r chi.Router, mirrorCtrl *mirror.Controller) {
+	r.Route("/mirrors", func(r chi.Router) {
+		r.Post("/", handlermirror.HandleCreate(mirrorCtrl))
+		r.Get("/", handlermirror.HandleList(mirrorCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamMirrorID), func(r chi.Router) {
+			r.Get("/", handlermirror.HandleFind(mirrorCtrl))
+			r.Patch("/", handlermirror.HandleUpdate(mirrorCtrl))
+			r.Delete("/", handlermirror.HandleDelete(mirrorCtrl))
+			r.Post("/sync", handlermirror.HandleSync(mirrorCtrl))
+		})
+	})
+}
+
+func SetupSecretScanning(r chi.Router, secretScanCtrl *secretscan.Controller) {
+	r.Route("/secret-scanning", func(r chi.Router) {
+		r.Route("/settings", func(r chi.Router) {
+			r.Get("/", handlersecretscan.HandleRepoSettingsFind(secretScanCtrl))
+			r.Put("/", handlersecretscan.HandleRepoSettingsUpdate(secretScanCtrl))
+			r.Delete("/", handlersecretscan.HandleRepoSettingsDelete(secretScanCtrl))
+		})
+
+		r.Route("/scans", func(r chi.Router) {
+			r.Post("/", handlersecretscan.HandleScanStart(secretScanCtrl))
+			r.Get("/", handlersecretscan.HandleScanList(secretScanCtrl))
+			r.Get(fmt.Sprintf("/{%s}", request.PathParamSecretScanID), handlersecretscan.HandleScanFind(secretScanCtrl))
+		})
+
+		r.Get("/findings", handlersecretscan.HandleFindingList(secretScanCtrl))
+	})
+}
+
 func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	r.Route("/checks", func(r chi.Router) {
 		r.Get("/recent", handlercheck.HandleCheckListRecent(checkCtrl))
@@ -566,8 +737,10 @@ func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 			r.Patch("/", handlerrepo.HandleRuleUpdate(repoCtrl))
 			r.Delete("/", handlerrepo.HandleRuleDelete(repoCtrl))
 			r.Get("/", handlerrepo.HandleRuleFind(repoCtrl))
+			r.Get("/violations", handlerrepo.HandleRuleViolationList(repoCtrl))
 		})
 	})
+	r.Post("/rules-dry-run", handlerrepo.HandleRuleDryRun(repoCtrl))
 }
 
 func setupUser(r chi.Router, userCtrl *user.Controller) {
@@ -598,6 +771,28 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 				r.Delete("/", handleruser.HandleDeleteToken(userCtrl, enum.TokenTypeSession))
 			})
 		})
+
+		// SSH public keys
+		r.Route("/keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListPublicKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreatePublicKey(userCtrl))
+
+			// per key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeletePublicKey(userCtrl))
+			})
+		})
+
+		// GPG and SSH keys used to sign commits and tags
+		r.Route("/signing-keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListSigningKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreateSigningKey(userCtrl))
+
+			// per key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamSigningKeyIdentifier), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeleteSigningKey(userCtrl))
+			})
+		})
 	})
 }
 
@@ -646,12 +841,26 @@ func setupPrincipals(r chi.Router, principalCtrl principal.Controller) {
 }
 
 func setupKeywordSearch(r chi.Router, searchCtrl *keywordsearch.Controller) {
-	r.Post("/search", handlerkeywordsearch.HandleSearch(searchCtrl))
+	r.Route("/search", func(r chi.Router) {
+		r.Post("/", handlerkeywordsearch.HandleSearch(searchCtrl))
+		r.With(middlewareprincipal.RestrictToAdmin()).
+			Post("/reindex", handlerkeywordsearch.HandleReindex(searchCtrl))
+	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(
+	r chi.Router,
+	userCtrl *user.Controller,
+	sysCtrl *system.Controller,
+	auditCtrl *controlleraudit.Controller,
+) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
+		r.Route("/signing-key", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleFindSigningKey(sysCtrl))
+			r.Put("/", handlersystem.HandleSetSigningKey(sysCtrl))
+			r.Delete("/", handlersystem.HandleDeleteSigningKey(sysCtrl))
+		})
 		r.Route("/users", func(r chi.Router) {
 			r.Get("/", users.HandleList(userCtrl))
 			r.Post("/", users.HandleCreate(userCtrl))
@@ -663,6 +872,10 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+		r.Route("/audit-events", func(r chi.Router) {
+			r.Get("/", handleraudit.HandleList(auditCtrl))
+			r.Get("/export", handleraudit.HandleExport(auditCtrl))
+		})
 	})
 }
 
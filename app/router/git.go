@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/repo"
+	handlerlfs "github.com/harness/gitness/app/api/handler/lfs"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
@@ -44,6 +46,7 @@ func NewGitHandler(
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
 ) GitHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -79,6 +82,11 @@ func NewGitHandler(
 				enum.GitServiceTypeReceivePack, repoCtrl, urlProvider))
 			r.Get("/info/refs", handlerrepo.HandleGitInfoRefs(repoCtrl, urlProvider))
 
+			// git LFS
+			r.Route("/info/lfs", func(r chi.Router) {
+				setupLFS(r, lfsCtrl, urlProvider)
+			})
+
 			// dumb protocol
 			r.Get("/HEAD", stubGitHandler())
 			r.Get("/objects/info/alternates", stubGitHandler())
@@ -95,6 +103,22 @@ func NewGitHandler(
 	return encode.GitPathBefore(r)
 }
 
+func setupLFS(r chi.Router, lfsCtrl *lfs.Controller, urlProvider url.Provider) {
+	r.Route("/objects", func(r chi.Router) {
+		r.Post("/batch", handlerlfs.HandleBatch(lfsCtrl, urlProvider))
+		r.Get(fmt.Sprintf("/{%s}", request.PathParamLFSObjectID), handlerlfs.HandleDownload(lfsCtrl, urlProvider))
+		r.Put(fmt.Sprintf("/{%s}", request.PathParamLFSObjectID), handlerlfs.HandleUpload(lfsCtrl, urlProvider))
+	})
+
+	r.Route("/locks", func(r chi.Router) {
+		r.Get("/", handlerlfs.HandleLockList(lfsCtrl, urlProvider))
+		r.Post("/", handlerlfs.HandleLockCreate(lfsCtrl, urlProvider))
+		r.Post("/verify", handlerlfs.HandleLockVerify(lfsCtrl, urlProvider))
+		r.Post(fmt.Sprintf("/{%s}/unlock", request.PathParamLFSLockID),
+			handlerlfs.HandleLockUnlock(lfsCtrl, urlProvider))
+	})
+}
+
 func stubGitHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte("Seems like an asteroid destroyed the ancient git protocol"))
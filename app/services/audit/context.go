@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import "context"
+
+type clientInfoKey struct{}
+
+type clientInfo struct {
+	ip        string
+	userAgent string
+}
+
+// WithClientInfo returns a copy of the context with the client IP address and user agent
+// that are recorded with the audit events.
+func WithClientInfo(ctx context.Context, ip, userAgent string) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, clientInfo{ip: ip, userAgent: userAgent})
+}
+
+// ClientInfoFrom returns the client IP address and user agent stored in the context.
+func ClientInfoFrom(ctx context.Context) (string, string) {
+	info, _ := ctx.Value(clientInfoKey{}).(clientInfo)
+	return info.ip, info.userAgent
+}
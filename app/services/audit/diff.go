@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ignoredFields are fields that change with every update and carry no information for the audit log.
+var ignoredFields = map[string]struct{}{
+	"updated": {},
+	"version": {},
+}
+
+// diff returns JSON representations of the before and after values.
+// If both values are JSON objects, only the top-level fields that differ are kept.
+func diff(before, after any) (json.RawMessage, json.RawMessage, error) {
+	beforeRaw, err := marshal(before)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal the before value: %w", err)
+	}
+
+	afterRaw, err := marshal(after)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal the after value: %w", err)
+	}
+
+	if beforeRaw == nil || afterRaw == nil {
+		return beforeRaw, afterRaw, nil
+	}
+
+	var beforeFields, afterFields map[string]json.RawMessage
+
+	if json.Unmarshal(beforeRaw, &beforeFields) != nil || json.Unmarshal(afterRaw, &afterFields) != nil {
+		return beforeRaw, afterRaw, nil
+	}
+
+	beforeDiff := make(map[string]json.RawMessage)
+	afterDiff := make(map[string]json.RawMessage)
+
+	for key, beforeValue := range beforeFields {
+		if _, ok := ignoredFields[key]; ok {
+			continue
+		}
+
+		afterValue, ok := afterFields[key]
+		if !ok {
+			beforeDiff[key] = beforeValue
+			continue
+		}
+
+		if !bytes.Equal(beforeValue, afterValue) {
+			beforeDiff[key] = beforeValue
+			afterDiff[key] = afterValue
+		}
+	}
+
+	for key, afterValue := range afterFields {
+		if _, ok := ignoredFields[key]; ok {
+			continue
+		}
+
+		if _, ok := beforeFields[key]; !ok {
+			afterDiff[key] = afterValue
+		}
+	}
+
+	// the errors are ignored because the maps contain only valid JSON values.
+	beforeRaw, _ = json.Marshal(beforeDiff)
+	afterRaw, _ = json.Marshal(afterDiff)
+
+	return beforeRaw, afterRaw, nil
+}
+
+func marshal(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+
+	return raw, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	type object struct {
+		Name    string `json:"name"`
+		Admin   bool   `json:"admin"`
+		Updated int64  `json:"updated"`
+		Deleted *int64 `json:"deleted,omitempty"`
+	}
+
+	deleted := int64(42)
+
+	tests := []struct {
+		name      string
+		before    any
+		after     any
+		expBefore string
+		expAfter  string
+	}{
+		{
+			name:      "create",
+			before:    nil,
+			after:     object{Name: "a"},
+			expBefore: "",
+			expAfter:  `{"name":"a","admin":false,"updated":0}`,
+		},
+		{
+			name:      "delete-typed-nil",
+			before:    object{Name: "a"},
+			after:     (*object)(nil),
+			expBefore: `{"name":"a","admin":false,"updated":0}`,
+			expAfter:  "",
+		},
+		{
+			name:      "update-changed-field",
+			before:    object{Name: "a", Updated: 1},
+			after:     object{Name: "a", Admin: true, Updated: 2},
+			expBefore: `{"admin":false}`,
+			expAfter:  `{"admin":true}`,
+		},
+		{
+			name:      "update-added-field",
+			before:    object{Name: "a"},
+			after:     object{Name: "a", Deleted: &deleted},
+			expBefore: `{}`,
+			expAfter:  `{"deleted":42}`,
+		},
+		{
+			name:      "not-objects",
+			before:    []int{1},
+			after:     []int{2},
+			expBefore: `[1]`,
+			expAfter:  `[2]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before, after, err := diff(test.before, test.after)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(before) != test.expBefore {
+				t.Errorf("before: got=%s want=%s", before, test.expBefore)
+			}
+
+			if string(after) != test.expAfter {
+				t.Errorf("after: got=%s want=%s", after, test.expAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Event describes a change to be recorded in the audit log.
+type Event struct {
+	ActorID      int64
+	Action       enum.AuditAction
+	ResourceType enum.AuditResourceType
+	Resource     string
+
+	// SpaceID and RepoID are optional, zero values mean that the resource doesn't belong to a space or a repository.
+	SpaceID int64
+	RepoID  int64
+
+	// Before and After are the states of the resource before and after the change.
+	// Either of them can be nil, e.g. when the resource is created or deleted.
+	// Only the fields that differ are stored.
+	Before any
+	After  any
+}
+
+type Service struct {
+	auditEventStore store.AuditEventStore
+}
+
+func NewService(auditEventStore store.AuditEventStore) *Service {
+	return &Service{
+		auditEventStore: auditEventStore,
+	}
+}
+
+// Log appends the event to the audit log. The client information is taken from the context.
+// Failures are only logged because they must not affect the operation that has already been performed.
+func (s *Service) Log(ctx context.Context, event Event) {
+	before, after, err := diff(event.Before, event.After)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("resource", event.Resource).
+			Msg("failed to calculate difference for audit event")
+	}
+
+	clientIP, userAgent := ClientInfoFrom(ctx)
+
+	auditEvent := &types.AuditEvent{
+		Created:      time.Now().UnixMilli(),
+		ActorID:      event.ActorID,
+		Action:       event.Action,
+		ResourceType: event.ResourceType,
+		Resource:     event.Resource,
+		SpaceID:      optionalID(event.SpaceID),
+		RepoID:       optionalID(event.RepoID),
+		Before:       before,
+		After:        after,
+		ClientIP:     clientIP,
+		UserAgent:    userAgent,
+	}
+
+	if err = s.auditEventStore.Create(ctx, auditEvent); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("action", string(event.Action)).
+			Str("resource_type", string(event.ResourceType)).
+			Str("resource", event.Resource).
+			Msg("failed to record audit event")
+	}
+}
+
+func optionalID(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
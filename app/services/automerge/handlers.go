@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventAutoMergeArmed tries to merge a pull request right after auto-merge has been enabled for it.
+func (s *Service) handleEventAutoMergeArmed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.AutoMergeArmedPayload],
+) error {
+	return s.tryMerge(ctx, event.Payload.PullReqID)
+}
+
+// handleEventBranchUpdated disables auto-merge if new commits have been pushed to the pull request
+// by someone else than the principal who enabled auto-merge. Otherwise, it tries to merge the pull request.
+func (s *Service) handleEventBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.AutoMerge == nil || pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	if pr.AutoMerge.RequestedBy != event.Payload.PrincipalID {
+		return s.disable(ctx, pr, event.Payload.PrincipalID, &types.PullRequestActivityPayloadAutoMerge{
+			Action: enum.AutoMergeActionDisarmed,
+			Reason: "New commits have been pushed to the pull request by another user.",
+		})
+	}
+
+	return s.tryMerge(ctx, pr.ID)
+}
+
+// handleEventReviewSubmitted tries to merge a pull request after a review has been submitted.
+func (s *Service) handleEventReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	if event.Payload.Decision != enum.PullReqReviewDecisionApproved {
+		return nil
+	}
+
+	return s.tryMerge(ctx, event.Payload.PullReqID)
+}
+
+// handleEventCheckReported tries to merge the pull requests waiting for the status checks of the reported commit.
+func (s *Service) handleEventCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if event.Payload.Status != enum.CheckStatusSuccess {
+		return nil
+	}
+
+	prs, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		TargetRepoID: event.Payload.RepoID,
+		SourceSHA:    event.Payload.CommitSHA,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		AutoMerge:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests with auto-merge enabled: %w", err)
+	}
+
+	// status checks of pull requests from forks can be reported for the source repository.
+	forkPRs, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		SourceRepoID: event.Payload.RepoID,
+		SourceSHA:    event.Payload.CommitSHA,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		AutoMerge:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests of the source repository with auto-merge enabled: %w", err)
+	}
+
+	for _, pr := range forkPRs {
+		if pr.TargetRepoID != pr.SourceRepoID {
+			prs = append(prs, pr)
+		}
+	}
+
+	for _, pr := range prs {
+		if err = s.tryMerge(ctx, pr.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// errAutoMergeDisabled is returned from the update function if auto-merge has been disabled in the meantime.
+var errAutoMergeDisabled = errors.New("auto-merge is disabled")
+
+// tryMerge merges the pull request on behalf of the principal who enabled auto-merge
+// if all requirements for merging are satisfied.
+// If the pull request can't be merged anymore, for example because of merge conflicts, auto-merge is disabled.
+func (s *Service) tryMerge(ctx context.Context, pullreqID int64) error {
+	pr, err := s.pullreqStore.Find(ctx, pullreqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.AutoMerge == nil || pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	autoMerge := *pr.AutoMerge
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, autoMerge.RequestedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal who enabled auto-merge: %w", err)
+	}
+
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  nil,
+	}
+
+	// the principal could have lost the permission to merge since auto-merge has been enabled.
+	allowed, err := s.canMerge(ctx, session, repo)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return s.disable(ctx, pr, autoMerge.RequestedBy, &types.PullRequestActivityPayloadAutoMerge{
+			Action: enum.AutoMergeActionDisarmed,
+			Method: autoMerge.Method,
+			Reason: "The user who enabled auto-merge isn't allowed to merge the pull request anymore.",
+		})
+	}
+
+	_, violations, err := s.pullreqCtrl.MergeNoAuth(ctx, session, repo, pr.Number, &pullreq.MergeInput{
+		Method:    autoMerge.Method,
+		SourceSHA: pr.SourceSHA,
+		Title:     autoMerge.Title,
+		Message:   autoMerge.Message,
+	})
+	if err != nil {
+		// errors caused by the state of the pull request can't be fixed by retrying.
+		if uErr := usererror.Translate(err); uErr.Status < http.StatusInternalServerError &&
+			uErr != usererror.ErrResourceLocked {
+			return s.disable(ctx, pr, autoMerge.RequestedBy, &types.PullRequestActivityPayloadAutoMerge{
+				Action: enum.AutoMergeActionFailed,
+				Method: autoMerge.Method,
+				Reason: uErr.Message,
+			})
+		}
+
+		return fmt.Errorf("failed to auto-merge pull request: %w", err)
+	}
+
+	if violations != nil {
+		if len(violations.ConflictFiles) > 0 {
+			return s.disable(ctx, pr, autoMerge.RequestedBy, &types.PullRequestActivityPayloadAutoMerge{
+				Action: enum.AutoMergeActionFailed,
+				Method: autoMerge.Method,
+				Reason: "Merge conflicts: " + strings.Join(violations.ConflictFiles, ", "),
+			})
+		}
+
+		if protection.RequiresMergeQueue(violations.RuleViolations) {
+			return s.disable(ctx, pr, autoMerge.RequestedBy, &types.PullRequestActivityPayloadAutoMerge{
+				Action: enum.AutoMergeActionDisarmed,
+				Method: autoMerge.Method,
+				Reason: "The pull request has to be merged through the merge queue.",
+			})
+		}
+
+		// not all rules are satisfied yet, the merge is attempted again on the next relevant event.
+		log.Ctx(ctx).Debug().Msgf("pull request %d can't be auto-merged yet", pr.Number)
+
+		return nil
+	}
+
+	// the pull request has been merged and auto-merge has been cleared with it.
+	pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to get pull request activity number")
+		return nil
+	}
+
+	payload := &types.PullRequestActivityPayloadAutoMerge{
+		Action: enum.AutoMergeActionMerged,
+		Method: autoMerge.Method,
+	}
+	if _, err = s.activityStore.CreateWithPayload(ctx, pr, autoMerge.RequestedBy, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request auto-merge activity")
+	}
+
+	return nil
+}
+
+// canMerge returns true if the principal of the session is still allowed to merge pull requests of the repository.
+func (s *Service) canMerge(ctx context.Context, session *auth.Session, repo *types.Repository) (bool, error) {
+	if session.Principal.Blocked {
+		return false, nil
+	}
+
+	err := apiauth.CheckRepo(ctx, s.authorizer, session, repo, enum.PermissionRepoPush, false)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check merge permission of principal who enabled auto-merge: %w", err)
+	}
+
+	return true, nil
+}
+
+// disable disables auto-merge for the pull request and writes the provided activity.
+func (s *Service) disable(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadAutoMerge,
+) error {
+	pr, err := s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.AutoMerge == nil {
+			return errAutoMergeDisabled
+		}
+
+		pr.AutoMerge = nil
+		pr.ActivitySeq++ // because we need to add the activity entry
+		return nil
+	})
+	if errors.Is(err, errAutoMergeDisabled) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to disable auto-merge: %w", err)
+	}
+
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, principalID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msg("failed to write pull request auto-merge activity")
+	}
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+)
+
+const (
+	eventsReaderGroupName = "gitness:automerge"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service merges pull requests with auto-merge enabled as soon as all requirements for merging are satisfied.
+// Merging is attempted whenever a pull request event or a status check report could have changed the outcome.
+type Service struct {
+	config         Config
+	authorizer     authz.Authorizer
+	pullreqCtrl    *pullreq.Controller
+	pullreqStore   store.PullReqStore
+	repoStore      store.RepoStore
+	principalStore store.PrincipalStore
+	activityStore  store.PullReqActivityStore
+	sseStreamer    sse.Streamer
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	authorizer authz.Authorizer,
+	pullreqCtrl *pullreq.Controller,
+	pullreqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	activityStore store.PullReqActivityStore,
+	sseStreamer sse.Streamer,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided auto-merge service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:         config,
+		authorizer:     authorizer,
+		pullreqCtrl:    pullreqCtrl,
+		pullreqStore:   pullreqStore,
+		repoStore:      repoStore,
+		principalStore: principalStore,
+		activityStore:  activityStore,
+		sseStreamer:    sseStreamer,
+	}
+
+	const idleTimeout = 1 * time.Minute
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterAutoMergeArmed(service.handleEventAutoMergeArmed)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterReviewSubmitted(service.handleEventReviewSubmitted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for auto-merge: %w", err)
+	}
+
+	_, err = checkEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterReported(service.handleEventCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for auto-merge: %w", err)
+	}
+
+	return service, nil
+}
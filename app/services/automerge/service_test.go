@@ -0,0 +1,311 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeAuthorizer struct {
+	authz.Authorizer
+	allowed bool
+}
+
+func (a *fakeAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	_ enum.Permission,
+) (bool, error) {
+	return a.allowed, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	prs   map[int64]*types.PullReq
+	found []int64
+}
+
+func (s *fakePullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	s.found = append(s.found, id)
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return pr, nil
+}
+
+func (s *fakePullReqStore) List(_ context.Context, filter *types.PullReqFilter) ([]*types.PullReq, error) {
+	var prs []*types.PullReq
+	for _, pr := range s.prs {
+		if filter.TargetRepoID != 0 && pr.TargetRepoID != filter.TargetRepoID ||
+			filter.SourceRepoID != 0 && pr.SourceRepoID != filter.SourceRepoID ||
+			filter.SourceSHA != "" && pr.SourceSHA != filter.SourceSHA ||
+			filter.AutoMerge && pr.AutoMerge == nil {
+			continue
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+func (s *fakePullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	if err := mutateFn(pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	return &types.Repository{ID: id, ParentID: 1, Path: "space/repo"}, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	principal *types.Principal
+}
+
+func (s *fakePrincipalStore) Find(context.Context, int64) (*types.Principal, error) {
+	return s.principal, nil
+}
+
+type fakeActivityStore struct {
+	store.PullReqActivityStore
+	payloads []*types.PullRequestActivityPayloadAutoMerge
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	_ context.Context,
+	_ *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+) (*types.PullReqActivity, error) {
+	s.payloads = append(s.payloads, payload.(*types.PullRequestActivityPayloadAutoMerge))
+	return &types.PullReqActivity{}, nil
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+}
+
+func (fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) error {
+	return nil
+}
+
+const (
+	testRequesterID = 10
+	testOtherUserID = 20
+)
+
+func setupService(
+	prs map[int64]*types.PullReq,
+	principal *types.Principal,
+	allowed bool,
+) (*Service, *fakePullReqStore, *fakeActivityStore) {
+	pullreqStore := &fakePullReqStore{prs: prs}
+	activityStore := &fakeActivityStore{}
+
+	s := &Service{
+		authorizer:     &fakeAuthorizer{allowed: allowed},
+		pullreqStore:   pullreqStore,
+		repoStore:      &fakeRepoStore{},
+		principalStore: &fakePrincipalStore{principal: principal},
+		activityStore:  activityStore,
+		sseStreamer:    fakeStreamer{},
+	}
+
+	return s, pullreqStore, activityStore
+}
+
+func autoMergePR(id, sourceRepoID, targetRepoID int64) *types.PullReq {
+	return &types.PullReq{
+		ID:           id,
+		Number:       id,
+		State:        enum.PullReqStateOpen,
+		SourceRepoID: sourceRepoID,
+		TargetRepoID: targetRepoID,
+		SourceSHA:    "sha",
+		AutoMerge: &types.AutoMerge{
+			Method:      enum.MergeMethodMerge,
+			RequestedBy: testRequesterID,
+		},
+	}
+}
+
+func TestService_tryMerge_DisarmsWithoutPermission(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *types.Principal
+		allowed   bool
+	}{
+		{
+			name:      "principal lost push permission",
+			principal: &types.Principal{ID: testRequesterID},
+			allowed:   false,
+		},
+		{
+			name:      "principal is blocked",
+			principal: &types.Principal{ID: testRequesterID, Blocked: true},
+			allowed:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := autoMergePR(1, 1, 1)
+			s, _, activityStore := setupService(map[int64]*types.PullReq{1: pr}, test.principal, test.allowed)
+
+			if err := s.tryMerge(context.Background(), pr.ID); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if pr.AutoMerge != nil {
+				t.Errorf("expected auto-merge to be disabled")
+			}
+
+			if len(activityStore.payloads) != 1 || activityStore.payloads[0].Action != enum.AutoMergeActionDisarmed {
+				t.Errorf("got=%+v want a single disarmed activity", activityStore.payloads)
+			}
+		})
+	}
+}
+
+func TestService_tryMerge_IgnoresPullReqWithoutAutoMerge(t *testing.T) {
+	pr := autoMergePR(1, 1, 1)
+	pr.AutoMerge = nil
+	s, _, activityStore := setupService(map[int64]*types.PullReq{1: pr}, &types.Principal{ID: testRequesterID}, false)
+
+	if err := s.tryMerge(context.Background(), pr.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(activityStore.payloads) != 0 {
+		t.Errorf("expected no activity, got=%+v", activityStore.payloads)
+	}
+}
+
+func TestService_handleEventBranchUpdated_DisarmsOnPushByOtherUser(t *testing.T) {
+	pr := autoMergePR(1, 1, 1)
+	s, _, activityStore := setupService(map[int64]*types.PullReq{1: pr}, &types.Principal{ID: testRequesterID}, true)
+
+	err := s.handleEventBranchUpdated(context.Background(), &events.Event[*pullreqevents.BranchUpdatedPayload]{
+		Payload: &pullreqevents.BranchUpdatedPayload{
+			Base: pullreqevents.Base{PullReqID: pr.ID, PrincipalID: testOtherUserID},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pr.AutoMerge != nil {
+		t.Errorf("expected auto-merge to be disabled")
+	}
+	if len(activityStore.payloads) != 1 || activityStore.payloads[0].Action != enum.AutoMergeActionDisarmed {
+		t.Errorf("got=%+v want a single disarmed activity", activityStore.payloads)
+	}
+}
+
+func TestService_handleEventCheckReported(t *testing.T) {
+	const (
+		upstreamRepoID = 1
+		forkRepoID     = 2
+	)
+
+	prs := map[int64]*types.PullReq{
+		1: autoMergePR(1, upstreamRepoID, upstreamRepoID),
+		2: autoMergePR(2, forkRepoID, upstreamRepoID),
+		3: autoMergePR(3, forkRepoID, forkRepoID),
+	}
+	prs[4] = autoMergePR(4, upstreamRepoID, upstreamRepoID)
+	prs[4].AutoMerge = nil
+
+	tests := []struct {
+		name    string
+		repoID  int64
+		status  enum.CheckStatus
+		wantPRs []int64
+	}{
+		{
+			name:    "check reported on the target repository",
+			repoID:  upstreamRepoID,
+			status:  enum.CheckStatusSuccess,
+			wantPRs: []int64{1, 2},
+		},
+		{
+			name:    "check reported on the source repository of a fork",
+			repoID:  forkRepoID,
+			status:  enum.CheckStatusSuccess,
+			wantPRs: []int64{2, 3},
+		},
+		{
+			name:   "failed check",
+			repoID: upstreamRepoID,
+			status: enum.CheckStatusFailure,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// without permission auto-merge is disarmed, so every considered pull request is found exactly once.
+			s, pullreqStore, _ := setupService(prs, &types.Principal{ID: testRequesterID}, false)
+			for _, pr := range prs {
+				if pr.ID != 4 {
+					pr.AutoMerge = &types.AutoMerge{Method: enum.MergeMethodMerge, RequestedBy: testRequesterID}
+				}
+			}
+
+			err := s.handleEventCheckReported(context.Background(), &events.Event[*checkevents.ReportedPayload]{
+				Payload: &checkevents.ReportedPayload{
+					RepoID:    test.repoID,
+					CommitSHA: "sha",
+					Status:    test.status,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := pullreqStore.found
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+
+			if !reflect.DeepEqual(got, test.wantPRs) {
+				t.Errorf("got=%v want=%v", got, test.wantPRs)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeAuditEvents        = "gitness:cleanup:audit-events"
+	jobCronAuditEvents        = "33 1 * * *" // At 01:33 every day.
+	jobMaxDurationAuditEvents = 5 * time.Minute
+)
+
+type auditEventsCleanupJob struct {
+	retentionTime time.Duration
+
+	auditEventStore store.AuditEventStore
+}
+
+func newAuditEventsCleanupJob(
+	retentionTime time.Duration,
+	auditEventStore store.AuditEventStore,
+) *auditEventsCleanupJob {
+	return &auditEventsCleanupJob{
+		retentionTime: retentionTime,
+
+		auditEventStore: auditEventStore,
+	}
+}
+
+// Handle purges old audit events that are past the retention time.
+func (j *auditEventsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging audit events older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n, err := j.auditEventStore.DeleteOld(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old audit events: %w", err)
+	}
+
+	result := "no old audit events found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d audit events", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -34,6 +34,7 @@ func ProvideService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	auditEventStore store.AuditEventStore,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -43,5 +44,6 @@ func ProvideService(
 		tokenStore,
 		repoStore,
 		repoCtrl,
+		auditEventStore,
 	)
 }
@@ -37,6 +37,8 @@ type hunkHeaderFetcher interface {
 // MigrateNew updates the "+" (the added lines) part of code comments
 // after a new commit on the pull request's source branch.
 // The parameter newSHA should contain the latest commit SHA of the pull request's source branch.
+// Code comments with changed lines are marked as outdated, which makes their suggestions outdated too,
+// because a suggestion can be applied only to the exact lines it was written for.
 func (migrator *Migrator) MigrateNew(
 	ctx context.Context,
 	repoGitUID string,
@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecomments
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+)
+
+const (
+	suggestionFenceOpen  = "```suggestion"
+	suggestionFenceClose = "```"
+)
+
+// ParseSuggestion returns the content of the suggestion block of a code comment text.
+// The suggestion block is a fenced code block with the "suggestion" info string.
+// The second return value is false if the text doesn't contain a suggestion.
+func ParseSuggestion(text string) (string, bool, error) {
+	var (
+		found   bool
+		inBlock bool
+		content strings.Builder
+	)
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case !inBlock && trimmed == suggestionFenceOpen:
+			if found {
+				return "", false, errors.InvalidArgument("A code comment can contain only one suggestion.")
+			}
+			found = true
+			inBlock = true
+		case inBlock && trimmed == suggestionFenceClose:
+			inBlock = false
+		case inBlock:
+			content.WriteString(strings.TrimRight(line, "\r\n") + "\n")
+		}
+	}
+
+	if inBlock {
+		return "", false, errors.InvalidArgument("The suggestion block is not closed.")
+	}
+
+	return content.String(), found, nil
+}
+
+// Suggestion is a replacement of the lines LineStart to LineEnd (inclusive, 1-based) of a file.
+type Suggestion struct {
+	LineStart int
+	LineEnd   int
+	Content   string
+}
+
+// ApplySuggestions replaces the lines of the file content with the provided suggestions.
+// The suggestions must not overlap.
+func ApplySuggestions(content []byte, suggestions []Suggestion) ([]byte, error) {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	sorted := make([]Suggestion, len(suggestions))
+	copy(sorted, suggestions)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LineStart > sorted[j].LineStart
+	})
+
+	for i, s := range sorted {
+		if s.LineStart < 1 || s.LineEnd < s.LineStart || s.LineEnd > len(lines) {
+			return nil, errors.InvalidArgument("Suggestion for lines %d-%d is outside of the file.",
+				s.LineStart, s.LineEnd)
+		}
+
+		if i > 0 && s.LineEnd >= sorted[i-1].LineStart {
+			return nil, errors.InvalidArgument("Suggestions for lines %d-%d and %d-%d overlap.",
+				s.LineStart, s.LineEnd, sorted[i-1].LineStart, sorted[i-1].LineEnd)
+		}
+	}
+
+	for _, s := range sorted {
+		replacement := strings.SplitAfter(s.Content, "\n")
+		if replacement[len(replacement)-1] == "" {
+			replacement = replacement[:len(replacement)-1]
+		}
+
+		// keep the line ending style and the missing new line at the end of the file.
+		lineEnd := "\n"
+		if strings.HasSuffix(lines[s.LineStart-1], "\r\n") {
+			lineEnd = "\r\n"
+		}
+		for i := range replacement {
+			replacement[i] = strings.TrimRight(replacement[i], "\r\n") + lineEnd
+		}
+		if s.LineEnd == len(lines) && !strings.HasSuffix(lines[s.LineEnd-1], "\n") && len(replacement) > 0 {
+			last := len(replacement) - 1
+			replacement[last] = strings.TrimRight(replacement[last], "\r\n")
+		}
+
+		lines = append(lines[:s.LineStart-1], append(replacement, lines[s.LineEnd:]...)...)
+	}
+
+	return []byte(strings.Join(lines, "")), nil
+}
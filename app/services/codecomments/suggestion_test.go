@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecomments
+
+import (
+	"testing"
+)
+
+func TestParseSuggestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantOK  bool
+		wantErr bool
+	}{
+		{
+			name: "no suggestion",
+			text: "looks good\n```go\nx := 1\n```",
+		},
+		{
+			name:   "suggestion",
+			text:   "rename it\n```suggestion\nfoo := 1\nbar := 2\n```\nthanks",
+			want:   "foo := 1\nbar := 2\n",
+			wantOK: true,
+		},
+		{
+			name:   "empty suggestion removes the lines",
+			text:   "```suggestion\n```",
+			want:   "",
+			wantOK: true,
+		},
+		{
+			name:   "windows line endings",
+			text:   "```suggestion\r\nfoo\r\n```\r\n",
+			want:   "foo\n",
+			wantOK: true,
+		},
+		{
+			name:    "two suggestions",
+			text:    "```suggestion\na\n```\n```suggestion\nb\n```",
+			wantErr: true,
+		},
+		{
+			name:    "not closed",
+			text:    "```suggestion\na\n",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok, err := ParseSuggestion(test.text)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseSuggestion() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if ok != test.wantOK {
+				t.Errorf("ParseSuggestion() ok = %v, want %v", ok, test.wantOK)
+			}
+			if got != test.want {
+				t.Errorf("ParseSuggestion() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestApplySuggestions(t *testing.T) {
+	const content = "1\n2\n3\n4\n5\n"
+
+	tests := []struct {
+		name        string
+		content     string
+		suggestions []Suggestion
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "replace single line",
+			content:     content,
+			suggestions: []Suggestion{{LineStart: 2, LineEnd: 2, Content: "two\n"}},
+			want:        "1\ntwo\n3\n4\n5\n",
+		},
+		{
+			name:    "replace multiple ranges",
+			content: content,
+			suggestions: []Suggestion{
+				{LineStart: 1, LineEnd: 1, Content: "one\n"},
+				{LineStart: 3, LineEnd: 4, Content: "three-four\n"},
+			},
+			want: "one\n2\nthree-four\n5\n",
+		},
+		{
+			name:        "remove lines",
+			content:     content,
+			suggestions: []Suggestion{{LineStart: 2, LineEnd: 3, Content: ""}},
+			want:        "1\n4\n5\n",
+		},
+		{
+			name:        "add lines",
+			content:     content,
+			suggestions: []Suggestion{{LineStart: 5, LineEnd: 5, Content: "5\n6\n7\n"}},
+			want:        "1\n2\n3\n4\n5\n6\n7\n",
+		},
+		{
+			name:        "no new line at the end of file",
+			content:     "1\n2",
+			suggestions: []Suggestion{{LineStart: 2, LineEnd: 2, Content: "two\n"}},
+			want:        "1\ntwo",
+		},
+		{
+			name:        "windows line endings",
+			content:     "1\r\n2\r\n",
+			suggestions: []Suggestion{{LineStart: 1, LineEnd: 1, Content: "one\n"}},
+			want:        "one\r\n2\r\n",
+		},
+		{
+			name:    "overlapping",
+			content: content,
+			suggestions: []Suggestion{
+				{LineStart: 1, LineEnd: 3, Content: "x\n"},
+				{LineStart: 3, LineEnd: 4, Content: "y\n"},
+			},
+			wantErr: true,
+		},
+		{
+			name:        "outside of the file",
+			content:     content,
+			suggestions: []Suggestion{{LineStart: 5, LineEnd: 6, Content: "x\n"}},
+			wantErr:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ApplySuggestions([]byte(test.content), test.suggestions)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ApplySuggestions() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if string(got) != test.want {
+				t.Errorf("ApplySuggestions() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
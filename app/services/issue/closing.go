@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// closingKeywordsRegex matches issue references preceded by a closing keyword, like "fixes #12".
+var closingKeywordsRegex = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+
+// ParseClosingKeywords returns the unique issue numbers referenced with a closing keyword in the texts.
+func ParseClosingKeywords(texts ...string) []int64 {
+	var numbers []int64
+	seen := make(map[int64]struct{})
+
+	for _, text := range texts {
+		for _, match := range closingKeywordsRegex.FindAllStringSubmatch(text, -1) {
+			number, err := strconv.ParseInt(match[1], 10, 64)
+			if err != nil || number <= 0 {
+				continue
+			}
+
+			if _, ok := seen[number]; ok {
+				continue
+			}
+
+			seen[number] = struct{}{}
+			numbers = append(numbers, number)
+		}
+	}
+
+	return numbers
+}
+
+// handleEventPullReqMerged closes the open issues of the target repository that are referenced with
+// a closing keyword in the description of the merged pull request or in the merge commit message.
+func (s *Service) handleEventPullReqMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	texts := []string{pr.Description}
+
+	if event.Payload.MergeSHA != "" {
+		commit, err := s.git.GetCommit(ctx, &git.GetCommitParams{
+			ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+			SHA:        event.Payload.MergeSHA,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to get merge commit to look for closing keywords")
+		} else {
+			texts = append(texts, commit.Commit.Title, commit.Commit.Message)
+		}
+	}
+
+	numbers := ParseClosingKeywords(texts...)
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	issues, err := s.issueStore.ListOpenByNumbers(ctx, repo.ID, numbers)
+	if err != nil {
+		return fmt.Errorf("failed to list issues referenced by the pull request: %w", err)
+	}
+
+	for _, issue := range issues {
+		_, err = s.SetState(ctx, event.Payload.PrincipalID, repo, issue, enum.IssueStateClosed, pr.Number)
+		if err != nil {
+			return fmt.Errorf("failed to close issue %d referenced by the pull request: %w", issue.Number, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	issueevents "github.com/harness/gitness/app/events/issue"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseClosingKeywords(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  []int64
+	}{
+		{
+			name:  "no reference",
+			texts: []string{"Refactor the parser", "see #12"},
+			want:  nil,
+		},
+		{
+			name:  "all keywords",
+			texts: []string{"close #1 closes #2 closed #3 fix #4 fixes #5 fixed #6 resolve #7 resolves #8 resolved #9"},
+			want:  []int64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:  "case insensitive with colon",
+			texts: []string{"FIXES: #12", "Resolves #13."},
+			want:  []int64{12, 13},
+		},
+		{
+			name:  "unique across texts",
+			texts: []string{"fixes #4", "Merge branch\n\nfixes #4, closes #5"},
+			want:  []int64{4, 5},
+		},
+		{
+			name:  "not a keyword",
+			texts: []string{"prefixes #3", "fixes#4", "fixes #0", "fixes #12abc"},
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ParseClosingKeywords(test.texts...)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestService_handleEventPullReqMerged(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		mergeSHA    string
+		commit      *git.Commit
+		want        []int64
+	}{
+		{
+			name:        "no reference",
+			description: "Refactor the parser, see #1",
+			want:        nil,
+		},
+		{
+			name:        "references in description",
+			description: "Fixes #1, closes #2 and resolves #4",
+			want:        []int64{1},
+		},
+		{
+			name:     "references in merge commit",
+			mergeSHA: "1234567890abcdef1234567890abcdef12345678",
+			commit:   &git.Commit{Title: "Fix the parser (#5)", Message: "Fix the parser\n\nfixes #3"},
+			want:     []int64{3},
+		},
+		{
+			name:        "merge commit not found",
+			description: "fixes #3",
+			mergeSHA:    "1234567890abcdef1234567890abcdef12345678",
+			want:        []int64{3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// issue #2 is already closed and issue #4 belongs to another repository.
+			issues := &fakeIssueStore{issues: []*types.Issue{
+				{ID: 1, RepoID: 1, Number: 1, State: enum.IssueStateOpen},
+				{ID: 2, RepoID: 1, Number: 2, State: enum.IssueStateClosed},
+				{ID: 3, RepoID: 1, Number: 3, State: enum.IssueStateOpen},
+				{ID: 4, RepoID: 2, Number: 4, State: enum.IssueStateOpen},
+			}}
+			activities := &fakeActivityStore{}
+
+			sys, err := events.ProvideSystem(events.Config{
+				Mode:            events.ModeInMemory,
+				Namespace:       "test",
+				MaxStreamLength: 100,
+			}, nil)
+			if err != nil {
+				t.Fatalf("failed to create event system: %v", err)
+			}
+
+			eventReporter, err := issueevents.NewReporter(sys)
+			if err != nil {
+				t.Fatalf("failed to create event reporter: %v", err)
+			}
+
+			s := &Service{
+				tx:        fakeTx{},
+				git:       fakeGit{commit: test.commit},
+				repoStore: fakeRepoStore{repo: &types.Repository{ID: 1, ParentID: 1, GitUID: "repo"}},
+				pullreqStore: fakePullReqStore{pr: &types.PullReq{
+					ID: 10, Number: 5, TargetRepoID: 1, Description: test.description,
+				}},
+				issueStore:    issues,
+				activityStore: activities,
+				assigneeStore: fakeAssigneeStore{},
+				labelSvc:      label.NewService(fakeTx{}, nil, nil, nil, fakeIssueLabelStore{}),
+				eventReporter: eventReporter,
+				sseStreamer:   fakeStreamer{},
+			}
+
+			err = s.handleEventPullReqMerged(ctx, &events.Event[*pullreqevents.MergedPayload]{
+				Payload: &pullreqevents.MergedPayload{
+					Base:     pullreqevents.Base{PullReqID: 10, TargetRepoID: 1, PrincipalID: 7, Number: 5},
+					MergeSHA: test.mergeSHA,
+				},
+			})
+			if err != nil {
+				t.Fatalf("failed to handle merged event: %v", err)
+			}
+
+			var closed []int64
+			for _, issue := range issues.issues {
+				if issue.ID == 2 || issue.State != enum.IssueStateClosed {
+					continue
+				}
+				closed = append(closed, issue.Number)
+				if issue.ClosedBy == nil || *issue.ClosedBy != 7 || issue.Closed == nil {
+					t.Errorf("expected issue %d to be closed by 7, got %v", issue.Number, issue.ClosedBy)
+				}
+			}
+
+			if !reflect.DeepEqual(closed, test.want) {
+				t.Errorf("expected closed issues %v, got %v", test.want, closed)
+			}
+
+			if len(activities.payloads) != len(test.want) {
+				t.Fatalf("expected %d activities, got %d", len(test.want), len(activities.payloads))
+			}
+			for _, payload := range activities.payloads {
+				want := types.IssueActivityPayloadStateChange{
+					Old:           enum.IssueStateOpen,
+					New:           enum.IssueStateClosed,
+					PullReqNumber: 5,
+				}
+				if got := *payload.(*types.IssueActivityPayloadStateChange); got != want {
+					t.Errorf("expected activity %+v, got %+v", want, got)
+				}
+			}
+		})
+	}
+}
+
+type fakeTx struct{}
+
+func (fakeTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+}
+
+func (fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) error {
+	return nil
+}
+
+// fakeGit returns the commit for any SHA or an error if there is no commit.
+type fakeGit struct {
+	git.Interface
+	commit *git.Commit
+}
+
+func (g fakeGit) GetCommit(context.Context, *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	if g.commit == nil {
+		return nil, errors.New("commit not found")
+	}
+	return &git.GetCommitOutput{Commit: *g.commit}, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s fakeRepoStore) Find(context.Context, int64) (*types.Repository, error) {
+	return s.repo, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	pr *types.PullReq
+}
+
+func (s fakePullReqStore) Find(context.Context, int64) (*types.PullReq, error) {
+	return s.pr, nil
+}
+
+type fakeIssueStore struct {
+	store.IssueStore
+	issues []*types.Issue
+}
+
+func (s *fakeIssueStore) ListOpenByNumbers(_ context.Context, repoID int64, numbers []int64) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	for _, issue := range s.issues {
+		if issue.RepoID != repoID || issue.State != enum.IssueStateOpen {
+			continue
+		}
+		for _, number := range numbers {
+			if issue.Number == number {
+				issue := *issue
+				issues = append(issues, &issue)
+			}
+		}
+	}
+	return issues, nil
+}
+
+func (s *fakeIssueStore) UpdateOptLock(
+	_ context.Context,
+	issue *types.Issue,
+	mutateFn func(issue *types.Issue) error,
+) (*types.Issue, error) {
+	for i, stored := range s.issues {
+		if stored.ID != issue.ID {
+			continue
+		}
+		updated := *issue
+		if err := mutateFn(&updated); err != nil {
+			return nil, err
+		}
+		updated.Version++
+		s.issues[i] = &updated
+		return &updated, nil
+	}
+	return nil, errors.New("issue not found")
+}
+
+type fakeActivityStore struct {
+	store.IssueActivityStore
+	payloads []types.IssueActivityPayload
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	_ context.Context,
+	issue *types.Issue,
+	principalID int64,
+	payload types.IssueActivityPayload,
+) (*types.IssueActivity, error) {
+	s.payloads = append(s.payloads, payload)
+	return &types.IssueActivity{IssueID: issue.ID, CreatedBy: principalID}, nil
+}
+
+type fakeAssigneeStore struct {
+	store.IssueAssigneeStore
+}
+
+func (fakeAssigneeStore) ListInfo(context.Context, []int64) (map[int64][]*types.PrincipalInfo, error) {
+	return map[int64][]*types.PrincipalInfo{}, nil
+}
+
+type fakeIssueLabelStore struct {
+	store.IssueLabelStore
+}
+
+func (fakeIssueLabelStore) ListInfo(context.Context, []int64) (map[int64][]*types.LabelInfo, error) {
+	return map[int64][]*types.LabelInfo{}, nil
+}
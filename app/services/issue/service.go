@@ -0,0 +1,273 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	issueevents "github.com/harness/gitness/app/events/issue"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:issue"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service holds the issue logic shared between the API and the background processing:
+// it changes the state of issues and closes the issues referenced by merged pull requests.
+type Service struct {
+	config         Config
+	tx             dbtx.Transactor
+	git            git.Interface
+	repoStore      store.RepoStore
+	pullreqStore   store.PullReqStore
+	issueStore     store.IssueStore
+	activityStore  store.IssueActivityStore
+	assigneeStore  store.IssueAssigneeStore
+	milestoneStore store.MilestoneStore
+	labelSvc       *label.Service
+	eventReporter  *issueevents.Reporter
+	sseStreamer    sse.Streamer
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	tx dbtx.Transactor,
+	git git.Interface,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	issueStore store.IssueStore,
+	activityStore store.IssueActivityStore,
+	assigneeStore store.IssueAssigneeStore,
+	milestoneStore store.MilestoneStore,
+	labelSvc *label.Service,
+	eventReporter *issueevents.Reporter,
+	sseStreamer sse.Streamer,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided issue service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:         config,
+		tx:             tx,
+		git:            git,
+		repoStore:      repoStore,
+		pullreqStore:   pullreqStore,
+		issueStore:     issueStore,
+		activityStore:  activityStore,
+		assigneeStore:  assigneeStore,
+		milestoneStore: milestoneStore,
+		labelSvc:       labelSvc,
+		eventReporter:  eventReporter,
+		sseStreamer:    sseStreamer,
+	}
+
+	const idleTimeout = 1 * time.Minute
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for issues: %w", err)
+	}
+
+	return service, nil
+}
+
+// Attach sets the assignees, the labels and the milestone of the issues.
+func (s *Service) Attach(ctx context.Context, issues ...*types.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+
+	assigneeMap, err := s.assigneeStore.ListInfo(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list issue assignees: %w", err)
+	}
+
+	if err = s.labelSvc.AttachToIssues(ctx, issues...); err != nil {
+		return err
+	}
+
+	milestones := make(map[int64]*types.MilestoneInfo)
+	for _, issue := range issues {
+		issue.Assignees = assigneeMap[issue.ID]
+		if issue.Assignees == nil {
+			issue.Assignees = []*types.PrincipalInfo{}
+		}
+		if issue.Labels == nil {
+			issue.Labels = []*types.LabelInfo{}
+		}
+
+		if issue.MilestoneID == nil {
+			issue.Milestone = nil
+			continue
+		}
+
+		info, ok := milestones[*issue.MilestoneID]
+		if !ok {
+			m, err := s.milestoneStore.Find(ctx, *issue.MilestoneID)
+			if err != nil {
+				return fmt.Errorf("failed to find issue milestone: %w", err)
+			}
+
+			info = m.ToMilestoneInfo()
+			milestones[m.ID] = info
+		}
+
+		issue.Milestone = info
+	}
+
+	return nil
+}
+
+// SetState changes the state of the issue and writes the state change activity.
+// The pullreqNumber should be provided if the issue is closed by merging a pull request.
+func (s *Service) SetState(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+	issue *types.Issue,
+	state enum.IssueState,
+	pullreqNumber int64,
+) (*types.Issue, error) {
+	if issue.State == state {
+		return issue, nil
+	}
+
+	oldState := issue.State
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		issue, err = s.issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+			issue.State = state
+			issue.ActivitySeq++
+
+			if state == enum.IssueStateClosed {
+				now := time.Now().UnixMilli()
+				issue.Closed = &now
+				issue.ClosedBy = &principalID
+			} else {
+				issue.Closed = nil
+				issue.ClosedBy = nil
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update issue state: %w", err)
+		}
+
+		_, err = s.activityStore.CreateWithPayload(ctx, issue, principalID, &types.IssueActivityPayloadStateChange{
+			Old:           oldState,
+			New:           state,
+			PullReqNumber: pullreqNumber,
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.Attach(ctx, issue); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to attach issue details")
+	}
+
+	s.Publish(ctx, repo, issue)
+
+	base := issueevents.Base{
+		IssueID:     issue.ID,
+		RepoID:      issue.RepoID,
+		PrincipalID: principalID,
+		Number:      issue.Number,
+	}
+
+	if state == enum.IssueStateClosed {
+		s.eventReporter.Closed(ctx, &issueevents.ClosedPayload{
+			Base:          base,
+			PullReqNumber: pullreqNumber,
+		})
+	} else {
+		s.eventReporter.Reopened(ctx, &issueevents.ReopenedPayload{Base: base})
+	}
+
+	return issue, nil
+}
+
+// Publish sends the issue updated server-sent event to the space of the repository.
+func (s *Service) Publish(ctx context.Context, repo *types.Repository, issue *types.Issue) {
+	if err := s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeIssueUpdated, issue); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish issue changed event")
+	}
+}
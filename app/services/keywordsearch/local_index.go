@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+const (
+	// maxIndexedFileSize is the maximum size of a file that is added to the index.
+	maxIndexedFileSize = 1 << 20 // 1 MiB
+
+	// binarySniffLength is the number of bytes that are checked to determine whether a file is binary.
+	binarySniffLength = 8000
+
+	// indexHeaderLengthSize is the size of the length prefix of the encoded index header.
+	indexHeaderLengthSize = 8
+)
+
+// trigram is a sequence of three (lower-cased) bytes packed into an integer.
+type trigram uint32
+
+// localIndex is the keyword search index of the default branch of a single repository.
+//
+// On disk the index consists of the length prefixed gob encoded index (the header),
+// followed by the content of all indexed files. This allows searches to decode the
+// posting lists only and load the content of the candidate files lazily.
+type localIndex struct {
+	RepoID    int64
+	Branch    string
+	CommitSHA string
+	Files     []indexedFile
+
+	// Trigrams maps every trigram to the sorted list of positions in Files that contain it.
+	Trigrams map[trigram][]uint32
+
+	// contents holds the content of the files while the index is built.
+	contents [][]byte
+
+	// contentOffset is the position of the file contents in the index file.
+	contentOffset int64
+}
+
+type indexedFile struct {
+	Path     string
+	Language string
+
+	// Offset and Size locate the content of the file relative to the start of the file contents.
+	Offset int64
+	Size   int64
+}
+
+// buildLocalIndex builds the index from a tar stream containing the files of the repository.
+func buildLocalIndex(repoID int64, branch, commitSHA string, r io.Reader) (*localIndex, error) {
+	index := &localIndex{
+		RepoID:    repoID,
+		Branch:    branch,
+		CommitSHA: commitSHA,
+		Trigrams:  map[trigram][]uint32{},
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read next archive entry: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || header.Size > maxIndexedFileSize {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %q from archive: %w", header.Name, err)
+		}
+
+		if isBinary(content) {
+			continue
+		}
+
+		index.add(header.Name, content)
+	}
+
+	return index, nil
+}
+
+func isBinary(content []byte) bool {
+	sniff := content
+	if len(sniff) > binarySniffLength {
+		sniff = sniff[:binarySniffLength]
+	}
+
+	return bytes.IndexByte(sniff, 0) >= 0 || !utf8.Valid(sniff)
+}
+
+func (index *localIndex) add(path string, content []byte) {
+	pos := uint32(len(index.Files))
+
+	var offset int64
+	if pos > 0 {
+		last := index.Files[pos-1]
+		offset = last.Offset + last.Size
+	}
+
+	index.Files = append(index.Files, indexedFile{
+		Path:     path,
+		Language: enry.GetLanguage(filepath.Base(path), content),
+		Offset:   offset,
+		Size:     int64(len(content)),
+	})
+	index.contents = append(index.contents, content)
+
+	seen := map[trigram]struct{}{}
+	for _, t := range trigrams(string(content)) {
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		index.Trigrams[t] = append(index.Trigrams[t], pos)
+	}
+}
+
+// candidates returns the positions of the files that contain all trigrams of the provided literal.
+// If the literal is too short to contain any trigrams, all files are candidates.
+func (index *localIndex) candidates(literal string) []uint32 {
+	tris := trigrams(literal)
+	if len(tris) == 0 {
+		all := make([]uint32, len(index.Files))
+		for i := range all {
+			all[i] = uint32(i)
+		}
+		return all
+	}
+
+	// start with the smallest posting list to keep the intersection cheap.
+	sort.Slice(tris, func(i, j int) bool {
+		return len(index.Trigrams[tris[i]]) < len(index.Trigrams[tris[j]])
+	})
+
+	result := index.Trigrams[tris[0]]
+	for _, t := range tris[1:] {
+		if len(result) == 0 {
+			break
+		}
+		result = intersect(result, index.Trigrams[t])
+	}
+
+	return result
+}
+
+// trigrams returns the trigrams of the lower-cased text.
+func trigrams(text string) []trigram {
+	b := bytes.ToLower([]byte(text))
+	if len(b) < 3 {
+		return nil
+	}
+
+	out := make([]trigram, 0, len(b)-2)
+	for i := 0; i+2 < len(b); i++ {
+		out = append(out, trigram(uint32(b[i])<<16|uint32(b[i+1])<<8|uint32(b[i+2])))
+	}
+
+	return out
+}
+
+func intersect(a, b []uint32) []uint32 {
+	out := make([]uint32, 0, len(a))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+
+	return out
+}
+
+func indexFilePath(dir string, repoID int64) string {
+	return filepath.Join(dir, strconv.FormatInt(repoID, 10)+".idx")
+}
+
+// saveLocalIndex writes the index to disk. The file is replaced atomically to not disturb concurrent searches.
+func saveLocalIndex(dir string, index *localIndex) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	header := &bytes.Buffer{}
+	if err := gob.NewEncoder(header).Encode(index); err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "tmp-*.idx")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+	defer func() {
+		// no-op if the file was renamed successfully
+		_ = os.Remove(tmp.Name())
+	}()
+
+	w := bufio.NewWriter(tmp)
+
+	err = binary.Write(w, binary.BigEndian, uint64(header.Len()))
+	if err == nil {
+		_, err = header.WriteTo(w)
+	}
+	for i := 0; err == nil && i < len(index.contents); i++ {
+		_, err = w.Write(index.contents[i])
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary index file: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), indexFilePath(dir, index.RepoID)); err != nil {
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
+
+	return nil
+}
+
+// openLocalIndex opens the index file of a repository. It returns nil if the repository isn't indexed.
+func openLocalIndex(dir string, repoID int64) (*os.File, error) {
+	f, err := os.Open(indexFilePath(dir, repoID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+
+	return f, nil
+}
+
+// readLocalIndex decodes the index header of the index file, the content of the files isn't loaded.
+func readLocalIndex(f io.ReaderAt) (*localIndex, error) {
+	var headerLength uint64
+	err := binary.Read(io.NewSectionReader(f, 0, indexHeaderLengthSize), binary.BigEndian, &headerLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index header length: %w", err)
+	}
+
+	index := &localIndex{}
+	err = gob.NewDecoder(io.NewSectionReader(f, indexHeaderLengthSize, int64(headerLength))).Decode(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode index: %w", err)
+	}
+
+	index.contentOffset = indexHeaderLengthSize + int64(headerLength)
+
+	return index, nil
+}
+
+// readContent reads the content of an indexed file from the index file.
+func (index *localIndex) readContent(f io.ReaderAt, file *indexedFile) (string, error) {
+	content := make([]byte, file.Size)
+	if _, err := f.ReadAt(content, index.contentOffset+file.Offset); err != nil {
+		return "", fmt.Errorf("failed to read content of file %q: %w", file.Path, err)
+	}
+
+	return string(content), nil
+}
+
+// deleteLocalIndex removes the index of a repository from disk.
+func deleteLocalIndex(dir string, repoID int64) error {
+	err := os.Remove(indexFilePath(dir, repoID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete index file: %w", err)
+	}
+
+	return nil
+}
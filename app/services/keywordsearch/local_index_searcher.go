@@ -17,26 +17,304 @@ package keywordsearch
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
 
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 )
 
+const (
+	// defaultMaxResultCount is the number of files returned if the caller doesn't provide a limit.
+	defaultMaxResultCount = 50
+
+	// maxMatchesPerFile is the maximum number of matched lines returned per file.
+	maxMatchesPerFile = 100
+
+	// maxCachedIndexes is the maximum number of decoded indexes kept in memory.
+	maxCachedIndexes = 256
+)
+
+// LocalIndexSearcher maintains a trigram index of the default branch of each repository on local disk.
 type LocalIndexSearcher struct {
+	git      git.Interface
+	indexDir string
+
+	// repoLocks serializes indexing of the same repository.
+	repoLocks sync.Map
+
+	// cache contains the decoded indexes (without file contents) of recently searched repositories.
+	cacheMu sync.Mutex
+	cache   map[int64]cachedIndex
+}
+
+// cachedIndex is a decoded index together with the info of the index file it was decoded from.
+type cachedIndex struct {
+	fileInfo os.FileInfo
+	index    *localIndex
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(git git.Interface, indexDir string) *LocalIndexSearcher {
+	return &LocalIndexSearcher{
+		git:      git,
+		indexDir: indexDir,
+		cache:    map[int64]cachedIndex{},
+	}
 }
 
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	enableRegex bool,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, fmt.Errorf("not implemented")
+	q, err := parseSearchQuery(query, enableRegex)
+	if err != nil {
+		return types.SearchResult{}, err
+	}
+
+	if maxResultCount <= 0 {
+		maxResultCount = defaultMaxResultCount
+	}
+
+	// search repos in a deterministic order to have stable results.
+	repoIDs = append([]int64(nil), repoIDs...)
+	sort.Slice(repoIDs, func(i, j int) bool { return repoIDs[i] < repoIDs[j] })
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+
+	for _, repoID := range repoIDs {
+		if err := ctx.Err(); err != nil {
+			return types.SearchResult{}, err
+		}
+
+		if err := s.searchRepo(repoID, q, maxResultCount, &result); err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to search repo %d: %w", repoID, err)
+		}
+	}
+
+	return result, nil
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
-	return fmt.Errorf("not implemented")
+// searchRepo adds the matches of the repository to the result.
+func (s *LocalIndexSearcher) searchRepo(
+	repoID int64,
+	q *searchQuery,
+	maxResultCount int,
+	result *types.SearchResult,
+) error {
+	f, err := openLocalIndex(s.indexDir, repoID)
+	if err != nil {
+		return err
+	}
+	if f == nil {
+		s.uncacheIndex(repoID)
+		return nil
+	}
+	defer f.Close()
+
+	index, err := s.getIndex(repoID, f)
+	if err != nil {
+		return err
+	}
+
+	for _, pos := range index.candidates(q.literal) {
+		file := &index.Files[pos]
+		if !q.matchesFile(file) {
+			continue
+		}
+
+		content, err := index.readContent(f, file)
+		if err != nil {
+			return err
+		}
+
+		matches, count := searchFile(q, content)
+		if count == 0 {
+			continue
+		}
+
+		result.Stats.TotalFiles++
+		result.Stats.TotalMatches += count
+
+		if len(result.FileMatches) >= maxResultCount {
+			continue
+		}
+
+		result.FileMatches = append(result.FileMatches, types.FileMatch{
+			FileName:   file.Path,
+			RepoID:     repoID,
+			RepoBranch: index.Branch,
+			Language:   file.Language,
+			Matches:    matches,
+		})
+	}
+
+	return nil
+}
+
+// getIndex returns the decoded index of the opened index file.
+// The cached index is only used if it was decoded from the same file, as the file is replaced on every reindex.
+func (s *LocalIndexSearcher) getIndex(repoID int64, f *os.File) (*localIndex, error) {
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat index file: %w", err)
+	}
+
+	s.cacheMu.Lock()
+	cached, ok := s.cache[repoID]
+	s.cacheMu.Unlock()
+
+	if ok && os.SameFile(cached.fileInfo, fileInfo) &&
+		cached.fileInfo.ModTime().Equal(fileInfo.ModTime()) && cached.fileInfo.Size() == fileInfo.Size() {
+		return cached.index, nil
+	}
+
+	index, err := readLocalIndex(f)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if _, ok = s.cache[repoID]; !ok && len(s.cache) >= maxCachedIndexes {
+		// evict an arbitrary index to keep the memory usage bounded.
+		for id := range s.cache {
+			delete(s.cache, id)
+			break
+		}
+	}
+
+	s.cache[repoID] = cachedIndex{fileInfo: fileInfo, index: index}
+
+	return index, nil
+}
+
+func (s *LocalIndexSearcher) uncacheIndex(repoID int64) {
+	s.cacheMu.Lock()
+	delete(s.cache, repoID)
+	s.cacheMu.Unlock()
+}
+
+// searchFile returns the matched lines of the file content and the total number of matches.
+func searchFile(q *searchQuery, content string) ([]types.Match, int) {
+	lines := strings.Split(content, "\n")
+
+	var matches []types.Match
+	count := 0
+	for i, line := range lines {
+		locs := q.pattern.FindAllStringIndex(line, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		count += len(locs)
+		if len(matches) >= maxMatchesPerFile {
+			continue
+		}
+
+		match := types.Match{
+			LineNum:   i + 1,
+			Fragments: make([]types.Fragment, 0, len(locs)),
+		}
+		if i > 0 {
+			match.Before = lines[i-1]
+		}
+		if i+1 < len(lines) {
+			match.After = lines[i+1]
+		}
+
+		prevEnd := 0
+		for _, loc := range locs {
+			match.Fragments = append(match.Fragments, types.Fragment{
+				Offset: loc[0],
+				Pre:    line[prevEnd:loc[0]],
+				Match:  line[loc[0]:loc[1]],
+			})
+			prevEnd = loc[1]
+		}
+		match.Fragments[len(match.Fragments)-1].Post = line[prevEnd:]
+
+		matches = append(matches, match)
+	}
+
+	return matches, count
+}
+
+// Index rebuilds the index of the default branch of the repository.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	mu, _ := s.repoLocks.LoadOrStore(repo.ID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	readParams := git.ReadParams{RepoUID: repo.GitUID}
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		// the repository is empty or the default branch doesn't exist (yet) - there's nothing to search.
+		return deleteLocalIndex(s.indexDir, repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	sha := branchOut.Branch.SHA
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	errArchive := make(chan error, 1)
+	go func() {
+		err := s.git.Archive(ctx, pw, &git.ArchiveParams{
+			ReadParams: readParams,
+			Format:     git.ArchiveFormatTar,
+			GitRef:     sha,
+		})
+		_ = pw.CloseWithError(err)
+		errArchive <- err
+	}()
+
+	index, err := buildLocalIndex(repo.ID, repo.DefaultBranch, sha, pr)
+	if err == nil {
+		// the tar reader stops at the end-of-archive marker, consume the padding that follows it.
+		_, err = io.Copy(io.Discard, pr)
+	}
+
+	// unblock the archive in case the index build stopped early.
+	_ = pr.Close()
+
+	if aErr := <-errArchive; aErr != nil && err == nil {
+		return fmt.Errorf("failed to archive default branch: %w", aErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build index: %w", err)
+	}
+
+	if err = saveLocalIndex(s.indexDir, index); err != nil {
+		return fmt.Errorf("failed to save index: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the index of the repository.
+func (s *LocalIndexSearcher) Delete(_ context.Context, repoID int64) error {
+	mu, _ := s.repoLocks.LoadOrStore(repoID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	s.uncacheIndex(repoID)
+
+	return deleteLocalIndex(s.indexDir, repoID)
 }
@@ -0,0 +1,298 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		enableRegex bool
+		wantPattern string
+		wantLiteral string
+		wantPaths   []string
+		wantLangs   []string
+		wantErr     bool
+	}{
+		{
+			name:        "plain",
+			query:       "foo.bar",
+			wantPattern: `(?i)foo\.bar`,
+			wantLiteral: "foo.bar",
+		},
+		{
+			name:        "filters",
+			query:       "lang:Go path:app/ case:yes hello   world",
+			wantPattern: "hello world",
+			wantLiteral: "hello world",
+			wantPaths:   []string{"app/"},
+			wantLangs:   []string{"Go"},
+		},
+		{
+			name:        "quoted",
+			query:       `"lang:go  x"`,
+			wantPattern: `(?i)lang:go  x`,
+			wantLiteral: "lang:go  x",
+		},
+		{
+			name:        "regex",
+			query:       `func\s+(Handle)\w+`,
+			enableRegex: true,
+			wantPattern: `(?i)func\s+(Handle)\w+`,
+			wantLiteral: "Handle",
+		},
+		{
+			name:        "invalid regex",
+			query:       "(foo",
+			enableRegex: true,
+			wantErr:     true,
+		},
+		{
+			name:    "filters only",
+			query:   "lang:go",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parseSearchQuery(test.query, test.enableRegex)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := q.pattern.String(); got != test.wantPattern {
+				t.Errorf("pattern: want=%q got=%q", test.wantPattern, got)
+			}
+			if q.literal != test.wantLiteral {
+				t.Errorf("literal: want=%q got=%q", test.wantLiteral, q.literal)
+			}
+			if !reflect.DeepEqual(q.paths, test.wantPaths) {
+				t.Errorf("paths: want=%v got=%v", test.wantPaths, q.paths)
+			}
+			if !reflect.DeepEqual(q.languages, test.wantLangs) {
+				t.Errorf("languages: want=%v got=%v", test.wantLangs, q.languages)
+			}
+		})
+	}
+}
+
+// buildTestIndex builds the index of the files, which are added in the order of their names.
+func buildTestIndex(t *testing.T, repoID int64, names []string, files map[string]string) *localIndex {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, name := range names {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(files[name])), Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte(files[name]))
+	}
+	_ = tw.Close()
+
+	index, err := buildLocalIndex(repoID, "main", "sha", buf)
+	if err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+
+	return index
+}
+
+func TestLocalIndexSearch(t *testing.T) {
+	files := map[string]string{
+		"main.go":      "package main\n\nfunc main() {\n\tprintln(\"Hello\", \"hello\")\n}\n",
+		"docs/a.md":    "# Hello\n",
+		"bin/data.bin": "hello\x00world",
+	}
+	index := buildTestIndex(t, 1, []string{"main.go", "docs/a.md", "bin/data.bin"}, files)
+
+	if len(index.Files) != 2 {
+		t.Fatalf("expected binary file to be skipped, got %d files", len(index.Files))
+	}
+
+	if got := index.candidates("HELLO"); !reflect.DeepEqual(got, []uint32{0, 1}) {
+		t.Errorf("unexpected candidates for HELLO: %v", got)
+	}
+	if got := index.candidates("println"); !reflect.DeepEqual(got, []uint32{0}) {
+		t.Errorf("unexpected candidates for println: %v", got)
+	}
+	if got := index.candidates("missing"); len(got) != 0 {
+		t.Errorf("unexpected candidates for missing: %v", got)
+	}
+
+	q, _ := parseSearchQuery("hello lang:go", false)
+	file := &index.Files[0]
+	if !q.matchesFile(file) || q.matchesFile(&index.Files[1]) {
+		t.Fatalf("language filter failed")
+	}
+
+	matches, count := searchFile(q, string(index.contents[0]))
+	if count != 2 {
+		t.Fatalf("expected 2 matches, got %d", count)
+	}
+
+	want := []types.Match{{
+		LineNum: 4,
+		Fragments: []types.Fragment{
+			{Offset: 10, Pre: "\tprintln(\"", Match: "Hello"},
+			{Offset: 19, Pre: "\", \"", Match: "hello", Post: "\")"},
+		},
+		Before: "func main() {",
+		After:  "}",
+	}}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("unexpected matches:\nwant=%+v\n got=%+v", want, matches)
+	}
+}
+
+func TestLocalIndex_SaveAndRead(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"a.txt": "first file",
+		"b.txt": "",
+		"c.txt": "third file\nwith two lines",
+	}
+	index := buildTestIndex(t, 7, []string{"a.txt", "b.txt", "c.txt"}, files)
+
+	if err := saveLocalIndex(dir, index); err != nil {
+		t.Fatalf("failed to save index: %v", err)
+	}
+
+	f, err := openLocalIndex(dir, 7)
+	if err != nil || f == nil {
+		t.Fatalf("failed to open index: %v", err)
+	}
+	defer f.Close()
+
+	loaded, err := readLocalIndex(f)
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+
+	if loaded.RepoID != 7 || loaded.Branch != "main" || loaded.CommitSHA != "sha" {
+		t.Errorf("unexpected index metadata: %+v", loaded)
+	}
+	if !reflect.DeepEqual(loaded.Trigrams, index.Trigrams) {
+		t.Errorf("trigrams don't match")
+	}
+	if len(loaded.contents) != 0 {
+		t.Errorf("expected file contents to not be loaded")
+	}
+
+	for i := range loaded.Files {
+		file := &loaded.Files[i]
+		content, err := loaded.readContent(f, file)
+		if err != nil {
+			t.Fatalf("failed to read content of %q: %v", file.Path, err)
+		}
+		if content != files[file.Path] {
+			t.Errorf("content of %q: got=%q want=%q", file.Path, content, files[file.Path])
+		}
+	}
+
+	f, err = openLocalIndex(dir, 8)
+	if err != nil || f != nil {
+		t.Errorf("expected no index for unknown repo, got file=%v err=%v", f, err)
+	}
+}
+
+func TestLocalIndexSearcher_Search(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := NewLocalIndexSearcher(nil, dir)
+
+	index := buildTestIndex(t, 1, []string{"a.go", "b.go"}, map[string]string{
+		"a.go": "func oldName() {}\n",
+		"b.go": "// calls oldName\n",
+	})
+	if err := saveLocalIndex(dir, index); err != nil {
+		t.Fatalf("failed to save index: %v", err)
+	}
+
+	search := func(query string) []string {
+		t.Helper()
+		result, err := s.Search(ctx, []int64{1, 2}, query, false, 10)
+		if err != nil {
+			t.Fatalf("failed to search: %v", err)
+		}
+		names := []string{}
+		for _, match := range result.FileMatches {
+			names = append(names, match.FileName)
+		}
+		return names
+	}
+
+	if got := search("oldname"); !reflect.DeepEqual(got, []string{"a.go", "b.go"}) {
+		t.Errorf("got=%v want=[a.go b.go]", got)
+	}
+
+	// searching again uses the cached index.
+	cached := s.cache[1].index
+	if got := search("func"); !reflect.DeepEqual(got, []string{"a.go"}) {
+		t.Errorf("got=%v want=[a.go]", got)
+	}
+	if s.cache[1].index != cached {
+		t.Errorf("expected the cached index to be reused")
+	}
+
+	// a reindex replaces the index file, the cached index mustn't be used anymore.
+	index = buildTestIndex(t, 1, []string{"a.go"}, map[string]string{
+		"a.go": "func newName() {}\n",
+	})
+	if err := saveLocalIndex(dir, index); err != nil {
+		t.Fatalf("failed to save index: %v", err)
+	}
+
+	if got := search("oldname"); len(got) != 0 {
+		t.Errorf("got=%v want=[]", got)
+	}
+	if got := search("newname"); !reflect.DeepEqual(got, []string{"a.go"}) {
+		t.Errorf("got=%v want=[a.go]", got)
+	}
+
+	if err := s.Delete(ctx, 1); err != nil {
+		t.Fatalf("failed to delete index: %v", err)
+	}
+
+	if _, err := os.Stat(indexFilePath(dir, 1)); !os.IsNotExist(err) {
+		t.Errorf("expected index file to be removed, got err=%v", err)
+	}
+	if _, ok := s.cache[1]; ok {
+		t.Errorf("expected cached index to be removed")
+	}
+	if got := search("newname"); len(got) != 0 {
+		t.Errorf("got=%v want=[]", got)
+	}
+
+	// deleting a missing index is a no-op.
+	if err := s.Delete(ctx, 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+)
+
+const (
+	queryFilterLanguage = "lang:"
+	queryFilterPath     = "path:"
+	queryFilterCase     = "case:"
+)
+
+// searchQuery is the parsed representation of a keyword search query.
+//
+// Besides the search pattern, a query can contain the following filters:
+//   - lang:<language> restricts the search to files of the language (e.g. lang:go).
+//   - path:<substring> restricts the search to files whose path contains the substring.
+//   - case:yes makes the search case-sensitive.
+//
+// Double quotes can be used to search for text containing whitespace or looking like a filter.
+type searchQuery struct {
+	pattern *regexp.Regexp
+
+	// literal is a string every match has to contain, used to select candidate files via the trigram index.
+	literal string
+
+	paths     []string
+	languages []string
+}
+
+func parseSearchQuery(query string, enableRegex bool) (*searchQuery, error) {
+	q := &searchQuery{}
+	caseSensitive := false
+
+	var patternParts []string
+	for _, token := range tokenizeQuery(query) {
+		if token.quoted {
+			patternParts = append(patternParts, token.value)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(token.value, queryFilterLanguage):
+			q.languages = append(q.languages, strings.TrimPrefix(token.value, queryFilterLanguage))
+		case strings.HasPrefix(token.value, queryFilterPath):
+			q.paths = append(q.paths, strings.TrimPrefix(token.value, queryFilterPath))
+		case strings.HasPrefix(token.value, queryFilterCase):
+			caseSensitive = strings.TrimPrefix(token.value, queryFilterCase) == "yes"
+		default:
+			patternParts = append(patternParts, token.value)
+		}
+	}
+
+	pattern := strings.Join(patternParts, " ")
+	if pattern == "" {
+		return nil, errors.InvalidArgument("search query doesn't contain a search pattern")
+	}
+
+	if enableRegex {
+		re, err := syntax.Parse(pattern, syntax.Perl)
+		if err != nil {
+			return nil, errors.InvalidArgument("invalid regular expression: %s", err)
+		}
+		q.literal = requiredLiteral(re.Simplify())
+	} else {
+		q.literal = pattern
+		pattern = regexp.QuoteMeta(pattern)
+	}
+
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	var err error
+	q.pattern, err = regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.InvalidArgument("invalid regular expression: %s", err)
+	}
+
+	return q, nil
+}
+
+// matchesFile returns true if the file satisfies the path and language filters of the query.
+func (q *searchQuery) matchesFile(file *indexedFile) bool {
+	for _, p := range q.paths {
+		if !strings.Contains(strings.ToLower(file.Path), strings.ToLower(p)) {
+			return false
+		}
+	}
+
+	for _, lang := range q.languages {
+		if !strings.EqualFold(file.Language, lang) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type queryToken struct {
+	value  string
+	quoted bool
+}
+
+// tokenizeQuery splits the query by whitespace, text in double quotes is kept as a single token.
+func tokenizeQuery(query string) []queryToken {
+	var tokens []queryToken
+	var current strings.Builder
+	inQuotes := false
+
+	flush := func(quoted bool) {
+		if current.Len() > 0 || quoted {
+			tokens = append(tokens, queryToken{value: current.String(), quoted: quoted})
+		}
+		current.Reset()
+	}
+
+	for _, r := range query {
+		switch {
+		case r == '"' && inQuotes:
+			flush(true)
+			inQuotes = false
+		case r == '"' && current.Len() == 0:
+			inQuotes = true
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n'):
+			flush(false)
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	// an unterminated quote is treated as if it was terminated at the end of the query.
+	flush(inQuotes)
+
+	return tokens
+}
+
+// requiredLiteral returns the longest literal string that any match of the regular expression contains.
+func requiredLiteral(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return string(re.Rune)
+	case syntax.OpCapture:
+		return requiredLiteral(re.Sub[0])
+	case syntax.OpConcat:
+		longest := ""
+		for _, sub := range re.Sub {
+			if literal := requiredLiteral(sub); len(literal) > len(longest) {
+				longest = literal
+			}
+		}
+		return longest
+	default:
+		return ""
+	}
+}
@@ -34,6 +34,7 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+	IndexPath       string
 }
 
 func (c *Config) Prepare() error {
@@ -49,6 +50,9 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.IndexPath == "" {
+		return errors.New("config.IndexPath is required")
+	}
 	return nil
 }
 
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// assignments provides access to the labels assigned to a single pull request or issue.
+type assignments struct {
+	list     func(ctx context.Context) ([]*types.LabelInfo, error)
+	assign   func(ctx context.Context, labelID int64) error
+	unassign func(ctx context.Context, labelID int64) error
+}
+
+// assign assigns the label available in the space to the entity. If the label is scoped,
+// the assigned label with the same key is replaced.
+func (s *Service) assign(
+	ctx context.Context,
+	spaceID int64,
+	labelID int64,
+	a assignments,
+) (AssignOutput, error) {
+	lbl, err := s.FindAvailable(ctx, spaceID, labelID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return AssignOutput{}, errors.NotFound("Label not found.")
+	}
+	if err != nil {
+		return AssignOutput{}, err
+	}
+
+	out := AssignOutput{Label: lbl}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		assigned, err := a.list(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, info := range assigned {
+			if info.ID == lbl.ID {
+				return nil
+			}
+
+			if !lbl.IsScoped() || info.Value == "" || !strings.EqualFold(info.Key, lbl.Key) {
+				continue
+			}
+
+			if err = a.unassign(ctx, info.ID); err != nil {
+				return fmt.Errorf("failed to unassign replaced scoped label: %w", err)
+			}
+
+			out.Replaced = info
+		}
+
+		if err = a.assign(ctx, lbl.ID); err != nil {
+			return fmt.Errorf("failed to assign label: %w", err)
+		}
+
+		out.Assigned = true
+
+		return nil
+	})
+	if err != nil {
+		return AssignOutput{}, err
+	}
+
+	return out, nil
+}
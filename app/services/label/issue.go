@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// AssignToIssue assigns the label to the issue. The label must be defined in the space of the
+// repository or in any of its ancestors. If the label is scoped, the assigned label with the same key
+// is replaced.
+func (s *Service) AssignToIssue(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+	issue *types.Issue,
+	labelID int64,
+) (AssignOutput, error) {
+	return s.assign(ctx, repo.ParentID, labelID, assignments{
+		list: func(ctx context.Context) ([]*types.LabelInfo, error) {
+			assignedMap, err := s.issueLabelStore.ListInfo(ctx, []int64{issue.ID})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list issue labels: %w", err)
+			}
+			return assignedMap[issue.ID], nil
+		},
+		assign: func(ctx context.Context, labelID int64) error {
+			return s.issueLabelStore.Assign(ctx, &types.IssueLabel{
+				IssueID:   issue.ID,
+				LabelID:   labelID,
+				Created:   time.Now().UnixMilli(),
+				CreatedBy: principalID,
+			})
+		},
+		unassign: func(ctx context.Context, labelID int64) error {
+			return s.issueLabelStore.Unassign(ctx, issue.ID, labelID)
+		},
+	})
+}
+
+// UnassignFromIssue removes the label from the issue.
+func (s *Service) UnassignFromIssue(
+	ctx context.Context,
+	issue *types.Issue,
+	labelID int64,
+) (*types.LabelInfo, error) {
+	lbl, err := s.labelStore.Find(ctx, labelID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("Label not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	err = s.issueLabelStore.Unassign(ctx, issue.ID, lbl.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("Label is not assigned to the issue.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unassign label: %w", err)
+	}
+
+	return lbl.ToLabelInfo(), nil
+}
+
+// AttachToIssues sets the assigned labels of the issues.
+func (s *Service) AttachToIssues(ctx context.Context, issues ...*types.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+
+	labelMap, err := s.issueLabelStore.ListInfo(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list issue labels: %w", err)
+	}
+
+	for _, issue := range issues {
+		issue.Labels = labelMap[issue.ID]
+	}
+
+	return nil
+}
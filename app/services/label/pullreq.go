@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// AssignOutput is the result of the assignment of a label to a pull request or an issue.
+type AssignOutput struct {
+	Label *types.Label
+
+	// Replaced is the scoped label with the same key that was unassigned, if any.
+	Replaced *types.LabelInfo
+
+	// Assigned is false if the label was already assigned.
+	Assigned bool
+}
+
+// Assign assigns the label to the pull request. The label must be defined in the space of the
+// repository or in any of its ancestors. If the label is scoped, the assigned label with the same key
+// is replaced.
+func (s *Service) Assign(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+	pr *types.PullReq,
+	labelID int64,
+) (AssignOutput, error) {
+	return s.assign(ctx, repo.ParentID, labelID, assignments{
+		list: func(ctx context.Context) ([]*types.LabelInfo, error) {
+			assignedMap, err := s.pullReqLabelStore.ListInfo(ctx, []int64{pr.ID})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pull request labels: %w", err)
+			}
+			return assignedMap[pr.ID], nil
+		},
+		assign: func(ctx context.Context, labelID int64) error {
+			return s.pullReqLabelStore.Assign(ctx, &types.PullReqLabel{
+				PullReqID: pr.ID,
+				LabelID:   labelID,
+				Created:   time.Now().UnixMilli(),
+				CreatedBy: principalID,
+			})
+		},
+		unassign: func(ctx context.Context, labelID int64) error {
+			return s.pullReqLabelStore.Unassign(ctx, pr.ID, labelID)
+		},
+	})
+}
+
+// Unassign removes the label from the pull request.
+func (s *Service) Unassign(
+	ctx context.Context,
+	pr *types.PullReq,
+	labelID int64,
+) (*types.LabelInfo, error) {
+	lbl, err := s.labelStore.Find(ctx, labelID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("Label not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find label: %w", err)
+	}
+
+	err = s.pullReqLabelStore.Unassign(ctx, pr.ID, lbl.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.NotFound("Label is not assigned to the pull request.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unassign label: %w", err)
+	}
+
+	return lbl.ToLabelInfo(), nil
+}
+
+// Attach sets the assigned labels of the pull requests.
+func (s *Service) Attach(ctx context.Context, prs ...*types.PullReq) error {
+	if len(prs) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(prs))
+	for i, pr := range prs {
+		ids[i] = pr.ID
+	}
+
+	labelMap, err := s.pullReqLabelStore.ListInfo(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request labels: %w", err)
+	}
+
+	for _, pr := range prs {
+		pr.Labels = labelMap[pr.ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestService_Assign(t *testing.T) {
+	s, prLabels := setupService()
+	ctx := context.Background()
+
+	repo := &types.Repository{ID: 1, ParentID: 3}
+	pr := &types.PullReq{ID: 1}
+
+	// the steps are applied to the same pull request in order.
+	tests := []struct {
+		name         string
+		labelID      int64
+		wantErr      bool
+		wantAssigned bool
+		wantReplaced int64
+		want         []int64
+	}{
+		{name: "label of the root space", labelID: 1, wantAssigned: true, want: []int64{1}},
+		{name: "already assigned", labelID: 1, wantAssigned: false, want: []int64{1}},
+		{name: "unscoped label", labelID: 4, wantAssigned: true, want: []int64{1, 4}},
+		{name: "unscoped label with a scoped key", labelID: 7, wantAssigned: true, want: []int64{1, 4, 7}},
+		{name: "scoped label replaces key", labelID: 2, wantAssigned: true, wantReplaced: 1, want: []int64{4, 7, 2}},
+		{
+			name:         "scoped key is case insensitive",
+			labelID:      3,
+			wantAssigned: true,
+			wantReplaced: 2,
+			want:         []int64{4, 7, 3},
+		},
+		{name: "scoped label of another key", labelID: 5, wantAssigned: true, want: []int64{4, 7, 3, 5}},
+		{name: "label of an unrelated space", labelID: 6, wantErr: true, want: []int64{4, 7, 3, 5}},
+		{name: "unknown label", labelID: 100, wantErr: true, want: []int64{4, 7, 3, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := s.Assign(ctx, 1, repo, pr, test.labelID)
+			if test.wantErr {
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected not found error, got: %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.Assigned != test.wantAssigned {
+				t.Errorf("assigned: want=%t got=%t", test.wantAssigned, out.Assigned)
+			}
+
+			var replaced int64
+			if out.Replaced != nil {
+				replaced = out.Replaced.ID
+			}
+			if replaced != test.wantReplaced {
+				t.Errorf("replaced: want=%d got=%d", test.wantReplaced, replaced)
+			}
+
+			if got := prLabels.assigned[pr.ID]; !slices.Equal(got, test.want) {
+				t.Errorf("want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestService_Unassign(t *testing.T) {
+	s, prLabels := setupService()
+	ctx := context.Background()
+
+	pr := &types.PullReq{ID: 1}
+	prLabels.assigned[pr.ID] = []int64{1, 4}
+
+	info, err := s.Unassign(ctx, pr, 1)
+	if err != nil {
+		t.Fatalf("failed to unassign label: %v", err)
+	}
+	if info.ID != 1 || info.Key != "kind" || info.Value != "bug" {
+		t.Errorf("want=kind:bug got=%+v", info)
+	}
+
+	if got := prLabels.assigned[pr.ID]; !slices.Equal(got, []int64{4}) {
+		t.Errorf("want=[4] got=%v", got)
+	}
+
+	for _, labelID := range []int64{1, 100} {
+		if _, err = s.Unassign(ctx, pr, labelID); !errors.IsNotFound(err) {
+			t.Errorf("label %d: expected not found error, got: %v", labelID, err)
+		}
+	}
+}
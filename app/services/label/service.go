@@ -0,0 +1,269 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+const (
+	maxKeyLength   = 50
+	maxValueLength = 50
+)
+
+var colorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Service manages the label definitions of spaces and the labels assigned to pull requests and issues.
+// Labels defined in a space are available in all its child spaces and repositories.
+type Service struct {
+	tx                dbtx.Transactor
+	spaceStore        store.SpaceStore
+	labelStore        store.LabelStore
+	pullReqLabelStore store.PullReqLabelStore
+	issueLabelStore   store.IssueLabelStore
+}
+
+func NewService(
+	tx dbtx.Transactor,
+	spaceStore store.SpaceStore,
+	labelStore store.LabelStore,
+	pullReqLabelStore store.PullReqLabelStore,
+	issueLabelStore store.IssueLabelStore,
+) *Service {
+	return &Service{
+		tx:                tx,
+		spaceStore:        spaceStore,
+		labelStore:        labelStore,
+		pullReqLabelStore: pullReqLabelStore,
+		issueLabelStore:   issueLabelStore,
+	}
+}
+
+// Define creates a new label definition in the space.
+func (s *Service) Define(
+	ctx context.Context,
+	spaceID int64,
+	principalID int64,
+	key, value, color, description string,
+) (*types.Label, error) {
+	now := time.Now().UnixMilli()
+	lbl := &types.Label{
+		SpaceID:     spaceID,
+		Key:         key,
+		Value:       value,
+		Color:       color,
+		Description: description,
+		Created:     now,
+		Updated:     now,
+		CreatedBy:   principalID,
+	}
+
+	if err := sanitize(lbl); err != nil {
+		return nil, err
+	}
+
+	err := s.labelStore.Create(ctx, lbl)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Label %q already exists in the space.", lbl.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label: %w", err)
+	}
+
+	return lbl, nil
+}
+
+// Update updates the label definition. Nil values are left unchanged.
+func (s *Service) Update(
+	ctx context.Context,
+	lbl *types.Label,
+	key, value, color, description *string,
+) (*types.Label, error) {
+	if key != nil {
+		lbl.Key = *key
+	}
+	if value != nil {
+		lbl.Value = *value
+	}
+	if color != nil {
+		lbl.Color = *color
+	}
+	if description != nil {
+		lbl.Description = *description
+	}
+
+	if err := sanitize(lbl); err != nil {
+		return nil, err
+	}
+
+	lbl.Updated = time.Now().UnixMilli()
+
+	err := s.labelStore.Update(ctx, lbl)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errors.Conflict("Label %q already exists in the space.", lbl.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update label: %w", err)
+	}
+
+	return lbl, nil
+}
+
+// Delete deletes the label definition. The label is removed from all pull requests it's assigned to.
+func (s *Service) Delete(ctx context.Context, lbl *types.Label) error {
+	return s.labelStore.Delete(ctx, lbl.ID)
+}
+
+// Find returns the label defined in the space.
+func (s *Service) Find(ctx context.Context, spaceID int64, labelID int64) (*types.Label, error) {
+	lbl, err := s.labelStore.Find(ctx, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if lbl.SpaceID != spaceID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	return lbl, nil
+}
+
+// FindAvailable returns the label if it's defined in the space or in any of its ancestors.
+func (s *Service) FindAvailable(ctx context.Context, spaceID int64, labelID int64) (*types.Label, error) {
+	lbl, err := s.labelStore.Find(ctx, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	spaceIDs, err := s.spaceIDs(ctx, spaceID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range spaceIDs {
+		if lbl.SpaceID == id {
+			return lbl, nil
+		}
+	}
+
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// List returns the labels defined in the space.
+// If the filter's inherited flag is set, the labels defined in the ancestor spaces are included.
+func (s *Service) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.LabelFilter,
+) ([]*types.Label, int64, error) {
+	spaceIDs, err := s.spaceIDs(ctx, spaceID, filter.Inherited)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var list []*types.Label
+	var count int64
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		list, err = s.labelStore.List(ctx, spaceIDs, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list labels: %w", err)
+		}
+
+		if filter.Page == 1 && len(list) < filter.Size {
+			count = int64(len(list))
+			return nil
+		}
+
+		count, err = s.labelStore.Count(ctx, spaceIDs, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count labels: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, count, nil
+}
+
+// spaceIDs returns the ID of the space, followed by the IDs of its ancestors if requested.
+func (s *Service) spaceIDs(ctx context.Context, spaceID int64, ancestors bool) ([]int64, error) {
+	ids := []int64{spaceID}
+	if !ancestors {
+		return ids, nil
+	}
+
+	for id := spaceID; ; {
+		space, err := s.spaceStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if space.ParentID <= 0 {
+			return ids, nil
+		}
+
+		id = space.ParentID
+		ids = append(ids, id)
+	}
+}
+
+func sanitize(lbl *types.Label) error {
+	lbl.Key = strings.TrimSpace(lbl.Key)
+	lbl.Value = strings.TrimSpace(lbl.Value)
+	lbl.Color = strings.ToLower(strings.TrimSpace(lbl.Color))
+	lbl.Description = strings.TrimSpace(lbl.Description)
+
+	if lbl.Key == "" {
+		return errors.InvalidArgument("Label key must be provided.")
+	}
+
+	if len(lbl.Key) > maxKeyLength {
+		return errors.InvalidArgument("Label key can be at most %d characters long.", maxKeyLength)
+	}
+
+	if len(lbl.Value) > maxValueLength {
+		return errors.InvalidArgument("Label value can be at most %d characters long.", maxValueLength)
+	}
+
+	// the colon separates the key and the value of scoped labels when they are shown as text.
+	if strings.Contains(lbl.Key, ":") {
+		return errors.InvalidArgument("Label key can't contain a colon.")
+	}
+
+	if err := check.ForControlCharacters(lbl.Key + lbl.Value); err != nil {
+		return err
+	}
+
+	if !colorRegex.MatchString(lbl.Color) {
+		return errors.InvalidArgument("Label color must be a hex color code, for example #ff0000.")
+	}
+
+	return check.Description(lbl.Description)
+}
@@ -0,0 +1,274 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   types.Label
+		want    types.Label
+		wantErr bool
+	}{
+		{
+			name:  "label",
+			label: types.Label{Key: " bug ", Color: "#FF0000", Description: " broken "},
+			want:  types.Label{Key: "bug", Color: "#ff0000", Description: "broken"},
+		},
+		{
+			name:  "scoped label",
+			label: types.Label{Key: "priority", Value: " high", Color: "#00ff00"},
+			want:  types.Label{Key: "priority", Value: "high", Color: "#00ff00"},
+		},
+		{
+			name:    "empty key",
+			label:   types.Label{Key: " ", Color: "#ff0000"},
+			wantErr: true,
+		},
+		{
+			name:    "key too long",
+			label:   types.Label{Key: strings.Repeat("k", maxKeyLength+1), Color: "#ff0000"},
+			wantErr: true,
+		},
+		{
+			name:    "colon in key",
+			label:   types.Label{Key: "priority:high", Color: "#ff0000"},
+			wantErr: true,
+		},
+		{
+			name:    "control characters",
+			label:   types.Label{Key: "bug", Value: "a\tb", Color: "#ff0000"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid color",
+			label:   types.Label{Key: "bug", Color: "red"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lbl := test.label
+			err := sanitize(&lbl)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if lbl != test.want {
+				t.Errorf("want=%+v got=%+v", test.want, lbl)
+			}
+		})
+	}
+}
+
+func TestService_FindAvailable(t *testing.T) {
+	s, _ := setupService()
+
+	tests := []struct {
+		name    string
+		spaceID int64
+		labelID int64
+		wantErr bool
+	}{
+		{name: "label of the space", spaceID: 3, labelID: 5},
+		{name: "label of the parent space", spaceID: 3, labelID: 4},
+		{name: "label of the root space", spaceID: 3, labelID: 1},
+		{name: "label of a child space", spaceID: 2, labelID: 5, wantErr: true},
+		{name: "label of an unrelated space", spaceID: 3, labelID: 6, wantErr: true},
+		{name: "unknown label", spaceID: 3, labelID: 100, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lbl, err := s.FindAvailable(context.Background(), test.spaceID, test.labelID)
+			if test.wantErr {
+				if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+					t.Errorf("want=%v got=%v", gitness_store.ErrResourceNotFound, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if lbl.ID != test.labelID {
+				t.Errorf("want=%d got=%d", test.labelID, lbl.ID)
+			}
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	s, _ := setupService()
+
+	tests := []struct {
+		name      string
+		spaceID   int64
+		inherited bool
+		want      []int64
+	}{
+		{name: "space", spaceID: 3, want: []int64{5}},
+		{name: "inherited", spaceID: 3, inherited: true, want: []int64{1, 2, 3, 4, 5, 7}},
+		{name: "inherited in the parent space", spaceID: 2, inherited: true, want: []int64{1, 2, 3, 4, 7}},
+		{name: "root space", spaceID: 1, inherited: true, want: []int64{1, 2, 7}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := &types.LabelFilter{
+				ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 10}},
+				Inherited:       test.inherited,
+			}
+
+			list, count, err := s.List(context.Background(), test.spaceID, filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make([]int64, len(list))
+			for i, lbl := range list {
+				got[i] = lbl.ID
+			}
+			slices.Sort(got)
+
+			if !slices.Equal(got, test.want) || count != int64(len(test.want)) {
+				t.Errorf("want=%v got=%v count=%d", test.want, got, count)
+			}
+		})
+	}
+}
+
+type fakeTx struct{}
+
+func (fakeTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+}
+
+func (s fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	space, ok := s.spaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return space, nil
+}
+
+type fakeLabelStore struct {
+	store.LabelStore
+	labels map[int64]*types.Label
+}
+
+func (s fakeLabelStore) Find(_ context.Context, id int64) (*types.Label, error) {
+	lbl, ok := s.labels[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return lbl, nil
+}
+
+func (s fakeLabelStore) List(_ context.Context, spaceIDs []int64, _ *types.LabelFilter) ([]*types.Label, error) {
+	var list []*types.Label
+	for _, lbl := range s.labels {
+		if slices.Contains(spaceIDs, lbl.SpaceID) {
+			list = append(list, lbl)
+		}
+	}
+	return list, nil
+}
+
+// fakePullReqLabelStore keeps the IDs of the labels assigned to pull requests in the order of assignment.
+type fakePullReqLabelStore struct {
+	store.PullReqLabelStore
+	labels   fakeLabelStore
+	assigned map[int64][]int64
+}
+
+func (s *fakePullReqLabelStore) Assign(_ context.Context, prLabel *types.PullReqLabel) error {
+	if slices.Contains(s.assigned[prLabel.PullReqID], prLabel.LabelID) {
+		return gitness_store.ErrDuplicate
+	}
+	s.assigned[prLabel.PullReqID] = append(s.assigned[prLabel.PullReqID], prLabel.LabelID)
+	return nil
+}
+
+func (s *fakePullReqLabelStore) Unassign(_ context.Context, pullreqID, labelID int64) error {
+	ids := s.assigned[pullreqID]
+	idx := slices.Index(ids, labelID)
+	if idx < 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+	s.assigned[pullreqID] = slices.Delete(ids, idx, idx+1)
+	return nil
+}
+
+func (s *fakePullReqLabelStore) ListInfo(_ context.Context, pullreqIDs []int64) (map[int64][]*types.LabelInfo, error) {
+	m := make(map[int64][]*types.LabelInfo)
+	for _, pullreqID := range pullreqIDs {
+		for _, labelID := range s.assigned[pullreqID] {
+			m[pullreqID] = append(m[pullreqID], s.labels.labels[labelID].ToLabelInfo())
+		}
+	}
+	return m, nil
+}
+
+// setupService returns a label service with the space hierarchy 1 > 2 > 3 and the unrelated space 4.
+func setupService() (*Service, *fakePullReqLabelStore) {
+	spaces := fakeSpaceStore{spaces: map[int64]*types.Space{
+		1: {ID: 1},
+		2: {ID: 2, ParentID: 1},
+		3: {ID: 3, ParentID: 2},
+		4: {ID: 4},
+	}}
+
+	labels := fakeLabelStore{labels: map[int64]*types.Label{
+		1: {ID: 1, SpaceID: 1, Key: "kind", Value: "bug"},
+		2: {ID: 2, SpaceID: 1, Key: "kind", Value: "feature"},
+		3: {ID: 3, SpaceID: 2, Key: "Kind", Value: "docs"},
+		4: {ID: 4, SpaceID: 2, Key: "urgent"},
+		5: {ID: 5, SpaceID: 3, Key: "priority", Value: "high"},
+		6: {ID: 6, SpaceID: 4, Key: "kind", Value: "other"},
+		7: {ID: 7, SpaceID: 1, Key: "kind"},
+	}}
+
+	prLabels := &fakePullReqLabelStore{labels: labels, assigned: map[int64][]int64{}}
+
+	return NewService(fakeTx{}, spaces, labels, prLabels, nil), prLabels
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+// handleEventBranchUpdated rebuilds the merge queue of a branch that has been updated outside of the queue.
+func (s *Service) handleEventBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	branch, ok := strings.CutPrefix(event.Payload.Ref, "refs/heads/")
+	if !ok {
+		return nil
+	}
+
+	return s.processIfQueued(ctx, event.Payload.RepoID, branch)
+}
+
+// handleEventBranchDeleted ejects all pull requests from the merge queue of a deleted branch.
+func (s *Service) handleEventBranchDeleted(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload],
+) error {
+	branch, ok := strings.CutPrefix(event.Payload.Ref, "refs/heads/")
+	if !ok {
+		return nil
+	}
+
+	return s.processIfQueued(ctx, event.Payload.RepoID, branch)
+}
+
+// handleEventPullReqBranchUpdated ejects a pull request from the merge queue if new commits are pushed to it.
+func (s *Service) handleEventPullReqBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.processForPullReq(ctx, event.Payload.PullReqID)
+}
+
+// handleEventPullReqClosed ejects a closed pull request from the merge queue.
+func (s *Service) handleEventPullReqClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.processForPullReq(ctx, event.Payload.PullReqID)
+}
+
+// handleEventPullReqMerged removes a pull request that has been merged outside of the queue.
+func (s *Service) handleEventPullReqMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.processForPullReq(ctx, event.Payload.PullReqID)
+}
+
+// handleEventCheckReported processes the merge queues waiting for the status checks of the reported commit.
+func (s *Service) handleEventCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	entries, err := s.mergeQueueStore.ListByMergeSHA(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries by merge commit: %w", err)
+	}
+
+	processed := make(map[string]struct{})
+	for _, entry := range entries {
+		if _, ok := processed[entry.TargetBranch]; ok {
+			continue
+		}
+		processed[entry.TargetBranch] = struct{}{}
+
+		if err = s.process(ctx, entry.RepoID, entry.TargetBranch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) processForPullReq(ctx context.Context, pullreqID int64) error {
+	entry, err := s.mergeQueueStore.FindByPullReqID(ctx, pullreqID)
+	if err != nil {
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to find merge queue entry of pull request: %w", err)
+	}
+
+	return s.process(ctx, entry.RepoID, entry.TargetBranch)
+}
+
+func (s *Service) processIfQueued(ctx context.Context, repoID int64, branch string) error {
+	entries, err := s.mergeQueueStore.List(ctx, repoID, branch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return s.process(ctx, repoID, branch)
+}
@@ -0,0 +1,722 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/lock"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// queueLockExpiry is the max time we give a single run of the merge queue processing to finish.
+	queueLockExpiry = 10 * time.Minute
+
+	// mergeTimeout is the max time we give the merge of a single pull request to finish.
+	mergeTimeout = 3 * time.Minute
+)
+
+// checkState is the combined state of the required status checks of a speculative merge commit.
+type checkState int
+
+const (
+	checkStatePending checkState = iota
+	checkStateSuccess
+	checkStateFailure
+)
+
+// process brings the merge queue of the target branch up to date: Pull requests that can't be merged anymore
+// are ejected, missing or outdated speculative merge commits are (re)created and the pull requests at the
+// head of the queue are merged as long as all their required status checks have succeeded.
+//
+//nolint:gocognit // refactor if needed
+func (s *Service) process(ctx context.Context, repoID int64, targetBranch string) error {
+	// processing should complete independent of request cancellation.
+	ctx, cancel := context.WithTimeout(
+		contextutil.WithNewValues(context.Background(), ctx),
+		queueLockExpiry,
+	)
+	defer cancel()
+
+	unlock, err := s.lockQueue(ctx, repoID, targetBranch)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := s.mergeQueueStore.List(ctx, repoID, targetBranch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: targetBranch,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	targetExists := err == nil
+
+	var targetSHA string
+	if targetExists {
+		targetSHA = branchOut.Branch.SHA
+	}
+
+	// baseSHA is the commit on top of which the next entry in the queue has to be merged.
+	baseSHA := targetSHA
+	// isHead is true while the processed entry is the first one in the queue.
+	isHead := true
+	changed := false
+
+	for _, entry := range entries {
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request of merge queue entry: %w", err)
+		}
+
+		var reason string
+		switch {
+		case !targetExists:
+			reason = "The target branch doesn't exist anymore."
+		case pr.State != enum.PullReqStateOpen:
+			reason = "The pull request is not open."
+		case pr.SourceSHA != entry.SourceSHA:
+			reason = "New commits have been pushed to the pull request."
+		case pr.TargetBranch != entry.TargetBranch:
+			reason = "The target branch of the pull request has changed."
+		}
+
+		if reason == "" && (entry.State != enum.MergeQueueEntryStateChecking || entry.BaseSHA != baseSHA) {
+			changed = true
+			reason, err = s.build(ctx, repo, pr, entry, baseSHA)
+			if err != nil {
+				return err
+			}
+		}
+
+		var (
+			ruleOut    protection.MergeVerifyOutput
+			violations []types.RuleViolations
+			checks     checkState
+		)
+
+		if reason == "" {
+			principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+			if err != nil {
+				return fmt.Errorf("failed to find principal who enqueued the pull request: %w", err)
+			}
+
+			ruleOut, violations, err = s.verify(ctx, principal, repo, pr, entry.Method)
+			if err != nil {
+				return err
+			}
+
+			checks, reason, err = s.checkState(ctx, repo.ID, entry.MergeSHA, ruleOut.RequiredChecks)
+			if err != nil {
+				return err
+			}
+
+			if reason == "" && !ruleOut.UseMergeQueue {
+				reason = "The merge queue is not enabled for the target branch anymore."
+			}
+		}
+
+		if reason != "" {
+			changed = true
+			if err = s.eject(ctx, repo, pr, entry, reason); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !isHead || checks != checkStateSuccess {
+			isHead = false
+			baseSHA = entry.MergeSHA
+			continue
+		}
+
+		// the entry is at the head of the queue and its merge commit has passed the required checks.
+
+		if protection.IsCritical(violations) {
+			changed = true
+			if err = s.eject(ctx, repo, pr, entry, violationsReason(violations)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		changed = true
+		if err = s.merge(ctx, repo, pr, entry, ruleOut.DeleteSourceBranch); err != nil {
+			return err
+		}
+
+		s.protectionManager.RecordMonitorViolations(ctx, repo.ID, entry.CreatedBy,
+			enum.RuleViolationActionMerge, violations)
+
+		baseSHA = entry.MergeSHA
+	}
+
+	if changed {
+		s.publishQueue(ctx, repo, targetBranch)
+	}
+
+	return nil
+}
+
+// build creates the speculative merge commit of the entry on top of the provided base commit.
+// It returns the reason for ejecting the entry if the pull request can't be merged.
+func (s *Service) build(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	baseSHA string,
+) (string, error) {
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		var err error
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find source repository: %w", err)
+		}
+	}
+
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return "", fmt.Errorf("failed to find principal who enqueued the pull request: %w", err)
+	}
+
+	writeParams, err := s.createWriteParams(ctx, principal, repo)
+	if err != nil {
+		return "", err
+	}
+
+	writeParams.Signer, err = s.signatureService.Signer(ctx, repo.ParentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit signer: %w", err)
+	}
+
+	author, committer, title := mergeCommitInfo(principal, sourceRepo, pr, entry.Method)
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseBranch:      baseSHA,
+		HeadRepoUID:     sourceRepo.GitUID,
+		HeadBranch:      entry.SourceSHA,
+		Title:           title,
+		Committer:       committer,
+		CommitterDate:   &now,
+		Author:          author,
+		AuthorDate:      &now,
+		RefType:         gitenum.RefTypeRaw,
+		RefName:         mergeQueueRef(pr.Number),
+		HeadExpectedSHA: entry.SourceSHA,
+		Method:          gitenum.MergeMethod(entry.Method),
+	})
+	if errors.IsInvalidArgument(err) || errors.IsNotFound(err) {
+		return errors.AsError(err).Message, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create merge queue commit: %w", err)
+	}
+
+	if mergeOutput.MergeSHA == "" || len(mergeOutput.ConflictFiles) > 0 {
+		return fmt.Sprintf(
+			"The pull request conflicts with the target branch or with pull requests ahead in the queue: %s",
+			strings.Join(mergeOutput.ConflictFiles, ", ")), nil
+	}
+
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.BaseSHA = baseSHA
+	entry.MergeSHA = mergeOutput.MergeSHA
+	entry.Updated = time.Now().UnixMilli()
+
+	if err = s.mergeQueueStore.Update(ctx, entry); err != nil {
+		return "", fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	s.pullreqEvReporter.MergeQueueCommitCreated(ctx, &pullreqevents.MergeQueueCommitCreatedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  entry.CreatedBy,
+			Number:       pr.Number,
+		},
+		TargetBranch: entry.TargetBranch,
+		BaseSHA:      entry.BaseSHA,
+		MergeSHA:     entry.MergeSHA,
+		Ref:          mergeQueueRef(pr.Number),
+	})
+
+	s.publishPullReq(ctx, repo, pr)
+
+	return "", nil
+}
+
+// checkState returns the combined state of the required status checks of the merge commit.
+// If a required check failed, the reason for ejecting the entry is returned.
+func (s *Service) checkState(
+	ctx context.Context,
+	repoID int64,
+	mergeSHA string,
+	requiredChecks []string,
+) (checkState, string, error) {
+	if len(requiredChecks) == 0 {
+		return checkStateSuccess, "", nil
+	}
+
+	results, err := s.checkStore.ListResults(ctx, repoID, mergeSHA)
+	if err != nil {
+		return checkStatePending, "", fmt.Errorf("failed to list status check results: %w", err)
+	}
+
+	statuses := make(map[string]enum.CheckStatus, len(results))
+	for _, result := range results {
+		statuses[result.Identifier] = result.Status
+	}
+
+	state := checkStateSuccess
+	for _, identifier := range requiredChecks {
+		switch statuses[identifier] {
+		case enum.CheckStatusSuccess:
+		case enum.CheckStatusFailure, enum.CheckStatusError:
+			return checkStateFailure, fmt.Sprintf("The required status check %q failed.", identifier), nil
+		default:
+			state = checkStatePending
+		}
+	}
+
+	return state, "", nil
+}
+
+// verify verifies the protection rules for merging the pull request through the merge queue.
+func (s *Service) verify(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+) (protection.MergeVerifyOutput, []types.RuleViolations, error) {
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		var err error
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to find source repository: %w", err)
+		}
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to load list of reviewers: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil,
+			fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:      principal,
+		TargetRepo: repo,
+		SourceRepo: sourceRepo,
+		PullReq:    pr,
+		Reviewers:  reviewers,
+		Method:     method,
+		CodeOwners: codeOwnerWithApproval,
+		MergeQueue: true,
+		Commits: protection.NewGitCommitVerifier(s.git, s.signatureService, repo,
+			git.CreateReadParams(sourceRepo), pr.SourceSHA, pr.MergeBaseSHA),
+	})
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return ruleOut, violations, nil
+}
+
+// merge fast-forwards the target branch to the merge commit of the entry and marks the pull request as merged.
+func (s *Service) merge(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	deleteSourceBranch bool,
+) error {
+	// merges of pull requests of the same repository are serialized, see pullreq.Controller.Merge.
+	unlock, err := s.lockPullReqs(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal who enqueued the pull request: %w", err)
+	}
+
+	writeParams, err := s.createWriteParams(ctx, principal, repo)
+	if err != nil {
+		return err
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        entry.TargetBranch,
+		OldValue:    entry.BaseSHA,
+		NewValue:    entry.MergeSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update target branch to the merge queue commit: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Msgf("merge queue merged pull request %d", pr.Number)
+
+	now := time.Now().UnixMilli()
+
+	var activitySeqMerge, activitySeqBranchDeleted int64
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.State = enum.PullReqStateMerged
+
+		pr.Merged = &now
+		pr.MergedBy = &entry.CreatedBy
+		pr.MergeMethod = &entry.Method
+
+		pr.MergeCheckStatus = enum.MergeCheckStatusMergeable
+		pr.MergeTargetSHA = &entry.BaseSHA
+		pr.MergeSHA = &entry.MergeSHA
+		pr.MergeConflicts = nil
+
+		pr.AutoMerge = nil
+
+		pr.ActivitySeq++
+		activitySeqMerge = pr.ActivitySeq
+
+		if deleteSourceBranch {
+			pr.ActivitySeq++
+			activitySeqBranchDeleted = pr.ActivitySeq
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	if err = s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	s.deleteRef(ctx, writeParams, pr.Number)
+
+	pr.ActivitySeq = activitySeqMerge
+	activityPayload := &types.PullRequestActivityPayloadMerge{
+		MergeMethod: entry.Method,
+		MergeSHA:    entry.MergeSHA,
+		TargetSHA:   entry.BaseSHA,
+		SourceSHA:   entry.SourceSHA,
+	}
+	if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, entry.CreatedBy, activityPayload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
+	}
+
+	s.pullreqEvReporter.Merged(ctx, &pullreqevents.MergedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  entry.CreatedBy,
+			Number:       pr.Number,
+		},
+		MergeMethod: entry.Method,
+		MergeSHA:    entry.MergeSHA,
+		TargetSHA:   entry.BaseSHA,
+		SourceSHA:   entry.SourceSHA,
+	})
+
+	// the source branch of a pull request from a fork isn't deleted by the merge queue.
+	if deleteSourceBranch && pr.SourceRepoID == pr.TargetRepoID {
+		errDelete := s.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+			WriteParams: writeParams,
+			BranchName:  pr.SourceBranch,
+		})
+		if errDelete != nil {
+			// non-critical error
+			log.Ctx(ctx).Err(errDelete).Msgf("failed to delete source branch after merging")
+		} else {
+			pr.ActivitySeq = activitySeqBranchDeleted
+			if _, errAct := s.activityStore.CreateWithPayload(ctx, pr, entry.CreatedBy,
+				&types.PullRequestActivityPayloadBranchDelete{SHA: entry.SourceSHA}); errAct != nil {
+				// non-critical error
+				log.Ctx(ctx).Err(errAct).
+					Msgf("failed to write pull request activity for successful automatic branch delete")
+			}
+		}
+	}
+
+	s.publishPullReq(ctx, repo, pr)
+
+	return nil
+}
+
+// eject removes the entry from the merge queue because the pull request can't be merged.
+func (s *Service) eject(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	reason string,
+) error {
+	log.Ctx(ctx).Debug().Msgf("merge queue ejected pull request %d: %s", pr.Number, reason)
+
+	return s.remove(ctx, repo, pr, entry, bootstrap.NewSystemServiceSession().Principal.ID,
+		&types.PullRequestActivityPayloadMergeQueue{
+			Action: enum.MergeQueueActionEjected,
+			Reason: reason,
+		})
+}
+
+// remove deletes the entry and its merge commit reference and writes the activity to the pull request.
+func (s *Service) remove(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadMergeQueue,
+) error {
+	err := s.mergeQueueStore.Delete(ctx, entry.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	// the reference exists only if the merge commit has been created.
+	if entry.MergeSHA != "" {
+		systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+		writeParams, err := s.createWriteParams(ctx, &systemPrincipal, repo)
+		if err != nil {
+			return err
+		}
+
+		s.deleteRef(ctx, writeParams, pr.Number)
+	}
+
+	// a closed or merged pull request doesn't get activities from the merge queue.
+	if pr.State == enum.PullReqStateOpen {
+		s.writeActivity(ctx, pr, principalID, payload)
+	}
+
+	s.publishPullReq(ctx, repo, pr)
+
+	return nil
+}
+
+func (s *Service) deleteRef(ctx context.Context, writeParams git.WriteParams, pullreqNumber int64) {
+	err := s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeRaw,
+		Name:        mergeQueueRef(pullreqNumber),
+		NewValue:    "", // delete the reference
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete merge queue reference")
+	}
+}
+
+func (s *Service) writeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadMergeQueue,
+) {
+	pr, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to get pull request activity number")
+		return
+	}
+
+	if _, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request merge queue activity")
+	}
+}
+
+func (s *Service) publishPullReq(ctx context.Context, repo *types.Repository, pr *types.PullReq) {
+	if err := s.Attach(ctx, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to attach merge queue entry to pull request")
+	}
+
+	if err := s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+}
+
+func (s *Service) publishQueue(ctx context.Context, repo *types.Repository, targetBranch string) {
+	entries, err := s.mergeQueueStore.List(ctx, repo.ID, targetBranch)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list merge queue entries")
+		return
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeMergeQueueUpdated, entries); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish merge queue changed event")
+	}
+}
+
+func (s *Service) createWriteParams(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+) (git.WriteParams, error) {
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		principal.ID,
+		false,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repo.GitUID,
+		EnvVars: envVars,
+	}, nil
+}
+
+// mergeCommitInfo returns the author, the committer and the title of the merge commit,
+// the same way as they are set by pullreq.Controller.Merge.
+func mergeCommitInfo(
+	principal *types.Principal,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+) (*git.Identity, *git.Identity, string) {
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	switch method {
+	case enum.MergeMethodMerge, enum.MergeMethodRebaseMerge:
+		return identity(principal.ToPrincipalInfo()),
+			identity(systemPrincipal.ToPrincipalInfo()),
+			fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodSquash:
+		return identity(&pr.Author),
+			identity(systemPrincipal.ToPrincipalInfo()),
+			fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	case enum.MergeMethodRebase:
+		// the author info in the commits is preserved and the title isn't used.
+		return nil, identity(systemPrincipal.ToPrincipalInfo()), ""
+	case enum.MergeMethodFastForward:
+		// no commits are created.
+		return nil, nil, ""
+	}
+
+	return nil, nil, ""
+}
+
+func identity(p *types.PrincipalInfo) *git.Identity {
+	return &git.Identity{
+		Name:  p.DisplayName,
+		Email: p.Email,
+	}
+}
+
+func violationsReason(violations []types.RuleViolations) string {
+	var messages []string
+	for _, ruleViolations := range violations {
+		for _, violation := range ruleViolations.Violations {
+			messages = append(messages, violation.Message)
+		}
+	}
+
+	return "The pull request doesn't satisfy the protection rules anymore: " + strings.Join(messages, " ")
+}
+
+func (s *Service) lockQueue(ctx context.Context, repoID int64, targetBranch string) (func(), error) {
+	return s.lock(ctx, fmt.Sprintf("%d/mergequeue/%s", repoID, targetBranch), queueLockExpiry)
+}
+
+func (s *Service) lockPullReqs(ctx context.Context, repoID int64) (func(), error) {
+	// the same key is used by pullreq.Controller for locking all pull requests of a repository.
+	return s.lock(ctx, fmt.Sprintf("%d/pulls", repoID), mergeTimeout+30*time.Second)
+}
+
+func (s *Service) lock(ctx context.Context, key string, expiry time.Duration) (func(), error) {
+	mutex, err := s.mtxManager.NewMutex(
+		key,
+		lock.WithNamespace("repo"),
+		lock.WithExpiry(expiry),
+		lock.WithTimeoutFactor(60/expiry.Seconds()), // 60s
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new mutex for key %q: %w", key, err)
+	}
+
+	if err = mutex.Lock(ctx); err != nil {
+		return nil, fmt.Errorf("failed to lock mutex for key %q: %w", key, err)
+	}
+
+	return func() {
+		if err := mutex.Unlock(ctx); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to unlock mutex for key %q", key)
+		}
+	}, nil
+}
@@ -0,0 +1,702 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signature"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/lock"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	testRepoID      = 1
+	testPrincipalID = 2
+	testTargetSHA   = "target"
+	testCheck       = "ci"
+)
+
+type fakeMergeQueueStore struct {
+	store.MergeQueueStore
+	entries []*types.MergeQueueEntry
+}
+
+func (s *fakeMergeQueueStore) FindByPullReqID(_ context.Context, pullreqID int64) (*types.MergeQueueEntry, error) {
+	for _, entry := range s.entries {
+		if entry.PullReqID == pullreqID {
+			e := *entry
+			return &e, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeMergeQueueStore) Update(_ context.Context, entry *types.MergeQueueEntry) error {
+	for i := range s.entries {
+		if s.entries[i].ID == entry.ID {
+			e := *entry
+			s.entries[i] = &e
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeMergeQueueStore) Delete(_ context.Context, id int64) error {
+	for i := range s.entries {
+		if s.entries[i].ID == id {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeMergeQueueStore) List(context.Context, int64, string) ([]*types.MergeQueueEntry, error) {
+	entries := make([]*types.MergeQueueEntry, len(s.entries))
+	for i, entry := range s.entries {
+		e := *entry
+		e.Position = i
+		entries[i] = &e
+	}
+	return entries, nil
+}
+
+type fakePullReqStore struct {
+	store.PullReqStore
+	prs map[int64]*types.PullReq
+}
+
+func (s *fakePullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	p := *pr
+	return &p, nil
+}
+
+func (s *fakePullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	p := *pr
+	if err := mutateFn(&p); err != nil {
+		return nil, err
+	}
+	s.prs[p.ID] = &p
+	updated := p
+	return &updated, nil
+}
+
+func (s *fakePullReqStore) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	p := *pr
+	p.ActivitySeq++
+	return &p, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	return &types.Repository{ID: id, ParentID: 1, GitUID: "repo-uid", Path: "space/repo"}, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{ID: id, UID: "user", DisplayName: "User", Email: "user@example.com"}, nil
+}
+
+type fakeActivityStore struct {
+	store.PullReqActivityStore
+	payloads map[int64][]types.PullReqActivityPayload
+}
+
+func (s *fakeActivityStore) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+) (*types.PullReqActivity, error) {
+	s.payloads[pr.Number] = append(s.payloads[pr.Number], payload)
+	return &types.PullReqActivity{}, nil
+}
+
+type fakeReviewerStore struct {
+	store.PullReqReviewerStore
+}
+
+func (fakeReviewerStore) List(context.Context, int64) ([]*types.PullReqReviewer, error) {
+	return nil, nil
+}
+
+type fakeCheckStore struct {
+	store.CheckStore
+	results map[string]enum.CheckStatus
+}
+
+func (s fakeCheckStore) ListResults(_ context.Context, _ int64, commitSHA string) ([]types.CheckResult, error) {
+	status, ok := s.results[commitSHA]
+	if !ok {
+		return nil, nil
+	}
+	return []types.CheckResult{{Identifier: testCheck, Status: status}}, nil
+}
+
+type fakeRuleStore struct {
+	store.RuleStore
+	rules []types.RuleInfoInternal
+}
+
+func (s fakeRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	return s.rules, nil
+}
+
+type fakeServerSigningKeyStore struct {
+	store.ServerSigningKeyStore
+}
+
+func (fakeServerSigningKeyStore) List(context.Context) ([]*types.ServerSigningKey, error) {
+	return nil, nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GetInternalAPIURL() string {
+	return "http://localhost:3000"
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+}
+
+func (fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) error {
+	return nil
+}
+
+// fakeGit creates speculative merge commits with the SHA "<base>+<head>",
+// which makes the chain of commits a merge commit was built on visible in the tests.
+type fakeGit struct {
+	git.Interface
+	targetSHA  string
+	conflicts  map[string][]string
+	merges     []*git.MergeParams
+	refUpdates []git.UpdateRefParams
+}
+
+func (g *fakeGit) GetBranch(context.Context, *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	return &git.GetBranchOutput{Branch: git.Branch{Name: "main", SHA: g.targetSHA}}, nil
+}
+
+func (g *fakeGit) Merge(_ context.Context, params *git.MergeParams) (git.MergeOutput, error) {
+	g.merges = append(g.merges, params)
+
+	if files, ok := g.conflicts[params.HeadBranch]; ok {
+		return git.MergeOutput{ConflictFiles: files}, nil
+	}
+
+	return git.MergeOutput{
+		BaseSHA:  params.BaseBranch,
+		HeadSHA:  params.HeadBranch,
+		MergeSHA: params.BaseBranch + "+" + params.HeadBranch,
+	}, nil
+}
+
+func (g *fakeGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	g.refUpdates = append(g.refUpdates, params)
+	if params.Type == gitenum.RefTypeBranch {
+		g.targetSHA = params.NewValue
+	}
+	return nil
+}
+
+func (g *fakeGit) DeleteBranch(context.Context, *git.DeleteBranchParams) error {
+	return nil
+}
+
+type fakes struct {
+	git        *fakeGit
+	queue      *fakeMergeQueueStore
+	pullreqs   *fakePullReqStore
+	activities *fakeActivityStore
+}
+
+// setupService returns a service maintaining the merge queue of the branch "main" with the provided
+// entries. The pull request of each entry is open and its number matches the entry's PullReqID.
+func setupService(
+	t *testing.T,
+	entries []*types.MergeQueueEntry,
+	checks map[string]enum.CheckStatus,
+	conflicts map[string][]string,
+) (*Service, *fakes) {
+	t.Helper()
+
+	bootstrap.SetSystemServicePrincipal(&types.Principal{ID: 1, UID: "gitness", Email: "system@gitness.io"})
+
+	definition, err := protection.ToJSON(&protection.Branch{
+		PullReq: protection.DefPullReq{
+			StatusChecks: protection.DefStatusChecks{RequireIdentifiers: []string{testCheck}},
+			Merge:        protection.DefMerge{UseMergeQueue: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal rule definition: %v", err)
+	}
+
+	protectionManager, err := protection.ProvideManager(fakeRuleStore{rules: []types.RuleInfoInternal{{
+		RuleInfo: types.RuleInfo{
+			ID:         1,
+			Identifier: "queue",
+			Type:       protection.TypeBranch,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    (&protection.Pattern{Include: []string{"main"}}).JSON(),
+		Definition: definition,
+	}}}, nil)
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %v", err)
+	}
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %v", err)
+	}
+
+	eventReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %v", err)
+	}
+
+	f := &fakes{
+		git:        &fakeGit{targetSHA: testTargetSHA, conflicts: conflicts},
+		queue:      &fakeMergeQueueStore{entries: entries},
+		pullreqs:   &fakePullReqStore{prs: map[int64]*types.PullReq{}},
+		activities: &fakeActivityStore{payloads: map[int64][]types.PullReqActivityPayload{}},
+	}
+
+	for _, entry := range entries {
+		f.pullreqs.prs[entry.PullReqID] = &types.PullReq{
+			ID:           entry.PullReqID,
+			Number:       entry.PullReqNumber,
+			State:        enum.PullReqStateOpen,
+			SourceRepoID: testRepoID,
+			TargetRepoID: testRepoID,
+			SourceBranch: entry.SourceSHA,
+			SourceSHA:    entry.SourceSHA,
+			TargetBranch: "main",
+		}
+	}
+
+	s := &Service{
+		git:               f.git,
+		urlProvider:       fakeURLProvider{},
+		mtxManager:        lock.NewInMemory(lock.Config{}),
+		mergeQueueStore:   f.queue,
+		pullreqStore:      f.pullreqs,
+		repoStore:         fakeRepoStore{},
+		principalStore:    fakePrincipalStore{},
+		activityStore:     f.activities,
+		reviewerStore:     fakeReviewerStore{},
+		checkStore:        fakeCheckStore{results: checks},
+		protectionManager: protectionManager,
+		codeOwners:        codeowners.New(nil, f.git, codeowners.Config{}, nil, nil),
+		signatureService: signature.NewService("gitness", nil, nil, nil, nil, nil,
+			fakeServerSigningKeyStore{}),
+		pullreqEvReporter: eventReporter,
+		sseStreamer:       fakeStreamer{},
+	}
+
+	return s, f
+}
+
+// testEntry returns a merge queue entry of the pull request with the provided number.
+// The source branch of the pull request is named after its source commit.
+func testEntry(number int64, sourceSHA string) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		ID:            number,
+		RepoID:        testRepoID,
+		PullReqID:     number,
+		PullReqNumber: number,
+		TargetBranch:  "main",
+		Method:        enum.MergeMethodMerge,
+		State:         enum.MergeQueueEntryStatePending,
+		SourceSHA:     sourceSHA,
+		CreatedBy:     testPrincipalID,
+	}
+}
+
+// checkingEntry returns the entry in the state after its merge commit has been built on top of baseSHA.
+func checkingEntry(entry *types.MergeQueueEntry, baseSHA string) *types.MergeQueueEntry {
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.BaseSHA = baseSHA
+	entry.MergeSHA = baseSHA + "+" + entry.SourceSHA
+	return entry
+}
+
+func pullReqBySource(prs map[int64]*types.PullReq, sourceSHA string) *types.PullReq {
+	for _, pr := range prs {
+		if pr.SourceBranch == sourceSHA {
+			return pr
+		}
+	}
+	return nil
+}
+
+type queued struct {
+	number  int64
+	state   enum.MergeQueueEntryState
+	baseSHA string
+}
+
+type ref struct {
+	old string
+	new string
+}
+
+//nolint:gocognit // table driven test
+func TestService_process(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []*types.MergeQueueEntry
+		modify    func(prs map[int64]*types.PullReq)
+		checks    map[string]enum.CheckStatus
+		conflicts map[string][]string
+
+		// wantBuilds are the base and the head commits of the created speculative merge commits.
+		wantBuilds []ref
+		// wantMerged are the old and the new values of the target branch updates.
+		wantMerged []ref
+		// wantEjected are the ejection reasons of the ejected pull requests by number.
+		wantEjected map[int64]string
+		wantQueue   []queued
+	}{
+		{
+			name:       "speculative merge commits are built on the target branch and on the entries ahead",
+			entries:    []*types.MergeQueueEntry{testEntry(1, "a"), testEntry(2, "b")},
+			wantBuilds: []ref{{testTargetSHA, "a"}, {testTargetSHA + "+a", "b"}},
+			wantQueue: []queued{
+				{1, enum.MergeQueueEntryStateChecking, testTargetSHA},
+				{2, enum.MergeQueueEntryStateChecking, testTargetSHA + "+a"},
+			},
+		},
+		{
+			name: "entries with up to date merge commits aren't rebuilt",
+			entries: []*types.MergeQueueEntry{
+				checkingEntry(testEntry(1, "a"), testTargetSHA),
+				checkingEntry(testEntry(2, "b"), testTargetSHA+"+a"),
+			},
+			checks: map[string]enum.CheckStatus{testTargetSHA + "+a": enum.CheckStatusRunning},
+			wantQueue: []queued{
+				{1, enum.MergeQueueEntryStateChecking, testTargetSHA},
+				{2, enum.MergeQueueEntryStateChecking, testTargetSHA + "+a"},
+			},
+		},
+		{
+			name: "head entry is merged once its checks succeeded",
+			entries: []*types.MergeQueueEntry{
+				checkingEntry(testEntry(1, "a"), testTargetSHA),
+				checkingEntry(testEntry(2, "b"), testTargetSHA+"+a"),
+			},
+			checks:     map[string]enum.CheckStatus{testTargetSHA + "+a": enum.CheckStatusSuccess},
+			wantMerged: []ref{{testTargetSHA, testTargetSHA + "+a"}},
+			wantQueue:  []queued{{2, enum.MergeQueueEntryStateChecking, testTargetSHA + "+a"}},
+		},
+		{
+			name: "entries are merged in order",
+			entries: []*types.MergeQueueEntry{
+				checkingEntry(testEntry(1, "a"), testTargetSHA),
+				checkingEntry(testEntry(2, "b"), testTargetSHA+"+a"),
+			},
+			checks: map[string]enum.CheckStatus{
+				testTargetSHA + "+a":   enum.CheckStatusSuccess,
+				testTargetSHA + "+a+b": enum.CheckStatusSuccess,
+			},
+			wantMerged: []ref{
+				{testTargetSHA, testTargetSHA + "+a"},
+				{testTargetSHA + "+a", testTargetSHA + "+a+b"},
+			},
+		},
+		{
+			name: "succeeded entry behind a pending one isn't merged",
+			entries: []*types.MergeQueueEntry{
+				checkingEntry(testEntry(1, "a"), testTargetSHA),
+				checkingEntry(testEntry(2, "b"), testTargetSHA+"+a"),
+			},
+			checks: map[string]enum.CheckStatus{testTargetSHA + "+a+b": enum.CheckStatusSuccess},
+			wantQueue: []queued{
+				{1, enum.MergeQueueEntryStateChecking, testTargetSHA},
+				{2, enum.MergeQueueEntryStateChecking, testTargetSHA + "+a"},
+			},
+		},
+		{
+			name: "entry with new commits is ejected and the entries behind are rebuilt",
+			entries: []*types.MergeQueueEntry{
+				checkingEntry(testEntry(1, "a"), testTargetSHA),
+				checkingEntry(testEntry(2, "b"), testTargetSHA+"+a"),
+			},
+			modify: func(prs map[int64]*types.PullReq) {
+				prs[1].SourceSHA = "a2"
+			},
+			wantBuilds:  []ref{{testTargetSHA, "b"}},
+			wantEjected: map[int64]string{1: "New commits have been pushed to the pull request."},
+			wantQueue:   []queued{{2, enum.MergeQueueEntryStateChecking, testTargetSHA}},
+		},
+		{
+			name: "entry with failed checks is ejected and the entries behind are rebuilt",
+			entries: []*types.MergeQueueEntry{
+				checkingEntry(testEntry(1, "a"), testTargetSHA),
+				checkingEntry(testEntry(2, "b"), testTargetSHA+"+a"),
+				checkingEntry(testEntry(3, "c"), testTargetSHA+"+a+b"),
+			},
+			checks: map[string]enum.CheckStatus{
+				testTargetSHA + "+a":   enum.CheckStatusRunning,
+				testTargetSHA + "+a+b": enum.CheckStatusFailure,
+			},
+			wantBuilds:  []ref{{testTargetSHA + "+a", "c"}},
+			wantEjected: map[int64]string{2: `The required status check "ci" failed.`},
+			wantQueue: []queued{
+				{1, enum.MergeQueueEntryStateChecking, testTargetSHA},
+				{3, enum.MergeQueueEntryStateChecking, testTargetSHA + "+a"},
+			},
+		},
+		{
+			name:       "conflicting entry is ejected and the entries behind are built without it",
+			entries:    []*types.MergeQueueEntry{testEntry(1, "a"), testEntry(2, "b")},
+			conflicts:  map[string][]string{"a": {"file.txt"}},
+			wantBuilds: []ref{{testTargetSHA, "a"}, {testTargetSHA, "b"}},
+			wantEjected: map[int64]string{
+				1: "The pull request conflicts with the target branch or with pull requests ahead in the queue: file.txt",
+			},
+			wantQueue: []queued{{2, enum.MergeQueueEntryStateChecking, testTargetSHA}},
+		},
+		{
+			name: "entries are rebuilt after the target branch was updated",
+			entries: []*types.MergeQueueEntry{
+				checkingEntry(testEntry(1, "a"), "old"),
+				checkingEntry(testEntry(2, "b"), "old+a"),
+			},
+			wantBuilds: []ref{{testTargetSHA, "a"}, {testTargetSHA + "+a", "b"}},
+			wantQueue: []queued{
+				{1, enum.MergeQueueEntryStateChecking, testTargetSHA},
+				{2, enum.MergeQueueEntryStateChecking, testTargetSHA + "+a"},
+			},
+		},
+		{
+			name:    "closed pull request is removed without an activity",
+			entries: []*types.MergeQueueEntry{checkingEntry(testEntry(1, "a"), testTargetSHA)},
+			modify: func(prs map[int64]*types.PullReq) {
+				prs[1].State = enum.PullReqStateClosed
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, f := setupService(t, test.entries, test.checks, test.conflicts)
+			if test.modify != nil {
+				test.modify(f.pullreqs.prs)
+			}
+
+			if err := s.process(context.Background(), testRepoID, "main"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var builds []ref
+			for _, params := range f.git.merges {
+				builds = append(builds, ref{params.BaseBranch, params.HeadBranch})
+
+				pr := pullReqBySource(f.pullreqs.prs, params.HeadBranch)
+				if params.RefType != gitenum.RefTypeRaw || params.RefName != mergeQueueRef(pr.Number) {
+					t.Errorf("merge commit ref: got=%s want=%s", params.RefName, mergeQueueRef(pr.Number))
+				}
+				if params.HeadExpectedSHA != params.HeadBranch {
+					t.Errorf("expected head: got=%s want=%s", params.HeadExpectedSHA, params.HeadBranch)
+				}
+			}
+			if !slices.Equal(builds, test.wantBuilds) {
+				t.Errorf("builds: got=%v want=%v", builds, test.wantBuilds)
+			}
+
+			var merged []ref
+			for _, params := range f.git.refUpdates {
+				if params.Type != gitenum.RefTypeBranch {
+					continue
+				}
+				if params.Name != "main" {
+					t.Errorf("updated branch: got=%s want=main", params.Name)
+				}
+				merged = append(merged, ref{params.OldValue, params.NewValue})
+			}
+			if !slices.Equal(merged, test.wantMerged) {
+				t.Errorf("merges: got=%v want=%v", merged, test.wantMerged)
+			}
+
+			for _, m := range test.wantMerged {
+				pr := pullReqBySource(f.pullreqs.prs, m.new[strings.LastIndex(m.new, "+")+1:])
+				if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || *pr.MergeSHA != m.new ||
+					pr.MergeTargetSHA == nil || *pr.MergeTargetSHA != m.old {
+					t.Errorf("pull request %d: got state=%s merge_sha=%v, want merged into %s",
+						pr.Number, pr.State, pr.MergeSHA, m.new)
+				}
+			}
+
+			for number, payloads := range f.activities.payloads {
+				for _, payload := range payloads {
+					p, ok := payload.(*types.PullRequestActivityPayloadMergeQueue)
+					if !ok {
+						continue
+					}
+					want, ok := test.wantEjected[number]
+					if !ok || p.Action != enum.MergeQueueActionEjected || p.Reason != want {
+						t.Errorf("pull request %d activity: got=%+v want ejected with %q", number, p, want)
+					}
+				}
+			}
+			for number := range test.wantEjected {
+				if len(f.activities.payloads[number]) == 0 {
+					t.Errorf("pull request %d: expected ejection activity", number)
+				}
+			}
+
+			var queue []queued
+			for _, entry := range f.queue.entries {
+				queue = append(queue, queued{entry.PullReqNumber, entry.State, entry.BaseSHA})
+				if entry.State == enum.MergeQueueEntryStateChecking &&
+					entry.MergeSHA != entry.BaseSHA+"+"+entry.SourceSHA {
+					t.Errorf("entry %d merge commit: got=%s want=%s",
+						entry.ID, entry.MergeSHA, entry.BaseSHA+"+"+entry.SourceSHA)
+				}
+			}
+			if !slices.Equal(queue, test.wantQueue) {
+				t.Errorf("queue: got=%v want=%v", queue, test.wantQueue)
+			}
+
+			// the merge commit references of merged and ejected pull requests are deleted.
+			for _, entry := range test.entries {
+				if slices.ContainsFunc(f.queue.entries, func(e *types.MergeQueueEntry) bool {
+					return e.ID == entry.ID
+				}) {
+					continue
+				}
+				deleted := slices.ContainsFunc(f.git.refUpdates, func(params git.UpdateRefParams) bool {
+					return params.Type == gitenum.RefTypeRaw &&
+						params.Name == mergeQueueRef(entry.PullReqNumber) && params.NewValue == ""
+				})
+				if !deleted {
+					t.Errorf("expected merge commit reference of pull request %d to be deleted", entry.PullReqNumber)
+				}
+			}
+		})
+	}
+}
+
+func TestService_checkState(t *testing.T) {
+	tests := []struct {
+		name       string
+		required   []string
+		results    map[string]enum.CheckStatus
+		wantState  checkState
+		wantReason string
+	}{
+		{
+			name:      "no required checks",
+			wantState: checkStateSuccess,
+		},
+		{
+			name:      "required check succeeded",
+			required:  []string{testCheck},
+			results:   map[string]enum.CheckStatus{"merge": enum.CheckStatusSuccess},
+			wantState: checkStateSuccess,
+		},
+		{
+			name:      "required check is running",
+			required:  []string{testCheck},
+			results:   map[string]enum.CheckStatus{"merge": enum.CheckStatusRunning},
+			wantState: checkStatePending,
+		},
+		{
+			name:      "required check not reported",
+			required:  []string{testCheck},
+			wantState: checkStatePending,
+		},
+		{
+			name:       "required check failed",
+			required:   []string{testCheck},
+			results:    map[string]enum.CheckStatus{"merge": enum.CheckStatusFailure},
+			wantState:  checkStateFailure,
+			wantReason: `The required status check "ci" failed.`,
+		},
+		{
+			name:       "required check errored",
+			required:   []string{testCheck},
+			results:    map[string]enum.CheckStatus{"merge": enum.CheckStatusError},
+			wantState:  checkStateFailure,
+			wantReason: `The required status check "ci" failed.`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{checkStore: fakeCheckStore{results: test.results}}
+
+			state, reason, err := s.checkState(context.Background(), testRepoID, "merge", test.required)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if state != test.wantState || reason != test.wantReason {
+				t.Errorf("got=%d,%q want=%d,%q", state, reason, test.wantState, test.wantReason)
+			}
+		})
+	}
+}
+
+func TestViolationsReason(t *testing.T) {
+	reason := violationsReason([]types.RuleViolations{{
+		Violations: []types.Violation{{Message: "Approval required."}, {Message: "Comments unresolved."}},
+	}})
+
+	if !strings.HasSuffix(reason, "Approval required. Comments unresolved.") {
+		t.Errorf("got=%q", reason)
+	}
+}
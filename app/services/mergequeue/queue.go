@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Enqueue adds the pull request to the end of the merge queue of its target branch.
+// If the pull request doesn't satisfy the protection rules, the rule violations are returned.
+func (s *Service) Enqueue(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+) (*types.MergeQueueEntry, []types.RuleViolations, error) {
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, errors.InvalidArgument("Pull request must be open.")
+	}
+
+	if pr.IsDraft {
+		return nil, nil, errors.InvalidArgument("Draft pull requests can't be merged. Clear the draft flag first.")
+	}
+
+	if pr.MergeCheckStatus == enum.MergeCheckStatusConflict {
+		return nil, nil, errors.InvalidArgument("Pull request has merge conflicts with the target branch.")
+	}
+
+	_, err := s.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if err == nil {
+		return nil, nil, errors.Conflict("Pull request is already in the merge queue.")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil, fmt.Errorf("failed to find merge queue entry of pull request: %w", err)
+	}
+
+	ruleOut, violations, err := s.verify(ctx, principal, repo, pr, method)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !ruleOut.UseMergeQueue {
+		return nil, nil, errors.InvalidArgument("Merge queue is not enabled for branch %q.", pr.TargetBranch)
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		RepoID:        repo.ID,
+		PullReqID:     pr.ID,
+		PullReqNumber: pr.Number,
+		TargetBranch:  pr.TargetBranch,
+		Method:        method,
+		State:         enum.MergeQueueEntryStatePending,
+		SourceSHA:     pr.SourceSHA,
+		CreatedBy:     principal.ID,
+		Created:       now,
+		Updated:       now,
+	}
+
+	err = s.mergeQueueStore.Create(ctx, entry)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, nil, errors.Conflict("Pull request is already in the merge queue.")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create merge queue entry: %w", err)
+	}
+
+	s.writeActivity(ctx, pr, principal.ID, &types.PullRequestActivityPayloadMergeQueue{
+		Action: enum.MergeQueueActionEnqueued,
+		Method: method,
+	})
+
+	if err = s.process(ctx, repo.ID, pr.TargetBranch); err != nil {
+		// non-critical error, the queue is processed again on the next relevant event.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to process merge queue after enqueuing pull request")
+	}
+
+	return entry, nil, nil
+}
+
+// Dequeue removes the pull request from the merge queue of its target branch.
+func (s *Service) Dequeue(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	pr *types.PullReq,
+) error {
+	unlock, err := s.lockQueue(ctx, repo.ID, pr.TargetBranch)
+	if err != nil {
+		return err
+	}
+
+	entry, err := s.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		unlock()
+		return errors.NotFound("Pull request is not in the merge queue.")
+	}
+	if err != nil {
+		unlock()
+		return fmt.Errorf("failed to find merge queue entry of pull request: %w", err)
+	}
+
+	err = s.remove(ctx, repo, pr, entry, principal.ID, &types.PullRequestActivityPayloadMergeQueue{
+		Action: enum.MergeQueueActionDequeued,
+	})
+	unlock()
+	if err != nil {
+		return err
+	}
+
+	// the pull requests behind the removed one have to be rebuilt.
+	if err = s.process(ctx, repo.ID, entry.TargetBranch); err != nil {
+		// non-critical error, the queue is processed again on the next relevant event.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to process merge queue after dequeuing pull request")
+	}
+
+	return nil
+}
+
+// List returns the entries of the merge queue of the target branch in order.
+func (s *Service) List(ctx context.Context, repoID int64, targetBranch string) ([]*types.MergeQueueEntry, error) {
+	entries, err := s.mergeQueueStore.List(ctx, repoID, targetBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Attach sets the merge queue entry of the pull request, if the pull request is in a merge queue.
+func (s *Service) Attach(ctx context.Context, pr *types.PullReq) error {
+	entry, err := s.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		pr.MergeQueue = nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry of pull request: %w", err)
+	}
+
+	entries, err := s.mergeQueueStore.List(ctx, entry.RepoID, entry.TargetBranch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.ID == entry.ID {
+			entry.Position = e.Position
+			break
+		}
+	}
+
+	pr.MergeQueue = entry
+
+	return nil
+}
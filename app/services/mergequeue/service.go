@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signature"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/stream"
+)
+
+const (
+	eventsReaderGroupName = "gitness:mergequeue"
+
+	// refPrefix is the prefix of the references pointing to speculative merge commits of pull requests.
+	refPrefix = "refs/mergequeue/"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service maintains merge queues of branches for which the merge queue is enabled by a protection rule.
+//
+// Pull requests in a queue are speculatively merged on top of each other, each into its own reference.
+// Required status checks are run against these merge commits and the pull requests are merged in order
+// once their checks succeed. A pull request that fails is ejected from the queue and the queue is rebuilt.
+type Service struct {
+	config            Config
+	git               git.Interface
+	urlProvider       url.Provider
+	mtxManager        lock.MutexManager
+	mergeQueueStore   store.MergeQueueStore
+	pullreqStore      store.PullReqStore
+	repoStore         store.RepoStore
+	principalStore    store.PrincipalStore
+	activityStore     store.PullReqActivityStore
+	reviewerStore     store.PullReqReviewerStore
+	checkStore        store.CheckStore
+	protectionManager *protection.Manager
+	codeOwners        *codeowners.Service
+	signatureService  *signature.Service
+	pullreqEvReporter *pullreqevents.Reporter
+	sseStreamer       sse.Streamer
+}
+
+//nolint:funlen // needs refactoring
+func NewService(
+	ctx context.Context,
+	config Config,
+	git git.Interface,
+	urlProvider url.Provider,
+	mtxManager lock.MutexManager,
+	mergeQueueStore store.MergeQueueStore,
+	pullreqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	checkStore store.CheckStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	signatureService *signature.Service,
+	pullreqEvReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided merge queue service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:            config,
+		git:               git,
+		urlProvider:       urlProvider,
+		mtxManager:        mtxManager,
+		mergeQueueStore:   mergeQueueStore,
+		pullreqStore:      pullreqStore,
+		repoStore:         repoStore,
+		principalStore:    principalStore,
+		activityStore:     activityStore,
+		reviewerStore:     reviewerStore,
+		checkStore:        checkStore,
+		protectionManager: protectionManager,
+		codeOwners:        codeOwners,
+		signatureService:  signatureService,
+		pullreqEvReporter: pullreqEvReporter,
+		sseStreamer:       sseStreamer,
+	}
+
+	const idleTimeout = 1 * time.Minute
+
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(service.handleEventBranchDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for merge queue: %w", err)
+	}
+
+	_, err = pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchUpdated(service.handleEventPullReqBranchUpdated)
+			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader for merge queue: %w", err)
+	}
+
+	_, err = checkEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterReported(service.handleEventCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for merge queue: %w", err)
+	}
+
+	return service, nil
+}
+
+func mergeQueueRef(pullreqNumber int64) string {
+	return fmt.Sprintf("%s%d", refPrefix, pullreqNumber)
+}
@@ -0,0 +1,357 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/store"
+	gitnessurl "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "gitness:mirror:sync"
+	jobCron = "* * * * *" // every minute
+
+	// maxMirrorsPerRun is the maximum number of mirrors synced by a single run of the sync job.
+	maxMirrorsPerRun = 100
+
+	// maxSyncErrorLength is the maximum length of a sync error stored with the mirror.
+	maxSyncErrorLength = 1024
+)
+
+type Config struct {
+	MinInterval         time.Duration
+	DefaultInterval     time.Duration
+	MaxDuration         time.Duration
+	AllowLoopback       bool
+	AllowPrivateNetwork bool
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.MinInterval <= 0 {
+		return errors.New("config.MinInterval has to be provided")
+	}
+	if c.DefaultInterval < c.MinInterval {
+		return errors.New("config.DefaultInterval can't be smaller than config.MinInterval")
+	}
+	if c.MaxDuration <= 0 {
+		return errors.New("config.MaxDuration has to be provided")
+	}
+	return nil
+}
+
+// Service is responsible for syncing pull and push mirrors of repositories.
+// Pull mirrors are synced periodically, push mirrors are synced after every push to the repository.
+type Service struct {
+	config      Config
+	scheduler   *job.Scheduler
+	executor    *job.Executor
+	mirrorStore store.RepoMirrorStore
+	repoStore   store.RepoStore
+	git         git.Interface
+	urlProvider gitnessurl.Provider
+	encrypter   encrypt.Encrypter
+}
+
+func NewService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	mirrorStore store.RepoMirrorStore,
+	repoStore store.RepoStore,
+	git git.Interface,
+	urlProvider gitnessurl.Provider,
+	encrypter encrypt.Encrypter,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided mirror config is invalid: %w", err)
+	}
+
+	return &Service{
+		config:      config,
+		scheduler:   scheduler,
+		executor:    executor,
+		mirrorStore: mirrorStore,
+		repoStore:   repoStore,
+		git:         git,
+		urlProvider: urlProvider,
+		encrypter:   encrypter,
+	}, nil
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if err := s.executor.Register(jobType, s); err != nil {
+		return fmt.Errorf("failed to register job handler for mirror sync: %w", err)
+	}
+
+	if err := s.scheduler.AddRecurring(ctx, jobType, jobType, jobCron, s.config.MaxDuration); err != nil {
+		return fmt.Errorf("failed to schedule mirror sync job: %w", err)
+	}
+
+	return nil
+}
+
+// EncryptPassword encrypts the password of a mirror for storing it in the database.
+func EncryptPassword(encrypter encrypt.Encrypter, password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	encrypted, err := encrypter.Encrypt(password)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt mirror password: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+func (s *Service) decryptPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	encrypted, err := base64.StdEncoding.DecodeString(password)
+	if err != nil {
+		return "", fmt.Errorf("failed to base64 decode mirror password: %w", err)
+	}
+
+	decrypted, err := s.encrypter.Decrypt(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt mirror password: %w", err)
+	}
+
+	return decrypted, nil
+}
+
+// Handle syncs all mirrors that are due.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	mirrors, err := s.mirrorStore.ListDue(ctx, time.Now().UnixMilli(), maxMirrorsPerRun)
+	if err != nil {
+		return "", fmt.Errorf("failed to list mirrors due for sync: %w", err)
+	}
+
+	if len(mirrors) == 0 {
+		return "no mirrors to sync", nil
+	}
+
+	var failed int
+	for _, mirror := range mirrors {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		if err := s.sync(ctx, mirror); err != nil {
+			failed++
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("mirror_id", mirror.ID).
+				Int64("repo_id", mirror.RepoID).
+				Msg("failed to sync repository mirror")
+		}
+	}
+
+	result := fmt.Sprintf("synced %d mirrors (%d failed)", len(mirrors), failed)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (s *Service) sync(ctx context.Context, mirror *types.RepoMirror) error {
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	mirror.LastSyncStatus = enum.MirrorSyncStatusRunning
+	mirror.LastSyncError = ""
+	if err = s.mirrorStore.UpdateSyncState(ctx, mirror); err != nil {
+		return fmt.Errorf("failed to mark mirror sync as running: %w", err)
+	}
+
+	password, err := s.decryptPassword(mirror.Password)
+	if err == nil {
+		switch mirror.Direction {
+		case enum.MirrorDirectionPull:
+			err = s.pull(ctx, repo, mirror, password)
+		case enum.MirrorDirectionPush:
+			err = s.push(ctx, repo, mirror, password)
+		default:
+			err = fmt.Errorf("unknown mirror direction %q", mirror.Direction)
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	mirror.LastSync = &now
+
+	switch {
+	case err != nil:
+		mirror.LastSyncStatus = enum.MirrorSyncStatusFailed
+		mirror.LastSyncError = sanitizeSyncError(err, password)
+		mirror.NextSync = now + mirror.Interval*1000
+	case mirror.Direction == enum.MirrorDirectionPull:
+		mirror.LastSyncStatus = enum.MirrorSyncStatusSuccess
+		mirror.NextSync = now + mirror.Interval*1000
+	default:
+		// push mirrors are synced again once the repository receives a push.
+		mirror.LastSyncStatus = enum.MirrorSyncStatusSuccess
+		mirror.NextSync = 0
+	}
+
+	if uerr := s.mirrorStore.UpdateSyncState(ctx, mirror); uerr != nil {
+		return fmt.Errorf("failed to update mirror sync state: %w", uerr)
+	}
+
+	return err
+}
+
+func (s *Service) pull(
+	ctx context.Context,
+	repo *types.Repository,
+	mirror *types.RepoMirror,
+	password string,
+) error {
+	remoteURL, err := remoteURLWithAuth(mirror.RemoteURL, mirror.Username, password)
+	if err != nil {
+		return err
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		systemPrincipal.ID,
+		false,
+		true,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	syncOut, err := s.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams: git.WriteParams{
+			Actor: git.Identity{
+				Name:  systemPrincipal.DisplayName,
+				Email: systemPrincipal.Email,
+			},
+			RepoUID: repo.GitUID,
+			EnvVars: envVars,
+		},
+		Source:            remoteURL,
+		CreateIfNotExists: false,
+		// the sync prunes branches and tags that got deleted on the remote.
+		RefSpecs: []string{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync repository: %w", err)
+	}
+
+	if syncOut.DefaultBranch != "" && syncOut.DefaultBranch != repo.DefaultBranch {
+		_, err = s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.DefaultBranch = syncOut.DefaultBranch
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update default branch of repository: %w", err)
+		}
+	}
+
+	// the sync doesn't run git hooks, hence push mirrors have to be triggered explicitly.
+	err = s.mirrorStore.ScheduleSyncByRepo(ctx, repo.ID, enum.MirrorDirectionPush, time.Now().UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to schedule push mirrors: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) push(
+	ctx context.Context,
+	repo *types.Repository,
+	mirror *types.RepoMirror,
+	password string,
+) error {
+	remoteURL, err := remoteURLWithAuth(mirror.RemoteURL, mirror.Username, password)
+	if err != nil {
+		return err
+	}
+
+	err = s.git.PushRemote(ctx, &git.PushRemoteParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		RemoteURL:  remoteURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to push to remote repository: %w", err)
+	}
+
+	return nil
+}
+
+func remoteURLWithAuth(rawURL string, username string, password string) (string, error) {
+	remoteURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse remote url: %w", err)
+	}
+
+	if username != "" || password != "" {
+		remoteURL.User = url.UserPassword(username, password)
+	}
+
+	return remoteURL.String(), nil
+}
+
+// sanitizeSyncError converts the sync error into a message that can be shown to the user.
+// It ensures the password of the remote isn't leaked in case git included the remote URL in the error.
+func sanitizeSyncError(err error, password string) string {
+	msg := err.Error()
+
+	// errors of the git service only contain a generic message, the source error contains the output of git.
+	if gitErr := errors.AsError(err); gitErr != nil && gitErr.Err != nil {
+		msg = gitErr.Err.Error()
+	}
+
+	if password != "" {
+		// git might print the remote URL with the password in its escaped form.
+		escaped := strings.TrimPrefix(url.UserPassword("", password).String(), ":")
+		msg = strings.ReplaceAll(msg, escaped, "*****")
+		msg = strings.ReplaceAll(msg, password, "*****")
+	}
+
+	if len(msg) > maxSyncErrorLength {
+		msg = msg[:maxSyncErrorLength]
+	}
+
+	return msg
+}
@@ -29,6 +29,7 @@ type ReviewSubmittedPayload struct {
 	Author   *types.PrincipalInfo
 	Reviewer *types.PrincipalInfo
 	Decision enum.PullReqReviewDecision
+	Comments int
 }
 
 func (s *Service) notifyReviewSubmitted(
@@ -96,5 +97,6 @@ func (s *Service) processReviewSubmittedEvent(
 		Author:   authorPrincipal,
 		Decision: event.Payload.Decision,
 		Reviewer: reviewerPrincipal,
+		Comments: len(event.Payload.CommentIDs),
 	}, []*types.PrincipalInfo{authorPrincipal}, nil
 }
@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/signature"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// GitCommitVerifier provides and verifies signatures of the commits in a range, e.g. of the commits of a pull request.
+type GitCommitVerifier struct {
+	git              git.Interface
+	signatureService *signature.Service
+	repo             *types.Repository
+	readParams       git.ReadParams
+	ref              string
+	after            string
+
+	gitCommits []git.Commit
+}
+
+var _ CommitProvider = (*GitCommitVerifier)(nil)
+
+// NewGitCommitVerifier returns a CommitProvider of the commits reachable from ref, but not from after.
+// The signatures are verified with the server signing keys trusted for the repo, e.g. the target of a pull request.
+func NewGitCommitVerifier(
+	git git.Interface,
+	signatureService *signature.Service,
+	repo *types.Repository,
+	readParams git.ReadParams,
+	ref string,
+	after string,
+) *GitCommitVerifier {
+	return &GitCommitVerifier{
+		git:              git,
+		signatureService: signatureService,
+		repo:             repo,
+		readParams:       readParams,
+		ref:              ref,
+		after:            after,
+	}
+}
+
+func (v *GitCommitVerifier) listCommits(ctx context.Context) ([]git.Commit, error) {
+	if v.gitCommits != nil {
+		return v.gitCommits, nil
+	}
+
+	out, err := v.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: v.readParams,
+		GitREF:     v.ref,
+		After:      v.after,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	v.gitCommits = out.Commits
+	if v.gitCommits == nil {
+		v.gitCommits = []git.Commit{}
+	}
+
+	return v.gitCommits, nil
+}
+
+func (v *GitCommitVerifier) Commits(ctx context.Context) ([]PushCommit, error) {
+	gitCommits, err := v.listCommits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]PushCommit, len(gitCommits))
+	for i, commit := range gitCommits {
+		commits[i] = PushCommit{
+			SHA:            commit.SHA,
+			Message:        commit.Message,
+			AuthorEmail:    commit.Author.Identity.Email,
+			CommitterEmail: commit.Committer.Identity.Email,
+			ParentSHAs:     commit.ParentSHAs,
+		}
+	}
+
+	return commits, nil
+}
+
+func (v *GitCommitVerifier) VerifyCommits(ctx context.Context) ([]CommitVerification, error) {
+	gitCommits, err := v.listCommits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifyCommits(ctx, v.signatureService, v.repo, gitCommits)
+}
+
+func verifyCommits(
+	ctx context.Context,
+	signatureService *signature.Service,
+	repo *types.Repository,
+	commits []git.Commit,
+) ([]CommitVerification, error) {
+	verifier := signatureService.NewVerifier(repo)
+
+	verifications := make([]CommitVerification, len(commits))
+	for i := range commits {
+		verification, err := verifier.VerifyCommit(ctx, &commits[i])
+		if err != nil {
+			return nil, err
+		}
+
+		verifications[i] = CommitVerification{
+			SHA:          commits[i].SHA,
+			Verification: verification,
+		}
+	}
+
+	return verifications, nil
+}
+
+// GitAncestryChecker checks the ancestry of the git revisions of a repository.
+type GitAncestryChecker struct {
+	git        git.Interface
+	readParams git.ReadParams
+}
+
+var _ AncestryChecker = GitAncestryChecker{}
+
+func NewGitAncestryChecker(git git.Interface, readParams git.ReadParams) GitAncestryChecker {
+	return GitAncestryChecker{
+		git:        git,
+		readParams: readParams,
+	}
+}
+
+func (c GitAncestryChecker) IsAncestor(ctx context.Context, ancestor, descendant string) (bool, error) {
+	out, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          c.readParams,
+		AncestorCommitSHA:   ancestor,
+		DescendantCommitSHA: descendant,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check ancestry: %w", err)
+	}
+
+	return out.Ancestor, nil
+}
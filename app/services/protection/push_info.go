@@ -0,0 +1,248 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/signature"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// GitPushInfo provides the content of a git push. The commits are read from the repository
+// (optionally from the alternate object directories of the push) and are cached once loaded.
+type GitPushInfo struct {
+	git              git.Interface
+	principalStore   store.PrincipalStore
+	signatureService *signature.Service
+	repo             *types.Repository
+	readParams       git.ReadParams
+	revisions        []string
+
+	gitCommits []git.Commit
+	commits    []PushCommit
+	paths      []string
+}
+
+var _ PushInfo = (*GitPushInfo)(nil)
+
+func NewGitPushInfo(
+	git git.Interface,
+	principalStore store.PrincipalStore,
+	signatureService *signature.Service,
+	repo *types.Repository,
+	readParams git.ReadParams,
+	revisions ...string,
+) *GitPushInfo {
+	return &GitPushInfo{
+		git:              git,
+		principalStore:   principalStore,
+		signatureService: signatureService,
+		repo:             repo,
+		readParams:       readParams,
+		revisions:        revisions,
+	}
+}
+
+func (p *GitPushInfo) listNewCommits(ctx context.Context) ([]git.Commit, error) {
+	if p.gitCommits != nil {
+		return p.gitCommits, nil
+	}
+
+	out, err := p.git.ListNewCommits(ctx, &git.ListNewCommitsParams{
+		ReadParams: p.readParams,
+		Revisions:  p.revisions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new commits: %w", err)
+	}
+
+	p.gitCommits = out.Commits
+	if p.gitCommits == nil {
+		p.gitCommits = []git.Commit{}
+	}
+
+	return p.gitCommits, nil
+}
+
+func (p *GitPushInfo) Commits(ctx context.Context) ([]PushCommit, error) {
+	if p.commits != nil {
+		return p.commits, nil
+	}
+
+	gitCommits, err := p.listNewCommits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.commits = make([]PushCommit, len(gitCommits))
+	for i, commit := range gitCommits {
+		p.commits[i] = PushCommit{
+			SHA:            commit.SHA,
+			Message:        commit.Message,
+			AuthorEmail:    commit.Author.Identity.Email,
+			CommitterEmail: commit.Committer.Identity.Email,
+			ParentSHAs:     commit.ParentSHAs,
+		}
+	}
+
+	return p.commits, nil
+}
+
+func (p *GitPushInfo) ChangedPaths(ctx context.Context) ([]string, error) {
+	if p.paths != nil {
+		return p.paths, nil
+	}
+
+	out, err := p.git.ListChangedPaths(ctx, &git.ListChangedPathsParams{
+		ReadParams: p.readParams,
+		Revisions:  p.revisions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed paths: %w", err)
+	}
+
+	p.paths = out.Paths
+	if p.paths == nil {
+		p.paths = []string{}
+	}
+
+	return p.paths, nil
+}
+
+func (p *GitPushInfo) OversizeFiles(ctx context.Context, sizeLimit int64) ([]PushFile, error) {
+	out, err := p.git.FindOversizeFiles(ctx, &git.FindOversizeFilesParams{
+		ReadParams: p.readParams,
+		Revisions:  p.revisions,
+		SizeLimit:  sizeLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oversize files: %w", err)
+	}
+
+	files := make([]PushFile, len(out.Files))
+	for i, f := range out.Files {
+		files[i] = PushFile{
+			Path: f.Path,
+			Size: f.Size,
+		}
+	}
+
+	return files, nil
+}
+
+func (p *GitPushInfo) IsUserEmail(ctx context.Context, email string) (bool, error) {
+	return isUserEmail(ctx, p.principalStore, email)
+}
+
+func (p *GitPushInfo) VerifyCommits(ctx context.Context) ([]CommitVerification, error) {
+	gitCommits, err := p.listNewCommits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifyCommits(ctx, p.signatureService, p.repo, gitCommits)
+}
+
+// CommitPushInfo provides the content of a single commit that is yet to be created, e.g. through the API.
+// The commit is considered verified if it's going to be signed by the server.
+type CommitPushInfo struct {
+	principalStore store.PrincipalStore
+	commit         PushCommit
+	files          []PushFile
+	signed         bool
+}
+
+var _ PushInfo = (*CommitPushInfo)(nil)
+
+func NewCommitPushInfo(
+	principalStore store.PrincipalStore,
+	commit PushCommit,
+	files []PushFile,
+	signed bool,
+) *CommitPushInfo {
+	return &CommitPushInfo{
+		principalStore: principalStore,
+		commit:         commit,
+		files:          files,
+		signed:         signed,
+	}
+}
+
+func (p *CommitPushInfo) Commits(context.Context) ([]PushCommit, error) {
+	return []PushCommit{p.commit}, nil
+}
+
+func (p *CommitPushInfo) ChangedPaths(context.Context) ([]string, error) {
+	paths := make([]string, len(p.files))
+	for i, f := range p.files {
+		paths[i] = f.Path
+	}
+
+	return paths, nil
+}
+
+func (p *CommitPushInfo) OversizeFiles(_ context.Context, sizeLimit int64) ([]PushFile, error) {
+	var files []PushFile
+	for _, f := range p.files {
+		if f.Size > sizeLimit {
+			files = append(files, f)
+		}
+	}
+
+	return files, nil
+}
+
+func (p *CommitPushInfo) IsUserEmail(ctx context.Context, email string) (bool, error) {
+	return isUserEmail(ctx, p.principalStore, email)
+}
+
+// VerifyCommits reports the commit as verified if it's going to be signed by the server, otherwise as unsigned.
+func (p *CommitPushInfo) VerifyCommits(context.Context) ([]CommitVerification, error) {
+	if p.signed {
+		return []CommitVerification{{
+			SHA: p.commit.SHA,
+			Verification: &types.SignatureVerification{
+				Verified: true,
+				Reason:   enum.SignatureVerificationReasonValid,
+			},
+		}}, nil
+	}
+
+	return []CommitVerification{{SHA: p.commit.SHA}}, nil
+}
+
+func isUserEmail(ctx context.Context, principalStore store.PrincipalStore, email string) (bool, error) {
+	if strings.TrimSpace(email) == "" {
+		return false, nil
+	}
+
+	_, err := principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	return true, nil
+}
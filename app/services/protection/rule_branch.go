@@ -17,22 +17,29 @@ package protection
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
 )
 
 const TypeBranch types.RuleType = "branch"
 
 // Branch implements protection rules for the rule type TypeBranch.
 type Branch struct {
-	Bypass    DefBypass    `json:"bypass"`
-	PullReq   DefPullReq   `json:"pullreq"`
-	Lifecycle DefLifecycle `json:"lifecycle"`
+	Bypass     DefBypass     `json:"bypass"`
+	PullReq    DefPullReq    `json:"pullreq"`
+	Lifecycle  DefLifecycle  `json:"lifecycle"`
+	Signatures DefSignatures `json:"signatures"`
+	History    DefHistory    `json:"history"`
+	Freeze     DefFreeze     `json:"freeze"`
 }
 
 var (
-	// ensures that the Branch type implements Definition interface.
+	// ensures that the Branch type implements Definition and Freezer interfaces.
 	_ Definition = (*Branch)(nil)
+	_ Freezer    = (*Branch)(nil)
 )
 
 func (v *Branch) MergeVerify(
@@ -44,6 +51,32 @@ func (v *Branch) MergeVerify(
 		return
 	}
 
+	signatureViolations, err := v.Signatures.Verify(ctx, in.Commits)
+	if err != nil {
+		return
+	}
+
+	violations = append(violations, signatureViolations...)
+
+	historyOut, historyViolations, err := v.History.MergeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	violations = append(violations, historyViolations...)
+
+	_, freezeViolations, err := v.Freeze.MergeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	violations = append(violations, freezeViolations...)
+
+	if in.Method == "" {
+		// the intersection modifies the first slice, so it's cloned to keep the original slices intact.
+		out.AllowedMethods = intersectSorted(slices.Clone(out.AllowedMethods), historyOut.AllowedMethods)
+	}
+
 	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
 	bypassed := in.AllowBypass && bypassable
 	for i := range violations {
@@ -63,6 +96,16 @@ func (v *Branch) RefChangeVerify(
 	}
 
 	violations, err = v.Lifecycle.RefChangeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	freezeViolations, err := v.Freeze.RefChangeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	violations = append(violations, freezeViolations...)
 
 	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
 	bypassed := in.AllowBypass && bypassable
@@ -74,6 +117,34 @@ func (v *Branch) RefChangeVerify(
 	return
 }
 
+// PushVerify verifies only signatures and history of the new commits, the rest of the content is verified by the push rules.
+func (v *Branch) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	violations, err := v.Signatures.Verify(ctx, in.Push)
+	if err != nil {
+		return nil, err
+	}
+
+	historyViolations, err := v.History.Verify(ctx, in.Push)
+	if err != nil {
+		return nil, err
+	}
+
+	violations = append(violations, historyViolations...)
+
+	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return violations, nil
+}
+
+func (v *Branch) ActiveFreeze(now time.Time) *types.RuleFreeze {
+	return v.Freeze.active(now)
+}
+
 func (v *Branch) UserIDs() ([]int64, error) {
 	return v.Bypass.UserIDs, nil
 }
@@ -91,5 +162,17 @@ func (v *Branch) Sanitize() error {
 		return fmt.Errorf("lifecycle: %w", err)
 	}
 
+	if err := v.Signatures.Sanitize(); err != nil {
+		return fmt.Errorf("signatures: %w", err)
+	}
+
+	if err := v.History.Sanitize(); err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+
+	if err := v.Freeze.Sanitize(); err != nil {
+		return fmt.Errorf("freeze: %w", err)
+	}
+
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -63,6 +64,7 @@ func TestBranch_MergeVerify(t *testing.T) {
 			expOut: MergeVerifyOutput{
 				DeleteSourceBranch: true,
 				AllowedMethods:     enum.MergeMethods,
+				RequiredChecks:     []string{"abc"},
 			},
 			expVs: []types.RuleViolations{
 				{
@@ -93,6 +95,7 @@ func TestBranch_MergeVerify(t *testing.T) {
 			expOut: MergeVerifyOutput{
 				DeleteSourceBranch: true,
 				AllowedMethods:     enum.MergeMethods,
+				RequiredChecks:     []string{"abc"},
 			},
 			expVs: []types.RuleViolations{
 				{
@@ -122,6 +125,7 @@ func TestBranch_MergeVerify(t *testing.T) {
 			expOut: MergeVerifyOutput{
 				DeleteSourceBranch: true,
 				AllowedMethods:     enum.MergeMethods,
+				RequiredChecks:     []string{"abc"},
 			},
 			expVs: []types.RuleViolations{
 				{
@@ -277,6 +281,32 @@ func TestBranch_RefChangeVerify(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "freeze-user-bypass",
+			branch: Branch{
+				Bypass: DefBypass{UserIDs: []int64{user.ID}},
+				Freeze: DefFreeze{Windows: []FreezeWindow{{
+					Start: time.Now().Add(-time.Hour).UnixMilli(),
+					End:   time.Now().Add(time.Hour).UnixMilli(),
+				}}},
+			},
+			in: RefChangeVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				RefAction:   RefActionUpdate,
+				RefType:     RefTypeBranch,
+				RefNames:    []string{"abc"},
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{
+						{Code: codeFreezeActive},
+					},
+				},
+			},
+		},
 		{
 			name: "user-no-bypass",
 			branch: Branch{
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const TypePush types.RuleType = "push"
+
+// Push implements protection rules for the rule type TypePush.
+type Push struct {
+	Bypass DefBypass     `json:"bypass"`
+	Push   DefPushPolicy `json:"push"`
+}
+
+var (
+	// ensures that the Push type implements Definition interface.
+	_ Definition = (*Push)(nil)
+)
+
+// MergeVerify doesn't restrict anything, push rules don't apply to pull requests.
+func (v *Push) MergeVerify(
+	context.Context,
+	MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	return MergeVerifyOutput{
+		AllowedMethods: slices.Clone(enum.MergeMethods),
+	}, nil, nil
+}
+
+// RefChangeVerify doesn't restrict anything, the content of commits is verified with PushVerify.
+func (v *Push) RefChangeVerify(context.Context, RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	return []types.RuleViolations{}, nil
+}
+
+func (v *Push) PushVerify(
+	ctx context.Context,
+	in PushVerifyInput,
+) (violations []types.RuleViolations, err error) {
+	violations, err = v.Push.PushVerify(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return
+}
+
+func (v *Push) UserIDs() ([]int64, error) {
+	return v.Bypass.UserIDs, nil
+}
+
+func (v *Push) Sanitize() error {
+	if err := v.Bypass.Sanitize(); err != nil {
+		return fmt.Errorf("bypass: %w", err)
+	}
+
+	if err := v.Push.Sanitize(); err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
+	return nil
+}
@@ -20,9 +20,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type (
@@ -34,6 +38,7 @@ type (
 	Protection interface {
 		MergeVerifier
 		RefChangeVerifier
+		PushVerifier
 
 		UserIDs() ([]int64, error)
 	}
@@ -48,8 +53,9 @@ type (
 
 	// Manager is used to enforce protection rules.
 	Manager struct {
-		defGenMap map[types.RuleType]DefinitionGenerator
-		ruleStore store.RuleStore
+		defGenMap      map[types.RuleType]DefinitionGenerator
+		ruleStore      store.RuleStore
+		violationStore store.RuleMonitorViolationStore
 	}
 )
 
@@ -69,11 +75,27 @@ func IsCritical(violations []types.RuleViolations) bool {
 	return false
 }
 
+// RequiresMergeQueue returns true if the violations require the pull request to be merged through the merge queue.
+func RequiresMergeQueue(violations []types.RuleViolations) bool {
+	for i := range violations {
+		if !violations[i].IsCritical() {
+			continue
+		}
+		for _, violation := range violations[i].Violations {
+			if violation.Code == codePullReqMergeUseMergeQueue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // NewManager creates new protection Manager.
-func NewManager(ruleStore store.RuleStore) *Manager {
+func NewManager(ruleStore store.RuleStore, violationStore store.RuleMonitorViolationStore) *Manager {
 	return &Manager{
-		defGenMap: make(map[types.RuleType]DefinitionGenerator),
-		ruleStore: ruleStore,
+		defGenMap:      make(map[types.RuleType]DefinitionGenerator),
+		ruleStore:      ruleStore,
+		violationStore: violationStore,
 	}
 }
 
@@ -134,3 +156,46 @@ func (m *Manager) ForRepository(ctx context.Context, repoID int64) (Protection,
 		manager: m,
 	}, nil
 }
+
+// ForRules returns Protection that evaluates only the provided rules.
+// It is used to find out what the rules would do before they are enabled.
+func (m *Manager) ForRules(rules []types.RuleInfoInternal) Protection {
+	return ruleSet{
+		rules:   rules,
+		manager: m,
+	}
+}
+
+// RecordMonitorViolations stores violations of the rules in the monitor state.
+// It should be called once an operation has been performed despite the violations,
+// so that the effect of a rule can be evaluated before the rule is activated.
+// Failures are only logged because they must not affect the operation.
+func (m *Manager) RecordMonitorViolations(
+	ctx context.Context,
+	repoID int64,
+	principalID int64,
+	action enum.RuleViolationAction,
+	violations []types.RuleViolations,
+) {
+	now := time.Now().UnixMilli()
+
+	for i := range violations {
+		if violations[i].Rule.State != enum.RuleStateMonitor || len(violations[i].Violations) == 0 {
+			continue
+		}
+
+		err := m.violationStore.Create(ctx, &types.RuleMonitorViolation{
+			RuleID:     violations[i].Rule.ID,
+			RepoID:     repoID,
+			Action:     action,
+			Violations: violations[i].Violations,
+			CreatedBy:  principalID,
+			Created:    now,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("rule", violations[i].Rule.Identifier).
+				Msg("failed to record violations of protection rule in monitor state")
+		}
+	}
+}
@@ -15,9 +15,12 @@
 package protection
 
 import (
+	"context"
 	"encoding/json"
+	"reflect"
 	"testing"
 
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -76,6 +79,63 @@ func TestIsCritical(t *testing.T) {
 	}
 }
 
+func TestRequiresMergeQueue(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []types.RuleViolations
+		exp   bool
+	}{
+		{
+			name:  "empty",
+			input: []types.RuleViolations{},
+			exp:   false,
+		},
+		{
+			name: "other-violations",
+			input: []types.RuleViolations{
+				{
+					Rule:       types.RuleInfo{State: enum.RuleStateActive},
+					Violations: []types.Violation{{Code: codePullReqApprovalReqMinCount}},
+				},
+			},
+			exp: false,
+		},
+		{
+			name: "non-critical",
+			input: []types.RuleViolations{
+				{
+					Rule:       types.RuleInfo{State: enum.RuleStateMonitor},
+					Violations: []types.Violation{{Code: codePullReqMergeUseMergeQueue}},
+				},
+				{
+					Rule:       types.RuleInfo{State: enum.RuleStateActive},
+					Bypassed:   true,
+					Violations: []types.Violation{{Code: codePullReqMergeUseMergeQueue}},
+				},
+			},
+			exp: false,
+		},
+		{
+			name: "merge-queue",
+			input: []types.RuleViolations{
+				{
+					Rule:       types.RuleInfo{State: enum.RuleStateActive},
+					Violations: []types.Violation{{Code: "x"}, {Code: codePullReqMergeUseMergeQueue}},
+				},
+			},
+			exp: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if want, got := test.exp, RequiresMergeQueue(test.input); want != got {
+				t.Errorf("want=%t got=%t", want, got)
+			}
+		})
+	}
+}
+
 func TestManager_SanitizeJSON(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -105,7 +165,7 @@ func TestManager_SanitizeJSON(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			m := NewManager(nil)
+			m := NewManager(nil, nil)
 
 			err := func() error {
 				for _, ruleType := range test.ruleTypes {
@@ -131,3 +191,51 @@ func TestManager_SanitizeJSON(t *testing.T) {
 		})
 	}
 }
+
+type violationStoreMock struct {
+	store.RuleMonitorViolationStore
+	created []*types.RuleMonitorViolation
+}
+
+func (s *violationStoreMock) Create(_ context.Context, v *types.RuleMonitorViolation) error {
+	s.created = append(s.created, v)
+	return nil
+}
+
+func TestManager_RecordMonitorViolations(t *testing.T) {
+	violation := types.Violation{Code: "test", Message: "test"}
+
+	violations := []types.RuleViolations{
+		{
+			Rule:       types.RuleInfo{ID: 1, State: enum.RuleStateActive},
+			Bypassed:   true,
+			Violations: []types.Violation{violation},
+		},
+		{
+			Rule:       types.RuleInfo{ID: 2, State: enum.RuleStateMonitor},
+			Violations: []types.Violation{violation},
+		},
+		{
+			Rule:       types.RuleInfo{ID: 3, State: enum.RuleStateMonitor},
+			Violations: nil,
+		},
+	}
+
+	violationStore := &violationStoreMock{}
+	m := NewManager(nil, violationStore)
+
+	m.RecordMonitorViolations(context.Background(), 10, 20, enum.RuleViolationActionMerge, violations)
+
+	if len(violationStore.created) != 1 {
+		t.Fatalf("expected one recorded violation, got %d", len(violationStore.created))
+	}
+
+	got := violationStore.created[0]
+	if got.RuleID != 2 || got.RepoID != 10 || got.CreatedBy != 20 || got.Action != enum.RuleViolationActionMerge {
+		t.Errorf("unexpected recorded violation: %+v", got)
+	}
+
+	if !reflect.DeepEqual(got.Violations, []types.Violation{violation}) {
+		t.Errorf("recorded violations mismatch: want=%v got=%v", []types.Violation{violation}, got.Violations)
+	}
+}
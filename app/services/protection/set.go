@@ -66,6 +66,8 @@ func (s ruleSet) MergeVerify(
 		violations = append(violations, backFillRule(rVs, r.RuleInfo)...)
 		out.DeleteSourceBranch = out.DeleteSourceBranch || rOut.DeleteSourceBranch
 		out.AllowedMethods = intersectSorted(out.AllowedMethods, rOut.AllowedMethods)
+		out.UseMergeQueue = out.UseMergeQueue || rOut.UseMergeQueue
+		out.RequiredChecks = unionSorted(out.RequiredChecks, rOut.RequiredChecks)
 	}
 
 	return out, violations, nil
@@ -74,8 +76,14 @@ func (s ruleSet) MergeVerify(
 func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations []types.RuleViolations
 
+	// the "default" pattern option refers to the default branch, so it can only match branches.
+	var defaultBranch string
+	if in.RefType == RefTypeBranch {
+		defaultBranch = in.Repo.DefaultBranch
+	}
+
 	for _, r := range s.rules {
-		matched, err := matchedNames(r.Pattern, in.Repo.DefaultBranch, in.RefNames...)
+		matched, err := matchedNames(r.Pattern, defaultBranch, in.RefNames...)
 		if err != nil {
 			return nil, err
 		}
@@ -103,6 +111,43 @@ func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) (
 	return violations, nil
 }
 
+func (s ruleSet) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	var violations []types.RuleViolations
+
+	for _, r := range s.rules {
+		if in.BranchName == "" {
+			// The commits pushed to a tag or to any other reference aren't on any branch,
+			// so the push rules apply to them regardless of the branch pattern.
+			if r.Type != TypePush {
+				continue
+			}
+		} else {
+			matches, err := matchesName(r.Pattern, in.Repo.DefaultBranch, in.BranchName)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				continue
+			}
+		}
+
+		protection, err := s.manager.FromJSON(r.Type, r.Definition, false)
+		if err != nil {
+			return nil,
+				fmt.Errorf("failed to parse protection definition ID=%d Type=%s: %w", r.ID, r.Type, err)
+		}
+
+		rVs, err := protection.PushVerify(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, backFillRule(rVs, r.RuleInfo)...)
+	}
+
+	return violations, nil
+}
+
 func (s ruleSet) UserIDs() ([]int64, error) {
 	mapIDs := make(map[int64]struct{})
 	for _, rule := range s.rules {
@@ -159,3 +204,15 @@ func intersectSorted[T constraints.Ordered](sliceA, sliceB []T) []T {
 
 	return sliceA
 }
+
+// unionSorted returns sorted slice containing all elements of both slices without duplicates.
+func unionSorted[T constraints.Ordered](sliceA, sliceB []T) []T {
+	if len(sliceB) == 0 {
+		return sliceA
+	}
+
+	result := append(slices.Clone(sliceA), sliceB...)
+	slices.Sort(result)
+
+	return slices.Compact(result)
+}
@@ -173,7 +173,7 @@ func TestRuleSet_MergeVerify(t *testing.T) {
 
 	ctx := context.Background()
 
-	m := NewManager(nil)
+	m := NewManager(nil, nil)
 	_ = m.Register(TypeBranch, func() Definition {
 		return &Branch{}
 	})
@@ -278,3 +278,70 @@ func TestIntersectSorted(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleSet_PushVerify(t *testing.T) {
+	pushDef, _ := ToJSON(&Push{Push: DefPushPolicy{CommitMessagePatterns: []string{"^JIRA-[0-9]+"}}})
+	branchDef, _ := ToJSON(&Branch{History: DefHistory{RequireLinearHistory: true}})
+
+	rules := []types.RuleInfoInternal{
+		{
+			RuleInfo:   types.RuleInfo{ID: 1, Identifier: "push", Type: TypePush, State: enum.RuleStateActive},
+			Pattern:    (&Pattern{Include: []string{"main"}}).JSON(),
+			Definition: pushDef,
+		},
+		{
+			RuleInfo:   types.RuleInfo{ID: 2, Identifier: "branch", Type: TypeBranch, State: enum.RuleStateActive},
+			Pattern:    (&Pattern{Include: []string{"*"}}).JSON(),
+			Definition: branchDef,
+		},
+	}
+
+	push := testPushInfo{
+		commits: []PushCommit{{
+			SHA:        "1111111111111111111111111111111111111111",
+			Message:    "merge",
+			ParentSHAs: []string{"2222222222222222222222222222222222222222", "3333333333333333333333333333333333333333"},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		branchName string
+		expRuleIDs []int64
+	}{
+		{name: "matching-branch", branchName: "main", expRuleIDs: []int64{1, 2}},
+		{name: "other-branch", branchName: "feature", expRuleIDs: []int64{2}},
+		{name: "non-branch-ref", branchName: "", expRuleIDs: []int64{1}},
+	}
+
+	m := NewManager(nil, nil)
+	_ = m.Register(TypeBranch, func() Definition { return &Branch{} })
+	_ = m.Register(TypePush, func() Definition { return &Push{} })
+
+	set := ruleSet{rules: rules, manager: m}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := set.PushVerify(context.Background(), PushVerifyInput{
+				Actor:      &types.Principal{ID: 1},
+				Repo:       &types.Repository{ID: 1, DefaultBranch: "main"},
+				BranchName: test.branchName,
+				Push:       push,
+			})
+			if err != nil {
+				t.Fatalf("got error: %s", err.Error())
+			}
+
+			var ruleIDs []int64
+			for _, v := range violations {
+				if len(v.Violations) > 0 {
+					ruleIDs = append(ruleIDs, v.Rule.ID)
+				}
+			}
+
+			if !reflect.DeepEqual(ruleIDs, test.expRuleIDs) {
+				t.Errorf("violated rules: want=%v got=%v", test.expRuleIDs, ruleIDs)
+			}
+		})
+	}
+}
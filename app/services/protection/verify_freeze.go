@@ -0,0 +1,227 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gorhill/cronexpr"
+)
+
+type (
+	// Freezer is implemented by the protection definitions that can freeze changes of the protected branches.
+	Freezer interface {
+		// ActiveFreeze returns the change freeze window that is active at the provided time, or nil.
+		ActiveFreeze(now time.Time) *types.RuleFreeze
+	}
+
+	// DefFreeze blocks merges into and pushes to the branch during the change freeze windows.
+	DefFreeze struct {
+		Windows   []FreezeWindow          `json:"windows,omitempty"`
+		Recurring []FreezeRecurringWindow `json:"recurring,omitempty"`
+	}
+
+	// FreezeWindow is a one-off change freeze window. Start and End are unix milliseconds.
+	FreezeWindow struct {
+		Start  int64  `json:"start"`
+		End    int64  `json:"end"`
+		Reason string `json:"reason,omitempty"`
+	}
+
+	// FreezeRecurringWindow is a change freeze window that starts at the times of the cron expression
+	// (minute, hour, day of month, month, day of week) and lasts for the provided number of minutes.
+	FreezeRecurringWindow struct {
+		Cron            string `json:"cron"`
+		DurationMinutes int64  `json:"duration_minutes"`
+		// Timezone is the IANA name of the time zone of the cron expression. UTC is used if empty.
+		Timezone string `json:"timezone,omitempty"`
+		Reason   string `json:"reason,omitempty"`
+	}
+)
+
+// ensures that the DefFreeze type implements Sanitizer, MergeVerifier and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefFreeze)(nil)
+	_ MergeVerifier     = (*DefFreeze)(nil)
+	_ RefChangeVerifier = (*DefFreeze)(nil)
+)
+
+const (
+	codeFreezeActive = "freeze.active"
+
+	freezeReasonMaxLength    = 256
+	freezeMaxDurationMinutes = 31 * 24 * 60
+)
+
+// MergeVerify reports a violation if the target branch of the pull request is frozen.
+func (v *DefFreeze) MergeVerify(
+	_ context.Context,
+	in MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	var out MergeVerifyOutput
+	if in.Method == "" {
+		out.AllowedMethods = enum.MergeMethods
+	}
+
+	freeze := v.active(time.Now())
+	if freeze == nil || in.PullReq == nil {
+		return out, []types.RuleViolations{}, nil
+	}
+
+	var violations types.RuleViolations
+	violations.Addf(codeFreezeActive, "Merging into branch %q is not allowed during the change freeze%s.",
+		in.PullReq.TargetBranch, freezeDetails(freeze))
+
+	return out, []types.RuleViolations{violations}, nil
+}
+
+// RefChangeVerify reports a violation if the branch is frozen.
+func (v *DefFreeze) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	if in.RefType != RefTypeBranch || len(in.RefNames) == 0 {
+		return []types.RuleViolations{}, nil
+	}
+
+	freeze := v.active(time.Now())
+	if freeze == nil {
+		return []types.RuleViolations{}, nil
+	}
+
+	var violations types.RuleViolations
+	violations.Addf(codeFreezeActive, "Changing branch %q is not allowed during the change freeze%s.",
+		in.RefNames[0], freezeDetails(freeze))
+
+	return []types.RuleViolations{violations}, nil
+}
+
+func (v *DefFreeze) Sanitize() error {
+	if len(v.Windows)+len(v.Recurring) > maxElements {
+		return errors.New("too many freeze windows provided")
+	}
+
+	for i := range v.Windows {
+		w := &v.Windows[i]
+
+		if w.Start <= 0 || w.End <= w.Start {
+			return errors.New("freeze window must have a start time and an end time after the start time")
+		}
+
+		reason, err := sanitizeFreezeReason(w.Reason)
+		if err != nil {
+			return err
+		}
+		w.Reason = reason
+	}
+
+	for i := range v.Recurring {
+		w := &v.Recurring[i]
+
+		w.Cron = strings.TrimSpace(w.Cron)
+		if _, err := cronexpr.Parse(w.Cron); err != nil {
+			return fmt.Errorf("invalid cron expression of recurring freeze window %q: %w", w.Cron, err)
+		}
+
+		if w.DurationMinutes <= 0 || w.DurationMinutes > freezeMaxDurationMinutes {
+			return fmt.Errorf("duration of recurring freeze window must be between 1 and %d minutes",
+				freezeMaxDurationMinutes)
+		}
+
+		w.Timezone = strings.TrimSpace(w.Timezone)
+		if _, err := time.LoadLocation(w.Timezone); err != nil {
+			return fmt.Errorf("invalid time zone of recurring freeze window %q: %w", w.Timezone, err)
+		}
+
+		reason, err := sanitizeFreezeReason(w.Reason)
+		if err != nil {
+			return err
+		}
+		w.Reason = reason
+	}
+
+	return nil
+}
+
+// active returns the freeze window that is active at the provided time.
+// If several windows are active, the one that ends last is returned.
+func (v *DefFreeze) active(now time.Time) *types.RuleFreeze {
+	var result *types.RuleFreeze
+
+	consider := func(freeze types.RuleFreeze) {
+		if result == nil || freeze.End > result.End {
+			result = &freeze
+		}
+	}
+
+	nowMilli := now.UnixMilli()
+
+	for _, w := range v.Windows {
+		if w.Start <= nowMilli && nowMilli < w.End {
+			consider(types.RuleFreeze{Start: w.Start, End: w.End, Reason: w.Reason})
+		}
+	}
+
+	for _, w := range v.Recurring {
+		exp, err := cronexpr.Parse(w.Cron)
+		if err != nil {
+			continue // the definition is sanitized, so this shouldn't happen.
+		}
+
+		loc, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			continue
+		}
+
+		// the window is active if it started in the last duration: the next start after
+		// the time that's one duration ago must not be in the future.
+		duration := time.Duration(w.DurationMinutes) * time.Minute
+		start := exp.Next(now.Add(-duration).In(loc))
+		if start.IsZero() || start.After(now) {
+			continue
+		}
+
+		consider(types.RuleFreeze{
+			Start:  start.UnixMilli(),
+			End:    start.Add(duration).UnixMilli(),
+			Reason: w.Reason,
+		})
+	}
+
+	return result
+}
+
+func sanitizeFreezeReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if len(reason) > freezeReasonMaxLength {
+		return "", fmt.Errorf("reason of freeze window can be at most %d characters long", freezeReasonMaxLength)
+	}
+
+	return reason, nil
+}
+
+// freezeDetails describes when the freeze ends and why the branch is frozen.
+func freezeDetails(freeze *types.RuleFreeze) string {
+	details := " until " + time.UnixMilli(freeze.End).UTC().Format(time.RFC3339)
+	if freeze.Reason != "" {
+		details += " (" + freeze.Reason + ")"
+	}
+
+	return details
+}
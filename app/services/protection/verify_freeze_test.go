@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestDefFreeze_active(t *testing.T) {
+	// Saturday, 2024-03-30 10:00:00 UTC.
+	now := time.Date(2024, time.March, 30, 10, 0, 0, 0, time.UTC)
+	ms := func(tm time.Time) int64 { return tm.UnixMilli() }
+
+	tests := []struct {
+		name string
+		def  DefFreeze
+		exp  *types.RuleFreeze
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "one-off-active",
+			def: DefFreeze{Windows: []FreezeWindow{
+				{Start: ms(now.Add(-time.Hour)), End: ms(now.Add(time.Hour)), Reason: "release"},
+			}},
+			exp: &types.RuleFreeze{Start: ms(now.Add(-time.Hour)), End: ms(now.Add(time.Hour)), Reason: "release"},
+		},
+		{
+			name: "one-off-ended",
+			def: DefFreeze{Windows: []FreezeWindow{
+				{Start: ms(now.Add(-2 * time.Hour)), End: ms(now)},
+			}},
+		},
+		{
+			name: "one-off-latest-end",
+			def: DefFreeze{Windows: []FreezeWindow{
+				{Start: ms(now.Add(-time.Hour)), End: ms(now.Add(time.Hour))},
+				{Start: ms(now), End: ms(now.Add(2 * time.Hour))},
+			}},
+			exp: &types.RuleFreeze{Start: ms(now), End: ms(now.Add(2 * time.Hour))},
+		},
+		{
+			name: "recurring-weekend-active",
+			def: DefFreeze{Recurring: []FreezeRecurringWindow{
+				// from Friday 18:00 for 62 hours, until Monday 08:00.
+				{Cron: "0 18 * * 5", DurationMinutes: 62 * 60, Reason: "weekend"},
+			}},
+			exp: &types.RuleFreeze{
+				Start:  ms(time.Date(2024, time.March, 29, 18, 0, 0, 0, time.UTC)),
+				End:    ms(time.Date(2024, time.April, 1, 8, 0, 0, 0, time.UTC)),
+				Reason: "weekend",
+			},
+		},
+		{
+			name: "recurring-inactive",
+			def: DefFreeze{Recurring: []FreezeRecurringWindow{
+				{Cron: "0 18 * * 5", DurationMinutes: 60},
+			}},
+		},
+		{
+			name: "recurring-timezone",
+			def: DefFreeze{Recurring: []FreezeRecurringWindow{
+				// 10:00 UTC is 19:00 in Tokyo.
+				{Cron: "30 18 * * *", DurationMinutes: 60, Timezone: "Asia/Tokyo"},
+			}},
+			exp: &types.RuleFreeze{
+				Start: ms(now.Add(-30 * time.Minute)),
+				End:   ms(now.Add(30 * time.Minute)),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.def.Sanitize(); err != nil {
+				t.Fatalf("invalid: %s", err.Error())
+			}
+
+			if want, got := test.exp, test.def.active(now); !reflect.DeepEqual(want, got) {
+				t.Errorf("want=%+v got=%+v", want, got)
+			}
+		})
+	}
+}
+
+func TestDefFreeze_Sanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    DefFreeze
+		expErr bool
+	}{
+		{
+			name: "valid",
+			def: DefFreeze{
+				Windows:   []FreezeWindow{{Start: 1, End: 2}},
+				Recurring: []FreezeRecurringWindow{{Cron: "0 0 * * 6", DurationMinutes: 60, Timezone: "UTC"}},
+			},
+		},
+		{
+			name:   "window-end-before-start",
+			def:    DefFreeze{Windows: []FreezeWindow{{Start: 2, End: 1}}},
+			expErr: true,
+		},
+		{
+			name:   "invalid-cron",
+			def:    DefFreeze{Recurring: []FreezeRecurringWindow{{Cron: "every friday", DurationMinutes: 60}}},
+			expErr: true,
+		},
+		{
+			name:   "missing-duration",
+			def:    DefFreeze{Recurring: []FreezeRecurringWindow{{Cron: "0 0 * * 6"}}},
+			expErr: true,
+		},
+		{
+			name: "invalid-timezone",
+			def: DefFreeze{Recurring: []FreezeRecurringWindow{
+				{Cron: "0 0 * * 6", DurationMinutes: 60, Timezone: "Mars/Olympus"},
+			}},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.def.Sanitize(); (err != nil) != test.expErr {
+				t.Errorf("want error=%t got=%v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestDefFreeze_MergeVerify(t *testing.T) {
+	def := DefFreeze{Windows: []FreezeWindow{{
+		Start: time.Now().Add(-time.Hour).UnixMilli(),
+		End:   time.Now().Add(time.Hour).UnixMilli(),
+	}}}
+
+	_, violations, err := def.MergeVerify(context.Background(), MergeVerifyInput{
+		PullReq: &types.PullReq{TargetBranch: "main"},
+	})
+	if err != nil {
+		t.Fatalf("error: %s", err.Error())
+	}
+
+	if len(violations) != 1 || len(violations[0].Violations) != 1 ||
+		violations[0].Violations[0].Code != codeFreezeActive {
+		t.Errorf("expected a single freeze violation, got %+v", violations)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type (
+	// CommitProvider provides the new commits of a push or the commits of a pull request.
+	CommitProvider interface {
+		Commits(ctx context.Context) ([]PushCommit, error)
+		CommitVerifier
+	}
+
+	DefHistory struct {
+		// RequireLinearHistory forbids merge commits on the branch.
+		RequireLinearHistory bool `json:"require_linear_history,omitempty"`
+	}
+)
+
+// ensures that the DefHistory type implements Sanitizer and MergeVerifier interface.
+var (
+	_ Sanitizer     = (*DefHistory)(nil)
+	_ MergeVerifier = (*DefHistory)(nil)
+)
+
+const (
+	codeHistoryRequireLinearHistory       = "history.require_linear_history"
+	codeHistoryRequireLinearHistoryMethod = "history.require_linear_history:merge_method"
+)
+
+// linearMergeMethods are the merge methods that don't create merge commits. The list is sorted.
+var linearMergeMethods = []enum.MergeMethod{
+	enum.MergeMethodFastForward,
+	enum.MergeMethodRebase,
+	enum.MergeMethodSquash,
+}
+
+// Verify reports the merge commits among the provided commits.
+func (v *DefHistory) Verify(ctx context.Context, commits CommitProvider) ([]types.RuleViolations, error) {
+	if !v.RequireLinearHistory || commits == nil {
+		return []types.RuleViolations{}, nil
+	}
+
+	mergeCommits, err := findMergeCommits(ctx, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations types.RuleViolations
+
+	for _, commit := range mergeCommits {
+		violations.Addf(codeHistoryRequireLinearHistory,
+			"Linear history is required, but %s is a merge commit.", commitName(commit.SHA))
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return []types.RuleViolations{}, nil
+}
+
+// MergeVerify allows only the merge methods that keep the history of the target branch linear.
+// The fast-forward method is allowed only if the pull request doesn't contain merge commits.
+func (v *DefHistory) MergeVerify(
+	ctx context.Context,
+	in MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	var out MergeVerifyOutput
+
+	if !v.RequireLinearHistory {
+		if in.Method == "" {
+			out.AllowedMethods = enum.MergeMethods
+		}
+		return out, []types.RuleViolations{}, nil
+	}
+
+	if in.Method == "" {
+		out.AllowedMethods = linearMergeMethods
+	}
+
+	var violations types.RuleViolations
+
+	switch in.Method {
+	case enum.MergeMethodMerge, enum.MergeMethodRebaseMerge:
+		violations.Addf(codeHistoryRequireLinearHistoryMethod,
+			"Linear history is required, the merge strategy %q is not allowed. Allowed strategies are %v.",
+			in.Method, linearMergeMethods)
+	case "", enum.MergeMethodFastForward:
+		if in.Commits == nil {
+			break
+		}
+
+		mergeCommits, err := findMergeCommits(ctx, in.Commits)
+		if err != nil {
+			return out, nil, err
+		}
+
+		if len(mergeCommits) == 0 {
+			break
+		}
+
+		if in.Method == "" {
+			out.AllowedMethods = []enum.MergeMethod{enum.MergeMethodRebase, enum.MergeMethodSquash}
+			break
+		}
+
+		for _, commit := range mergeCommits {
+			violations.Addf(codeHistoryRequireLinearHistory,
+				"Linear history is required, but %s is a merge commit.", commitName(commit.SHA))
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return out, []types.RuleViolations{violations}, nil
+	}
+
+	return out, []types.RuleViolations{}, nil
+}
+
+func (*DefHistory) Sanitize() error {
+	return nil
+}
+
+func findMergeCommits(ctx context.Context, commits CommitProvider) ([]PushCommit, error) {
+	list, err := commits.Commits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	var mergeCommits []PushCommit
+	for _, commit := range list {
+		if len(commit.ParentSHAs) > 1 {
+			mergeCommits = append(mergeCommits, commit)
+		}
+	}
+
+	return mergeCommits, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDefHistory_Verify(t *testing.T) {
+	push := testPushInfo{
+		commits: []PushCommit{
+			{
+				SHA:        "1111111111111111111111111111111111111111",
+				ParentSHAs: []string{"0000000000000000000000000000000000000000"},
+			},
+			{
+				SHA: "2222222222222222222222222222222222222222",
+				ParentSHAs: []string{
+					"1111111111111111111111111111111111111111",
+					"3333333333333333333333333333333333333333",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		def       DefHistory
+		commits   CommitProvider
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name:    "empty",
+			commits: push,
+		},
+		{
+			name: "no-commits",
+			def:  DefHistory{RequireLinearHistory: true},
+		},
+		{
+			name:      "merge-commit-fail",
+			def:       DefHistory{RequireLinearHistory: true},
+			commits:   push,
+			expCodes:  []string{codeHistoryRequireLinearHistory},
+			expParams: [][]any{{"commit 22222222"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := test.def.Verify(context.Background(), test.commits)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+func TestDefHistory_MergeVerify(t *testing.T) {
+	linear := testPushInfo{
+		commits: []PushCommit{{SHA: "1111111111111111111111111111111111111111", ParentSHAs: []string{"0"}}},
+	}
+	nonLinear := testPushInfo{
+		commits: []PushCommit{{SHA: "2222222222222222222222222222222222222222", ParentSHAs: []string{"0", "1"}}},
+	}
+
+	tests := []struct {
+		name       string
+		def        DefHistory
+		method     enum.MergeMethod
+		commits    CommitProvider
+		expMethods []enum.MergeMethod
+		expCodes   []string
+		expParams  [][]any
+	}{
+		{
+			name:       "empty",
+			commits:    nonLinear,
+			expMethods: enum.MergeMethods,
+		},
+		{
+			name:       "linear-methods",
+			def:        DefHistory{RequireLinearHistory: true},
+			commits:    linear,
+			expMethods: linearMergeMethods,
+		},
+		{
+			name:    "linear-methods-merge-commits",
+			def:     DefHistory{RequireLinearHistory: true},
+			commits: nonLinear,
+			expMethods: []enum.MergeMethod{
+				enum.MergeMethodRebase,
+				enum.MergeMethodSquash,
+			},
+		},
+		{
+			name:      "merge-method-fail",
+			def:       DefHistory{RequireLinearHistory: true},
+			method:    enum.MergeMethodRebaseMerge,
+			commits:   linear,
+			expCodes:  []string{codeHistoryRequireLinearHistoryMethod},
+			expParams: [][]any{{enum.MergeMethodRebaseMerge, linearMergeMethods}},
+		},
+		{
+			name:    "fast-forward-success",
+			def:     DefHistory{RequireLinearHistory: true},
+			method:  enum.MergeMethodFastForward,
+			commits: linear,
+		},
+		{
+			name:      "fast-forward-fail",
+			def:       DefHistory{RequireLinearHistory: true},
+			method:    enum.MergeMethodFastForward,
+			commits:   nonLinear,
+			expCodes:  []string{codeHistoryRequireLinearHistory},
+			expParams: [][]any{{"commit 22222222"}},
+		},
+		{
+			name:    "squash-success",
+			def:     DefHistory{RequireLinearHistory: true},
+			method:  enum.MergeMethodSquash,
+			commits: nonLinear,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, violations, err := test.def.MergeVerify(context.Background(), MergeVerifyInput{
+				Method:  test.method,
+				Commits: test.commits,
+			})
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if want, got := test.expMethods, out.AllowedMethods; !reflect.DeepEqual(want, got) {
+				t.Errorf("allowed methods mismatch: want=%v got=%v", want, got)
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
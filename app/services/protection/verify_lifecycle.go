@@ -73,21 +73,27 @@ const (
 func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations types.RuleViolations
 
+	msgCreate := "Creation of branch %q is not allowed."
+	msgDelete := "Delete of branch %q is not allowed."
+	msgUpdate := "Push to branch %q is not allowed. Please use pull requests."
+	if in.RefType == RefTypeTag {
+		msgCreate = "Creation of tag %q is not allowed."
+		msgDelete = "Delete of tag %q is not allowed."
+		msgUpdate = "Update of tag %q is not allowed."
+	}
+
 	switch in.RefAction {
 	case RefActionCreate:
 		if v.CreateForbidden {
-			violations.Addf(codeLifecycleCreate,
-				"Creation of branch %q is not allowed.", in.RefNames[0])
+			violations.Addf(codeLifecycleCreate, msgCreate, in.RefNames[0])
 		}
 	case RefActionDelete:
 		if v.DeleteForbidden {
-			violations.Addf(codeLifecycleDelete,
-				"Delete of branch %q is not allowed.", in.RefNames[0])
+			violations.Addf(codeLifecycleDelete, msgDelete, in.RefNames[0])
 		}
 	case RefActionUpdate:
 		if v.UpdateForbidden {
-			violations.Addf(codeLifecycleUpdate,
-				"Push to branch %q is not allowed. Please use pull requests.", in.RefNames[0])
+			violations.Addf(codeLifecycleUpdate, msgUpdate, in.RefNames[0])
 		}
 	}
 
@@ -44,11 +44,33 @@ type (
 		Method       enum.MergeMethod
 		CheckResults []types.CheckResult
 		CodeOwners   *codeowners.Evaluation
+
+		// MergeQueue is set if the pull request is verified for, or merged by, the merge queue.
+		// Required status checks are then run against the speculative merge commit, so they are not verified.
+		MergeQueue bool
+
+		// Commits provides the pull request commits and verifies their signatures.
+		// If nil, the commits are not verified.
+		Commits CommitProvider
+
+		// Ancestry is used to verify that the source branch is up to date with the target branch.
+		// If nil, it's not verified.
+		Ancestry AncestryChecker
+	}
+
+	// AncestryChecker checks if a git revision is an ancestor of another git revision.
+	AncestryChecker interface {
+		IsAncestor(ctx context.Context, ancestor, descendant string) (bool, error)
 	}
 
 	MergeVerifyOutput struct {
 		DeleteSourceBranch bool
 		AllowedMethods     []enum.MergeMethod
+
+		// UseMergeQueue is set if the pull request must be merged through the merge queue.
+		UseMergeQueue bool
+		// RequiredChecks contains identifiers of status checks that are required to succeed.
+		RequiredChecks []string
 	}
 )
 
@@ -69,11 +91,13 @@ const (
 	codePullReqStatusChecksReqIdentifiers            = "pullreq.status_checks.required_identifiers"
 	codePullReqMergeStrategiesAllowed                = "pullreq.merge.strategies_allowed"
 	codePullReqMergeDeleteBranch                     = "pullreq.merge.delete_branch"
+	codePullReqMergeUseMergeQueue                    = "pullreq.merge.use_merge_queue"
+	codePullReqMergeRequireUpToDate                  = "pullreq.merge.require_up_to_date"
 )
 
-//nolint:gocognit // well aware of this
+//nolint:gocognit,gocyclo,cyclop // well aware of this
 func (v *DefPullReq) MergeVerify(
-	_ context.Context,
+	ctx context.Context,
 	in MergeVerifyInput,
 ) (MergeVerifyOutput, []types.RuleViolations, error) {
 	var out MergeVerifyOutput
@@ -147,6 +171,9 @@ func (v *DefPullReq) MergeVerify(
 
 	// pullreq.status_checks
 
+	out.RequiredChecks = v.StatusChecks.RequireIdentifiers
+
+	// status checks of pull requests in the merge queue are verified against the speculative merge commit.
 	var violatingStatusCheckIdentifiers []string
 	for _, requiredIdentifier := range v.StatusChecks.RequireIdentifiers {
 		var succeeded bool
@@ -157,7 +184,7 @@ func (v *DefPullReq) MergeVerify(
 			}
 		}
 
-		if !succeeded {
+		if !succeeded && !in.MergeQueue {
 			violatingStatusCheckIdentifiers = append(violatingStatusCheckIdentifiers, requiredIdentifier)
 		}
 	}
@@ -172,6 +199,26 @@ func (v *DefPullReq) MergeVerify(
 
 	// pullreq.merge
 
+	out.UseMergeQueue = v.Merge.UseMergeQueue
+	if v.Merge.UseMergeQueue && !in.MergeQueue {
+		violations.Addf(codePullReqMergeUseMergeQueue,
+			"Pull requests targeting this branch must be merged through the merge queue.")
+	}
+
+	// the merge queue always merges on top of the latest target branch commit.
+	if v.Merge.RequireUpToDate && !in.MergeQueue && in.Ancestry != nil {
+		upToDate, err := in.Ancestry.IsAncestor(ctx, "refs/heads/"+in.PullReq.TargetBranch, in.PullReq.SourceSHA)
+		if err != nil {
+			return out, nil, fmt.Errorf("failed to check if source branch is up to date: %w", err)
+		}
+
+		if !upToDate {
+			violations.Addf(codePullReqMergeRequireUpToDate,
+				"The source branch must be up to date with the target branch %q before merging.",
+				in.PullReq.TargetBranch)
+		}
+	}
+
 	if in.Method == "" {
 		out.AllowedMethods = enum.MergeMethods
 	}
@@ -274,6 +321,8 @@ func (c *DefStatusChecks) Sanitize() error {
 type DefMerge struct {
 	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
 	DeleteBranch      bool               `json:"delete_branch,omitempty"`
+	UseMergeQueue     bool               `json:"use_merge_queue,omitempty"`
+	RequireUpToDate   bool               `json:"require_up_to_date,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
@@ -260,7 +260,7 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			},
 			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
 			expParams: [][]any{{"check1"}},
-			expOut:    MergeVerifyOutput{},
+			expOut:    MergeVerifyOutput{RequiredChecks: []string{"check1"}},
 		},
 		{
 			name: codePullReqStatusChecksReqIdentifiers + "-missing",
@@ -273,7 +273,7 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			},
 			expCodes:  []string{codePullReqStatusChecksReqIdentifiers},
 			expParams: [][]any{{"check1"}},
-			expOut:    MergeVerifyOutput{},
+			expOut:    MergeVerifyOutput{RequiredChecks: []string{"check1"}},
 		},
 		{
 			name: codePullReqStatusChecksReqIdentifiers + "-success",
@@ -285,7 +285,38 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				},
 				Method: enum.MergeMethodMerge,
 			},
-			expOut: MergeVerifyOutput{},
+			expOut: MergeVerifyOutput{RequiredChecks: []string{"check1"}},
+		},
+		{
+			name: codePullReqStatusChecksReqIdentifiers + "-merge-queue",
+			def:  DefPullReq{StatusChecks: DefStatusChecks{RequireIdentifiers: []string{"check1"}}},
+			in: MergeVerifyInput{
+				CheckResults: []types.CheckResult{
+					{Identifier: "check1", Status: enum.CheckStatusFailure},
+				},
+				Method:     enum.MergeMethodMerge,
+				MergeQueue: true,
+			},
+			expOut: MergeVerifyOutput{RequiredChecks: []string{"check1"}},
+		},
+		{
+			name: codePullReqMergeUseMergeQueue + "-fail",
+			def:  DefPullReq{Merge: DefMerge{UseMergeQueue: true}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqMergeUseMergeQueue},
+			expParams: [][]any{nil},
+			expOut:    MergeVerifyOutput{UseMergeQueue: true},
+		},
+		{
+			name: codePullReqMergeUseMergeQueue + "-success",
+			def:  DefPullReq{Merge: DefMerge{UseMergeQueue: true}},
+			in: MergeVerifyInput{
+				Method:     enum.MergeMethodMerge,
+				MergeQueue: true,
+			},
+			expOut: MergeVerifyOutput{UseMergeQueue: true},
 		},
 		{
 			name: codePullReqMergeStrategiesAllowed + "-fail",
@@ -317,6 +348,39 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			},
 			expOut: MergeVerifyOutput{},
 		},
+		{
+			name: codePullReqMergeRequireUpToDate + "-fail",
+			def:  DefPullReq{Merge: DefMerge{RequireUpToDate: true}},
+			in: MergeVerifyInput{
+				PullReq:  &types.PullReq{SourceSHA: "abc", TargetBranch: "main"},
+				Method:   enum.MergeMethodMerge,
+				Ancestry: testAncestry{},
+			},
+			expCodes:  []string{codePullReqMergeRequireUpToDate},
+			expParams: [][]any{{"main"}},
+			expOut:    MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqMergeRequireUpToDate + "-success",
+			def:  DefPullReq{Merge: DefMerge{RequireUpToDate: true}},
+			in: MergeVerifyInput{
+				PullReq:  &types.PullReq{SourceSHA: "abc", TargetBranch: "main"},
+				Method:   enum.MergeMethodMerge,
+				Ancestry: testAncestry{"refs/heads/main": "abc"},
+			},
+			expOut: MergeVerifyOutput{},
+		},
+		{
+			name: codePullReqMergeRequireUpToDate + "-merge-queue",
+			def:  DefPullReq{Merge: DefMerge{RequireUpToDate: true}},
+			in: MergeVerifyInput{
+				PullReq:    &types.PullReq{SourceSHA: "abc", TargetBranch: "main"},
+				Method:     enum.MergeMethodMerge,
+				MergeQueue: true,
+				Ancestry:   testAncestry{},
+			},
+			expOut: MergeVerifyOutput{},
+		},
 		{
 			name: codePullReqMergeDeleteBranch,
 			def:  DefPullReq{Merge: DefMerge{DeleteBranch: true}},
@@ -351,3 +415,10 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 		})
 	}
 }
+
+// testAncestry maps ancestor revisions to their descendants.
+type testAncestry map[string]string
+
+func (a testAncestry) IsAncestor(_ context.Context, ancestor, descendant string) (bool, error) {
+	return a[ancestor] == descendant, nil
+}
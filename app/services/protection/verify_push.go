@@ -0,0 +1,286 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+type (
+	PushVerifier interface {
+		PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error)
+	}
+
+	PushVerifyInput struct {
+		Actor       *types.Principal
+		AllowBypass bool
+		IsRepoOwner bool
+		Repo        *types.Repository
+		// BranchName is the name of the updated branch. It's empty if a tag or any other
+		// reference is updated, in which case only the push rules apply, regardless of their pattern.
+		BranchName string
+		Push       PushInfo
+	}
+
+	// PushInfo provides the content of a push (or of a commit made through the API).
+	// The data is requested only by the rules that need it, so implementations should load it lazily.
+	PushInfo interface {
+		// CommitProvider provides the new commits and verifies their signatures.
+		CommitProvider
+
+		// ChangedPaths returns the paths of all files changed by the new commits.
+		ChangedPaths(ctx context.Context) ([]string, error)
+
+		// OversizeFiles returns the new files that are larger than the size limit.
+		OversizeFiles(ctx context.Context, sizeLimit int64) ([]PushFile, error)
+
+		// IsUserEmail returns true if the email address belongs to a user of the system.
+		IsUserEmail(ctx context.Context, email string) (bool, error)
+	}
+
+	PushCommit struct {
+		// SHA of the commit, empty if the commit isn't created yet.
+		SHA            string
+		Message        string
+		AuthorEmail    string
+		CommitterEmail string
+		ParentSHAs     []string
+	}
+
+	PushFile struct {
+		Path string
+		Size int64
+	}
+
+	DefPushPolicy struct {
+		// CommitMessagePatterns are regular expressions that the message of every new commit must match.
+		CommitMessagePatterns []string `json:"commit_message_patterns,omitempty"`
+
+		// RestrictEmails requires that author and committer emails of every new commit
+		// belong to a user of the system, or to one of the AllowedEmailDomains.
+		RestrictEmails      bool     `json:"restrict_emails,omitempty"`
+		AllowedEmailDomains []string `json:"allowed_email_domains,omitempty"`
+
+		// ForbiddenPaths are glob patterns of files that mustn't be changed. Patterns without
+		// a slash are matched against the file name, others against the full file path.
+		ForbiddenPaths []string `json:"forbidden_paths,omitempty"`
+
+		// MaxFileSize is the maximum size of a new file in bytes. Zero means there's no limit.
+		MaxFileSize int64 `json:"max_file_size,omitempty"`
+	}
+)
+
+// ensures that the DefPushPolicy type implements Sanitizer and PushVerifier interfaces.
+var (
+	_ Sanitizer    = (*DefPushPolicy)(nil)
+	_ PushVerifier = (*DefPushPolicy)(nil)
+)
+
+const (
+	codePushCommitMessage  = "push.commit_message"
+	codePushAuthorEmail    = "push.author_email"
+	codePushCommitterEmail = "push.committer_email"
+	codePushForbiddenPath  = "push.forbidden_path"
+	codePushFileSize       = "push.file_size"
+)
+
+//nolint:gocognit // it's fine
+func (v *DefPushPolicy) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	if len(v.CommitMessagePatterns) > 0 || v.RestrictEmails {
+		commits, err := in.Push.Commits(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commits: %w", err)
+		}
+
+		patterns := make([]*regexp.Regexp, len(v.CommitMessagePatterns))
+		for i, pattern := range v.CommitMessagePatterns {
+			// the patterns are validated in Sanitize
+			patterns[i] = regexp.MustCompile(pattern)
+		}
+
+		emailVerified := map[string]bool{}
+		isEmailAllowed := func(email string) (bool, error) {
+			if allowed, ok := emailVerified[email]; ok {
+				return allowed, nil
+			}
+
+			allowed := v.isEmailDomainAllowed(email)
+			if !allowed {
+				var err error
+				allowed, err = in.Push.IsUserEmail(ctx, email)
+				if err != nil {
+					return false, fmt.Errorf("failed to verify email: %w", err)
+				}
+			}
+
+			emailVerified[email] = allowed
+
+			return allowed, nil
+		}
+
+		for _, commit := range commits {
+			name := commitName(commit.SHA)
+
+			for i, pattern := range patterns {
+				if !pattern.MatchString(commit.Message) {
+					violations.Addf(codePushCommitMessage,
+						"Message of %s doesn't match the required pattern %q.",
+						name, v.CommitMessagePatterns[i])
+				}
+			}
+
+			if !v.RestrictEmails {
+				continue
+			}
+
+			allowed, err := isEmailAllowed(commit.AuthorEmail)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				violations.Addf(codePushAuthorEmail,
+					"Author email %q of %s is not allowed.", commit.AuthorEmail, name)
+			}
+
+			allowed, err = isEmailAllowed(commit.CommitterEmail)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				violations.Addf(codePushCommitterEmail,
+					"Committer email %q of %s is not allowed.", commit.CommitterEmail, name)
+			}
+		}
+	}
+
+	if len(v.ForbiddenPaths) > 0 {
+		paths, err := in.Push.ChangedPaths(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get changed paths: %w", err)
+		}
+
+		for _, p := range paths {
+			if pattern, ok := v.matchForbiddenPath(p); ok {
+				violations.Addf(codePushForbiddenPath,
+					"Changing file %q is not allowed, it matches the forbidden path pattern %q.", p, pattern)
+			}
+		}
+	}
+
+	if v.MaxFileSize > 0 {
+		files, err := in.Push.OversizeFiles(ctx, v.MaxFileSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oversize files: %w", err)
+		}
+
+		for _, f := range files {
+			violations.Addf(codePushFileSize,
+				"File %q is %d bytes, which exceeds the limit of %d bytes.", f.Path, f.Size, v.MaxFileSize)
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return []types.RuleViolations{}, nil
+}
+
+func (v *DefPushPolicy) isEmailDomainAllowed(email string) bool {
+	idx := strings.LastIndexByte(email, '@')
+	if idx < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(email[idx+1:])
+	for _, allowed := range v.AllowedEmailDomains {
+		if domain == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *DefPushPolicy) matchForbiddenPath(filePath string) (string, bool) {
+	for _, pattern := range v.ForbiddenPaths {
+		name := filePath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(filePath)
+		}
+
+		if ok, _ := doublestar.Match(pattern, name); ok {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+func commitName(sha string) string {
+	const shortSHALen = 8
+
+	if sha == "" {
+		return "the new commit"
+	}
+	if len(sha) > shortSHALen {
+		sha = sha[:shortSHALen]
+	}
+
+	return "commit " + sha
+}
+
+func (v *DefPushPolicy) Sanitize() error {
+	for _, pattern := range v.CommitMessagePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid commit message pattern %q: %w", pattern, err)
+		}
+	}
+
+	for i, domain := range v.AllowedEmailDomains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain == "" {
+			return errors.New("allowed email domain can't be empty")
+		}
+		v.AllowedEmailDomains[i] = domain
+	}
+
+	if len(v.AllowedEmailDomains) > 0 && !v.RestrictEmails {
+		return errors.New("allowed email domains can only be used with restrict emails")
+	}
+
+	for _, pattern := range v.ForbiddenPaths {
+		if pattern == "" || !doublestar.ValidatePattern(pattern) {
+			return fmt.Errorf("invalid forbidden path pattern %q", pattern)
+		}
+	}
+
+	if v.MaxFileSize < 0 {
+		return errors.New("max file size must be zero or a positive integer")
+	}
+
+	return nil
+}
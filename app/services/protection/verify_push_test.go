@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+)
+
+type testPushInfo struct {
+	commits       []PushCommit
+	files         []PushFile
+	userEmails    []string
+	verifications []CommitVerification
+}
+
+func (p testPushInfo) Commits(context.Context) ([]PushCommit, error) {
+	return p.commits, nil
+}
+
+func (p testPushInfo) ChangedPaths(context.Context) ([]string, error) {
+	paths := make([]string, len(p.files))
+	for i := range p.files {
+		paths[i] = p.files[i].Path
+	}
+	return paths, nil
+}
+
+func (p testPushInfo) OversizeFiles(_ context.Context, sizeLimit int64) ([]PushFile, error) {
+	var files []PushFile
+	for _, f := range p.files {
+		if f.Size > sizeLimit {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+func (p testPushInfo) IsUserEmail(_ context.Context, email string) (bool, error) {
+	for _, e := range p.userEmails {
+		if e == email {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p testPushInfo) VerifyCommits(context.Context) ([]CommitVerification, error) {
+	return p.verifications, nil
+}
+
+func TestDefPushPolicy_PushVerify(t *testing.T) {
+	push := testPushInfo{
+		commits: []PushCommit{
+			{
+				SHA:            "1111111111111111111111111111111111111111",
+				Message:        "JIRA-1 add config",
+				AuthorEmail:    "user@example.com",
+				CommitterEmail: "ci@corp.io",
+			},
+			{
+				SHA:            "2222222222222222222222222222222222222222",
+				Message:        "fix typo",
+				AuthorEmail:    "stranger@other.com",
+				CommitterEmail: "stranger@other.com",
+			},
+		},
+		files: []PushFile{
+			{Path: "README.md", Size: 10},
+			{Path: "config/.env", Size: 20},
+			{Path: "certs/server.pem", Size: 30},
+			{Path: "assets/video.mp4", Size: 5000},
+		},
+		userEmails: []string{"user@example.com"},
+	}
+
+	tests := []struct {
+		name      string
+		def       DefPushPolicy
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "push.commit_message-fail",
+			def:      DefPushPolicy{CommitMessagePatterns: []string{`^[A-Z]+-\d+ `}},
+			expCodes: []string{"push.commit_message"},
+			expParams: [][]any{
+				{"commit 22222222", `^[A-Z]+-\d+ `},
+			},
+		},
+		{
+			name:     "push.email-fail",
+			def:      DefPushPolicy{RestrictEmails: true, AllowedEmailDomains: []string{"@Corp.io"}},
+			expCodes: []string{"push.author_email", "push.committer_email"},
+			expParams: [][]any{
+				{"stranger@other.com", "commit 22222222"},
+				{"stranger@other.com", "commit 22222222"},
+			},
+		},
+		{
+			name:     "push.forbidden_path-fail",
+			def:      DefPushPolicy{ForbiddenPaths: []string{".env", "certs/*.pem"}},
+			expCodes: []string{"push.forbidden_path", "push.forbidden_path"},
+			expParams: [][]any{
+				{"config/.env", ".env"},
+				{"certs/server.pem", "certs/*.pem"},
+			},
+		},
+		{
+			name:     "push.file_size-fail",
+			def:      DefPushPolicy{MaxFileSize: 1024},
+			expCodes: []string{"push.file_size"},
+			expParams: [][]any{
+				{"assets/video.mp4", int64(5000), int64(1024)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.PushVerify(context.Background(), PushVerifyInput{Push: push})
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if violations == nil {
+				t.Errorf("expected a non-nil slice of violations")
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+func TestDefPushPolicy_Sanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    DefPushPolicy
+		expErr bool
+	}{
+		{name: "empty", def: DefPushPolicy{}},
+		{name: "invalid-regex", def: DefPushPolicy{CommitMessagePatterns: []string{"("}}, expErr: true},
+		{name: "domains-without-restrict", def: DefPushPolicy{AllowedEmailDomains: []string{"a.io"}}, expErr: true},
+		{name: "empty-domain", def: DefPushPolicy{RestrictEmails: true, AllowedEmailDomains: []string{" "}}, expErr: true},
+		{name: "invalid-path", def: DefPushPolicy{ForbiddenPaths: []string{"[a"}}, expErr: true},
+		{name: "negative-size", def: DefPushPolicy{MaxFileSize: -1}, expErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if want, got := test.expErr, err != nil; want != got {
+				t.Errorf("error mismatch: want=%t got=%v", want, err)
+			}
+		})
+	}
+}
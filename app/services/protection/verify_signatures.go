@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type (
+	// CommitVerifier provides the signature verification of the new commits of a push or of a pull request.
+	CommitVerifier interface {
+		VerifyCommits(ctx context.Context) ([]CommitVerification, error)
+	}
+
+	CommitVerification struct {
+		// SHA of the commit, empty if the commit isn't created yet.
+		SHA string
+		// Verification is the result of the signature verification, nil means that the commit isn't signed.
+		Verification *types.SignatureVerification
+	}
+
+	DefSignatures struct {
+		// RequireVerified requires that every new commit is signed with a signing key of the committer.
+		RequireVerified bool `json:"require_verified,omitempty"`
+	}
+)
+
+const (
+	codeSignaturesUnsigned   = "signatures.unsigned"
+	codeSignaturesUnverified = "signatures.unverified"
+)
+
+func (v *DefSignatures) Verify(ctx context.Context, commits CommitVerifier) ([]types.RuleViolations, error) {
+	if !v.RequireVerified || commits == nil {
+		return []types.RuleViolations{}, nil
+	}
+
+	verifications, err := commits.VerifyCommits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
+	var violations types.RuleViolations
+
+	for _, commit := range verifications {
+		name := commitName(commit.SHA)
+
+		switch {
+		case commit.Verification == nil,
+			commit.Verification.Reason == enum.SignatureVerificationReasonUnsigned:
+			violations.Addf(codeSignaturesUnsigned,
+				"Missing signature of %s.", name)
+		case !commit.Verification.Verified:
+			violations.Addf(codeSignaturesUnverified,
+				"Signature of %s is not verified: %s.", name, commit.Verification.Reason)
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return []types.RuleViolations{}, nil
+}
+
+func (*DefSignatures) Sanitize() error {
+	return nil
+}
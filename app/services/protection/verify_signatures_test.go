@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDefSignatures_Verify(t *testing.T) {
+	push := testPushInfo{
+		verifications: []CommitVerification{
+			{
+				SHA: "1111111111111111111111111111111111111111",
+				Verification: &types.SignatureVerification{
+					Verified: true,
+					Reason:   enum.SignatureVerificationReasonValid,
+				},
+			},
+			{
+				SHA: "2222222222222222222222222222222222222222",
+				Verification: &types.SignatureVerification{
+					Reason: enum.SignatureVerificationReasonUnsigned,
+				},
+			},
+			{
+				SHA: "3333333333333333333333333333333333333333",
+				Verification: &types.SignatureVerification{
+					Reason: enum.SignatureVerificationReasonUnknownKey,
+				},
+			},
+			{
+				SHA: "",
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		def       DefSignatures
+		commits   CommitVerifier
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name:    "empty",
+			commits: push,
+		},
+		{
+			name: "no-commits",
+			def:  DefSignatures{RequireVerified: true},
+		},
+		{
+			name:     "signatures-fail",
+			def:      DefSignatures{RequireVerified: true},
+			commits:  push,
+			expCodes: []string{"signatures.unsigned", "signatures.unverified", "signatures.unsigned"},
+			expParams: [][]any{
+				{"commit 22222222"},
+				{"commit 33333333", enum.SignatureVerificationReasonUnknownKey},
+				{"the new commit"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := test.def.Verify(context.Background(), test.commits)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
@@ -24,12 +24,23 @@ var WireSet = wire.NewSet(
 	ProvideManager,
 )
 
-func ProvideManager(ruleStore store.RuleStore) (*Manager, error) {
-	m := NewManager(ruleStore)
+func ProvideManager(
+	ruleStore store.RuleStore,
+	violationStore store.RuleMonitorViolationStore,
+) (*Manager, error) {
+	m := NewManager(ruleStore, violationStore)
 
 	if err := m.Register(TypeBranch, func() Definition { return &Branch{} }); err != nil {
 		return nil, err
 	}
 
+	if err := m.Register(TypeTag, func() Definition { return &Tag{} }); err != nil {
+		return nil, err
+	}
+
+	if err := m.Register(TypePush, func() Definition { return &Push{} }); err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
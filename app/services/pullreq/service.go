@@ -278,3 +278,37 @@ func createSystemRPCWriteParams(
 		EnvVars: envVars,
 	}, nil
 }
+
+// fetchSourceObjects makes the commit of a fork available in the target repository of a pull request.
+// It's a no-op for pull requests where the source and the target repository are the same.
+func (s *Service) fetchSourceObjects(
+	ctx context.Context,
+	sourceRepoID int64,
+	targetRepo *types.RepositoryGitInfo,
+	sha string,
+) error {
+	if sourceRepoID == targetRepo.ID {
+		return nil
+	}
+
+	sourceRepo, err := s.repoGitInfoCache.Get(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate rpc write params: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []string{sha},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch commits of the source repository: %w", err)
+	}
+
+	return nil
+}
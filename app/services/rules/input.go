@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Type  types.RuleType `json:"type"`
+	State enum.RuleState `json:"state"`
+	// TODO [CODE-1363]: remove after identifier migration.
+	UID         string             `json:"uid" deprecated:"true"`
+	Identifier  string             `json:"identifier"`
+	Description string             `json:"description"`
+	Pattern     protection.Pattern `json:"pattern"`
+	Definition  json.RawMessage    `json:"definition"`
+}
+
+// sanitize validates and sanitizes the create rule input data.
+func (in *CreateInput) sanitize() error {
+	// TODO [CODE-1363]: remove after identifier migration.
+	if in.Identifier == "" {
+		in.Identifier = in.UID
+	}
+
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if err := in.Pattern.Validate(); err != nil {
+		return errors.InvalidArgument("invalid pattern: %s", err)
+	}
+
+	var ok bool
+	in.State, ok = in.State.Sanitize()
+	if !ok {
+		return errors.InvalidArgument("rule state is invalid")
+	}
+
+	if in.Type == "" {
+		in.Type = protection.TypeBranch
+	}
+
+	if len(in.Definition) == 0 {
+		return errors.InvalidArgument("rule definition missing")
+	}
+
+	return nil
+}
+
+type UpdateInput struct {
+	// TODO [CODE-1363]: remove after identifier migration.
+	UID         *string             `json:"uid" deprecated:"true"`
+	Identifier  *string             `json:"identifier"`
+	State       *enum.RuleState     `json:"state"`
+	Description *string             `json:"description"`
+	Pattern     *protection.Pattern `json:"pattern"`
+	Definition  *json.RawMessage    `json:"definition"`
+}
+
+// sanitize validates and sanitizes the update rule input data.
+func (in *UpdateInput) sanitize() error {
+	// TODO [CODE-1363]: remove after identifier migration.
+	if in.Identifier == nil {
+		in.Identifier = in.UID
+	}
+
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.State != nil {
+		state, ok := in.State.Sanitize()
+		if !ok {
+			return errors.InvalidArgument("rule state is invalid")
+		}
+
+		in.State = &state
+	}
+
+	if in.Pattern != nil {
+		if err := in.Pattern.Validate(); err != nil {
+			return errors.InvalidArgument("invalid pattern: %s", err)
+		}
+	}
+
+	if in.Definition != nil && len(*in.Definition) == 0 {
+		return errors.InvalidArgument("rule definition missing")
+	}
+
+	return nil
+}
+
+func (in *UpdateInput) isEmpty() bool {
+	return in.Identifier == nil && in.State == nil && in.Description == nil && in.Pattern == nil && in.Definition == nil
+}
@@ -0,0 +1,470 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// draftIdentifier is used for the rule drafts without an identifier.
+const draftIdentifier = "draft"
+
+// Service manages protection rules of spaces and repositories.
+// Rules defined on a space apply to all repositories in the space and its subspaces.
+type Service struct {
+	tx                 dbtx.Transactor
+	ruleStore          store.RuleStore
+	spaceStore         store.SpaceStore
+	repoStore          store.RepoStore
+	violationStore     store.RuleMonitorViolationStore
+	principalInfoCache store.PrincipalInfoCache
+	protectionManager  *protection.Manager
+	auditService       *audit.Service
+}
+
+func NewService(
+	tx dbtx.Transactor,
+	ruleStore store.RuleStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	violationStore store.RuleMonitorViolationStore,
+	principalInfoCache store.PrincipalInfoCache,
+	protectionManager *protection.Manager,
+	auditService *audit.Service,
+) *Service {
+	return &Service{
+		tx:                 tx,
+		ruleStore:          ruleStore,
+		spaceStore:         spaceStore,
+		repoStore:          repoStore,
+		violationStore:     violationStore,
+		principalInfoCache: principalInfoCache,
+		protectionManager:  protectionManager,
+		auditService:       auditService,
+	}
+}
+
+// Create creates a new protection rule on the space or on the repository.
+func (s *Service) Create(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	in *CreateInput,
+) (*types.Rule, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	var err error
+
+	in.Definition, err = s.protectionManager.SanitizeJSON(in.Type, in.Definition)
+	if err != nil {
+		return nil, errors.InvalidArgument("invalid rule definition: %s", err.Error())
+	}
+
+	now := time.Now().UnixMilli()
+	r := &types.Rule{
+		CreatedBy:     principalID,
+		Created:       now,
+		Updated:       now,
+		RepoID:        repoID,
+		SpaceID:       spaceID,
+		Type:          in.Type,
+		State:         in.State,
+		Identifier:    in.Identifier,
+		Description:   in.Description,
+		Pattern:       in.Pattern.JSON(),
+		Definition:    in.Definition,
+		CreatedByInfo: types.PrincipalInfo{},
+	}
+
+	err = s.ruleStore.Create(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create protection rule: %w", err)
+	}
+
+	if err = s.backfill(ctx, r); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, principalID, enum.AuditActionCreate, r, nil, r)
+
+	return r, nil
+}
+
+// Update updates an existing protection rule of the space or of the repository.
+func (s *Service) Update(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	identifier string,
+	in *UpdateInput,
+) (*types.Rule, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	r, err := s.ruleStore.FindByIdentifier(ctx, spaceID, repoID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a protection rule by its identifier: %w", err)
+	}
+
+	if in.isEmpty() {
+		if err = s.backfill(ctx, r); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+
+	before := *r
+
+	if in.Identifier != nil {
+		r.Identifier = *in.Identifier
+	}
+	if in.State != nil {
+		r.State = *in.State
+	}
+	if in.Description != nil {
+		r.Description = *in.Description
+	}
+	if in.Pattern != nil {
+		r.Pattern = in.Pattern.JSON()
+	}
+	if in.Definition != nil {
+		r.Definition, err = s.protectionManager.SanitizeJSON(r.Type, *in.Definition)
+		if err != nil {
+			return nil, errors.InvalidArgument("invalid rule definition: %s", err.Error())
+		}
+	}
+
+	err = s.ruleStore.Update(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update protection rule: %w", err)
+	}
+
+	s.audit(ctx, principalID, enum.AuditActionUpdate, r, &before, r)
+
+	if err = s.backfill(ctx, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Delete deletes a protection rule of the space or of the repository.
+func (s *Service) Delete(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	identifier string,
+) error {
+	r, err := s.ruleStore.FindByIdentifier(ctx, spaceID, repoID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find protection rule by identifier: %w", err)
+	}
+
+	err = s.ruleStore.Delete(ctx, r.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete protection rule: %w", err)
+	}
+
+	s.audit(ctx, principalID, enum.AuditActionDelete, r, r, nil)
+
+	return nil
+}
+
+// audit records a change of a protection rule in the audit log.
+// Changes of repository rules are recorded under the parent space of the repository as well.
+func (s *Service) audit(
+	ctx context.Context,
+	principalID int64,
+	action enum.AuditAction,
+	r *types.Rule,
+	before, after *types.Rule,
+) {
+	event := audit.Event{
+		ActorID:      principalID,
+		Action:       action,
+		ResourceType: enum.AuditResourceTypeRule,
+		Resource:     r.Identifier,
+		Before:       before,
+		After:        after,
+	}
+
+	if r.SpaceID != nil {
+		event.SpaceID = *r.SpaceID
+	}
+
+	if r.RepoID != nil {
+		event.RepoID = *r.RepoID
+
+		repo, err := s.repoStore.Find(ctx, *r.RepoID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to find repository of protection rule for audit event")
+		} else {
+			event.SpaceID = repo.ParentID
+		}
+	}
+
+	s.auditService.Log(ctx, event)
+}
+
+// Find returns a protection rule of the space or of the repository.
+func (s *Service) Find(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	identifier string,
+) (*types.Rule, error) {
+	r, err := s.ruleStore.FindByIdentifier(ctx, spaceID, repoID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find protection rule by identifier: %w", err)
+	}
+
+	if err = s.backfill(ctx, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// FindInherited returns a protection rule defined on the space or on any of its ancestors.
+// It returns store.ErrResourceNotFound if none of the spaces has a rule with the identifier.
+func (s *Service) FindInherited(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.Rule, error) {
+	spaceIDs, err := s.SpaceIDs(ctx, spaceID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range spaceIDs {
+		r, err := s.ruleStore.FindByIdentifier(ctx, &id, nil, identifier)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space-level protection rule by identifier: %w", err)
+		}
+
+		if err = s.backfill(ctx, r); err != nil {
+			return nil, err
+		}
+
+		return r, nil
+	}
+
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// List returns protection rules defined on any of the spaces or on the repository.
+func (s *Service) List(
+	ctx context.Context,
+	spaceIDs []int64,
+	repoID *int64,
+	filter *types.RuleFilter,
+) ([]types.Rule, int64, error) {
+	var list []types.Rule
+	var count int64
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		list, err = s.ruleStore.List(ctx, spaceIDs, repoID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list protection rules: %w", err)
+		}
+
+		if filter.Page == 1 && len(list) < filter.Size {
+			count = int64(len(list))
+			return nil
+		}
+
+		count, err = s.ruleStore.Count(ctx, spaceIDs, repoID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count protection rules: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range list {
+		if err = s.backfill(ctx, &list[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return list, count, nil
+}
+
+// Draft validates the draft of a protection rule and returns it in the form in which it can be evaluated.
+// The draft isn't stored.
+func (s *Service) Draft(in *CreateInput) (types.RuleInfoInternal, error) {
+	if in.Identifier == "" && in.UID == "" {
+		in.Identifier = draftIdentifier
+	}
+
+	if err := in.sanitize(); err != nil {
+		return types.RuleInfoInternal{}, err
+	}
+
+	definition, err := s.protectionManager.SanitizeJSON(in.Type, in.Definition)
+	if err != nil {
+		return types.RuleInfoInternal{}, errors.InvalidArgument("invalid rule definition: %s", err.Error())
+	}
+
+	return types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			Identifier: in.Identifier,
+			Type:       in.Type,
+			State:      in.State,
+		},
+		Pattern:    in.Pattern.JSON(),
+		Definition: definition,
+	}, nil
+}
+
+// ListMonitorViolations returns the violations of the protection rule in monitor state
+// that have been recorded in the repository.
+func (s *Service) ListMonitorViolations(
+	ctx context.Context,
+	ruleID int64,
+	repoID int64,
+	filter *types.RuleMonitorViolationFilter,
+) ([]*types.RuleMonitorViolation, int64, error) {
+	var list []*types.RuleMonitorViolation
+	var count int64
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		list, err = s.violationStore.List(ctx, ruleID, repoID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list protection rule violations: %w", err)
+		}
+
+		if filter.Page == 1 && len(list) < filter.Size {
+			count = int64(len(list))
+			return nil
+		}
+
+		count, err = s.violationStore.Count(ctx, ruleID, repoID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count protection rule violations: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, count, nil
+}
+
+// SpaceIDs returns the ID of the space and, if ancestors is true, the IDs of all its parent spaces.
+// The IDs are ordered from the space towards the root space.
+func (s *Service) SpaceIDs(ctx context.Context, spaceID int64, ancestors bool) ([]int64, error) {
+	ids := []int64{spaceID}
+	if !ancestors {
+		return ids, nil
+	}
+
+	for id := spaceID; ; {
+		space, err := s.spaceStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if space.ParentID <= 0 {
+			return ids, nil
+		}
+
+		id = space.ParentID
+		ids = append(ids, id)
+	}
+}
+
+func (s *Service) backfill(ctx context.Context, r *types.Rule) error {
+	rule, err := s.protectionManager.FromJSON(r.Type, r.Definition, false)
+	if err != nil {
+		return fmt.Errorf("failed to parse json rule definition: %w", err)
+	}
+
+	if err = s.backfillUsers(ctx, r, rule); err != nil {
+		return err
+	}
+
+	if freezer, ok := rule.(protection.Freezer); ok {
+		r.ActiveFreeze = freezer.ActiveFreeze(time.Now())
+	}
+
+	return s.backfillPaths(ctx, r)
+}
+
+// backfillUsers sets the principal info of all users referenced in the rule definition.
+func (s *Service) backfillUsers(ctx context.Context, r *types.Rule, rule protection.Protection) error {
+	userIDs, err := rule.UserIDs()
+	if err != nil {
+		return fmt.Errorf("failed to get user ID from rule: %w", err)
+	}
+
+	r.Users, err = s.principalInfoCache.Map(ctx, userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get principal infos: %w", err)
+	}
+
+	return nil
+}
+
+// backfillPaths sets the path of the space or of the repository the rule is defined on.
+func (s *Service) backfillPaths(ctx context.Context, r *types.Rule) error {
+	if r.SpaceID != nil {
+		space, err := s.spaceStore.Find(ctx, *r.SpaceID)
+		if err != nil {
+			return fmt.Errorf("failed to find space of protection rule: %w", err)
+		}
+
+		r.SpacePath = space.Path
+	}
+
+	if r.RepoID != nil {
+		repo, err := s.repoStore.Find(ctx, *r.RepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find repository of protection rule: %w", err)
+		}
+
+		r.RepoPath = repo.Path
+	}
+
+	return nil
+}
@@ -0,0 +1,422 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+type fakeTx struct{}
+
+func (fakeTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+// fakeRuleStore keeps protection rules in memory.
+type fakeRuleStore struct {
+	store.RuleStore
+	rules []types.Rule
+}
+
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (s *fakeRuleStore) FindByIdentifier(
+	_ context.Context,
+	spaceID, repoID *int64,
+	identifier string,
+) (*types.Rule, error) {
+	for _, r := range s.rules {
+		if sameID(r.SpaceID, spaceID) && sameID(r.RepoID, repoID) && r.Identifier == identifier {
+			return &r, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeRuleStore) Create(_ context.Context, r *types.Rule) error {
+	r.ID = int64(len(s.rules) + 1)
+	s.rules = append(s.rules, *r)
+	return nil
+}
+
+func (s *fakeRuleStore) Update(_ context.Context, r *types.Rule) error {
+	for i := range s.rules {
+		if s.rules[i].ID == r.ID {
+			s.rules[i] = *r
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeRuleStore) Delete(_ context.Context, id int64) error {
+	for i := range s.rules {
+		if s.rules[i].ID == id {
+			s.rules = append(s.rules[:i], s.rules[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *fakeRuleStore) List(
+	_ context.Context,
+	spaceIDs []int64,
+	repoID *int64,
+	_ *types.RuleFilter,
+) ([]types.Rule, error) {
+	var list []types.Rule
+	for _, r := range s.rules {
+		if (r.SpaceID != nil && slices.Contains(spaceIDs, *r.SpaceID)) || (r.RepoID != nil && sameID(r.RepoID, repoID)) {
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+func (s *fakeRuleStore) Count(
+	ctx context.Context,
+	spaceIDs []int64,
+	repoID *int64,
+	filter *types.RuleFilter,
+) (int64, error) {
+	list, err := s.List(ctx, spaceIDs, repoID, filter)
+	return int64(len(list)), err
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+}
+
+func (s fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	space, ok := s.spaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return space, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+}
+
+func (s fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	repo, ok := s.repos[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+type fakePrincipalInfoCache struct {
+	store.PrincipalInfoCache
+}
+
+func (fakePrincipalInfoCache) Map(context.Context, []int64) (map[int64]*types.PrincipalInfo, error) {
+	return map[int64]*types.PrincipalInfo{}, nil
+}
+
+type fakeAuditEventStore struct {
+	store.AuditEventStore
+	events []*types.AuditEvent
+}
+
+func (s *fakeAuditEventStore) Create(_ context.Context, event *types.AuditEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+const (
+	rootSpaceID  = int64(1)
+	childSpaceID = int64(2)
+	leafSpaceID  = int64(3)
+	repoID       = int64(10)
+)
+
+func newTestService(t *testing.T, rules ...types.Rule) (*Service, *fakeRuleStore, *fakeAuditEventStore) {
+	t.Helper()
+
+	ruleStore := &fakeRuleStore{rules: rules}
+	auditEventStore := &fakeAuditEventStore{}
+
+	protectionManager, err := protection.ProvideManager(ruleStore, nil)
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %v", err)
+	}
+
+	spaceStore := fakeSpaceStore{spaces: map[int64]*types.Space{
+		rootSpaceID:  {ID: rootSpaceID, Path: "root"},
+		childSpaceID: {ID: childSpaceID, ParentID: rootSpaceID, Path: "root/child"},
+		leafSpaceID:  {ID: leafSpaceID, ParentID: childSpaceID, Path: "root/child/leaf"},
+	}}
+
+	repoStore := fakeRepoStore{repos: map[int64]*types.Repository{
+		repoID: {ID: repoID, ParentID: leafSpaceID, Path: "root/child/leaf/repo"},
+	}}
+
+	s := NewService(fakeTx{}, ruleStore, spaceStore, repoStore, nil, fakePrincipalInfoCache{},
+		protectionManager, audit.NewService(auditEventStore))
+
+	return s, ruleStore, auditEventStore
+}
+
+func spaceRule(id, spaceID int64, identifier string) types.Rule {
+	return types.Rule{
+		ID:         id,
+		SpaceID:    &spaceID,
+		Identifier: identifier,
+		Type:       protection.TypeBranch,
+		State:      enum.RuleStateActive,
+		Definition: json.RawMessage("{}"),
+	}
+}
+
+func repoRule(id int64, identifier string) types.Rule {
+	r := spaceRule(id, 0, identifier)
+	r.SpaceID = nil
+	r.RepoID = new(int64)
+	*r.RepoID = repoID
+	return r
+}
+
+func TestService_SpaceIDs(t *testing.T) {
+	s, _, _ := newTestService(t)
+
+	tests := []struct {
+		name      string
+		spaceID   int64
+		ancestors bool
+		want      []int64
+	}{
+		{name: "without-ancestors", spaceID: leafSpaceID, ancestors: false, want: []int64{leafSpaceID}},
+		{name: "leaf", spaceID: leafSpaceID, ancestors: true, want: []int64{leafSpaceID, childSpaceID, rootSpaceID}},
+		{name: "root", spaceID: rootSpaceID, ancestors: true, want: []int64{rootSpaceID}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := s.SpaceIDs(context.Background(), test.spaceID, test.ancestors)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(got, test.want) {
+				t.Errorf("got=%v want=%v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestService_FindInherited(t *testing.T) {
+	s, _, _ := newTestService(t,
+		spaceRule(1, rootSpaceID, "shared"),
+		spaceRule(2, childSpaceID, "shared"),
+		spaceRule(3, rootSpaceID, "root-only"),
+		repoRule(4, "repo-only"),
+	)
+
+	tests := []struct {
+		name       string
+		identifier string
+		wantID     int64
+		wantPath   string
+		wantErr    error
+	}{
+		{name: "nearest-space-wins", identifier: "shared", wantID: 2, wantPath: "root/child"},
+		{name: "root-space", identifier: "root-only", wantID: 3, wantPath: "root"},
+		{name: "repo-rules-are-not-inherited", identifier: "repo-only", wantErr: gitness_store.ErrResourceNotFound},
+		{name: "not-found", identifier: "unknown", wantErr: gitness_store.ErrResourceNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := s.FindInherited(context.Background(), leafSpaceID, test.identifier)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("error: got=%v want=%v", err, test.wantErr)
+			}
+			if test.wantErr != nil {
+				return
+			}
+
+			if r.ID != test.wantID || r.SpacePath != test.wantPath {
+				t.Errorf("got=%d:%s want=%d:%s", r.ID, r.SpacePath, test.wantID, test.wantPath)
+			}
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	s, _, _ := newTestService(t,
+		spaceRule(1, rootSpaceID, "root"),
+		spaceRule(2, childSpaceID, "child"),
+		spaceRule(3, leafSpaceID, "leaf"),
+		repoRule(4, "repo"),
+	)
+
+	ctx := context.Background()
+
+	ancestors, err := s.SpaceIDs(ctx, leafSpaceID, true)
+	if err != nil {
+		t.Fatalf("failed to get space IDs: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		spaceIDs []int64
+		repo     bool
+		want     []string
+	}{
+		{name: "space", spaceIDs: []int64{childSpaceID}, want: []string{"root/child"}},
+		{name: "space-inherited", spaceIDs: ancestors, want: []string{"root", "root/child", "root/child/leaf"}},
+		{name: "repo", repo: true, want: []string{"root/child/leaf/repo"}},
+		{
+			name:     "repo-inherited",
+			spaceIDs: ancestors,
+			repo:     true,
+			want:     []string{"root", "root/child", "root/child/leaf", "root/child/leaf/repo"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var repoIDFilter *int64
+			if test.repo {
+				id := repoID
+				repoIDFilter = &id
+			}
+
+			list, count, err := s.List(ctx, test.spaceIDs, repoIDFilter, &types.RuleFilter{
+				ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 10}},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make([]string, len(list))
+			for i, r := range list {
+				got[i] = r.SpacePath + r.RepoPath
+			}
+
+			if !slices.Equal(got, test.want) || count != int64(len(test.want)) {
+				t.Errorf("got=%v (count=%d) want=%v", got, count, test.want)
+			}
+		})
+	}
+}
+
+func TestService_CreateUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	spaceID := childSpaceID
+	repoIDRef := repoID
+
+	tests := []struct {
+		name         string
+		spaceID      *int64
+		repoID       *int64
+		wantPath     string
+		wantAuditSID int64
+		wantAuditRID *int64
+	}{
+		{
+			name:         "space",
+			spaceID:      &spaceID,
+			wantPath:     "root/child",
+			wantAuditSID: childSpaceID,
+		},
+		{
+			name:         "repo",
+			repoID:       &repoIDRef,
+			wantPath:     "root/child/leaf/repo",
+			wantAuditSID: leafSpaceID,
+			wantAuditRID: &repoIDRef,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, ruleStore, auditEventStore := newTestService(t)
+
+			r, err := s.Create(ctx, 1, test.spaceID, test.repoID, &CreateInput{
+				Identifier: "rule",
+				State:      enum.RuleStateActive,
+				Pattern:    protection.Pattern{Default: true},
+				Definition: json.RawMessage("{}"),
+			})
+			if err != nil {
+				t.Fatalf("failed to create rule: %v", err)
+			}
+
+			if !sameID(r.SpaceID, test.spaceID) || !sameID(r.RepoID, test.repoID) {
+				t.Errorf("scope: got=%v/%v want=%v/%v", r.SpaceID, r.RepoID, test.spaceID, test.repoID)
+			}
+
+			if got := r.SpacePath + r.RepoPath; got != test.wantPath {
+				t.Errorf("path: got=%s want=%s", got, test.wantPath)
+			}
+
+			description := "updated"
+			r, err = s.Update(ctx, 1, test.spaceID, test.repoID, "rule", &UpdateInput{Description: &description})
+			if err != nil {
+				t.Fatalf("failed to update rule: %v", err)
+			}
+
+			if r.Description != description {
+				t.Errorf("description: got=%s want=%s", r.Description, description)
+			}
+
+			if err = s.Delete(ctx, 1, test.spaceID, test.repoID, "rule"); err != nil {
+				t.Fatalf("failed to delete rule: %v", err)
+			}
+
+			if len(ruleStore.rules) != 0 {
+				t.Errorf("expected the rule to be deleted, got=%v", ruleStore.rules)
+			}
+
+			wantActions := []enum.AuditAction{enum.AuditActionCreate, enum.AuditActionUpdate, enum.AuditActionDelete}
+			if len(auditEventStore.events) != len(wantActions) {
+				t.Fatalf("audit events: got=%d want=%d", len(auditEventStore.events), len(wantActions))
+			}
+
+			for i, event := range auditEventStore.events {
+				if event.Action != wantActions[i] {
+					t.Errorf("audit action: got=%s want=%s", event.Action, wantActions[i])
+				}
+				if event.SpaceID == nil || *event.SpaceID != test.wantAuditSID || !sameID(event.RepoID, test.wantAuditRID) {
+					t.Errorf("audit scope: got=%v/%v want=%d/%v",
+						event.SpaceID, event.RepoID, test.wantAuditSID, test.wantAuditRID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	// maxEntropy is the maximum Shannon entropy of a secret with characters of a single byte.
+	maxEntropy = 8
+
+	// maxBypassJustificationLength is the maximum length of a justification for bypassing secret scanning.
+	maxBypassJustificationLength = 1024
+)
+
+var regexFingerprint = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// SettingsInput holds the secret scanning settings of a space or of a repository.
+type SettingsInput struct {
+	// Mode is the enforcement mode. If empty, the mode is inherited from the parent space.
+	Mode      enum.SecretScanMode       `json:"mode"`
+	Rules     []types.SecretScanRule    `json:"rules"`
+	Allowlist types.SecretScanAllowlist `json:"allowlist"`
+}
+
+// sanitize validates and sanitizes the secret scanning settings input data.
+func (in *SettingsInput) sanitize() error {
+	if in.Mode != "" {
+		mode, ok := in.Mode.Sanitize()
+		if !ok {
+			return errors.InvalidArgument("secret scanning mode is invalid")
+		}
+		in.Mode = mode
+	}
+
+	if in.Rules == nil {
+		in.Rules = []types.SecretScanRule{}
+	}
+
+	identifiers := make(map[string]struct{}, len(in.Rules))
+	for i := range in.Rules {
+		rule := &in.Rules[i]
+
+		if err := check.Identifier(rule.Identifier); err != nil {
+			return err
+		}
+
+		if isBuiltinRule(rule.Identifier) {
+			return errors.InvalidArgument("rule identifier %q is used by a built-in rule", rule.Identifier)
+		}
+
+		if _, ok := identifiers[rule.Identifier]; ok {
+			return errors.InvalidArgument("rule identifier %q is used more than once", rule.Identifier)
+		}
+		identifiers[rule.Identifier] = struct{}{}
+
+		rule.Description = strings.TrimSpace(rule.Description)
+
+		regex, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return errors.InvalidArgument("invalid regular expression of rule %q: %s", rule.Identifier, err)
+		}
+
+		if rule.SecretGroup < 0 || rule.SecretGroup > regex.NumSubexp() {
+			return errors.InvalidArgument("secret group of rule %q doesn't exist in the regular expression",
+				rule.Identifier)
+		}
+
+		if rule.MinEntropy < 0 || rule.MinEntropy > maxEntropy {
+			return errors.InvalidArgument("minimum entropy of rule %q must be between 0 and %d",
+				rule.Identifier, maxEntropy)
+		}
+	}
+
+	if in.Allowlist.Paths == nil {
+		in.Allowlist.Paths = []string{}
+	}
+
+	for _, pattern := range in.Allowlist.Paths {
+		if pattern == "" || !doublestar.ValidatePattern(pattern) {
+			return errors.InvalidArgument("invalid allowlist path pattern %q", pattern)
+		}
+	}
+
+	if in.Allowlist.Fingerprints == nil {
+		in.Allowlist.Fingerprints = []string{}
+	}
+
+	for i, fingerprint := range in.Allowlist.Fingerprints {
+		fingerprint = strings.ToLower(strings.TrimSpace(fingerprint))
+		if !regexFingerprint.MatchString(fingerprint) {
+			return errors.InvalidArgument("invalid allowlist fingerprint %q", in.Allowlist.Fingerprints[i])
+		}
+		in.Allowlist.Fingerprints[i] = fingerprint
+	}
+
+	return nil
+}
+
+// SanitizeBypassJustification validates the justification for bypassing secret scanning.
+func SanitizeBypassJustification(justification string) (string, error) {
+	justification = strings.TrimSpace(justification)
+	if len(justification) > maxBypassJustificationLength {
+		return "", errors.InvalidArgument("bypass justification can't be longer than %d characters",
+			maxBypassJustificationLength)
+	}
+
+	return justification, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "gitness:secret_scan"
+
+	// maxScanErrorLength is the maximum length of a scan error stored with the scan.
+	maxScanErrorLength = 1024
+)
+
+func jobUID(scanID int64) string {
+	return jobType + ":" + strconv.FormatInt(scanID, 10)
+}
+
+// StartScan starts a background job that scans the whole history of the repository for secrets.
+// Only one scan of a repository can be in progress at a time.
+func (s *Service) StartScan(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+) (*types.SecretScan, error) {
+	latest, err := s.scanStore.List(ctx, repo.ID, types.Pagination{Page: 1, Size: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secret scans: %w", err)
+	}
+
+	if len(latest) > 0 && (latest[0].Status == enum.SecretScanStatusScheduled ||
+		latest[0].Status == enum.SecretScanStatusRunning) {
+		return nil, errors.Conflict("A secret scan of the repository is already in progress.")
+	}
+
+	now := time.Now().UnixMilli()
+	scan := &types.SecretScan{
+		RepoID:    repo.ID,
+		Status:    enum.SecretScanStatusScheduled,
+		CreatedBy: principalID,
+		Created:   now,
+		Updated:   now,
+	}
+
+	if err = s.scanStore.Create(ctx, scan); err != nil {
+		return nil, fmt.Errorf("failed to create secret scan: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID(scan.ID),
+		Type:       jobType,
+		MaxRetries: 0,
+		Timeout:    s.config.MaxScanDuration,
+		Data:       strconv.FormatInt(scan.ID, 10),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run secret scan job: %w", err)
+	}
+
+	return scan, nil
+}
+
+// FindScan returns the secret scan of the repository.
+func (s *Service) FindScan(ctx context.Context, repoID, scanID int64) (*types.SecretScan, error) {
+	scan, err := s.scanStore.Find(ctx, scanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secret scan: %w", err)
+	}
+
+	if scan.RepoID != repoID {
+		return nil, errors.NotFound("Secret scan not found")
+	}
+
+	return scan, nil
+}
+
+// ListScans returns the secret scans of the repository, the most recent first.
+func (s *Service) ListScans(
+	ctx context.Context,
+	repoID int64,
+	pagination types.Pagination,
+) ([]*types.SecretScan, int64, error) {
+	count, err := s.scanStore.Count(ctx, repoID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count secret scans: %w", err)
+	}
+
+	scans, err := s.scanStore.List(ctx, repoID, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list secret scans: %w", err)
+	}
+
+	return scans, count, nil
+}
+
+// ListFindings returns the recorded secret findings of the repository, the most recent first.
+func (s *Service) ListFindings(
+	ctx context.Context,
+	repoID int64,
+	filter *types.SecretFindingFilter,
+) ([]*types.SecretFinding, int64, error) {
+	count, err := s.findingStore.Count(ctx, repoID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count secret findings: %w", err)
+	}
+
+	findings, err := s.findingStore.List(ctx, repoID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list secret findings: %w", err)
+	}
+
+	return findings, count, nil
+}
+
+// Handle scans the whole history of a repository for secrets.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	scanID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse secret scan id: %w", err)
+	}
+
+	scan, err := s.scanStore.Find(ctx, scanID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find secret scan: %w", err)
+	}
+
+	scan.Status = enum.SecretScanStatusRunning
+	if err = s.scanStore.UpdateStatus(ctx, scan); err != nil {
+		return "", fmt.Errorf("failed to mark secret scan as running: %w", err)
+	}
+
+	err = s.scan(ctx, scan)
+
+	if err != nil {
+		scan.Status = enum.SecretScanStatusFailed
+		scan.Error = err.Error()
+		if len(scan.Error) > maxScanErrorLength {
+			scan.Error = scan.Error[:maxScanErrorLength]
+		}
+	} else {
+		scan.Status = enum.SecretScanStatusSuccess
+	}
+
+	if uerr := s.scanStore.UpdateStatus(ctx, scan); uerr != nil {
+		log.Ctx(ctx).Warn().Err(uerr).Int64("scan_id", scan.ID).Msg("failed to update secret scan status")
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("found %d secrets", scan.Findings), nil
+}
+
+func (s *Service) scan(ctx context.Context, scan *types.SecretScan) error {
+	repo, err := s.repoStore.Find(ctx, scan.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	config, err := s.EffectiveConfig(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	scanner, err := NewScanner(config)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.scanAddedLines(ctx, scanner, &git.ListAddedLinesParams{
+		ReadParams: git.CreateReadParams(repo),
+		AllCommits: true,
+	}, func(findings []types.SecretFinding) error {
+		now := time.Now().UnixMilli()
+		for i := range findings {
+			findings[i].RepoID = repo.ID
+			findings[i].ScanID = &scan.ID
+			findings[i].Source = enum.SecretFindingSourceScan
+			findings[i].CreatedBy = scan.CreatedBy
+			findings[i].Created = now
+		}
+
+		if err := s.createFindings(ctx, findings); err != nil {
+			return err
+		}
+
+		scan.Findings += int64(len(findings))
+
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"github.com/harness/gitness/types"
+)
+
+// builtinRules are the rules of the secret scanner that are always active.
+// Custom rules of spaces and repositories are used in addition to these.
+var builtinRules = []types.SecretScanRule{
+	{
+		Identifier:  "aws-access-key-id",
+		Description: "AWS access key ID",
+		Regex:       `\b((?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16})\b`,
+		SecretGroup: 1,
+	},
+	{
+		Identifier:  "aws-secret-access-key",
+		Description: "AWS secret access key",
+		Regex:       `(?i)aws.{0,20}(?:secret|key).{0,20}?[=:]\s*["']?([A-Za-z0-9/+]{40})(?:[^A-Za-z0-9/+]|$)`,
+		SecretGroup: 1,
+		MinEntropy:  4,
+	},
+	{
+		Identifier:  "github-token",
+		Description: "GitHub token",
+		Regex:       `\b((?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36,255})\b`,
+		SecretGroup: 1,
+	},
+	{
+		Identifier:  "github-fine-grained-token",
+		Description: "GitHub fine-grained personal access token",
+		Regex:       `\b(github_pat_[A-Za-z0-9_]{82})\b`,
+		SecretGroup: 1,
+	},
+	{
+		Identifier:  "gitlab-token",
+		Description: "GitLab personal access token",
+		Regex:       `\b(glpat-[A-Za-z0-9_-]{20})\b`,
+		SecretGroup: 1,
+	},
+	{
+		Identifier:  "slack-token",
+		Description: "Slack token",
+		Regex:       `\b(xox[abposr]-[A-Za-z0-9-]{10,})\b`,
+		SecretGroup: 1,
+	},
+	{
+		Identifier:  "google-api-key",
+		Description: "Google API key",
+		Regex:       `\b(AIza[0-9A-Za-z_-]{35})\b`,
+		SecretGroup: 1,
+	},
+	{
+		Identifier:  "stripe-secret-key",
+		Description: "Stripe secret key",
+		Regex:       `\b((?:sk|rk)_live_[0-9A-Za-z]{24,99})\b`,
+		SecretGroup: 1,
+	},
+	{
+		Identifier:  "private-key",
+		Description: "Private key",
+		Regex:       `-----BEGIN[ A-Z0-9_-]{0,100}PRIVATE KEY(?: BLOCK)?-----`,
+	},
+	{
+		Identifier:  "jwt",
+		Description: "JSON web token",
+		Regex:       `\b(eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,})`,
+		SecretGroup: 1,
+	},
+	{
+		Identifier:  "generic-secret",
+		Description: "Generic secret assigned to a variable",
+		Regex: `(?i)(?:secret|passw(?:or)?d|token|api[_-]?key|access[_-]?key)[A-Za-z0-9_.-]{0,20}` +
+			`["']?\s*[:=]\s*["']?([A-Za-z0-9/+_.=-]{16,})`,
+		SecretGroup: 1,
+		MinEntropy:  3.5,
+	},
+}
+
+// isBuiltinRule returns true if the identifier is used by a built-in rule.
+func isBuiltinRule(identifier string) bool {
+	for _, rule := range builtinRules {
+		if rule.Identifier == identifier {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Result is the outcome of secret scanning of new content of a repository.
+type Result struct {
+	Mode     enum.SecretScanMode
+	Findings []types.SecretFinding
+}
+
+// IsBlocked returns true if the content must be rejected because of the found secrets.
+// The secrets are accepted despite the block mode if the user provided a justification.
+func (r Result) IsBlocked(bypassJustification string) bool {
+	return r.Mode == enum.SecretScanModeBlock && len(r.Findings) > 0 && bypassJustification == ""
+}
+
+// File is a file with its new content, e.g. of a commit that is yet to be created.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// ScanPush scans the lines added by the new commits reachable from the revisions, e.g. of a git push.
+func (s *Service) ScanPush(
+	ctx context.Context,
+	repo *types.Repository,
+	readParams git.ReadParams,
+	revisions []string,
+) (Result, error) {
+	config, err := s.EffectiveConfig(ctx, repo)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if config.Mode == enum.SecretScanModeOff || len(revisions) == 0 {
+		return Result{Mode: config.Mode}, nil
+	}
+
+	scanner, err := NewScanner(config)
+	if err != nil {
+		return Result{}, err
+	}
+
+	findings, err := s.scanAddedLines(ctx, scanner, &git.ListAddedLinesParams{
+		ReadParams: readParams,
+		Revisions:  revisions,
+	}, nil)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Mode:     config.Mode,
+		Findings: findings,
+	}, nil
+}
+
+// ScanFiles scans the content of the files, e.g. of a commit created through the API.
+func (s *Service) ScanFiles(
+	ctx context.Context,
+	repo *types.Repository,
+	files []File,
+) (Result, error) {
+	config, err := s.EffectiveConfig(ctx, repo)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if config.Mode == enum.SecretScanModeOff {
+		return Result{Mode: config.Mode}, nil
+	}
+
+	scanner, err := NewScanner(config)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var findings []types.SecretFinding
+	for _, f := range files {
+		findings = append(findings, scanner.ScanContent(f.Path, f.Content)...)
+	}
+
+	return Result{
+		Mode:     config.Mode,
+		Findings: findings,
+	}, nil
+}
+
+// RecordFindings stores the secrets that entered the repository, either because the mode
+// of the repository is warn, or because the user bypassed secret scanning with a justification.
+// If the findings don't have a commit SHA, the provided commit SHA is used.
+// Failures are only logged, because the content is already accepted at this point.
+func (s *Service) RecordFindings(
+	ctx context.Context,
+	repoID int64,
+	principalID int64,
+	source enum.SecretFindingSource,
+	commitSHA string,
+	bypassJustification string,
+	findings []types.SecretFinding,
+) {
+	if len(findings) == 0 {
+		return
+	}
+
+	now := time.Now().UnixMilli()
+
+	for i := range findings {
+		findings[i].RepoID = repoID
+		findings[i].Source = source
+		findings[i].BypassJustification = bypassJustification
+		findings[i].CreatedBy = principalID
+		findings[i].Created = now
+		if findings[i].CommitSHA == "" {
+			findings[i].CommitSHA = commitSHA
+		}
+	}
+
+	if err := s.createFindings(ctx, findings); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("repo_id", repoID).
+			Msg("failed to record secret findings")
+	}
+}
+
+func (s *Service) createFindings(ctx context.Context, findings []types.SecretFinding) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		for i := range findings {
+			if err := s.findingStore.Create(ctx, &findings[i]); err != nil {
+				return fmt.Errorf("failed to create secret finding: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// scanAddedLines scans the added lines listed by the git params. If the fn is provided,
+// it's called with every batch of findings instead of returning them.
+func (s *Service) scanAddedLines(
+	ctx context.Context,
+	scanner *Scanner,
+	params *git.ListAddedLinesParams,
+	fn func([]types.SecretFinding) error,
+) ([]types.SecretFinding, error) {
+	ch, chErr := s.git.ListAddedLines(ctx, params)
+
+	var (
+		result []types.SecretFinding
+		errFn  error
+	)
+
+	// the channel must be read until it's closed, even if the processing failed.
+	for added := range ch {
+		if errFn != nil || scanner.IsPathAllowed(added.Path) {
+			continue
+		}
+
+		var findings []types.SecretFinding
+		for _, line := range added.Lines {
+			for _, finding := range scanner.ScanLine(added.Path, line.Number, line.Content) {
+				finding.CommitSHA = added.CommitSHA
+				findings = append(findings, finding)
+			}
+		}
+
+		if len(findings) == 0 {
+			continue
+		}
+
+		if fn != nil {
+			errFn = fn(findings)
+			continue
+		}
+
+		result = append(result, findings...)
+	}
+
+	if err := <-chErr; err != nil {
+		return nil, fmt.Errorf("failed to list added lines: %w", err)
+	}
+
+	if errFn != nil {
+		return nil, errFn
+	}
+
+	return result, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	// binaryCheckSize is the size of the file prefix that is checked for binary content, the same as git uses.
+	binaryCheckSize = 8000
+
+	// redactVisibleChars is the number of leading characters of a secret that remain visible after redaction.
+	redactVisibleChars = 4
+)
+
+// Scanner finds secrets in the content of files.
+type Scanner struct {
+	rules        []scannerRule
+	allowPaths   []string
+	fingerprints map[string]struct{}
+}
+
+type scannerRule struct {
+	types.SecretScanRule
+	regex *regexp.Regexp
+}
+
+// NewScanner returns a scanner that uses the rules and the allowlist of the configuration.
+func NewScanner(config types.SecretScanConfig) (*Scanner, error) {
+	rules := make([]scannerRule, len(config.Rules))
+	for i, rule := range config.Rules {
+		regex, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression of secret scan rule %q: %w", rule.Identifier, err)
+		}
+
+		rules[i] = scannerRule{
+			SecretScanRule: rule,
+			regex:          regex,
+		}
+	}
+
+	fingerprints := make(map[string]struct{}, len(config.Allowlist.Fingerprints))
+	for _, fingerprint := range config.Allowlist.Fingerprints {
+		fingerprints[fingerprint] = struct{}{}
+	}
+
+	return &Scanner{
+		rules:        rules,
+		allowPaths:   config.Allowlist.Paths,
+		fingerprints: fingerprints,
+	}, nil
+}
+
+// IsPathAllowed returns true if the file matches a path of the allowlist, such files aren't scanned.
+func (s *Scanner) IsPathAllowed(filePath string) bool {
+	for _, pattern := range s.allowPaths {
+		name := filePath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(filePath)
+		}
+
+		if ok, _ := doublestar.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScanContent returns the secrets found in the content of a file. Binary files aren't scanned.
+func (s *Scanner) ScanContent(filePath string, content []byte) []types.SecretFinding {
+	if s.IsPathAllowed(filePath) || isBinary(content) {
+		return nil
+	}
+
+	var findings []types.SecretFinding
+	for i, line := range strings.Split(string(content), "\n") {
+		findings = append(findings, s.ScanLine(filePath, i+1, line)...)
+	}
+
+	return findings
+}
+
+// ScanLine returns the secrets found in a line of a file. A secret matched by several rules
+// is reported only once, by the first rule. Specific rules precede the generic ones.
+// It doesn't check the allowlisted paths, the caller should skip the files with IsPathAllowed.
+func (s *Scanner) ScanLine(filePath string, lineNum int, line string) []types.SecretFinding {
+	var (
+		findings []types.SecretFinding
+		matched  [][2]int
+	)
+
+	isMatched := func(start, end int) bool {
+		for _, m := range matched {
+			if start < m[1] && m[0] < end {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, rule := range s.rules {
+		for _, match := range rule.regex.FindAllStringSubmatchIndex(line, -1) {
+			idx := 2 * rule.SecretGroup
+			if idx+1 >= len(match) || match[idx] < 0 {
+				continue
+			}
+
+			start, end := match[idx], match[idx+1]
+
+			secret := line[start:end]
+			if secret == "" || isMatched(start, end) || entropy(secret) < rule.MinEntropy {
+				continue
+			}
+
+			matched = append(matched, [2]int{start, end})
+
+			fingerprint := Fingerprint(filePath, rule.Identifier, secret)
+			if _, ok := s.fingerprints[fingerprint]; ok {
+				continue
+			}
+
+			findings = append(findings, types.SecretFinding{
+				Path:        filePath,
+				Line:        lineNum,
+				Rule:        rule.Identifier,
+				Fingerprint: fingerprint,
+				Secret:      redact(secret),
+			})
+		}
+	}
+
+	return findings
+}
+
+// Fingerprint returns the fingerprint of a secret. It doesn't depend on the commit or the line number,
+// so the fingerprint of a secret remains the same if the file is changed.
+func Fingerprint(filePath, rule, secret string) string {
+	sum := sha256.Sum256([]byte(filePath + ":" + rule + ":" + secret))
+	return hex.EncodeToString(sum[:16])
+}
+
+// entropy returns the Shannon entropy of the string in bits per character.
+func entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := map[rune]int{}
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	var result float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		result -= p * math.Log2(p)
+	}
+
+	return result
+}
+
+// redact hides all but the first few characters of a secret.
+func redact(secret string) string {
+	if len(secret) <= 2*redactVisibleChars {
+		return "*****"
+	}
+
+	return secret[:redactVisibleChars] + "*****"
+}
+
+func isBinary(content []byte) bool {
+	if len(content) > binaryCheckSize {
+		content = content[:binaryCheckSize]
+	}
+
+	return bytes.IndexByte(content, 0) >= 0
+}
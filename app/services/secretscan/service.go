@@ -0,0 +1,279 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_errors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Config struct {
+	// DefaultMode is the enforcement mode of repositories without secret scanning settings.
+	DefaultMode enum.SecretScanMode
+	// MaxScanDuration is the maximum time a scan of the history of a repository is allowed to take.
+	MaxScanDuration time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+
+	mode, ok := c.DefaultMode.Sanitize()
+	if !ok {
+		return fmt.Errorf("config.DefaultMode %q is invalid", c.DefaultMode)
+	}
+	c.DefaultMode = mode
+
+	if c.MaxScanDuration < time.Minute {
+		return errors.New("config.MaxScanDuration has to be at least a minute")
+	}
+
+	return nil
+}
+
+// Service scans new content of repositories for secrets, like cloud credentials and access tokens,
+// and scans the whole history of repositories on request.
+// Spaces and repositories configure the enforcement mode, custom rules and the allowlist;
+// repositories inherit the settings of all their parent spaces.
+type Service struct {
+	config        Config
+	tx            dbtx.Transactor
+	scheduler     *job.Scheduler
+	settingsStore store.SecretScanSettingsStore
+	scanStore     store.SecretScanStore
+	findingStore  store.SecretFindingStore
+	spaceStore    store.SpaceStore
+	repoStore     store.RepoStore
+	git           git.Interface
+}
+
+func NewService(
+	config Config,
+	tx dbtx.Transactor,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	settingsStore store.SecretScanSettingsStore,
+	scanStore store.SecretScanStore,
+	findingStore store.SecretFindingStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	git git.Interface,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided secret scan config is invalid: %w", err)
+	}
+
+	s := &Service{
+		config:        config,
+		tx:            tx,
+		scheduler:     scheduler,
+		settingsStore: settingsStore,
+		scanStore:     scanStore,
+		findingStore:  findingStore,
+		spaceStore:    spaceStore,
+		repoStore:     repoStore,
+		git:           git,
+	}
+
+	if err := executor.Register(jobType, s); err != nil {
+		return nil, fmt.Errorf("failed to register job handler for secret scan: %w", err)
+	}
+
+	return s, nil
+}
+
+// FindSettings returns the secret scanning settings of the space or of the repository.
+// It returns nil if the space or the repository doesn't have any settings.
+func (s *Service) FindSettings(
+	ctx context.Context,
+	spaceID, repoID *int64,
+) (*types.SecretScanSettings, error) {
+	settings, err := s.findSettings(ctx, spaceID, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil // no settings is a valid state
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secret scan settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings creates or replaces the secret scanning settings of the space or of the repository.
+func (s *Service) UpdateSettings(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	in *SettingsInput,
+) (*types.SecretScanSettings, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	var settings *types.SecretScanSettings
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		settings, err = s.findSettings(ctx, spaceID, repoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			now := time.Now().UnixMilli()
+			settings = &types.SecretScanSettings{
+				SpaceID:   spaceID,
+				RepoID:    repoID,
+				Mode:      in.Mode,
+				Rules:     in.Rules,
+				Allowlist: in.Allowlist,
+				CreatedBy: principalID,
+				Created:   now,
+				Updated:   now,
+			}
+
+			return s.settingsStore.Create(ctx, settings)
+		}
+		if err != nil {
+			return err
+		}
+
+		settings.Mode = in.Mode
+		settings.Rules = in.Rules
+		settings.Allowlist = in.Allowlist
+
+		return s.settingsStore.Update(ctx, settings)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update secret scan settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// DeleteSettings deletes the secret scanning settings of the space or of the repository,
+// after which the settings are inherited from the parent spaces.
+func (s *Service) DeleteSettings(ctx context.Context, spaceID, repoID *int64) error {
+	settings, err := s.findSettings(ctx, spaceID, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find secret scan settings: %w", err)
+	}
+
+	if err = s.settingsStore.Delete(ctx, settings.ID); err != nil {
+		return fmt.Errorf("failed to delete secret scan settings: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) findSettings(
+	ctx context.Context,
+	spaceID, repoID *int64,
+) (*types.SecretScanSettings, error) {
+	switch {
+	case spaceID != nil:
+		return s.settingsStore.FindBySpace(ctx, *spaceID)
+	case repoID != nil:
+		return s.settingsStore.FindByRepo(ctx, *repoID)
+	default:
+		return nil, gitness_errors.InvalidArgument("either space or repository must be provided")
+	}
+}
+
+// EffectiveConfig returns the secret scanning configuration of the repository. The mode is taken from
+// the settings of the repository or of the closest parent space that defines it. The custom rules
+// and the allowlists of the repository and of all parent spaces are combined with the built-in rules.
+func (s *Service) EffectiveConfig(ctx context.Context, repo *types.Repository) (types.SecretScanConfig, error) {
+	spaceIDs, err := s.ancestorSpaceIDs(ctx, repo.ParentID)
+	if err != nil {
+		return types.SecretScanConfig{}, err
+	}
+
+	list, err := s.settingsStore.List(ctx, repo.ID, spaceIDs)
+	if err != nil {
+		return types.SecretScanConfig{}, fmt.Errorf("failed to list secret scan settings: %w", err)
+	}
+
+	// order the settings from the repository to the root space.
+	ordered := make([]*types.SecretScanSettings, 0, len(list))
+	for _, settings := range list {
+		if settings.RepoID != nil {
+			ordered = append(ordered, settings)
+		}
+	}
+	for _, spaceID := range spaceIDs {
+		for _, settings := range list {
+			if settings.SpaceID != nil && *settings.SpaceID == spaceID {
+				ordered = append(ordered, settings)
+			}
+		}
+	}
+
+	config := types.SecretScanConfig{
+		Rules: []types.SecretScanRule{},
+		Allowlist: types.SecretScanAllowlist{
+			Paths:        []string{},
+			Fingerprints: []string{},
+		},
+	}
+
+	for _, settings := range ordered {
+		if config.Mode == "" {
+			config.Mode = settings.Mode
+		}
+
+		config.Rules = append(config.Rules, settings.Rules...)
+		config.Allowlist.Paths = append(config.Allowlist.Paths, settings.Allowlist.Paths...)
+		config.Allowlist.Fingerprints = append(config.Allowlist.Fingerprints, settings.Allowlist.Fingerprints...)
+	}
+
+	// custom rules come first, the generic built-in rule must not take over their findings.
+	config.Rules = append(config.Rules, builtinRules...)
+
+	if config.Mode == "" {
+		config.Mode = s.config.DefaultMode
+	}
+
+	return config, nil
+}
+
+// ancestorSpaceIDs returns the ID of the space and of all its parent spaces, from the closest to the root.
+func (s *Service) ancestorSpaceIDs(ctx context.Context, spaceID int64) ([]int64, error) {
+	var ids []int64
+
+	for id := spaceID; id > 0; {
+		space, err := s.spaceStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		ids = append(ids, id)
+		id = space.ParentID
+	}
+
+	return ids, nil
+}
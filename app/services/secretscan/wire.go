@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretscan
+
+import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config Config,
+	tx dbtx.Transactor,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	settingsStore store.SecretScanSettingsStore,
+	scanStore store.SecretScanStore,
+	findingStore store.SecretFindingStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	git git.Interface,
+) (*Service, error) {
+	return NewService(
+		config,
+		tx,
+		scheduler,
+		executor,
+		settingsStore,
+		scanStore,
+		findingStore,
+		spaceStore,
+		repoStore,
+		git,
+	)
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	gittypes "github.com/harness/gitness/git/types"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/42wim/sshsig"
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+// FindServerKey returns the signing key of the space, or the instance-level key if the space ID is nil.
+func (s *Service) FindServerKey(ctx context.Context, spaceID *int64) (*types.ServerSigningKey, error) {
+	return s.serverSigningKeyStore.Find(ctx, spaceID)
+}
+
+// SetServerKey replaces the signing key of the space, or the instance-level key if the space ID is nil.
+// The private key is stored encrypted.
+func (s *Service) SetServerKey(
+	ctx context.Context,
+	spaceID *int64,
+	principalID int64,
+	parsed ParsedPrivateKey,
+) (*types.ServerSigningKey, error) {
+	encrypted, err := s.encrypter.Encrypt(parsed.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt private key: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	key := &types.ServerSigningKey{
+		SpaceID:     spaceID,
+		Created:     now,
+		Updated:     now,
+		CreatedBy:   principalID,
+		Type:        parsed.Type,
+		PublicKey:   parsed.Content,
+		Fingerprint: parsed.Fingerprint,
+		PrivateKey:  base64.StdEncoding.EncodeToString(encrypted),
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := s.serverSigningKeyStore.Delete(ctx, spaceID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to delete old server signing key: %w", err)
+		}
+
+		return s.serverSigningKeyStore.Create(ctx, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// DeleteServerKey deletes the signing key of the space, or the instance-level key if the space ID is nil.
+func (s *Service) DeleteServerKey(ctx context.Context, spaceID *int64) error {
+	return s.serverSigningKeyStore.Delete(ctx, spaceID)
+}
+
+// Signer returns the signer for commits and tags the server creates in a repository of the space.
+// The signing key of the nearest space in the hierarchy is used, or the instance-level key if no space has one.
+// It returns nil if no signing key is configured.
+func (s *Service) Signer(ctx context.Context, spaceID int64) (gittypes.Signer, error) {
+	_, key, err := s.findServerKeys(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, nil //nolint:nilnil // no signing key is configured
+	}
+
+	return s.newSigner(key)
+}
+
+// findServerKeys returns the instance-level signing key and the signing key
+// used for repositories of the space, any of them can be nil.
+func (s *Service) findServerKeys(
+	ctx context.Context,
+	spaceID int64,
+) (*types.ServerSigningKey, *types.ServerSigningKey, error) {
+	keys, err := s.serverSigningKeyStore.List(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list server signing keys: %w", err)
+	}
+
+	var instanceKey *types.ServerSigningKey
+	spaceKeys := make(map[int64]*types.ServerSigningKey, len(keys))
+	for _, key := range keys {
+		if key.SpaceID == nil {
+			instanceKey = key
+			continue
+		}
+		spaceKeys[*key.SpaceID] = key
+	}
+
+	key := instanceKey
+	for id := spaceID; id > 0 && len(spaceKeys) > 0; {
+		if spaceKey, ok := spaceKeys[id]; ok {
+			key = spaceKey
+			break
+		}
+
+		space, err := s.spaceStore.Find(ctx, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		id = space.ParentID
+	}
+
+	return instanceKey, key, nil
+}
+
+func (s *Service) newSigner(key *types.ServerSigningKey) (gittypes.Signer, error) {
+	encrypted, err := base64.StdEncoding.DecodeString(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to base64 decode private key: %w", err)
+	}
+
+	privateKey, err := s.encrypter.Decrypt(encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	}
+
+	switch key.Type {
+	case enum.SigningKeyTypeGPG:
+		keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader([]byte(privateKey)))
+		if err != nil || len(keyring) != 1 {
+			return nil, fmt.Errorf("failed to read GPG private key: %w", err)
+		}
+
+		return gpgSigner{entity: keyring[0]}, nil
+	case enum.SigningKeyTypeSSH:
+		return sshSigner{privateKey: []byte(privateKey)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key type: %s", key.Type)
+	}
+}
+
+type gpgSigner struct {
+	entity *openpgp.Entity
+}
+
+func (s gpgSigner) Sign(content []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := openpgp.ArmoredDetachSign(buf, s.entity, bytes.NewReader(content), nil); err != nil {
+		return nil, fmt.Errorf("failed to create GPG signature: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+type sshSigner struct {
+	privateKey []byte
+}
+
+func (s sshSigner) Sign(content []byte) ([]byte, error) {
+	signature, err := sshsig.Sign(s.privateKey, bytes.NewReader(content), sshNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SSH signature: %w", err)
+	}
+
+	return signature, nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/42wim/sshsig"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+// sshNamespace is the namespace git uses for SSH signatures.
+const sshNamespace = "git"
+
+// Service verifies signatures of commits and tags against the signing keys of the users.
+// The signer is the user with the email address of the committer (or tagger).
+// It also manages the keys the server uses to sign commits and tags it creates.
+type Service struct {
+	systemPrincipalUID    string
+	tx                    dbtx.Transactor
+	encrypter             encrypt.Encrypter
+	principalStore        store.PrincipalStore
+	spaceStore            store.SpaceStore
+	signingKeyStore       store.SigningKeyStore
+	serverSigningKeyStore store.ServerSigningKeyStore
+}
+
+func NewService(
+	systemPrincipalUID string,
+	tx dbtx.Transactor,
+	encrypter encrypt.Encrypter,
+	principalStore store.PrincipalStore,
+	spaceStore store.SpaceStore,
+	signingKeyStore store.SigningKeyStore,
+	serverSigningKeyStore store.ServerSigningKeyStore,
+) *Service {
+	return &Service{
+		systemPrincipalUID:    systemPrincipalUID,
+		tx:                    tx,
+		encrypter:             encrypter,
+		principalStore:        principalStore,
+		spaceStore:            spaceStore,
+		signingKeyStore:       signingKeyStore,
+		serverSigningKeyStore: serverSigningKeyStore,
+	}
+}
+
+// VerifyCommit verifies the signature of a commit of the repository.
+func (s *Service) VerifyCommit(
+	ctx context.Context,
+	repo *types.Repository,
+	commit *git.Commit,
+) (*types.SignatureVerification, error) {
+	return s.NewVerifier(repo).VerifyCommit(ctx, commit)
+}
+
+// VerifyTag verifies the signature of an annotated tag of the repository.
+func (s *Service) VerifyTag(
+	ctx context.Context,
+	repo *types.Repository,
+	tag *git.CommitTag,
+) (*types.SignatureVerification, error) {
+	return s.NewVerifier(repo).VerifyTag(ctx, tag)
+}
+
+// NewVerifier returns a Verifier of the commits and tags of the repository that caches the signers and their keys.
+// It should be used to verify multiple commits, but it shouldn't be kept for long.
+func (s *Service) NewVerifier(repo *types.Repository) *Verifier {
+	return &Verifier{
+		service: s,
+		repo:    repo,
+		signers: map[string]*signer{},
+	}
+}
+
+type Verifier struct {
+	service    *Service
+	repo       *types.Repository
+	signers    map[string]*signer
+	serverKeys []*types.ServerSigningKey
+}
+
+type signer struct {
+	principal *types.Principal
+	keys      []*types.SigningKey
+}
+
+// VerifyCommit verifies the signature of the commit.
+func (v *Verifier) VerifyCommit(ctx context.Context, commit *git.Commit) (*types.SignatureVerification, error) {
+	return v.verify(ctx, commit.SignedData, commit.Committer.Identity.Email)
+}
+
+// VerifyTag verifies the signature of the annotated tag.
+func (v *Verifier) VerifyTag(ctx context.Context, tag *git.CommitTag) (*types.SignatureVerification, error) {
+	var email string
+	if tag.Tagger != nil {
+		email = tag.Tagger.Identity.Email
+	}
+
+	return v.verify(ctx, tag.SignedData, email)
+}
+
+func (v *Verifier) verify(
+	ctx context.Context,
+	signedData *git.SignedData,
+	email string,
+) (*types.SignatureVerification, error) {
+	if signedData == nil {
+		return unverified(enum.SignatureVerificationReasonUnsigned), nil
+	}
+
+	var keyType enum.SigningKeyType
+	switch signedData.Type {
+	case git.SignatureTypeGPG:
+		keyType = enum.SigningKeyTypeGPG
+		if _, err := armor.Decode(bytes.NewReader(signedData.Signature)); err != nil {
+			return unverified(enum.SignatureVerificationReasonMalformedSignature), nil
+		}
+	case git.SignatureTypeSSH:
+		keyType = enum.SigningKeyTypeSSH
+		if _, err := sshsig.Decode(signedData.Signature); err != nil {
+			return unverified(enum.SignatureVerificationReasonMalformedSignature), nil
+		}
+	default:
+		return unverified(enum.SignatureVerificationReasonUnknownSignatureType), nil
+	}
+
+	signer, err := v.getSigner(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if signer != nil {
+		for _, key := range signer.keys {
+			if key.Type != keyType || !verifySignature(key.Content, key.Type, signedData) {
+				continue
+			}
+
+			return &types.SignatureVerification{
+				Verified: true,
+				Reason:   enum.SignatureVerificationReasonValid,
+				KeyType:  key.Type,
+				KeyID:    key.Fingerprint,
+				Signer:   signer.principal.ToPrincipalInfo(),
+			}, nil
+		}
+	}
+
+	// commits and tags created by the server are committed by the system principal
+	// and signed with the server signing key of the repository.
+	if signer != nil && signer.principal.UID == v.service.systemPrincipalUID {
+		serverKeys, err := v.getServerKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range serverKeys {
+			if key.Type != keyType || !verifySignature(key.PublicKey, key.Type, signedData) {
+				continue
+			}
+
+			return &types.SignatureVerification{
+				Verified: true,
+				Reason:   enum.SignatureVerificationReasonValid,
+				KeyType:  key.Type,
+				KeyID:    key.Fingerprint,
+				Signer:   signer.principal.ToPrincipalInfo(),
+			}, nil
+		}
+	}
+
+	if signer == nil {
+		return unverified(enum.SignatureVerificationReasonNoUser), nil
+	}
+
+	return unverified(enum.SignatureVerificationReasonUnknownKey), nil
+}
+
+// getServerKeys returns the server signing keys trusted for the repository:
+// The instance-level key and the key the server uses to sign commits in the repository.
+// Keys of other spaces are never trusted, those are managed by the users of the spaces.
+func (v *Verifier) getServerKeys(ctx context.Context) ([]*types.ServerSigningKey, error) {
+	if v.serverKeys != nil {
+		return v.serverKeys, nil
+	}
+
+	instanceKey, repoKey, err := v.service.findServerKeys(ctx, v.repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	v.serverKeys = []*types.ServerSigningKey{}
+	if instanceKey != nil {
+		v.serverKeys = append(v.serverKeys, instanceKey)
+	}
+	if repoKey != nil && repoKey != instanceKey {
+		v.serverKeys = append(v.serverKeys, repoKey)
+	}
+
+	return v.serverKeys, nil
+}
+
+func (v *Verifier) getSigner(ctx context.Context, email string) (*signer, error) {
+	if s, ok := v.signers[email]; ok {
+		return s, nil
+	}
+
+	var s *signer
+
+	principal, err := v.service.principalStore.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find signer by email: %w", err)
+	}
+
+	if principal != nil && email != "" {
+		keys, err := v.service.signingKeyStore.List(ctx, principal.ID, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list signing keys: %w", err)
+		}
+
+		s = &signer{
+			principal: principal,
+			keys:      keys,
+		}
+	}
+
+	v.signers[email] = s
+
+	return s, nil
+}
+
+func verifySignature(publicKey string, keyType enum.SigningKeyType, signedData *git.SignedData) bool {
+	switch keyType {
+	case enum.SigningKeyTypeGPG:
+		keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader([]byte(publicKey)))
+		if err != nil {
+			return false
+		}
+
+		_, err = openpgp.CheckArmoredDetachedSignature(keyring,
+			bytes.NewReader(signedData.SignedContent),
+			bytes.NewReader(signedData.Signature),
+			nil)
+
+		return err == nil
+	case enum.SigningKeyTypeSSH:
+		err := sshsig.Verify(bytes.NewReader(signedData.SignedContent),
+			signedData.Signature, []byte(publicKey), sshNamespace)
+
+		return err == nil
+	default:
+		return false
+	}
+}
+
+func unverified(reason enum.SignatureVerificationReason) *types.SignatureVerification {
+	return &types.SignatureVerification{
+		Verified: false,
+		Reason:   reason,
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	testSystemUID   = "gitness"
+	testSystemEmail = "system@gitness.io"
+	testUserEmail   = "user@example.com"
+)
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (fakePrincipalStore) FindByEmail(_ context.Context, email string) (*types.Principal, error) {
+	switch email {
+	case testSystemEmail:
+		return &types.Principal{ID: 1, UID: testSystemUID, Email: testSystemEmail, Type: enum.PrincipalTypeService}, nil
+	case testUserEmail:
+		return &types.Principal{ID: 2, UID: "user", Email: testUserEmail, Type: enum.PrincipalTypeUser}, nil
+	default:
+		return nil, gitness_store.ErrResourceNotFound
+	}
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+	parents map[int64]int64
+}
+
+func (s fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	parentID, ok := s.parents[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Space{ID: id, ParentID: parentID}, nil
+}
+
+type fakeSigningKeyStore struct {
+	store.SigningKeyStore
+}
+
+func (fakeSigningKeyStore) List(context.Context, int64, enum.SigningKeyType) ([]*types.SigningKey, error) {
+	return nil, nil
+}
+
+type fakeServerSigningKeyStore struct {
+	store.ServerSigningKeyStore
+	keys []*types.ServerSigningKey
+}
+
+func (s fakeServerSigningKeyStore) List(context.Context) ([]*types.ServerSigningKey, error) {
+	return s.keys, nil
+}
+
+// newServerKey generates an SSH server signing key of the space, or the instance-level key if the space ID is nil.
+func newServerKey(t *testing.T, encrypter encrypt.Encrypter, spaceID *int64) *types.ServerSigningKey {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+
+	parsed, err := ParsePrivateKey(string(pem.EncodeToMemory(block)))
+	if err != nil {
+		t.Fatalf("failed to parse private key: %v", err)
+	}
+
+	encrypted, err := encrypter.Encrypt(parsed.PrivateKey)
+	if err != nil {
+		t.Fatalf("failed to encrypt private key: %v", err)
+	}
+
+	return &types.ServerSigningKey{
+		SpaceID:     spaceID,
+		Type:        parsed.Type,
+		PublicKey:   parsed.Content,
+		Fingerprint: parsed.Fingerprint,
+		PrivateKey:  base64.StdEncoding.EncodeToString(encrypted),
+	}
+}
+
+func signedCommit(t *testing.T, s *Service, key *types.ServerSigningKey, committerEmail string) *git.Commit {
+	t.Helper()
+
+	signer, err := s.newSigner(key)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	content := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ncommit\n")
+	signature, err := signer.Sign(content)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	return &git.Commit{
+		Committer: git.Signature{Identity: git.Identity{Email: committerEmail}},
+		SignedData: &git.SignedData{
+			Type:          git.SignatureTypeSSH,
+			Signature:     signature,
+			SignedContent: content,
+		},
+	}
+}
+
+// setupService returns a service with an instance-level signing key and signing keys of the spaces 1 and 2.
+// Space 3 is a child of space 1 without a key of its own.
+func setupService(t *testing.T) (*Service, map[string]*types.ServerSigningKey) {
+	t.Helper()
+
+	encrypter, err := encrypt.New(strings.Repeat("k", 32), false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	space1, space2 := int64(1), int64(2)
+	keys := map[string]*types.ServerSigningKey{
+		"instance": newServerKey(t, encrypter, nil),
+		"space1":   newServerKey(t, encrypter, &space1),
+		"space2":   newServerKey(t, encrypter, &space2),
+	}
+
+	s := NewService(
+		testSystemUID,
+		nil,
+		encrypter,
+		fakePrincipalStore{},
+		fakeSpaceStore{parents: map[int64]int64{1: 0, 2: 0, 3: 1}},
+		fakeSigningKeyStore{},
+		fakeServerSigningKeyStore{
+			keys: []*types.ServerSigningKey{keys["instance"], keys["space1"], keys["space2"]},
+		},
+	)
+
+	return s, keys
+}
+
+func TestVerifier_VerifyCommit_ServerKeys(t *testing.T) {
+	s, keys := setupService(t)
+
+	tests := []struct {
+		name       string
+		repoSpace  int64
+		key        string
+		committer  string
+		wantReason enum.SignatureVerificationReason
+	}{
+		{
+			name:       "key of the repository space",
+			repoSpace:  1,
+			key:        "space1",
+			committer:  testSystemEmail,
+			wantReason: enum.SignatureVerificationReasonValid,
+		},
+		{
+			name:       "key of the parent space",
+			repoSpace:  3,
+			key:        "space1",
+			committer:  testSystemEmail,
+			wantReason: enum.SignatureVerificationReasonValid,
+		},
+		{
+			name:       "instance key",
+			repoSpace:  1,
+			key:        "instance",
+			committer:  testSystemEmail,
+			wantReason: enum.SignatureVerificationReasonValid,
+		},
+		{
+			name:       "key of another space",
+			repoSpace:  1,
+			key:        "space2",
+			committer:  testSystemEmail,
+			wantReason: enum.SignatureVerificationReasonUnknownKey,
+		},
+		{
+			name:       "key of a child space",
+			repoSpace:  3,
+			key:        "space2",
+			committer:  testSystemEmail,
+			wantReason: enum.SignatureVerificationReasonUnknownKey,
+		},
+		{
+			name:       "committer isn't the system principal",
+			repoSpace:  1,
+			key:        "space1",
+			committer:  testUserEmail,
+			wantReason: enum.SignatureVerificationReasonUnknownKey,
+		},
+		{
+			name:       "committer is unknown",
+			repoSpace:  1,
+			key:        "instance",
+			committer:  "unknown@example.com",
+			wantReason: enum.SignatureVerificationReasonNoUser,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			commit := signedCommit(t, s, keys[test.key], test.committer)
+			repo := &types.Repository{ID: 1, ParentID: test.repoSpace}
+
+			verification, err := s.VerifyCommit(context.Background(), repo, commit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got, want := verification.Reason, test.wantReason; got != want {
+				t.Errorf("reason: got=%s want=%s", got, want)
+			}
+
+			wantVerified := test.wantReason == enum.SignatureVerificationReasonValid
+			if got := verification.Verified; got != wantVerified {
+				t.Errorf("verified: got=%t want=%t", got, wantVerified)
+			}
+
+			if wantVerified && verification.KeyID != keys[test.key].Fingerprint {
+				t.Errorf("key ID: got=%s want=%s", verification.KeyID, keys[test.key].Fingerprint)
+			}
+		})
+	}
+}
+
+func TestService_Signer(t *testing.T) {
+	s, keys := setupService(t)
+
+	tests := []struct {
+		name    string
+		spaceID int64
+		wantKey string
+	}{
+		{
+			name:    "space key",
+			spaceID: 2,
+			wantKey: "space2",
+		},
+		{
+			name:    "parent space key",
+			spaceID: 3,
+			wantKey: "space1",
+		},
+		{
+			name:    "instance key",
+			spaceID: 4,
+			wantKey: "instance",
+		},
+	}
+
+	// space 4 has no key and no parent.
+	s.spaceStore = fakeSpaceStore{parents: map[int64]int64{1: 0, 2: 0, 3: 1, 4: 0}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signer, err := s.Signer(context.Background(), test.spaceID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			signedData := &git.SignedData{
+				Type:          git.SignatureTypeSSH,
+				SignedContent: []byte("content"),
+			}
+			signedData.Signature, err = signer.Sign(signedData.SignedContent)
+			if err != nil {
+				t.Fatalf("failed to sign: %v", err)
+			}
+
+			if !verifySignature(keys[test.wantKey].PublicKey, enum.SigningKeyTypeSSH, signedData) {
+				t.Errorf("content isn't signed with the %s key", test.wantKey)
+			}
+		})
+	}
+
+	t.Run("no keys", func(t *testing.T) {
+		s.serverSigningKeyStore = fakeServerSigningKeyStore{}
+
+		signer, err := s.Signer(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if signer != nil {
+			t.Errorf("expected no signer")
+		}
+	})
+}
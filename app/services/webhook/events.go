@@ -99,6 +99,36 @@ func (s *Service) triggerForEventWithPullReq(ctx context.Context,
 	return s.triggerForEvent(ctx, eventID, enum.WebhookParentRepo, targetRepo.ID, triggerType, body)
 }
 
+// triggerForEventWithIssue triggers all webhooks for the given repo and triggerType
+// using the eventID to generate a deterministic triggerID and using the output of bodyFn as payload.
+// The method tries to find the issue, principal and repo and provides all to the bodyFn to generate the body.
+func (s *Service) triggerForEventWithIssue(ctx context.Context,
+	triggerType enum.WebhookTrigger, eventID string, principalID int64, issueID int64,
+	createBodyFn func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error)) error {
+	principal, err := s.findPrincipalForEvent(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	issue, err := s.findIssueForEvent(ctx, issueID)
+	if err != nil {
+		return err
+	}
+
+	repo, err := s.findRepositoryForEvent(ctx, issue.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue repo: %w", err)
+	}
+
+	// create body
+	body, err := createBodyFn(principal, issue, repo)
+	if err != nil {
+		return fmt.Errorf("body creation function failed: %w", err)
+	}
+
+	return s.triggerForEvent(ctx, eventID, enum.WebhookParentRepo, repo.ID, triggerType, body)
+}
+
 // findRepositoryForEvent finds the repository for the provided repoID.
 func (s *Service) findRepositoryForEvent(ctx context.Context, repoID int64) (*types.Repository, error) {
 	repo, err := s.repoStore.Find(ctx, repoID)
@@ -131,6 +161,22 @@ func (s *Service) findPullReqForEvent(ctx context.Context, prID int64) (*types.P
 	return pr, nil
 }
 
+// findIssueForEvent finds the issue for the provided issueID.
+func (s *Service) findIssueForEvent(ctx context.Context, issueID int64) (*types.Issue, error) {
+	issue, err := s.issueStore.Find(ctx, issueID)
+
+	if err != nil && errors.Is(err, store.ErrResourceNotFound) {
+		// not found error is unrecoverable - most likely a racing condition of repo being deleted by now
+		return nil, events.NewDiscardEventErrorf("issue with id '%d' doesn't exist anymore", issueID)
+	}
+	if err != nil {
+		// all other errors we return and force the event to be reprocessed
+		return nil, fmt.Errorf("failed to get issue for id '%d': %w", issueID, err)
+	}
+
+	return issue, nil
+}
+
 // findPrincipalForEvent finds the principal for the provided principalID.
 func (s *Service) findPrincipalForEvent(ctx context.Context, principalID int64) (*types.Principal, error) {
 	principal, err := s.principalStore.Find(ctx, principalID)
@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	issueevents "github.com/harness/gitness/app/events/issue"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// IssuePayload describes the body of the issue created, closed and reopened triggers.
+type IssuePayload struct {
+	BaseSegment
+	IssueSegment
+}
+
+// IssueClosedPayload describes the body of the issue closed trigger.
+type IssueClosedPayload struct {
+	BaseSegment
+	IssueSegment
+	// PullReqNumber is set if the issue got closed by a merged pull request.
+	PullReqNumber int64 `json:"pullreq_number,omitempty"`
+}
+
+// IssueCommentPayload describes the body of the issue comment created trigger.
+type IssueCommentPayload struct {
+	BaseSegment
+	IssueSegment
+	IssueCommentSegment
+}
+
+// handleEventIssueCreated handles created events for issues and triggers issue created webhooks.
+func (s *Service) handleEventIssueCreated(ctx context.Context,
+	event *events.Event[*issueevents.CreatedPayload]) error {
+	return s.triggerForEventWithIssue(ctx, enum.WebhookTriggerIssueCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.IssueID,
+		func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error) {
+			return &IssuePayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerIssueCreated,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(issue),
+				},
+			}, nil
+		})
+}
+
+// handleEventIssueClosed handles closed events for issues and triggers issue closed webhooks.
+func (s *Service) handleEventIssueClosed(ctx context.Context,
+	event *events.Event[*issueevents.ClosedPayload]) error {
+	return s.triggerForEventWithIssue(ctx, enum.WebhookTriggerIssueClosed,
+		event.ID, event.Payload.PrincipalID, event.Payload.IssueID,
+		func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error) {
+			return &IssueClosedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerIssueClosed,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(issue),
+				},
+				PullReqNumber: event.Payload.PullReqNumber,
+			}, nil
+		})
+}
+
+// handleEventIssueReopened handles reopened events for issues and triggers issue reopened webhooks.
+func (s *Service) handleEventIssueReopened(ctx context.Context,
+	event *events.Event[*issueevents.ReopenedPayload]) error {
+	return s.triggerForEventWithIssue(ctx, enum.WebhookTriggerIssueReopened,
+		event.ID, event.Payload.PrincipalID, event.Payload.IssueID,
+		func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error) {
+			return &IssuePayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerIssueReopened,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(issue),
+				},
+			}, nil
+		})
+}
+
+// handleEventIssueComment handles comment created events for issues
+// and triggers issue comment created webhooks.
+func (s *Service) handleEventIssueComment(ctx context.Context,
+	event *events.Event[*issueevents.CommentCreatedPayload]) error {
+	return s.triggerForEventWithIssue(ctx, enum.WebhookTriggerIssueCommentCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.IssueID,
+		func(principal *types.Principal, issue *types.Issue, repo *types.Repository) (any, error) {
+			activity, err := s.issueActivityStore.Find(ctx, event.Payload.ActivityID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue activity by id %d: %w", event.Payload.ActivityID, err)
+			}
+
+			return &IssueCommentPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerIssueCommentCreated,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				IssueSegment: IssueSegment{
+					Issue: issueInfoFrom(issue),
+				},
+				IssueCommentSegment: IssueCommentSegment{
+					CommentInfo: CommentInfo{
+						ID:   activity.ID,
+						Text: activity.Text,
+					},
+				},
+			}, nil
+		})
+}
@@ -16,10 +16,12 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -359,3 +361,171 @@ func (s *Service) handleEventPullReqComment(
 			}, nil
 		})
 }
+
+// PullReqReviewSubmittedPayload describes the body of the pullreq review submitted trigger.
+type PullReqReviewSubmittedPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	ReferenceDetailsSegment
+	PullReqReviewSegment
+}
+
+func (s *Service) handleEventPullReqReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqReviewSubmitted,
+		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+
+			comments := make([]CommentInfo, 0, len(event.Payload.CommentIDs))
+			for _, activityID := range event.Payload.CommentIDs {
+				activity, err := s.activityStore.Find(ctx, activityID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get activity by id for activity id %d: %w", activityID, err)
+				}
+				if activity.Deleted != nil {
+					continue
+				}
+				comments = append(comments, CommentInfo{
+					ID:   activity.ID,
+					Text: activity.Text,
+				})
+			}
+
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, pr.SourceSHA)
+			if err != nil {
+				return nil, err
+			}
+
+			return &PullReqReviewSubmittedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqReviewSubmitted,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				ReferenceDetailsSegment: ReferenceDetailsSegment{
+					SHA:        pr.SourceSHA,
+					Commit:     &commitInfo,
+					HeadCommit: &commitInfo,
+				},
+				PullReqReviewSegment: PullReqReviewSegment{
+					ReviewInfo: ReviewInfo{
+						Decision: event.Payload.Decision,
+						Comments: comments,
+					},
+				},
+			}, nil
+		})
+}
+
+// PullReqLabelPayload describes the body of the pullreq label assigned and unassigned triggers.
+type PullReqLabelPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqTargetReferenceSegment
+	ReferenceSegment
+	ReferenceDetailsSegment
+	PullReqLabelSegment
+}
+
+func (s *Service) handleEventPullReqLabelAssigned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.LabelAssignedPayload],
+) error {
+	return s.handleEventPullReqLabel(ctx, enum.WebhookTriggerPullReqLabelAssigned,
+		event.ID, event.Payload.Base, event.Payload.LabelID)
+}
+
+func (s *Service) handleEventPullReqLabelUnassigned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.LabelUnassignedPayload],
+) error {
+	return s.handleEventPullReqLabel(ctx, enum.WebhookTriggerPullReqLabelUnassigned,
+		event.ID, event.Payload.Base, event.Payload.LabelID)
+}
+
+func (s *Service) handleEventPullReqLabel(
+	ctx context.Context,
+	trigger enum.WebhookTrigger,
+	eventID string,
+	base pullreqevents.Base,
+	labelID int64,
+) error {
+	return s.triggerForEventWithPullReq(ctx, trigger,
+		eventID, base.PrincipalID, base.PullReqID,
+		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
+			targetRepoInfo := repositoryInfoFrom(targetRepo, s.urlProvider)
+			sourceRepoInfo := repositoryInfoFrom(sourceRepo, s.urlProvider)
+
+			// the label could have been deleted in the meantime, in which case only its ID is known.
+			labelInfo := LabelInfo{ID: labelID}
+			label, err := s.labelStore.Find(ctx, labelID)
+			if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return nil, fmt.Errorf("failed to get label by id for label id %d: %w", labelID, err)
+			}
+			if label != nil {
+				labelInfo = LabelInfo{
+					ID:    label.ID,
+					Key:   label.Key,
+					Value: label.Value,
+					Color: label.Color,
+				}
+			}
+
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, pr.SourceSHA)
+			if err != nil {
+				return nil, err
+			}
+
+			return &PullReqLabelPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   trigger,
+					Repo:      targetRepoInfo,
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+				},
+				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+					TargetRef: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.TargetBranch,
+						Repo: targetRepoInfo,
+					},
+				},
+				ReferenceSegment: ReferenceSegment{
+					Ref: ReferenceInfo{
+						Name: gitReferenceNamePrefixBranch + pr.SourceBranch,
+						Repo: sourceRepoInfo,
+					},
+				},
+				ReferenceDetailsSegment: ReferenceDetailsSegment{
+					SHA:        pr.SourceSHA,
+					Commit:     &commitInfo,
+					HeadCommit: &commitInfo,
+				},
+				PullReqLabelSegment: PullReqLabelSegment{
+					LabelInfo: labelInfo,
+				},
+			}, nil
+		})
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	issueevents "github.com/harness/gitness/app/events/issue"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -84,6 +85,9 @@ type Service struct {
 	principalStore        store.PrincipalStore
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
+	labelStore            store.LabelStore
+	issueStore            store.IssueStore
+	issueActivityStore    store.IssueActivityStore
 	encrypter             encrypt.Encrypter
 
 	secureHTTPClient   *http.Client
@@ -100,11 +104,15 @@ func NewService(
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	issueReaderFactory *events.ReaderFactory[*issueevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
+	labelStore store.LabelStore,
+	issueStore store.IssueStore,
+	issueActivityStore store.IssueActivityStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
@@ -119,6 +127,9 @@ func NewService(
 		repoStore:             repoStore,
 		pullreqStore:          pullreqStore,
 		activityStore:         activityStore,
+		labelStore:            labelStore,
+		issueStore:            issueStore,
+		issueActivityStore:    issueActivityStore,
 		urlProvider:           urlProvider,
 		principalStore:        principalStore,
 		git:                   git,
@@ -175,6 +186,9 @@ func NewService(
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterCommentCreated(service.handleEventPullReqComment)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
+			_ = r.RegisterLabelAssigned(service.handleEventPullReqLabelAssigned)
+			_ = r.RegisterLabelUnassigned(service.handleEventPullReqLabelUnassigned)
 
 			return nil
 		})
@@ -182,5 +196,27 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = issueReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *issueevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventIssueCreated)
+			_ = r.RegisterClosed(service.handleEventIssueClosed)
+			_ = r.RegisterReopened(service.handleEventIssueReopened)
+			_ = r.RegisterCommentCreated(service.handleEventIssueComment)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch issue event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
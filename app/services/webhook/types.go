@@ -76,6 +76,26 @@ type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
 }
 
+// PullReqReviewSegment contains details for all pull req review related payloads for webhooks.
+type PullReqReviewSegment struct {
+	ReviewInfo ReviewInfo `json:"review"`
+}
+
+// PullReqLabelSegment contains details for all pull req label related payloads for webhooks.
+type PullReqLabelSegment struct {
+	LabelInfo LabelInfo `json:"label"`
+}
+
+// IssueSegment contains details for all issue related payloads for webhooks.
+type IssueSegment struct {
+	Issue IssueInfo `json:"issue"`
+}
+
+// IssueCommentSegment contains details for all issue comment related payloads for webhooks.
+type IssueCommentSegment struct {
+	CommentInfo CommentInfo `json:"comment"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -143,6 +163,25 @@ func pullReqInfoFrom(pr *types.PullReq, repo *types.Repository, urlProvider url.
 	}
 }
 
+// IssueInfo describes the issue related info for a webhook payload.
+// NOTE: don't use types package as we want issue payload to be independent from API calls.
+type IssueInfo struct {
+	Number int64           `json:"number"`
+	State  enum.IssueState `json:"state"`
+	Title  string          `json:"title"`
+	Author PrincipalInfo   `json:"author"`
+}
+
+// issueInfoFrom gets the IssueInfo from a types.Issue.
+func issueInfoFrom(issue *types.Issue) IssueInfo {
+	return IssueInfo{
+		Number: issue.Number,
+		State:  issue.State,
+		Title:  issue.Title,
+		Author: principalInfoFrom(&issue.Author),
+	}
+}
+
 // PrincipalInfo describes the principal related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type PrincipalInfo struct {
@@ -244,3 +283,17 @@ type CommentInfo struct {
 	ID   int64  `json:"id"`
 	Text string `json:"text"`
 }
+
+// LabelInfo describes a label assigned to or removed from a pull request.
+type LabelInfo struct {
+	ID    int64  `json:"id"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	Color string `json:"color"`
+}
+
+// ReviewInfo describes a pull request review, with the comments published as part of the review.
+type ReviewInfo struct {
+	Decision enum.PullReqReviewDecision `json:"decision"`
+	Comments []CommentInfo              `json:"comments"`
+}
@@ -15,9 +15,13 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/issue"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/reposize"
@@ -42,6 +46,10 @@ type Services struct {
 	Cleanup            *cleanup.Service
 	Notification       *notification.Service
 	Keywordsearch      *keywordsearch.Service
+	Mirror             *mirror.Service
+	MergeQueue         *mergequeue.Service
+	AutoMerge          *automerge.Service
+	Issue              *issue.Service
 }
 
 func ProvideServices(
@@ -54,6 +62,10 @@ func ProvideServices(
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	mirrorSvc *mirror.Service,
+	mergeQueueSvc *mergequeue.Service,
+	autoMergeSvc *automerge.Service,
+	issueSvc *issue.Service,
 ) Services {
 	return Services{
 		Webhook:            webhooksSvc,
@@ -65,5 +77,9 @@ func ProvideServices(
 		Cleanup:            cleanupSvc,
 		Notification:       notificationSvc,
 		Keywordsearch:      keywordsearchSvc,
+		Mirror:             mirrorSvc,
+		MergeQueue:         mergeQueueSvc,
+		AutoMerge:          autoMergeSvc,
+		Issue:              issueSvc,
 	}
 }
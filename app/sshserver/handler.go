@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/ssh"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	extensionPublicKeyID = "gitness-public-key-id"
+	envGitProtocol       = "GIT_PROTOCOL="
+)
+
+var _ ssh.Handler = (*Handler)(nil)
+
+// Handler authenticates ssh clients using the public keys of principals
+// and serves git's ssh protocol using the same code path as smart http.
+type Handler struct {
+	publicKeyStore store.PublicKeyStore
+	principalStore store.PrincipalStore
+	repoCtrl       *repo.Controller
+}
+
+func NewHandler(
+	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
+	repoCtrl *repo.Controller,
+) *Handler {
+	return &Handler{
+		publicKeyStore: publicKeyStore,
+		principalStore: principalStore,
+		repoCtrl:       repoCtrl,
+	}
+}
+
+// Authenticate accepts the public key if it's registered for a principal.
+func (h *Handler) Authenticate(
+	ctx context.Context,
+	_ gossh.ConnMetadata,
+	key gossh.PublicKey,
+) (*gossh.Permissions, error) {
+	publicKey, err := h.publicKeyStore.FindByFingerprint(ctx, gossh.FingerprintSHA256(key))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, errors.New("unknown public key")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find public key: %w", err)
+	}
+
+	return &gossh.Permissions{
+		Extensions: map[string]string{
+			extensionPublicKeyID: strconv.FormatInt(publicKey.ID, 10),
+		},
+	}, nil
+}
+
+// Exec executes git upload-pack or receive-pack for the repository requested by the client.
+func (h *Handler) Exec(ctx context.Context, s *ssh.Session) error {
+	session, err := h.createSession(ctx, s.Permissions)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create auth session for ssh client")
+		return errors.New("authentication failed")
+	}
+
+	service, repoRef, err := parseGitCommand(s.Command)
+	if err != nil {
+		return err
+	}
+
+	gitProtocol := ""
+	for _, env := range s.Env {
+		if strings.HasPrefix(env, envGitProtocol) {
+			gitProtocol = strings.TrimPrefix(env, envGitProtocol)
+		}
+	}
+
+	err = h.repoCtrl.GitServicePack(ctx, session, repoRef, service, gitProtocol, false, s.Stdin, s.Stdout)
+	if err != nil {
+		return errors.New(usererror.Translate(err).Message)
+	}
+
+	return nil
+}
+
+// createSession returns the same auth session for the principal of the public key
+// that would be created for the principal via http.
+func (h *Handler) createSession(ctx context.Context, permissions *gossh.Permissions) (*auth.Session, error) {
+	if permissions == nil {
+		return nil, errors.New("connection is missing permissions")
+	}
+
+	publicKeyID, err := strconv.ParseInt(permissions.Extensions[extensionPublicKeyID], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key id: %w", err)
+	}
+
+	// the key could have been deleted since the connection was established.
+	publicKey, err := h.publicKeyStore.Find(ctx, publicKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find public key: %w", err)
+	}
+
+	principal, err := h.principalStore.Find(ctx, publicKey.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	if principal.Blocked {
+		return nil, fmt.Errorf("principal %d is blocked", principal.ID)
+	}
+
+	if err = h.publicKeyStore.MarkAsUsed(ctx, publicKey.ID, time.Now().UnixMilli()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to mark public key as used")
+	}
+
+	return &auth.Session{
+		Principal: *principal,
+		Metadata: &auth.PublicKeyMetadata{
+			PublicKeyID: publicKey.ID,
+		},
+	}, nil
+}
+
+// parseGitCommand parses the command sent by git clients (e.g. "git-upload-pack '/space/repo.git'").
+func parseGitCommand(command string) (enum.GitServiceType, string, error) {
+	name, arg, _ := strings.Cut(strings.TrimSpace(command), " ")
+
+	service, err := enum.ParseGitServiceType(strings.TrimPrefix(name, "git-"))
+	if err != nil || !strings.HasPrefix(name, "git-") {
+		return "", "", fmt.Errorf("unsupported command %q, only git operations are supported", name)
+	}
+
+	repoRef := strings.Trim(strings.TrimSpace(arg), "'\"")
+	repoRef = strings.TrimSuffix(strings.Trim(repoRef, "/"), ".git")
+	if repoRef == "" {
+		return "", "", errors.New("repository path is required")
+	}
+
+	return service, repoRef, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshserver
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestParseGitCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     string
+		wantService enum.GitServiceType
+		wantRepoRef string
+		wantErr     bool
+	}{
+		{
+			name:        "upload pack with quoted absolute path",
+			command:     "git-upload-pack '/space/repo.git'",
+			wantService: enum.GitServiceTypeUploadPack,
+			wantRepoRef: "space/repo",
+		},
+		{
+			name:        "receive pack with nested space",
+			command:     "git-receive-pack 'space/sub/repo.git'",
+			wantService: enum.GitServiceTypeReceivePack,
+			wantRepoRef: "space/sub/repo",
+		},
+		{
+			name:        "double quotes and no .git suffix",
+			command:     " git-upload-pack \"space/repo/\" ",
+			wantService: enum.GitServiceTypeUploadPack,
+			wantRepoRef: "space/repo",
+		},
+		{
+			name:    "unsupported git command",
+			command: "git-upload-archive 'space/repo.git'",
+			wantErr: true,
+		},
+		{
+			name:    "service without git prefix",
+			command: "upload-pack 'space/repo.git'",
+			wantErr: true,
+		},
+		{
+			name:    "arbitrary command",
+			command: "rm -rf /",
+			wantErr: true,
+		},
+		{
+			name:    "missing repository",
+			command: "git-upload-pack ''",
+			wantErr: true,
+		},
+		{
+			name:    "empty command",
+			command: "",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, repoRef, err := parseGitCommand(test.command)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got service=%q repoRef=%q", service, repoRef)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if service != test.wantService {
+				t.Errorf("service: got=%q want=%q", service, test.wantService)
+			}
+			if repoRef != test.wantRepoRef {
+				t.Errorf("repoRef: got=%q want=%q", repoRef, test.wantRepoRef)
+			}
+		})
+	}
+}
+
+type fakePublicKeyStore struct {
+	store.PublicKeyStore
+	keys []*types.PublicKey
+	used map[int64]int64
+}
+
+func (s *fakePublicKeyStore) Find(_ context.Context, id int64) (*types.PublicKey, error) {
+	for _, key := range s.keys {
+		if key.ID == id {
+			return key, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakePublicKeyStore) FindByFingerprint(_ context.Context, fingerprint string) (*types.PublicKey, error) {
+	for _, key := range s.keys {
+		if key.Fingerprint == fingerprint {
+			return key, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakePublicKeyStore) MarkAsUsed(_ context.Context, id int64, usedAt int64) error {
+	s.used[id] = usedAt
+	return nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s *fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	principal, ok := s.principals[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return principal, nil
+}
+
+func generatePublicKey(t *testing.T) gossh.PublicKey {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	key, err := gossh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to create ssh public key: %v", err)
+	}
+
+	return key
+}
+
+func TestHandler_Authenticate(t *testing.T) {
+	known := generatePublicKey(t)
+	blocked := generatePublicKey(t)
+	unknown := generatePublicKey(t)
+
+	publicKeyStore := &fakePublicKeyStore{
+		keys: []*types.PublicKey{
+			{ID: 1, PrincipalID: 10, Fingerprint: gossh.FingerprintSHA256(known)},
+			{ID: 2, PrincipalID: 20, Fingerprint: gossh.FingerprintSHA256(blocked)},
+		},
+		used: map[int64]int64{},
+	}
+	principalStore := &fakePrincipalStore{principals: map[int64]*types.Principal{
+		10: {ID: 10, UID: "user"},
+		20: {ID: 20, UID: "blocked", Blocked: true},
+	}}
+
+	h := NewHandler(publicKeyStore, principalStore, nil)
+	ctx := context.Background()
+
+	if _, err := h.Authenticate(ctx, nil, unknown); err == nil {
+		t.Errorf("expected unknown public key to be rejected")
+	}
+
+	permissions, err := h.Authenticate(ctx, nil, known)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := permissions.Extensions[extensionPublicKeyID]; got != "1" {
+		t.Errorf("public key id: got=%q want=%q", got, "1")
+	}
+
+	session, err := h.createSession(ctx, permissions)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	if session.Principal.ID != 10 {
+		t.Errorf("principal: got=%d want=%d", session.Principal.ID, 10)
+	}
+	if _, ok := publicKeyStore.used[1]; !ok {
+		t.Errorf("expected the public key to be marked as used")
+	}
+
+	// blocked principals are authenticated by key, but don't get a session.
+	permissions, err = h.Authenticate(ctx, nil, blocked)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = h.createSession(ctx, permissions); err == nil {
+		t.Errorf("expected session creation for blocked principal to fail")
+	}
+
+	// the key could have been deleted since the connection was established.
+	publicKeyStore.keys = publicKeyStore.keys[1:]
+	if _, err = h.createSession(ctx, &gossh.Permissions{
+		Extensions: map[string]string{extensionPublicKeyID: "1"},
+	}); err == nil {
+		t.Errorf("expected session creation for deleted public key to fail")
+	}
+
+	if _, err = h.createSession(ctx, nil); err == nil {
+		t.Errorf("expected session creation without permissions to fail")
+	}
+}
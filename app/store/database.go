@@ -228,6 +228,9 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// ListForks returns all repos (including the deleted ones) that were forked from the repo.
+		ListForks(ctx context.Context, id int64) ([]*types.Repository, error)
 	}
 
 	// RepoGitInfoView defines the repository GitUID view.
@@ -339,6 +342,12 @@ type (
 		// CountUnresolved returns number of unresolved comments.
 		CountUnresolved(ctx context.Context, prID int64) (int, error)
 
+		// ListPending returns the pending activities (comments of a review in progress) of the principal.
+		ListPending(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqActivity, error)
+
+		// DeletePending permanently deletes the pending activities of the principal.
+		DeletePending(ctx context.Context, prID int64, principalID int64) (int64, error)
+
 		// List returns a list of pull request activities in a pull request (a timeline).
 		List(ctx context.Context, prID int64, opts *types.PullReqActivityFilter) ([]*types.PullReqActivity, error)
 	}
@@ -421,16 +430,100 @@ type (
 		// DeleteByIdentifier removes a protection rule by its identifier.
 		DeleteByIdentifier(ctx context.Context, spaceID, repoID *int64, identifier string) error
 
-		// Count returns count of protection rules matching the provided criteria.
-		Count(ctx context.Context, spaceID, repoID *int64, filter *types.RuleFilter) (int64, error)
+		// Count returns count of protection rules defined on any of the spaces or on the repository
+		// that match the provided criteria.
+		Count(ctx context.Context, spaceIDs []int64, repoID *int64, filter *types.RuleFilter) (int64, error)
 
-		// List returns a list of protection rules of a repository or a space that matches the provided criteria.
-		List(ctx context.Context, spaceID, repoID *int64, filter *types.RuleFilter) ([]types.Rule, error)
+		// List returns a list of protection rules defined on any of the spaces or on the repository
+		// that match the provided criteria.
+		List(ctx context.Context, spaceIDs []int64, repoID *int64, filter *types.RuleFilter) ([]types.Rule, error)
 
 		// ListAllRepoRules returns a list of all protection rules that can be applied on a repository.
 		ListAllRepoRules(ctx context.Context, repoID int64) ([]types.RuleInfoInternal, error)
 	}
 
+	// RuleMonitorViolationStore defines the storage of violations of protection rules in the monitor state.
+	RuleMonitorViolationStore interface {
+		// Create records new violations of a protection rule.
+		Create(ctx context.Context, v *types.RuleMonitorViolation) error
+
+		// Count returns count of the recorded violations of a protection rule in a repository.
+		Count(ctx context.Context, ruleID, repoID int64, filter *types.RuleMonitorViolationFilter) (int64, error)
+
+		// List returns the recorded violations of a protection rule in a repository, the most recent first.
+		List(
+			ctx context.Context,
+			ruleID, repoID int64,
+			filter *types.RuleMonitorViolationFilter,
+		) ([]*types.RuleMonitorViolation, error)
+	}
+
+	// SecretScanSettingsStore defines the secret scanning settings data storage.
+	SecretScanSettingsStore interface {
+		// FindBySpace finds the secret scanning settings of a space.
+		FindBySpace(ctx context.Context, spaceID int64) (*types.SecretScanSettings, error)
+
+		// FindByRepo finds the secret scanning settings of a repository.
+		FindByRepo(ctx context.Context, repoID int64) (*types.SecretScanSettings, error)
+
+		// List returns the secret scanning settings of the repository and of the spaces.
+		List(ctx context.Context, repoID int64, spaceIDs []int64) ([]*types.SecretScanSettings, error)
+
+		// Create creates new secret scanning settings of a space or of a repository.
+		Create(ctx context.Context, settings *types.SecretScanSettings) error
+
+		// Update updates the secret scanning settings.
+		Update(ctx context.Context, settings *types.SecretScanSettings) error
+
+		// Delete deletes the secret scanning settings.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// SecretScanStore defines the secret scan data storage.
+	SecretScanStore interface {
+		// Find finds the secret scan by id.
+		Find(ctx context.Context, id int64) (*types.SecretScan, error)
+
+		// Create creates a new secret scan.
+		Create(ctx context.Context, scan *types.SecretScan) error
+
+		// UpdateStatus updates the status, the error and the number of findings of the secret scan.
+		UpdateStatus(ctx context.Context, scan *types.SecretScan) error
+
+		// Count returns count of the secret scans of a repository.
+		Count(ctx context.Context, repoID int64) (int64, error)
+
+		// List returns the secret scans of a repository, the most recent first.
+		List(ctx context.Context, repoID int64, pagination types.Pagination) ([]*types.SecretScan, error)
+	}
+
+	// SecretFindingStore defines the secret finding data storage.
+	SecretFindingStore interface {
+		// Create records a new secret finding.
+		Create(ctx context.Context, finding *types.SecretFinding) error
+
+		// Count returns count of the secret findings of a repository.
+		Count(ctx context.Context, repoID int64, filter *types.SecretFindingFilter) (int64, error)
+
+		// List returns the secret findings of a repository, the most recent first.
+		List(ctx context.Context, repoID int64, filter *types.SecretFindingFilter) ([]*types.SecretFinding, error)
+	}
+
+	// AuditEventStore defines the append-only audit log data storage.
+	AuditEventStore interface {
+		// Create appends a new event to the audit log.
+		Create(ctx context.Context, event *types.AuditEvent) error
+
+		// Count returns count of the audit log events.
+		Count(ctx context.Context, filter *types.AuditEventFilter) (int64, error)
+
+		// List returns the audit log events, the most recent first.
+		List(ctx context.Context, filter *types.AuditEventFilter) ([]*types.AuditEvent, error)
+
+		// DeleteOld removes all events that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
 	// WebhookStore defines the webhook data storage.
 	WebhookStore interface {
 		// Find finds the webhook by id.
@@ -753,4 +846,288 @@ type (
 		// FindByIdentifier returns a types.UserGroup given a space ID and identifier.
 		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.UserGroup, error)
 	}
+
+	// LFSObjectStore defines the git LFS object data storage.
+	LFSObjectStore interface {
+		// Find finds an LFS object of a repository by its OID.
+		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
+
+		// FindMany finds all LFS objects of a repository with the provided OIDs.
+		FindMany(ctx context.Context, repoID int64, oids []string) ([]*types.LFSObject, error)
+
+		// Create creates a new LFS object.
+		Create(ctx context.Context, obj *types.LFSObject) error
+	}
+
+	// LFSLockStore defines the git LFS lock data storage.
+	LFSLockStore interface {
+		// Find finds an LFS lock of a repository by its ID.
+		Find(ctx context.Context, repoID, id int64) (*types.LFSLock, error)
+
+		// Create creates a new LFS lock.
+		Create(ctx context.Context, lock *types.LFSLock) error
+
+		// Delete deletes an LFS lock of a repository.
+		Delete(ctx context.Context, repoID, id int64) error
+
+		// Count returns the number of LFS locks of a repository.
+		Count(ctx context.Context, repoID int64) (int64, error)
+
+		// List returns a list of LFS locks of a repository that matches the provided filter.
+		List(ctx context.Context, repoID int64, filter *types.LFSLockFilter) ([]*types.LFSLock, error)
+
+		// ListByPaths returns all LFS locks of a repository for the provided paths.
+		ListByPaths(ctx context.Context, repoID int64, paths []string) ([]*types.LFSLock, error)
+	}
+
+	// PublicKeyStore defines the SSH public key data storage.
+	PublicKeyStore interface {
+		// Find finds a public key by its ID.
+		Find(ctx context.Context, id int64) (*types.PublicKey, error)
+
+		// FindByIdentifier finds a public key of a principal by its identifier.
+		FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.PublicKey, error)
+
+		// FindByFingerprint finds a public key by its fingerprint.
+		FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error)
+
+		// Create creates a new public key.
+		Create(ctx context.Context, key *types.PublicKey) error
+
+		// Delete deletes the public key with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// MarkAsUsed updates the last used timestamp of a public key.
+		MarkAsUsed(ctx context.Context, id int64, usedAt int64) error
+
+		// Count returns the number of public keys of a principal.
+		Count(ctx context.Context, principalID int64) (int64, error)
+
+		// List returns all public keys of a principal.
+		List(ctx context.Context, principalID int64) ([]*types.PublicKey, error)
+	}
+
+	// SigningKeyStore defines the data storage of keys used to sign commits and tags.
+	SigningKeyStore interface {
+		// FindByIdentifier finds a signing key of a principal by its identifier.
+		FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.SigningKey, error)
+
+		// Create creates a new signing key.
+		Create(ctx context.Context, key *types.SigningKey) error
+
+		// Delete deletes the signing key with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all signing keys of a principal, optionally only the keys of the provided type.
+		List(ctx context.Context, principalID int64, keyType enum.SigningKeyType) ([]*types.SigningKey, error)
+	}
+
+	// ServerSigningKeyStore defines the data storage of keys the server uses to sign commits and tags.
+	ServerSigningKeyStore interface {
+		// Find finds the signing key of a space, or the instance-level key if the space ID is nil.
+		Find(ctx context.Context, spaceID *int64) (*types.ServerSigningKey, error)
+
+		// Create creates a new server signing key.
+		Create(ctx context.Context, key *types.ServerSigningKey) error
+
+		// Delete deletes the signing key of a space, or the instance-level key if the space ID is nil.
+		Delete(ctx context.Context, spaceID *int64) error
+
+		// List returns all server signing keys.
+		List(ctx context.Context) ([]*types.ServerSigningKey, error)
+	}
+
+	// RepoMirrorStore defines the repository mirror data storage.
+	RepoMirrorStore interface {
+		// Find finds a repository mirror by its ID.
+		Find(ctx context.Context, id int64) (*types.RepoMirror, error)
+
+		// Create creates a new repository mirror.
+		Create(ctx context.Context, mirror *types.RepoMirror) error
+
+		// Update updates the configuration of an existing repository mirror.
+		Update(ctx context.Context, mirror *types.RepoMirror) error
+
+		// UpdateSyncState updates the last sync and the next sync information of a repository mirror.
+		UpdateSyncState(ctx context.Context, mirror *types.RepoMirror) error
+
+		// ScheduleSync schedules the sync of a repository mirror at the provided time.
+		ScheduleSync(ctx context.Context, id int64, at int64) error
+
+		// ScheduleSyncByRepo schedules the sync of all enabled mirrors of a repository
+		// with the provided direction at the provided time.
+		ScheduleSyncByRepo(ctx context.Context, repoID int64, direction enum.MirrorDirection, at int64) error
+
+		// Delete deletes the repository mirror with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the mirrors of a repository. If direction is empty, mirrors of both directions are returned.
+		List(ctx context.Context, repoID int64, direction enum.MirrorDirection) ([]*types.RepoMirror, error)
+
+		// ListDue returns enabled repository mirrors which are scheduled to be synced
+		// at or before the provided time.
+		ListDue(ctx context.Context, now int64, limit int) ([]*types.RepoMirror, error)
+	}
+
+	MergeQueueStore interface {
+		// Find finds a merge queue entry by its ID.
+		Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error)
+
+		// FindByPullReqID finds the merge queue entry of a pull request.
+		FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error)
+
+		// Create adds a new entry to the end of the merge queue.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the state and the speculative merge commit of a merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes the merge queue entry with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the entries of the merge queue of the target branch in the order they were enqueued.
+		List(ctx context.Context, repoID int64, targetBranch string) ([]*types.MergeQueueEntry, error)
+
+		// ListByMergeSHA returns the merge queue entries of a repository with the provided merge commit.
+		ListByMergeSHA(ctx context.Context, repoID int64, mergeSHA string) ([]*types.MergeQueueEntry, error)
+	}
+
+	// LabelStore defines the label definition data storage.
+	LabelStore interface {
+		// Find finds the label by id.
+		Find(ctx context.Context, id int64) (*types.Label, error)
+
+		// Create creates a new label.
+		Create(ctx context.Context, label *types.Label) error
+
+		// Update updates the label.
+		Update(ctx context.Context, label *types.Label) error
+
+		// Delete deletes the label with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the labels defined in any of the provided spaces.
+		List(ctx context.Context, spaceIDs []int64, filter *types.LabelFilter) ([]*types.Label, error)
+
+		// Count returns the number of labels defined in any of the provided spaces.
+		Count(ctx context.Context, spaceIDs []int64, filter *types.LabelFilter) (int64, error)
+	}
+
+	// PullReqLabelStore defines the data storage of labels assigned to pull requests.
+	PullReqLabelStore interface {
+		// Assign assigns the label to the pull request.
+		Assign(ctx context.Context, pullreqLabel *types.PullReqLabel) error
+
+		// Unassign removes the label from the pull request.
+		Unassign(ctx context.Context, pullreqID, labelID int64) error
+
+		// ListInfo returns the labels assigned to the provided pull requests, mapped by the pull request id.
+		ListInfo(ctx context.Context, pullreqIDs []int64) (map[int64][]*types.LabelInfo, error)
+	}
+
+	// IssueStore defines the issue data storage.
+	IssueStore interface {
+		// Find the issue by id.
+		Find(ctx context.Context, id int64) (*types.Issue, error)
+
+		// FindByNumber finds the issue by repo ID and issue number.
+		FindByNumber(ctx context.Context, repoID, number int64) (*types.Issue, error)
+
+		// Create a new issue.
+		Create(ctx context.Context, issue *types.Issue) error
+
+		// Update the issue. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, issue *types.Issue) error
+
+		// UpdateOptLock the issue details using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, issue *types.Issue,
+			mutateFn func(issue *types.Issue) error) (*types.Issue, error)
+
+		// UpdateActivitySeq the issue's activity sequence number.
+		// It will set new values to the ActivitySeq, Version and Updated fields.
+		UpdateActivitySeq(ctx context.Context, issue *types.Issue) (*types.Issue, error)
+
+		// Count of issues in a repository.
+		Count(ctx context.Context, opts *types.IssueFilter) (int64, error)
+
+		// List returns a list of issues in a repository.
+		List(ctx context.Context, opts *types.IssueFilter) ([]*types.Issue, error)
+
+		// ListOpenByNumbers returns the open issues of a repository with the provided numbers.
+		ListOpenByNumbers(ctx context.Context, repoID int64, numbers []int64) ([]*types.Issue, error)
+	}
+
+	// IssueActivityStore defines the issue activity data storage.
+	IssueActivityStore interface {
+		// Find the issue activity by id.
+		Find(ctx context.Context, id int64) (*types.IssueActivity, error)
+
+		// Create a new issue activity. Value of the Order field should be fetched with UpdateActivitySeq.
+		// Value of the SubOrder field (for replies) should be the incremented ReplySeq field (non-replies have 0).
+		Create(ctx context.Context, act *types.IssueActivity) error
+
+		// CreateWithPayload create a new system activity from the provided payload.
+		CreateWithPayload(ctx context.Context,
+			issue *types.Issue, principalID int64, payload types.IssueActivityPayload) (*types.IssueActivity, error)
+
+		// Update the issue activity. It will set new values to the Version and Updated fields.
+		Update(ctx context.Context, act *types.IssueActivity) error
+
+		// UpdateOptLock updates the issue activity using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context,
+			act *types.IssueActivity,
+			mutateFn func(act *types.IssueActivity) error,
+		) (*types.IssueActivity, error)
+
+		// Count returns number of issue activities in an issue.
+		Count(ctx context.Context, issueID int64, opts *types.IssueActivityFilter) (int64, error)
+
+		// List returns a list of issue activities in an issue (a timeline).
+		List(ctx context.Context, issueID int64, opts *types.IssueActivityFilter) ([]*types.IssueActivity, error)
+	}
+
+	// IssueAssigneeStore defines the data storage of principals assigned to issues.
+	IssueAssigneeStore interface {
+		// Assign assigns the principal to the issue.
+		Assign(ctx context.Context, assignee *types.IssueAssignee) error
+
+		// Unassign removes the principal from the issue assignees.
+		Unassign(ctx context.Context, issueID, principalID int64) error
+
+		// ListInfo returns the assignees of the provided issues, mapped by the issue id.
+		ListInfo(ctx context.Context, issueIDs []int64) (map[int64][]*types.PrincipalInfo, error)
+	}
+
+	// IssueLabelStore defines the data storage of labels assigned to issues.
+	IssueLabelStore interface {
+		// Assign assigns the label to the issue.
+		Assign(ctx context.Context, issueLabel *types.IssueLabel) error
+
+		// Unassign removes the label from the issue.
+		Unassign(ctx context.Context, issueID, labelID int64) error
+
+		// ListInfo returns the labels assigned to the provided issues, mapped by the issue id.
+		ListInfo(ctx context.Context, issueIDs []int64) (map[int64][]*types.LabelInfo, error)
+	}
+
+	// MilestoneStore defines the milestone data storage.
+	MilestoneStore interface {
+		// Find finds the milestone by id.
+		Find(ctx context.Context, id int64) (*types.Milestone, error)
+
+		// Create creates a new milestone.
+		Create(ctx context.Context, milestone *types.Milestone) error
+
+		// Update updates the milestone.
+		Update(ctx context.Context, milestone *types.Milestone) error
+
+		// Delete deletes the milestone with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns the milestones of the repository.
+		List(ctx context.Context, repoID int64, filter *types.MilestoneFilter) ([]*types.Milestone, error)
+
+		// Count returns the number of milestones of the repository.
+		Count(ctx context.Context, repoID int64, filter *types.MilestoneFilter) (int64, error)
+	}
 )
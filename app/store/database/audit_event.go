@@ -0,0 +1,298 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.AuditEventStore = (*AuditEventStore)(nil)
+
+// NewAuditEventStore returns a new AuditEventStore.
+func NewAuditEventStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *AuditEventStore {
+	return &AuditEventStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// AuditEventStore implements store.AuditEventStore backed by a relational database.
+type AuditEventStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type auditEvent struct {
+	ID           int64                  `db:"ae_id"`
+	Created      int64                  `db:"ae_created"`
+	ActorID      int64                  `db:"ae_actor_id"`
+	Action       enum.AuditAction       `db:"ae_action"`
+	ResourceType enum.AuditResourceType `db:"ae_resource_type"`
+	Resource     string                 `db:"ae_resource"`
+	SpaceID      null.Int               `db:"ae_space_id"`
+	RepoID       null.Int               `db:"ae_repo_id"`
+	Before       string                 `db:"ae_before"`
+	After        string                 `db:"ae_after"`
+	ClientIP     string                 `db:"ae_client_ip"`
+	UserAgent    string                 `db:"ae_user_agent"`
+}
+
+const (
+	auditEventColumns = `
+		 ae_id
+		,ae_created
+		,ae_actor_id
+		,ae_action
+		,ae_resource_type
+		,ae_resource
+		,ae_space_id
+		,ae_repo_id
+		,ae_before
+		,ae_after
+		,ae_client_ip
+		,ae_user_agent`
+)
+
+// Create appends a new event to the audit log.
+func (s *AuditEventStore) Create(ctx context.Context, event *types.AuditEvent) error {
+	const sqlQuery = `
+		INSERT INTO audit_events (
+			 ae_created
+			,ae_actor_id
+			,ae_action
+			,ae_resource_type
+			,ae_resource
+			,ae_space_id
+			,ae_repo_id
+			,ae_before
+			,ae_after
+			,ae_client_ip
+			,ae_user_agent
+		) values (
+			 :ae_created
+			,:ae_actor_id
+			,:ae_action
+			,:ae_resource_type
+			,:ae_resource
+			,:ae_space_id
+			,:ae_repo_id
+			,:ae_before
+			,:ae_after
+			,:ae_client_ip
+			,:ae_user_agent
+		) RETURNING ae_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAuditEvent(event))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind audit event object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&event.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert audit event query failed")
+	}
+
+	return nil
+}
+
+// Count returns count of the audit log events.
+func (s *AuditEventStore) Count(ctx context.Context, filter *types.AuditEventFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("audit_events")
+
+	stmt = applyAuditEventFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count audit events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count audit events query")
+	}
+
+	return count, nil
+}
+
+// List returns the audit log events, the most recent first.
+func (s *AuditEventStore) List(ctx context.Context, filter *types.AuditEventFilter) ([]*types.AuditEvent, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events")
+
+	stmt = applyAuditEventFilter(stmt, filter)
+
+	if filter.BeforeID > 0 {
+		stmt = stmt.Where("ae_id < ?", filter.BeforeID)
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("ae_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list audit events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*auditEvent
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list audit events query")
+	}
+
+	return s.mapToAuditEvents(ctx, dst), nil
+}
+
+// DeleteOld removes all events that are older than the provided time.
+func (s *AuditEventStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("audit_events").
+		Where("ae_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete audit events query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed to execute delete audit events query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed to get number of deleted audit events")
+	}
+
+	return n, nil
+}
+
+func applyAuditEventFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.AuditEventFilter,
+) squirrel.SelectBuilder {
+	if filter.SpaceID > 0 {
+		stmt = stmt.Where("ae_space_id = ?", filter.SpaceID)
+	}
+
+	if filter.RepoID > 0 {
+		stmt = stmt.Where("ae_repo_id = ?", filter.RepoID)
+	}
+
+	if filter.ActorID > 0 {
+		stmt = stmt.Where("ae_actor_id = ?", filter.ActorID)
+	}
+
+	if filter.Since > 0 {
+		stmt = stmt.Where("ae_created >= ?", filter.Since)
+	}
+
+	if filter.Until > 0 {
+		stmt = stmt.Where("ae_created <= ?", filter.Until)
+	}
+
+	return stmt
+}
+
+func (s *AuditEventStore) mapToAuditEvents(
+	ctx context.Context,
+	in []*auditEvent,
+) []*types.AuditEvent {
+	res := make([]*types.AuditEvent, len(in))
+	for i := range in {
+		res[i] = s.mapToAuditEvent(ctx, in[i])
+	}
+	return res
+}
+
+func (s *AuditEventStore) mapToAuditEvent(
+	ctx context.Context,
+	in *auditEvent,
+) *types.AuditEvent {
+	event := &types.AuditEvent{
+		ID:           in.ID,
+		Created:      in.Created,
+		ActorID:      in.ActorID,
+		Action:       in.Action,
+		ResourceType: in.ResourceType,
+		Resource:     in.Resource,
+		SpaceID:      in.SpaceID.Ptr(),
+		RepoID:       in.RepoID.Ptr(),
+		ClientIP:     in.ClientIP,
+		UserAgent:    in.UserAgent,
+	}
+
+	if in.Before != "" {
+		event.Before = json.RawMessage(in.Before)
+	}
+
+	if in.After != "" {
+		event.After = json.RawMessage(in.After)
+	}
+
+	actor, err := s.pCache.Get(ctx, in.ActorID)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load audit event actor")
+	}
+
+	if actor != nil {
+		event.Actor = *actor
+	}
+
+	return event
+}
+
+func mapToInternalAuditEvent(in *types.AuditEvent) *auditEvent {
+	return &auditEvent{
+		ID:           in.ID,
+		Created:      in.Created,
+		ActorID:      in.ActorID,
+		Action:       in.Action,
+		ResourceType: in.ResourceType,
+		Resource:     in.Resource,
+		SpaceID:      null.IntFromPtr(in.SpaceID),
+		RepoID:       null.IntFromPtr(in.RepoID),
+		Before:       string(in.Before),
+		After:        string(in.After),
+		ClientIP:     in.ClientIP,
+		UserAgent:    in.UserAgent,
+	}
+}
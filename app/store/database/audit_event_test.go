@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestAuditEventStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	if err := principalStore.CreateUser(ctx, &types.User{ID: 2, UID: "user_2", Email: "user_2@example.com"}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	auditEventStore := database.NewAuditEventStore(db, pCache)
+
+	spaceID := int64(10)
+	repoID := int64(20)
+
+	events := []*types.AuditEvent{
+		{Created: 1000, ActorID: 1, SpaceID: &spaceID},
+		{Created: 2000, ActorID: 2, SpaceID: &spaceID, RepoID: &repoID, Before: json.RawMessage(`{"a":1}`)},
+		{Created: 3000, ActorID: 1, After: json.RawMessage(`{"admin":true}`)},
+	}
+
+	for _, event := range events {
+		event.Action = enum.AuditActionUpdate
+		event.ResourceType = enum.AuditResourceTypeUser
+		if err := auditEventStore.Create(ctx, event); err != nil {
+			t.Fatalf("failed to create audit event: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter types.AuditEventFilter
+		expIDs []int64
+	}{
+		{
+			name:   "all",
+			filter: types.AuditEventFilter{},
+			expIDs: []int64{events[2].ID, events[1].ID, events[0].ID},
+		},
+		{
+			name:   "space",
+			filter: types.AuditEventFilter{SpaceID: spaceID},
+			expIDs: []int64{events[1].ID, events[0].ID},
+		},
+		{
+			name:   "repo",
+			filter: types.AuditEventFilter{RepoID: repoID},
+			expIDs: []int64{events[1].ID},
+		},
+		{
+			name:   "actor",
+			filter: types.AuditEventFilter{ActorID: 1},
+			expIDs: []int64{events[2].ID, events[0].ID},
+		},
+		{
+			name:   "time-range",
+			filter: types.AuditEventFilter{Since: 1500, Until: 3000},
+			expIDs: []int64{events[2].ID, events[1].ID},
+		},
+		{
+			name:   "before-id",
+			filter: types.AuditEventFilter{BeforeID: events[2].ID},
+			expIDs: []int64{events[1].ID, events[0].ID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := auditEventStore.List(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to list audit events: %v", err)
+			}
+
+			ids := make([]int64, len(list))
+			for i := range list {
+				ids[i] = list[i].ID
+			}
+
+			if len(ids) != len(test.expIDs) {
+				t.Fatalf("got=%v want=%v", ids, test.expIDs)
+			}
+			for i := range ids {
+				if ids[i] != test.expIDs[i] {
+					t.Fatalf("got=%v want=%v", ids, test.expIDs)
+				}
+			}
+		})
+	}
+
+	list, err := auditEventStore.List(ctx, &types.AuditEventFilter{RepoID: repoID})
+	if err != nil {
+		t.Fatalf("failed to list audit events: %v", err)
+	}
+	if string(list[0].Before) != `{"a":1}` || list[0].After != nil || list[0].Actor.ID != 2 {
+		t.Errorf("unexpected audit event: %+v", list[0])
+	}
+
+	n, err := auditEventStore.DeleteOld(ctx, time.UnixMilli(2500))
+	if err != nil {
+		t.Fatalf("failed to delete old audit events: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("deleted=%d want=2", n)
+	}
+
+	count, err := auditEventStore.Count(ctx, &types.AuditEventFilter{})
+	if err != nil {
+		t.Fatalf("failed to count audit events: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count=%d want=1", count)
+	}
+}
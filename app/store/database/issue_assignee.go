@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.IssueAssigneeStore = (*IssueAssigneeStore)(nil)
+
+// NewIssueAssigneeStore returns a new IssueAssigneeStore.
+func NewIssueAssigneeStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *IssueAssigneeStore {
+	return &IssueAssigneeStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// IssueAssigneeStore implements store.IssueAssigneeStore backed by a relational database.
+type IssueAssigneeStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type issueAssignee struct {
+	IssueID     int64 `db:"issue_assignee_issue_id"`
+	PrincipalID int64 `db:"issue_assignee_principal_id"`
+	Created     int64 `db:"issue_assignee_created"`
+	CreatedBy   int64 `db:"issue_assignee_created_by"`
+}
+
+// Assign assigns the principal to the issue.
+func (s *IssueAssigneeStore) Assign(ctx context.Context, assignee *types.IssueAssignee) error {
+	const sqlQuery = `
+		INSERT INTO issue_assignees (
+			 issue_assignee_issue_id
+			,issue_assignee_principal_id
+			,issue_assignee_created
+			,issue_assignee_created_by
+		) values (
+			 :issue_assignee_issue_id
+			,:issue_assignee_principal_id
+			,:issue_assignee_created
+			,:issue_assignee_created_by
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &issueAssignee{
+		IssueID:     assignee.IssueID,
+		PrincipalID: assignee.PrincipalID,
+		Created:     assignee.Created,
+		CreatedBy:   assignee.CreatedBy,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind issue assignee")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert issue assignee query failed")
+	}
+
+	return nil
+}
+
+// Unassign removes the principal from the issue assignees.
+func (s *IssueAssigneeStore) Unassign(ctx context.Context, issueID, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM issue_assignees
+		WHERE issue_assignee_issue_id = $1 AND issue_assignee_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, issueID, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete issue assignee query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted issue assignees")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListInfo returns the assignees of the provided issues, mapped by the issue id.
+func (s *IssueAssigneeStore) ListInfo(
+	ctx context.Context,
+	issueIDs []int64,
+) (map[int64][]*types.PrincipalInfo, error) {
+	stmt := database.Builder.
+		Select("issue_assignee_issue_id, issue_assignee_principal_id").
+		From("issue_assignees").
+		Where(squirrel.Eq{"issue_assignee_issue_id": issueIDs}).
+		OrderBy("issue_assignee_created")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list issue assignees query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*issueAssignee
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list issue assignees query")
+	}
+
+	ids := make([]int64, len(dst))
+	for i, assignee := range dst {
+		ids[i] = assignee.PrincipalID
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issue assignee principal infos: %w", err)
+	}
+
+	result := make(map[int64][]*types.PrincipalInfo, len(issueIDs))
+	for _, assignee := range dst {
+		if info, ok := infoMap[assignee.PrincipalID]; ok {
+			result[assignee.IssueID] = append(result[assignee.IssueID], info)
+		}
+	}
+
+	return result, nil
+}
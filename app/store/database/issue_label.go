@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.IssueLabelStore = (*IssueLabelStore)(nil)
+
+// NewIssueLabelStore returns a new IssueLabelStore.
+func NewIssueLabelStore(db *sqlx.DB) *IssueLabelStore {
+	return &IssueLabelStore{
+		db: db,
+	}
+}
+
+// IssueLabelStore implements store.IssueLabelStore backed by a relational database.
+type IssueLabelStore struct {
+	db *sqlx.DB
+}
+
+type issueLabel struct {
+	IssueID   int64 `db:"issue_label_issue_id"`
+	LabelID   int64 `db:"issue_label_label_id"`
+	Created   int64 `db:"issue_label_created"`
+	CreatedBy int64 `db:"issue_label_created_by"`
+}
+
+type issueLabelInfo struct {
+	IssueID int64  `db:"issue_label_issue_id"`
+	ID      int64  `db:"label_id"`
+	Key     string `db:"label_key"`
+	Value   string `db:"label_value"`
+	Color   string `db:"label_color"`
+}
+
+// Assign assigns the label to the issue.
+func (s *IssueLabelStore) Assign(ctx context.Context, assignment *types.IssueLabel) error {
+	const sqlQuery = `
+		INSERT INTO issue_labels (
+			 issue_label_issue_id
+			,issue_label_label_id
+			,issue_label_created
+			,issue_label_created_by
+		) values (
+			 :issue_label_issue_id
+			,:issue_label_label_id
+			,:issue_label_created
+			,:issue_label_created_by
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &issueLabel{
+		IssueID:   assignment.IssueID,
+		LabelID:   assignment.LabelID,
+		Created:   assignment.Created,
+		CreatedBy: assignment.CreatedBy,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind issue label")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert issue label query failed")
+	}
+
+	return nil
+}
+
+// Unassign removes the label from the issue.
+func (s *IssueLabelStore) Unassign(ctx context.Context, issueID, labelID int64) error {
+	const sqlQuery = `
+		DELETE FROM issue_labels
+		WHERE issue_label_issue_id = $1 AND issue_label_label_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, issueID, labelID)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete issue label query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted issue labels")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListInfo returns the labels assigned to the provided issues, mapped by the issue id.
+func (s *IssueLabelStore) ListInfo(
+	ctx context.Context,
+	issueIDs []int64,
+) (map[int64][]*types.LabelInfo, error) {
+	stmt := database.Builder.
+		Select(`
+			 issue_label_issue_id
+			,label_id
+			,label_key
+			,label_value
+			,label_color`).
+		From("issue_labels").
+		InnerJoin("labels ON label_id = issue_label_label_id").
+		Where(squirrel.Eq{"issue_label_issue_id": issueIDs}).
+		OrderBy("LOWER(label_key)", "LOWER(label_value)")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list issue labels query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*issueLabelInfo
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list issue labels query")
+	}
+
+	result := make(map[int64][]*types.LabelInfo, len(issueIDs))
+	for _, info := range dst {
+		result[info.IssueID] = append(result[info.IssueID], &types.LabelInfo{
+			ID:    info.ID,
+			Key:   info.Key,
+			Value: info.Value,
+			Color: info.Color,
+		})
+	}
+
+	return result, nil
+}
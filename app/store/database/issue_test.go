@@ -0,0 +1,564 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+func createIssue(
+	t *testing.T,
+	ctx context.Context,
+	issueStore *database.IssueStore,
+	repoID int64,
+	number int64,
+	title string,
+) *types.Issue {
+	t.Helper()
+
+	issue := &types.Issue{
+		Number:    number,
+		RepoID:    repoID,
+		CreatedBy: userID,
+		State:     enum.IssueStateOpen,
+		Title:     title,
+	}
+	if err := issueStore.Create(ctx, issue); err != nil {
+		t.Fatalf("failed to create issue %d: %v", number, err)
+	}
+
+	return issue
+}
+
+func issueNumbers(issues []*types.Issue) []int64 {
+	numbers := make([]int64, len(issues))
+	for i, issue := range issues {
+		numbers[i] = issue.Number
+	}
+	return numbers
+}
+
+func TestIssueStore_Numbers(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(t, &ctx, repoStore, 1, 1, 0)
+	createRepo(t, &ctx, repoStore, 2, 1, 0)
+
+	issueStore := database.NewIssueStore(db, cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)))
+
+	createIssue(t, ctx, issueStore, 1, 1, "first")
+
+	// the same number can be used in another repository.
+	createIssue(t, ctx, issueStore, 2, 1, "other repo")
+
+	err := issueStore.Create(ctx, &types.Issue{
+		Number:    1,
+		RepoID:    1,
+		CreatedBy: userID,
+		State:     enum.IssueStateOpen,
+		Title:     "duplicate",
+	})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrDuplicate)
+	}
+
+	issue, err := issueStore.FindByNumber(ctx, 2, 1)
+	if err != nil {
+		t.Fatalf("failed to find issue by number: %v", err)
+	}
+	if issue.Title != "other repo" || issue.Author.ID != userID {
+		t.Errorf("got=%s by %d want=%s by %d", issue.Title, issue.Author.ID, "other repo", userID)
+	}
+
+	_, err = issueStore.FindByNumber(ctx, 1, 2)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrResourceNotFound)
+	}
+}
+
+func TestIssueStore_State(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	createUser(t, &ctx, principalStore, 2)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(t, &ctx, repoStore, 1, 1, 0)
+
+	issueStore := database.NewIssueStore(db, cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)))
+
+	issue := createIssue(t, ctx, issueStore, 1, 1, "crash")
+
+	closerID := int64(2)
+	closed := int64(1000)
+	issue, err := issueStore.UpdateOptLock(ctx, issue, func(issue *types.Issue) error {
+		issue.State = enum.IssueStateClosed
+		issue.Closed = &closed
+		issue.ClosedBy = &closerID
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to close issue: %v", err)
+	}
+
+	found, err := issueStore.Find(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to find issue: %v", err)
+	}
+	if found.State != enum.IssueStateClosed || found.Version != 1 || found.Closer == nil || found.Closer.ID != closerID {
+		t.Errorf("got state=%s version=%d closer=%v, want closed by %d", found.State, found.Version,
+			found.Closer, closerID)
+	}
+
+	// an update of an outdated issue is retried with the latest version.
+	outdated := *issue
+	outdated.Version = 0
+	reopened, err := issueStore.UpdateOptLock(ctx, &outdated, func(issue *types.Issue) error {
+		issue.State = enum.IssueStateOpen
+		issue.Closed = nil
+		issue.ClosedBy = nil
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to reopen issue: %v", err)
+	}
+	if reopened.State != enum.IssueStateOpen || reopened.Version != 2 || reopened.Closer != nil {
+		t.Errorf("got state=%s version=%d closer=%v, want open", reopened.State, reopened.Version, reopened.Closer)
+	}
+
+	issue, err = issueStore.UpdateActivitySeq(ctx, reopened)
+	if err != nil {
+		t.Fatalf("failed to update activity sequence: %v", err)
+	}
+	if issue.ActivitySeq != 1 {
+		t.Errorf("activity seq: got=%d want=1", issue.ActivitySeq)
+	}
+}
+
+func TestIssueStore_List(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	createUser(t, &ctx, principalStore, 2)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(t, &ctx, repoStore, 1, 1, 0)
+	createRepo(t, &ctx, repoStore, 2, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	issueStore := database.NewIssueStore(db, pCache)
+	assigneeStore := database.NewIssueAssigneeStore(db, pCache)
+	issueLabelStore := database.NewIssueLabelStore(db)
+	labelStore := database.NewLabelStore(db)
+	milestoneStore := database.NewMilestoneStore(db)
+
+	milestone := &types.Milestone{RepoID: 1, Title: "v1", State: enum.MilestoneStateOpen, CreatedBy: userID}
+	if err := milestoneStore.Create(ctx, milestone); err != nil {
+		t.Fatalf("failed to create milestone: %v", err)
+	}
+
+	bug := &types.Label{SpaceID: 1, Key: "bug", Color: "#ff0000", CreatedBy: userID}
+	high := &types.Label{SpaceID: 1, Key: "priority", Value: "high", Color: "#00ff00", CreatedBy: userID}
+	for _, lbl := range []*types.Label{bug, high} {
+		if err := labelStore.Create(ctx, lbl); err != nil {
+			t.Fatalf("failed to create label: %v", err)
+		}
+	}
+
+	crash := createIssue(t, ctx, issueStore, 1, 1, "Crash on start")
+	typo := createIssue(t, ctx, issueStore, 1, 3, "Typo in docs")
+	leak := createIssue(t, ctx, issueStore, 1, 4, "Memory leak")
+	createIssue(t, ctx, issueStore, 2, 1, "Crash in other repo")
+
+	if _, err := issueStore.UpdateOptLock(ctx, typo, func(issue *types.Issue) error {
+		issue.State = enum.IssueStateClosed
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to close issue: %v", err)
+	}
+
+	if _, err := issueStore.UpdateOptLock(ctx, leak, func(issue *types.Issue) error {
+		issue.MilestoneID = &milestone.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to set milestone: %v", err)
+	}
+
+	for _, a := range []*types.IssueAssignee{
+		{IssueID: crash.ID, PrincipalID: 2, CreatedBy: userID},
+		{IssueID: leak.ID, PrincipalID: 2, CreatedBy: userID},
+		{IssueID: leak.ID, PrincipalID: 1, CreatedBy: userID},
+	} {
+		if err := assigneeStore.Assign(ctx, a); err != nil {
+			t.Fatalf("failed to assign issue: %v", err)
+		}
+	}
+
+	for _, l := range []*types.IssueLabel{
+		{IssueID: crash.ID, LabelID: bug.ID, CreatedBy: userID},
+		{IssueID: crash.ID, LabelID: high.ID, CreatedBy: userID},
+		{IssueID: leak.ID, LabelID: bug.ID, CreatedBy: userID},
+	} {
+		if err := issueLabelStore.Assign(ctx, l); err != nil {
+			t.Fatalf("failed to assign label: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter types.IssueFilter
+		want   []int64
+	}{
+		{
+			name: "all issues of the repo",
+			want: []int64{1, 3, 4},
+		},
+		{
+			name:   "open issues",
+			filter: types.IssueFilter{States: []enum.IssueState{enum.IssueStateOpen}},
+			want:   []int64{1, 4},
+		},
+		{
+			name:   "closed issues",
+			filter: types.IssueFilter{States: []enum.IssueState{enum.IssueStateClosed}},
+			want:   []int64{3},
+		},
+		{
+			name:   "open and closed issues",
+			filter: types.IssueFilter{States: []enum.IssueState{enum.IssueStateOpen, enum.IssueStateClosed}},
+			want:   []int64{1, 3, 4},
+		},
+		{
+			name:   "query",
+			filter: types.IssueFilter{Query: "CRASH"},
+			want:   []int64{1},
+		},
+		{
+			name:   "milestone",
+			filter: types.IssueFilter{MilestoneID: milestone.ID},
+			want:   []int64{4},
+		},
+		{
+			name:   "assignee",
+			filter: types.IssueFilter{AssigneeID: 2},
+			want:   []int64{1, 4},
+		},
+		{
+			name:   "label",
+			filter: types.IssueFilter{LabelIDs: []int64{bug.ID}},
+			want:   []int64{1, 4},
+		},
+		{
+			name:   "all labels are required",
+			filter: types.IssueFilter{LabelIDs: []int64{bug.ID, high.ID}},
+			want:   []int64{1},
+		},
+		{
+			name: "combined filters",
+			filter: types.IssueFilter{
+				States:     []enum.IssueState{enum.IssueStateOpen},
+				AssigneeID: 1,
+				LabelIDs:   []int64{bug.ID},
+			},
+			want: []int64{4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := test.filter
+			filter.RepoID = 1
+			filter.Page = 1
+			filter.Size = 10
+			filter.Sort = enum.IssueSortNumber
+			filter.Order = enum.OrderAsc
+
+			list, err := issueStore.List(ctx, &filter)
+			if err != nil {
+				t.Fatalf("failed to list issues: %v", err)
+			}
+
+			count, err := issueStore.Count(ctx, &filter)
+			if err != nil {
+				t.Fatalf("failed to count issues: %v", err)
+			}
+
+			if got := issueNumbers(list); !slices.Equal(got, test.want) {
+				t.Errorf("got=%v want=%v", got, test.want)
+			}
+			if count != int64(len(test.want)) {
+				t.Errorf("count: got=%d want=%d", count, len(test.want))
+			}
+		})
+	}
+
+	open, err := issueStore.ListOpenByNumbers(ctx, 1, []int64{1, 3, 5})
+	if err != nil {
+		t.Fatalf("failed to list open issues by numbers: %v", err)
+	}
+	if got := issueNumbers(open); !slices.Equal(got, []int64{1}) {
+		t.Errorf("open by numbers: got=%v want=%v", got, []int64{1})
+	}
+}
+
+func TestIssueAssigneeStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	createUser(t, &ctx, principalStore, 2)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(t, &ctx, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	issueStore := database.NewIssueStore(db, pCache)
+	assigneeStore := database.NewIssueAssigneeStore(db, pCache)
+
+	first := createIssue(t, ctx, issueStore, 1, 1, "first")
+	second := createIssue(t, ctx, issueStore, 1, 2, "second")
+
+	for _, a := range []*types.IssueAssignee{
+		{IssueID: first.ID, PrincipalID: 2, Created: 1, CreatedBy: userID},
+		{IssueID: first.ID, PrincipalID: 1, Created: 2, CreatedBy: userID},
+	} {
+		if err := assigneeStore.Assign(ctx, a); err != nil {
+			t.Fatalf("failed to assign issue: %v", err)
+		}
+	}
+
+	err := assigneeStore.Assign(ctx, &types.IssueAssignee{IssueID: first.ID, PrincipalID: 2, CreatedBy: userID})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrDuplicate)
+	}
+
+	assignees, err := assigneeStore.ListInfo(ctx, []int64{first.ID, second.ID})
+	if err != nil {
+		t.Fatalf("failed to list assignees: %v", err)
+	}
+	if len(assignees[first.ID]) != 2 || assignees[first.ID][0].ID != 2 || assignees[first.ID][1].ID != 1 {
+		t.Errorf("got=%v want assignees 2 and 1 in order of assignment", assignees[first.ID])
+	}
+	if len(assignees[second.ID]) != 0 {
+		t.Errorf("got=%v want no assignees", assignees[second.ID])
+	}
+
+	if err = assigneeStore.Unassign(ctx, first.ID, 2); err != nil {
+		t.Fatalf("failed to unassign issue: %v", err)
+	}
+
+	err = assigneeStore.Unassign(ctx, first.ID, 2)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrResourceNotFound)
+	}
+}
+
+func TestIssueLabelStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(t, &ctx, repoStore, 1, 1, 0)
+
+	issueStore := database.NewIssueStore(db, cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)))
+	issueLabelStore := database.NewIssueLabelStore(db)
+	labelStore := database.NewLabelStore(db)
+
+	issue := createIssue(t, ctx, issueStore, 1, 1, "first")
+
+	bug := &types.Label{SpaceID: 1, Key: "bug", Color: "#ff0000", CreatedBy: userID}
+	area := &types.Label{SpaceID: 1, Key: "Area", Value: "ui", Color: "#0000ff", CreatedBy: userID}
+	for _, lbl := range []*types.Label{bug, area} {
+		if err := labelStore.Create(ctx, lbl); err != nil {
+			t.Fatalf("failed to create label: %v", err)
+		}
+		if err := issueLabelStore.Assign(ctx, &types.IssueLabel{IssueID: issue.ID, LabelID: lbl.ID}); err != nil {
+			t.Fatalf("failed to assign label: %v", err)
+		}
+	}
+
+	err := issueLabelStore.Assign(ctx, &types.IssueLabel{IssueID: issue.ID, LabelID: bug.ID})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrDuplicate)
+	}
+
+	labels, err := issueLabelStore.ListInfo(ctx, []int64{issue.ID})
+	if err != nil {
+		t.Fatalf("failed to list issue labels: %v", err)
+	}
+	if len(labels[issue.ID]) != 2 || labels[issue.ID][0].ID != area.ID || labels[issue.ID][0].Value != "ui" ||
+		labels[issue.ID][1].ID != bug.ID {
+		t.Errorf("got=%v want labels ordered by key", labels[issue.ID])
+	}
+
+	if err = issueLabelStore.Unassign(ctx, issue.ID, bug.ID); err != nil {
+		t.Fatalf("failed to unassign label: %v", err)
+	}
+
+	err = issueLabelStore.Unassign(ctx, issue.ID, bug.ID)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrResourceNotFound)
+	}
+}
+
+func TestMilestoneStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(t, &ctx, repoStore, 1, 1, 0)
+	createRepo(t, &ctx, repoStore, 2, 1, 0)
+
+	milestoneStore := database.NewMilestoneStore(db)
+
+	dueDate := int64(5000)
+	milestones := []*types.Milestone{
+		{RepoID: 1, Title: "v1", State: enum.MilestoneStateClosed, Created: 1},
+		{RepoID: 1, Title: "v2", State: enum.MilestoneStateOpen, DueDate: &dueDate, Created: 2},
+		{RepoID: 1, Title: "v3", State: enum.MilestoneStateOpen, Created: 3},
+		{RepoID: 2, Title: "v1", State: enum.MilestoneStateOpen, Created: 4},
+	}
+	for _, m := range milestones {
+		m.CreatedBy = userID
+		if err := milestoneStore.Create(ctx, m); err != nil {
+			t.Fatalf("failed to create milestone: %v", err)
+		}
+	}
+
+	err := milestoneStore.Create(ctx, &types.Milestone{RepoID: 1, Title: "v1", State: enum.MilestoneStateOpen})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrDuplicate)
+	}
+
+	found, err := milestoneStore.Find(ctx, milestones[1].ID)
+	if err != nil {
+		t.Fatalf("failed to find milestone: %v", err)
+	}
+	if found.Title != "v2" || found.DueDate == nil || *found.DueDate != dueDate {
+		t.Errorf("got=%+v want=%+v", found, milestones[1])
+	}
+
+	tests := []struct {
+		name   string
+		filter types.MilestoneFilter
+		want   []string
+	}{
+		{
+			name: "all milestones of the repo, newest first",
+			want: []string{"v3", "v2", "v1"},
+		},
+		{
+			name:   "open milestones",
+			filter: types.MilestoneFilter{States: []enum.MilestoneState{enum.MilestoneStateOpen}},
+			want:   []string{"v3", "v2"},
+		},
+		{
+			name:   "query",
+			filter: types.MilestoneFilter{ListQueryFilter: types.ListQueryFilter{Query: "V1"}},
+			want:   []string{"v1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := test.filter
+			filter.Page = 1
+			filter.Size = 10
+
+			list, err := milestoneStore.List(ctx, 1, &filter)
+			if err != nil {
+				t.Fatalf("failed to list milestones: %v", err)
+			}
+
+			count, err := milestoneStore.Count(ctx, 1, &filter)
+			if err != nil {
+				t.Fatalf("failed to count milestones: %v", err)
+			}
+
+			titles := make([]string, len(list))
+			for i, m := range list {
+				titles[i] = m.Title
+			}
+			if !slices.Equal(titles, test.want) {
+				t.Errorf("got=%v want=%v", titles, test.want)
+			}
+			if count != int64(len(test.want)) {
+				t.Errorf("count: got=%d want=%d", count, len(test.want))
+			}
+		})
+	}
+
+	found.State = enum.MilestoneStateClosed
+	if err = milestoneStore.Update(ctx, found); err != nil {
+		t.Fatalf("failed to update milestone: %v", err)
+	}
+
+	if err = milestoneStore.Delete(ctx, milestones[0].ID); err != nil {
+		t.Fatalf("failed to delete milestone: %v", err)
+	}
+
+	err = milestoneStore.Delete(ctx, milestones[0].ID)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	count, err := milestoneStore.Count(ctx, 1, &types.MilestoneFilter{
+		States: []enum.MilestoneState{enum.MilestoneStateOpen},
+	})
+	if err != nil {
+		t.Fatalf("failed to count milestones: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("open milestones: got=%d want=1", count)
+	}
+}
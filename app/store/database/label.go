@@ -0,0 +1,290 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LabelStore = (*LabelStore)(nil)
+
+// NewLabelStore returns a new LabelStore.
+func NewLabelStore(db *sqlx.DB) *LabelStore {
+	return &LabelStore{
+		db: db,
+	}
+}
+
+// LabelStore implements store.LabelStore backed by a relational database.
+type LabelStore struct {
+	db *sqlx.DB
+}
+
+type label struct {
+	ID          int64  `db:"label_id"`
+	SpaceID     int64  `db:"label_space_id"`
+	Key         string `db:"label_key"`
+	Value       string `db:"label_value"`
+	Color       string `db:"label_color"`
+	Description string `db:"label_description"`
+	Created     int64  `db:"label_created"`
+	Updated     int64  `db:"label_updated"`
+	CreatedBy   int64  `db:"label_created_by"`
+}
+
+const (
+	labelColumns = `
+		 label_id
+		,label_space_id
+		,label_key
+		,label_value
+		,label_color
+		,label_description
+		,label_created
+		,label_updated
+		,label_created_by`
+)
+
+// Find finds the label by id.
+func (s *LabelStore) Find(ctx context.Context, id int64) (*types.Label, error) {
+	stmt := database.Builder.
+		Select(labelColumns).
+		From("labels").
+		Where("label_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find label query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &label{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find label")
+	}
+
+	return mapToLabel(dst), nil
+}
+
+// Create creates a new label.
+func (s *LabelStore) Create(ctx context.Context, lbl *types.Label) error {
+	const sqlQuery = `
+		INSERT INTO labels (
+			 label_space_id
+			,label_key
+			,label_value
+			,label_color
+			,label_description
+			,label_created
+			,label_updated
+			,label_created_by
+		) values (
+			 :label_space_id
+			,:label_key
+			,:label_value
+			,:label_color
+			,:label_description
+			,:label_created
+			,:label_updated
+			,:label_created_by
+		) RETURNING label_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalLabel(lbl))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind label")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&lbl.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert label query failed")
+	}
+
+	return nil
+}
+
+// Update updates the label.
+func (s *LabelStore) Update(ctx context.Context, lbl *types.Label) error {
+	const sqlQuery = `
+		UPDATE labels
+		SET
+			 label_key = :label_key
+			,label_value = :label_value
+			,label_color = :label_color
+			,label_description = :label_description
+			,label_updated = :label_updated
+		WHERE label_id = :label_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalLabel(lbl))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind label")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update label")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated labels")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the label with the given id.
+func (s *LabelStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM labels
+		WHERE label_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete label query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted labels")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns the labels defined in any of the provided spaces.
+func (s *LabelStore) List(
+	ctx context.Context,
+	spaceIDs []int64,
+	filter *types.LabelFilter,
+) ([]*types.Label, error) {
+	stmt := database.Builder.
+		Select(labelColumns).
+		From("labels").
+		Where(squirrel.Eq{"label_space_id": spaceIDs}).
+		OrderBy("LOWER(label_key)", "LOWER(label_value)")
+
+	stmt = applyLabelFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list labels query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*label
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list labels query")
+	}
+
+	labels := make([]*types.Label, len(dst))
+	for i, lbl := range dst {
+		labels[i] = mapToLabel(lbl)
+	}
+
+	return labels, nil
+}
+
+// Count returns the number of labels defined in any of the provided spaces.
+func (s *LabelStore) Count(
+	ctx context.Context,
+	spaceIDs []int64,
+	filter *types.LabelFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("labels").
+		Where(squirrel.Eq{"label_space_id": spaceIDs})
+
+	stmt = applyLabelFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count labels query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count labels query")
+	}
+
+	return count, nil
+}
+
+func applyLabelFilter(stmt squirrel.SelectBuilder, filter *types.LabelFilter) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(label_key) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	return stmt
+}
+
+func mapToInternalLabel(lbl *types.Label) *label {
+	return &label{
+		ID:          lbl.ID,
+		SpaceID:     lbl.SpaceID,
+		Key:         lbl.Key,
+		Value:       lbl.Value,
+		Color:       lbl.Color,
+		Description: lbl.Description,
+		Created:     lbl.Created,
+		Updated:     lbl.Updated,
+		CreatedBy:   lbl.CreatedBy,
+	}
+}
+
+func mapToLabel(lbl *label) *types.Label {
+	return &types.Label{
+		ID:          lbl.ID,
+		SpaceID:     lbl.SpaceID,
+		Key:         lbl.Key,
+		Value:       lbl.Value,
+		Color:       lbl.Color,
+		Description: lbl.Description,
+		Created:     lbl.Created,
+		Updated:     lbl.Updated,
+		CreatedBy:   lbl.CreatedBy,
+	}
+}
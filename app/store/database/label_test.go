@@ -0,0 +1,249 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+func createLabel(
+	t *testing.T,
+	ctx context.Context,
+	labelStore *database.LabelStore,
+	spaceID int64,
+	key, value string,
+) *types.Label {
+	t.Helper()
+
+	lbl := &types.Label{SpaceID: spaceID, Key: key, Value: value, Color: "#ff0000", CreatedBy: userID}
+	if err := labelStore.Create(ctx, lbl); err != nil {
+		t.Fatalf("failed to create label %s: %v", lbl, err)
+	}
+
+	return lbl
+}
+
+func createPullReq(
+	t *testing.T,
+	ctx context.Context,
+	pullReqStore *database.PullReqStore,
+	repoID int64,
+	number int64,
+) *types.PullReq {
+	t.Helper()
+
+	pr := &types.PullReq{
+		Number:           number,
+		CreatedBy:        userID,
+		State:            enum.PullReqStateOpen,
+		Title:            "pull request",
+		SourceRepoID:     repoID,
+		SourceBranch:     fmt.Sprintf("feature-%d", number),
+		SourceSHA:        "1234567890abcdef1234567890abcdef12345678",
+		TargetRepoID:     repoID,
+		TargetBranch:     "main",
+		MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+	}
+	if err := pullReqStore.Create(ctx, pr); err != nil {
+		t.Fatalf("failed to create pull request %d: %v", number, err)
+	}
+
+	return pr
+}
+
+func TestLabelStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 2, 1)
+
+	labelStore := database.NewLabelStore(db)
+
+	high := createLabel(t, ctx, labelStore, 1, "Priority", "high")
+
+	// a scoped label key can have many values, but each value is unique in the space.
+	low := createLabel(t, ctx, labelStore, 1, "priority", "low")
+
+	err := labelStore.Create(ctx, &types.Label{
+		SpaceID: 1, Key: "PRIORITY", Value: "High", Color: "#00ff00", CreatedBy: userID,
+	})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrDuplicate)
+	}
+
+	// the child space can define the same label.
+	child := createLabel(t, ctx, labelStore, 2, "priority", "high")
+	bug := createLabel(t, ctx, labelStore, 2, "bug", "")
+
+	low.Value = "HIGH"
+	if err = labelStore.Update(ctx, low); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrDuplicate)
+	}
+
+	tests := []struct {
+		name     string
+		spaceIDs []int64
+		query    string
+		want     []int64
+	}{
+		{name: "parent space", spaceIDs: []int64{1}, want: []int64{high.ID, low.ID}},
+		{name: "child space", spaceIDs: []int64{2}, want: []int64{child.ID, bug.ID}},
+		{name: "with ancestors", spaceIDs: []int64{2, 1}, want: []int64{high.ID, low.ID, child.ID, bug.ID}},
+		{name: "query by key", spaceIDs: []int64{2, 1}, query: "PRIO", want: []int64{high.ID, low.ID, child.ID}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := &types.LabelFilter{ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: 1, Size: 10},
+				Query:      test.query,
+			}}
+
+			list, err := labelStore.List(ctx, test.spaceIDs, filter)
+			if err != nil {
+				t.Fatalf("failed to list labels: %v", err)
+			}
+
+			got := make([]int64, len(list))
+			for i, lbl := range list {
+				got[i] = lbl.ID
+			}
+
+			slices.Sort(got)
+			slices.Sort(test.want)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("got=%v want=%v", got, test.want)
+			}
+
+			count, err := labelStore.Count(ctx, test.spaceIDs, filter)
+			if err != nil {
+				t.Fatalf("failed to count labels: %v", err)
+			}
+			if count != int64(len(test.want)) {
+				t.Errorf("count: got=%d want=%d", count, len(test.want))
+			}
+		})
+	}
+}
+
+func TestPullReqStore_LabelIDs(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(t, &ctx, repoStore, 1, 1, 0)
+	createRepo(t, &ctx, repoStore, 2, 1, 0)
+
+	pullReqStore := database.NewPullReqStore(db, cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)))
+	pullReqLabelStore := database.NewPullReqLabelStore(db)
+	labelStore := database.NewLabelStore(db)
+
+	bug := createLabel(t, ctx, labelStore, 1, "bug", "")
+	ui := createLabel(t, ctx, labelStore, 1, "area", "ui")
+	docs := createLabel(t, ctx, labelStore, 1, "docs", "")
+
+	pr1 := createPullReq(t, ctx, pullReqStore, 1, 1)
+	pr2 := createPullReq(t, ctx, pullReqStore, 1, 2)
+	createPullReq(t, ctx, pullReqStore, 1, 3)
+	other := createPullReq(t, ctx, pullReqStore, 2, 1)
+
+	for _, assignment := range []struct {
+		pr  *types.PullReq
+		lbl *types.Label
+	}{
+		{pr: pr1, lbl: bug},
+		{pr: pr1, lbl: ui},
+		{pr: pr2, lbl: bug},
+		{pr: other, lbl: bug},
+		{pr: other, lbl: ui},
+	} {
+		err := pullReqLabelStore.Assign(ctx, &types.PullReqLabel{
+			PullReqID: assignment.pr.ID,
+			LabelID:   assignment.lbl.ID,
+			CreatedBy: userID,
+		})
+		if err != nil {
+			t.Fatalf("failed to assign label: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		labelIDs []int64
+		want     []int64
+	}{
+		{name: "no labels", want: []int64{1, 2, 3}},
+		{name: "one label", labelIDs: []int64{bug.ID}, want: []int64{1, 2}},
+		{name: "all labels required", labelIDs: []int64{bug.ID, ui.ID}, want: []int64{1}},
+		{name: "unassigned label", labelIDs: []int64{docs.ID}, want: []int64{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := &types.PullReqFilter{
+				Page:         1,
+				Size:         10,
+				TargetRepoID: 1,
+				Sort:         enum.PullReqSortNumber,
+				Order:        enum.OrderAsc,
+				LabelIDs:     test.labelIDs,
+			}
+
+			list, err := pullReqStore.List(ctx, filter)
+			if err != nil {
+				t.Fatalf("failed to list pull requests: %v", err)
+			}
+
+			got := make([]int64, len(list))
+			for i, pr := range list {
+				got[i] = pr.Number
+			}
+
+			if !slices.Equal(got, test.want) {
+				t.Errorf("got=%v want=%v", got, test.want)
+			}
+
+			count, err := pullReqStore.Count(ctx, filter)
+			if err != nil {
+				t.Fatalf("failed to count pull requests: %v", err)
+			}
+			if count != int64(len(test.want)) {
+				t.Errorf("count: got=%d want=%d", count, len(test.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LFSLockStore = (*LFSLockStore)(nil)
+
+// NewLFSLockStore returns a new LFSLockStore.
+func NewLFSLockStore(db *sqlx.DB) *LFSLockStore {
+	return &LFSLockStore{
+		db: db,
+	}
+}
+
+// LFSLockStore implements store.LFSLockStore backed by a relational database.
+type LFSLockStore struct {
+	db *sqlx.DB
+}
+
+type lfsLock struct {
+	ID        int64  `db:"lfs_lock_id"`
+	RepoID    int64  `db:"lfs_lock_repo_id"`
+	Path      string `db:"lfs_lock_path"`
+	Ref       string `db:"lfs_lock_ref"`
+	Created   int64  `db:"lfs_lock_created"`
+	CreatedBy int64  `db:"lfs_lock_created_by"`
+}
+
+const (
+	lfsLockColumns = `
+		 lfs_lock_id
+		,lfs_lock_repo_id
+		,lfs_lock_path
+		,lfs_lock_ref
+		,lfs_lock_created
+		,lfs_lock_created_by`
+)
+
+// Find finds an LFS lock of a repository by its ID.
+func (s *LFSLockStore) Find(ctx context.Context, repoID, id int64) (*types.LFSLock, error) {
+	stmt := database.Builder.
+		Select(lfsLockColumns).
+		From("lfs_locks").
+		Where("lfs_lock_repo_id = ?", repoID).
+		Where("lfs_lock_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find LFS lock query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsLock{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find LFS lock")
+	}
+
+	return mapToLFSLock(dst), nil
+}
+
+// Create creates a new LFS lock.
+func (s *LFSLockStore) Create(ctx context.Context, lock *types.LFSLock) error {
+	const sqlQuery = `
+		INSERT INTO lfs_locks (
+			 lfs_lock_repo_id
+			,lfs_lock_path
+			,lfs_lock_ref
+			,lfs_lock_created
+			,lfs_lock_created_by
+		) values (
+			 :lfs_lock_repo_id
+			,:lfs_lock_path
+			,:lfs_lock_ref
+			,:lfs_lock_created
+			,:lfs_lock_created_by
+		) RETURNING lfs_lock_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalLFSLock(lock))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind LFS lock")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&lock.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert LFS lock query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes an LFS lock of a repository.
+func (s *LFSLockStore) Delete(ctx context.Context, repoID, id int64) error {
+	const sqlQuery = `
+		DELETE FROM lfs_locks
+		WHERE lfs_lock_repo_id = $1 AND lfs_lock_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, repoID, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete LFS lock query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted LFS locks")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Count returns the number of LFS locks of a repository.
+func (s *LFSLockStore) Count(ctx context.Context, repoID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("lfs_locks").
+		Where("lfs_lock_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count LFS locks query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count LFS locks query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of LFS locks of a repository that matches the provided filter.
+func (s *LFSLockStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.LFSLockFilter,
+) ([]*types.LFSLock, error) {
+	stmt := database.Builder.
+		Select(lfsLockColumns).
+		From("lfs_locks").
+		Where("lfs_lock_repo_id = ?", repoID).
+		OrderBy("lfs_lock_id")
+
+	if filter.Path != "" {
+		stmt = stmt.Where("lfs_lock_path = ?", filter.Path)
+	}
+
+	if filter.CreatedBy != 0 {
+		stmt = stmt.Where("lfs_lock_created_by = ?", filter.CreatedBy)
+	}
+
+	if filter.AfterID > 0 {
+		stmt = stmt.Where("lfs_lock_id > ?", filter.AfterID)
+	}
+
+	if filter.Limit > 0 {
+		stmt = stmt.Limit(uint64(filter.Limit))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list LFS locks query to sql: %w", err)
+	}
+
+	return s.list(ctx, sql, args)
+}
+
+// ListByPaths returns all LFS locks of a repository for the provided paths.
+func (s *LFSLockStore) ListByPaths(ctx context.Context, repoID int64, paths []string) ([]*types.LFSLock, error) {
+	stmt := database.Builder.
+		Select(lfsLockColumns).
+		From("lfs_locks").
+		Where("lfs_lock_repo_id = ?", repoID).
+		Where(squirrel.Eq{"lfs_lock_path": paths}).
+		OrderBy("lfs_lock_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list LFS locks by paths query to sql: %w", err)
+	}
+
+	return s.list(ctx, sql, args)
+}
+
+func (s *LFSLockStore) list(ctx context.Context, sql string, args []any) ([]*types.LFSLock, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*lfsLock
+	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list LFS locks query")
+	}
+
+	locks := make([]*types.LFSLock, len(dst))
+	for i, lock := range dst {
+		locks[i] = mapToLFSLock(lock)
+	}
+
+	return locks, nil
+}
+
+func mapToInternalLFSLock(lock *types.LFSLock) *lfsLock {
+	return &lfsLock{
+		ID:        lock.ID,
+		RepoID:    lock.RepoID,
+		Path:      lock.Path,
+		Ref:       lock.Ref,
+		Created:   lock.Created,
+		CreatedBy: lock.CreatedBy,
+	}
+}
+
+func mapToLFSLock(lock *lfsLock) *types.LFSLock {
+	return &types.LFSLock{
+		ID:        lock.ID,
+		RepoID:    lock.RepoID,
+		Path:      lock.Path,
+		Ref:       lock.Ref,
+		Created:   lock.Created,
+		CreatedBy: lock.CreatedBy,
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = (*MergeQueueStore)(nil)
+
+// NewMergeQueueStore returns a new MergeQueueStore.
+func NewMergeQueueStore(db *sqlx.DB) *MergeQueueStore {
+	return &MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	ID            int64                     `db:"mqe_id"`
+	RepoID        int64                     `db:"mqe_repo_id"`
+	PullReqID     int64                     `db:"mqe_pullreq_id"`
+	PullReqNumber int64                     `db:"mqe_pullreq_number"`
+	TargetBranch  string                    `db:"mqe_target_branch"`
+	Method        enum.MergeMethod          `db:"mqe_method"`
+	State         enum.MergeQueueEntryState `db:"mqe_state"`
+	SourceSHA     string                    `db:"mqe_source_sha"`
+	BaseSHA       string                    `db:"mqe_base_sha"`
+	MergeSHA      string                    `db:"mqe_merge_sha"`
+	CreatedBy     int64                     `db:"mqe_created_by"`
+	Created       int64                     `db:"mqe_created"`
+	Updated       int64                     `db:"mqe_updated"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 mqe_id
+		,mqe_repo_id
+		,mqe_pullreq_id
+		,mqe_pullreq_number
+		,mqe_target_branch
+		,mqe_method
+		,mqe_state
+		,mqe_source_sha
+		,mqe_base_sha
+		,mqe_merge_sha
+		,mqe_created_by
+		,mqe_created
+		,mqe_updated`
+)
+
+// Find finds a merge queue entry by its ID.
+func (s *MergeQueueStore) Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("mqe_id = ?", id)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByPullReqID finds the merge queue entry of a pull request.
+func (s *MergeQueueStore) FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("mqe_pullreq_id = ?", pullreqID)
+
+	return s.find(ctx, stmt)
+}
+
+func (s *MergeQueueStore) find(ctx context.Context, stmt squirrel.SelectBuilder) (*types.MergeQueueEntry, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find merge queue entry query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find merge queue entry")
+	}
+
+	return mapToMergeQueueEntry(dst), nil
+}
+
+// Create adds a new entry to the end of the merge queue.
+func (s *MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 mqe_repo_id
+			,mqe_pullreq_id
+			,mqe_pullreq_number
+			,mqe_target_branch
+			,mqe_method
+			,mqe_state
+			,mqe_source_sha
+			,mqe_base_sha
+			,mqe_merge_sha
+			,mqe_created_by
+			,mqe_created
+			,mqe_updated
+		) values (
+			 :mqe_repo_id
+			,:mqe_pullreq_id
+			,:mqe_pullreq_number
+			,:mqe_target_branch
+			,:mqe_method
+			,:mqe_state
+			,:mqe_source_sha
+			,:mqe_base_sha
+			,:mqe_merge_sha
+			,:mqe_created_by
+			,:mqe_created
+			,:mqe_updated
+		) RETURNING mqe_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind merge queue entry")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert merge queue entry query failed")
+	}
+
+	return nil
+}
+
+// Update updates the state and the speculative merge commit of a merge queue entry.
+func (s *MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 mqe_state = :mqe_state
+			,mqe_base_sha = :mqe_base_sha
+			,mqe_merge_sha = :mqe_merge_sha
+			,mqe_updated = :mqe_updated
+		WHERE mqe_id = :mqe_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind merge queue entry")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated merge queue entries")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the merge queue entry with the given id.
+func (s *MergeQueueStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM merge_queue_entries
+		WHERE mqe_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete merge queue entry query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted merge queue entries")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns the entries of the merge queue of the target branch in the order they were enqueued.
+func (s *MergeQueueStore) List(
+	ctx context.Context,
+	repoID int64,
+	targetBranch string,
+) ([]*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("mqe_repo_id = ?", repoID).
+		Where("mqe_target_branch = ?", targetBranch).
+		OrderBy("mqe_id")
+
+	entries, err := s.list(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entries[i].Position = i
+	}
+
+	return entries, nil
+}
+
+// ListByMergeSHA returns the merge queue entries of a repository with the provided merge commit.
+func (s *MergeQueueStore) ListByMergeSHA(
+	ctx context.Context,
+	repoID int64,
+	mergeSHA string,
+) ([]*types.MergeQueueEntry, error) {
+	stmt := database.Builder.
+		Select(mergeQueueEntryColumns).
+		From("merge_queue_entries").
+		Where("mqe_repo_id = ?", repoID).
+		Where("mqe_merge_sha = ?", mergeSHA).
+		OrderBy("mqe_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *MergeQueueStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.MergeQueueEntry, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list merge queue entries query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*mergeQueueEntry
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list merge queue entries query")
+	}
+
+	entries := make([]*types.MergeQueueEntry, len(dst))
+	for i, entry := range dst {
+		entries[i] = mapToMergeQueueEntry(entry)
+	}
+
+	return entries, nil
+}
+
+func mapToInternalMergeQueueEntry(entry *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		ID:            entry.ID,
+		RepoID:        entry.RepoID,
+		PullReqID:     entry.PullReqID,
+		PullReqNumber: entry.PullReqNumber,
+		TargetBranch:  entry.TargetBranch,
+		Method:        entry.Method,
+		State:         entry.State,
+		SourceSHA:     entry.SourceSHA,
+		BaseSHA:       entry.BaseSHA,
+		MergeSHA:      entry.MergeSHA,
+		CreatedBy:     entry.CreatedBy,
+		Created:       entry.Created,
+		Updated:       entry.Updated,
+	}
+}
+
+func mapToMergeQueueEntry(entry *mergeQueueEntry) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		ID:            entry.ID,
+		RepoID:        entry.RepoID,
+		PullReqID:     entry.PullReqID,
+		PullReqNumber: entry.PullReqNumber,
+		TargetBranch:  entry.TargetBranch,
+		Method:        entry.Method,
+		State:         entry.State,
+		SourceSHA:     entry.SourceSHA,
+		BaseSHA:       entry.BaseSHA,
+		MergeSHA:      entry.MergeSHA,
+		CreatedBy:     entry.CreatedBy,
+		Created:       entry.Created,
+		Updated:       entry.Updated,
+	}
+}
@@ -0,0 +1,37 @@
+CREATE TABLE repository_mirrors (
+ mirror_id SERIAL PRIMARY KEY
+,mirror_repo_id INTEGER NOT NULL
+,mirror_direction TEXT NOT NULL
+,mirror_remote_url TEXT NOT NULL
+,mirror_username TEXT NOT NULL
+,mirror_password TEXT NOT NULL
+,mirror_interval INTEGER NOT NULL
+,mirror_enabled BOOLEAN NOT NULL
+,mirror_created_by INTEGER NOT NULL
+,mirror_created BIGINT NOT NULL
+,mirror_updated BIGINT NOT NULL
+,mirror_last_sync BIGINT
+,mirror_last_sync_status TEXT NOT NULL
+,mirror_last_sync_error TEXT NOT NULL
+,mirror_next_sync BIGINT NOT NULL
+,CONSTRAINT fk_mirror_repo_id FOREIGN KEY (mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_mirror_created_by FOREIGN KEY (mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX repository_mirrors_repo_id
+    ON repository_mirrors(mirror_repo_id);
+
+-- a repository can only have a single pull mirror
+CREATE UNIQUE INDEX repository_mirrors_repo_id_pull
+    ON repository_mirrors(mirror_repo_id)
+    WHERE mirror_direction = 'pull';
+
+CREATE INDEX repository_mirrors_next_sync
+    ON repository_mirrors(mirror_next_sync)
+    WHERE mirror_enabled AND mirror_next_sync > 0;
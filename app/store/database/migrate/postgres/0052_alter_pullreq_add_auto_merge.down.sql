@@ -0,0 +1,6 @@
+ALTER TABLE pullreqs
+    DROP COLUMN pullreq_auto_merge_method,
+    DROP COLUMN pullreq_auto_merge_title,
+    DROP COLUMN pullreq_auto_merge_message,
+    DROP COLUMN pullreq_auto_merge_by,
+    DROP COLUMN pullreq_auto_merge_requested;
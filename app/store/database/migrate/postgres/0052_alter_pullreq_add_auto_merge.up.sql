@@ -0,0 +1,6 @@
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_auto_merge_method TEXT,
+    ADD COLUMN pullreq_auto_merge_title TEXT,
+    ADD COLUMN pullreq_auto_merge_message TEXT,
+    ADD COLUMN pullreq_auto_merge_by INTEGER,
+    ADD COLUMN pullreq_auto_merge_requested BIGINT;
@@ -0,0 +1,20 @@
+CREATE TABLE signing_keys (
+ signing_key_id SERIAL PRIMARY KEY
+,signing_key_principal_id INTEGER NOT NULL
+,signing_key_created BIGINT NOT NULL
+,signing_key_identifier TEXT NOT NULL
+,signing_key_type TEXT NOT NULL
+,signing_key_content TEXT NOT NULL
+,signing_key_fingerprint TEXT NOT NULL
+,CONSTRAINT fk_signing_key_principal_id FOREIGN KEY (signing_key_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX signing_keys_principal_id_identifier
+    ON signing_keys(signing_key_principal_id, LOWER(signing_key_identifier));
+
+-- a key can only be registered once as it identifies the signer of commits and tags
+CREATE UNIQUE INDEX signing_keys_fingerprint
+    ON signing_keys(signing_key_fingerprint);
@@ -0,0 +1,19 @@
+CREATE TABLE server_signing_keys (
+ server_signing_key_id SERIAL PRIMARY KEY
+,server_signing_key_space_id INTEGER
+,server_signing_key_created BIGINT NOT NULL
+,server_signing_key_updated BIGINT NOT NULL
+,server_signing_key_created_by INTEGER NOT NULL
+,server_signing_key_type TEXT NOT NULL
+,server_signing_key_public_key TEXT NOT NULL
+,server_signing_key_private_key TEXT NOT NULL
+,server_signing_key_fingerprint TEXT NOT NULL
+,CONSTRAINT fk_server_signing_key_space_id FOREIGN KEY (server_signing_key_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+-- there is at most one key per space, and one instance-level key (without space)
+CREATE UNIQUE INDEX server_signing_keys_space_id
+    ON server_signing_keys(COALESCE(server_signing_key_space_id, 0));
@@ -0,0 +1,5 @@
+DROP TABLE issue_activities;
+DROP TABLE issue_labels;
+DROP TABLE issue_assignees;
+DROP TABLE issues;
+DROP TABLE milestones;
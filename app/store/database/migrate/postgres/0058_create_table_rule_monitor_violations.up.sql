@@ -0,0 +1,20 @@
+CREATE TABLE rule_monitor_violations (
+ rmv_id SERIAL PRIMARY KEY
+,rmv_rule_id INTEGER NOT NULL
+,rmv_repo_id INTEGER NOT NULL
+,rmv_action TEXT NOT NULL
+,rmv_violations TEXT NOT NULL
+,rmv_created_by INTEGER NOT NULL
+,rmv_created BIGINT NOT NULL
+,CONSTRAINT fk_rmv_rule_id FOREIGN KEY (rmv_rule_id)
+    REFERENCES rules (rule_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_rmv_repo_id FOREIGN KEY (rmv_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX rule_monitor_violations_rule_id_repo_id_created
+    ON rule_monitor_violations(rmv_rule_id, rmv_repo_id, rmv_created);
@@ -0,0 +1,3 @@
+DROP TABLE secret_findings;
+DROP TABLE secret_scans;
+DROP TABLE secret_scan_settings;
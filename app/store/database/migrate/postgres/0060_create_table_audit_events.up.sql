@@ -0,0 +1,28 @@
+CREATE TABLE audit_events (
+ ae_id SERIAL PRIMARY KEY
+,ae_created BIGINT NOT NULL
+,ae_actor_id INTEGER NOT NULL
+,ae_action TEXT NOT NULL
+,ae_resource_type TEXT NOT NULL
+,ae_resource TEXT NOT NULL
+,ae_space_id INTEGER
+,ae_repo_id INTEGER
+,ae_before TEXT NOT NULL
+,ae_after TEXT NOT NULL
+,ae_client_ip TEXT NOT NULL
+,ae_user_agent TEXT NOT NULL
+);
+
+CREATE INDEX audit_events_created
+    ON audit_events(ae_created);
+
+CREATE INDEX audit_events_space_id_created
+    ON audit_events(ae_space_id, ae_created)
+    WHERE ae_space_id IS NOT NULL;
+
+CREATE INDEX audit_events_repo_id_created
+    ON audit_events(ae_repo_id, ae_created)
+    WHERE ae_repo_id IS NOT NULL;
+
+CREATE INDEX audit_events_actor_id_created
+    ON audit_events(ae_actor_id, ae_created);
@@ -0,0 +1 @@
+DROP TABLE lfs_locks;
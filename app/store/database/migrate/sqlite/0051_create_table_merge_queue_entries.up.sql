@@ -0,0 +1,37 @@
+CREATE TABLE merge_queue_entries (
+ mqe_id INTEGER PRIMARY KEY AUTOINCREMENT
+,mqe_repo_id INTEGER NOT NULL
+,mqe_pullreq_id INTEGER NOT NULL
+,mqe_pullreq_number INTEGER NOT NULL
+,mqe_target_branch TEXT NOT NULL
+,mqe_method TEXT NOT NULL
+,mqe_state TEXT NOT NULL
+,mqe_source_sha TEXT NOT NULL
+,mqe_base_sha TEXT NOT NULL
+,mqe_merge_sha TEXT NOT NULL
+,mqe_created_by INTEGER NOT NULL
+,mqe_created BIGINT NOT NULL
+,mqe_updated BIGINT NOT NULL
+,CONSTRAINT fk_mqe_repo_id FOREIGN KEY (mqe_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_mqe_pullreq_id FOREIGN KEY (mqe_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_mqe_created_by FOREIGN KEY (mqe_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+-- a pull request can be in the merge queue only once
+CREATE UNIQUE INDEX merge_queue_entries_pullreq_id
+    ON merge_queue_entries(mqe_pullreq_id);
+
+CREATE INDEX merge_queue_entries_repo_id_target_branch
+    ON merge_queue_entries(mqe_repo_id, mqe_target_branch);
+
+CREATE INDEX merge_queue_entries_repo_id_merge_sha
+    ON merge_queue_entries(mqe_repo_id, mqe_merge_sha);
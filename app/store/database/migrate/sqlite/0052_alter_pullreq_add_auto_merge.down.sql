@@ -0,0 +1,5 @@
+ALTER TABLE pullreqs DROP COLUMN pullreq_auto_merge_method;
+ALTER TABLE pullreqs DROP COLUMN pullreq_auto_merge_title;
+ALTER TABLE pullreqs DROP COLUMN pullreq_auto_merge_message;
+ALTER TABLE pullreqs DROP COLUMN pullreq_auto_merge_by;
+ALTER TABLE pullreqs DROP COLUMN pullreq_auto_merge_requested;
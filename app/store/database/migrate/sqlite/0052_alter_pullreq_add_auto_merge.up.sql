@@ -0,0 +1,5 @@
+ALTER TABLE pullreqs ADD COLUMN pullreq_auto_merge_method TEXT;
+ALTER TABLE pullreqs ADD COLUMN pullreq_auto_merge_title TEXT;
+ALTER TABLE pullreqs ADD COLUMN pullreq_auto_merge_message TEXT;
+ALTER TABLE pullreqs ADD COLUMN pullreq_auto_merge_by INTEGER;
+ALTER TABLE pullreqs ADD COLUMN pullreq_auto_merge_requested BIGINT;
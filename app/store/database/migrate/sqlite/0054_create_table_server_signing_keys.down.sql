@@ -0,0 +1 @@
+DROP TABLE server_signing_keys;
@@ -0,0 +1,75 @@
+CREATE TABLE secret_scan_settings (
+ sss_id INTEGER PRIMARY KEY AUTOINCREMENT
+,sss_space_id INTEGER
+,sss_repo_id INTEGER
+,sss_mode TEXT NOT NULL
+,sss_rules TEXT NOT NULL
+,sss_allowlist TEXT NOT NULL
+,sss_created_by INTEGER NOT NULL
+,sss_created BIGINT NOT NULL
+,sss_updated BIGINT NOT NULL
+,CONSTRAINT fk_sss_space_id FOREIGN KEY (sss_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_sss_repo_id FOREIGN KEY (sss_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX secret_scan_settings_space_id
+    ON secret_scan_settings(sss_space_id)
+    WHERE sss_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX secret_scan_settings_repo_id
+    ON secret_scan_settings(sss_repo_id)
+    WHERE sss_repo_id IS NOT NULL;
+
+CREATE TABLE secret_scans (
+ ss_id INTEGER PRIMARY KEY AUTOINCREMENT
+,ss_repo_id INTEGER NOT NULL
+,ss_status TEXT NOT NULL
+,ss_error TEXT NOT NULL
+,ss_findings INTEGER NOT NULL
+,ss_created_by INTEGER NOT NULL
+,ss_created BIGINT NOT NULL
+,ss_updated BIGINT NOT NULL
+,CONSTRAINT fk_ss_repo_id FOREIGN KEY (ss_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX secret_scans_repo_id_created
+    ON secret_scans(ss_repo_id, ss_created);
+
+CREATE TABLE secret_findings (
+ sf_id INTEGER PRIMARY KEY AUTOINCREMENT
+,sf_repo_id INTEGER NOT NULL
+,sf_scan_id INTEGER
+,sf_source TEXT NOT NULL
+,sf_commit_sha TEXT NOT NULL
+,sf_path TEXT NOT NULL
+,sf_line INTEGER NOT NULL
+,sf_rule TEXT NOT NULL
+,sf_fingerprint TEXT NOT NULL
+,sf_secret TEXT NOT NULL
+,sf_bypass_justification TEXT NOT NULL
+,sf_created_by INTEGER NOT NULL
+,sf_created BIGINT NOT NULL
+,CONSTRAINT fk_sf_repo_id FOREIGN KEY (sf_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_sf_scan_id FOREIGN KEY (sf_scan_id)
+    REFERENCES secret_scans (ss_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX secret_findings_repo_id_created
+    ON secret_findings(sf_repo_id, sf_created);
+
+CREATE INDEX secret_findings_scan_id
+    ON secret_findings(sf_scan_id);
@@ -0,0 +1,296 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MilestoneStore = (*MilestoneStore)(nil)
+
+// NewMilestoneStore returns a new MilestoneStore.
+func NewMilestoneStore(db *sqlx.DB) *MilestoneStore {
+	return &MilestoneStore{
+		db: db,
+	}
+}
+
+// MilestoneStore implements store.MilestoneStore backed by a relational database.
+type MilestoneStore struct {
+	db *sqlx.DB
+}
+
+type milestone struct {
+	ID          int64               `db:"milestone_id"`
+	RepoID      int64               `db:"milestone_repo_id"`
+	Title       string              `db:"milestone_title"`
+	Description string              `db:"milestone_description"`
+	State       enum.MilestoneState `db:"milestone_state"`
+	DueDate     null.Int            `db:"milestone_due_date"`
+	Created     int64               `db:"milestone_created"`
+	Updated     int64               `db:"milestone_updated"`
+	CreatedBy   int64               `db:"milestone_created_by"`
+}
+
+const (
+	milestoneColumns = `
+		 milestone_id
+		,milestone_repo_id
+		,milestone_title
+		,milestone_description
+		,milestone_state
+		,milestone_due_date
+		,milestone_created
+		,milestone_updated
+		,milestone_created_by`
+)
+
+// Find finds the milestone by id.
+func (s *MilestoneStore) Find(ctx context.Context, id int64) (*types.Milestone, error) {
+	stmt := database.Builder.
+		Select(milestoneColumns).
+		From("milestones").
+		Where("milestone_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find milestone query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &milestone{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find milestone")
+	}
+
+	return mapToMilestone(dst), nil
+}
+
+// Create creates a new milestone.
+func (s *MilestoneStore) Create(ctx context.Context, m *types.Milestone) error {
+	const sqlQuery = `
+		INSERT INTO milestones (
+			 milestone_repo_id
+			,milestone_title
+			,milestone_description
+			,milestone_state
+			,milestone_due_date
+			,milestone_created
+			,milestone_updated
+			,milestone_created_by
+		) values (
+			 :milestone_repo_id
+			,:milestone_title
+			,:milestone_description
+			,:milestone_state
+			,:milestone_due_date
+			,:milestone_created
+			,:milestone_updated
+			,:milestone_created_by
+		) RETURNING milestone_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMilestone(m))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind milestone")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&m.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert milestone query failed")
+	}
+
+	return nil
+}
+
+// Update updates the milestone.
+func (s *MilestoneStore) Update(ctx context.Context, m *types.Milestone) error {
+	const sqlQuery = `
+		UPDATE milestones
+		SET
+			 milestone_title = :milestone_title
+			,milestone_description = :milestone_description
+			,milestone_state = :milestone_state
+			,milestone_due_date = :milestone_due_date
+			,milestone_updated = :milestone_updated
+		WHERE milestone_id = :milestone_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMilestone(m))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind milestone")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update milestone")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated milestones")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the milestone with the given id.
+func (s *MilestoneStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM milestones
+		WHERE milestone_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete milestone query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted milestones")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns the milestones of the repository.
+func (s *MilestoneStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.MilestoneFilter,
+) ([]*types.Milestone, error) {
+	stmt := database.Builder.
+		Select(milestoneColumns).
+		From("milestones").
+		Where("milestone_repo_id = ?", repoID).
+		OrderBy("milestone_created desc")
+
+	stmt = applyMilestoneFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list milestones query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*milestone
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list milestones query")
+	}
+
+	milestones := make([]*types.Milestone, len(dst))
+	for i, m := range dst {
+		milestones[i] = mapToMilestone(m)
+	}
+
+	return milestones, nil
+}
+
+// Count returns the number of milestones of the repository.
+func (s *MilestoneStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.MilestoneFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("milestones").
+		Where("milestone_repo_id = ?", repoID)
+
+	stmt = applyMilestoneFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count milestones query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count milestones query")
+	}
+
+	return count, nil
+}
+
+func applyMilestoneFilter(stmt squirrel.SelectBuilder, filter *types.MilestoneFilter) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(milestone_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	if len(filter.States) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"milestone_state": filter.States})
+	}
+
+	return stmt
+}
+
+func mapToInternalMilestone(m *types.Milestone) *milestone {
+	return &milestone{
+		ID:          m.ID,
+		RepoID:      m.RepoID,
+		Title:       m.Title,
+		Description: m.Description,
+		State:       m.State,
+		DueDate:     null.IntFromPtr(m.DueDate),
+		Created:     m.Created,
+		Updated:     m.Updated,
+		CreatedBy:   m.CreatedBy,
+	}
+}
+
+func mapToMilestone(m *milestone) *types.Milestone {
+	return &types.Milestone{
+		ID:          m.ID,
+		RepoID:      m.RepoID,
+		Title:       m.Title,
+		Description: m.Description,
+		State:       m.State,
+		DueDate:     m.DueDate.Ptr(),
+		Created:     m.Created,
+		Updated:     m.Updated,
+		CreatedBy:   m.CreatedBy,
+	}
+}
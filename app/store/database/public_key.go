@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PublicKeyStore = (*PublicKeyStore)(nil)
+
+// NewPublicKeyStore returns a new PublicKeyStore.
+func NewPublicKeyStore(db *sqlx.DB) *PublicKeyStore {
+	return &PublicKeyStore{
+		db: db,
+	}
+}
+
+// PublicKeyStore implements store.PublicKeyStore backed by a relational database.
+type PublicKeyStore struct {
+	db *sqlx.DB
+}
+
+type publicKey struct {
+	ID          int64  `db:"public_key_id"`
+	PrincipalID int64  `db:"public_key_principal_id"`
+	Created     int64  `db:"public_key_created"`
+	LastUsed    *int64 `db:"public_key_last_used"`
+	Identifier  string `db:"public_key_identifier"`
+	Type        string `db:"public_key_type"`
+	Content     string `db:"public_key_content"`
+	Fingerprint string `db:"public_key_fingerprint"`
+	Comment     string `db:"public_key_comment"`
+}
+
+const (
+	publicKeyColumns = `
+		 public_key_id
+		,public_key_principal_id
+		,public_key_created
+		,public_key_last_used
+		,public_key_identifier
+		,public_key_type
+		,public_key_content
+		,public_key_fingerprint
+		,public_key_comment`
+)
+
+// Find finds a public key by its ID.
+func (s *PublicKeyStore) Find(ctx context.Context, id int64) (*types.PublicKey, error) {
+	stmt := database.Builder.
+		Select(publicKeyColumns).
+		From("public_keys").
+		Where("public_key_id = ?", id)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByIdentifier finds a public key of a principal by its identifier.
+func (s *PublicKeyStore) FindByIdentifier(
+	ctx context.Context,
+	principalID int64,
+	identifier string,
+) (*types.PublicKey, error) {
+	stmt := database.Builder.
+		Select(publicKeyColumns).
+		From("public_keys").
+		Where("public_key_principal_id = ?", principalID).
+		Where("LOWER(public_key_identifier) = ?", strings.ToLower(identifier))
+
+	return s.find(ctx, stmt)
+}
+
+// FindByFingerprint finds a public key by its fingerprint.
+func (s *PublicKeyStore) FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error) {
+	stmt := database.Builder.
+		Select(publicKeyColumns).
+		From("public_keys").
+		Where("public_key_fingerprint = ?", fingerprint)
+
+	return s.find(ctx, stmt)
+}
+
+func (s *PublicKeyStore) find(ctx context.Context, stmt squirrel.SelectBuilder) (*types.PublicKey, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find public key query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &publicKey{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find public key")
+	}
+
+	return mapToPublicKey(dst), nil
+}
+
+// Create creates a new public key.
+func (s *PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error {
+	const sqlQuery = `
+		INSERT INTO public_keys (
+			 public_key_principal_id
+			,public_key_created
+			,public_key_last_used
+			,public_key_identifier
+			,public_key_type
+			,public_key_content
+			,public_key_fingerprint
+			,public_key_comment
+		) values (
+			 :public_key_principal_id
+			,:public_key_created
+			,:public_key_last_used
+			,:public_key_identifier
+			,:public_key_type
+			,:public_key_content
+			,:public_key_fingerprint
+			,:public_key_comment
+		) RETURNING public_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPublicKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind public key")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert public key query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the public key with the given id.
+func (s *PublicKeyStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM public_keys
+		WHERE public_key_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete public key query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted public keys")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// MarkAsUsed updates the last used timestamp of a public key.
+func (s *PublicKeyStore) MarkAsUsed(ctx context.Context, id int64, usedAt int64) error {
+	stmt := database.Builder.
+		Update("public_keys").
+		Set("public_key_last_used", usedAt).
+		Where("public_key_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert mark public key as used query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to mark public key as used")
+	}
+
+	return nil
+}
+
+// Count returns the number of public keys of a principal.
+func (s *PublicKeyStore) Count(ctx context.Context, principalID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("public_keys").
+		Where("public_key_principal_id = ?", principalID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count public keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count public keys query")
+	}
+
+	return count, nil
+}
+
+// List returns all public keys of a principal.
+func (s *PublicKeyStore) List(ctx context.Context, principalID int64) ([]*types.PublicKey, error) {
+	stmt := database.Builder.
+		Select(publicKeyColumns).
+		From("public_keys").
+		Where("public_key_principal_id = ?", principalID).
+		OrderBy("public_key_created DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list public keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*publicKey
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list public keys query")
+	}
+
+	keys := make([]*types.PublicKey, len(dst))
+	for i, key := range dst {
+		keys[i] = mapToPublicKey(key)
+	}
+
+	return keys, nil
+}
+
+func mapToInternalPublicKey(key *types.PublicKey) *publicKey {
+	return &publicKey{
+		ID:          key.ID,
+		PrincipalID: key.PrincipalID,
+		Created:     key.Created,
+		LastUsed:    key.LastUsed,
+		Identifier:  key.Identifier,
+		Type:        key.Type,
+		Content:     key.Content,
+		Fingerprint: key.Fingerprint,
+		Comment:     key.Comment,
+	}
+}
+
+func mapToPublicKey(key *publicKey) *types.PublicKey {
+	return &types.PublicKey{
+		ID:          key.ID,
+		PrincipalID: key.PrincipalID,
+		Created:     key.Created,
+		LastUsed:    key.LastUsed,
+		Identifier:  key.Identifier,
+		Type:        key.Type,
+		Content:     key.Content,
+		Fingerprint: key.Fingerprint,
+		Comment:     key.Comment,
+	}
+}
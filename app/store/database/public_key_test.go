@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestPublicKeyStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	if err := principalStore.CreateUser(ctx, &types.User{ID: 2, UID: "user_2", Email: "user_2@example.com"}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	publicKeyStore := database.NewPublicKeyStore(db)
+
+	key := &types.PublicKey{
+		PrincipalID: 1,
+		Created:     1000,
+		Identifier:  "laptop",
+		Type:        "ssh-ed25519",
+		Content:     "ssh-ed25519 AAAA1",
+		Fingerprint: "SHA256:one",
+	}
+	if err := publicKeyStore.Create(ctx, key); err != nil {
+		t.Fatalf("failed to create public key: %v", err)
+	}
+
+	duplicates := []struct {
+		name string
+		key  types.PublicKey
+	}{
+		{
+			name: "same identifier of the same principal",
+			key: types.PublicKey{PrincipalID: 1, Identifier: "LAPTOP",
+				Type: "ssh-ed25519", Content: "ssh-ed25519 AAAA2", Fingerprint: "SHA256:two"},
+		},
+		{
+			name: "same fingerprint of the same principal",
+			key: types.PublicKey{PrincipalID: 1, Identifier: "desktop",
+				Type: "ssh-ed25519", Content: "ssh-ed25519 AAAA1", Fingerprint: "SHA256:one"},
+		},
+		{
+			name: "same fingerprint of another principal",
+			key: types.PublicKey{PrincipalID: 2, Identifier: "laptop",
+				Type: "ssh-ed25519", Content: "ssh-ed25519 AAAA1", Fingerprint: "SHA256:one"},
+		},
+	}
+
+	for _, test := range duplicates {
+		t.Run(test.name, func(t *testing.T) {
+			dup := test.key
+			if err := publicKeyStore.Create(ctx, &dup); !errors.Is(err, gitness_store.ErrDuplicate) {
+				t.Errorf("got=%v want=%v", err, gitness_store.ErrDuplicate)
+			}
+		})
+	}
+
+	other := &types.PublicKey{
+		PrincipalID: 2,
+		Created:     2000,
+		Identifier:  "laptop",
+		Type:        "ssh-ed25519",
+		Content:     "ssh-ed25519 AAAA3",
+		Fingerprint: "SHA256:three",
+	}
+	if err := publicKeyStore.Create(ctx, other); err != nil {
+		t.Fatalf("failed to create public key with the same identifier for another principal: %v", err)
+	}
+
+	found, err := publicKeyStore.FindByFingerprint(ctx, "SHA256:three")
+	if err != nil {
+		t.Fatalf("failed to find public key by fingerprint: %v", err)
+	}
+	if found.ID != other.ID || found.PrincipalID != 2 {
+		t.Errorf("got=%+v want=%+v", found, other)
+	}
+
+	found, err = publicKeyStore.FindByIdentifier(ctx, 1, "Laptop")
+	if err != nil {
+		t.Fatalf("failed to find public key by identifier: %v", err)
+	}
+	if found.ID != key.ID {
+		t.Errorf("got=%d want=%d", found.ID, key.ID)
+	}
+
+	if err = publicKeyStore.MarkAsUsed(ctx, key.ID, 5000); err != nil {
+		t.Fatalf("failed to mark public key as used: %v", err)
+	}
+
+	keys, err := publicKeyStore.List(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to list public keys: %v", err)
+	}
+	if len(keys) != 1 || keys[0].LastUsed == nil || *keys[0].LastUsed != 5000 {
+		t.Errorf("got=%+v want a single key used at 5000", keys)
+	}
+
+	count, err := publicKeyStore.Count(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to count public keys: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("got=%d want=%d", count, 1)
+	}
+
+	if err = publicKeyStore.Delete(ctx, key.ID); err != nil {
+		t.Fatalf("failed to delete public key: %v", err)
+	}
+
+	if err = publicKeyStore.Delete(ctx, key.ID); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	_, err = publicKeyStore.FindByFingerprint(ctx, "SHA256:one")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("got=%v want=%v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	// the fingerprint can be registered again once the key is deleted.
+	again := &types.PublicKey{
+		PrincipalID: 2,
+		Identifier:  "desktop",
+		Type:        "ssh-ed25519",
+		Content:     "ssh-ed25519 AAAA1",
+		Fingerprint: "SHA256:one",
+	}
+	if err = publicKeyStore.Create(ctx, again); err != nil {
+		t.Errorf("failed to re-register deleted public key: %v", err)
+	}
+}
@@ -92,6 +92,12 @@ type pullReq struct {
 
 	CommitCount null.Int `db:"pullreq_commit_count"`
 	FileCount   null.Int `db:"pullreq_file_count"`
+
+	AutoMergeMethod    null.String `db:"pullreq_auto_merge_method"`
+	AutoMergeTitle     null.String `db:"pullreq_auto_merge_title"`
+	AutoMergeMessage   null.String `db:"pullreq_auto_merge_message"`
+	AutoMergeBy        null.Int    `db:"pullreq_auto_merge_by"`
+	AutoMergeRequested null.Int    `db:"pullreq_auto_merge_requested"`
 }
 
 const (
@@ -124,7 +130,12 @@ const (
 		,pullreq_merge_sha
 		,pullreq_merge_conflicts
 		,pullreq_commit_count
-		,pullreq_file_count`
+		,pullreq_file_count
+		,pullreq_auto_merge_method
+		,pullreq_auto_merge_title
+		,pullreq_auto_merge_message
+		,pullreq_auto_merge_by
+		,pullreq_auto_merge_requested`
 
 	pullReqSelectBase = `
 	SELECT` + pullReqColumns + `
@@ -216,6 +227,11 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merge_conflicts
 		,pullreq_commit_count
 		,pullreq_file_count
+		,pullreq_auto_merge_method
+		,pullreq_auto_merge_title
+		,pullreq_auto_merge_message
+		,pullreq_auto_merge_by
+		,pullreq_auto_merge_requested
 	) values (
 		 :pullreq_version
 		,:pullreq_number
@@ -245,6 +261,11 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_merge_conflicts
 		,:pullreq_commit_count
 		,:pullreq_file_count
+		,:pullreq_auto_merge_method
+		,:pullreq_auto_merge_title
+		,:pullreq_auto_merge_message
+		,:pullreq_auto_merge_by
+		,:pullreq_auto_merge_requested
 	) RETURNING pullreq_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -287,6 +308,11 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merge_conflicts = :pullreq_merge_conflicts
 		,pullreq_commit_count = :pullreq_commit_count 
 		,pullreq_file_count = :pullreq_file_count
+		,pullreq_auto_merge_method = :pullreq_auto_merge_method
+		,pullreq_auto_merge_title = :pullreq_auto_merge_title
+		,pullreq_auto_merge_message = :pullreq_auto_merge_message
+		,pullreq_auto_merge_by = :pullreq_auto_merge_by
+		,pullreq_auto_merge_requested = :pullreq_auto_merge_requested
 	WHERE pullreq_id = :pullreq_id AND pullreq_version = :pullreq_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -438,6 +464,20 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
 	}
 
+	if opts.SourceSHA != "" {
+		stmt = stmt.Where("pullreq_source_sha = ?", opts.SourceSHA)
+	}
+
+	if opts.AutoMerge {
+		stmt = stmt.Where("pullreq_auto_merge_method IS NOT NULL")
+	}
+
+	// the pull request must have all the provided labels assigned.
+	for _, labelID := range opts.LabelIDs {
+		stmt = stmt.Where(`EXISTS (SELECT 1 FROM pullreq_labels
+			WHERE pullreq_label_pullreq_id = pullreq_id AND pullreq_label_label_id = ?)`, labelID)
+	}
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -490,6 +530,20 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
 	}
 
+	if opts.SourceSHA != "" {
+		stmt = stmt.Where("pullreq_source_sha = ?", opts.SourceSHA)
+	}
+
+	if opts.AutoMerge {
+		stmt = stmt.Where("pullreq_auto_merge_method IS NOT NULL")
+	}
+
+	// the pull request must have all the provided labels assigned.
+	for _, labelID := range opts.LabelIDs {
+		stmt = stmt.Where(`EXISTS (SELECT 1 FROM pullreq_labels
+			WHERE pullreq_label_pullreq_id = pullreq_id AND pullreq_label_label_id = ?)`, labelID)
+	}
+
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
@@ -526,6 +580,17 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 		mergeConflicts = strings.Split(pr.MergeConflicts.String, "\n")
 	}
 
+	var autoMerge *types.AutoMerge
+	if pr.AutoMergeMethod.Valid {
+		autoMerge = &types.AutoMerge{
+			Method:      enum.MergeMethod(pr.AutoMergeMethod.String),
+			Title:       pr.AutoMergeTitle.String,
+			Message:     pr.AutoMergeMessage.String,
+			RequestedBy: pr.AutoMergeBy.Int64,
+			Requested:   pr.AutoMergeRequested.Int64,
+		}
+	}
+
 	return &types.PullReq{
 		ID:               pr.ID,
 		Version:          pr.Version,
@@ -556,6 +621,7 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 		MergeConflicts:   mergeConflicts,
 		Author:           types.PrincipalInfo{},
 		Merger:           nil,
+		AutoMerge:        autoMerge,
 		Stats: types.PullReqStats{
 			Conversations:   pr.CommentCount,
 			UnresolvedCount: pr.UnresolvedCount,
@@ -601,6 +667,14 @@ func mapInternalPullReq(pr *types.PullReq) *pullReq {
 		FileCount:        null.IntFromPtr(pr.Stats.FilesChanged),
 	}
 
+	if pr.AutoMerge != nil {
+		m.AutoMergeMethod = null.StringFrom(string(pr.AutoMerge.Method))
+		m.AutoMergeTitle = null.NewString(pr.AutoMerge.Title, pr.AutoMerge.Title != "")
+		m.AutoMergeMessage = null.NewString(pr.AutoMerge.Message, pr.AutoMerge.Message != "")
+		m.AutoMergeBy = null.IntFrom(pr.AutoMerge.RequestedBy)
+		m.AutoMergeRequested = null.IntFrom(pr.AutoMerge.Requested)
+	}
+
 	return m
 }
 
@@ -626,6 +700,13 @@ func (s *PullReqStore) mapPullReq(ctx context.Context, pr *pullReq) *types.PullR
 		m.Merger = merger
 	}
 
+	if m.AutoMerge != nil {
+		m.AutoMerge.Requester, err = s.pCache.Get(ctx, m.AutoMerge.RequestedBy)
+		if err != nil {
+			log.Ctx(ctx).Err(err).Msg("failed to load PR auto-merge requester")
+		}
+	}
+
 	return m
 }
 
@@ -637,6 +718,9 @@ func (s *PullReqStore) mapSlicePullReq(ctx context.Context, prs []*pullReq) ([]*
 		if pr.MergedBy.Valid {
 			ids = append(ids, pr.MergedBy.Int64)
 		}
+		if pr.AutoMergeBy.Valid {
+			ids = append(ids, pr.AutoMergeBy.Int64)
+		}
 	}
 
 	// pull principal infos from cache
@@ -657,6 +741,9 @@ func (s *PullReqStore) mapSlicePullReq(ctx context.Context, prs []*pullReq) ([]*
 				m[i].Merger = merger
 			}
 		}
+		if m[i].AutoMerge != nil {
+			m[i].AutoMerge.Requester = infoMap[m[i].AutoMerge.RequestedBy]
+		}
 	}
 
 	return m, nil
@@ -64,6 +64,7 @@ type pullReqActivity struct {
 	Updated   int64    `db:"pullreq_activity_updated"`
 	Edited    int64    `db:"pullreq_activity_edited"`
 	Deleted   null.Int `db:"pullreq_activity_deleted"`
+	Pending   bool     `db:"pullreq_activity_pending"`
 
 	ParentID  null.Int `db:"pullreq_activity_parent_id"`
 	RepoID    int64    `db:"pullreq_activity_repo_id"`
@@ -102,6 +103,7 @@ const (
 		,pullreq_activity_updated
 		,pullreq_activity_edited
 		,pullreq_activity_deleted
+		,pullreq_activity_pending
 		,pullreq_activity_parent_id
 		,pullreq_activity_repo_id
 		,pullreq_activity_pullreq_id
@@ -154,6 +156,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_updated
 		,pullreq_activity_edited
 		,pullreq_activity_deleted
+		,pullreq_activity_pending
 		,pullreq_activity_parent_id
 		,pullreq_activity_repo_id
 		,pullreq_activity_pullreq_id
@@ -182,6 +185,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,:pullreq_activity_updated
 		,:pullreq_activity_edited
 		,:pullreq_activity_deleted
+		,:pullreq_activity_pending
 		,:pullreq_activity_parent_id
 		,:pullreq_activity_repo_id
 		,:pullreq_activity_pullreq_id
@@ -258,6 +262,7 @@ func (s *PullReqActivityStore) Update(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_updated = :pullreq_activity_updated
 		,pullreq_activity_edited = :pullreq_activity_edited
 		,pullreq_activity_deleted = :pullreq_activity_deleted
+		,pullreq_activity_pending = :pullreq_activity_pending
 		,pullreq_activity_reply_seq = :pullreq_activity_reply_seq
 		,pullreq_activity_text = :pullreq_activity_text
 		,pullreq_activity_payload = :pullreq_activity_payload
@@ -356,6 +361,12 @@ func (s *PullReqActivityStore) Count(ctx context.Context,
 		stmt = stmt.Where(squirrel.Eq{"pullreq_activity_kind": opts.Kinds})
 	}
 
+	if opts.PendingAuthorID != 0 {
+		stmt = stmt.Where("(pullreq_activity_pending = false OR pullreq_activity_created_by = ?)", opts.PendingAuthorID)
+	} else {
+		stmt = stmt.Where("pullreq_activity_pending = false")
+	}
+
 	if opts.After != 0 {
 		stmt = stmt.Where("pullreq_activity_created > ?", opts.After)
 	}
@@ -402,6 +413,12 @@ func (s *PullReqActivityStore) List(ctx context.Context,
 		stmt = stmt.Where(squirrel.Eq{"pullreq_activity_kind": opts.Kinds})
 	}
 
+	if opts.PendingAuthorID != 0 {
+		stmt = stmt.Where("(pullreq_activity_pending = false OR pullreq_activity_created_by = ?)", opts.PendingAuthorID)
+	} else {
+		stmt = stmt.Where("pullreq_activity_pending = false")
+	}
+
 	if opts.After != 0 {
 		stmt = stmt.Where("pullreq_activity_created > ?", opts.After)
 	}
@@ -437,6 +454,58 @@ func (s *PullReqActivityStore) List(ctx context.Context,
 	return result, nil
 }
 
+// ListPending returns the pending activities of the principal in a pull request.
+func (s *PullReqActivityStore) ListPending(ctx context.Context,
+	prID int64,
+	principalID int64,
+) ([]*types.PullReqActivity, error) {
+	stmt := database.Builder.
+		Select(pullreqActivityColumns).
+		From("pullreq_activities").
+		Where("pullreq_activity_pullreq_id = ?", prID).
+		Where("pullreq_activity_created_by = ?", principalID).
+		Where("pullreq_activity_pending = true").
+		OrderBy("pullreq_activity_order asc", "pullreq_activity_sub_order asc")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert pending pull request activity query to sql")
+	}
+
+	dst := make([]*pullReqActivity, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing pending pull request activity list query")
+	}
+
+	return s.mapSlicePullReqActivity(ctx, dst)
+}
+
+// DeletePending permanently deletes the pending activities of the principal in a pull request.
+func (s *PullReqActivityStore) DeletePending(ctx context.Context, prID int64, principalID int64) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM pullreq_activities
+	WHERE pullreq_activity_pullreq_id = $1 AND
+		pullreq_activity_created_by = $2 AND
+		pullreq_activity_pending = true`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, prID, principalID)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to delete pending pull request activities")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to get number of deleted pending pull request activities")
+	}
+
+	return count, nil
+}
+
 func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64) (int, error) {
 	stmt := database.Builder.
 		Select("count(*)").
@@ -445,6 +514,7 @@ func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64)
 		Where("pullreq_activity_sub_order = 0").
 		Where("pullreq_activity_resolved IS NULL").
 		Where("pullreq_activity_deleted IS NULL").
+		Where("pullreq_activity_pending = false").
 		Where("pullreq_activity_kind <> ?", enum.PullReqActivityKindSystem)
 
 	sql, args, err := stmt.ToSql()
@@ -472,6 +542,7 @@ func mapPullReqActivity(act *pullReqActivity) *types.PullReqActivity {
 		Updated:    act.Updated,
 		Edited:     act.Edited,
 		Deleted:    act.Deleted.Ptr(),
+		Pending:    act.Pending,
 		ParentID:   act.ParentID.Ptr(),
 		RepoID:     act.RepoID,
 		PullReqID:  act.PullReqID,
@@ -515,6 +586,7 @@ func mapInternalPullReqActivity(act *types.PullReqActivity) *pullReqActivity {
 		Updated:    act.Updated,
 		Edited:     act.Edited,
 		Deleted:    null.IntFromPtr(act.Deleted),
+		Pending:    act.Pending,
 		ParentID:   null.IntFromPtr(act.ParentID),
 		RepoID:     act.RepoID,
 		PullReqID:  act.PullReqID,
@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqLabelStore = (*PullReqLabelStore)(nil)
+
+// NewPullReqLabelStore returns a new PullReqLabelStore.
+func NewPullReqLabelStore(db *sqlx.DB) *PullReqLabelStore {
+	return &PullReqLabelStore{
+		db: db,
+	}
+}
+
+// PullReqLabelStore implements store.PullReqLabelStore backed by a relational database.
+type PullReqLabelStore struct {
+	db *sqlx.DB
+}
+
+type pullReqLabel struct {
+	PullReqID int64 `db:"pullreq_label_pullreq_id"`
+	LabelID   int64 `db:"pullreq_label_label_id"`
+	Created   int64 `db:"pullreq_label_created"`
+	CreatedBy int64 `db:"pullreq_label_created_by"`
+}
+
+type pullReqLabelInfo struct {
+	PullReqID int64  `db:"pullreq_label_pullreq_id"`
+	ID        int64  `db:"label_id"`
+	Key       string `db:"label_key"`
+	Value     string `db:"label_value"`
+	Color     string `db:"label_color"`
+}
+
+// Assign assigns the label to the pull request.
+func (s *PullReqLabelStore) Assign(ctx context.Context, pullreqLabel *types.PullReqLabel) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_labels (
+			 pullreq_label_pullreq_id
+			,pullreq_label_label_id
+			,pullreq_label_created
+			,pullreq_label_created_by
+		) values (
+			 :pullreq_label_pullreq_id
+			,:pullreq_label_label_id
+			,:pullreq_label_created
+			,:pullreq_label_created_by
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &pullReqLabel{
+		PullReqID: pullreqLabel.PullReqID,
+		LabelID:   pullreqLabel.LabelID,
+		Created:   pullreqLabel.Created,
+		CreatedBy: pullreqLabel.CreatedBy,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request label")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert pull request label query failed")
+	}
+
+	return nil
+}
+
+// Unassign removes the label from the pull request.
+func (s *PullReqLabelStore) Unassign(ctx context.Context, pullreqID, labelID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_labels
+		WHERE pullreq_label_pullreq_id = $1 AND pullreq_label_label_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullreqID, labelID)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete pull request label query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted pull request labels")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListInfo returns the labels assigned to the provided pull requests, mapped by the pull request id.
+func (s *PullReqLabelStore) ListInfo(
+	ctx context.Context,
+	pullreqIDs []int64,
+) (map[int64][]*types.LabelInfo, error) {
+	stmt := database.Builder.
+		Select(`
+			 pullreq_label_pullreq_id
+			,label_id
+			,label_key
+			,label_value
+			,label_color`).
+		From("pullreq_labels").
+		InnerJoin("labels ON label_id = pullreq_label_label_id").
+		Where(squirrel.Eq{"pullreq_label_pullreq_id": pullreqIDs}).
+		OrderBy("LOWER(label_key)", "LOWER(label_value)")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list pull request labels query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqLabelInfo
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list pull request labels query")
+	}
+
+	result := make(map[int64][]*types.LabelInfo, len(pullreqIDs))
+	for _, info := range dst {
+		result[info.PullReqID] = append(result[info.PullReqID], &types.LabelInfo{
+			ID:    info.ID,
+			Key:   info.Key,
+			Value: info.Value,
+			Color: info.Color,
+		})
+	}
+
+	return result, nil
+}
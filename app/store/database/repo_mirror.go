@@ -0,0 +1,365 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoMirrorStore = (*RepoMirrorStore)(nil)
+
+// NewRepoMirrorStore returns a new RepoMirrorStore.
+func NewRepoMirrorStore(db *sqlx.DB) *RepoMirrorStore {
+	return &RepoMirrorStore{
+		db: db,
+	}
+}
+
+// RepoMirrorStore implements store.RepoMirrorStore backed by a relational database.
+type RepoMirrorStore struct {
+	db *sqlx.DB
+}
+
+type repoMirror struct {
+	ID             int64                 `db:"mirror_id"`
+	RepoID         int64                 `db:"mirror_repo_id"`
+	Direction      enum.MirrorDirection  `db:"mirror_direction"`
+	RemoteURL      string                `db:"mirror_remote_url"`
+	Username       string                `db:"mirror_username"`
+	Password       string                `db:"mirror_password"`
+	Interval       int64                 `db:"mirror_interval"`
+	Enabled        bool                  `db:"mirror_enabled"`
+	CreatedBy      int64                 `db:"mirror_created_by"`
+	Created        int64                 `db:"mirror_created"`
+	Updated        int64                 `db:"mirror_updated"`
+	LastSync       *int64                `db:"mirror_last_sync"`
+	LastSyncStatus enum.MirrorSyncStatus `db:"mirror_last_sync_status"`
+	LastSyncError  string                `db:"mirror_last_sync_error"`
+	NextSync       int64                 `db:"mirror_next_sync"`
+}
+
+const (
+	repoMirrorColumns = `
+		 mirror_id
+		,mirror_repo_id
+		,mirror_direction
+		,mirror_remote_url
+		,mirror_username
+		,mirror_password
+		,mirror_interval
+		,mirror_enabled
+		,mirror_created_by
+		,mirror_created
+		,mirror_updated
+		,mirror_last_sync
+		,mirror_last_sync_status
+		,mirror_last_sync_error
+		,mirror_next_sync`
+)
+
+// Find finds a repository mirror by its ID.
+func (s *RepoMirrorStore) Find(ctx context.Context, id int64) (*types.RepoMirror, error) {
+	stmt := database.Builder.
+		Select(repoMirrorColumns).
+		From("repository_mirrors").
+		Where("mirror_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find repository mirror query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoMirror{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find repository mirror")
+	}
+
+	return mapToRepoMirror(dst), nil
+}
+
+// Create creates a new repository mirror.
+func (s *RepoMirrorStore) Create(ctx context.Context, mirror *types.RepoMirror) error {
+	const sqlQuery = `
+		INSERT INTO repository_mirrors (
+			 mirror_repo_id
+			,mirror_direction
+			,mirror_remote_url
+			,mirror_username
+			,mirror_password
+			,mirror_interval
+			,mirror_enabled
+			,mirror_created_by
+			,mirror_created
+			,mirror_updated
+			,mirror_last_sync
+			,mirror_last_sync_status
+			,mirror_last_sync_error
+			,mirror_next_sync
+		) values (
+			 :mirror_repo_id
+			,:mirror_direction
+			,:mirror_remote_url
+			,:mirror_username
+			,:mirror_password
+			,:mirror_interval
+			,:mirror_enabled
+			,:mirror_created_by
+			,:mirror_created
+			,:mirror_updated
+			,:mirror_last_sync
+			,:mirror_last_sync_status
+			,:mirror_last_sync_error
+			,:mirror_next_sync
+		) RETURNING mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repository mirror")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert repository mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the configuration of an existing repository mirror.
+func (s *RepoMirrorStore) Update(ctx context.Context, mirror *types.RepoMirror) error {
+	const sqlQuery = `
+		UPDATE repository_mirrors
+		SET
+			 mirror_remote_url = :mirror_remote_url
+			,mirror_username = :mirror_username
+			,mirror_password = :mirror_password
+			,mirror_interval = :mirror_interval
+			,mirror_enabled = :mirror_enabled
+			,mirror_updated = :mirror_updated
+			,mirror_next_sync = :mirror_next_sync
+		WHERE mirror_id = :mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repository mirror")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update repository mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated repository mirrors")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// UpdateSyncState updates the last sync and the next sync information of a repository mirror.
+func (s *RepoMirrorStore) UpdateSyncState(ctx context.Context, mirror *types.RepoMirror) error {
+	stmt := database.Builder.
+		Update("repository_mirrors").
+		Set("mirror_last_sync", mirror.LastSync).
+		Set("mirror_last_sync_status", mirror.LastSyncStatus).
+		Set("mirror_last_sync_error", mirror.LastSyncError).
+		Set("mirror_next_sync", mirror.NextSync).
+		Where("mirror_id = ?", mirror.ID)
+
+	return s.exec(ctx, stmt, "update repository mirror sync state")
+}
+
+// ScheduleSync schedules the sync of a repository mirror at the provided time.
+func (s *RepoMirrorStore) ScheduleSync(ctx context.Context, id int64, at int64) error {
+	stmt := database.Builder.
+		Update("repository_mirrors").
+		Set("mirror_next_sync", at).
+		Where("mirror_id = ?", id)
+
+	return s.exec(ctx, stmt, "schedule repository mirror sync")
+}
+
+// ScheduleSyncByRepo schedules the sync of all enabled mirrors of a repository
+// with the provided direction at the provided time.
+func (s *RepoMirrorStore) ScheduleSyncByRepo(
+	ctx context.Context,
+	repoID int64,
+	direction enum.MirrorDirection,
+	at int64,
+) error {
+	stmt := database.Builder.
+		Update("repository_mirrors").
+		Set("mirror_next_sync", at).
+		Where("mirror_repo_id = ?", repoID).
+		Where("mirror_direction = ?", direction).
+		Where("mirror_enabled")
+
+	return s.exec(ctx, stmt, "schedule repository mirror syncs")
+}
+
+func (s *RepoMirrorStore) exec(ctx context.Context, stmt squirrel.UpdateBuilder, op string) error {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert %s query to sql: %w", op, err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to %s", op)
+	}
+
+	return nil
+}
+
+// Delete deletes the repository mirror with the given id.
+func (s *RepoMirrorStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM repository_mirrors
+		WHERE mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete repository mirror query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted repository mirrors")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns the mirrors of a repository. If direction is empty, mirrors of both directions are returned.
+func (s *RepoMirrorStore) List(
+	ctx context.Context,
+	repoID int64,
+	direction enum.MirrorDirection,
+) ([]*types.RepoMirror, error) {
+	stmt := database.Builder.
+		Select(repoMirrorColumns).
+		From("repository_mirrors").
+		Where("mirror_repo_id = ?", repoID).
+		OrderBy("mirror_id")
+
+	if direction != "" {
+		stmt = stmt.Where("mirror_direction = ?", direction)
+	}
+
+	return s.list(ctx, stmt)
+}
+
+// ListDue returns enabled repository mirrors which are scheduled to be synced at or before the provided time.
+func (s *RepoMirrorStore) ListDue(ctx context.Context, now int64, limit int) ([]*types.RepoMirror, error) {
+	stmt := database.Builder.
+		Select(repoMirrorColumns).
+		From("repository_mirrors").
+		Where("mirror_enabled").
+		Where("mirror_next_sync > 0").
+		Where("mirror_next_sync <= ?", now).
+		OrderBy("mirror_next_sync").
+		Limit(uint64(limit))
+
+	return s.list(ctx, stmt)
+}
+
+func (s *RepoMirrorStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.RepoMirror, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list repository mirrors query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*repoMirror
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list repository mirrors query")
+	}
+
+	mirrors := make([]*types.RepoMirror, len(dst))
+	for i, mirror := range dst {
+		mirrors[i] = mapToRepoMirror(mirror)
+	}
+
+	return mirrors, nil
+}
+
+func mapToInternalRepoMirror(mirror *types.RepoMirror) *repoMirror {
+	return &repoMirror{
+		ID:             mirror.ID,
+		RepoID:         mirror.RepoID,
+		Direction:      mirror.Direction,
+		RemoteURL:      mirror.RemoteURL,
+		Username:       mirror.Username,
+		Password:       mirror.Password,
+		Interval:       mirror.Interval,
+		Enabled:        mirror.Enabled,
+		CreatedBy:      mirror.CreatedBy,
+		Created:        mirror.Created,
+		Updated:        mirror.Updated,
+		LastSync:       mirror.LastSync,
+		LastSyncStatus: mirror.LastSyncStatus,
+		LastSyncError:  mirror.LastSyncError,
+		NextSync:       mirror.NextSync,
+	}
+}
+
+func mapToRepoMirror(mirror *repoMirror) *types.RepoMirror {
+	return &types.RepoMirror{
+		ID:             mirror.ID,
+		RepoID:         mirror.RepoID,
+		Direction:      mirror.Direction,
+		RemoteURL:      mirror.RemoteURL,
+		Username:       mirror.Username,
+		Password:       mirror.Password,
+		Interval:       mirror.Interval,
+		Enabled:        mirror.Enabled,
+		CreatedBy:      mirror.CreatedBy,
+		Created:        mirror.Created,
+		Updated:        mirror.Updated,
+		LastSync:       mirror.LastSync,
+		LastSyncStatus: mirror.LastSyncStatus,
+		LastSyncError:  mirror.LastSyncError,
+		NextSync:       mirror.NextSync,
+	}
+}
@@ -280,12 +280,17 @@ func (s *RuleStore) DeleteByIdentifier(ctx context.Context, spaceID, repoID *int
 }
 
 // Count returns count of protection rules matching the provided criteria.
-func (s *RuleStore) Count(ctx context.Context, spaceID, repoID *int64, filter *types.RuleFilter) (int64, error) {
+func (s *RuleStore) Count(
+	ctx context.Context,
+	spaceIDs []int64,
+	repoID *int64,
+	filter *types.RuleFilter,
+) (int64, error) {
 	stmt := database.Builder.
 		Select("count(*)").
 		From("rules")
 
-	stmt = s.applyParentID(stmt, spaceID, repoID)
+	stmt = s.applyScopes(stmt, spaceIDs, repoID)
 	stmt = s.applyFilter(stmt, filter)
 
 	sql, args, err := stmt.ToSql()
@@ -305,13 +310,18 @@ func (s *RuleStore) Count(ctx context.Context, spaceID, repoID *int64, filter *t
 	return count, nil
 }
 
-// List returns a list of protection rules of a repository or a space.
-func (s *RuleStore) List(ctx context.Context, spaceID, repoID *int64, filter *types.RuleFilter) ([]types.Rule, error) {
+// List returns a list of protection rules defined on any of the spaces or on the repository.
+func (s *RuleStore) List(
+	ctx context.Context,
+	spaceIDs []int64,
+	repoID *int64,
+	filter *types.RuleFilter,
+) ([]types.Rule, error) {
 	stmt := database.Builder.
 		Select(ruleColumns).
 		From("rules")
 
-	stmt = s.applyParentID(stmt, spaceID, repoID)
+	stmt = s.applyScopes(stmt, spaceIDs, repoID)
 	stmt = s.applyFilter(stmt, filter)
 
 	stmt = stmt.Limit(database.Limit(filter.Size))
@@ -442,6 +452,29 @@ func (*RuleStore) applyParentID(
 	return stmt
 }
 
+// applyScopes limits the rules to the ones defined on any of the provided spaces or on the repository.
+func (*RuleStore) applyScopes(
+	stmt squirrel.SelectBuilder,
+	spaceIDs []int64,
+	repoID *int64,
+) squirrel.SelectBuilder {
+	scopes := squirrel.Or{}
+
+	if len(spaceIDs) > 0 {
+		scopes = append(scopes, squirrel.Eq{"rule_space_id": spaceIDs})
+	}
+
+	if repoID != nil {
+		scopes = append(scopes, squirrel.Eq{"rule_repo_id": *repoID})
+	}
+
+	if len(scopes) == 0 {
+		return stmt.Where("1 = 0")
+	}
+
+	return stmt.Where(scopes)
+}
+
 func (*RuleStore) applyFilter(
 	stmt squirrel.SelectBuilder,
 	filter *types.RuleFilter,
@@ -0,0 +1,243 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.RuleMonitorViolationStore = (*RuleMonitorViolationStore)(nil)
+
+// NewRuleMonitorViolationStore returns a new RuleMonitorViolationStore.
+func NewRuleMonitorViolationStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *RuleMonitorViolationStore {
+	return &RuleMonitorViolationStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// RuleMonitorViolationStore implements store.RuleMonitorViolationStore backed by a relational database.
+type RuleMonitorViolationStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type ruleMonitorViolation struct {
+	ID         int64                    `db:"rmv_id"`
+	RuleID     int64                    `db:"rmv_rule_id"`
+	RepoID     int64                    `db:"rmv_repo_id"`
+	Action     enum.RuleViolationAction `db:"rmv_action"`
+	Violations string                   `db:"rmv_violations"`
+	CreatedBy  int64                    `db:"rmv_created_by"`
+	Created    int64                    `db:"rmv_created"`
+}
+
+const (
+	ruleMonitorViolationColumns = `
+		 rmv_id
+		,rmv_rule_id
+		,rmv_repo_id
+		,rmv_action
+		,rmv_violations
+		,rmv_created_by
+		,rmv_created`
+)
+
+// Create records new violations of a protection rule.
+func (s *RuleMonitorViolationStore) Create(ctx context.Context, v *types.RuleMonitorViolation) error {
+	const sqlQuery = `
+		INSERT INTO rule_monitor_violations (
+			 rmv_rule_id
+			,rmv_repo_id
+			,rmv_action
+			,rmv_violations
+			,rmv_created_by
+			,rmv_created
+		) values (
+			 :rmv_rule_id
+			,:rmv_repo_id
+			,:rmv_action
+			,:rmv_violations
+			,:rmv_created_by
+			,:rmv_created
+		) RETURNING rmv_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbObj, err := mapToInternalRuleMonitorViolation(v)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbObj)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind rule monitor violation object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&v.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert rule monitor violation query failed")
+	}
+
+	return nil
+}
+
+// Count returns count of the recorded violations of a protection rule in a repository.
+func (s *RuleMonitorViolationStore) Count(
+	ctx context.Context,
+	ruleID, repoID int64,
+	filter *types.RuleMonitorViolationFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("rule_monitor_violations").
+		Where("rmv_rule_id = ?", ruleID).
+		Where("rmv_repo_id = ?", repoID)
+
+	stmt = applyRuleMonitorViolationFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count rule monitor violations query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count rule monitor violations query")
+	}
+
+	return count, nil
+}
+
+// List returns the recorded violations of a protection rule in a repository, the most recent first.
+func (s *RuleMonitorViolationStore) List(
+	ctx context.Context,
+	ruleID, repoID int64,
+	filter *types.RuleMonitorViolationFilter,
+) ([]*types.RuleMonitorViolation, error) {
+	stmt := database.Builder.
+		Select(ruleMonitorViolationColumns).
+		From("rule_monitor_violations").
+		Where("rmv_rule_id = ?", ruleID).
+		Where("rmv_repo_id = ?", repoID)
+
+	stmt = applyRuleMonitorViolationFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("rmv_created DESC", "rmv_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list rule monitor violations query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*ruleMonitorViolation
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list rule monitor violations query")
+	}
+
+	return s.mapToRuleMonitorViolations(ctx, dst), nil
+}
+
+func applyRuleMonitorViolationFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.RuleMonitorViolationFilter,
+) squirrel.SelectBuilder {
+	if filter.Since > 0 {
+		stmt = stmt.Where("rmv_created >= ?", filter.Since)
+	}
+
+	if filter.Until > 0 {
+		stmt = stmt.Where("rmv_created <= ?", filter.Until)
+	}
+
+	return stmt
+}
+
+func (s *RuleMonitorViolationStore) mapToRuleMonitorViolations(
+	ctx context.Context,
+	in []*ruleMonitorViolation,
+) []*types.RuleMonitorViolation {
+	res := make([]*types.RuleMonitorViolation, len(in))
+	for i := range in {
+		res[i] = s.mapToRuleMonitorViolation(ctx, in[i])
+	}
+	return res
+}
+
+func (s *RuleMonitorViolationStore) mapToRuleMonitorViolation(
+	ctx context.Context,
+	in *ruleMonitorViolation,
+) *types.RuleMonitorViolation {
+	v := &types.RuleMonitorViolation{
+		ID:        in.ID,
+		RuleID:    in.RuleID,
+		RepoID:    in.RepoID,
+		Action:    in.Action,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+	}
+
+	if err := json.Unmarshal([]byte(in.Violations), &v.Violations); err != nil {
+		log.Ctx(ctx).Err(err).Int64("id", in.ID).Msg("failed to unmarshal rule monitor violations")
+	}
+
+	author, err := s.pCache.Get(ctx, in.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load rule monitor violation author")
+	}
+
+	if author != nil {
+		v.Author = *author
+	}
+
+	return v
+}
+
+func mapToInternalRuleMonitorViolation(in *types.RuleMonitorViolation) (*ruleMonitorViolation, error) {
+	violations, err := json.Marshal(in.Violations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rule monitor violations: %w", err)
+	}
+
+	return &ruleMonitorViolation{
+		ID:         in.ID,
+		RuleID:     in.RuleID,
+		RepoID:     in.RepoID,
+		Action:     in.Action,
+		Violations: string(violations),
+		CreatedBy:  in.CreatedBy,
+		Created:    in.Created,
+	}, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestRuleStore_Scopes(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(t, &ctx, principalStore, 1)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 2, 1)
+	createSpace(t, &ctx, spaceStore, spacePathStore, userID, 3, 1)
+
+	repoID := int64(1)
+	createRepo(t, &ctx, repoStore, repoID, 2, 0)
+
+	ruleStore := database.NewRuleStore(db, cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)))
+
+	rootSpaceID, childSpaceID, siblingSpaceID := int64(1), int64(2), int64(3)
+
+	rules := []*types.Rule{
+		{SpaceID: &rootSpaceID, Identifier: "root"},
+		{SpaceID: &childSpaceID, Identifier: "child"},
+		{SpaceID: &siblingSpaceID, Identifier: "sibling"},
+		{RepoID: &repoID, Identifier: "repo"},
+		{SpaceID: &rootSpaceID, Identifier: "root-disabled", State: enum.RuleStateDisabled},
+	}
+
+	for _, r := range rules {
+		r.CreatedBy = userID
+		r.Type = "branch"
+		if r.State == "" {
+			r.State = enum.RuleStateActive
+		}
+		r.Pattern = json.RawMessage("{}")
+		r.Definition = json.RawMessage("{}")
+		if err := ruleStore.Create(ctx, r); err != nil {
+			t.Fatalf("failed to create rule %s: %v", r.Identifier, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		spaceIDs []int64
+		repoID   *int64
+		want     []string
+	}{
+		{
+			name:     "space",
+			spaceIDs: []int64{childSpaceID},
+			want:     []string{"child"},
+		},
+		{
+			name:     "space with ancestors",
+			spaceIDs: []int64{childSpaceID, rootSpaceID},
+			want:     []string{"child", "root", "root-disabled"},
+		},
+		{
+			name:   "repo",
+			repoID: &repoID,
+			want:   []string{"repo"},
+		},
+		{
+			name:     "repo with inherited",
+			spaceIDs: []int64{childSpaceID, rootSpaceID},
+			repoID:   &repoID,
+			want:     []string{"child", "repo", "root", "root-disabled"},
+		},
+		{
+			name: "no scope",
+			want: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := &types.RuleFilter{
+				ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 10}},
+			}
+
+			list, err := ruleStore.List(ctx, test.spaceIDs, test.repoID, filter)
+			if err != nil {
+				t.Fatalf("failed to list rules: %v", err)
+			}
+
+			count, err := ruleStore.Count(ctx, test.spaceIDs, test.repoID, filter)
+			if err != nil {
+				t.Fatalf("failed to count rules: %v", err)
+			}
+
+			got := make([]string, len(list))
+			for i, r := range list {
+				got[i] = r.Identifier
+			}
+			sort.Strings(got)
+
+			if !slices.Equal(got, test.want) || count != int64(len(test.want)) {
+				t.Errorf("got=%v (count=%d) want=%v", got, count, test.want)
+			}
+		})
+	}
+
+	t.Run("all repo rules", func(t *testing.T) {
+		infos, err := ruleStore.ListAllRepoRules(ctx, repoID)
+		if err != nil {
+			t.Fatalf("failed to list all repo rules: %v", err)
+		}
+
+		got := make([]string, len(infos))
+		for i, info := range infos {
+			got[i] = info.Identifier + "@" + info.SpacePath + info.RepoPath
+		}
+		sort.Strings(got)
+
+		want := []string{"child@space_1/space_2", "repo@space_1/space_2/repo_1", "root@space_1"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got=%v want=%v", got, want)
+		}
+	})
+}
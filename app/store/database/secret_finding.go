@@ -0,0 +1,264 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.SecretFindingStore = (*SecretFindingStore)(nil)
+
+// NewSecretFindingStore returns a new SecretFindingStore.
+func NewSecretFindingStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *SecretFindingStore {
+	return &SecretFindingStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// SecretFindingStore implements store.SecretFindingStore backed by a relational database.
+type SecretFindingStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type secretFinding struct {
+	ID                  int64                    `db:"sf_id"`
+	RepoID              int64                    `db:"sf_repo_id"`
+	ScanID              null.Int                 `db:"sf_scan_id"`
+	Source              enum.SecretFindingSource `db:"sf_source"`
+	CommitSHA           string                   `db:"sf_commit_sha"`
+	Path                string                   `db:"sf_path"`
+	Line                int                      `db:"sf_line"`
+	Rule                string                   `db:"sf_rule"`
+	Fingerprint         string                   `db:"sf_fingerprint"`
+	Secret              string                   `db:"sf_secret"`
+	BypassJustification string                   `db:"sf_bypass_justification"`
+	CreatedBy           int64                    `db:"sf_created_by"`
+	Created             int64                    `db:"sf_created"`
+}
+
+const (
+	secretFindingColumns = `
+		 sf_id
+		,sf_repo_id
+		,sf_scan_id
+		,sf_source
+		,sf_commit_sha
+		,sf_path
+		,sf_line
+		,sf_rule
+		,sf_fingerprint
+		,sf_secret
+		,sf_bypass_justification
+		,sf_created_by
+		,sf_created`
+)
+
+// Create records a new secret finding.
+func (s *SecretFindingStore) Create(ctx context.Context, finding *types.SecretFinding) error {
+	const sqlQuery = `
+		INSERT INTO secret_findings (
+			 sf_repo_id
+			,sf_scan_id
+			,sf_source
+			,sf_commit_sha
+			,sf_path
+			,sf_line
+			,sf_rule
+			,sf_fingerprint
+			,sf_secret
+			,sf_bypass_justification
+			,sf_created_by
+			,sf_created
+		) values (
+			 :sf_repo_id
+			,:sf_scan_id
+			,:sf_source
+			,:sf_commit_sha
+			,:sf_path
+			,:sf_line
+			,:sf_rule
+			,:sf_fingerprint
+			,:sf_secret
+			,:sf_bypass_justification
+			,:sf_created_by
+			,:sf_created
+		) RETURNING sf_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalSecretFinding(finding))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind secret finding object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&finding.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert secret finding query failed")
+	}
+
+	return nil
+}
+
+// Count returns count of the secret findings of a repository.
+func (s *SecretFindingStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.SecretFindingFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("secret_findings").
+		Where("sf_repo_id = ?", repoID)
+
+	stmt = applySecretFindingFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count secret findings query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count secret findings query")
+	}
+
+	return count, nil
+}
+
+// List returns the secret findings of a repository, the most recent first.
+func (s *SecretFindingStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.SecretFindingFilter,
+) ([]*types.SecretFinding, error) {
+	stmt := database.Builder.
+		Select(secretFindingColumns).
+		From("secret_findings").
+		Where("sf_repo_id = ?", repoID)
+
+	stmt = applySecretFindingFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("sf_created DESC", "sf_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list secret findings query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*secretFinding
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list secret findings query")
+	}
+
+	return s.mapToSecretFindings(ctx, dst), nil
+}
+
+func applySecretFindingFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.SecretFindingFilter,
+) squirrel.SelectBuilder {
+	if filter.ScanID > 0 {
+		stmt = stmt.Where("sf_scan_id = ?", filter.ScanID)
+	}
+
+	if filter.Source != "" {
+		stmt = stmt.Where("sf_source = ?", filter.Source)
+	}
+
+	return stmt
+}
+
+func (s *SecretFindingStore) mapToSecretFindings(
+	ctx context.Context,
+	in []*secretFinding,
+) []*types.SecretFinding {
+	res := make([]*types.SecretFinding, len(in))
+	for i := range in {
+		res[i] = s.mapToSecretFinding(ctx, in[i])
+	}
+	return res
+}
+
+func (s *SecretFindingStore) mapToSecretFinding(
+	ctx context.Context,
+	in *secretFinding,
+) *types.SecretFinding {
+	f := &types.SecretFinding{
+		ID:                  in.ID,
+		RepoID:              in.RepoID,
+		ScanID:              in.ScanID.Ptr(),
+		Source:              in.Source,
+		CommitSHA:           in.CommitSHA,
+		Path:                in.Path,
+		Line:                in.Line,
+		Rule:                in.Rule,
+		Fingerprint:         in.Fingerprint,
+		Secret:              in.Secret,
+		BypassJustification: in.BypassJustification,
+		CreatedBy:           in.CreatedBy,
+		Created:             in.Created,
+	}
+
+	author, err := s.pCache.Get(ctx, in.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load secret finding author")
+	}
+
+	if author != nil {
+		f.Author = *author
+	}
+
+	return f
+}
+
+func mapToInternalSecretFinding(in *types.SecretFinding) *secretFinding {
+	return &secretFinding{
+		ID:                  in.ID,
+		RepoID:              in.RepoID,
+		ScanID:              null.IntFromPtr(in.ScanID),
+		Source:              in.Source,
+		CommitSHA:           in.CommitSHA,
+		Path:                in.Path,
+		Line:                in.Line,
+		Rule:                in.Rule,
+		Fingerprint:         in.Fingerprint,
+		Secret:              in.Secret,
+		BypassJustification: in.BypassJustification,
+		CreatedBy:           in.CreatedBy,
+		Created:             in.Created,
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SecretScanStore = (*SecretScanStore)(nil)
+
+// NewSecretScanStore returns a new SecretScanStore.
+func NewSecretScanStore(db *sqlx.DB) *SecretScanStore {
+	return &SecretScanStore{
+		db: db,
+	}
+}
+
+// SecretScanStore implements store.SecretScanStore backed by a relational database.
+type SecretScanStore struct {
+	db *sqlx.DB
+}
+
+type secretScan struct {
+	ID        int64                 `db:"ss_id"`
+	RepoID    int64                 `db:"ss_repo_id"`
+	Status    enum.SecretScanStatus `db:"ss_status"`
+	Error     string                `db:"ss_error"`
+	Findings  int64                 `db:"ss_findings"`
+	CreatedBy int64                 `db:"ss_created_by"`
+	Created   int64                 `db:"ss_created"`
+	Updated   int64                 `db:"ss_updated"`
+}
+
+const (
+	secretScanColumns = `
+		 ss_id
+		,ss_repo_id
+		,ss_status
+		,ss_error
+		,ss_findings
+		,ss_created_by
+		,ss_created
+		,ss_updated`
+)
+
+// Find finds the secret scan by id.
+func (s *SecretScanStore) Find(ctx context.Context, id int64) (*types.SecretScan, error) {
+	stmt := database.Builder.
+		Select(secretScanColumns).
+		From("secret_scans").
+		Where("ss_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find secret scan query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &secretScan{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find secret scan")
+	}
+
+	return mapToSecretScan(dst), nil
+}
+
+// Create creates a new secret scan.
+func (s *SecretScanStore) Create(ctx context.Context, scan *types.SecretScan) error {
+	const sqlQuery = `
+		INSERT INTO secret_scans (
+			 ss_repo_id
+			,ss_status
+			,ss_error
+			,ss_findings
+			,ss_created_by
+			,ss_created
+			,ss_updated
+		) values (
+			 :ss_repo_id
+			,:ss_status
+			,:ss_error
+			,:ss_findings
+			,:ss_created_by
+			,:ss_created
+			,:ss_updated
+		) RETURNING ss_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalSecretScan(scan))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind secret scan object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&scan.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert secret scan query failed")
+	}
+
+	return nil
+}
+
+// UpdateStatus updates the status, the error and the number of findings of the secret scan.
+func (s *SecretScanStore) UpdateStatus(ctx context.Context, scan *types.SecretScan) error {
+	const sqlQuery = `
+		UPDATE secret_scans
+		SET
+			 ss_status = :ss_status
+			,ss_error = :ss_error
+			,ss_findings = :ss_findings
+			,ss_updated = :ss_updated
+		WHERE ss_id = :ss_id`
+
+	dbObj := mapToInternalSecretScan(scan)
+	dbObj.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbObj)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind secret scan object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update secret scan status")
+	}
+
+	scan.Updated = dbObj.Updated
+
+	return nil
+}
+
+// Count returns count of the secret scans of a repository.
+func (s *SecretScanStore) Count(ctx context.Context, repoID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("secret_scans").
+		Where("ss_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count secret scans query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count secret scans query")
+	}
+
+	return count, nil
+}
+
+// List returns the secret scans of a repository, the most recent first.
+func (s *SecretScanStore) List(
+	ctx context.Context,
+	repoID int64,
+	pagination types.Pagination,
+) ([]*types.SecretScan, error) {
+	stmt := database.Builder.
+		Select(secretScanColumns).
+		From("secret_scans").
+		Where("ss_repo_id = ?", repoID).
+		Limit(database.Limit(pagination.Size)).
+		Offset(database.Offset(pagination.Page, pagination.Size)).
+		OrderBy("ss_created DESC", "ss_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list secret scans query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*secretScan
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list secret scans query")
+	}
+
+	res := make([]*types.SecretScan, len(dst))
+	for i := range dst {
+		res[i] = mapToSecretScan(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToSecretScan(in *secretScan) *types.SecretScan {
+	return &types.SecretScan{
+		ID:        in.ID,
+		RepoID:    in.RepoID,
+		Status:    in.Status,
+		Error:     in.Error,
+		Findings:  in.Findings,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Updated:   in.Updated,
+	}
+}
+
+func mapToInternalSecretScan(in *types.SecretScan) *secretScan {
+	return &secretScan{
+		ID:        in.ID,
+		RepoID:    in.RepoID,
+		Status:    in.Status,
+		Error:     in.Error,
+		Findings:  in.Findings,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Updated:   in.Updated,
+	}
+}
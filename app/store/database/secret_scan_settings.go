@@ -0,0 +1,275 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SecretScanSettingsStore = (*SecretScanSettingsStore)(nil)
+
+// NewSecretScanSettingsStore returns a new SecretScanSettingsStore.
+func NewSecretScanSettingsStore(db *sqlx.DB) *SecretScanSettingsStore {
+	return &SecretScanSettingsStore{
+		db: db,
+	}
+}
+
+// SecretScanSettingsStore implements store.SecretScanSettingsStore backed by a relational database.
+type SecretScanSettingsStore struct {
+	db *sqlx.DB
+}
+
+type secretScanSettings struct {
+	ID        int64               `db:"sss_id"`
+	SpaceID   null.Int            `db:"sss_space_id"`
+	RepoID    null.Int            `db:"sss_repo_id"`
+	Mode      enum.SecretScanMode `db:"sss_mode"`
+	Rules     string              `db:"sss_rules"`
+	Allowlist string              `db:"sss_allowlist"`
+	CreatedBy int64               `db:"sss_created_by"`
+	Created   int64               `db:"sss_created"`
+	Updated   int64               `db:"sss_updated"`
+}
+
+const (
+	secretScanSettingsColumns = `
+		 sss_id
+		,sss_space_id
+		,sss_repo_id
+		,sss_mode
+		,sss_rules
+		,sss_allowlist
+		,sss_created_by
+		,sss_created
+		,sss_updated`
+)
+
+// FindBySpace finds the secret scanning settings of a space.
+func (s *SecretScanSettingsStore) FindBySpace(ctx context.Context, spaceID int64) (*types.SecretScanSettings, error) {
+	return s.find(ctx, squirrel.Eq{"sss_space_id": spaceID})
+}
+
+// FindByRepo finds the secret scanning settings of a repository.
+func (s *SecretScanSettingsStore) FindByRepo(ctx context.Context, repoID int64) (*types.SecretScanSettings, error) {
+	return s.find(ctx, squirrel.Eq{"sss_repo_id": repoID})
+}
+
+func (s *SecretScanSettingsStore) find(ctx context.Context, cond squirrel.Sqlizer) (*types.SecretScanSettings, error) {
+	stmt := database.Builder.
+		Select(secretScanSettingsColumns).
+		From("secret_scan_settings").
+		Where(cond)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find secret scan settings query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &secretScanSettings{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find secret scan settings")
+	}
+
+	return mapToSecretScanSettings(dst)
+}
+
+// List returns the secret scanning settings of the repository and of the spaces.
+func (s *SecretScanSettingsStore) List(
+	ctx context.Context,
+	repoID int64,
+	spaceIDs []int64,
+) ([]*types.SecretScanSettings, error) {
+	stmt := database.Builder.
+		Select(secretScanSettingsColumns).
+		From("secret_scan_settings").
+		Where(squirrel.Or{
+			squirrel.Eq{"sss_repo_id": repoID},
+			squirrel.Eq{"sss_space_id": spaceIDs},
+		})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list secret scan settings query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*secretScanSettings
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list secret scan settings query")
+	}
+
+	res := make([]*types.SecretScanSettings, len(dst))
+	for i := range dst {
+		if res[i], err = mapToSecretScanSettings(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// Create creates new secret scanning settings of a space or of a repository.
+func (s *SecretScanSettingsStore) Create(ctx context.Context, settings *types.SecretScanSettings) error {
+	const sqlQuery = `
+		INSERT INTO secret_scan_settings (
+			 sss_space_id
+			,sss_repo_id
+			,sss_mode
+			,sss_rules
+			,sss_allowlist
+			,sss_created_by
+			,sss_created
+			,sss_updated
+		) values (
+			 :sss_space_id
+			,:sss_repo_id
+			,:sss_mode
+			,:sss_rules
+			,:sss_allowlist
+			,:sss_created_by
+			,:sss_created
+			,:sss_updated
+		) RETURNING sss_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbObj, err := mapToInternalSecretScanSettings(settings)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbObj)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind secret scan settings object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&settings.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert secret scan settings query failed")
+	}
+
+	return nil
+}
+
+// Update updates the secret scanning settings.
+func (s *SecretScanSettingsStore) Update(ctx context.Context, settings *types.SecretScanSettings) error {
+	const sqlQuery = `
+		UPDATE secret_scan_settings
+		SET
+			 sss_mode = :sss_mode
+			,sss_rules = :sss_rules
+			,sss_allowlist = :sss_allowlist
+			,sss_updated = :sss_updated
+		WHERE sss_id = :sss_id`
+
+	dbObj, err := mapToInternalSecretScanSettings(settings)
+	if err != nil {
+		return err
+	}
+
+	dbObj.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbObj)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind secret scan settings object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update secret scan settings")
+	}
+
+	settings.Updated = dbObj.Updated
+
+	return nil
+}
+
+// Delete deletes the secret scanning settings.
+func (s *SecretScanSettingsStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM secret_scan_settings
+		WHERE sss_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "the delete secret scan settings query failed")
+	}
+
+	return nil
+}
+
+func mapToSecretScanSettings(in *secretScanSettings) (*types.SecretScanSettings, error) {
+	settings := &types.SecretScanSettings{
+		ID:        in.ID,
+		SpaceID:   in.SpaceID.Ptr(),
+		RepoID:    in.RepoID.Ptr(),
+		Mode:      in.Mode,
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Updated:   in.Updated,
+	}
+
+	if err := json.Unmarshal([]byte(in.Rules), &settings.Rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secret scan rules: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(in.Allowlist), &settings.Allowlist); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secret scan allowlist: %w", err)
+	}
+
+	return settings, nil
+}
+
+func mapToInternalSecretScanSettings(in *types.SecretScanSettings) (*secretScanSettings, error) {
+	rules, err := json.Marshal(in.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal secret scan rules: %w", err)
+	}
+
+	allowlist, err := json.Marshal(in.Allowlist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal secret scan allowlist: %w", err)
+	}
+
+	return &secretScanSettings{
+		ID:        in.ID,
+		SpaceID:   null.IntFromPtr(in.SpaceID),
+		RepoID:    null.IntFromPtr(in.RepoID),
+		Mode:      in.Mode,
+		Rules:     string(rules),
+		Allowlist: string(allowlist),
+		CreatedBy: in.CreatedBy,
+		Created:   in.Created,
+		Updated:   in.Updated,
+	}, nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ServerSigningKeyStore = (*ServerSigningKeyStore)(nil)
+
+// NewServerSigningKeyStore returns a new ServerSigningKeyStore.
+func NewServerSigningKeyStore(db *sqlx.DB) *ServerSigningKeyStore {
+	return &ServerSigningKeyStore{
+		db: db,
+	}
+}
+
+// ServerSigningKeyStore implements store.ServerSigningKeyStore backed by a relational database.
+type ServerSigningKeyStore struct {
+	db *sqlx.DB
+}
+
+type serverSigningKey struct {
+	ID          int64               `db:"server_signing_key_id"`
+	SpaceID     null.Int            `db:"server_signing_key_space_id"`
+	Created     int64               `db:"server_signing_key_created"`
+	Updated     int64               `db:"server_signing_key_updated"`
+	CreatedBy   int64               `db:"server_signing_key_created_by"`
+	Type        enum.SigningKeyType `db:"server_signing_key_type"`
+	PublicKey   string              `db:"server_signing_key_public_key"`
+	PrivateKey  string              `db:"server_signing_key_private_key"`
+	Fingerprint string              `db:"server_signing_key_fingerprint"`
+}
+
+const (
+	serverSigningKeyColumns = `
+		 server_signing_key_id
+		,server_signing_key_space_id
+		,server_signing_key_created
+		,server_signing_key_updated
+		,server_signing_key_created_by
+		,server_signing_key_type
+		,server_signing_key_public_key
+		,server_signing_key_private_key
+		,server_signing_key_fingerprint`
+)
+
+// Find finds the signing key of a space, or the instance-level key if the space ID is nil.
+func (s *ServerSigningKeyStore) Find(ctx context.Context, spaceID *int64) (*types.ServerSigningKey, error) {
+	stmt := database.Builder.
+		Select(serverSigningKeyColumns).
+		From("server_signing_keys")
+
+	stmt = whereServerSigningKeySpace(stmt, spaceID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find server signing key query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &serverSigningKey{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find server signing key")
+	}
+
+	return mapToServerSigningKey(dst), nil
+}
+
+// Create creates a new server signing key.
+func (s *ServerSigningKeyStore) Create(ctx context.Context, key *types.ServerSigningKey) error {
+	const sqlQuery = `
+		INSERT INTO server_signing_keys (
+			 server_signing_key_space_id
+			,server_signing_key_created
+			,server_signing_key_updated
+			,server_signing_key_created_by
+			,server_signing_key_type
+			,server_signing_key_public_key
+			,server_signing_key_private_key
+			,server_signing_key_fingerprint
+		) values (
+			 :server_signing_key_space_id
+			,:server_signing_key_created
+			,:server_signing_key_updated
+			,:server_signing_key_created_by
+			,:server_signing_key_type
+			,:server_signing_key_public_key
+			,:server_signing_key_private_key
+			,:server_signing_key_fingerprint
+		) RETURNING server_signing_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalServerSigningKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind server signing key")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert server signing key query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the signing key of a space, or the instance-level key if the space ID is nil.
+func (s *ServerSigningKeyStore) Delete(ctx context.Context, spaceID *int64) error {
+	stmt := database.Builder.
+		Delete("server_signing_keys")
+
+	if spaceID == nil {
+		stmt = stmt.Where("server_signing_key_space_id IS NULL")
+	} else {
+		stmt = stmt.Where("server_signing_key_space_id = ?", *spaceID)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert delete server signing key query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete server signing key query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted server signing keys")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns all server signing keys.
+func (s *ServerSigningKeyStore) List(ctx context.Context) ([]*types.ServerSigningKey, error) {
+	stmt := database.Builder.
+		Select(serverSigningKeyColumns).
+		From("server_signing_keys").
+		OrderBy("server_signing_key_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list server signing keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*serverSigningKey
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list server signing keys query")
+	}
+
+	keys := make([]*types.ServerSigningKey, len(dst))
+	for i, key := range dst {
+		keys[i] = mapToServerSigningKey(key)
+	}
+
+	return keys, nil
+}
+
+func whereServerSigningKeySpace(stmt squirrel.SelectBuilder, spaceID *int64) squirrel.SelectBuilder {
+	if spaceID == nil {
+		return stmt.Where("server_signing_key_space_id IS NULL")
+	}
+
+	return stmt.Where("server_signing_key_space_id = ?", *spaceID)
+}
+
+func mapToInternalServerSigningKey(key *types.ServerSigningKey) *serverSigningKey {
+	return &serverSigningKey{
+		ID:          key.ID,
+		SpaceID:     null.IntFromPtr(key.SpaceID),
+		Created:     key.Created,
+		Updated:     key.Updated,
+		CreatedBy:   key.CreatedBy,
+		Type:        key.Type,
+		PublicKey:   key.PublicKey,
+		PrivateKey:  key.PrivateKey,
+		Fingerprint: key.Fingerprint,
+	}
+}
+
+func mapToServerSigningKey(key *serverSigningKey) *types.ServerSigningKey {
+	return &types.ServerSigningKey{
+		ID:          key.ID,
+		SpaceID:     key.SpaceID.Ptr(),
+		Created:     key.Created,
+		Updated:     key.Updated,
+		CreatedBy:   key.CreatedBy,
+		Type:        key.Type,
+		PublicKey:   key.PublicKey,
+		PrivateKey:  key.PrivateKey,
+		Fingerprint: key.Fingerprint,
+	}
+}
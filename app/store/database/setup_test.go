@@ -93,7 +93,7 @@ func createUser(
 
 	uid := "user_" + strconv.FormatInt(userID, 10)
 	if err := principalStore.CreateUser(*ctx,
-		&types.User{ID: userID, UID: uid}); err != nil {
+		&types.User{ID: userID, UID: uid, Email: uid + "@example.com"}); err != nil {
 		t.Fatalf("failed to create user %v", err)
 	}
 }
@@ -0,0 +1,215 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SigningKeyStore = (*SigningKeyStore)(nil)
+
+// NewSigningKeyStore returns a new SigningKeyStore.
+func NewSigningKeyStore(db *sqlx.DB) *SigningKeyStore {
+	return &SigningKeyStore{
+		db: db,
+	}
+}
+
+// SigningKeyStore implements store.SigningKeyStore backed by a relational database.
+type SigningKeyStore struct {
+	db *sqlx.DB
+}
+
+type signingKey struct {
+	ID          int64               `db:"signing_key_id"`
+	PrincipalID int64               `db:"signing_key_principal_id"`
+	Created     int64               `db:"signing_key_created"`
+	Identifier  string              `db:"signing_key_identifier"`
+	Type        enum.SigningKeyType `db:"signing_key_type"`
+	Content     string              `db:"signing_key_content"`
+	Fingerprint string              `db:"signing_key_fingerprint"`
+}
+
+const (
+	signingKeyColumns = `
+		 signing_key_id
+		,signing_key_principal_id
+		,signing_key_created
+		,signing_key_identifier
+		,signing_key_type
+		,signing_key_content
+		,signing_key_fingerprint`
+)
+
+// FindByIdentifier finds a signing key of a principal by its identifier.
+func (s *SigningKeyStore) FindByIdentifier(
+	ctx context.Context,
+	principalID int64,
+	identifier string,
+) (*types.SigningKey, error) {
+	stmt := database.Builder.
+		Select(signingKeyColumns).
+		From("signing_keys").
+		Where("signing_key_principal_id = ?", principalID).
+		Where("LOWER(signing_key_identifier) = ?", strings.ToLower(identifier))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert find signing key query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &signingKey{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find signing key")
+	}
+
+	return mapToSigningKey(dst), nil
+}
+
+// Create creates a new signing key.
+func (s *SigningKeyStore) Create(ctx context.Context, key *types.SigningKey) error {
+	const sqlQuery = `
+		INSERT INTO signing_keys (
+			 signing_key_principal_id
+			,signing_key_created
+			,signing_key_identifier
+			,signing_key_type
+			,signing_key_content
+			,signing_key_fingerprint
+		) values (
+			 :signing_key_principal_id
+			,:signing_key_created
+			,:signing_key_identifier
+			,:signing_key_type
+			,:signing_key_content
+			,:signing_key_fingerprint
+		) RETURNING signing_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalSigningKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind signing key")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert signing key query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the signing key with the given id.
+func (s *SigningKeyStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM signing_keys
+		WHERE signing_key_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Delete signing key query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of deleted signing keys")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns all signing keys of a principal, optionally only the keys of the provided type.
+func (s *SigningKeyStore) List(
+	ctx context.Context,
+	principalID int64,
+	keyType enum.SigningKeyType,
+) ([]*types.SigningKey, error) {
+	stmt := database.Builder.
+		Select(signingKeyColumns).
+		From("signing_keys").
+		Where("signing_key_principal_id = ?", principalID).
+		OrderBy("signing_key_created DESC")
+
+	if keyType != "" {
+		stmt = stmt.Where("signing_key_type = ?", keyType)
+	}
+
+	return s.list(ctx, stmt)
+}
+
+func (s *SigningKeyStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.SigningKey, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list signing keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*signingKey
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list signing keys query")
+	}
+
+	keys := make([]*types.SigningKey, len(dst))
+	for i, key := range dst {
+		keys[i] = mapToSigningKey(key)
+	}
+
+	return keys, nil
+}
+
+func mapToInternalSigningKey(key *types.SigningKey) *signingKey {
+	return &signingKey{
+		ID:          key.ID,
+		PrincipalID: key.PrincipalID,
+		Created:     key.Created,
+		Identifier:  key.Identifier,
+		Type:        key.Type,
+		Content:     key.Content,
+		Fingerprint: key.Fingerprint,
+	}
+}
+
+func mapToSigningKey(key *signingKey) *types.SigningKey {
+	return &types.SigningKey{
+		ID:          key.ID,
+		PrincipalID: key.PrincipalID,
+		Created:     key.Created,
+		Identifier:  key.Identifier,
+		Type:        key.Type,
+		Content:     key.Content,
+		Fingerprint: key.Fingerprint,
+	}
+}
@@ -35,6 +35,11 @@ var WireSet = wire.NewSet(
 	ProvideSpaceStore,
 	ProvideRepoStore,
 	ProvideRuleStore,
+	ProvideRuleMonitorViolationStore,
+	ProvideSecretScanSettingsStore,
+	ProvideSecretScanStore,
+	ProvideSecretFindingStore,
+	ProvideAuditEventStore,
 	ProvideJobStore,
 	ProvideExecutionStore,
 	ProvidePipelineStore,
@@ -57,6 +62,20 @@ var WireSet = wire.NewSet(
 	ProvideTemplateStore,
 	ProvideTriggerStore,
 	ProvidePluginStore,
+	ProvideLFSObjectStore,
+	ProvideLFSLockStore,
+	ProvidePublicKeyStore,
+	ProvideSigningKeyStore,
+	ProvideServerSigningKeyStore,
+	ProvideRepoMirrorStore,
+	ProvideMergeQueueStore,
+	ProvideLabelStore,
+	ProvidePullReqLabelStore,
+	ProvideIssueStore,
+	ProvideIssueActivityStore,
+	ProvideIssueAssigneeStore,
+	ProvideIssueLabelStore,
+	ProvideMilestoneStore,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -119,6 +138,40 @@ func ProvideRuleStore(
 	return NewRuleStore(db, principalInfoCache)
 }
 
+// ProvideRuleMonitorViolationStore provides a rule monitor violation store.
+func ProvideRuleMonitorViolationStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.RuleMonitorViolationStore {
+	return NewRuleMonitorViolationStore(db, principalInfoCache)
+}
+
+// ProvideSecretScanSettingsStore provides a secret scanning settings store.
+func ProvideSecretScanSettingsStore(db *sqlx.DB) store.SecretScanSettingsStore {
+	return NewSecretScanSettingsStore(db)
+}
+
+// ProvideSecretScanStore provides a secret scan store.
+func ProvideSecretScanStore(db *sqlx.DB) store.SecretScanStore {
+	return NewSecretScanStore(db)
+}
+
+// ProvideSecretFindingStore provides a secret finding store.
+func ProvideSecretFindingStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.SecretFindingStore {
+	return NewSecretFindingStore(db, principalInfoCache)
+}
+
+// ProvideAuditEventStore provides an audit event store.
+func ProvideAuditEventStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.AuditEventStore {
+	return NewAuditEventStore(db, principalInfoCache)
+}
+
 // ProvideJobStore provides a job store.
 func ProvideJobStore(db *sqlx.DB) job.Store {
 	return NewJobStore(db)
@@ -239,3 +292,73 @@ func ProvideCheckStore(db *sqlx.DB,
 ) store.CheckStore {
 	return NewCheckStore(db, principalInfoCache)
 }
+
+// ProvideLFSObjectStore provides an LFS object store.
+func ProvideLFSObjectStore(db *sqlx.DB) store.LFSObjectStore {
+	return NewLFSObjectStore(db)
+}
+
+// ProvideLFSLockStore provides an LFS lock store.
+func ProvideLFSLockStore(db *sqlx.DB) store.LFSLockStore {
+	return NewLFSLockStore(db)
+}
+
+// ProvidePublicKeyStore provides a public key store.
+func ProvidePublicKeyStore(db *sqlx.DB) store.PublicKeyStore {
+	return NewPublicKeyStore(db)
+}
+
+// ProvideSigningKeyStore provides a signing key store.
+func ProvideSigningKeyStore(db *sqlx.DB) store.SigningKeyStore {
+	return NewSigningKeyStore(db)
+}
+
+// ProvideServerSigningKeyStore provides a server signing key store.
+func ProvideServerSigningKeyStore(db *sqlx.DB) store.ServerSigningKeyStore {
+	return NewServerSigningKeyStore(db)
+}
+
+// ProvideRepoMirrorStore provides a repository mirror store.
+func ProvideRepoMirrorStore(db *sqlx.DB) store.RepoMirrorStore {
+	return NewRepoMirrorStore(db)
+}
+
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
+// ProvideLabelStore provides a label store.
+func ProvideLabelStore(db *sqlx.DB) store.LabelStore {
+	return NewLabelStore(db)
+}
+
+// ProvidePullReqLabelStore provides a pull request label store.
+func ProvidePullReqLabelStore(db *sqlx.DB) store.PullReqLabelStore {
+	return NewPullReqLabelStore(db)
+}
+
+// ProvideIssueStore provides an issue store.
+func ProvideIssueStore(db *sqlx.DB, principalInfoCache store.PrincipalInfoCache) store.IssueStore {
+	return NewIssueStore(db, principalInfoCache)
+}
+
+// ProvideIssueActivityStore provides an issue activity store.
+func ProvideIssueActivityStore(db *sqlx.DB, principalInfoCache store.PrincipalInfoCache) store.IssueActivityStore {
+	return NewIssueActivityStore(db, principalInfoCache)
+}
+
+// ProvideIssueAssigneeStore provides an issue assignee store.
+func ProvideIssueAssigneeStore(db *sqlx.DB, principalInfoCache store.PrincipalInfoCache) store.IssueAssigneeStore {
+	return NewIssueAssigneeStore(db, principalInfoCache)
+}
+
+// ProvideIssueLabelStore provides an issue label store.
+func ProvideIssueLabelStore(db *sqlx.DB) store.IssueLabelStore {
+	return NewIssueLabelStore(db)
+}
+
+// ProvideMilestoneStore provides a milestone store.
+func ProvideMilestoneStore(db *sqlx.DB) store.MilestoneStore {
+	return NewMilestoneStore(db)
+}
@@ -22,10 +22,16 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/harness/gitness/app/api/middleware/clientinfo"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/issue"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/secretscan"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
@@ -36,6 +42,7 @@ import (
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/kelseyhightower/envconfig"
 	"golang.org/x/text/runes"
@@ -44,10 +51,13 @@ import (
 )
 
 const (
-	schemeHTTP     = "http"
-	schemeHTTPS    = "https"
-	gitnessHomeDir = ".gitness"
-	blobDir        = "blob"
+	schemeHTTP       = "http"
+	schemeHTTPS      = "https"
+	gitnessHomeDir   = ".gitness"
+	blobDir          = "blob"
+	sshDir           = "ssh"
+	sshHostKeyFile   = "host_ed25519"
+	keywordSearchDir = "keywordsearch"
 )
 
 // LoadConfig returns the system configuration from the
@@ -69,6 +79,10 @@ func LoadConfig() (*types.Config, error) {
 		return nil, fmt.Errorf("failed to backfil urls: %w", err)
 	}
 
+	if _, err = clientinfo.ParseTrustedProxies(config.Server.HTTP.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
 	if config.Git.HookPath == "" {
 		executablePath, err := os.Executable()
 		if err != nil {
@@ -95,6 +109,14 @@ func LoadConfig() (*types.Config, error) {
 		}
 	}
 
+	if config.Server.SSH.HostKeyPath == "" {
+		config.Server.SSH.HostKeyPath = filepath.Join(config.Git.Root, sshDir, sshHostKeyFile)
+	}
+
+	if config.KeywordSearch.IndexPath == "" {
+		config.KeywordSearch.IndexPath = filepath.Join(config.Git.Root, keywordSearchDir)
+	}
+
 	return config, nil
 }
 
@@ -345,6 +367,7 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		AuditEventsRetentionTime:         config.Audit.RetentionTime,
 	}
 }
 
@@ -361,6 +384,45 @@ func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.KeywordSearch.Concurrency,
 		MaxRetries:      config.KeywordSearch.MaxRetries,
+		IndexPath:       config.KeywordSearch.IndexPath,
+	}
+}
+
+// ProvideAutoMergeConfig loads the auto-merge service config from the main config.
+func ProvideAutoMergeConfig(config *types.Config) automerge.Config {
+	return automerge.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.AutoMerge.Concurrency,
+		MaxRetries:      config.AutoMerge.MaxRetries,
+	}
+}
+
+// ProvideIssueConfig loads the issue service config from the main config.
+func ProvideIssueConfig(config *types.Config) issue.Config {
+	return issue.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Issues.Concurrency,
+		MaxRetries:      config.Issues.MaxRetries,
+	}
+}
+
+// ProvideMergeQueueConfig loads the merge queue service config from the main config.
+func ProvideMergeQueueConfig(config *types.Config) mergequeue.Config {
+	return mergequeue.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.MergeQueue.Concurrency,
+		MaxRetries:      config.MergeQueue.MaxRetries,
+	}
+}
+
+// ProvideMirrorConfig loads the repository mirror service config from the main config.
+func ProvideMirrorConfig(config *types.Config) mirror.Config {
+	return mirror.Config{
+		MinInterval:         config.Mirror.MinInterval,
+		DefaultInterval:     config.Mirror.DefaultInterval,
+		MaxDuration:         config.Mirror.MaxDuration,
+		AllowLoopback:       config.Mirror.AllowLoopback,
+		AllowPrivateNetwork: config.Mirror.AllowPrivateNetwork,
 	}
 }
 
@@ -371,3 +433,11 @@ func ProvideJobsConfig(config *types.Config) job.Config {
 		BackgroundJobsRetentionTime: config.BackgroundJobs.RetentionTime,
 	}
 }
+
+// ProvideSecretScanConfig loads the secret scanning service config from the main config.
+func ProvideSecretScanConfig(config *types.Config) secretscan.Config {
+	return secretscan.Config{
+		DefaultMode:     enum.SecretScanMode(config.SecretScan.DefaultMode),
+		MaxScanDuration: config.SecretScan.MaxScanDuration,
+	}
+}
@@ -105,12 +105,32 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.Mirror.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register mirror service")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
 	// start server
 	gHTTP, shutdownHTTP := system.server.ListenAndServe()
 	g.Go(gHTTP.Wait)
+
+	// start ssh server
+	shutdownSSH := func(context.Context) error { return nil }
+	if config.Server.SSH.Enabled {
+		var gSSH *errgroup.Group
+		gSSH, shutdownSSH, err = system.sshServer.ListenAndServe()
+		if err != nil {
+			return fmt.Errorf("failed to start ssh server: %w", err)
+		}
+		g.Go(gSSH.Wait)
+
+		log.Info().
+			Int("port", config.Server.SSH.Port).
+			Msg("ssh server started")
+	}
 	if c.enableCI {
 		// start populating plugins
 		g.Go(func() error {
@@ -152,6 +172,10 @@ func (c *command) run(*kingpin.ParseContext) error {
 		log.Err(sErr).Msg("failed to shutdown http server gracefully")
 	}
 
+	if sErr := shutdownSSH(shutdownCtx); sErr != nil {
+		log.Err(sErr).Msg("failed to shutdown ssh server gracefully")
+	}
+
 	system.services.JobScheduler.WaitJobsDone(shutdownCtx)
 
 	log.Info().Msg("wait for subroutines to complete")
@@ -10,18 +10,23 @@ package main
 import (
 	"context"
 
+	controlleraudit "github.com/harness/gitness/app/api/controller/audit"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
+	controllerissue "github.com/harness/gitness/app/api/controller/issue"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
+	controllermirror "github.com/harness/gitness/app/api/controller/mirror"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/secret"
+	controllersecretscan "github.com/harness/gitness/app/api/controller/secretscan"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -35,7 +40,9 @@ import (
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	issueevents "github.com/harness/gitness/app/events/issue"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
@@ -51,22 +58,32 @@ import (
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	issueservice "github.com/harness/gitness/app/services/issue"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/app/services/signature"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/sshserver"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
@@ -107,6 +124,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		pullreqservice.WireSet,
 		services.WireSet,
 		server.WireSet,
+		sshserver.WireSet,
 		url.WireSet,
 		space.WireSet,
 		limiter.WireSet,
@@ -116,11 +134,13 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		serviceaccount.WireSet,
 		user.WireSet,
 		upload.WireSet,
+		lfs.WireSet,
 		service.WireSet,
 		principal.WireSet,
 		system.WireSet,
 		authn.WireSet,
 		authz.WireSet,
+		checkevents.WireSet,
 		gitevents.WireSet,
 		pullreqevents.WireSet,
 		repoevents.WireSet,
@@ -149,6 +169,9 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cleanup.WireSet,
 		codecomments.WireSet,
 		protection.WireSet,
+		rules.WireSet,
+		signature.WireSet,
+		label.WireSet,
 		checkcontroller.WireSet,
 		execution.WireSet,
 		pipeline.WireSet,
@@ -179,6 +202,22 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
 		controllerkeywordsearch.WireSet,
+		cliserver.ProvideMirrorConfig,
+		mirror.WireSet,
+		controllermirror.WireSet,
+		cliserver.ProvideSecretScanConfig,
+		secretscan.WireSet,
+		controllersecretscan.WireSet,
+		audit.WireSet,
+		controlleraudit.WireSet,
+		mergequeue.WireSet,
+		cliserver.ProvideMergeQueueConfig,
+		automerge.WireSet,
+		cliserver.ProvideAutoMergeConfig,
+		issueevents.WireSet,
+		issueservice.WireSet,
+		cliserver.ProvideIssueConfig,
+		controllerissue.WireSet,
 		usergroup.WireSet,
 		openapi.WireSet,
 	)
@@ -9,18 +9,23 @@ package main
 import (
 	"context"
 
+	audit2 "github.com/harness/gitness/app/api/controller/audit"
 	check2 "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
+	issue2 "github.com/harness/gitness/app/api/controller/issue"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/mirror"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	pullreq2 "github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/secret"
+	secretscan2 "github.com/harness/gitness/app/api/controller/secretscan"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -34,8 +39,10 @@ import (
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
-	events4 "github.com/harness/gitness/app/events/git"
-	events3 "github.com/harness/gitness/app/events/pullreq"
+	events3 "github.com/harness/gitness/app/events/check"
+	events5 "github.com/harness/gitness/app/events/git"
+	events6 "github.com/harness/gitness/app/events/issue"
+	events4 "github.com/harness/gitness/app/events/pullreq"
 	events2 "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/pipeline/canceler"
@@ -50,22 +57,32 @@ import (
 	"github.com/harness/gitness/app/router"
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/audit"
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/issue"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
+	mirror2 "github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/app/services/secretscan"
+	"github.com/harness/gitness/app/services/signature"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/sshserver"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
@@ -114,7 +131,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore)
+	publicKeyStore := database.ProvidePublicKeyStore(db)
+	signingKeyStore := database.ProvideSigningKeyStore(db)
+	auditEventStore := database.ProvideAuditEventStore(db, principalInfoCache)
+	auditService := audit.ProvideService(auditEventStore)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, signingKeyStore, auditService)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
 	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
@@ -125,10 +146,12 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore)
 	pipelineStore := database.ProvidePipelineStore(db)
 	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
-	protectionManager, err := protection.ProvideManager(ruleStore)
+	ruleMonitorViolationStore := database.ProvideRuleMonitorViolationStore(db, principalInfoCache)
+	protectionManager, err := protection.ProvideManager(ruleStore, ruleMonitorViolationStore)
 	if err != nil {
 		return nil, err
 	}
+	rulesService := rules.ProvideService(transactor, ruleStore, spaceStore, repoStore, ruleMonitorViolationStore, principalInfoCache, protectionManager, auditService)
 	typesConfig := server.ProvideGitConfig(config)
 	universalClient, err := server.ProvideRedis(config)
 	if err != nil {
@@ -165,12 +188,21 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	streamer := sse.ProvideEventsStreaming(pubSub)
-	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
+	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
+	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher(keywordsearchConfig, gitInterface)
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
 	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer)
 	if err != nil {
 		return nil, err
 	}
+	secretscanConfig := server.ProvideSecretScanConfig(config)
+	secretScanSettingsStore := database.ProvideSecretScanSettingsStore(db)
+	secretScanStore := database.ProvideSecretScanStore(db)
+	secretFindingStore := database.ProvideSecretFindingStore(db, principalInfoCache)
+	secretscanService, err := secretscan.ProvideService(secretscanConfig, transactor, jobScheduler, executor, secretScanSettingsStore, secretScanStore, secretFindingStore, spaceStore, repoStore, gitInterface)
+	if err != nil {
+		return nil, err
+	}
 	codeownersConfig := server.ProvideCodeOwnerConfig(config)
 	usergroupResolver := usergroup.ProvideUserGroupResolver()
 	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
@@ -187,9 +219,21 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, mutexManager)
-	executionStore := database.ProvideExecutionStore(db)
+	serverSigningKeyStore := database.ProvideServerSigningKeyStore(db)
+	signatureService := signature.ProvideService(config, transactor, encrypter, principalStore, spaceStore, signingKeyStore, serverSigningKeyStore)
+	labelStore := database.ProvideLabelStore(db)
+	pullReqLabelStore := database.ProvidePullReqLabelStore(db)
+	issueLabelStore := database.ProvideIssueLabelStore(db)
+	labelService := label.ProvideService(transactor, spaceStore, labelStore, pullReqLabelStore, issueLabelStore)
+	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
+	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, pullReqStore, pullReqReviewerStore, checkStore, protectionManager, rulesService, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, mutexManager, signatureService, labelService, secretscanService, auditService)
+	executionStore := database.ProvideExecutionStore(db)
+	eventsReporter, err := events3.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
 	stageStore := database.ProvideStageStore(db)
 	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
 	if err != nil {
@@ -202,8 +246,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	converterService := converter.ProvideService(fileService)
 	templateStore := database.ProvideTemplateStore(db)
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore)
-	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, eventsReporter, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore)
+	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, eventsReporter, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
 	logStore := logs.ProvideLogStore(db, config)
 	logStream := livelog.ProvideLogStream()
 	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
@@ -214,57 +258,89 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter)
+	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter, signatureService, labelService, rulesService, auditService)
 	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore)
 	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore)
 	connectorController := connector.ProvideController(connectorStore, authorizer, spaceStore)
 	templateController := template.ProvideController(templateStore, authorizer, spaceStore)
 	pluginController := plugin.ProvideController(pluginStore)
-	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
 	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
 	codeCommentView := database.ProvideCodeCommentView(db)
 	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
-	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
 	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
-	eventsReporter, err := events3.ProvideReporter(eventsSystem)
+	reporter2, err := events4.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	migrator := codecomments.ProvideMigrator(gitInterface)
-	readerFactory, err := events4.ProvideReaderFactory(eventsSystem)
+	readerFactory, err := events5.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	eventsReaderFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	eventsReaderFactory, err := events4.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	repoGitInfoView := database.ProvideRepoGitInfoView(db)
 	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter2, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
 	if err != nil {
 		return nil, err
 	}
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService)
+	mergequeueConfig := server.ProvideMergeQueueConfig(config)
+	mergeQueueStore := database.ProvideMergeQueueStore(db)
+	readerFactory2, err := events3.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	mergequeueService, err := mergequeue.ProvideService(ctx, mergequeueConfig, gitInterface, provider, mutexManager, mergeQueueStore, pullReqStore, repoStore, principalStore, pullReqActivityStore, pullReqReviewerStore, checkStore, protectionManager, codeownersService, signatureService, reporter2, streamer, readerFactory, eventsReaderFactory, readerFactory2)
+	if err != nil {
+		return nil, err
+	}
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, reporter2, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService, mergequeueService, signatureService, labelService, auditService)
+	issueStore := database.ProvideIssueStore(db, principalInfoCache)
+	issueActivityStore := database.ProvideIssueActivityStore(db, principalInfoCache)
+	issueAssigneeStore := database.ProvideIssueAssigneeStore(db, principalInfoCache)
+	milestoneStore := database.ProvideMilestoneStore(db)
+	issueConfig := server.ProvideIssueConfig(config)
+	reporter3, err := events6.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	issueService, err := issue.ProvideService(ctx, issueConfig, transactor, gitInterface, repoStore, pullReqStore, issueStore, issueActivityStore, issueAssigneeStore, milestoneStore, labelService, reporter3, streamer, eventsReaderFactory)
+	if err != nil {
+		return nil, err
+	}
+	issueController := issue2.ProvideController(transactor, authorizer, repoStore, principalStore, issueStore, issueActivityStore, issueAssigneeStore, milestoneStore, issueService, labelService, reporter3)
 	webhookConfig := server.ProvideWebhookConfig(config)
 	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
+	readerFactory3, err := events6.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, readerFactory3, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, issueStore, issueActivityStore, provider, principalStore, gitInterface, encrypter)
 	if err != nil {
 		return nil, err
 	}
 	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter)
-	reporter2, err := events4.ProvideReporter(eventsSystem)
+	mirrorConfig := server.ProvideMirrorConfig(config)
+	repoMirrorStore := database.ProvideRepoMirrorStore(db)
+	mirrorController := mirror.ProvideController(mirrorConfig, authorizer, repoStore, repoMirrorStore, encrypter)
+	secretscanController := secretscan2.ProvideController(authorizer, repoStore, spaceStore, secretscanService)
+	reporter4, err := events5.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
+	lfsLockStore := database.ProvideLFSLockStore(db)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter4, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, lfsLockStore, repoMirrorStore, signatureService, secretscanService)
+	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore, auditService)
 	principalController := principal.ProvideController(principalStore)
 	v := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
-	systemController := system.NewController(principalStore, config)
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v, eventsReporter)
+	systemController := system.NewController(principalStore, signatureService, config)
+	auditController := audit2.ProvideController(auditEventStore)
 	blobConfig, err := server.ProvideBlobStoreConfig(config)
 	if err != nil {
 		return nil, err
@@ -275,14 +351,17 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
-	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
-	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController)
-	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController)
+	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, indexer, repoController, spaceController)
+	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, issueController, webhookController, mirrorController, secretscanController, githookController, serviceaccountController, controller, principalController, checkController, systemController, auditController, uploadController, keywordsearchController)
+	lfsObjectStore := database.ProvideLFSObjectStore(db)
+	lfsController := lfs.ProvideController(config, authorizer, repoStore, principalInfoCache, lfsObjectStore, lfsLockStore, blobStore, provider)
+	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController, lfsController)
 	openapiService := openapi.ProvideOpenAPIService()
 	webHandler := router.ProvideWebHandler(config, openapiService)
 	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, provider)
 	serverServer := server2.ProvideServer(config, routerRouter)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore)
+	sshserverServer := sshserver.ProvideServer(config, publicKeyStore, principalStore, repoController)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, eventsReporter, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore)
 	client := manager.ProvideExecutionClient(executionManager, provider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
@@ -304,7 +383,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController, auditEventStore)
 	if err != nil {
 		return nil, err
 	}
@@ -315,12 +394,20 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
 	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, repoStore, indexer)
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, calculator, cleanupService, notificationService, keywordsearchService)
-	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, resolverManager, servicesServices)
+	mirrorService, err := mirror2.ProvideService(mirrorConfig, jobScheduler, executor, repoMirrorStore, repoStore, gitInterface, provider, encrypter)
+	if err != nil {
+		return nil, err
+	}
+	automergeConfig := server.ProvideAutoMergeConfig(config)
+	automergeService, err := automerge.ProvideService(ctx, automergeConfig, authorizer, pullreqController, pullReqStore, repoStore, principalStore, pullReqActivityStore, streamer, eventsReaderFactory, readerFactory2)
+	if err != nil {
+		return nil, err
+	}
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, calculator, cleanupService, notificationService, keywordsearchService, mirrorService, mergequeueService, automergeService, issueService)
+	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshserverServer, poller, resolverManager, servicesServices)
 	return serverSystem, nil
 }
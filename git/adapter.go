@@ -83,6 +83,8 @@ type Adapter interface {
 	IsAncestor(ctx context.Context, repoPath, ancestorCommitSHA, descendantCommitSHA string) (bool, error)
 	Blame(ctx context.Context, repoPath, rev, file string, lineFrom, lineTo int) types.BlameReader
 	Sync(ctx context.Context, repoPath string, source string, refSpecs []string) error
+	FetchObjects(ctx context.Context, repoPath string, source string, objectSHAs []string) error
+	Repack(ctx context.Context, repoPath string) error
 
 	//
 	// Diff operations
@@ -106,6 +108,14 @@ type Adapter interface {
 		sha string,
 		w io.Writer) error
 
+	Archive(ctx context.Context,
+		w io.Writer,
+		repoPath,
+		format,
+		treeish,
+		prefix string,
+		paths ...string) error
+
 	DiffShortStat(ctx context.Context,
 		repoPath string,
 		baseRef string,
@@ -143,6 +153,7 @@ type Adapter interface {
 		ctx context.Context,
 		repoPath string,
 		service string,
+		statelessRPC bool,
 		stdin io.Reader,
 		stdout io.Writer,
 		env ...string,
@@ -152,4 +163,23 @@ type Adapter interface {
 		baseRef string,
 		headRef string,
 		mergeBase bool) ([]string, error)
+	ListChangedPaths(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		revs []string) ([]string, error)
+	ListNewCommits(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		revs []string) ([]types.Commit, error)
+	FindOversizeFiles(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		revs []string,
+		sizeLimit int64) ([]types.FileInfo, error)
+	ListAddedLines(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		revs []string,
+		allCommits bool,
+		fn func(types.AddedLines) error) error
 }
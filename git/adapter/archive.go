@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/git/command"
+)
+
+// Archive streams the output of git archive for the provided tree-ish to the writer.
+func (a Adapter) Archive(
+	ctx context.Context,
+	w io.Writer,
+	repoPath string,
+	format string,
+	treeish string,
+	prefix string,
+	paths ...string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("archive",
+		command.WithFlag("--format="+format),
+	)
+	if prefix != "" {
+		cmd.Add(command.WithFlag("--prefix=" + prefix))
+	}
+	// git-archive(1) doesn't support "--", so paths are passed right after the tree-ish.
+	cmd.Add(command.WithArg(treeish))
+	cmd.Add(command.WithArg(paths...))
+
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(w))
+	if err != nil {
+		return processGiteaErrorf(err, "failed to archive %s", treeish)
+	}
+
+	return nil
+}
@@ -15,11 +15,16 @@
 package adapter
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
 	"github.com/harness/gitness/git/types"
 )
 
@@ -87,3 +92,84 @@ func (l limitReaderCloser) Close() error {
 	l.stop()
 	return nil
 }
+
+// FindOversizeFiles returns the blobs larger than the size limit that are reachable from the provided revisions,
+// but not reachable from any existing reference of the repository (e.g. the blobs of a push).
+func (a Adapter) FindOversizeFiles(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	revs []string,
+	sizeLimit int64,
+) ([]types.FileInfo, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	if len(revs) == 0 {
+		return nil, nil
+	}
+
+	// revisions from stdin aren't affected by the --not flag.
+	revList := command.New("rev-list",
+		command.WithFlag("--objects"),
+		command.WithFlag("--not", "--all", "--stdin"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	objects := &bytes.Buffer{}
+	err := revList.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(strings.NewReader(strings.Join(revs, "\n")+"\n")),
+		command.WithStdout(objects))
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to list new objects")
+	}
+
+	if objects.Len() == 0 {
+		return nil, nil
+	}
+
+	catFile := command.New("cat-file",
+		command.WithFlag("--batch-check=%(objecttype) %(objectname) %(objectsize) %(rest)"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	output := &bytes.Buffer{}
+	err = catFile.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(objects),
+		command.WithStdout(output))
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to get sizes of new objects")
+	}
+
+	var files []types.FileInfo
+
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		// the rest of the line is the path of the object as reported by the rev-list command.
+		fields := strings.SplitN(scanner.Text(), " ", 4)
+		if len(fields) != 4 || fields[0] != string(ObjectBlob) {
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse size of object %s: %w", fields[1], err)
+		}
+
+		if size <= sizeLimit {
+			continue
+		}
+
+		files = append(files, types.FileInfo{
+			SHA:  fields[1],
+			Path: fields[3],
+			Size: size,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read object sizes: %w", err)
+	}
+
+	return files, nil
+}
@@ -400,7 +400,19 @@ func (a Adapter) GetCommit(
 		return nil, ErrRepositoryPathEmpty
 	}
 
-	return GetCommit(ctx, repoPath, rev, "")
+	commit, err := GetCommit(ctx, repoPath, rev, "")
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := getSignedData(ctx, repoPath, nil, []string{commit.SHA})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit signature: %w", err)
+	}
+
+	commit.SignedData = signedData[commit.SHA]
+
+	return commit, nil
 }
 
 func (a Adapter) GetFullCommitID(
@@ -647,3 +659,100 @@ func GetCommit(
 		},
 	}, nil
 }
+
+// ListNewCommits returns the commits reachable from the provided revisions,
+// but not reachable from any existing reference of the repository (e.g. the commits of a push).
+func (a Adapter) ListNewCommits(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	revs []string,
+) ([]types.Commit, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	if len(revs) == 0 {
+		return nil, nil
+	}
+
+	const format = "" +
+		fmtCommitHash + fmtZero + // 0
+		fmtAuthorName + fmtZero + // 1
+		fmtAuthorEmail + fmtZero + // 2
+		fmtAuthorTime + fmtZero + // 3
+		fmtCommitterName + fmtZero + // 4
+		fmtCommitterEmail + fmtZero + // 5
+		fmtCommitterTime + fmtZero + // 6
+		fmtSubject + fmtZero + // 7
+		fmtRawBody + fmtZero + // 8
+		fmtParentHash // 9
+
+	const columnCount = 10
+
+	// revisions from stdin aren't affected by the --not flag.
+	cmd := command.New("log",
+		command.WithFlag("-z", "--format="+format),
+		command.WithFlag("--not", "--all", "--stdin"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(strings.NewReader(strings.Join(revs, "\n")+"\n")),
+		command.WithStdout(output))
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to list new commits")
+	}
+
+	if output.Len() == 0 {
+		return nil, nil
+	}
+
+	// each commit is terminated with the zero byte.
+	data := strings.Split(strings.TrimSuffix(output.String(), separatorZero), separatorZero)
+	if len(data)%columnCount != 0 {
+		return nil, fmt.Errorf(
+			"unexpected git log formatted output, got %d columns for %d column rows", len(data), columnCount)
+	}
+
+	shas := make([]string, 0, len(data)/columnCount)
+	for i := 0; i < len(data); i += columnCount {
+		shas = append(shas, data[i])
+	}
+
+	signedData, err := getSignedData(ctx, repoPath, alternateObjectDirs, shas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signatures of new commits: %w", err)
+	}
+
+	commits := make([]types.Commit, 0, len(data)/columnCount)
+	for i := 0; i < len(data); i += columnCount {
+		authorTime, _ := time.Parse(time.RFC3339Nano, data[i+3])
+		committerTime, _ := time.Parse(time.RFC3339Nano, data[i+6])
+
+		commits = append(commits, types.Commit{
+			SHA:     data[i],
+			Title:   data[i+7],
+			Message: data[i+8],
+			Author: types.Signature{
+				Identity: types.Identity{
+					Name:  data[i+1],
+					Email: data[i+2],
+				},
+				When: authorTime,
+			},
+			Committer: types.Signature{
+				Identity: types.Identity{
+					Name:  data[i+4],
+					Email: data[i+5],
+				},
+				When: committerTime,
+			},
+			ParentSHAs: strings.Fields(data[i+9]),
+			SignedData: signedData[data[i]],
+		})
+	}
+
+	return commits, nil
+}
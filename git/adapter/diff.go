@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
 	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/git/types"
 
@@ -450,3 +451,139 @@ func parseDiffStderr(stderr *bytes.Buffer) error {
 
 	return errors.New(errRaw)
 }
+
+// ListChangedPaths returns the paths that are changed by the commits reachable from the provided revisions,
+// but not reachable from any existing reference of the repository (e.g. the commits of a push).
+func (a Adapter) ListChangedPaths(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	revs []string,
+) ([]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	if len(revs) == 0 {
+		return nil, nil
+	}
+
+	// revisions from stdin aren't affected by the --not flag.
+	revList := command.New("rev-list",
+		command.WithFlag("--not", "--all", "--stdin"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	commits := &bytes.Buffer{}
+	err := revList.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(strings.NewReader(strings.Join(revs, "\n")+"\n")),
+		command.WithStdout(commits))
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to list new commits")
+	}
+
+	if commits.Len() == 0 {
+		return nil, nil
+	}
+
+	diffTree := command.New("diff-tree",
+		command.WithFlag("--stdin", "-r", "--root", "--name-only", "--no-commit-id", "-z"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	output := &bytes.Buffer{}
+	err = diffTree.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(commits),
+		command.WithStdout(output))
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to list changed paths of new commits")
+	}
+
+	pathMap := map[string]struct{}{}
+	paths := make([]string, 0)
+	for _, path := range bytes.Split(output.Bytes(), []byte{0}) {
+		if len(path) == 0 {
+			continue
+		}
+		if _, ok := pathMap[string(path)]; ok {
+			continue
+		}
+		pathMap[string(path)] = struct{}{}
+		paths = append(paths, string(path))
+	}
+
+	return paths, nil
+}
+
+// ListAddedLines calls fn for every file the commits reachable from the provided revisions added lines to.
+// If allCommits is false only the commits that aren't reachable from any existing reference of the repository
+// are inspected (e.g. the commits of a push), otherwise the whole history of the repository is inspected.
+func (a Adapter) ListAddedLines(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	revs []string,
+	allCommits bool,
+	fn func(types.AddedLines) error,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	if !allCommits && len(revs) == 0 {
+		return nil
+	}
+
+	cmd := command.New("log",
+		command.WithConfig("core.quotePath", "false"),
+		command.WithFlag("--format=commit %H", "--patch", "--unified=0",
+			"--no-color", "--no-ext-diff", "--find-renames"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+
+	stdin := ""
+	if allCommits {
+		cmd.Add(command.WithFlag("--all"))
+	} else {
+		// revisions from stdin aren't affected by the --not flag.
+		cmd.Add(command.WithFlag("--not", "--all", "--stdin"))
+		stdin = strings.Join(revs, "\n") + "\n"
+	}
+
+	pipeRead, pipeWrite := io.Pipe()
+	stderr := &bytes.Buffer{}
+
+	go func() {
+		var err error
+
+		defer func() {
+			// If running of the command below fails, make the pipe reader also fail with the same error.
+			_ = pipeWrite.CloseWithError(err)
+		}()
+
+		err = cmd.Run(ctx,
+			command.WithDir(repoPath),
+			command.WithStdin(strings.NewReader(stdin)),
+			command.WithStdout(pipeWrite),
+			command.WithStderr(stderr),
+		)
+	}()
+
+	var errFn error
+	err := parser.AddedLines(pipeRead, func(lines types.AddedLines) error {
+		errFn = fn(lines)
+		return errFn
+	})
+
+	// stops the command in case parsing stopped early.
+	_ = pipeRead.Close()
+
+	if errFn != nil {
+		return errFn
+	}
+	if err != nil {
+		return processGiteaErrorf(err, "failed to list added lines: %s", stderr.String())
+	}
+
+	return nil
+}
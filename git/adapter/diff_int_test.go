@@ -18,6 +18,10 @@ import (
 	"bytes"
 	"context"
 	"testing"
+
+	"github.com/harness/gitness/git/types"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestAdapter_RawDiff(t *testing.T) {
@@ -151,3 +155,59 @@ index ` + oid1.String() + `..` + oid2.String() + ` 100644
 		})
 	}
 }
+
+func TestAdapter_ListAddedLines(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testlistaddedlines")
+	defer teardown()
+
+	_, baseSHA := writeFile(t, repo, "config.env", "USER=admin\n", nil)
+
+	err := repo.SetReference("refs/heads/main", baseSHA.String())
+	if err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	// the new commit isn't referenced yet, just like a commit of a push in the pre-receive hook.
+	_, newSHA := writeFile(t, repo, "config.env", "USER=admin\nPASSWORD=secret\n", []string{baseSHA.String()})
+
+	list := func(revs []string, allCommits bool) []types.AddedLines {
+		var result []types.AddedLines
+		err := git.ListAddedLines(context.Background(), repo.Path, nil, revs, allCommits,
+			func(lines types.AddedLines) error {
+				result = append(result, lines)
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("ListAddedLines() error = %v", err)
+		}
+		return result
+	}
+
+	got := list([]string{newSHA.String()}, false)
+	want := []types.AddedLines{
+		{
+			CommitSHA: newSHA.String(),
+			Path:      "config.env",
+			Lines:     []types.AddedLine{{Number: 2, Content: "PASSWORD=secret"}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListAddedLines() of new commits: %s", diff)
+	}
+
+	err = repo.SetReference("refs/heads/main", newSHA.String())
+	if err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	got = list(nil, true)
+	want = append(want, types.AddedLines{
+		CommitSHA: baseSHA.String(),
+		Path:      "config.env",
+		Lines:     []types.AddedLine{{Number: 1, Content: "USER=admin"}},
+	})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListAddedLines() of all commits: %s", diff)
+	}
+}
@@ -21,7 +21,7 @@ const (
 
 	fmtCommitHash = "%H"
 	fmtTreeHash   = "%T"
-	fmtParentHash = "%T"
+	fmtParentHash = "%P"
 
 	fmtAuthorName  = "%an"
 	fmtAuthorEmail = "%ae"
@@ -35,4 +35,5 @@ const (
 
 	fmtSubject = "%s"
 	fmtBody    = "%b"
+	fmtRawBody = "%B"
 )
@@ -36,7 +36,8 @@ func (a Adapter) InfoRefs(
 	env ...string,
 ) error {
 	cmd := &bytes.Buffer{}
-	if err := git.NewCommand(ctx, service, "--stateless-rpc", "--advertise-refs", ".").
+	args := append(serviceConfigArgs(service), service, "--stateless-rpc", "--advertise-refs", ".")
+	if err := git.NewCommand(ctx, args...).
 		Run(&git.RunOpts{
 			Env:    env,
 			Dir:    repoPath,
@@ -62,6 +63,7 @@ func (a Adapter) ServicePack(
 	ctx context.Context,
 	repoPath string,
 	service string,
+	statelessRPC bool,
 	stdin io.Reader,
 	stdout io.Writer,
 	env ...string,
@@ -72,8 +74,17 @@ func (a Adapter) ServicePack(
 	var (
 		stderr bytes.Buffer
 	)
-	cmd := git.NewCommand(ctx, service, "--stateless-rpc", repoPath)
-	cmd.SetDescription(fmt.Sprintf("%s %s %s [repo_path: %s]", git.GitExecutable, service, "--stateless-rpc", repoPath))
+
+	// smart http requires stateless rpc, while ssh keeps a single bidirectional stream for the whole exchange.
+	args := append(serviceConfigArgs(service), service)
+	if statelessRPC {
+		args = append(args, "--stateless-rpc")
+	}
+	args = append(args, repoPath)
+
+	cmd := git.NewCommand(ctx, args...)
+	cmd.SetDescription(fmt.Sprintf("%s %s [repo_path: %s]",
+		git.GitExecutable, strings.Join(args[:len(args)-1], " "), repoPath))
 	err := cmd.Run(&git.RunOpts{
 		Dir:               repoPath,
 		Env:               env,
@@ -88,6 +99,16 @@ func (a Adapter) ServicePack(
 	return err
 }
 
+// serviceConfigArgs returns the config arguments of a service pack command.
+// receive-pack advertises push options, so clients are able to pass them to the git hooks.
+func serviceConfigArgs(service string) []string {
+	if service != "receive-pack" {
+		return nil
+	}
+
+	return []string{"-c", "receive.advertisePushOptions=true"}
+}
+
 func packetWrite(str string) []byte {
 	s := strconv.FormatInt(int64(len(str)+4), 16)
 	if len(s)%4 != 0 {
@@ -219,6 +219,66 @@ func (a Adapter) Sync(
 	return nil
 }
 
+// FetchObjects pulls the objects with the provided SHAs (and everything reachable from them)
+// from the source repository into the repository. No references are created or updated.
+func (a Adapter) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(objectSHAs) == 0 {
+		return nil
+	}
+
+	cmd := command.New("fetch",
+		command.WithConfig("advice.fetchShowForcedUpdates", "false"),
+		command.WithConfig("credential.helper", ""),
+		// required to be able to fetch commits by SHA that aren't advertised by the source
+		command.WithConfig("uploadpack.allowAnySHA1InWant", "true"),
+		command.WithFlag(
+			"--quiet",
+			"--no-auto-gc",
+			"--no-tags",
+			"--no-write-fetch-head",
+			"--no-show-forced-updates",
+		),
+		command.WithArg(source),
+		command.WithArg(objectSHAs...),
+	)
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return processGiteaErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
+// Repack packs all objects of the repository into a single pack, including the objects
+// that are borrowed from alternate object directories. After the call the repository
+// no longer depends on its alternates.
+func (a Adapter) Repack(
+	ctx context.Context,
+	repoPath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("repack",
+		command.WithFlag("-a", "-d", "-q"),
+	)
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return processGiteaErrorf(err, "failed to repack objects")
+	}
+
+	return nil
+}
+
 func (a Adapter) AddFiles(
 	repoPath string,
 	all bool,
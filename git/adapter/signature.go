@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/types"
+)
+
+const (
+	signatureHeaderGPG = "gpgsig"
+
+	signatureBeginPGP = "-----BEGIN PGP SIGNATURE-----"
+	signatureBeginSSH = "-----BEGIN SSH SIGNATURE-----"
+)
+
+// getSignedData returns the signatures of the provided commit and tag objects.
+// Objects without a signature are not present in the returned map.
+func getSignedData(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	shas []string,
+) (map[string]*types.SignedData, error) {
+	if len(shas) == 0 {
+		return map[string]*types.SignedData{}, nil
+	}
+
+	cmd := command.New("cat-file",
+		command.WithFlag("--batch"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(strings.NewReader(strings.Join(shas, "\n")+"\n")),
+		command.WithStdout(output))
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to read objects")
+	}
+
+	result := make(map[string]*types.SignedData, len(shas))
+
+	reader := bufio.NewReader(output)
+	for range shas {
+		sha, typ, size, err := ReadBatchHeaderLine(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read object header: %w", err)
+		}
+
+		data := make([]byte, size)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return nil, fmt.Errorf("failed to read object %s: %w", sha, err)
+		}
+		if _, err = reader.Discard(1); err != nil {
+			return nil, fmt.Errorf("failed to read object %s: %w", sha, err)
+		}
+
+		var signedData *types.SignedData
+		switch typ {
+		case string(ObjectCommit):
+			signedData = parseCommitSignedData(data)
+		case string(ObjectTag):
+			signedData = parseTagSignedData(data)
+		}
+
+		if signedData != nil {
+			result[string(sha)] = signedData
+		}
+	}
+
+	return result, nil
+}
+
+// parseCommitSignedData extracts the signature from the gpgsig header of a raw commit object.
+// The signed content is the commit object without the signature header.
+func parseCommitSignedData(data []byte) *types.SignedData {
+	var signature []byte
+	content := make([]byte, 0, len(data))
+
+	inSignature := false
+	rest := data
+	for len(rest) > 0 {
+		line := rest
+		if idx := bytes.IndexByte(rest, '\n'); idx >= 0 {
+			line = rest[:idx+1]
+		}
+		rest = rest[len(line):]
+
+		switch {
+		case inSignature && len(line) > 0 && line[0] == ' ':
+			// continuation line of the signature header
+			signature = append(signature, line[1:]...)
+			continue
+		case bytes.HasPrefix(line, []byte(signatureHeaderGPG+" ")):
+			inSignature = true
+			signature = append(signature, line[len(signatureHeaderGPG)+1:]...)
+			continue
+		}
+
+		inSignature = false
+		content = append(content, line...)
+
+		if len(bytes.TrimRight(line, "\n")) == 0 {
+			// end of the headers, the rest is the commit message
+			content = append(content, rest...)
+			break
+		}
+	}
+
+	if len(signature) == 0 {
+		return nil
+	}
+
+	return &types.SignedData{
+		Type:          signatureType(signature),
+		Signature:     signature,
+		SignedContent: content,
+	}
+}
+
+// parseTagSignedData extracts the signature that is appended to the message of a raw tag object.
+func parseTagSignedData(data []byte) *types.SignedData {
+	idx := findTagSignature(data)
+	if idx < 0 {
+		return nil
+	}
+
+	return &types.SignedData{
+		Type:          signatureType(data[idx:]),
+		Signature:     data[idx:],
+		SignedContent: data[:idx],
+	}
+}
+
+// findTagSignature returns the start of the signature in the raw tag object, or -1 if the tag isn't signed.
+func findTagSignature(data []byte) int {
+	idx := -1
+	for _, begin := range []string{signatureBeginPGP, signatureBeginSSH} {
+		i := bytes.LastIndex(data, []byte("\n"+begin+"\n"))
+		if i > idx {
+			idx = i
+		}
+	}
+
+	if idx < 0 {
+		return -1
+	}
+
+	return idx + 1
+}
+
+func signatureType(signature []byte) string {
+	switch {
+	case bytes.HasPrefix(signature, []byte(signatureBeginPGP)):
+		return types.SignatureTypeGPG
+	case bytes.HasPrefix(signature, []byte(signatureBeginSSH)):
+		return types.SignatureTypeSSH
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseCommitSignedData(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *types.SignedData
+	}{
+		{
+			name: "unsigned",
+			data: "tree 1111\n" +
+				"author A <a@example.com> 1700000000 +0000\n" +
+				"committer A <a@example.com> 1700000000 +0000\n" +
+				"\n" +
+				"message\n",
+			want: nil,
+		},
+		{
+			name: "gpg",
+			data: "tree 1111\n" +
+				"parent 2222\n" +
+				"author A <a@example.com> 1700000000 +0000\n" +
+				"committer A <a@example.com> 1700000000 +0000\n" +
+				"gpgsig -----BEGIN PGP SIGNATURE-----\n" +
+				" \n" +
+				" abcd\n" +
+				" -----END PGP SIGNATURE-----\n" +
+				"\n" +
+				"title\n" +
+				"\n" +
+				" indented body\n",
+			want: &types.SignedData{
+				Type: types.SignatureTypeGPG,
+				Signature: []byte("-----BEGIN PGP SIGNATURE-----\n" +
+					"\n" +
+					"abcd\n" +
+					"-----END PGP SIGNATURE-----\n"),
+				SignedContent: []byte("tree 1111\n" +
+					"parent 2222\n" +
+					"author A <a@example.com> 1700000000 +0000\n" +
+					"committer A <a@example.com> 1700000000 +0000\n" +
+					"\n" +
+					"title\n" +
+					"\n" +
+					" indented body\n"),
+			},
+		},
+		{
+			name: "ssh",
+			data: "tree 1111\n" +
+				"author A <a@example.com> 1700000000 +0000\n" +
+				"committer A <a@example.com> 1700000000 +0000\n" +
+				"gpgsig -----BEGIN SSH SIGNATURE-----\n" +
+				" abcd\n" +
+				" -----END SSH SIGNATURE-----\n" +
+				"\n" +
+				"message",
+			want: &types.SignedData{
+				Type: types.SignatureTypeSSH,
+				Signature: []byte("-----BEGIN SSH SIGNATURE-----\n" +
+					"abcd\n" +
+					"-----END SSH SIGNATURE-----\n"),
+				SignedContent: []byte("tree 1111\n" +
+					"author A <a@example.com> 1700000000 +0000\n" +
+					"committer A <a@example.com> 1700000000 +0000\n" +
+					"\n" +
+					"message"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCommitSignedData([]byte(tt.data))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseCommitSignedData() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_parseTagSignedData(t *testing.T) {
+	const header = "object 1111\n" +
+		"type commit\n" +
+		"tag v1.0\n" +
+		"tagger A <a@example.com> 1700000000 +0000\n" +
+		"\n"
+
+	tests := []struct {
+		name string
+		data string
+		want *types.SignedData
+	}{
+		{
+			name: "unsigned",
+			data: header + "release\n",
+			want: nil,
+		},
+		{
+			name: "gpg",
+			data: header + "release\n" +
+				"-----BEGIN PGP SIGNATURE-----\n" +
+				"abcd\n" +
+				"-----END PGP SIGNATURE-----\n",
+			want: &types.SignedData{
+				Type: types.SignatureTypeGPG,
+				Signature: []byte("-----BEGIN PGP SIGNATURE-----\n" +
+					"abcd\n" +
+					"-----END PGP SIGNATURE-----\n"),
+				SignedContent: []byte(header + "release\n"),
+			},
+		},
+		{
+			name: "ssh",
+			data: header + "release\n" +
+				"-----BEGIN SSH SIGNATURE-----\n" +
+				"abcd\n" +
+				"-----END SSH SIGNATURE-----\n",
+			want: &types.SignedData{
+				Type: types.SignatureTypeSSH,
+				Signature: []byte("-----BEGIN SSH SIGNATURE-----\n" +
+					"abcd\n" +
+					"-----END SSH SIGNATURE-----\n"),
+				SignedContent: []byte(header + "release\n"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseTagSignedData([]byte(tt.data))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseTagSignedData() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -29,8 +29,7 @@ import (
 )
 
 const (
-	pgpSignatureBeginToken = "\n-----BEGIN PGP SIGNATURE-----\n" //#nosec G101
-	pgpSignatureEndToken   = "\n-----END PGP SIGNATURE-----"     //#nosec G101
+	pgpSignatureEndToken = "\n-----END PGP SIGNATURE-----" //#nosec G101
 )
 
 // GetAnnotatedTag returns the tag for a specific tag sha.
@@ -188,15 +187,21 @@ func parseTagDataFromCatFile(data []byte) (tag types.Tag, err error) {
 		return tag, err
 	}
 
-	// remainder is message and gpg (remove leading and tailing new lines)
-	message := string(bytes.Trim(data[p:], "\n"))
+	// remainder is the message, optionally followed by the signature
+	body := data[p:]
+	if idx := findTagSignature(data); idx >= p {
+		tag.SignedData = parseTagSignedData(data)
+		body = data[p:idx]
+	}
+
+	// remove leading and tailing new lines
+	message := string(bytes.Trim(body, "\n"))
 
-	// handle gpg signature
+	// handle gpg signature that precedes the message
 	pgpEnd := strings.Index(message, pgpSignatureEndToken)
 	if pgpEnd > -1 {
 		messageStart := pgpEnd + len(pgpSignatureEndToken)
-		// for now we just remove the signature (and trim any separating new lines)
-		// TODO: add support for GPG signature of tags
+		// the signature is just removed (and any separating new lines trimmed)
 		message = strings.TrimLeft(message[messageStart:], "\n")
 	}
 
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+)
+
+// ArchiveFormat represents the format of a repository archive.
+type ArchiveFormat string
+
+const (
+	ArchiveFormatTar   ArchiveFormat = "tar"
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+	ArchiveFormatTgz   ArchiveFormat = "tgz"
+	ArchiveFormatZip   ArchiveFormat = "zip"
+)
+
+// ArchiveFormats contains all supported archive formats.
+var ArchiveFormats = []ArchiveFormat{
+	ArchiveFormatTar,
+	ArchiveFormatTarGz,
+	ArchiveFormatTgz,
+	ArchiveFormatZip,
+}
+
+// ParseArchiveFormat parses the archive format and returns an error in case it's not supported.
+func ParseArchiveFormat(format string) (ArchiveFormat, error) {
+	for _, f := range ArchiveFormats {
+		if string(f) == format {
+			return f, nil
+		}
+	}
+
+	return "", errors.InvalidArgument("archive format %q is not supported", format)
+}
+
+type ArchiveParams struct {
+	ReadParams
+	Format ArchiveFormat
+	GitRef string
+
+	// Prefix is prepended to the path of every file in the archive (e.g. "repo-main/").
+	// Optional, ignored if empty.
+	Prefix string
+
+	// Paths restricts the archive to the provided files and directories.
+	// Optional, the whole tree is archived if empty.
+	Paths []string
+}
+
+func (params *ArchiveParams) Validate() error {
+	if params == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := params.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := ParseArchiveFormat(string(params.Format)); err != nil {
+		return err
+	}
+
+	if params.GitRef == "" {
+		return errors.InvalidArgument("git ref needs to be provided")
+	}
+
+	if params.Prefix != "" {
+		prefix := strings.Trim(path.Clean("/"+params.Prefix), "/")
+		if prefix == "" {
+			return errors.InvalidArgument("archive prefix is invalid")
+		}
+		// the prefix is always treated as a directory.
+		params.Prefix = prefix + "/"
+	}
+
+	for i, p := range params.Paths {
+		p = strings.Trim(path.Clean("/"+p), "/")
+		if p == "" {
+			return errors.InvalidArgument("archive path can't be empty")
+		}
+		params.Paths[i] = p
+	}
+
+	return nil
+}
+
+// Archive streams the archive of the git tree at the provided reference to the writer.
+func (s *Service) Archive(ctx context.Context, w io.Writer, params *ArchiveParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	// resolve the reference and paths upfront to fail before any data is written.
+	commit, err := s.adapter.GetCommit(ctx, repoPath, params.GitRef)
+	if err != nil {
+		return fmt.Errorf("failed to get commit for ref %q: %w", params.GitRef, err)
+	}
+
+	for _, p := range params.Paths {
+		if _, err = s.adapter.GetTreeNode(ctx, repoPath, commit.SHA, p); err != nil {
+			return err
+		}
+	}
+
+	err = s.adapter.Archive(ctx, w, repoPath, string(params.Format), commit.SHA, params.Prefix, params.Paths...)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+func TestArchiveParams_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     ArchiveParams
+		wantPrefix string
+		wantPaths  []string
+		wantErr    bool
+	}{
+		{
+			name:   "no prefix and paths",
+			params: ArchiveParams{Format: ArchiveFormatZip, GitRef: "main"},
+		},
+		{
+			name:       "prefix is cleaned and treated as directory",
+			params:     ArchiveParams{Format: ArchiveFormatTar, GitRef: "main", Prefix: "/repo/../repo-main"},
+			wantPrefix: "repo-main/",
+		},
+		{
+			name:       "prefix can't escape the archive root",
+			params:     ArchiveParams{Format: ArchiveFormatTar, GitRef: "main", Prefix: "../../etc"},
+			wantPrefix: "etc/",
+		},
+		{
+			name:    "prefix of only slashes",
+			params:  ArchiveParams{Format: ArchiveFormatTar, GitRef: "main", Prefix: "//"},
+			wantErr: true,
+		},
+		{
+			name:      "paths are cleaned",
+			params:    ArchiveParams{Format: ArchiveFormatTar, GitRef: "main", Paths: []string{"/dir/", "a/../b.txt"}},
+			wantPaths: []string{"dir", "b.txt"},
+		},
+		{
+			name:    "root path",
+			params:  ArchiveParams{Format: ArchiveFormatTar, GitRef: "main", Paths: []string{"/"}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			params:  ArchiveParams{Format: "rar", GitRef: "main"},
+			wantErr: true,
+		},
+		{
+			name:    "missing ref",
+			params:  ArchiveParams{Format: ArchiveFormatZip},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := test.params
+			params.RepoUID = "repo"
+
+			err := params.Validate()
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if params.Prefix != test.wantPrefix {
+				t.Errorf("prefix: got=%q want=%q", params.Prefix, test.wantPrefix)
+			}
+			if len(test.wantPaths) > 0 && !reflect.DeepEqual(params.Paths, test.wantPaths) {
+				t.Errorf("paths: got=%v want=%v", params.Paths, test.wantPaths)
+			}
+		})
+	}
+}
+
+// setupArchiveRepo creates a repository with files in the root and in a sub directory.
+func setupArchiveRepo(t *testing.T, s *Service, repoUID string) {
+	t.Helper()
+	ctx := context.Background()
+
+	setupRepoWithCommit(t, s, repoUID, "initial")
+
+	repo, err := s.adapter.OpenRepository(ctx, getFullPathForRepo(s.reposRoot, repoUID))
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	defer repo.Close()
+
+	for _, file := range []string{"README.md", "dir/a.txt", "dir/sub/b.txt"} {
+		oid, err := repo.HashObject(strings.NewReader("content of " + file))
+		if err != nil {
+			t.Fatalf("failed to hash object: %v", err)
+		}
+		if err = repo.AddObjectToIndex("100644", oid, file); err != nil {
+			t.Fatalf("failed to add object to index: %v", err)
+		}
+	}
+
+	tree, err := repo.WriteTree()
+	if err != nil {
+		t.Fatalf("failed to write tree: %v", err)
+	}
+
+	signature := &gitea.Signature{Name: testIdentity.Name, Email: testIdentity.Email, When: time.Now()}
+	sha, err := repo.CommitTree(signature, signature, tree, gitea.CommitTreeOpts{Message: "add files"})
+	if err != nil {
+		t.Fatalf("failed to commit tree: %v", err)
+	}
+
+	if err = repo.SetReference("refs/heads/main", sha.String()); err != nil {
+		t.Fatalf("failed to set reference: %v", err)
+	}
+}
+
+// archiveFiles returns the sorted names of the files in the archive.
+func archiveFiles(t *testing.T, format ArchiveFormat, data []byte) []string {
+	t.Helper()
+
+	var files []string
+
+	switch format {
+	case ArchiveFormatZip:
+		r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatalf("failed to read zip archive: %v", err)
+		}
+		for _, f := range r.File {
+			if !f.FileInfo().IsDir() {
+				files = append(files, f.Name)
+			}
+		}
+	case ArchiveFormatTar, ArchiveFormatTarGz, ArchiveFormatTgz:
+		var r io.Reader = bytes.NewReader(data)
+		if format != ArchiveFormatTar {
+			gz, err := gzip.NewReader(r)
+			if err != nil {
+				t.Fatalf("failed to read gzip archive: %v", err)
+			}
+			r = gz
+		}
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("failed to read tar archive: %v", err)
+			}
+			if hdr.Typeflag == tar.TypeReg {
+				files = append(files, hdr.Name)
+			}
+		}
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
+func TestService_Archive(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+
+	repoUID := "archive"
+	setupArchiveRepo(t, s, repoUID)
+
+	tests := []struct {
+		name      string
+		format    ArchiveFormat
+		prefix    string
+		paths     []string
+		wantFiles []string
+	}{
+		{
+			name:      "tar",
+			format:    ArchiveFormatTar,
+			wantFiles: []string{"README.md", "dir/a.txt", "dir/sub/b.txt", "file.txt"},
+		},
+		{
+			name:      "tar.gz with prefix",
+			format:    ArchiveFormatTarGz,
+			prefix:    "repo-main",
+			wantFiles: []string{"repo-main/README.md", "repo-main/dir/a.txt", "repo-main/dir/sub/b.txt", "repo-main/file.txt"},
+		},
+		{
+			name:      "tgz restricted to a directory",
+			format:    ArchiveFormatTgz,
+			paths:     []string{"dir/sub"},
+			wantFiles: []string{"dir/sub/b.txt"},
+		},
+		{
+			name:      "zip restricted to files with prefix",
+			format:    ArchiveFormatZip,
+			prefix:    "/out/",
+			paths:     []string{"/README.md", "dir/a.txt"},
+			wantFiles: []string{"out/README.md", "out/dir/a.txt"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := s.Archive(ctx, buf, &ArchiveParams{
+				ReadParams: ReadParams{RepoUID: repoUID},
+				Format:     test.format,
+				GitRef:     "main",
+				Prefix:     test.prefix,
+				Paths:      test.paths,
+			})
+			if err != nil {
+				t.Fatalf("failed to create archive: %v", err)
+			}
+
+			if got := archiveFiles(t, test.format, buf.Bytes()); !reflect.DeepEqual(got, test.wantFiles) {
+				t.Errorf("got=%v want=%v", got, test.wantFiles)
+			}
+		})
+	}
+}
+
+func TestService_Archive_FailsBeforeWriting(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+
+	repoUID := "archive"
+	setupArchiveRepo(t, s, repoUID)
+
+	tests := []struct {
+		name   string
+		gitRef string
+		paths  []string
+	}{
+		{
+			name:   "unknown ref",
+			gitRef: "unknown",
+		},
+		{
+			name:   "unknown path",
+			gitRef: "main",
+			paths:  []string{"missing.txt"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := s.Archive(ctx, buf, &ArchiveParams{
+				ReadParams: ReadParams{RepoUID: repoUID},
+				Format:     ArchiveFormatZip,
+				GitRef:     test.gitRef,
+				Paths:      test.paths,
+			})
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+
+			if buf.Len() != 0 {
+				t.Errorf("expected nothing to be written, got %d bytes", buf.Len())
+			}
+		})
+	}
+}
@@ -16,7 +16,10 @@ package git
 
 import (
 	"context"
+	"fmt"
 	"io"
+
+	"github.com/harness/gitness/errors"
 )
 
 type GetBlobParams struct {
@@ -55,3 +58,82 @@ func (s *Service) GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobO
 		Content:     reader.Content,
 	}, nil
 }
+
+type FindOversizeFilesParams struct {
+	ReadParams
+
+	// Revisions contains the revisions whose new blobs are inspected.
+	// Blobs that are already reachable from any reference of the repository are ignored.
+	Revisions []string
+
+	// SizeLimit is the maximum allowed size of a blob in bytes.
+	SizeLimit int64
+}
+
+func (p *FindOversizeFilesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	for _, rev := range p.Revisions {
+		if !isValidGitSHA(rev) {
+			return errors.InvalidArgument("invalid revision %q", rev)
+		}
+	}
+
+	if p.SizeLimit < 0 {
+		return errors.InvalidArgument("size limit can't be negative")
+	}
+
+	return nil
+}
+
+type FileInfo struct {
+	SHA  string
+	Path string
+	Size int64
+}
+
+type FindOversizeFilesOutput struct {
+	Files []FileInfo
+}
+
+// FindOversizeFiles returns all new blobs that are larger than the size limit.
+// It's used to inspect the files of a push before the references are updated.
+func (s *Service) FindOversizeFiles(
+	ctx context.Context,
+	params *FindOversizeFilesParams,
+) (FindOversizeFilesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return FindOversizeFilesOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := validateAlternateObjectDirs(repoPath, params.AlternateObjectDirs); err != nil {
+		return FindOversizeFilesOutput{}, err
+	}
+
+	result, err := s.adapter.FindOversizeFiles(ctx,
+		repoPath, params.AlternateObjectDirs, params.Revisions, params.SizeLimit)
+	if err != nil {
+		return FindOversizeFilesOutput{}, fmt.Errorf("failed to find oversize files: %w", err)
+	}
+
+	files := make([]FileInfo, len(result))
+	for i := range result {
+		files[i] = FileInfo{
+			SHA:  result[i].SHA,
+			Path: result[i].Path,
+			Size: result[i].Size,
+		}
+	}
+
+	return FindOversizeFilesOutput{
+		Files: files,
+	}, nil
+}
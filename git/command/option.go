@@ -17,6 +17,7 @@ package command
 import (
 	"io"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -105,6 +106,15 @@ func WithConfig(key, value string) CmdOptionFunc {
 	}
 }
 
+// WithAlternateObjectDirs function sets alternates directories for object access.
+func WithAlternateObjectDirs(dirs ...string) CmdOptionFunc {
+	return func(c *Command) {
+		if len(dirs) > 0 {
+			c.Envs[GitAlternateObjectDirs] = strings.Join(dirs, ":")
+		}
+	}
+}
+
 // RunOption contains option for running a command.
 type RunOption struct {
 	// Dir is location of repo.
@@ -30,12 +30,27 @@ type GetCommitParams struct {
 }
 
 type Commit struct {
-	SHA       string          `json:"sha"`
-	Title     string          `json:"title"`
-	Message   string          `json:"message,omitempty"`
-	Author    Signature       `json:"author"`
-	Committer Signature       `json:"committer"`
-	FileStats CommitFileStats `json:"file_stats,omitempty"`
+	SHA        string          `json:"sha"`
+	Title      string          `json:"title"`
+	Message    string          `json:"message,omitempty"`
+	Author     Signature       `json:"author"`
+	Committer  Signature       `json:"committer"`
+	FileStats  CommitFileStats `json:"file_stats,omitempty"`
+	ParentSHAs []string        `json:"parent_shas,omitempty"`
+	SignedData *SignedData     `json:"-"`
+}
+
+const (
+	SignatureTypeGPG = "gpg"
+	SignatureTypeSSH = "ssh"
+)
+
+// SignedData contains the signature of a signed commit or tag together with the content that was signed.
+type SignedData struct {
+	// Type is the type of the signature, SignatureTypeGPG or SignatureTypeSSH, empty if it's not recognized.
+	Type          string
+	Signature     []byte
+	SignedContent []byte
 }
 
 type GetCommitOutput struct {
@@ -247,3 +262,68 @@ func (s *Service) GetCommitDivergences(
 		Divergences: divergences,
 	}, nil
 }
+
+type ListNewCommitsParams struct {
+	ReadParams
+
+	// Revisions contains the revisions whose new commits are returned.
+	// Commits that are already reachable from any reference of the repository are ignored.
+	Revisions []string
+}
+
+func (p *ListNewCommitsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	for _, rev := range p.Revisions {
+		if !isValidGitSHA(rev) {
+			return errors.InvalidArgument("invalid revision %q", rev)
+		}
+	}
+
+	return nil
+}
+
+type ListNewCommitsOutput struct {
+	Commits []Commit
+}
+
+// ListNewCommits returns all commits that aren't yet reachable from any reference.
+// It's used to inspect the commits of a push before the references are updated.
+func (s *Service) ListNewCommits(
+	ctx context.Context,
+	params *ListNewCommitsParams,
+) (ListNewCommitsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ListNewCommitsOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := validateAlternateObjectDirs(repoPath, params.AlternateObjectDirs); err != nil {
+		return ListNewCommitsOutput{}, err
+	}
+
+	result, err := s.adapter.ListNewCommits(ctx, repoPath, params.AlternateObjectDirs, params.Revisions)
+	if err != nil {
+		return ListNewCommitsOutput{}, fmt.Errorf("failed to list new commits: %w", err)
+	}
+
+	commits := make([]Commit, len(result))
+	for i := range result {
+		commit, err := mapCommit(&result[i])
+		if err != nil {
+			return ListNewCommitsOutput{}, fmt.Errorf("failed to map rpc commit: %w", err)
+		}
+		commits[i] = *commit
+	}
+
+	return ListNewCommitsOutput{
+		Commits: commits,
+	}, nil
+}
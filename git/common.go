@@ -16,11 +16,16 @@ package git
 
 import (
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
 )
 
 // ReadParams contains the base parameters for read operations.
 type ReadParams struct {
 	RepoUID string
+
+	// AlternateObjectDirs contains additional object directories required to read objects
+	// that aren't part of the repository yet (e.g. the quarantine directory of a push in progress).
+	AlternateObjectDirs []string
 }
 
 func (p ReadParams) Validate() error {
@@ -35,6 +40,9 @@ type WriteParams struct {
 	RepoUID string
 	Actor   Identity
 	EnvVars map[string]string
+
+	// Signer is optional, if provided it is used to sign the commits and annotated tags created by the operation.
+	Signer types.Signer
 }
 
 func (p *WriteParams) Validate() error {
@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/harness/gitness/errors"
@@ -505,3 +507,172 @@ func (s *Service) DiffFileNames(ctx context.Context, params *DiffParams) (DiffFi
 		Files: fileNames,
 	}, nil
 }
+
+type ListChangedPathsParams struct {
+	ReadParams
+
+	// Revisions contains the revisions whose new commits are inspected.
+	// Commits that are already reachable from any reference of the repository are ignored.
+	Revisions []string
+}
+
+func (p *ListChangedPathsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	for _, rev := range p.Revisions {
+		if !isValidGitSHA(rev) {
+			return errors.InvalidArgument("invalid revision %q", rev)
+		}
+	}
+
+	return nil
+}
+
+type ListChangedPathsOutput struct {
+	Paths []string
+}
+
+// ListChangedPaths returns all paths changed by commits that aren't yet reachable from any reference.
+// It's used to inspect the changes of a push before the references are updated.
+func (s *Service) ListChangedPaths(
+	ctx context.Context,
+	params *ListChangedPathsParams,
+) (ListChangedPathsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ListChangedPathsOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := validateAlternateObjectDirs(repoPath, params.AlternateObjectDirs); err != nil {
+		return ListChangedPathsOutput{}, err
+	}
+
+	paths, err := s.adapter.ListChangedPaths(ctx, repoPath, params.AlternateObjectDirs, params.Revisions)
+	if err != nil {
+		return ListChangedPathsOutput{}, fmt.Errorf("failed to list changed paths: %w", err)
+	}
+
+	return ListChangedPathsOutput{
+		Paths: paths,
+	}, nil
+}
+
+type ListAddedLinesParams struct {
+	ReadParams
+
+	// Revisions contains the revisions whose new commits are inspected.
+	// Commits that are already reachable from any reference of the repository are ignored.
+	Revisions []string
+
+	// AllCommits inspects the whole history of the repository instead of the new commits of the revisions.
+	AllCommits bool
+}
+
+func (p *ListAddedLinesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.AllCommits && len(p.Revisions) > 0 {
+		return errors.InvalidArgument("revisions can't be provided when all commits are inspected")
+	}
+
+	for _, rev := range p.Revisions {
+		if !isValidGitSHA(rev) {
+			return errors.InvalidArgument("invalid revision %q", rev)
+		}
+	}
+
+	return nil
+}
+
+// AddedLines contains the lines a commit added to a file.
+type AddedLines struct {
+	CommitSHA string
+	Path      string
+	Lines     []AddedLine
+}
+
+// AddedLine is a line added to a file, Number is the line number in the new version of the file.
+type AddedLine struct {
+	Number  int
+	Content string
+}
+
+// ListAddedLines streams the lines added by commits, grouped by commit and file.
+// It's used to inspect the content of a push before the references are updated,
+// or the whole history of a repository.
+// The function returns two channels: The data channel and the error channel.
+// If any error happens during the operation it will be put to the error channel
+// and the streaming will stop. Maximum of one error can be put on the channel.
+func (s *Service) ListAddedLines(
+	ctx context.Context,
+	params *ListAddedLinesParams,
+) (<-chan *AddedLines, <-chan error) {
+	ch := make(chan *AddedLines)
+	chErr := make(chan error, 1)
+
+	go func() {
+		defer close(ch)
+		defer close(chErr)
+
+		if err := params.Validate(); err != nil {
+			chErr <- err
+			return
+		}
+
+		repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+		if err := validateAlternateObjectDirs(repoPath, params.AlternateObjectDirs); err != nil {
+			chErr <- err
+			return
+		}
+
+		err := s.adapter.ListAddedLines(ctx, repoPath, params.AlternateObjectDirs, params.Revisions,
+			params.AllCommits, func(in types.AddedLines) error {
+				lines := make([]AddedLine, len(in.Lines))
+				for i, line := range in.Lines {
+					lines[i] = AddedLine{
+						Number:  line.Number,
+						Content: line.Content,
+					}
+				}
+
+				select {
+				case ch <- &AddedLines{CommitSHA: in.CommitSHA, Path: in.Path, Lines: lines}:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+		if err != nil {
+			chErr <- fmt.Errorf("failed to list added lines: %w", err)
+		}
+	}()
+
+	return ch, chErr
+}
+
+// validateAlternateObjectDirs makes sure that alternate object directories are located
+// inside the repository, to prevent access to objects of other repositories.
+func validateAlternateObjectDirs(repoPath string, dirs []string) error {
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(repoPath, filepath.Clean(dir))
+		if err != nil || !filepath.IsAbs(dir) || rel == ".." || strings.HasPrefix(rel, "../") {
+			return errors.InvalidArgument("alternate object directory %q is outside of the repository", dir)
+		}
+	}
+
+	return nil
+}
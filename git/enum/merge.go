@@ -24,17 +24,23 @@ const (
 	MergeMethodSquash MergeMethod = "squash"
 	// MergeMethodRebase rebase before merging.
 	MergeMethodRebase MergeMethod = "rebase"
+	// MergeMethodFastForward fast-forward the base branch to the head commit, no new commits are created.
+	MergeMethodFastForward MergeMethod = "fast-forward"
+	// MergeMethodRebaseMerge rebase before merging and then create merge commit (semi-linear history).
+	MergeMethodRebaseMerge MergeMethod = "rebase-merge"
 )
 
 var MergeMethods = []MergeMethod{
 	MergeMethodMerge,
 	MergeMethodSquash,
 	MergeMethodRebase,
+	MergeMethodFastForward,
+	MergeMethodRebaseMerge,
 }
 
 func (m MergeMethod) Sanitize() (MergeMethod, bool) {
 	switch m {
-	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase, MergeMethodFastForward, MergeMethodRebaseMerge:
 		return m, true
 	default:
 		return MergeMethodMerge, false
@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/storage"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ForkRepositoryParams struct {
+	// Fork operation is similar to the create operation, the UID of the new repository doesn't exist yet.
+	RepoUID string
+	Actor   Identity
+	EnvVars map[string]string
+
+	// UpstreamRepoUID is the UID of the repository that is forked.
+	UpstreamRepoUID string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if p.UpstreamRepoUID == "" {
+		return errors.InvalidArgument("upstream repository UID is mandatory")
+	}
+
+	return p.Actor.Validate()
+}
+
+type ForkRepositoryOutput struct {
+	UID           string
+	DefaultBranch string
+}
+
+// ForkRepository creates a new repository with all branches and tags of the upstream repository.
+// The new repository doesn't copy the objects of the upstream repository,
+// instead it borrows them from the upstream repository using git alternates.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if params.RepoUID == "" {
+		uid, err := NewRepositoryUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new uid: %w", err)
+		}
+		params.RepoUID = uid
+	}
+
+	upstreamPath := getFullPathForRepo(s.reposRoot, params.UpstreamRepoUID)
+	if _, err := os.Stat(upstreamPath); os.IsNotExist(err) {
+		return nil, errors.NotFound("upstream repository not found")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to check the status of the upstream repository: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	if _, err := os.Stat(repoPath); !os.IsNotExist(err) {
+		return nil, errors.Conflict("repository exists already: %v", repoPath)
+	}
+
+	log := log.Ctx(ctx).With().
+		Str("repo_uid", params.RepoUID).
+		Str("upstream_repo_uid", params.UpstreamRepoUID).
+		Logger()
+
+	err := s.adapter.InitRepository(ctx, repoPath, true)
+	// delete repo dir on error
+	defer func() {
+		if err != nil {
+			cleanupErr := s.DeleteRepositoryBestEffort(ctx, params.RepoUID)
+			if cleanupErr != nil {
+				log.Warn().Err(cleanupErr).Msg("failed to cleanup repo dir")
+			}
+		}
+	}()
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to initialize the repository: %w", err)
+	}
+
+	err = storage.LinkObjects(repoPath, upstreamPath)
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to link objects of the upstream repository: %w", err)
+	}
+
+	// all objects are available through alternates, so the fetch only copies the references.
+	err = s.adapter.Sync(ctx, repoPath, upstreamPath, []string{
+		"+" + gitReferenceNamePrefixBranch + "*:" + gitReferenceNamePrefixBranch + "*",
+		"+" + gitReferenceNamePrefixTag + "*:" + gitReferenceNamePrefixTag + "*",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to copy references of the upstream repository: %w", err)
+	}
+
+	defaultBranchRef, err := s.adapter.GetDefaultBranch(ctx, upstreamPath)
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to get default branch of the upstream repository: %w", err)
+	}
+
+	// the adapter returns the raw output of symbolic-ref (e.g. "refs/heads/main\n").
+	defaultBranch := strings.TrimPrefix(strings.TrimSpace(defaultBranchRef), gitReferenceNamePrefixBranch)
+
+	err = s.adapter.SetDefaultBranch(ctx, repoPath, defaultBranch, true)
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to set default branch: %w", err)
+	}
+
+	// IMPORTANT: Setup hooks after repo creation to avoid issues with externally dependent services.
+	err = s.createServerHooks(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("repository forked. Path: %s", repoPath)
+
+	return &ForkRepositoryOutput{
+		UID:           params.RepoUID,
+		DefaultBranch: defaultBranch,
+	}, nil
+}
+
+type DetachRepositoryParams struct {
+	WriteParams
+}
+
+// DetachRepository copies all objects the repository borrows from other repositories (using git alternates)
+// into the repository itself. It must be called on all forks of a repository before the repository is deleted.
+func (s *Service) DetachRepository(ctx context.Context, params *DetachRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	alternates, err := storage.ReadAlternates(repoPath)
+	if err != nil {
+		return fmt.Errorf("DetachRepository: failed to read alternates: %w", err)
+	}
+
+	if len(alternates) == 0 {
+		return nil
+	}
+
+	err = s.adapter.Repack(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("DetachRepository: failed to repack the repository: %w", err)
+	}
+
+	err = storage.UnlinkObjects(repoPath)
+	if err != nil {
+		return fmt.Errorf("DetachRepository: failed to unlink objects: %w", err)
+	}
+
+	return nil
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+
+	// SourceRepoUID is the UID of the repository from which the objects are fetched.
+	SourceRepoUID string
+
+	// ObjectSHAs is the list of commit SHAs that should be fetched (with all their history).
+	ObjectSHAs []string
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository UID is mandatory")
+	}
+
+	for _, sha := range p.ObjectSHAs {
+		if !isValidGitSHA(sha) {
+			return errors.InvalidArgument("invalid object SHA %q", sha)
+		}
+	}
+
+	return nil
+}
+
+// FetchObjects copies commits from another repository without creating any references.
+// It's used to make commits of a fork available in the upstream repository (e.g. for pull requests).
+func (s *Service) FetchObjects(ctx context.Context, params *FetchObjectsParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if params.SourceRepoUID == params.RepoUID {
+		return nil
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourceRepoPath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+
+	err := s.adapter.FetchObjects(ctx, repoPath, sourceRepoPath, params.ObjectSHAs)
+	if err != nil {
+		return fmt.Errorf("FetchObjects: failed to fetch objects: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/storage"
+	"github.com/harness/gitness/git/types"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+type noopHookClientFactory struct{}
+
+func (f *noopHookClientFactory) NewClient(context.Context, map[string]string) (hook.Client, error) {
+	return hook.NewNoopClient(nil), nil
+}
+
+var testIdentity = Identity{Name: "test", Email: "test@test.com"}
+
+func setupService(t *testing.T) *Service {
+	t.Helper()
+
+	root := t.TempDir()
+	config := types.Config{
+		Root:     root,
+		TmpDir:   filepath.Join(root, "tmp"),
+		HookPath: filepath.Join(root, "hook"),
+	}
+
+	gitAdapter, err := adapter.New(config, adapter.NewInMemoryLastCommitCache(time.Minute), &noopHookClientFactory{})
+	if err != nil {
+		t.Fatalf("failed to create git adapter: %v", err)
+	}
+
+	s, err := New(config, gitAdapter, storage.NewLocalStore())
+	if err != nil {
+		t.Fatalf("failed to create git service: %v", err)
+	}
+
+	return s
+}
+
+// setupRepoWithCommit creates a bare repository with a single commit on the main branch.
+func setupRepoWithCommit(t *testing.T, s *Service, repoUID, content string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	repoPath := getFullPathForRepo(s.reposRoot, repoUID)
+	if _, err := os.Stat(repoPath); os.IsNotExist(err) {
+		if err = s.adapter.InitRepository(ctx, repoPath, true); err != nil {
+			t.Fatalf("failed to init repository: %v", err)
+		}
+		if err = s.adapter.SetDefaultBranch(ctx, repoPath, "main", true); err != nil {
+			t.Fatalf("failed to set default branch: %v", err)
+		}
+	}
+
+	return writeCommit(t, s, repoUID, content)
+}
+
+func writeCommit(t *testing.T, s *Service, repoUID, content string, parents ...string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	repo, err := s.adapter.OpenRepository(ctx, getFullPathForRepo(s.reposRoot, repoUID))
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	defer repo.Close()
+
+	oid, err := repo.HashObject(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("failed to hash object: %v", err)
+	}
+
+	if err = repo.AddObjectToIndex("100644", oid, "file.txt"); err != nil {
+		t.Fatalf("failed to add object to index: %v", err)
+	}
+
+	tree, err := repo.WriteTree()
+	if err != nil {
+		t.Fatalf("failed to write tree: %v", err)
+	}
+
+	signature := &gitea.Signature{Name: testIdentity.Name, Email: testIdentity.Email, When: time.Now()}
+	sha, err := repo.CommitTree(signature, signature, tree, gitea.CommitTreeOpts{
+		Message: "commit " + content,
+		Parents: parents,
+	})
+	if err != nil {
+		t.Fatalf("failed to commit tree: %v", err)
+	}
+
+	if err = repo.SetReference("refs/heads/main", sha.String()); err != nil {
+		t.Fatalf("failed to set reference: %v", err)
+	}
+
+	return sha.String()
+}
+
+func hasCommit(t *testing.T, s *Service, repoUID, sha string) bool {
+	t.Helper()
+	_, err := s.adapter.GetCommit(context.Background(), getFullPathForRepo(s.reposRoot, repoUID), sha)
+	return err == nil
+}
+
+func TestService_ForkRepository(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+
+	upstreamUID := "upstream"
+	sha := setupRepoWithCommit(t, s, upstreamUID, "upstream content")
+
+	out, err := s.ForkRepository(ctx, &ForkRepositoryParams{
+		Actor:           testIdentity,
+		UpstreamRepoUID: upstreamUID,
+	})
+	if err != nil {
+		t.Fatalf("failed to fork repository: %v", err)
+	}
+
+	if out.DefaultBranch != "main" {
+		t.Errorf("default branch: got=%s want=main", out.DefaultBranch)
+	}
+
+	forkPath := getFullPathForRepo(s.reposRoot, out.UID)
+
+	alternates, err := storage.ReadAlternates(forkPath)
+	if err != nil {
+		t.Fatalf("failed to read alternates: %v", err)
+	}
+
+	upstreamObjects := filepath.Join(getFullPathForRepo(s.reposRoot, upstreamUID), "objects")
+	if len(alternates) != 1 || alternates[0] != upstreamObjects {
+		t.Errorf("alternates: got=%v want=[%s]", alternates, upstreamObjects)
+	}
+
+	commit, err := s.adapter.GetCommit(ctx, forkPath, "refs/heads/main")
+	if err != nil {
+		t.Fatalf("failed to get the main branch of the fork: %v", err)
+	}
+	if commit.SHA != sha {
+		t.Errorf("main branch of the fork: got=%s want=%s", commit.SHA, sha)
+	}
+
+	_, err = s.ForkRepository(ctx, &ForkRepositoryParams{
+		Actor:           testIdentity,
+		UpstreamRepoUID: "missing",
+	})
+	if err == nil {
+		t.Errorf("expected an error for a missing upstream repository")
+	}
+}
+
+func TestService_DetachRepository(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+
+	upstreamUID := "upstream"
+	sha := setupRepoWithCommit(t, s, upstreamUID, "upstream content")
+
+	out, err := s.ForkRepository(ctx, &ForkRepositoryParams{
+		Actor:           testIdentity,
+		UpstreamRepoUID: upstreamUID,
+	})
+	if err != nil {
+		t.Fatalf("failed to fork repository: %v", err)
+	}
+
+	err = s.DetachRepository(ctx, &DetachRepositoryParams{
+		WriteParams: WriteParams{RepoUID: out.UID, Actor: testIdentity},
+	})
+	if err != nil {
+		t.Fatalf("failed to detach repository: %v", err)
+	}
+
+	alternates, err := storage.ReadAlternates(getFullPathForRepo(s.reposRoot, out.UID))
+	if err != nil {
+		t.Fatalf("failed to read alternates: %v", err)
+	}
+	if len(alternates) != 0 {
+		t.Errorf("expected no alternates after detach, got=%v", alternates)
+	}
+
+	// the fork must keep all objects after the upstream repository is gone.
+	if err = os.RemoveAll(getFullPathForRepo(s.reposRoot, upstreamUID)); err != nil {
+		t.Fatalf("failed to remove upstream repository: %v", err)
+	}
+
+	if !hasCommit(t, s, out.UID, sha) {
+		t.Errorf("commit %s is missing in the detached fork", sha)
+	}
+
+	// detaching a repository without alternates is a no-op.
+	err = s.DetachRepository(ctx, &DetachRepositoryParams{
+		WriteParams: WriteParams{RepoUID: out.UID, Actor: testIdentity},
+	})
+	if err != nil {
+		t.Errorf("failed to detach repository without alternates: %v", err)
+	}
+}
+
+func TestService_FetchObjects(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+
+	upstreamUID := "upstream"
+	baseSHA := setupRepoWithCommit(t, s, upstreamUID, "upstream content")
+
+	out, err := s.ForkRepository(ctx, &ForkRepositoryParams{
+		Actor:           testIdentity,
+		UpstreamRepoUID: upstreamUID,
+	})
+	if err != nil {
+		t.Fatalf("failed to fork repository: %v", err)
+	}
+
+	forkSHA := writeCommit(t, s, out.UID, "fork content", baseSHA)
+
+	if hasCommit(t, s, upstreamUID, forkSHA) {
+		t.Fatalf("commit %s of the fork must not be in the upstream repository", forkSHA)
+	}
+
+	err = s.FetchObjects(ctx, &FetchObjectsParams{
+		WriteParams:   WriteParams{RepoUID: upstreamUID, Actor: testIdentity},
+		SourceRepoUID: out.UID,
+		ObjectSHAs:    []string{forkSHA},
+	})
+	if err != nil {
+		t.Fatalf("failed to fetch objects: %v", err)
+	}
+
+	if !hasCommit(t, s, upstreamUID, forkSHA) {
+		t.Errorf("commit %s of the fork is missing in the upstream repository", forkSHA)
+	}
+
+	err = s.FetchObjects(ctx, &FetchObjectsParams{
+		WriteParams:   WriteParams{RepoUID: upstreamUID, Actor: testIdentity},
+		SourceRepoUID: out.UID,
+		ObjectSHAs:    []string{"not-a-sha"},
+	})
+	if err == nil {
+		t.Errorf("expected an error for an invalid object SHA")
+	}
+}
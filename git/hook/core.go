@@ -21,10 +21,18 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
 
+const (
+	envNameObjectDir           = "GIT_OBJECT_DIRECTORY"
+	envNameAlternateObjectDirs = "GIT_ALTERNATE_OBJECT_DIRECTORIES"
+	envNamePushOptionCount     = "GIT_PUSH_OPTION_COUNT"
+	envNamePushOptionPrefix    = "GIT_PUSH_OPTION_"
+)
+
 // CLICore implements the core of a githook cli. It uses the client and execution timeout
 // to perform githook operations as part of a cli.
 type CLICore struct {
@@ -48,7 +56,9 @@ func (c *CLICore) PreReceive(ctx context.Context) error {
 	}
 
 	in := PreReceiveInput{
-		RefUpdates: refUpdates,
+		RefUpdates:  refUpdates,
+		Environment: getEnvironment(),
+		PushOptions: getPushOptions(),
 	}
 
 	out, err := c.client.PreReceive(ctx, in)
@@ -110,6 +120,39 @@ func handleServerHookOutput(out Output, err error) error {
 	return nil
 }
 
+// getEnvironment returns the object directories git provides to the hook.
+// During a push git stores the received objects in a quarantine directory
+// that is only accessible via the environment variables of the hook.
+func getEnvironment() Environment {
+	env := Environment{}
+
+	if objectDir := os.Getenv(envNameObjectDir); objectDir != "" {
+		env.AlternateObjectDirs = append(env.AlternateObjectDirs, objectDir)
+	}
+
+	if alternates := os.Getenv(envNameAlternateObjectDirs); alternates != "" {
+		env.AlternateObjectDirs = append(env.AlternateObjectDirs, strings.Split(alternates, ":")...)
+	}
+
+	return env
+}
+
+// getPushOptions returns the push options git provides to the hook.
+// Git only accepts push options if the server advertises them (receive.advertisePushOptions).
+func getPushOptions() []string {
+	count, err := strconv.Atoi(os.Getenv(envNamePushOptionCount))
+	if err != nil || count <= 0 {
+		return nil
+	}
+
+	options := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		options = append(options, os.Getenv(envNamePushOptionPrefix+strconv.Itoa(i)))
+	}
+
+	return options
+}
+
 // getUpdatedReferencesFromStdIn reads the updated references provided by git from stdin.
 // The expected format is "<old-value> SP <new-value> SP <ref-name> LF"
 // For more details see https://git-scm.com/docs/githooks#pre-receive
@@ -43,6 +43,19 @@ type PostReceiveInput struct {
 type PreReceiveInput struct {
 	// RefUpdates contains all references that are being updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+
+	// Environment contains the information required to access the objects of the git operation.
+	Environment Environment `json:"environment"`
+
+	// PushOptions contains the push options provided by the client (e.g. git push -o key=value).
+	PushOptions []string `json:"push_options,omitempty"`
+}
+
+// Environment contains the information required to access the objects of a git operation.
+type Environment struct {
+	// AlternateObjectDirs contains the object directories of the operation that are not yet part of the repository
+	// (e.g. the quarantine directory of a push).
+	AlternateObjectDirs []string `json:"alternate_object_dirs,omitempty"`
 }
 
 // UpdateInput represents the input of the update git hook.
@@ -55,8 +55,10 @@ type ServicePackParams struct {
 	*WriteParams
 	Service     string
 	GitProtocol string
-	Data        io.Reader
-	Options     []string // (key, value) pair
+	// StatelessRPC runs the service in stateless rpc mode as required by git's smart http protocol.
+	StatelessRPC bool
+	Data         io.Reader
+	Options      []string // (key, value) pair
 }
 
 func (p *ServicePackParams) Validate() error {
@@ -96,7 +98,7 @@ func (s *Service) ServicePack(ctx context.Context, w io.Writer, params *ServiceP
 		env = append(env, "GIT_PROTOCOL="+params.GitProtocol)
 	}
 
-	err := s.adapter.ServicePack(ctx, repoPath, params.Service, params.Data, w, env...)
+	err := s.adapter.ServicePack(ctx, repoPath, params.Service, params.StatelessRPC, params.Data, w, env...)
 	if err != nil {
 		return fmt.Errorf("failed to execute git %s: %w", params.Service, err)
 	}
@@ -28,6 +28,7 @@ type Interface interface {
 	ListTreeNodes(ctx context.Context, params *ListTreeNodeParams) (*ListTreeNodeOutput, error)
 	GetSubmodule(ctx context.Context, params *GetSubmoduleParams) (*GetSubmoduleOutput, error)
 	GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobOutput, error)
+	FindOversizeFiles(ctx context.Context, params *FindOversizeFilesParams) (FindOversizeFilesOutput, error)
 	CreateBranch(ctx context.Context, params *CreateBranchParams) (*CreateBranchOutput, error)
 	CreateCommitTag(ctx context.Context, params *CreateCommitTagParams) (*CreateCommitTagOutput, error)
 	DeleteTag(ctx context.Context, params *DeleteTagParams) error
@@ -46,6 +47,10 @@ type Interface interface {
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
 
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*ForkRepositoryOutput, error)
+	DetachRepository(ctx context.Context, params *DetachRepositoryParams) error
+	FetchObjects(ctx context.Context, params *FetchObjectsParams) error
+
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
 	/*
@@ -53,6 +58,7 @@ type Interface interface {
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
+	ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (ListNewCommitsOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
@@ -65,12 +71,19 @@ type Interface interface {
 	GetInfoRefs(ctx context.Context, w io.Writer, params *InfoRefsParams) error
 	ServicePack(ctx context.Context, w io.Writer, params *ServicePackParams) error
 
+	/*
+	 * Archive services
+	 */
+	Archive(ctx context.Context, w io.Writer, params *ArchiveParams) error
+
 	/*
 	 * Diff services
 	 */
 	RawDiff(ctx context.Context, w io.Writer, in *DiffParams, files ...types.FileDiffRequest) error
 	Diff(ctx context.Context, in *DiffParams, files ...types.FileDiffRequest) (<-chan *FileDiff, <-chan error)
 	DiffFileNames(ctx context.Context, in *DiffParams) (DiffFileNamesOutput, error)
+	ListChangedPaths(ctx context.Context, params *ListChangedPathsParams) (ListChangedPathsOutput, error)
+	ListAddedLines(ctx context.Context, params *ListAddedLinesParams) (<-chan *AddedLines, <-chan error)
 	CommitDiff(ctx context.Context, params *GetCommitParams, w io.Writer) error
 	DiffShortStat(ctx context.Context, params *DiffParams) (DiffShortStatOutput, error)
 	DiffStats(ctx context.Context, params *DiffParams) (DiffStatsOutput, error)
@@ -83,6 +96,15 @@ type Interface interface {
 	 * Merge services
 	 */
 	Merge(ctx context.Context, in *MergeParams) (MergeOutput, error)
+	UpdateBranch(ctx context.Context, params *UpdateBranchParams) (UpdateBranchOutput, error)
+	MergeConflicts(ctx context.Context, params *MergeConflictsParams) (MergeConflictsOutput, error)
+	ResolveConflicts(ctx context.Context, params *ResolveConflictsParams) (ResolveConflictsOutput, error)
+
+	/*
+	 * Cherry-pick and revert services
+	 */
+	CherryPick(ctx context.Context, params *PickParams) (PickOutput, error)
+	Revert(ctx context.Context, params *PickParams) (PickOutput, error)
 
 	/*
 	 * Blame services
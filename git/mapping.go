@@ -56,15 +56,29 @@ func mapCommit(c *types.Commit) (*Commit, error) {
 		return nil, fmt.Errorf("failed to map rpc committer: %w", err)
 	}
 	return &Commit{
-		SHA:       c.SHA,
-		Title:     c.Title,
-		Message:   c.Message,
-		Author:    *author,
-		Committer: *comitter,
-		FileStats: *mapFileStats(&c.FileStats),
+		SHA:        c.SHA,
+		Title:      c.Title,
+		Message:    c.Message,
+		Author:     *author,
+		Committer:  *comitter,
+		FileStats:  *mapFileStats(&c.FileStats),
+		ParentSHAs: c.ParentSHAs,
+		SignedData: mapSignedData(c.SignedData),
 	}, nil
 }
 
+func mapSignedData(d *types.SignedData) *SignedData {
+	if d == nil {
+		return nil
+	}
+
+	return &SignedData{
+		Type:          d.Type,
+		Signature:     d.Signature,
+		SignedContent: d.SignedContent,
+	}
+}
+
 func mapFileStats(s *types.CommitFileStats) *CommitFileStats {
 	return &CommitFileStats{
 		Added:    s.Added,
@@ -124,6 +138,7 @@ func mapAnnotatedTag(tag *types.Tag) *CommitTag {
 		Tagger:      tagger,
 		IsAnnotated: true,
 		Commit:      nil,
+		SignedData:  mapSignedData(tag.SignedData),
 	}
 }
 
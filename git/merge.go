@@ -33,7 +33,7 @@ type MergeParams struct {
 	WriteParams
 	BaseBranch string
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// If it's different from the RepoUID, the head commits are first fetched into the repository.
 	HeadRepoUID string
 	HeadBranch  string
 	Title       string
@@ -95,6 +95,8 @@ type MergeOutput struct {
 	MergeBaseSHA string
 	// MergeSHA is the sha of the commit after merging HeadSHA with BaseSHA.
 	MergeSHA string
+	// FastForwardPossible is true if the BaseSHA is an ancestor of the HeadSHA.
+	FastForwardPossible bool
 
 	CommitCount      int
 	ChangedFileCount int
@@ -140,6 +142,10 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		mergeFunc = merge.Squash
 	case enum.MergeMethodRebase:
 		mergeFunc = merge.Rebase
+	case enum.MergeMethodFastForward:
+		mergeFunc = merge.FastForward
+	case enum.MergeMethodRebaseMerge:
+		mergeFunc = merge.RebaseMerge
 	default:
 		// should not happen, the call to Sanitize above should handle this case.
 		panic("unsupported merge method")
@@ -183,7 +189,12 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
 	}
 
-	headCommitSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, params.HeadBranch)
+	headRepoPath := repoPath
+	if params.HeadRepoUID != "" && params.HeadRepoUID != params.RepoUID {
+		headRepoPath = getFullPathForRepo(s.reposRoot, params.HeadRepoUID)
+	}
+
+	headCommitSHA, err := s.adapter.GetFullCommitID(ctx, headRepoPath, params.HeadBranch)
 	if err != nil {
 		return MergeOutput{}, fmt.Errorf("failed to get merge base branch commit SHA: %w", err)
 	}
@@ -196,6 +207,14 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 			params.HeadExpectedSHA)
 	}
 
+	// the head commits must exist in the base repository to be able to merge them.
+	if headRepoPath != repoPath {
+		err = s.adapter.FetchObjects(ctx, repoPath, headRepoPath, []string{headCommitSHA})
+		if err != nil {
+			return MergeOutput{}, fmt.Errorf("failed to fetch head branch commits from the head repository: %w", err)
+		}
+	}
+
 	mergeBaseCommitSHA, _, err := s.adapter.GetMergeBase(ctx, repoPath, "origin", baseCommitSHA, headCommitSHA)
 	if err != nil {
 		return MergeOutput{}, fmt.Errorf("failed to get merge base: %w", err)
@@ -205,6 +224,8 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		return MergeOutput{}, errors.InvalidArgument("head branch doesn't contain any new commits.")
 	}
 
+	fastForwardPossible := baseCommitSHA == mergeBaseCommitSHA
+
 	// find short stat and number of commits
 
 	shortStat, err := s.adapter.DiffShortStat(ctx, repoPath, baseCommitSHA, headCommitSHA, true)
@@ -232,16 +253,23 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		log.Debug().Msg("merged check completed")
 
 		return MergeOutput{
-			BaseSHA:          baseCommitSHA,
-			HeadSHA:          headCommitSHA,
-			MergeBaseSHA:     mergeBaseCommitSHA,
-			MergeSHA:         "",
-			CommitCount:      commitCount,
-			ChangedFileCount: changedFileCount,
-			ConflictFiles:    conflicts,
+			BaseSHA:             baseCommitSHA,
+			HeadSHA:             headCommitSHA,
+			MergeBaseSHA:        mergeBaseCommitSHA,
+			MergeSHA:            "",
+			FastForwardPossible: fastForwardPossible,
+			CommitCount:         commitCount,
+			ChangedFileCount:    changedFileCount,
+			ConflictFiles:       conflicts,
 		}, nil
 	}
 
+	if mergeMethod == enum.MergeMethodFastForward && !fastForwardPossible {
+		return MergeOutput{}, errors.InvalidArgument(
+			"Fast-forward merge is not possible: base branch %q contains commits that are not in head branch %q.",
+			params.BaseBranch, params.HeadBranch)
+	}
+
 	// author and committer
 
 	now := time.Now().UTC()
@@ -278,20 +306,22 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		repoPath, s.tmpDir,
 		&author, &committer,
 		mergeMsg,
-		mergeBaseCommitSHA, baseCommitSHA, headCommitSHA)
+		mergeBaseCommitSHA, baseCommitSHA, headCommitSHA,
+		params.Signer)
 	if err != nil {
 		return MergeOutput{}, errors.Internal(err, "failed to merge %q to %q in %q using the %q merge method.",
 			params.HeadBranch, params.BaseBranch, params.RepoUID, mergeMethod)
 	}
 	if len(conflicts) != 0 {
 		return MergeOutput{
-			BaseSHA:          baseCommitSHA,
-			HeadSHA:          headCommitSHA,
-			MergeBaseSHA:     mergeBaseCommitSHA,
-			MergeSHA:         "",
-			CommitCount:      commitCount,
-			ChangedFileCount: changedFileCount,
-			ConflictFiles:    conflicts,
+			BaseSHA:             baseCommitSHA,
+			HeadSHA:             headCommitSHA,
+			MergeBaseSHA:        mergeBaseCommitSHA,
+			MergeSHA:            "",
+			FastForwardPossible: fastForwardPossible,
+			CommitCount:         commitCount,
+			ChangedFileCount:    changedFileCount,
+			ConflictFiles:       conflicts,
 		}, nil
 	}
 
@@ -315,13 +345,14 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 	log.Trace().Msg("merge completed - git reference updated")
 
 	return MergeOutput{
-		BaseSHA:          baseCommitSHA,
-		HeadSHA:          headCommitSHA,
-		MergeBaseSHA:     mergeBaseCommitSHA,
-		MergeSHA:         mergeCommitSHA,
-		CommitCount:      commitCount,
-		ChangedFileCount: changedFileCount,
-		ConflictFiles:    nil,
+		BaseSHA:             baseCommitSHA,
+		HeadSHA:             headCommitSHA,
+		MergeBaseSHA:        mergeBaseCommitSHA,
+		MergeSHA:            mergeCommitSHA,
+		FastForwardPossible: fastForwardPossible,
+		CommitCount:         commitCount,
+		ChangedFileCount:    changedFileCount,
+		ConflictFiles:       nil,
 	}, nil
 }
 
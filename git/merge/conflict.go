@@ -0,0 +1,415 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sharedrepo"
+	"github.com/harness/gitness/git/types"
+)
+
+const (
+	// conflictFileMaxSize is the max size of a file version whose content is returned.
+	conflictFileMaxSize = 1 << 20
+
+	markerOurs   = "<<<<<<<"
+	markerBase   = "|||||||"
+	markerSep    = "======="
+	markerTheirs = ">>>>>>>"
+
+	defaultFileMode = "100644"
+)
+
+// ConflictFiles returns the versions of the files that can't be merged automatically,
+// with the content of every file with the conflict markers split into hunks.
+func ConflictFiles(
+	ctx context.Context,
+	repoPath, tmpDir string,
+	mergeBaseSHA, oursSHA, theirsSHA string,
+	paths []string,
+) ([]types.MergeConflictFile, error) {
+	files := make([]types.MergeConflictFile, 0, len(paths))
+
+	for _, path := range paths {
+		file := types.MergeConflictFile{Path: path}
+
+		var err error
+		var baseContent, oursContent, theirsContent []byte
+
+		file.Base, baseContent, err = fileVersion(ctx, repoPath, mergeBaseSHA, path)
+		if err != nil {
+			return nil, err
+		}
+
+		file.Ours, oursContent, err = fileVersion(ctx, repoPath, oursSHA, path)
+		if err != nil {
+			return nil, err
+		}
+
+		file.Theirs, theirsContent, err = fileVersion(ctx, repoPath, theirsSHA, path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range []*types.MergeConflictFileVersion{file.Base, file.Ours, file.Theirs} {
+			if v != nil && v.Size > conflictFileMaxSize {
+				file.TooLarge = true
+			}
+		}
+
+		for _, content := range [][]byte{baseContent, oursContent, theirsContent} {
+			if isBinary(content) {
+				file.Binary = true
+			}
+		}
+
+		if file.TooLarge || file.Binary {
+			for _, v := range []*types.MergeConflictFileVersion{file.Base, file.Ours, file.Theirs} {
+				if v != nil {
+					v.Content = ""
+				}
+			}
+			files = append(files, file)
+			continue
+		}
+
+		merged, err := mergeFile(ctx, tmpDir, oursContent, baseContent, theirsContent,
+			oursSHA, mergeBaseSHA, theirsSHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge file %q: %w", path, err)
+		}
+
+		file.Merged = string(merged)
+		file.Hunks = conflictHunks(merged)
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// ResolveConflicts merges theirsSHA into oursSHA using the resolutions for the conflicting files.
+// Every conflicting file must be resolved and every resolution must be for a conflicting file.
+func ResolveConflicts(
+	ctx context.Context,
+	repoPath, tmpDir string,
+	author, committer *types.Signature,
+	message string,
+	mergeBaseSHA, oursSHA, theirsSHA string,
+	resolutions []types.MergeConflictResolution,
+	signer types.Signer,
+) (mergeSHA string, err error) {
+	err = runInSharedRepo(ctx, tmpDir, repoPath, signer, func(s *sharedrepo.SharedRepo) error {
+		treeSHA, conflicts, err := s.MergeTree(ctx, mergeBaseSHA, oursSHA, theirsSHA)
+		if err != nil {
+			return fmt.Errorf("merge tree failed: %w", err)
+		}
+
+		if err := validateResolutions(conflicts, resolutions); err != nil {
+			return err
+		}
+
+		// the merged tree contains the conflicting files with the conflict markers, they are replaced here.
+		if err := s.SetIndex(ctx, treeSHA); err != nil {
+			return fmt.Errorf("failed to set index to the merged tree: %w", err)
+		}
+
+		for _, resolution := range resolutions {
+			if resolution.Delete {
+				if err := s.RemoveFilesFromIndex(ctx, resolution.Path); err != nil {
+					return fmt.Errorf("failed to remove file %q: %w", resolution.Path, err)
+				}
+				continue
+			}
+
+			mode, err := fileMode(ctx, s.Directory(), treeSHA, resolution.Path)
+			if err != nil {
+				return err
+			}
+
+			objectSHA, err := s.WriteGitObject(ctx, bytes.NewReader(resolution.Content))
+			if err != nil {
+				return fmt.Errorf("failed to write file %q: %w", resolution.Path, err)
+			}
+
+			if err := s.AddObjectToIndex(ctx, mode, objectSHA, resolution.Path); err != nil {
+				return fmt.Errorf("failed to add file %q: %w", resolution.Path, err)
+			}
+		}
+
+		resolvedTreeSHA, err := s.WriteTree(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to write tree: %w", err)
+		}
+
+		mergeSHA, err = s.CommitTree(ctx, author, committer, resolvedTreeSHA, message, false, oursSHA, theirsSHA)
+		if err != nil {
+			return fmt.Errorf("commit tree failed: %w", err)
+		}
+
+		return nil
+	})
+	if errors.IsInvalidArgument(err) {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("resolve conflicts: %w", err)
+	}
+
+	return mergeSHA, nil
+}
+
+// validateResolutions verifies that every conflict is resolved, and that resolved content has no conflict markers.
+func validateResolutions(conflicts []string, resolutions []types.MergeConflictResolution) error {
+	if len(conflicts) == 0 {
+		return errors.InvalidArgument("There are no conflicts to resolve.")
+	}
+
+	conflictMap := make(map[string]bool, len(conflicts))
+	for _, path := range conflicts {
+		conflictMap[path] = false
+	}
+
+	for _, resolution := range resolutions {
+		resolved, ok := conflictMap[resolution.Path]
+		if !ok {
+			return errors.InvalidArgument("File %q is not in conflict.", resolution.Path)
+		}
+		if resolved {
+			return errors.InvalidArgument("File %q is resolved more than once.", resolution.Path)
+		}
+
+		conflictMap[resolution.Path] = true
+
+		if !resolution.Delete && hasConflictMarkers(resolution.Content) {
+			return errors.InvalidArgument("Resolved content of file %q contains conflict markers.", resolution.Path)
+		}
+	}
+
+	for _, path := range conflicts {
+		if !conflictMap[path] {
+			return errors.InvalidArgument("Conflict in file %q is not resolved.", path)
+		}
+	}
+
+	return nil
+}
+
+func hasConflictMarkers(content []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, conflictFileMaxSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, markerOurs+" ") || strings.HasPrefix(line, markerTheirs+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// conflictHunks splits the content with the conflict markers (in the diff3 style) into hunks.
+func conflictHunks(merged []byte) []types.MergeConflictHunk {
+	const (
+		stateNone = iota
+		stateOurs
+		stateBase
+		stateTheirs
+	)
+
+	var hunks []types.MergeConflictHunk
+	var hunk types.MergeConflictHunk
+	var ours, base, theirs strings.Builder
+
+	state := stateNone
+	lines := strings.SplitAfter(string(merged), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimRight(line, "\r\n")
+
+		switch {
+		case state == stateNone && strings.HasPrefix(trimmed, markerOurs):
+			hunk = types.MergeConflictHunk{Line: i + 1}
+			state = stateOurs
+		case state == stateOurs && strings.HasPrefix(trimmed, markerBase):
+			state = stateBase
+		case (state == stateOurs || state == stateBase) && trimmed == markerSep:
+			state = stateTheirs
+		case state == stateTheirs && strings.HasPrefix(trimmed, markerTheirs):
+			hunk.Ours, hunk.Base, hunk.Theirs = ours.String(), base.String(), theirs.String()
+			hunks = append(hunks, hunk)
+			ours.Reset()
+			base.Reset()
+			theirs.Reset()
+			state = stateNone
+		case state == stateOurs:
+			ours.WriteString(line)
+		case state == stateBase:
+			base.WriteString(line)
+		case state == stateTheirs:
+			theirs.WriteString(line)
+		}
+	}
+
+	return hunks
+}
+
+// mergeFile merges the three versions of a file using git merge-file and returns the content
+// with the conflict markers in the diff3 style.
+func mergeFile(
+	ctx context.Context,
+	tmpDir string,
+	ours, base, theirs []byte,
+	oursLabel, baseLabel, theirsLabel string,
+) ([]byte, error) {
+	dir, err := os.MkdirTemp(tmpDir, "merge-file-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	files := make([]string, 3)
+	for i, content := range [][]byte{ours, base, theirs} {
+		files[i] = filepath.Join(dir, strconv.Itoa(i))
+		if err := os.WriteFile(files[i], content, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write temporary file: %w", err)
+		}
+	}
+
+	cmd := command.New("merge-file",
+		command.WithFlag("--stdout"),
+		command.WithFlag("--diff3"),
+		command.WithFlag("-L", oursLabel),
+		command.WithFlag("-L", baseLabel),
+		command.WithFlag("-L", theirsLabel),
+		command.WithArg(files...))
+
+	stdout := bytes.NewBuffer(nil)
+
+	err = cmd.Run(ctx, command.WithDir(dir), command.WithStdout(stdout))
+	// the exit code is the number of conflicts, a negative exit code means an error.
+	if cErr := command.AsError(err); cErr != nil && cErr.ExitCode() > 0 && cErr.ExitCode() < 128 {
+		err = nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge-file: %w", err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// fileVersion returns the version of the file in the commit, and its content.
+// It returns nil if the file doesn't exist in the commit.
+func fileVersion(
+	ctx context.Context,
+	repoPath, commitSHA, path string,
+) (*types.MergeConflictFileVersion, []byte, error) {
+	mode, objectSHA, size, err := lsTree(ctx, repoPath, commitSHA, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if objectSHA == "" {
+		return nil, nil, nil
+	}
+
+	version := &types.MergeConflictFileVersion{
+		SHA:  objectSHA,
+		Mode: mode,
+		Size: size,
+	}
+
+	if size > conflictFileMaxSize {
+		return version, nil, nil
+	}
+
+	cmd := command.New("cat-file", command.WithArg("blob", objectSHA))
+
+	stdout := bytes.NewBuffer(nil)
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(stdout)); err != nil {
+		return nil, nil, fmt.Errorf("failed to read file %q of commit %s: %w", path, commitSHA, err)
+	}
+
+	content := stdout.Bytes()
+	version.Content = string(content)
+
+	return version, content, nil
+}
+
+// fileMode returns the mode of the file in the tree, or the default file mode if the file doesn't exist.
+func fileMode(ctx context.Context, repoPath, treeish, path string) (string, error) {
+	mode, _, _, err := lsTree(ctx, repoPath, treeish, path)
+	if err != nil {
+		return "", err
+	}
+	if mode == "" {
+		return defaultFileMode, nil
+	}
+
+	return mode, nil
+}
+
+// lsTree returns the mode, the object SHA and the size of the file in the tree.
+// All returned values are empty if the file doesn't exist.
+func lsTree(ctx context.Context, repoPath, treeish, path string) (string, string, int64, error) {
+	cmd := command.New("ls-tree",
+		command.WithFlag("-z"),
+		command.WithFlag("--long"),
+		command.WithArg(treeish),
+		command.WithPostSepArg(path))
+
+	stdout := bytes.NewBuffer(nil)
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(stdout)); err != nil {
+		return "", "", 0, fmt.Errorf("failed to ls-tree %q of %s: %w", path, treeish, err)
+	}
+
+	// the output format is: <mode> SP <type> SP <object> SP+ <size> TAB <path> NUL
+	info, _, found := strings.Cut(stdout.String(), "\t")
+	if !found {
+		return "", "", 0, nil
+	}
+
+	fields := strings.Fields(info)
+	const fieldCount = 4
+	if len(fields) != fieldCount || fields[1] != "blob" {
+		return "", "", 0, nil
+	}
+
+	size, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to parse size of %q: %w", path, err)
+	}
+
+	return fields[0], fields[2], size, nil
+}
+
+func isBinary(content []byte) bool {
+	const sniffLen = 8000
+	if len(content) > sniffLen {
+		content = content[:sniffLen]
+	}
+
+	return bytes.IndexByte(content, 0) >= 0
+}
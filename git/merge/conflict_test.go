@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestConflictHunks(t *testing.T) {
+	tests := []struct {
+		name   string
+		merged string
+		want   []types.MergeConflictHunk
+	}{
+		{
+			name:   "no conflicts",
+			merged: "a\nb\n",
+			want:   nil,
+		},
+		{
+			name: "single hunk",
+			merged: "a\n" +
+				"<<<<<<< source\n" +
+				"ours\n" +
+				"||||||| base\n" +
+				"base\n" +
+				"=======\n" +
+				"theirs\n" +
+				">>>>>>> target\n" +
+				"z\n",
+			want: []types.MergeConflictHunk{
+				{Line: 2, Ours: "ours\n", Base: "base\n", Theirs: "theirs\n"},
+			},
+		},
+		{
+			name: "two hunks, second without base",
+			merged: "<<<<<<< source\n" +
+				"x\n" +
+				"||||||| base\n" +
+				"=======\n" +
+				"y\n" +
+				">>>>>>> target\n" +
+				"middle\n" +
+				"<<<<<<< source\n" +
+				"1\n" +
+				"2\n" +
+				"=======\n" +
+				">>>>>>> target\n",
+			want: []types.MergeConflictHunk{
+				{Line: 1, Ours: "x\n", Base: "", Theirs: "y\n"},
+				{Line: 8, Ours: "1\n2\n", Base: "", Theirs: ""},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := conflictHunks([]byte(test.merged))
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateResolutions(t *testing.T) {
+	conflicts := []string{"a.txt", "b.txt"}
+
+	tests := []struct {
+		name        string
+		resolutions []types.MergeConflictResolution
+		wantErr     bool
+	}{
+		{
+			name: "all resolved",
+			resolutions: []types.MergeConflictResolution{
+				{Path: "a.txt", Content: []byte("resolved\n")},
+				{Path: "b.txt", Delete: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing resolution",
+			resolutions: []types.MergeConflictResolution{
+				{Path: "a.txt", Content: []byte("resolved\n")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "file not in conflict",
+			resolutions: []types.MergeConflictResolution{
+				{Path: "a.txt", Content: []byte("resolved\n")},
+				{Path: "b.txt", Content: []byte("resolved\n")},
+				{Path: "c.txt", Content: []byte("resolved\n")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "resolved twice",
+			resolutions: []types.MergeConflictResolution{
+				{Path: "a.txt", Content: []byte("resolved\n")},
+				{Path: "a.txt", Content: []byte("resolved\n")},
+				{Path: "b.txt", Content: []byte("resolved\n")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflict markers left",
+			resolutions: []types.MergeConflictResolution{
+				{Path: "a.txt", Content: []byte("<<<<<<< source\nx\n=======\ny\n>>>>>>> target\n")},
+				{Path: "b.txt", Content: []byte("resolved\n")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateResolutions(conflicts, test.resolutions)
+			if (err != nil) != test.wantErr {
+				t.Errorf("validateResolutions() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
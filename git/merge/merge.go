@@ -30,6 +30,7 @@ type Func func(
 	author, committer *types.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA string,
+	signer types.Signer,
 ) (mergeSHA string, conflicts []string, err error)
 
 // Merge merges two the commits (targetSHA and sourceSHA) using the Merge method.
@@ -39,12 +40,14 @@ func Merge(
 	author, committer *types.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA string,
+	signer types.Signer,
 ) (mergeSHA string, conflicts []string, err error) {
 	return mergeInternal(ctx,
 		repoPath, tmpDir,
 		author, committer,
 		message,
 		mergeBaseSHA, targetSHA, sourceSHA,
+		signer,
 		false)
 }
 
@@ -55,12 +58,14 @@ func Squash(
 	author, committer *types.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA string,
+	signer types.Signer,
 ) (mergeSHA string, conflicts []string, err error) {
 	return mergeInternal(ctx,
 		repoPath, tmpDir,
 		author, committer,
 		message,
 		mergeBaseSHA, targetSHA, sourceSHA,
+		signer,
 		true)
 }
 
@@ -71,9 +76,10 @@ func mergeInternal(
 	author, committer *types.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA string,
+	signer types.Signer,
 	squash bool,
 ) (mergeSHA string, conflicts []string, err error) {
-	err = runInSharedRepo(ctx, tmpDir, repoPath, func(s *sharedrepo.SharedRepo) error {
+	err = runInSharedRepo(ctx, tmpDir, repoPath, signer, func(s *sharedrepo.SharedRepo) error {
 		var err error
 
 		var treeSHA string
@@ -114,76 +120,141 @@ func Rebase(
 	_, committer *types.Signature, // commit author isn't used here - it's copied from every commit
 	_ string, // commit message isn't used here
 	mergeBaseSHA, targetSHA, sourceSHA string,
+	signer types.Signer,
 ) (mergeSHA string, conflicts []string, err error) {
-	err = runInSharedRepo(ctx, tmpDir, repoPath, func(s *sharedrepo.SharedRepo) error {
-		sourceSHAs, err := s.CommitSHAList(ctx, mergeBaseSHA, sourceSHA)
+	err = runInSharedRepo(ctx, tmpDir, repoPath, signer, func(s *sharedrepo.SharedRepo) error {
+		mergeSHA, conflicts, err = rebaseCommits(ctx, s, committer, mergeBaseSHA, targetSHA, sourceSHA)
+		return err
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("merge method=rebase: %w", err)
+	}
+
+	return mergeSHA, conflicts, nil
+}
+
+// RebaseMerge merges two the commits (targetSHA and sourceSHA) using the RebaseMerge method:
+// The source commits are rebased on top of the target and then merged with a merge commit.
+func RebaseMerge(
+	ctx context.Context,
+	repoPath, tmpDir string,
+	author, committer *types.Signature,
+	message string,
+	mergeBaseSHA, targetSHA, sourceSHA string,
+	signer types.Signer,
+) (mergeSHA string, conflicts []string, err error) {
+	err = runInSharedRepo(ctx, tmpDir, repoPath, signer, func(s *sharedrepo.SharedRepo) error {
+		var rebasedSHA string
+
+		rebasedSHA, conflicts, err = rebaseCommits(ctx, s, committer, mergeBaseSHA, targetSHA, sourceSHA)
+		if err != nil || len(conflicts) > 0 {
+			return err
+		}
+
+		// the rebased commits are on top of the target, so the merge commit has the tree of the last of them.
+		treeSHA, err := s.TreeSHA(ctx, rebasedSHA)
 		if err != nil {
-			return fmt.Errorf("failed to find commit list in rebase merge: %w", err)
+			return fmt.Errorf("failed to get tree of the rebased commit: %w", err)
 		}
 
-		lastCommitSHA := targetSHA
+		mergeSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, targetSHA, rebasedSHA)
+		if err != nil {
+			return fmt.Errorf("commit tree failed: %w", err)
+		}
 
-		for i := len(sourceSHAs) - 1; i >= 0; i-- {
-			commitSHA := sourceSHAs[i]
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("merge method=rebase-merge: %w", err)
+	}
 
-			var treeSHA string
-			var commitConflicts []string
+	return mergeSHA, conflicts, nil
+}
 
-			commitInfo, err := adapter.GetCommit(ctx, s.Directory(), commitSHA, "")
-			if err != nil {
-				return fmt.Errorf("failed to get commit data in rebase merge: %w", err)
-			}
+// FastForward merges two the commits (targetSHA and sourceSHA) using the FastForward method:
+// No commit is created, the target is simply moved to the source commit.
+func FastForward(
+	_ context.Context,
+	_, _ string,
+	_, _ *types.Signature,
+	_ string,
+	mergeBaseSHA, targetSHA, sourceSHA string,
+	_ types.Signer,
+) (mergeSHA string, conflicts []string, err error) {
+	if mergeBaseSHA != targetSHA {
+		return "", nil, fmt.Errorf("merge method=fast-forward: target %s is not an ancestor of source %s",
+			targetSHA, sourceSHA)
+	}
 
-			// rebase merge preserves the commit author (and date) and the commit message, but changes the committer.
-			author := &commitInfo.Author
-			message := commitInfo.Title
-			if commitInfo.Message != "" {
-				message += "\n\n" + commitInfo.Message
-			}
+	return sourceSHA, nil, nil
+}
 
-			treeSHA, commitConflicts, err = s.MergeTree(ctx, mergeBaseSHA, lastCommitSHA, commitSHA)
-			if err != nil {
-				return fmt.Errorf("failed to merge tree in rebase merge: %w", err)
-			}
+// rebaseCommits applies, one by one, the commits between mergeBaseSHA and sourceSHA on top of the targetSHA.
+// It returns the SHA of the last rebased commit.
+func rebaseCommits(
+	ctx context.Context,
+	s *sharedrepo.SharedRepo,
+	committer *types.Signature,
+	mergeBaseSHA, targetSHA, sourceSHA string,
+) (string, []string, error) {
+	sourceSHAs, err := s.CommitSHAList(ctx, mergeBaseSHA, sourceSHA)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find commit list in rebase merge: %w", err)
+	}
 
-			if len(commitConflicts) > 0 {
-				_, _, conflicts, err = FindConflicts(ctx, s.Directory(), targetSHA, sourceSHA)
-				if err != nil {
-					return fmt.Errorf("failed to find conflicts in rebase merge: %w", err)
-				}
+	lastCommitSHA := targetSHA
 
-				if len(conflicts) == 0 {
-					return fmt.Errorf("expected to find conflicts after rebase merge between %s and %s, but couldn't",
-						mergeBaseSHA, sourceSHA)
-				}
+	for i := len(sourceSHAs) - 1; i >= 0; i-- {
+		commitSHA := sourceSHAs[i]
 
-				return nil
-			}
+		commitInfo, err := adapter.GetCommit(ctx, s.Directory(), commitSHA, "")
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get commit data in rebase merge: %w", err)
+		}
+
+		// rebase merge preserves the commit author (and date) and the commit message, but changes the committer.
+		author := &commitInfo.Author
+		message := commitInfo.Title
+		if commitInfo.Message != "" {
+			message += "\n\n" + commitInfo.Message
+		}
 
-			lastCommitSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, lastCommitSHA)
+		treeSHA, commitConflicts, err := s.MergeTree(ctx, mergeBaseSHA, lastCommitSHA, commitSHA)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to merge tree in rebase merge: %w", err)
+		}
+
+		if len(commitConflicts) > 0 {
+			_, _, conflicts, err := FindConflicts(ctx, s.Directory(), targetSHA, sourceSHA)
 			if err != nil {
-				return fmt.Errorf("failed to commit tree in rebase merge: %w", err)
+				return "", nil, fmt.Errorf("failed to find conflicts in rebase merge: %w", err)
 			}
-		}
 
-		mergeSHA = lastCommitSHA
+			if len(conflicts) == 0 {
+				return "", nil, fmt.Errorf("expected to find conflicts after rebase merge between %s and %s, but couldn't",
+					mergeBaseSHA, sourceSHA)
+			}
 
-		return nil
-	})
-	if err != nil {
-		return "", nil, fmt.Errorf("merge method=rebase: %w", err)
+			return "", conflicts, nil
+		}
+
+		lastCommitSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, lastCommitSHA)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to commit tree in rebase merge: %w", err)
+		}
 	}
 
-	return mergeSHA, conflicts, nil
+	return lastCommitSHA, nil, nil
 }
 
 // runInSharedRepo is helper function used to run the provided function inside a shared repository.
 func runInSharedRepo(
 	ctx context.Context,
 	tmpDir, repoPath string,
+	signer types.Signer,
 	fn func(s *sharedrepo.SharedRepo) error,
 ) error {
-	s, err := sharedrepo.NewSharedRepo(tmpDir, repoPath)
+	s, err := sharedrepo.NewSharedRepo(tmpDir, repoPath, signer)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/sharedrepo"
+	"github.com/harness/gitness/git/types"
+)
+
+// PickOutput is the result of the cherry-pick and the revert operations.
+type PickOutput struct {
+	// CommitSHA is the SHA of the last commit created. It's empty if a conflict was found.
+	CommitSHA string
+	// CommitCount is the number of commits that are applied.
+	CommitCount int
+	// ConflictCommitSHA is the SHA of the commit that couldn't be applied because of the conflicts.
+	ConflictCommitSHA string
+	ConflictFiles     []string
+}
+
+// CherryPick applies the changes introduced by the commits on top of the targetSHA.
+// If the startSHA is empty only the commit endSHA is applied, otherwise all commits in the startSHA..endSHA range,
+// following only the first parent of merge commits. Changes of a merge commit are taken relative
+// to the parent with the number mainline (starting from 1).
+// Each commit is applied separately, the author and the message of the original commit are preserved.
+func CherryPick(
+	ctx context.Context,
+	repoPath, tmpDir string,
+	committer *types.Signature,
+	targetSHA, startSHA, endSHA string,
+	mainline int,
+	signer types.Signer,
+) (out PickOutput, err error) {
+	err = runInSharedRepo(ctx, tmpDir, repoPath, signer, func(s *sharedrepo.SharedRepo) error {
+		commitSHAs, err := pickCommitList(ctx, s, startSHA, endSHA)
+		if err != nil {
+			return err
+		}
+
+		lastCommitSHA := targetSHA
+
+		// the commit list is in the reverse chronological order, the oldest commit is applied first.
+		for i := len(commitSHAs) - 1; i >= 0; i-- {
+			commitSHA := commitSHAs[i]
+
+			parentSHA, err := pickParent(ctx, s, commitSHA, mainline)
+			if err != nil {
+				return err
+			}
+
+			commitInfo, err := adapter.GetCommit(ctx, s.Directory(), commitSHA, "")
+			if err != nil {
+				return fmt.Errorf("failed to get commit data in cherry-pick: %w", err)
+			}
+
+			treeSHA, conflicts, err := s.MergeTree(ctx, parentSHA, lastCommitSHA, commitSHA)
+			if err != nil {
+				return fmt.Errorf("failed to merge tree in cherry-pick: %w", err)
+			}
+
+			if len(conflicts) > 0 {
+				out = PickOutput{
+					ConflictCommitSHA: commitSHA,
+					ConflictFiles:     conflicts,
+				}
+				return nil
+			}
+
+			// cherry-pick preserves the commit author (and date) and the commit message, but changes the committer.
+			message := commitInfo.Title
+			if commitInfo.Message != "" {
+				message += "\n\n" + commitInfo.Message
+			}
+			message += "\n\n(cherry picked from commit " + commitSHA + ")"
+
+			lastCommitSHA, err = s.CommitTree(ctx, &commitInfo.Author, committer, treeSHA, message, false, lastCommitSHA)
+			if err != nil {
+				return fmt.Errorf("failed to commit tree in cherry-pick: %w", err)
+			}
+		}
+
+		out = PickOutput{
+			CommitSHA:   lastCommitSHA,
+			CommitCount: len(commitSHAs),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return PickOutput{}, fmt.Errorf("cherry-pick: %w", err)
+	}
+
+	return out, nil
+}
+
+// Revert creates commits on top of the targetSHA that revert the changes introduced by the commits.
+// If the startSHA is empty only the commit endSHA is reverted, otherwise all commits in the startSHA..endSHA range,
+// following only the first parent of merge commits. Changes of a merge commit are taken relative
+// to the parent with the number mainline (starting from 1).
+// Commits are reverted one by one, starting from the most recent one.
+func Revert(
+	ctx context.Context,
+	repoPath, tmpDir string,
+	committer *types.Signature,
+	targetSHA, startSHA, endSHA string,
+	mainline int,
+	signer types.Signer,
+) (out PickOutput, err error) {
+	err = runInSharedRepo(ctx, tmpDir, repoPath, signer, func(s *sharedrepo.SharedRepo) error {
+		commitSHAs, err := pickCommitList(ctx, s, startSHA, endSHA)
+		if err != nil {
+			return err
+		}
+
+		lastCommitSHA := targetSHA
+
+		for _, commitSHA := range commitSHAs {
+			parentSHA, err := pickParent(ctx, s, commitSHA, mainline)
+			if err != nil {
+				return err
+			}
+
+			commitInfo, err := adapter.GetCommit(ctx, s.Directory(), commitSHA, "")
+			if err != nil {
+				return fmt.Errorf("failed to get commit data in revert: %w", err)
+			}
+
+			// reverting is merging of the parent commit, with the commit itself as the merge base.
+			treeSHA, conflicts, err := s.MergeTree(ctx, commitSHA, lastCommitSHA, parentSHA)
+			if err != nil {
+				return fmt.Errorf("failed to merge tree in revert: %w", err)
+			}
+
+			if len(conflicts) > 0 {
+				out = PickOutput{
+					ConflictCommitSHA: commitSHA,
+					ConflictFiles:     conflicts,
+				}
+				return nil
+			}
+
+			message := fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s.", commitInfo.Title, commitSHA)
+
+			lastCommitSHA, err = s.CommitTree(ctx, committer, committer, treeSHA, message, false, lastCommitSHA)
+			if err != nil {
+				return fmt.Errorf("failed to commit tree in revert: %w", err)
+			}
+		}
+
+		out = PickOutput{
+			CommitSHA:   lastCommitSHA,
+			CommitCount: len(commitSHAs),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return PickOutput{}, fmt.Errorf("revert: %w", err)
+	}
+
+	return out, nil
+}
+
+// pickCommitList returns the commits to cherry-pick or revert, in the reverse chronological order.
+func pickCommitList(ctx context.Context, s *sharedrepo.SharedRepo, startSHA, endSHA string) ([]string, error) {
+	if startSHA == "" {
+		return []string{endSHA}, nil
+	}
+
+	commitSHAs, err := s.FirstParentCommitSHAList(ctx, startSHA, endSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find commit list: %w", err)
+	}
+
+	if len(commitSHAs) == 0 {
+		return nil, errors.InvalidArgument("There are no commits between %s and %s.", startSHA, endSHA)
+	}
+
+	return commitSHAs, nil
+}
+
+// pickParent returns the parent of the commit the changes of the commit are taken relative to.
+func pickParent(ctx context.Context, s *sharedrepo.SharedRepo, commitSHA string, mainline int) (string, error) {
+	parents, err := s.CommitParents(ctx, commitSHA)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case len(parents) == 0:
+		return "", errors.InvalidArgument("Commit %s has no parent.", commitSHA)
+	case len(parents) == 1:
+		return parents[0], nil
+	case mainline < 1 || mainline > len(parents):
+		return "", errors.InvalidArgument("Commit %s is a merge commit, but the mainline parent %d doesn't exist.",
+			commitSHA, mainline)
+	default:
+		return parents[mainline-1], nil
+	}
+}
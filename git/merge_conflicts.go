@@ -0,0 +1,288 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/merge"
+	"github.com/harness/gitness/git/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MergeConflictsParams is input structure object for finding the merge conflicts of two revisions.
+type MergeConflictsParams struct {
+	ReadParams
+
+	// Ours is the revision the changes of the Theirs revision are merged into.
+	Ours string
+	// Theirs is the revision whose changes are merged into the Ours revision.
+	Theirs string
+}
+
+func (p *MergeConflictsParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Ours == "" || p.Theirs == "" {
+		return errors.InvalidArgument("both revisions are mandatory")
+	}
+
+	return nil
+}
+
+// MergeConflictsOutput is result object of finding the merge conflicts of two revisions.
+type MergeConflictsOutput struct {
+	OursSHA      string
+	TheirsSHA    string
+	MergeBaseSHA string
+
+	Files []types.MergeConflictFile
+}
+
+// MergeConflicts returns the files that can't be merged automatically with their base, ours and theirs versions.
+func (s *Service) MergeConflicts(ctx context.Context, params *MergeConflictsParams) (MergeConflictsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return MergeConflictsOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	oursSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, params.Ours)
+	if err != nil {
+		return MergeConflictsOutput{}, fmt.Errorf("failed to get commit SHA of %q: %w", params.Ours, err)
+	}
+
+	theirsSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, params.Theirs)
+	if err != nil {
+		return MergeConflictsOutput{}, fmt.Errorf("failed to get commit SHA of %q: %w", params.Theirs, err)
+	}
+
+	mergeBaseSHA, _, err := s.adapter.GetMergeBase(ctx, repoPath, "", oursSHA, theirsSHA)
+	if err != nil {
+		return MergeConflictsOutput{}, fmt.Errorf("failed to get merge base: %w", err)
+	}
+
+	_, _, conflicts, err := merge.FindConflicts(ctx, repoPath, oursSHA, theirsSHA)
+	if err != nil {
+		return MergeConflictsOutput{}, fmt.Errorf("failed to find conflicts: %w", err)
+	}
+
+	// a path is listed once for every conflicting stage
+	paths := make([]string, 0, len(conflicts))
+	for _, path := range conflicts {
+		if path != "" && (len(paths) == 0 || paths[len(paths)-1] != path) {
+			paths = append(paths, path)
+		}
+	}
+
+	files, err := merge.ConflictFiles(ctx, repoPath, s.tmpDir, mergeBaseSHA, oursSHA, theirsSHA, paths)
+	if err != nil {
+		return MergeConflictsOutput{}, errors.Internal(err, "failed to get conflicting files")
+	}
+
+	return MergeConflictsOutput{
+		OursSHA:      oursSHA,
+		TheirsSHA:    theirsSHA,
+		MergeBaseSHA: mergeBaseSHA,
+		Files:        files,
+	}, nil
+}
+
+// ResolveConflictsParams is input structure object for merging a base branch into a branch
+// with the provided resolutions of the conflicts.
+type ResolveConflictsParams struct {
+	WriteParams
+
+	// Branch is the branch the merge commit is created on.
+	Branch string
+	// BranchExpectedSHA is optional, if provided the operation fails if the branch is on a different commit.
+	BranchExpectedSHA string
+
+	// BaseRepoUID is optional, it's the repository of the BaseBranch if it's not the same repository.
+	BaseRepoUID string
+	// BaseBranch is the branch that is merged into the Branch.
+	BaseBranch string
+
+	// Resolutions contains the resolved content of every conflicting file.
+	Resolutions []types.MergeConflictResolution
+
+	Title   string
+	Message string
+
+	// Committer overwrites the git committer used for creating the commit
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for creating the commit
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for creating the commit
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for creating the commit
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+}
+
+func (p *ResolveConflictsParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Branch == "" {
+		return errors.InvalidArgument("branch is mandatory")
+	}
+
+	if p.BaseBranch == "" {
+		return errors.InvalidArgument("base branch is mandatory")
+	}
+
+	if len(p.Resolutions) == 0 {
+		return errors.InvalidArgument("resolutions are mandatory")
+	}
+
+	for _, resolution := range p.Resolutions {
+		if resolution.Path == "" {
+			return errors.InvalidArgument("path of the resolved file is mandatory")
+		}
+	}
+
+	return nil
+}
+
+// ResolveConflictsOutput is result object of the resolve conflicts operation.
+type ResolveConflictsOutput struct {
+	BranchSHA    string
+	BaseSHA      string
+	MergeBaseSHA string
+	// CommitSHA is the sha of the created merge commit.
+	CommitSHA string
+}
+
+// ResolveConflicts merges the base branch into the branch using the provided resolutions of the conflicting files.
+// The resolutions are validated against the conflicts found while merging.
+func (s *Service) ResolveConflicts(
+	ctx context.Context,
+	params *ResolveConflictsParams,
+) (ResolveConflictsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ResolveConflictsOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	baseRepoPath := repoPath
+	if params.BaseRepoUID != "" && params.BaseRepoUID != params.RepoUID {
+		baseRepoPath = getFullPathForRepo(s.reposRoot, params.BaseRepoUID)
+	}
+
+	log := log.Ctx(ctx).With().
+		Str("repo_uid", params.RepoUID).
+		Str("branch", params.Branch).
+		Str("base", params.BaseBranch).
+		Logger()
+
+	// find the commit SHAs
+
+	refPath := adapter.GetReferenceFromBranchName(params.Branch)
+
+	branchSHA, baseSHA, mergeBaseSHA, err := s.findBranchUpdateSHAs(ctx,
+		repoPath, params.Branch, params.BranchExpectedSHA,
+		baseRepoPath, params.BaseBranch)
+	if err != nil {
+		return ResolveConflictsOutput{}, err
+	}
+
+	// author and committer
+
+	now := time.Now().UTC()
+
+	committer := types.Signature{Identity: types.Identity(params.Actor), When: now}
+
+	if params.Committer != nil {
+		committer.Identity = types.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	author := committer
+
+	if params.Author != nil {
+		author.Identity = types.Identity(*params.Author)
+	}
+	if params.AuthorDate != nil {
+		author.When = *params.AuthorDate
+	}
+
+	// merge message
+
+	message := strings.TrimSpace(params.Title)
+	if message == "" {
+		message = fmt.Sprintf("Merge branch '%s' into %s", params.BaseBranch, params.Branch)
+	}
+	if len(params.Message) > 0 {
+		message += "\n\n" + strings.TrimSpace(params.Message)
+	}
+
+	// merge
+
+	commitSHA, err := merge.ResolveConflicts(ctx,
+		repoPath, s.tmpDir,
+		&author, &committer,
+		message,
+		mergeBaseSHA, branchSHA, baseSHA,
+		params.Resolutions,
+		params.Signer)
+	if errors.IsInvalidArgument(err) {
+		return ResolveConflictsOutput{}, err
+	}
+	if err != nil {
+		return ResolveConflictsOutput{}, errors.Internal(err, "failed to resolve conflicts merging %q into %q in %q",
+			params.BaseBranch, params.Branch, params.RepoUID)
+	}
+
+	// git reference update
+
+	log.Trace().Msg("resolve conflicts completed - updating git reference")
+
+	err = s.adapter.UpdateRef(
+		ctx,
+		params.EnvVars,
+		repoPath,
+		refPath,
+		branchSHA,
+		commitSHA,
+	)
+	if err != nil {
+		return ResolveConflictsOutput{}, errors.Internal(err, "failed to update branch %q", params.Branch)
+	}
+
+	log.Trace().Msg("resolve conflicts completed - git reference updated")
+
+	return ResolveConflictsOutput{
+		BranchSHA:    branchSHA,
+		BaseSHA:      baseSHA,
+		MergeBaseSHA: mergeBaseSHA,
+		CommitSHA:    commitSHA,
+	}, nil
+}
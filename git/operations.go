@@ -24,6 +24,7 @@ import (
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/sharedrepo"
 	"github.com/harness/gitness/git/types"
 
 	"code.gitea.io/gitea/modules/git"
@@ -226,6 +227,13 @@ func (s *Service) CommitFiles(ctx context.Context, params *CommitFilesParams) (C
 			return "", fmt.Errorf("failed to commit the tree: %w", err)
 		}
 
+		if params.Signer != nil {
+			commitSHA, err = sharedrepo.SignCommit(ctx, shared.Path(), params.Signer, commitSHA)
+			if err != nil {
+				return "", fmt.Errorf("failed to sign the commit: %w", err)
+			}
+		}
+
 		err = shared.MoveObjects(ctx)
 		if err != nil {
 			return "", fmt.Errorf("failed to move git objects: %w", err)
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/git/types"
+)
+
+const (
+	addedLinesCommitPrefix  = "commit "
+	addedLinesNewFilePrefix = "+++ "
+	addedLinesDevNull       = "/dev/null"
+)
+
+// AddedLines parses the output of git log with patches, where every commit starts with
+// the line "commit <sha>", and calls fn for every file the commits added lines to.
+// Lines of deleted files and binary files are ignored.
+//
+//nolint:gocognit // it's a simple state machine
+func AddedLines(r io.Reader, fn func(types.AddedLines) error) error {
+	reader := bufio.NewReader(r)
+
+	var (
+		commitSHA string
+		current   *types.AddedLines
+		inHunk    bool
+		lineNum   int
+	)
+
+	flush := func() error {
+		file := current
+		current = nil
+
+		if file == nil || file.Path == "" || len(file.Lines) == 0 {
+			return nil
+		}
+
+		return fn(*file)
+	}
+
+	for {
+		line, errRead := reader.ReadString('\n')
+		if errRead != nil && !errors.Is(errRead, io.EOF) {
+			return fmt.Errorf("failed to read git log output: %w", errRead)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+
+		// lines of a hunk always start with '+', '-', ' ' or '\', so these prefixes are unambiguous.
+		switch {
+		case strings.HasPrefix(line, addedLinesCommitPrefix):
+			if err := flush(); err != nil {
+				return err
+			}
+			commitSHA = line[len(addedLinesCommitPrefix):]
+			inHunk = false
+
+		case strings.HasPrefix(line, "diff --git "):
+			if err := flush(); err != nil {
+				return err
+			}
+			current = &types.AddedLines{CommitSHA: commitSHA}
+			inHunk = false
+
+		case current == nil:
+			// the commit header or the empty line between the commit header and the patch.
+
+		case strings.HasPrefix(line, "@@ "):
+			h, ok := ParseDiffHunkHeader(line)
+			if !ok {
+				return fmt.Errorf("failed to parse hunk header %q", line)
+			}
+			inHunk = true
+			lineNum = h.NewLine
+
+		case !inHunk && strings.HasPrefix(line, addedLinesNewFilePrefix):
+			current.Path = parseNewFilePath(line[len(addedLinesNewFilePrefix):])
+
+		case inHunk && strings.HasPrefix(line, "+"):
+			current.Lines = append(current.Lines, types.AddedLine{
+				Number:  lineNum,
+				Content: strings.TrimSuffix(line[1:], "\r"),
+			})
+			lineNum++
+
+		case inHunk && strings.HasPrefix(line, " "):
+			lineNum++
+		}
+
+		if errRead != nil {
+			break
+		}
+	}
+
+	return flush()
+}
+
+// parseNewFilePath returns the file path of the "+++" line of a patch.
+// It returns an empty string if the file is deleted.
+func parseNewFilePath(s string) string {
+	if s == addedLinesDevNull {
+		return ""
+	}
+
+	// git quotes paths that contain special characters.
+	if strings.HasPrefix(s, `"`) {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			s = unquoted
+		}
+	}
+
+	return strings.TrimPrefix(s, "b/")
+}
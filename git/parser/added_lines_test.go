@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAddedLines(t *testing.T) {
+	input := `commit 1111111111111111111111111111111111111111
+
+diff --git a/config.env b/config.env
+new file mode 100644
+index 0000000..fb0c863
+--- /dev/null
++++ b/config.env
+@@ -0,0 +1,2 @@
++USER=admin
+++++ not a header
+diff --git a/image.png b/image.png
+new file mode 100644
+index 0000000..aaaaaaa
+Binary files /dev/null and b/image.png differ
+commit 2222222222222222222222222222222222222222
+
+diff --git a/config.env b/config.env
+index fb0c863..e9449b5 100644
+--- a/config.env
++++ b/config.env
+@@ -1 +1 @@ some context
+-USER=admin
++USER=root
+@@ -5,0 +6,2 @@
++A=1
++B=2
+\ No newline at end of file
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index e9449b5..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+diff --git "a/dir/tab\there.txt" "b/dir/tab\there.txt"
+new file mode 100644
+index 0000000..fb0c863
+--- /dev/null
++++ "b/dir/tab\there.txt"
+@@ -0,0 +1 @@
++x
+`
+
+	var got []types.AddedLines
+	err := AddedLines(strings.NewReader(input), func(lines types.AddedLines) error {
+		got = append(got, lines)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+
+	want := []types.AddedLines{
+		{
+			CommitSHA: "1111111111111111111111111111111111111111",
+			Path:      "config.env",
+			Lines: []types.AddedLine{
+				{Number: 1, Content: "USER=admin"},
+				{Number: 2, Content: "+++ not a header"},
+			},
+		},
+		{
+			CommitSHA: "2222222222222222222222222222222222222222",
+			Path:      "config.env",
+			Lines: []types.AddedLine{
+				{Number: 1, Content: "USER=root"},
+				{Number: 6, Content: "A=1"},
+				{Number: 7, Content: "B=2"},
+			},
+		},
+		{
+			CommitSHA: "2222222222222222222222222222222222222222",
+			Path:      "dir/tab\there.txt",
+			Lines: []types.AddedLine{
+				{Number: 1, Content: "x"},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/merge"
+	"github.com/harness/gitness/git/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PickParams is input structure object for the cherry-pick and the revert operations.
+type PickParams struct {
+	WriteParams
+
+	// Revision is a commit or a commit range in the "start..end" form.
+	// Commits of a range are found following only the first parent of merge commits.
+	Revision string
+
+	// Mainline is the parent number (starting from 1) of the merge commits
+	// the changes of the merge commits are taken relative to.
+	// (optional, default: 1)
+	Mainline int
+
+	// Branch is the branch the new commits are created on.
+	Branch string
+	// BranchExpectedSHA is optional, if provided the operation fails if the branch is on a different commit.
+	BranchExpectedSHA string
+	// NewBranch is optional, if provided the new commits are created on a new branch, created from the Branch.
+	NewBranch string
+	// SkipRefUpdate is optional, if true the commits are created, but the branch isn't updated.
+	// It allows the caller to inspect the new commits before updating the branch with UpdateRef.
+	SkipRefUpdate bool
+
+	// Committer overwrites the git committer used for creating the commits
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for creating the commits
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+}
+
+func (p *PickParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Revision == "" {
+		return errors.InvalidArgument("revision is mandatory")
+	}
+
+	if p.Branch == "" {
+		return errors.InvalidArgument("branch is mandatory")
+	}
+
+	if p.Mainline < 0 {
+		return errors.InvalidArgument("mainline parent number can't be negative")
+	}
+
+	return nil
+}
+
+// PickOutput is result object of the cherry-pick and the revert operations.
+type PickOutput struct {
+	// BranchSHA is the sha of the commit on the branch the new commits are created on top of.
+	BranchSHA string
+	// CommitSHA is the sha of the last created commit, it's empty if there are conflicts.
+	CommitSHA   string
+	CommitCount int
+
+	// ConflictCommitSHA is the sha of the commit that couldn't be applied because of the conflicts.
+	ConflictCommitSHA string
+	ConflictFiles     []string
+}
+
+// CherryPick applies the changes introduced by the commit (or the commit range) on the branch.
+// The author and the message of every commit are preserved.
+func (s *Service) CherryPick(ctx context.Context, params *PickParams) (PickOutput, error) {
+	return s.pick(ctx, params, "cherry-pick", merge.CherryPick)
+}
+
+// Revert creates commits on the branch reverting the changes introduced by the commit (or the commit range).
+func (s *Service) Revert(ctx context.Context, params *PickParams) (PickOutput, error) {
+	return s.pick(ctx, params, "revert", merge.Revert)
+}
+
+type pickFunc func(
+	ctx context.Context,
+	repoPath, tmpDir string,
+	committer *types.Signature,
+	targetSHA, startSHA, endSHA string,
+	mainline int,
+	signer types.Signer,
+) (merge.PickOutput, error)
+
+func (s *Service) pick(
+	ctx context.Context,
+	params *PickParams,
+	operation string,
+	fn pickFunc,
+) (PickOutput, error) {
+	if err := params.Validate(); err != nil {
+		return PickOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	log := log.Ctx(ctx).With().
+		Str("repo_uid", params.RepoUID).
+		Str("revision", params.Revision).
+		Str("branch", params.Branch).
+		Str("new_branch", params.NewBranch).
+		Str("operation", operation).
+		Logger()
+
+	// find the commit SHAs
+
+	branchSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, adapter.GetReferenceFromBranchName(params.Branch))
+	if err != nil {
+		return PickOutput{}, fmt.Errorf("failed to get branch commit SHA: %w", err)
+	}
+
+	if params.BranchExpectedSHA != "" && params.BranchExpectedSHA != branchSHA {
+		return PickOutput{}, errors.PreconditionFailed(
+			"branch '%s' is on SHA '%s' which doesn't match expected SHA '%s'.",
+			params.Branch, branchSHA, params.BranchExpectedSHA)
+	}
+
+	var startSHA, endSHA string
+	if start, end, isRange := strings.Cut(params.Revision, ".."); isRange {
+		startSHA, err = s.adapter.GetFullCommitID(ctx, repoPath, start)
+		if err != nil {
+			return PickOutput{}, fmt.Errorf("failed to get commit SHA of %q: %w", start, err)
+		}
+
+		endSHA, err = s.adapter.GetFullCommitID(ctx, repoPath, end)
+		if err != nil {
+			return PickOutput{}, fmt.Errorf("failed to get commit SHA of %q: %w", end, err)
+		}
+	} else {
+		endSHA, err = s.adapter.GetFullCommitID(ctx, repoPath, params.Revision)
+		if err != nil {
+			return PickOutput{}, fmt.Errorf("failed to get commit SHA of %q: %w", params.Revision, err)
+		}
+	}
+
+	// set up the target reference
+
+	refPath := adapter.GetReferenceFromBranchName(params.Branch)
+	refOldValue := branchSHA
+
+	if params.NewBranch != "" {
+		refPath = adapter.GetReferenceFromBranchName(params.NewBranch)
+		refOldValue = types.NilSHA
+
+		_, err = s.adapter.GetFullCommitID(ctx, repoPath, refPath)
+		if err == nil {
+			return PickOutput{}, errors.Conflict("branch %s already exists", params.NewBranch)
+		}
+		if !errors.IsNotFound(err) {
+			return PickOutput{}, fmt.Errorf("failed to check if the new branch exists: %w", err)
+		}
+	}
+
+	// committer
+
+	committer := types.Signature{Identity: types.Identity(params.Actor), When: time.Now().UTC()}
+
+	if params.Committer != nil {
+		committer.Identity = types.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	mainline := params.Mainline
+	if mainline == 0 {
+		mainline = 1
+	}
+
+	// create the commits
+
+	out, err := fn(ctx,
+		repoPath, s.tmpDir,
+		&committer,
+		branchSHA, startSHA, endSHA,
+		mainline,
+		params.Signer)
+	if errors.IsInvalidArgument(err) {
+		return PickOutput{}, err
+	}
+	if err != nil {
+		return PickOutput{}, errors.Internal(err, "failed to %s %q on %q in %q",
+			operation, params.Revision, params.Branch, params.RepoUID)
+	}
+
+	if len(out.ConflictFiles) > 0 {
+		return PickOutput{
+			BranchSHA:         branchSHA,
+			ConflictCommitSHA: out.ConflictCommitSHA,
+			ConflictFiles:     out.ConflictFiles,
+		}, nil
+	}
+
+	if params.SkipRefUpdate {
+		return PickOutput{
+			BranchSHA:   branchSHA,
+			CommitSHA:   out.CommitSHA,
+			CommitCount: out.CommitCount,
+		}, nil
+	}
+
+	// git reference update
+
+	log.Trace().Msgf("%s completed - updating git reference", operation)
+
+	err = s.adapter.UpdateRef(
+		ctx,
+		params.EnvVars,
+		repoPath,
+		refPath,
+		refOldValue,
+		out.CommitSHA,
+	)
+	if err != nil {
+		return PickOutput{}, errors.Internal(err, "failed to update branch after %s", operation)
+	}
+
+	log.Trace().Msgf("%s completed - git reference updated", operation)
+
+	return PickOutput{
+		BranchSHA:   branchSHA,
+		CommitSHA:   out.CommitSHA,
+		CommitCount: out.CommitCount,
+	}, nil
+}
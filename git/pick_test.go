@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/types"
+)
+
+// requireMergeTreeMergeBase skips the test if the installed git doesn't support merge-tree with --merge-base,
+// it was added in git 2.40.
+func requireMergeTreeMergeBase(t *testing.T) {
+	t.Helper()
+
+	out, err := exec.Command("git", "version").Output()
+	if err != nil {
+		t.Fatalf("failed to get git version: %v", err)
+	}
+
+	// the output is in the "git version 2.40.1" format.
+	fields := strings.Fields(string(out))
+	if len(fields) < 3 {
+		t.Fatalf("unexpected git version output: %q", out)
+	}
+
+	parts := strings.SplitN(fields[2], ".", 3)
+	major, _ := strconv.Atoi(parts[0])
+	minor := 0
+	if len(parts) > 1 {
+		minor, _ = strconv.Atoi(parts[1])
+	}
+
+	if major < 2 || major == 2 && minor < 40 {
+		t.Skipf("git %s doesn't support merge-tree with --merge-base", fields[2])
+	}
+}
+
+func TestService_Revert(t *testing.T) {
+	requireMergeTreeMergeBase(t)
+
+	tests := []struct {
+		name          string
+		newBranch     string
+		skipRefUpdate bool
+	}{
+		{
+			name: "revert on the branch",
+		},
+		{
+			name:      "revert on a new branch",
+			newBranch: "revert",
+		},
+		{
+			name:          "skip ref update",
+			newBranch:     "revert",
+			skipRefUpdate: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := setupService(t)
+
+			firstSHA := setupRepoWithCommit(t, s, "repo", "first")
+			secondSHA := writeCommit(t, s, "repo", `"second"`, firstSHA)
+			repoPath := getFullPathForRepo(s.reposRoot, "repo")
+
+			out, err := s.Revert(ctx, &PickParams{
+				WriteParams:   WriteParams{RepoUID: "repo", Actor: testIdentity},
+				Revision:      secondSHA,
+				Branch:        "main",
+				NewBranch:     test.newBranch,
+				SkipRefUpdate: test.skipRefUpdate,
+			})
+			if err != nil {
+				t.Fatalf("failed to revert: %v", err)
+			}
+
+			if out.BranchSHA != secondSHA {
+				t.Errorf("branch SHA: got=%s want=%s", out.BranchSHA, secondSHA)
+			}
+			if out.CommitCount != 1 {
+				t.Errorf("commit count: got=%d want=1", out.CommitCount)
+			}
+
+			commit, err := s.adapter.GetCommit(ctx, repoPath, out.CommitSHA)
+			if err != nil {
+				t.Fatalf("failed to get the revert commit: %v", err)
+			}
+
+			if want := `Revert "commit "second""`; commit.Title != want {
+				t.Errorf("title: got=%s want=%s", commit.Title, want)
+			}
+
+			branchName := "main"
+			if test.newBranch != "" {
+				branchName = test.newBranch
+			}
+
+			branchSHA, err := s.adapter.GetFullCommitID(ctx, repoPath, adapter.GetReferenceFromBranchName(branchName))
+			if test.skipRefUpdate {
+				if !types.IsNotFoundError(err) {
+					t.Errorf("expected the branch not to be created, got=%s err=%v", branchSHA, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get branch SHA: %v", err)
+			}
+
+			if branchSHA != out.CommitSHA {
+				t.Errorf("branch: got=%s want=%s", branchSHA, out.CommitSHA)
+			}
+		})
+	}
+}
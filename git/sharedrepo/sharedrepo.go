@@ -41,12 +41,15 @@ import (
 type SharedRepo struct {
 	temporaryPath  string
 	repositoryPath string
+	signer         types.Signer
 }
 
 // NewSharedRepo creates a new temporary bare repository.
+// If the signer is provided, it is used to sign all commits created in the repository.
 func NewSharedRepo(
 	baseTmpDir string,
 	repositoryPath string,
+	signer types.Signer,
 ) (*SharedRepo, error) {
 	var buf [5]byte
 	_, _ = rand.Read(buf[:])
@@ -60,6 +63,7 @@ func NewSharedRepo(
 	t := &SharedRepo{
 		temporaryPath:  temporaryPath,
 		repositoryPath: repositoryPath,
+		signer:         signer,
 	}
 
 	return t, nil
@@ -329,7 +333,7 @@ func (r *SharedRepo) CommitTree(
 		cmd.Add(command.WithFlag("-p", parentCommit))
 	}
 
-	// temporary no signing
+	// the commit is signed below by the signer of the shared repo, never by git.
 	cmd.Add(command.WithFlag("--no-gpg-sign"))
 
 	messageBytes := new(bytes.Buffer)
@@ -353,7 +357,13 @@ func (r *SharedRepo) CommitTree(
 		return "", fmt.Errorf("failed to commit-tree in shared repo: %w", err)
 	}
 
-	return strings.TrimSpace(stdout.String()), nil
+	commitSHA := strings.TrimSpace(stdout.String())
+
+	if r.signer == nil {
+		return commitSHA, nil
+	}
+
+	return SignCommit(ctx, r.temporaryPath, r.signer, commitSHA)
 }
 
 // CommitSHAList returns list of SHAs of the commits between the two git revisions.
@@ -383,6 +393,66 @@ func (r *SharedRepo) CommitSHAList(
 	return commitSHAs, nil
 }
 
+// FirstParentCommitSHAList returns list of SHAs of the commits between the two git revisions,
+// following only the first parent of merge commits.
+func (r *SharedRepo) FirstParentCommitSHAList(
+	ctx context.Context,
+	start, end string,
+) ([]string, error) {
+	cmd := command.New("rev-list",
+		command.WithFlag("--first-parent"),
+		command.WithArg(start+".."+end))
+
+	stdout := bytes.NewBuffer(nil)
+
+	if err := cmd.Run(ctx, command.WithDir(r.temporaryPath), command.WithStdout(stdout)); err != nil {
+		return nil, fmt.Errorf("failed to rev-list in shared repo: %w", err)
+	}
+
+	return strings.Fields(stdout.String()), nil
+}
+
+// CommitParents returns list of SHAs of the parents of the commit.
+func (r *SharedRepo) CommitParents(
+	ctx context.Context,
+	commitSHA string,
+) ([]string, error) {
+	cmd := command.New("rev-list",
+		command.WithFlag("--parents"),
+		command.WithFlag("--max-count", "1"),
+		command.WithArg(commitSHA))
+
+	stdout := bytes.NewBuffer(nil)
+
+	if err := cmd.Run(ctx, command.WithDir(r.temporaryPath), command.WithStdout(stdout)); err != nil {
+		return nil, fmt.Errorf("failed to rev-list parents in shared repo: %w", err)
+	}
+
+	// the output is the commit SHA followed by the SHAs of its parents.
+	shas := strings.Fields(stdout.String())
+	if len(shas) == 0 {
+		return nil, fmt.Errorf("failed to find commit %s in shared repo", commitSHA)
+	}
+
+	return shas[1:], nil
+}
+
+// TreeSHA returns SHA of the tree of the commit.
+func (r *SharedRepo) TreeSHA(
+	ctx context.Context,
+	commitSHA string,
+) (string, error) {
+	cmd := command.New("rev-parse", command.WithArg(commitSHA+"^{tree}"))
+
+	stdout := bytes.NewBuffer(nil)
+
+	if err := cmd.Run(ctx, command.WithDir(r.temporaryPath), command.WithStdout(stdout)); err != nil {
+		return "", fmt.Errorf("failed to rev-parse tree in shared repo: %w", err)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
 // MergeBase returns number of commits between the two git revisions.
 func (r *SharedRepo) MergeBase(
 	ctx context.Context,
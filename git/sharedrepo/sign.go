@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharedrepo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/types"
+)
+
+const signatureHeader = "gpgsig"
+
+// SignCommit signs the commit with the provided signer and writes the signed commit object
+// to the repository. The unsigned commit object is left in the repository. It returns SHA of the signed commit.
+func SignCommit(
+	ctx context.Context,
+	repoPath string,
+	signer types.Signer,
+	commitSHA string,
+) (string, error) {
+	content, err := readObject(ctx, repoPath, "commit", commitSHA)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := signer.Sign(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign commit %s: %w", commitSHA, err)
+	}
+
+	// the signature header is added as the last header, the same as git does it.
+	headersEnd := bytes.Index(content, []byte("\n\n"))
+	if headersEnd < 0 {
+		return "", fmt.Errorf("failed to find end of headers of commit %s", commitSHA)
+	}
+
+	signed := bytes.NewBuffer(make([]byte, 0, len(content)+len(signature)+len(signatureHeader)))
+	signed.Write(content[:headersEnd+1])
+	signed.WriteString(signatureHeader)
+	for _, line := range strings.Split(strings.TrimRight(string(signature), "\n"), "\n") {
+		signed.WriteString(" ")
+		signed.WriteString(line)
+		signed.WriteString("\n")
+	}
+	signed.Write(content[headersEnd+1:])
+
+	return writeObject(ctx, repoPath, "commit", signed)
+}
+
+// SignTag signs the annotated tag with the provided signer and writes the signed tag object
+// to the repository. The unsigned tag object is left in the repository. It returns SHA of the signed tag.
+func SignTag(
+	ctx context.Context,
+	repoPath string,
+	signer types.Signer,
+	tagSHA string,
+) (string, error) {
+	content, err := readObject(ctx, repoPath, "tag", tagSHA)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := signer.Sign(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign tag %s: %w", tagSHA, err)
+	}
+
+	// the signature is appended to the tag message.
+	signed := bytes.NewBuffer(make([]byte, 0, len(content)+len(signature)))
+	signed.Write(content)
+	signed.Write(signature)
+	if !bytes.HasSuffix(signature, []byte("\n")) {
+		signed.WriteString("\n")
+	}
+
+	return writeObject(ctx, repoPath, "tag", signed)
+}
+
+func readObject(ctx context.Context, repoPath, objectType, sha string) ([]byte, error) {
+	cmd := command.New("cat-file",
+		command.WithArg(objectType),
+		command.WithArg(sha))
+
+	stdout := bytes.NewBuffer(nil)
+
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(stdout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s %s: %w", objectType, sha, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func writeObject(ctx context.Context, repoPath, objectType string, content *bytes.Buffer) (string, error) {
+	cmd := command.New("hash-object",
+		command.WithFlag("-t", objectType),
+		command.WithFlag("-w"),
+		command.WithFlag("--stdin"))
+
+	stdout := bytes.NewBuffer(nil)
+
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(content),
+		command.WithStdout(stdout))
+	if err != nil {
+		return "", fmt.Errorf("failed to write signed %s: %w", objectType, err)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
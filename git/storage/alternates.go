@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	objectsDirName = "objects"
+)
+
+// alternatesFilePath returns the path of the alternates file of a bare repository.
+func alternatesFilePath(repoPath string) string {
+	return filepath.Join(repoPath, objectsDirName, "info", "alternates")
+}
+
+// LinkObjects adds the object directory of the source repository to the alternates
+// of the repository. After the call all objects of the source repository are readable
+// from the repository without being copied.
+func LinkObjects(repoPath, sourceRepoPath string) error {
+	objectsPath := filepath.Join(sourceRepoPath, objectsDirName)
+
+	alternates, err := ReadAlternates(repoPath)
+	if err != nil {
+		return err
+	}
+
+	for _, alternate := range alternates {
+		if alternate == objectsPath {
+			return nil
+		}
+	}
+
+	filePath := alternatesFilePath(repoPath)
+
+	err = os.MkdirAll(filepath.Dir(filePath), 0o700)
+	if err != nil {
+		return fmt.Errorf("failed to create objects info directory: %w", err)
+	}
+
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open alternates file: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	if _, err = fmt.Fprintln(f, objectsPath); err != nil {
+		return fmt.Errorf("failed to write alternates file: %w", err)
+	}
+
+	return nil
+}
+
+// UnlinkObjects removes all alternates of the repository.
+// The caller must make sure that the repository contains all of its objects locally.
+func UnlinkObjects(repoPath string) error {
+	err := os.Remove(alternatesFilePath(repoPath))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove alternates file: %w", err)
+	}
+
+	return nil
+}
+
+// ReadAlternates returns the list of object directories the repository borrows objects from.
+func ReadAlternates(repoPath string) ([]string, error) {
+	f, err := os.Open(alternatesFilePath(repoPath))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open alternates file: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	var alternates []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		alternates = append(alternates, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read alternates file: %w", err)
+	}
+
+	return alternates, nil
+}
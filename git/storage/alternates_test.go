@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAlternates(t *testing.T) {
+	repoPath := t.TempDir()
+	sourcePath := t.TempDir()
+	otherPath := t.TempDir()
+
+	alternates, err := ReadAlternates(repoPath)
+	if err != nil {
+		t.Fatalf("failed to read missing alternates: %v", err)
+	}
+	if len(alternates) != 0 {
+		t.Fatalf("expected no alternates, got=%v", alternates)
+	}
+
+	if err = LinkObjects(repoPath, sourcePath); err != nil {
+		t.Fatalf("failed to link objects: %v", err)
+	}
+
+	// linking the same source twice must not duplicate the entry.
+	if err = LinkObjects(repoPath, sourcePath); err != nil {
+		t.Fatalf("failed to link objects again: %v", err)
+	}
+
+	if err = LinkObjects(repoPath, otherPath); err != nil {
+		t.Fatalf("failed to link objects of another repository: %v", err)
+	}
+
+	alternates, err = ReadAlternates(repoPath)
+	if err != nil {
+		t.Fatalf("failed to read alternates: %v", err)
+	}
+
+	want := []string{filepath.Join(sourcePath, "objects"), filepath.Join(otherPath, "objects")}
+	if !reflect.DeepEqual(alternates, want) {
+		t.Errorf("alternates: got=%v want=%v", alternates, want)
+	}
+
+	if err = UnlinkObjects(repoPath); err != nil {
+		t.Fatalf("failed to unlink objects: %v", err)
+	}
+
+	if _, err = os.Stat(alternatesFilePath(repoPath)); !os.IsNotExist(err) {
+		t.Errorf("expected the alternates file to be removed, got err=%v", err)
+	}
+
+	// unlinking a repository without alternates is a no-op.
+	if err = UnlinkObjects(repoPath); err != nil {
+		t.Errorf("failed to unlink objects of repository without alternates: %v", err)
+	}
+}
+
+func TestReadAlternates_SkipsCommentsAndEmptyLines(t *testing.T) {
+	repoPath := t.TempDir()
+
+	filePath := alternatesFilePath(repoPath)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	content := "# comment\n\n/a/objects\n  /b/objects  \n"
+	if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write alternates file: %v", err)
+	}
+
+	alternates, err := ReadAlternates(repoPath)
+	if err != nil {
+		t.Fatalf("failed to read alternates: %v", err)
+	}
+
+	want := []string{"/a/objects", "/b/objects"}
+	if !reflect.DeepEqual(alternates, want) {
+		t.Errorf("alternates: got=%v want=%v", alternates, want)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+)
+
+func TestService_SyncRepository_PrunesDeletedBranches(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+
+	remoteUID := "remote"
+	sha := setupRepoWithCommit(t, s, remoteUID, "remote content")
+	remotePath := getFullPathForRepo(s.reposRoot, remoteUID)
+
+	if out, err := exec.Command("git", "-C", remotePath, "branch", "feature", sha).CombinedOutput(); err != nil {
+		t.Fatalf("failed to create branch: %v: %s", err, out)
+	}
+
+	params := &SyncRepositoryParams{
+		WriteParams:       WriteParams{Actor: testIdentity, RepoUID: "mirror"},
+		Source:            remotePath,
+		CreateIfNotExists: true,
+		RefSpecs:          []string{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"},
+	}
+
+	if _, err := s.SyncRepository(ctx, params); err != nil {
+		t.Fatalf("failed to sync repository: %v", err)
+	}
+
+	mirrorPath := getFullPathForRepo(s.reposRoot, "mirror")
+	if _, err := s.adapter.GetRef(ctx, mirrorPath, "refs/heads/feature"); err != nil {
+		t.Fatalf("expected feature branch to be synced: %v", err)
+	}
+
+	if out, err := exec.Command("git", "-C", remotePath, "branch", "-D", "feature").CombinedOutput(); err != nil {
+		t.Fatalf("failed to delete branch: %v: %s", err, out)
+	}
+
+	params.CreateIfNotExists = false
+	if _, err := s.SyncRepository(ctx, params); err != nil {
+		t.Fatalf("failed to sync repository: %v", err)
+	}
+
+	if _, err := s.adapter.GetRef(ctx, mirrorPath, "refs/heads/feature"); err == nil {
+		t.Errorf("expected feature branch to be pruned")
+	}
+	if _, err := s.adapter.GetRef(ctx, mirrorPath, "refs/heads/main"); err != nil {
+		t.Errorf("expected main branch to be kept: %v", err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/sharedrepo"
 	"github.com/harness/gitness/git/types"
 
 	"github.com/rs/zerolog/log"
@@ -68,6 +69,7 @@ type CommitTag struct {
 	Message     string
 	Tagger      *Signature
 	Commit      *Commit
+	SignedData  *SignedData
 }
 
 type CreateCommitTagParams struct {
@@ -193,6 +195,7 @@ func (s *Service) ListCommitTags(
 				return nil, fmt.Errorf("signature mapping error: %w", err)
 			}
 			tags[wi].Tagger = tagger
+			tags[wi].SignedData = mapSignedData(aTags[ai].SignedData)
 
 			ai++
 			wi++
@@ -301,6 +304,13 @@ func (s *Service) CreateCommitTag(ctx context.Context, params *CreateCommitTagPa
 			return fmt.Errorf("failed to read annotated tag after creation: %w", err)
 		}
 
+		if params.Signer != nil && params.Message != "" {
+			tag.Sha, err = sharedrepo.SignTag(ctx, sharedRepo.Path(), params.Signer, tag.Sha)
+			if err != nil {
+				return fmt.Errorf("failed to sign tag '%s': %w", tagName, err)
+			}
+		}
+
 		err = sharedRepo.MoveObjects(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to move git objects: %w", err)
@@ -141,12 +141,14 @@ type WalkReferencesOptions struct {
 }
 
 type Commit struct {
-	SHA       string          `json:"sha"`
-	Title     string          `json:"title"`
-	Message   string          `json:"message,omitempty"`
-	Author    Signature       `json:"author"`
-	Committer Signature       `json:"committer"`
-	FileStats CommitFileStats `json:"file_stats,omitempty"`
+	SHA        string          `json:"sha"`
+	Title      string          `json:"title"`
+	Message    string          `json:"message,omitempty"`
+	Author     Signature       `json:"author"`
+	Committer  Signature       `json:"committer"`
+	FileStats  CommitFileStats `json:"file_stats,omitempty"`
+	ParentSHAs []string        `json:"parent_shas,omitempty"`
+	SignedData *SignedData     `json:"-"`
 }
 
 type CommitFileStats struct {
@@ -178,6 +180,26 @@ type Tag struct {
 	Title      string
 	Message    string
 	Tagger     Signature
+	SignedData *SignedData
+}
+
+const (
+	SignatureTypeGPG = "gpg"
+	SignatureTypeSSH = "ssh"
+)
+
+// SignedData contains the signature of a signed commit or tag together with the content that was signed.
+type SignedData struct {
+	// Type is the type of the signature, SignatureTypeGPG or SignatureTypeSSH, empty if it's not recognized.
+	Type          string
+	Signature     []byte
+	SignedContent []byte
+}
+
+// Signer signs commits and annotated tags created by the server.
+type Signer interface {
+	// Sign returns the armored signature of the raw content of a git object.
+	Sign(content []byte) ([]byte, error)
 }
 
 type CreateTagOptions struct {
@@ -283,6 +305,13 @@ type BlobReader struct {
 	Content io.ReadCloser
 }
 
+// FileInfo contains the path and the size of a blob.
+type FileInfo struct {
+	SHA  string
+	Path string
+	Size int64
+}
+
 // CommitDivergenceRequest contains the refs for which the converging commits should be counted.
 type CommitDivergenceRequest struct {
 	// From is the ref from which the counting of the diverging commits starts.
@@ -398,3 +427,59 @@ type FileDiffRequest struct {
 }
 
 type FileDiffRequests []FileDiffRequest
+
+// MergeConflictFile contains the versions of a file that couldn't be merged automatically.
+type MergeConflictFile struct {
+	Path string `json:"path"`
+
+	// Base, Ours and Theirs are versions of the file in the merge base and in the two merged commits.
+	// A version is nil if the file doesn't exist in the commit.
+	Base   *MergeConflictFileVersion `json:"base"`
+	Ours   *MergeConflictFileVersion `json:"ours"`
+	Theirs *MergeConflictFileVersion `json:"theirs"`
+
+	// Merged is the content of the file with the conflict markers, and Hunks are the conflicting parts of it.
+	// Both are empty for binary and for too large files.
+	Merged string              `json:"merged,omitempty"`
+	Hunks  []MergeConflictHunk `json:"hunks,omitempty"`
+
+	Binary   bool `json:"binary,omitempty"`
+	TooLarge bool `json:"too_large,omitempty"`
+}
+
+type MergeConflictFileVersion struct {
+	SHA     string `json:"sha"`
+	Mode    string `json:"mode"`
+	Size    int64  `json:"size"`
+	Content string `json:"content,omitempty"`
+}
+
+// MergeConflictHunk is a conflicting part of a file.
+type MergeConflictHunk struct {
+	// Line is the number of the line (starting from 1) of the merged content where the hunk begins.
+	Line   int    `json:"line"`
+	Ours   string `json:"ours"`
+	Base   string `json:"base"`
+	Theirs string `json:"theirs"`
+}
+
+// MergeConflictResolution is the resolved content of a conflicting file.
+type MergeConflictResolution struct {
+	Path    string
+	Content []byte
+	// Delete is set if the file is resolved by deleting it.
+	Delete bool
+}
+
+// AddedLines contains the lines a commit added to a file.
+type AddedLines struct {
+	CommitSHA string
+	Path      string
+	Lines     []AddedLine
+}
+
+// AddedLine is a line added to a file, Number is the line number in the new version of the file.
+type AddedLine struct {
+	Number  int
+	Content string
+}
@@ -0,0 +1,286 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/merge"
+	"github.com/harness/gitness/git/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UpdateBranchParams is input structure object for the update branch operation.
+type UpdateBranchParams struct {
+	WriteParams
+
+	// Branch is the branch that gets updated with the latest changes of the BaseBranch.
+	Branch string
+	// BranchExpectedSHA is optional, if provided the operation fails if the branch is on a different commit.
+	BranchExpectedSHA string
+
+	// BaseRepoUID is optional, it's the repository of the BaseBranch if it's not the same repository.
+	BaseRepoUID string
+	// BaseBranch is the branch whose changes are brought to the Branch.
+	BaseBranch string
+
+	// Method is either enum.MergeMethodMerge (the BaseBranch is merged into the Branch)
+	// or enum.MergeMethodRebase (commits of the Branch are rebased on top of the BaseBranch).
+	// (optional, default: enum.MergeMethodMerge)
+	Method enum.MergeMethod
+
+	// SkipRefUpdate is optional, if true the commits are created, but the branch isn't updated.
+	// It allows the caller to inspect the new commits before updating the branch with UpdateRef.
+	SkipRefUpdate bool
+
+	// Title is the title of the merge commit, it isn't used for the rebase.
+	// (optional, default: "Merge branch '<BaseBranch>' into <Branch>")
+	Title string
+
+	// Committer overwrites the git committer used for creating the commits
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for creating the commits
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for creating the merge commit
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for creating the merge commit
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+}
+
+func (p *UpdateBranchParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Branch == "" {
+		return errors.InvalidArgument("branch is mandatory")
+	}
+
+	if p.BaseBranch == "" {
+		return errors.InvalidArgument("base branch is mandatory")
+	}
+
+	switch p.Method {
+	case "", enum.MergeMethodMerge, enum.MergeMethodRebase:
+	default:
+		return errors.InvalidArgument("unsupported update branch method: %s", p.Method)
+	}
+
+	return nil
+}
+
+// UpdateBranchOutput is result object of the update branch operation.
+type UpdateBranchOutput struct {
+	// BranchSHA is the sha of the commit the branch was on before the update.
+	BranchSHA string
+	// BaseSHA is the sha of the latest commit of the base branch that was used for the update.
+	BaseSHA string
+	// MergeBaseSHA is the sha of the merge base of the BranchSHA and BaseSHA.
+	MergeBaseSHA string
+	// CommitSHA is the sha of the commit the branch is on after the update, it's empty if there are conflicts.
+	CommitSHA string
+
+	ConflictFiles []string
+}
+
+// UpdateBranch brings the latest changes of the base branch to the branch,
+// either by merging the base branch into the branch, or by rebasing the branch on top of the base branch.
+func (s *Service) UpdateBranch(ctx context.Context, params *UpdateBranchParams) (UpdateBranchOutput, error) {
+	if err := params.Validate(); err != nil {
+		return UpdateBranchOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	method := params.Method
+	if method == "" {
+		method = enum.MergeMethodMerge
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	baseRepoPath := repoPath
+	if params.BaseRepoUID != "" && params.BaseRepoUID != params.RepoUID {
+		baseRepoPath = getFullPathForRepo(s.reposRoot, params.BaseRepoUID)
+	}
+
+	log := log.Ctx(ctx).With().
+		Str("repo_uid", params.RepoUID).
+		Str("branch", params.Branch).
+		Str("base", params.BaseBranch).
+		Str("method", string(method)).
+		Logger()
+
+	// find the commit SHAs
+
+	refPath := adapter.GetReferenceFromBranchName(params.Branch)
+
+	branchSHA, baseSHA, mergeBaseSHA, err := s.findBranchUpdateSHAs(ctx,
+		repoPath, params.Branch, params.BranchExpectedSHA,
+		baseRepoPath, params.BaseBranch)
+	if err != nil {
+		return UpdateBranchOutput{}, err
+	}
+
+	// author and committer
+
+	now := time.Now().UTC()
+
+	committer := types.Signature{Identity: types.Identity(params.Actor), When: now}
+
+	if params.Committer != nil {
+		committer.Identity = types.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	author := committer
+
+	if params.Author != nil {
+		author.Identity = types.Identity(*params.Author)
+	}
+	if params.AuthorDate != nil {
+		author.When = *params.AuthorDate
+	}
+
+	// update
+
+	var commitSHA string
+	var conflicts []string
+
+	switch method {
+	case enum.MergeMethodMerge:
+		message := strings.TrimSpace(params.Title)
+		if message == "" {
+			message = fmt.Sprintf("Merge branch '%s' into %s", params.BaseBranch, params.Branch)
+		}
+
+		commitSHA, conflicts, err = merge.Merge(ctx,
+			repoPath, s.tmpDir,
+			&author, &committer,
+			message,
+			mergeBaseSHA, branchSHA, baseSHA,
+			params.Signer)
+	case enum.MergeMethodRebase:
+		commitSHA, conflicts, err = merge.Rebase(ctx,
+			repoPath, s.tmpDir,
+			&author, &committer,
+			"",
+			mergeBaseSHA, baseSHA, branchSHA,
+			params.Signer)
+	}
+	if err != nil {
+		return UpdateBranchOutput{}, errors.Internal(err, "failed to update branch %q with %q in %q using %q.",
+			params.Branch, params.BaseBranch, params.RepoUID, method)
+	}
+
+	if len(conflicts) > 0 {
+		return UpdateBranchOutput{
+			BranchSHA:     branchSHA,
+			BaseSHA:       baseSHA,
+			MergeBaseSHA:  mergeBaseSHA,
+			ConflictFiles: conflicts,
+		}, nil
+	}
+
+	if params.SkipRefUpdate {
+		return UpdateBranchOutput{
+			BranchSHA:    branchSHA,
+			BaseSHA:      baseSHA,
+			MergeBaseSHA: mergeBaseSHA,
+			CommitSHA:    commitSHA,
+		}, nil
+	}
+
+	// git reference update
+
+	log.Trace().Msg("update branch completed - updating git reference")
+
+	err = s.adapter.UpdateRef(
+		ctx,
+		params.EnvVars,
+		repoPath,
+		refPath,
+		branchSHA,
+		commitSHA,
+	)
+	if err != nil {
+		return UpdateBranchOutput{}, errors.Internal(err, "failed to update branch %q", params.Branch)
+	}
+
+	log.Trace().Msg("update branch completed - git reference updated")
+
+	return UpdateBranchOutput{
+		BranchSHA:    branchSHA,
+		BaseSHA:      baseSHA,
+		MergeBaseSHA: mergeBaseSHA,
+		CommitSHA:    commitSHA,
+	}, nil
+}
+
+// findBranchUpdateSHAs returns the SHAs of the branch, the base branch and their merge base.
+// If the base branch is in another repository, its commits are fetched to the repository of the branch.
+// It fails if the branch already contains the latest commit of the base branch.
+func (s *Service) findBranchUpdateSHAs(
+	ctx context.Context,
+	repoPath, branch, branchExpectedSHA string,
+	baseRepoPath, baseBranch string,
+) (branchSHA, baseSHA, mergeBaseSHA string, err error) {
+	branchSHA, err = s.adapter.GetFullCommitID(ctx, repoPath, adapter.GetReferenceFromBranchName(branch))
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to get branch commit SHA: %w", err)
+	}
+
+	if branchExpectedSHA != "" && branchExpectedSHA != branchSHA {
+		return "", "", "", errors.PreconditionFailed(
+			"branch '%s' is on SHA '%s' which doesn't match expected SHA '%s'.",
+			branch, branchSHA, branchExpectedSHA)
+	}
+
+	baseSHA, err = s.adapter.GetFullCommitID(ctx, baseRepoPath, adapter.GetReferenceFromBranchName(baseBranch))
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to get base branch commit SHA: %w", err)
+	}
+
+	// the base commits must exist in the repository of the branch to be able to update it.
+	if baseRepoPath != repoPath {
+		err = s.adapter.FetchObjects(ctx, repoPath, baseRepoPath, []string{baseSHA})
+		if err != nil {
+			return "", "", "", fmt.Errorf("failed to fetch base branch commits from the base repository: %w", err)
+		}
+	}
+
+	mergeBaseSHA, _, err = s.adapter.GetMergeBase(ctx, repoPath, "origin", branchSHA, baseSHA)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to get merge base: %w", err)
+	}
+
+	if mergeBaseSHA == baseSHA {
+		return "", "", "", errors.InvalidArgument(
+			"branch %q is already up to date with branch %q.", branch, baseBranch)
+	}
+
+	return branchSHA, baseSHA, mergeBaseSHA, nil
+}
@@ -0,0 +1,339 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultHandshakeTimeout defines the default timeout for the ssh handshake (incl. authentication).
+	DefaultHandshakeTimeout = 30 * time.Second
+
+	serverVersion = "SSH-2.0-Gitness"
+)
+
+// Config defines the config of an ssh server.
+type Config struct {
+	Port             int
+	HostKeyPath      string
+	HandshakeTimeout time.Duration
+}
+
+// Session contains the details of a command execution requested by an authenticated client.
+type Session struct {
+	// Permissions are the permissions returned by the handler during authentication.
+	Permissions *gossh.Permissions
+	Command     string
+	Env         []string
+	Stdin       io.Reader
+	Stdout      io.Writer
+	Stderr      io.Writer
+}
+
+// Handler authenticates ssh clients and executes the commands they request.
+type Handler interface {
+	// Authenticate returns the permissions of the connection if the public key is accepted.
+	// NOTE: The client didn't prove possession of the private key at this point yet.
+	Authenticate(ctx context.Context, conn gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error)
+
+	// Exec executes the command of the session. In case of an error,
+	// the error message is written to stderr and the command exits with a non-zero status.
+	Exec(ctx context.Context, session *Session) error
+}
+
+// Server is an ssh server that exposes async ListenAndServe and a corresponding ShutdownFunction.
+type Server struct {
+	config  Config
+	handler Handler
+}
+
+// ShutdownFunction defines a function that is called to shutdown the server.
+type ShutdownFunction func(context.Context) error
+
+func NewServer(config Config, handler Handler) *Server {
+	if config.HandshakeTimeout == 0 {
+		config.HandshakeTimeout = DefaultHandshakeTimeout
+	}
+
+	return &Server{
+		config:  config,
+		handler: handler,
+	}
+}
+
+// ListenAndServe initializes a server to respond to ssh network requests.
+func (s *Server) ListenAndServe() (*errgroup.Group, ShutdownFunction, error) {
+	hostKey, err := loadOrCreateHostKey(s.config.HostKeyPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load ssh host key: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.Port))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen on port %d: %w", s.config.Port, err)
+	}
+
+	// the base context is canceled only in case of a forced shutdown.
+	ctx, cancel := context.WithCancel(log.Logger.WithContext(context.Background()))
+
+	l := &listenerState{
+		listener: listener,
+		conns:    map[net.Conn]struct{}{},
+	}
+
+	sshConfig := &gossh.ServerConfig{
+		ServerVersion: serverVersion,
+		PublicKeyCallback: func(conn gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+			return s.handler.Authenticate(ctx, conn, key)
+		},
+	}
+	sshConfig.AddHostKey(hostKey)
+
+	var g errgroup.Group
+	g.Go(func() error {
+		for {
+			conn, err := listener.Accept()
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to accept ssh connection: %w", err)
+			}
+
+			if !l.add(conn) {
+				_ = conn.Close()
+				return nil
+			}
+
+			go func() {
+				defer l.remove(conn)
+				s.handleConn(ctx, sshConfig, conn)
+			}()
+		}
+	})
+
+	return &g, func(shutdownCtx context.Context) error {
+		defer cancel()
+		return l.shutdown(shutdownCtx)
+	}, nil
+}
+
+func (s *Server) handleConn(ctx context.Context, config *gossh.ServerConfig, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	_ = conn.SetDeadline(time.Now().Add(s.config.HandshakeTimeout))
+	serverConn, channels, requests, err := gossh.NewServerConn(conn, config)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msgf("ssh handshake with %s failed", conn.RemoteAddr())
+		return
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	go gossh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(gossh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to accept ssh channel")
+			continue
+		}
+
+		go s.handleSession(ctx, serverConn.Permissions, channel, requests)
+	}
+}
+
+func (s *Server) handleSession(
+	ctx context.Context,
+	permissions *gossh.Permissions,
+	channel gossh.Channel,
+	requests <-chan *gossh.Request,
+) {
+	defer func() { _ = channel.Close() }()
+
+	var env []string
+	for req := range requests {
+		switch req.Type {
+		case "env":
+			var payload struct{ Name, Value string }
+			if err := gossh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			env = append(env, payload.Name+"="+payload.Value)
+			_ = req.Reply(true, nil)
+
+		case "exec":
+			var payload struct{ Command string }
+			if err := gossh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			_ = req.Reply(true, nil)
+
+			var status uint32
+			err := s.handler.Exec(ctx, &Session{
+				Permissions: permissions,
+				Command:     payload.Command,
+				Env:         env,
+				Stdin:       channel,
+				Stdout:      channel,
+				Stderr:      channel.Stderr(),
+			})
+			if err != nil {
+				_, _ = fmt.Fprintf(channel.Stderr(), "%s\n", err)
+				status = 1
+			}
+
+			_ = channel.CloseWrite()
+			_, _ = channel.SendRequest("exit-status", false, gossh.Marshal(struct{ Status uint32 }{status}))
+
+			return
+
+		default:
+			// pty, shell, subsystems, ... aren't supported.
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+// listenerState keeps track of the listener and all open connections to allow a graceful shutdown.
+type listenerState struct {
+	listener net.Listener
+	mx       sync.Mutex
+	closed   bool
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+// add registers a new connection - it returns false in case the listener is shutting down.
+func (l *listenerState) add(conn net.Conn) bool {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.closed {
+		return false
+	}
+
+	l.wg.Add(1)
+	l.conns[conn] = struct{}{}
+
+	return true
+}
+
+func (l *listenerState) remove(conn net.Conn) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	delete(l.conns, conn)
+	l.wg.Done()
+}
+
+// shutdown stops accepting new connections and waits for existing connections to finish.
+// Once the context is done, all remaining connections are closed forcefully.
+func (l *listenerState) shutdown(ctx context.Context) error {
+	l.mx.Lock()
+	l.closed = true
+	l.mx.Unlock()
+
+	err := l.listener.Close()
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		return fmt.Errorf("failed to close listener: %w", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	l.mx.Lock()
+	for conn := range l.conns {
+		_ = conn.Close()
+	}
+	l.mx.Unlock()
+
+	return ctx.Err()
+}
+
+// loadOrCreateHostKey loads the private host key from the provided path.
+// In case the file doesn't exist yet, a new ed25519 key is generated and stored at the path.
+func loadOrCreateHostKey(path string) (gossh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		data, err = createHostKey(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := gossh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host key %q: %w", path, err)
+	}
+
+	return signer, nil
+}
+
+func createHostKey(path string) ([]byte, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate host key: %w", err)
+	}
+
+	block, err := gossh.MarshalPrivateKey(key, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal host key: %w", err)
+	}
+
+	data := pem.EncodeToMemory(block)
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create host key directory: %w", err)
+	}
+
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write host key %q: %w", path, err)
+	}
+
+	return data, nil
+}
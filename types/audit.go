@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// AuditEvent is an entry of the audit log, a record of a security-relevant change.
+type AuditEvent struct {
+	ID      int64 `json:"id"`
+	Created int64 `json:"created"`
+
+	ActorID int64            `json:"actor_id"`
+	Action  enum.AuditAction `json:"action"`
+
+	ResourceType enum.AuditResourceType `json:"resource_type"`
+	// Resource identifies the changed resource, e.g. the path of a repository or the UID of a user.
+	Resource string `json:"resource"`
+
+	// SpaceID is the space of the changed resource, if the resource belongs to a space.
+	SpaceID *int64 `json:"space_id,omitempty"`
+	// RepoID is the repository of the changed resource, if the resource belongs to a repository.
+	RepoID *int64 `json:"repo_id,omitempty"`
+
+	// Before and After contain the changed fields of the resource before and after the change.
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+
+	ClientIP  string `json:"client_ip"`
+	UserAgent string `json:"user_agent"`
+
+	Actor PrincipalInfo `json:"actor"`
+}
+
+// AuditEventFilter stores audit log query parameters.
+type AuditEventFilter struct {
+	Pagination
+	SpaceID int64 `json:"space_id"`
+	RepoID  int64 `json:"repo_id"`
+	ActorID int64 `json:"actor_id"`
+	Since   int64 `json:"since"`
+	Until   int64 `json:"until"`
+
+	// BeforeID restricts the results to the events older than the event with the ID.
+	// It's used to iterate over all events, because the offset is unstable in a growing log.
+	BeforeID int64 `json:"-"`
+}
@@ -32,3 +32,15 @@ type CodeCommentFields struct {
 	LineOld      int    `db:"pullreq_activity_code_comment_line_old" json:"line_old"`
 	SpanOld      int    `db:"pullreq_activity_code_comment_span_old" json:"span_old"`
 }
+
+// CodeCommentSuggestion is a replacement of the lines of a code comment proposed by the reviewer.
+// The suggestion replaces the lines LineNew to LineNew+SpanNew-1 of the file at the comment's SourceSHA,
+// so it can be applied only while the code comment is not outdated.
+type CodeCommentSuggestion struct {
+	Content string `json:"content"`
+
+	// Applied is the time when the suggestion has been applied to the source branch.
+	Applied    *int64 `json:"applied,omitempty"`
+	AppliedBy  *int64 `json:"applied_by,omitempty"`
+	AppliedSHA string `json:"applied_sha,omitempty"`
+}
@@ -21,5 +21,6 @@ type CommitFilesResponse struct {
 	DryRunRules    bool               `json:"dry_run_rules,omitempty"`
 	CommitID       string             `json:"commit_id"`
 	RuleViolations []RuleViolations   `json:"rule_violations,omitempty"`
+	SecretFindings []SecretFinding    `json:"secret_findings,omitempty"`
 	Stats          git.CommitFileStat `json:"stats,omitempty"`
 }
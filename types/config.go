@@ -121,6 +121,20 @@ type Config struct {
 		HTTP struct {
 			Port  int    `envconfig:"GITNESS_HTTP_PORT" default:"3000"`
 			Proto string `envconfig:"GITNESS_HTTP_PROTO" default:"http"`
+
+			// TrustedProxies is a list of IP addresses or CIDR ranges of the reverse proxies in front
+			// of the server. Only for the requests coming from them the client IP address is resolved
+			// using the X-Forwarded-For and X-Real-IP headers.
+			TrustedProxies []string `envconfig:"GITNESS_HTTP_TRUSTED_PROXIES"`
+		}
+
+		// SSH defines the ssh configuration parameters used for git operations.
+		SSH struct {
+			Enabled bool `envconfig:"GITNESS_SSH_ENABLED"`
+			Port    int  `envconfig:"GITNESS_SSH_PORT" default:"3022"`
+			// HostKeyPath points to the private host key of the server.
+			// A new key is generated in case the file doesn't exist.
+			HostKeyPath string `envconfig:"GITNESS_SSH_HOST_KEY_PATH"`
 		}
 
 		// Acme defines Acme configuration parameters.
@@ -347,10 +361,55 @@ type Config struct {
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+		// IndexPath is the directory in which the keyword search index is stored (defaults to <git root>/keywordsearch).
+		IndexPath string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_PATH"`
+	}
+
+	MergeQueue struct {
+		Concurrency int `envconfig:"GITNESS_MERGE_QUEUE_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_MERGE_QUEUE_MAX_RETRIES" default:"3"`
+	}
+
+	AutoMerge struct {
+		Concurrency int `envconfig:"GITNESS_AUTO_MERGE_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_AUTO_MERGE_MAX_RETRIES" default:"3"`
+	}
+
+	Issues struct {
+		Concurrency int `envconfig:"GITNESS_ISSUES_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_ISSUES_MAX_RETRIES" default:"3"`
+	}
+
+	LFS struct {
+		// MaxObjectSize is the maximum size of an LFS object in bytes.
+		MaxObjectSize int64 `envconfig:"GITNESS_LFS_MAX_OBJECT_SIZE" default:"5368709120"` // 5 GiB
 	}
 
 	Repos struct {
 		// DeletedRetentionTime is the duration after which deleted repositories will be purged.
 		DeletedRetentionTime time.Duration `envconfig:"GITNESS_REPOS_DELETED_RETENTION_TIME" default:"2160h"` // 90 days
 	}
+
+	Mirror struct {
+		// MinInterval is the minimum time allowed between two syncs of a pull mirror.
+		MinInterval time.Duration `envconfig:"GITNESS_MIRROR_MIN_INTERVAL" default:"10m"`
+		// DefaultInterval is the time between two syncs of a mirror if none is provided by the user.
+		DefaultInterval time.Duration `envconfig:"GITNESS_MIRROR_DEFAULT_INTERVAL" default:"8h"`
+		// MaxDuration is the maximum time a single run of the mirror sync job is allowed to take.
+		MaxDuration         time.Duration `envconfig:"GITNESS_MIRROR_MAX_DURATION" default:"30m"`
+		AllowPrivateNetwork bool          `envconfig:"GITNESS_MIRROR_ALLOW_PRIVATE_NETWORK" default:"false"`
+		AllowLoopback       bool          `envconfig:"GITNESS_MIRROR_ALLOW_LOOPBACK" default:"false"`
+	}
+
+	SecretScan struct {
+		// DefaultMode is the secret scanning mode of repositories whose spaces don't configure one.
+		DefaultMode string `envconfig:"GITNESS_SECRET_SCAN_DEFAULT_MODE" default:"off"`
+		// MaxScanDuration is the maximum time a scan of the whole history of a repository is allowed to take.
+		MaxScanDuration time.Duration `envconfig:"GITNESS_SECRET_SCAN_MAX_SCAN_DURATION" default:"1h"`
+	}
+
+	Audit struct {
+		// RetentionTime is the duration after which audit events will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_AUDIT_RETENTION_TIME" default:"8760h"` // 365 days
+	}
 }
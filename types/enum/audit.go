@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AuditAction is the kind of change recorded in the audit log.
+type AuditAction string
+
+// AuditAction enumeration.
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+	// AuditActionPurge is the permanent removal of a soft deleted resource.
+	AuditActionPurge AuditAction = "purge"
+	// AuditActionBypass is an operation performed despite violating protection rules.
+	AuditActionBypass AuditAction = "bypass"
+)
+
+var auditActions = sortEnum([]AuditAction{
+	AuditActionCreate,
+	AuditActionUpdate,
+	AuditActionDelete,
+	AuditActionPurge,
+	AuditActionBypass,
+})
+
+func (AuditAction) Enum() []interface{} { return toInterfaceSlice(auditActions) }
+func (a AuditAction) Sanitize() (AuditAction, bool) {
+	return Sanitize(a, GetAllAuditActions)
+}
+func GetAllAuditActions() ([]AuditAction, AuditAction) {
+	return auditActions, ""
+}
+
+// AuditResourceType is the type of the resource whose change is recorded in the audit log.
+type AuditResourceType string
+
+// AuditResourceType enumeration.
+const (
+	AuditResourceTypeRepository      AuditResourceType = "repository"
+	AuditResourceTypeSpaceMembership AuditResourceType = "space_membership"
+	AuditResourceTypeRule            AuditResourceType = "rule"
+	AuditResourceTypeToken           AuditResourceType = "token"
+	AuditResourceTypeUser            AuditResourceType = "user"
+	AuditResourceTypePullReq         AuditResourceType = "pullreq"
+)
+
+var auditResourceTypes = sortEnum([]AuditResourceType{
+	AuditResourceTypeRepository,
+	AuditResourceTypeSpaceMembership,
+	AuditResourceTypeRule,
+	AuditResourceTypeToken,
+	AuditResourceTypeUser,
+	AuditResourceTypePullReq,
+})
+
+func (AuditResourceType) Enum() []interface{} { return toInterfaceSlice(auditResourceTypes) }
+func (t AuditResourceType) Sanitize() (AuditResourceType, bool) {
+	return Sanitize(t, GetAllAuditResourceTypes)
+}
+func GetAllAuditResourceTypes() ([]AuditResourceType, AuditResourceType) {
+	return auditResourceTypes, ""
+}
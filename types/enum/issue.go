@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// IssueState defines issue state.
+type IssueState string
+
+func (IssueState) Enum() []interface{}              { return toInterfaceSlice(issueStates) }
+func (s IssueState) Sanitize() (IssueState, bool)   { return Sanitize(s, GetAllIssueStates) }
+func GetAllIssueStates() ([]IssueState, IssueState) { return issueStates, "" }
+
+// IssueState enumeration.
+const (
+	IssueStateOpen   IssueState = "open"
+	IssueStateClosed IssueState = "closed"
+)
+
+var issueStates = sortEnum([]IssueState{
+	IssueStateOpen,
+	IssueStateClosed,
+})
+
+// IssueSort defines issue attribute that can be used for sorting.
+type IssueSort string
+
+func (IssueSort) Enum() []interface{}            { return toInterfaceSlice(issueSorts) }
+func (s IssueSort) Sanitize() (IssueSort, bool)  { return Sanitize(s, GetAllIssueSorts) }
+func GetAllIssueSorts() ([]IssueSort, IssueSort) { return issueSorts, IssueSortNumber }
+
+// IssueSort enumeration.
+const (
+	IssueSortNumber  IssueSort = "number"
+	IssueSortCreated IssueSort = "created"
+	IssueSortEdited  IssueSort = "edited"
+	IssueSortClosed  IssueSort = "closed"
+)
+
+var issueSorts = sortEnum([]IssueSort{
+	IssueSortNumber,
+	IssueSortCreated,
+	IssueSortEdited,
+	IssueSortClosed,
+})
+
+// IssueActivityType defines issue activity message type.
+// Essentially, the Type determines the structure of the issue activity's Payload structure.
+type IssueActivityType string
+
+func (IssueActivityType) Enum() []interface{} { return toInterfaceSlice(issueActivityTypes) }
+
+func (t IssueActivityType) Sanitize() (IssueActivityType, bool) {
+	return Sanitize(t, GetAllIssueActivityTypes)
+}
+
+func GetAllIssueActivityTypes() ([]IssueActivityType, IssueActivityType) {
+	return issueActivityTypes, "" // No default value
+}
+
+// IssueActivityType enumeration.
+const (
+	IssueActivityTypeComment         IssueActivityType = "comment"
+	IssueActivityTypeTitleChange     IssueActivityType = "title-change"
+	IssueActivityTypeStateChange     IssueActivityType = "state-change"
+	IssueActivityTypeAssigneeModify  IssueActivityType = "assignee-modify"
+	IssueActivityTypeLabelModify     IssueActivityType = "label-modify"
+	IssueActivityTypeMilestoneModify IssueActivityType = "milestone-modify"
+)
+
+var issueActivityTypes = sortEnum([]IssueActivityType{
+	IssueActivityTypeComment,
+	IssueActivityTypeTitleChange,
+	IssueActivityTypeStateChange,
+	IssueActivityTypeAssigneeModify,
+	IssueActivityTypeLabelModify,
+	IssueActivityTypeMilestoneModify,
+})
+
+// IssueActivityKind defines kind of issue activity: whether it's generated by the system or it's a user comment.
+type IssueActivityKind string
+
+func (IssueActivityKind) Enum() []interface{} { return toInterfaceSlice(issueActivityKinds) }
+
+func (k IssueActivityKind) Sanitize() (IssueActivityKind, bool) {
+	return Sanitize(k, GetAllIssueActivityKinds)
+}
+
+func GetAllIssueActivityKinds() ([]IssueActivityKind, IssueActivityKind) {
+	return issueActivityKinds, "" // No default value
+}
+
+// IssueActivityKind enumeration.
+const (
+	IssueActivityKindSystem  IssueActivityKind = "system"
+	IssueActivityKindComment IssueActivityKind = "comment"
+)
+
+var issueActivityKinds = sortEnum([]IssueActivityKind{
+	IssueActivityKindSystem,
+	IssueActivityKindComment,
+})
+
+// AssigneeAction defines the change of issue assignees.
+type AssigneeAction string
+
+// AssigneeAction enumeration.
+const (
+	AssigneeActionAssigned   AssigneeAction = "assigned"
+	AssigneeActionUnassigned AssigneeAction = "unassigned"
+)
+
+var assigneeActions = sortEnum([]AssigneeAction{
+	AssigneeActionAssigned,
+	AssigneeActionUnassigned,
+})
+
+func (AssigneeAction) Enum() []interface{} { return toInterfaceSlice(assigneeActions) }
+
+// MilestoneState defines milestone state.
+type MilestoneState string
+
+func (MilestoneState) Enum() []interface{}                      { return toInterfaceSlice(milestoneStates) }
+func (s MilestoneState) Sanitize() (MilestoneState, bool)       { return Sanitize(s, GetAllMilestoneStates) }
+func GetAllMilestoneStates() ([]MilestoneState, MilestoneState) { return milestoneStates, "" }
+
+// MilestoneState enumeration.
+const (
+	MilestoneStateOpen   MilestoneState = "open"
+	MilestoneStateClosed MilestoneState = "closed"
+)
+
+var milestoneStates = sortEnum([]MilestoneState{
+	MilestoneStateOpen,
+	MilestoneStateClosed,
+})
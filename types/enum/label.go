@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// LabelAction defines the change of the labels assigned to a pull request.
+type LabelAction string
+
+// LabelAction enumeration.
+const (
+	LabelActionAssigned   LabelAction = "assigned"
+	LabelActionUnassigned LabelAction = "unassigned"
+	// LabelActionReassigned is used when a scoped label replaces the assigned label with the same key.
+	LabelActionReassigned LabelAction = "reassigned"
+)
+
+var labelActions = sortEnum([]LabelAction{
+	LabelActionAssigned,
+	LabelActionUnassigned,
+	LabelActionReassigned,
+})
+
+func (LabelAction) Enum() []interface{} { return toInterfaceSlice(labelActions) }
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MergeQueueEntryState defines the state of a pull request in a merge queue.
+type MergeQueueEntryState string
+
+// MergeQueueEntryState enumeration.
+const (
+	// MergeQueueEntryStatePending means that the merge commit of the entry has yet to be created.
+	MergeQueueEntryStatePending MergeQueueEntryState = "pending"
+	// MergeQueueEntryStateChecking means that the merge commit of the entry has been created
+	// and the queue is waiting for the required status checks to complete.
+	MergeQueueEntryStateChecking MergeQueueEntryState = "checking"
+)
+
+var mergeQueueEntryStates = sortEnum([]MergeQueueEntryState{
+	MergeQueueEntryStatePending,
+	MergeQueueEntryStateChecking,
+})
+
+func (MergeQueueEntryState) Enum() []interface{} { return toInterfaceSlice(mergeQueueEntryStates) }
+func (s MergeQueueEntryState) Sanitize() (MergeQueueEntryState, bool) {
+	return Sanitize(s, GetAllMergeQueueEntryStates)
+}
+func GetAllMergeQueueEntryStates() ([]MergeQueueEntryState, MergeQueueEntryState) {
+	return mergeQueueEntryStates, ""
+}
+
+// MergeQueueAction defines the change of a pull request's merge queue membership.
+type MergeQueueAction string
+
+// MergeQueueAction enumeration.
+const (
+	MergeQueueActionEnqueued MergeQueueAction = "enqueued"
+	MergeQueueActionDequeued MergeQueueAction = "dequeued"
+	MergeQueueActionEjected  MergeQueueAction = "ejected"
+)
+
+var mergeQueueActions = sortEnum([]MergeQueueAction{
+	MergeQueueActionEnqueued,
+	MergeQueueActionDequeued,
+	MergeQueueActionEjected,
+})
+
+func (MergeQueueAction) Enum() []interface{} { return toInterfaceSlice(mergeQueueActions) }
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MirrorDirection defines the direction in which a repository mirror replicates.
+type MirrorDirection string
+
+// MirrorDirection enumeration.
+const (
+	// MirrorDirectionPull fetches the content of the remote repository into the repository.
+	MirrorDirectionPull MirrorDirection = "pull"
+	// MirrorDirectionPush pushes the content of the repository to the remote repository.
+	MirrorDirectionPush MirrorDirection = "push"
+)
+
+var mirrorDirections = sortEnum([]MirrorDirection{
+	MirrorDirectionPull,
+	MirrorDirectionPush,
+})
+
+func (MirrorDirection) Enum() []interface{} { return toInterfaceSlice(mirrorDirections) }
+func (d MirrorDirection) Sanitize() (MirrorDirection, bool) {
+	return Sanitize(d, GetAllMirrorDirections)
+}
+func GetAllMirrorDirections() ([]MirrorDirection, MirrorDirection) {
+	return mirrorDirections, ""
+}
+
+// MirrorSyncStatus defines the status of the latest sync of a repository mirror.
+type MirrorSyncStatus string
+
+// MirrorSyncStatus enumeration.
+const (
+	MirrorSyncStatusPending MirrorSyncStatus = "pending"
+	MirrorSyncStatusRunning MirrorSyncStatus = "running"
+	MirrorSyncStatusSuccess MirrorSyncStatus = "success"
+	MirrorSyncStatusFailed  MirrorSyncStatus = "failed"
+)
+
+var mirrorSyncStatuses = sortEnum([]MirrorSyncStatus{
+	MirrorSyncStatusPending,
+	MirrorSyncStatusRunning,
+	MirrorSyncStatusSuccess,
+	MirrorSyncStatusFailed,
+})
+
+func (MirrorSyncStatus) Enum() []interface{} { return toInterfaceSlice(mirrorSyncStatuses) }
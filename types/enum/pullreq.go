@@ -86,6 +86,9 @@ const (
 	PullReqActivityTypeBranchUpdate PullReqActivityType = "branch-update"
 	PullReqActivityTypeBranchDelete PullReqActivityType = "branch-delete"
 	PullReqActivityTypeMerge        PullReqActivityType = "merge"
+	PullReqActivityTypeMergeQueue   PullReqActivityType = "merge-queue"
+	PullReqActivityTypeAutoMerge    PullReqActivityType = "auto-merge"
+	PullReqActivityTypeLabelModify  PullReqActivityType = "label-modify"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -97,6 +100,9 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeBranchUpdate,
 	PullReqActivityTypeBranchDelete,
 	PullReqActivityTypeMerge,
+	PullReqActivityTypeMergeQueue,
+	PullReqActivityTypeAutoMerge,
+	PullReqActivityTypeLabelModify,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -211,15 +217,19 @@ type MergeMethod gitenum.MergeMethod
 
 // MergeMethod enumeration.
 const (
-	MergeMethodMerge  = MergeMethod(gitenum.MergeMethodMerge)
-	MergeMethodSquash = MergeMethod(gitenum.MergeMethodSquash)
-	MergeMethodRebase = MergeMethod(gitenum.MergeMethodRebase)
+	MergeMethodMerge       = MergeMethod(gitenum.MergeMethodMerge)
+	MergeMethodSquash      = MergeMethod(gitenum.MergeMethodSquash)
+	MergeMethodRebase      = MergeMethod(gitenum.MergeMethodRebase)
+	MergeMethodFastForward = MergeMethod(gitenum.MergeMethodFastForward)
+	MergeMethodRebaseMerge = MergeMethod(gitenum.MergeMethodRebaseMerge)
 )
 
 var MergeMethods = sortEnum([]MergeMethod{
 	MergeMethodMerge,
 	MergeMethodSquash,
 	MergeMethodRebase,
+	MergeMethodFastForward,
+	MergeMethodRebaseMerge,
 })
 
 func (MergeMethod) Enum() []interface{} { return toInterfaceSlice(MergeMethods) }
@@ -238,3 +248,23 @@ const (
 	// MergeCheckStatusMergeable branch can merged cleanly into the target branch.
 	MergeCheckStatusMergeable MergeCheckStatus = "mergeable"
 )
+
+// AutoMergeAction defines the change of a pull request's auto-merge setting.
+type AutoMergeAction string
+
+// AutoMergeAction enumeration.
+const (
+	AutoMergeActionArmed    AutoMergeAction = "armed"
+	AutoMergeActionDisarmed AutoMergeAction = "disarmed"
+	AutoMergeActionMerged   AutoMergeAction = "merged"
+	AutoMergeActionFailed   AutoMergeAction = "failed"
+)
+
+var autoMergeActions = sortEnum([]AutoMergeAction{
+	AutoMergeActionArmed,
+	AutoMergeActionDisarmed,
+	AutoMergeActionMerged,
+	AutoMergeActionFailed,
+})
+
+func (AutoMergeAction) Enum() []interface{} { return toInterfaceSlice(autoMergeActions) }
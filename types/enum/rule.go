@@ -83,3 +83,24 @@ func ParseRuleSortAttr(s string) RuleSort {
 
 	return RuleSortIdentifier
 }
+
+// RuleViolationAction is the operation during which a violation of a protection rule has been recorded.
+type RuleViolationAction string
+
+// RuleViolationAction enumeration.
+const (
+	// RuleViolationActionMerge is merging of a pull request.
+	RuleViolationActionMerge RuleViolationAction = "merge"
+	// RuleViolationActionRefChange is creation or deletion of a branch or a tag.
+	RuleViolationActionRefChange RuleViolationAction = "ref_change"
+	// RuleViolationActionPush is adding commits to a branch, either by git push or by a commit made on the server.
+	RuleViolationActionPush RuleViolationAction = "push"
+)
+
+var ruleViolationActions = sortEnum([]RuleViolationAction{
+	RuleViolationActionMerge,
+	RuleViolationActionRefChange,
+	RuleViolationActionPush,
+})
+
+func (RuleViolationAction) Enum() []interface{} { return toInterfaceSlice(ruleViolationActions) }
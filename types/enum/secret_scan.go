@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SecretScanMode defines how secrets found in new content of a repository are handled.
+type SecretScanMode string
+
+// SecretScanMode enumeration.
+const (
+	// SecretScanModeOff disables secret scanning.
+	SecretScanModeOff SecretScanMode = "off"
+	// SecretScanModeWarn accepts content with secrets, but reports and records the secrets.
+	SecretScanModeWarn SecretScanMode = "warn"
+	// SecretScanModeBlock rejects content with secrets, unless the secrets are bypassed with a justification.
+	SecretScanModeBlock SecretScanMode = "block"
+)
+
+var secretScanModes = sortEnum([]SecretScanMode{
+	SecretScanModeOff,
+	SecretScanModeWarn,
+	SecretScanModeBlock,
+})
+
+func (SecretScanMode) Enum() []interface{} { return toInterfaceSlice(secretScanModes) }
+func (m SecretScanMode) Sanitize() (SecretScanMode, bool) {
+	return Sanitize(m, GetAllSecretScanModes)
+}
+func GetAllSecretScanModes() ([]SecretScanMode, SecretScanMode) {
+	return secretScanModes, ""
+}
+
+// SecretFindingSource defines how a secret has been found.
+type SecretFindingSource string
+
+// SecretFindingSource enumeration.
+const (
+	// SecretFindingSourcePush is a secret found in a git push.
+	SecretFindingSourcePush SecretFindingSource = "push"
+	// SecretFindingSourceCommit is a secret found in a commit created through the API.
+	SecretFindingSourceCommit SecretFindingSource = "commit"
+	// SecretFindingSourceScan is a secret found by a scan of the repository history.
+	SecretFindingSourceScan SecretFindingSource = "scan"
+)
+
+var secretFindingSources = sortEnum([]SecretFindingSource{
+	SecretFindingSourcePush,
+	SecretFindingSourceCommit,
+	SecretFindingSourceScan,
+})
+
+func (SecretFindingSource) Enum() []interface{} { return toInterfaceSlice(secretFindingSources) }
+func (s SecretFindingSource) Sanitize() (SecretFindingSource, bool) {
+	return Sanitize(s, GetAllSecretFindingSources)
+}
+func GetAllSecretFindingSources() ([]SecretFindingSource, SecretFindingSource) {
+	return secretFindingSources, ""
+}
+
+// SecretScanStatus defines the status of a scan of the repository history.
+type SecretScanStatus string
+
+// SecretScanStatus enumeration.
+const (
+	SecretScanStatusScheduled SecretScanStatus = "scheduled"
+	SecretScanStatusRunning   SecretScanStatus = "running"
+	SecretScanStatusSuccess   SecretScanStatus = "success"
+	SecretScanStatusFailed    SecretScanStatus = "failed"
+)
+
+var secretScanStatuses = sortEnum([]SecretScanStatus{
+	SecretScanStatusScheduled,
+	SecretScanStatusRunning,
+	SecretScanStatusSuccess,
+	SecretScanStatusFailed,
+})
+
+func (SecretScanStatus) Enum() []interface{} { return toInterfaceSlice(secretScanStatuses) }
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SigningKeyType defines the type of a key used to sign commits and tags.
+type SigningKeyType string
+
+// SigningKeyType enumeration.
+const (
+	SigningKeyTypeGPG SigningKeyType = "gpg"
+	SigningKeyTypeSSH SigningKeyType = "ssh"
+)
+
+var signingKeyTypes = sortEnum([]SigningKeyType{
+	SigningKeyTypeGPG,
+	SigningKeyTypeSSH,
+})
+
+func (SigningKeyType) Enum() []interface{} { return toInterfaceSlice(signingKeyTypes) }
+func (t SigningKeyType) Sanitize() (SigningKeyType, bool) {
+	return Sanitize(t, GetAllSigningKeyTypes)
+}
+func GetAllSigningKeyTypes() ([]SigningKeyType, SigningKeyType) {
+	return signingKeyTypes, ""
+}
+
+// SignatureVerificationReason defines the outcome of the verification of a commit or tag signature.
+type SignatureVerificationReason string
+
+// SignatureVerificationReason enumeration.
+const (
+	// SignatureVerificationReasonValid means that the signature is verified by a key of the signer.
+	SignatureVerificationReasonValid SignatureVerificationReason = "valid"
+	// SignatureVerificationReasonUnsigned means that the object isn't signed.
+	SignatureVerificationReasonUnsigned SignatureVerificationReason = "unsigned"
+	// SignatureVerificationReasonUnknownSignatureType means that the signature is neither GPG nor SSH.
+	SignatureVerificationReasonUnknownSignatureType SignatureVerificationReason = "unknown_signature_type"
+	// SignatureVerificationReasonMalformedSignature means that the signature can't be parsed.
+	SignatureVerificationReasonMalformedSignature SignatureVerificationReason = "malformed_signature"
+	// SignatureVerificationReasonNoUser means that no user is registered with the committer (or tagger) email.
+	SignatureVerificationReasonNoUser SignatureVerificationReason = "no_user"
+	// SignatureVerificationReasonUnknownKey means that none of the signing keys of the user verifies the signature.
+	SignatureVerificationReasonUnknownKey SignatureVerificationReason = "unknown_key"
+)
+
+var signatureVerificationReasons = sortEnum([]SignatureVerificationReason{
+	SignatureVerificationReasonValid,
+	SignatureVerificationReasonUnsigned,
+	SignatureVerificationReasonUnknownSignatureType,
+	SignatureVerificationReasonMalformedSignature,
+	SignatureVerificationReasonNoUser,
+	SignatureVerificationReasonUnknownKey,
+})
+
+func (SignatureVerificationReason) Enum() []interface{} {
+	return toInterfaceSlice(signatureVerificationReasons)
+}
@@ -28,4 +28,8 @@ const (
 	SSETypeRepositoryExportCompleted SSEType = "repository_export_completed"
 
 	SSETypePullRequestUpdated SSEType = "pullreq_updated"
+
+	SSETypeIssueUpdated SSEType = "issue_updated"
+
+	SSETypeMergeQueueUpdated SSEType = "merge_queue_updated"
 )
@@ -158,6 +158,21 @@ const (
 	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
 	// WebhookTriggerPullReqMerged gets triggered when a pull request is merged.
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
+	// WebhookTriggerPullReqReviewSubmitted gets triggered when a pull request review is submitted.
+	WebhookTriggerPullReqReviewSubmitted WebhookTrigger = "pullreq_review_submitted"
+	// WebhookTriggerPullReqLabelAssigned gets triggered when a label is assigned to a pull request.
+	WebhookTriggerPullReqLabelAssigned WebhookTrigger = "pullreq_label_assigned"
+	// WebhookTriggerPullReqLabelUnassigned gets triggered when a label is removed from a pull request.
+	WebhookTriggerPullReqLabelUnassigned WebhookTrigger = "pullreq_label_unassigned"
+
+	// WebhookTriggerIssueCreated gets triggered when an issue gets created.
+	WebhookTriggerIssueCreated WebhookTrigger = "issue_created"
+	// WebhookTriggerIssueClosed gets triggered when an issue is closed.
+	WebhookTriggerIssueClosed WebhookTrigger = "issue_closed"
+	// WebhookTriggerIssueReopened gets triggered when an issue gets reopened.
+	WebhookTriggerIssueReopened WebhookTrigger = "issue_reopened"
+	// WebhookTriggerIssueCommentCreated gets triggered when an issue comment gets created.
+	WebhookTriggerIssueCommentCreated WebhookTrigger = "issue_comment_created"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -173,4 +188,11 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerPullReqReviewSubmitted,
+	WebhookTriggerPullReqLabelAssigned,
+	WebhookTriggerPullReqLabelUnassigned,
+	WebhookTriggerIssueCreated,
+	WebhookTriggerIssueClosed,
+	WebhookTriggerIssueReopened,
+	WebhookTriggerIssueCommentCreated,
 })
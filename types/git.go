@@ -62,6 +62,9 @@ type Commit struct {
 	Message   string    `json:"message"`
 	Author    Signature `json:"author"`
 	Committer Signature `json:"committer"`
+
+	// Verification is the result of the verification of the commit signature.
+	Verification *SignatureVerification `json:"verification,omitempty"`
 }
 
 type Signature struct {
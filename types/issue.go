@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// Issue represents an issue of a repository.
+// Issues share the number sequence with pull requests of the same repository.
+type Issue struct {
+	ID      int64 `json:"-"` // not returned, it's an internal field
+	Version int64 `json:"-"` // not returned, it's an internal field
+	Number  int64 `json:"number"`
+	RepoID  int64 `json:"repo_id"`
+
+	CreatedBy int64 `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"-"` // not returned, it's updated by the server internally. Clients should use Edited.
+	Edited    int64 `json:"edited"`
+
+	State       enum.IssueState `json:"state"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+
+	ActivitySeq  int64 `json:"-"` // not returned, because it's a server's internal field
+	CommentCount int   `json:"comment_count"`
+
+	MilestoneID *int64 `json:"-"` // not returned, because the milestone info is in the Milestone field
+
+	ClosedBy *int64 `json:"-"` // not returned, because the closer info is in the Closer field
+	Closed   *int64 `json:"closed"`
+
+	Author PrincipalInfo  `json:"author"`
+	Closer *PrincipalInfo `json:"closer"`
+
+	Assignees []*PrincipalInfo `json:"assignees"`
+	Labels    []*LabelInfo     `json:"labels"`
+	Milestone *MilestoneInfo   `json:"milestone"`
+}
+
+// IssueFilter stores issue query parameters.
+type IssueFilter struct {
+	Page        int               `json:"page"`
+	Size        int               `json:"size"`
+	Query       string            `json:"query"`
+	CreatedBy   int64             `json:"created_by"`
+	RepoID      int64             `json:"-"`
+	States      []enum.IssueState `json:"state"`
+	AssigneeID  int64             `json:"assignee_id"`
+	MilestoneID int64             `json:"milestone_id"`
+	LabelIDs    []int64           `json:"label_id"`
+	Sort        enum.IssueSort    `json:"sort"`
+	Order       enum.Order        `json:"order"`
+}
+
+// IssueAssignee is an assignment of a principal to an issue.
+type IssueAssignee struct {
+	IssueID     int64
+	PrincipalID int64
+	Created     int64
+	CreatedBy   int64
+}
+
+// IssueLabel is an assignment of a label to an issue.
+type IssueLabel struct {
+	IssueID   int64
+	LabelID   int64
+	Created   int64
+	CreatedBy int64
+}
+
+// Milestone groups issues of a repository that should be completed together, optionally by a due date.
+type Milestone struct {
+	ID     int64 `json:"id"`
+	RepoID int64 `json:"repo_id"`
+
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	State       enum.MilestoneState `json:"state"`
+	DueDate     *int64              `json:"due_date"`
+
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	CreatedBy int64 `json:"created_by"`
+}
+
+// ToMilestoneInfo returns the short version of the milestone.
+func (m *Milestone) ToMilestoneInfo() *MilestoneInfo {
+	return &MilestoneInfo{
+		ID:      m.ID,
+		Title:   m.Title,
+		State:   m.State,
+		DueDate: m.DueDate,
+	}
+}
+
+// MilestoneInfo is a short version of the milestone, used in issue responses.
+type MilestoneInfo struct {
+	ID      int64               `json:"id"`
+	Title   string              `json:"title"`
+	State   enum.MilestoneState `json:"state"`
+	DueDate *int64              `json:"due_date"`
+}
+
+// MilestoneFilter stores milestone query parameters.
+type MilestoneFilter struct {
+	ListQueryFilter
+	States []enum.MilestoneState `json:"state"`
+}
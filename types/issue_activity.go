@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// IssueActivity represents an issue activity.
+type IssueActivity struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"-"` // not returned, it's an internal field
+
+	CreatedBy int64  `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"` // we need updated to determine the latest version reliably.
+	Edited    int64  `json:"edited"`
+	Deleted   *int64 `json:"deleted,omitempty"`
+
+	ParentID *int64 `json:"parent_id"`
+	RepoID   int64  `json:"repo_id"`
+	IssueID  int64  `json:"issue_id"`
+
+	Order    int64 `json:"order"`
+	SubOrder int64 `json:"sub_order"`
+	ReplySeq int64 `json:"-"` // not returned, because it's a server's internal field
+
+	Type enum.IssueActivityType `json:"type"`
+	Kind enum.IssueActivityKind `json:"kind"`
+
+	Text       string                 `json:"text"`
+	PayloadRaw json.RawMessage        `json:"payload"`
+	Metadata   map[string]interface{} `json:"metadata"`
+
+	Author PrincipalInfo `json:"author"`
+}
+
+func (a *IssueActivity) IsReplyable() bool {
+	return a.Type == enum.IssueActivityTypeComment && a.SubOrder == 0
+}
+
+func (a *IssueActivity) IsReply() bool {
+	return a.SubOrder > 0
+}
+
+// SetPayload sets the payload and verifies it's of correct type for the activity.
+func (a *IssueActivity) SetPayload(payload IssueActivityPayload) error {
+	if payload == nil {
+		a.PayloadRaw = json.RawMessage(nil)
+		return nil
+	}
+
+	if payload.ActivityType() != a.Type {
+		return fmt.Errorf("wrong payload type %T for activity %s, payload is for %s",
+			payload, a.Type, payload.ActivityType())
+	}
+
+	var err error
+	if a.PayloadRaw, err = json.Marshal(payload); err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return nil
+}
+
+// GetPayload returns the payload of the activity.
+// An error is returned in case there's an issue retrieving the payload from its raw value.
+// NOTE: To ensure rawValue gets changed always use SetPayload() with the updated payload.
+func (a *IssueActivity) GetPayload() (IssueActivityPayload, error) {
+	// jsonMessage could also contain "null" - we still want to return ErrNoPayload in that case
+	if a.PayloadRaw == nil ||
+		bytes.Equal(a.PayloadRaw, jsonRawMessageNullBytes) {
+		return nil, ErrNoPayload
+	}
+
+	payload, err := newPayloadForIssueActivity(a.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new payload: %w", err)
+	}
+
+	if err = json.Unmarshal(a.PayloadRaw, payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+// IssueActivityFilter stores issue activity query parameters.
+type IssueActivityFilter struct {
+	After  int64 `json:"after"`
+	Before int64 `json:"before"`
+	Limit  int   `json:"limit"`
+
+	Types []enum.IssueActivityType `json:"type"`
+	Kinds []enum.IssueActivityKind `json:"kind"`
+}
+
+// IssueActivityPayload is an interface used to identify issue activity payload types.
+type IssueActivityPayload interface {
+	// ActivityType returns the issue activity type the payload is meant for.
+	ActivityType() enum.IssueActivityType
+}
+
+// issueActivityPayloadFactoryMethod is an alias for a function that creates a new IssueActivityPayload.
+type issueActivityPayloadFactoryMethod func() IssueActivityPayload
+
+// allIssueActivityPayloads is a map that contains the payload factory methods for all activity types with payload.
+var allIssueActivityPayloads = func(
+	factoryMethods []issueActivityPayloadFactoryMethod,
+) map[enum.IssueActivityType]issueActivityPayloadFactoryMethod {
+	payloadMap := make(map[enum.IssueActivityType]issueActivityPayloadFactoryMethod)
+	for _, factoryMethod := range factoryMethods {
+		payloadMap[factoryMethod().ActivityType()] = factoryMethod
+	}
+	return payloadMap
+}([]issueActivityPayloadFactoryMethod{
+	func() IssueActivityPayload { return IssueActivityPayloadComment{} },
+	func() IssueActivityPayload { return &IssueActivityPayloadStateChange{} },
+	func() IssueActivityPayload { return &IssueActivityPayloadTitleChange{} },
+	func() IssueActivityPayload { return &IssueActivityPayloadAssignee{} },
+	func() IssueActivityPayload { return &IssueActivityPayloadLabel{} },
+	func() IssueActivityPayload { return &IssueActivityPayloadMilestone{} },
+})
+
+// newPayloadForIssueActivity returns a new payload instance for the requested activity type.
+func newPayloadForIssueActivity(t enum.IssueActivityType) (IssueActivityPayload, error) {
+	payloadFactoryMethod, ok := allIssueActivityPayloads[t]
+	if !ok {
+		return nil, fmt.Errorf("issue activity type '%s' doesn't have a payload", t)
+	}
+
+	return payloadFactoryMethod(), nil
+}
+
+type IssueActivityPayloadComment struct{}
+
+func (a IssueActivityPayloadComment) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeComment
+}
+
+type IssueActivityPayloadStateChange struct {
+	Old enum.IssueState `json:"old"`
+	New enum.IssueState `json:"new"`
+
+	// PullReqNumber is set if the issue got closed by merging a pull request that references it.
+	PullReqNumber int64 `json:"pullreq_number,omitempty"`
+}
+
+func (a *IssueActivityPayloadStateChange) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeStateChange
+}
+
+type IssueActivityPayloadTitleChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+func (a *IssueActivityPayloadTitleChange) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeTitleChange
+}
+
+type IssueActivityPayloadAssignee struct {
+	Action   enum.AssigneeAction `json:"action"`
+	Assignee *PrincipalInfo      `json:"assignee"`
+}
+
+func (a *IssueActivityPayloadAssignee) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeAssigneeModify
+}
+
+type IssueActivityPayloadLabel struct {
+	Action enum.LabelAction `json:"action"`
+	Label  *LabelInfo       `json:"label"`
+
+	// OldLabel is the scoped label that was replaced, set only for the reassigned action.
+	OldLabel *LabelInfo `json:"old_label,omitempty"`
+}
+
+func (a *IssueActivityPayloadLabel) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeLabelModify
+}
+
+type IssueActivityPayloadMilestone struct {
+	Old *MilestoneInfo `json:"old"`
+	New *MilestoneInfo `json:"new"`
+}
+
+func (a *IssueActivityPayloadMilestone) ActivityType() enum.IssueActivityType {
+	return enum.IssueActivityTypeMilestoneModify
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Label is a label definition. Labels are defined in a space and can be assigned to pull requests and issues
+// of all repositories in the space and in its child spaces.
+// A label with a value is a scoped label: only one label with the same key can be assigned to a pull request
+// or an issue.
+type Label struct {
+	ID      int64 `json:"id"`
+	SpaceID int64 `json:"space_id"`
+
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	CreatedBy int64 `json:"created_by"`
+}
+
+// IsScoped returns true if the label is a scoped (key/value) label.
+func (l *Label) IsScoped() bool {
+	return l.Value != ""
+}
+
+// String returns the label as text: the key, followed by a colon and the value for scoped labels.
+func (l *Label) String() string {
+	if l.IsScoped() {
+		return l.Key + ":" + l.Value
+	}
+
+	return l.Key
+}
+
+// ToLabelInfo returns the short version of the label.
+func (l *Label) ToLabelInfo() *LabelInfo {
+	return &LabelInfo{
+		ID:    l.ID,
+		Key:   l.Key,
+		Value: l.Value,
+		Color: l.Color,
+	}
+}
+
+// LabelInfo is a short version of the label, used in pull request and issue responses.
+type LabelInfo struct {
+	ID    int64  `json:"id"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	Color string `json:"color"`
+}
+
+// LabelFilter stores label query parameters.
+type LabelFilter struct {
+	ListQueryFilter
+	Inherited bool `json:"inherited"`
+}
+
+// PullReqLabel is an assignment of a label to a pull request.
+type PullReqLabel struct {
+	PullReqID int64
+	LabelID   int64
+	Created   int64
+	CreatedBy int64
+}
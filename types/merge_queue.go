@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its target branch.
+type MergeQueueEntry struct {
+	ID            int64                     `json:"id"`
+	RepoID        int64                     `json:"repo_id"`
+	PullReqID     int64                     `json:"-"`
+	PullReqNumber int64                     `json:"pullreq_number"`
+	TargetBranch  string                    `json:"target_branch"`
+	Method        enum.MergeMethod          `json:"method"`
+	State         enum.MergeQueueEntryState `json:"state"`
+
+	// SourceSHA is the commit of the pull request's source branch that was enqueued.
+	SourceSHA string `json:"source_sha"`
+	// BaseSHA is the commit the speculative merge commit was created on top of: Either the head of
+	// the target branch or the merge commit of the previous entry in the queue.
+	BaseSHA string `json:"base_sha"`
+	// MergeSHA is the speculative merge commit against which the required status checks are run.
+	MergeSHA string `json:"merge_sha"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	// Position is the zero based position of the entry in the queue. Not stored in the DB.
+	Position int `json:"position"`
+}
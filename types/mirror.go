@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoMirror represents a pull or push mirror configuration of a repository.
+type RepoMirror struct {
+	ID        int64                `json:"id"`
+	RepoID    int64                `json:"repo_id"`
+	Direction enum.MirrorDirection `json:"direction"`
+	RemoteURL string               `json:"remote_url"`
+	Username  string               `json:"username"`
+
+	// Password contains the encrypted password or access token of the remote.
+	Password string `json:"-"`
+
+	// Interval is the time in seconds between two syncs of a pull mirror.
+	// For push mirrors it's the time after which a failed sync is retried.
+	Interval int64 `json:"interval"`
+	Enabled  bool  `json:"enabled"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	LastSync       *int64                `json:"last_sync"`
+	LastSyncStatus enum.MirrorSyncStatus `json:"last_sync_status"`
+	LastSyncError  string                `json:"last_sync_error"`
+
+	// NextSync is the time at which the mirror is synced next, zero if no sync is scheduled.
+	NextSync int64 `json:"next_sync"`
+}
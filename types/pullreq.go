@@ -59,6 +59,26 @@ type PullReq struct {
 	Author PrincipalInfo  `json:"author"`
 	Merger *PrincipalInfo `json:"merger"`
 	Stats  PullReqStats   `json:"stats"`
+
+	// AutoMerge holds the auto-merge settings, nil if auto-merge isn't enabled for the pull request.
+	AutoMerge *AutoMerge `json:"auto_merge,omitempty"`
+
+	// MergeQueue is the merge queue entry of the pull request, nil if the pull request isn't in a merge queue.
+	MergeQueue *MergeQueueEntry `json:"merge_queue,omitempty"`
+
+	// Labels are the labels assigned to the pull request.
+	Labels []*LabelInfo `json:"labels,omitempty"`
+}
+
+// AutoMerge holds the settings for merging a pull request automatically once all requirements are satisfied.
+type AutoMerge struct {
+	Method  enum.MergeMethod `json:"method"`
+	Title   string           `json:"title,omitempty"`
+	Message string           `json:"message,omitempty"`
+
+	RequestedBy int64          `json:"-"` // not returned, because the requester info is in the Requester field
+	Requested   int64          `json:"requested"`
+	Requester   *PrincipalInfo `json:"requester"`
 }
 
 // DiffStats shows total number of commits and modified files.
@@ -97,6 +117,9 @@ type PullReqFilter struct {
 	States        []enum.PullReqState `json:"state"`
 	Sort          enum.PullReqSort    `json:"sort"`
 	Order         enum.Order          `json:"order"`
+	SourceSHA     string              `json:"-"` // used internally, to find pull requests of a commit
+	AutoMerge     bool                `json:"-"` // used internally, to find pull requests with auto-merge enabled
+	LabelIDs      []int64             `json:"label_id"`
 }
 
 // PullReqReview holds pull request review.
@@ -154,6 +177,9 @@ type MergeResponse struct {
 	AllowedMethods []enum.MergeMethod `json:"allowed_methods,omitempty"`
 	ConflictFiles  []string           `json:"conflict_files,omitempty"`
 	RuleViolations []RuleViolations   `json:"rule_violations,omitempty"`
+
+	// FastForwardPossible is set in the dry run if the target branch can be fast-forwarded to the source branch.
+	FastForwardPossible bool `json:"fast_forward_possible,omitempty"`
 }
 
 type MergeViolations struct {
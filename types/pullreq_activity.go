@@ -42,6 +42,9 @@ type PullReqActivity struct {
 	Edited    int64  `json:"edited"`
 	Deleted   *int64 `json:"deleted,omitempty"`
 
+	// Pending activities are comments of a review that is not yet submitted, visible only to the author.
+	Pending bool `json:"pending,omitempty"`
+
 	ParentID  *int64 `json:"parent_id"`
 	RepoID    int64  `json:"repo_id"`
 	PullReqID int64  `json:"pullreq_id"`
@@ -104,7 +107,8 @@ func (a *PullReqActivity) IsReply() bool {
 
 // IsBlocking returns true if the pull request activity (comment/code-comment) is blocking the pull request merge.
 func (a *PullReqActivity) IsBlocking() bool {
-	return a.SubOrder == 0 && a.Resolved == nil && a.Deleted == nil && a.Kind != enum.PullReqActivityKindSystem
+	return a.SubOrder == 0 && a.Resolved == nil && a.Deleted == nil && !a.Pending &&
+		a.Kind != enum.PullReqActivityKindSystem
 }
 
 // SetPayload sets the payload and verifies it's of correct type for the activity.
@@ -157,6 +161,9 @@ type PullReqActivityFilter struct {
 
 	Types []enum.PullReqActivityType `json:"type"`
 	Kinds []enum.PullReqActivityKind `json:"kind"`
+
+	// PendingAuthorID includes the pending activities of the principal. Pending activities of others are excluded.
+	PendingAuthorID int64 `json:"-"`
 }
 
 // PullReqActivityPayload is an interface used to identify PR activity payload types.
@@ -190,6 +197,9 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewSubmit{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueue{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMerge{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadLabel{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -213,6 +223,9 @@ type PullRequestActivityPayloadCodeComment struct {
 	Lines        []string `json:"lines"`
 	LineStartNew bool     `json:"line_start_new"`
 	LineEndNew   bool     `json:"line_end_new"`
+
+	// Suggestion is set if the text of the code comment contains a suggestion block.
+	Suggestion *CodeCommentSuggestion `json:"suggestion,omitempty"`
 }
 
 func (a *PullRequestActivityPayloadCodeComment) ActivityType() enum.PullReqActivityType {
@@ -275,3 +288,35 @@ type PullRequestActivityPayloadBranchDelete struct {
 func (a *PullRequestActivityPayloadBranchDelete) ActivityType() enum.PullReqActivityType {
 	return enum.PullReqActivityTypeBranchDelete
 }
+
+type PullRequestActivityPayloadMergeQueue struct {
+	Action enum.MergeQueueAction `json:"action"`
+	Method enum.MergeMethod      `json:"method,omitempty"`
+	Reason string                `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueue) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueue
+}
+
+type PullRequestActivityPayloadAutoMerge struct {
+	Action enum.AutoMergeAction `json:"action"`
+	Method enum.MergeMethod     `json:"method,omitempty"`
+	Reason string               `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMerge) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMerge
+}
+
+type PullRequestActivityPayloadLabel struct {
+	Action enum.LabelAction `json:"action"`
+	Label  *LabelInfo       `json:"label"`
+
+	// OldLabel is the scoped label that was replaced, set only for the reassigned action.
+	OldLabel *LabelInfo `json:"old_label,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadLabel) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeLabelModify
+}
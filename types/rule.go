@@ -32,6 +32,11 @@ type Rule struct {
 	RepoID  *int64 `json:"-"`
 	SpaceID *int64 `json:"-"`
 
+	// SpacePath is the path of the space the rule is defined on. Set only for space-level rules.
+	SpacePath string `json:"space_path,omitempty"`
+	// RepoPath is the path of the repository the rule is defined on. Set only for repository-level rules.
+	RepoPath string `json:"repo_path,omitempty"`
+
 	Identifier  string `json:"identifier"`
 	Description string `json:"description"`
 
@@ -44,6 +49,9 @@ type Rule struct {
 	CreatedByInfo PrincipalInfo `json:"created_by"`
 
 	Users map[int64]*PrincipalInfo `json:"users"`
+
+	// ActiveFreeze is the change freeze window of the rule that is currently active, if any.
+	ActiveFreeze *RuleFreeze `json:"active_freeze,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -66,6 +74,9 @@ type RuleFilter struct {
 	States []enum.RuleState
 	Sort   enum.RuleSort `json:"sort"`
 	Order  enum.Order    `json:"order"`
+
+	// Inherited includes the rules defined on the parent spaces.
+	Inherited bool `json:"inherited"`
 }
 
 // Violation represents a single violation.
@@ -103,6 +114,13 @@ func (violations *RuleViolations) IsCritical() bool {
 	return violations.Rule.State == enum.RuleStateActive && !violations.Bypassed && len(violations.Violations) > 0
 }
 
+// RuleFreeze is a change freeze window of a protection rule. Start and End are unix milliseconds.
+type RuleFreeze struct {
+	Start  int64  `json:"start"`
+	End    int64  `json:"end"`
+	Reason string `json:"reason,omitempty"`
+}
+
 // RuleInfo holds basic info about a rule that is used to describe the rule in RuleViolations.
 type RuleInfo struct {
 	SpacePath string `json:"space_path,omitempty"`
@@ -136,3 +154,24 @@ type RuleInfoInternal struct {
 type RulesViolations struct {
 	Violations []RuleViolations `json:"violations"`
 }
+
+// RuleMonitorViolation holds violations of a protection rule in the monitor state
+// that have been recorded when an operation was performed on a repository.
+type RuleMonitorViolation struct {
+	ID         int64                    `json:"id"`
+	RuleID     int64                    `json:"-"`
+	RepoID     int64                    `json:"-"`
+	Action     enum.RuleViolationAction `json:"action"`
+	Violations []Violation              `json:"violations"`
+	CreatedBy  int64                    `json:"-"`
+	Created    int64                    `json:"created"`
+
+	Author PrincipalInfo `json:"author"`
+}
+
+// RuleMonitorViolationFilter stores monitor-mode rule violation query parameters.
+type RuleMonitorViolationFilter struct {
+	Pagination
+	Since int64 `json:"since"`
+	Until int64 `json:"until"`
+}
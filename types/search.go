@@ -16,8 +16,12 @@ package types
 
 type (
 	SearchInput struct {
+		// Query contains the search pattern and optional filters (lang:<language>, path:<substring>, case:yes).
 		Query string `json:"query"`
 
+		// EnableRegex indicates that the search pattern is a regular expression
+		EnableRegex bool `json:"enable_regex"`
+
 		// RepoPaths contains the paths of repositories to search in
 		RepoPaths []string `json:"repo_paths"`
 
@@ -64,8 +68,9 @@ type (
 
 	// Fragment holds data of a single contiguous match within a line.
 	Fragment struct {
-		Pre   string `json:"pre"`   // the string before the match within the line
-		Match string `json:"match"` // the matched string
-		Post  string `json:"post"`  // the string after the match within the line
+		Offset int    `json:"offset"` // the byte offset of the match within the line
+		Pre    string `json:"pre"`    // the string before the match within the line
+		Match  string `json:"match"`  // the matched string
+		Post   string `json:"post"`   // the string after the match within the line
 	}
 )
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// SecretScanRule is a rule of the secret scanner.
+type SecretScanRule struct {
+	Identifier  string `json:"identifier"`
+	Description string `json:"description,omitempty"`
+
+	// Regex is the regular expression that matches the secret.
+	Regex string `json:"regex"`
+
+	// SecretGroup is the index of the capture group of the regular expression that contains the secret.
+	// Zero means that the whole match is the secret.
+	SecretGroup int `json:"secret_group,omitempty"`
+
+	// MinEntropy is the minimum Shannon entropy (in bits per character) the secret must have.
+	// It filters out placeholders and example values. Zero disables the entropy check.
+	MinEntropy float64 `json:"min_entropy,omitempty"`
+}
+
+// SecretScanAllowlist contains the files and the secrets that are ignored by the secret scanner.
+type SecretScanAllowlist struct {
+	// Paths are glob patterns of files that aren't scanned. Patterns without
+	// a slash are matched against the file name, others against the full file path.
+	Paths []string `json:"paths"`
+
+	// Fingerprints are fingerprints of secrets that are ignored, e.g. of well-known test credentials.
+	Fingerprints []string `json:"fingerprints"`
+}
+
+// SecretScanSettings holds the secret scanning settings of a space or of a repository.
+// Repositories inherit the settings of all their parent spaces.
+type SecretScanSettings struct {
+	ID      int64  `json:"-"`
+	SpaceID *int64 `json:"space_id,omitempty"`
+	RepoID  *int64 `json:"repo_id,omitempty"`
+
+	// Mode is the enforcement mode. If empty, the mode is inherited from the parent space.
+	Mode enum.SecretScanMode `json:"mode"`
+
+	// Rules are custom rules that are used in addition to the built-in rules.
+	Rules     []SecretScanRule    `json:"rules"`
+	Allowlist SecretScanAllowlist `json:"allowlist"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// SecretScanConfig is the effective secret scanning configuration of a repository.
+// It combines the built-in rules with the settings of the repository and of its parent spaces.
+type SecretScanConfig struct {
+	Mode      enum.SecretScanMode `json:"mode"`
+	Rules     []SecretScanRule    `json:"rules"`
+	Allowlist SecretScanAllowlist `json:"allowlist"`
+}
+
+// SecretFinding is a secret found in the content of a repository.
+type SecretFinding struct {
+	ID     int64                    `json:"id"`
+	RepoID int64                    `json:"repo_id"`
+	ScanID *int64                   `json:"scan_id,omitempty"`
+	Source enum.SecretFindingSource `json:"source"`
+
+	CommitSHA string `json:"commit_sha"`
+	Path      string `json:"path"`
+	Line      int    `json:"line"`
+	Rule      string `json:"rule"`
+
+	// Fingerprint identifies the secret, it can be used to add the secret to the allowlist.
+	Fingerprint string `json:"fingerprint"`
+
+	// Secret contains the redacted secret.
+	Secret string `json:"secret"`
+
+	// BypassJustification is the reason provided by the user for committing the secret
+	// despite the repository blocking secrets.
+	BypassJustification string `json:"bypass_justification,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+
+	Author PrincipalInfo `json:"author"`
+}
+
+// SecretFindingFilter stores secret finding query parameters.
+type SecretFindingFilter struct {
+	Pagination
+	ScanID int64                    `json:"scan_id"`
+	Source enum.SecretFindingSource `json:"source"`
+}
+
+// SecretScan is a scan of the whole history of a repository for secrets.
+type SecretScan struct {
+	ID       int64                 `json:"id"`
+	RepoID   int64                 `json:"repo_id"`
+	Status   enum.SecretScanStatus `json:"status"`
+	Error    string                `json:"error,omitempty"`
+	Findings int64                 `json:"findings"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// SigningKey represents a GPG or SSH public key a principal uses to sign commits and tags.
+type SigningKey struct {
+	ID          int64 `json:"-"`
+	PrincipalID int64 `json:"-"`
+
+	Created int64 `json:"created"`
+
+	Identifier  string              `json:"identifier"`
+	Type        enum.SigningKeyType `json:"type"`
+	Content     string              `json:"content"`
+	Fingerprint string              `json:"fingerprint"`
+}
+
+// ServerSigningKey is a GPG or SSH key the server uses to sign commits and tags it creates.
+// The key belongs to a space, or to the whole instance if it has no space.
+type ServerSigningKey struct {
+	ID      int64  `json:"-"`
+	SpaceID *int64 `json:"-"`
+
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	CreatedBy int64 `json:"created_by"`
+
+	Type        enum.SigningKeyType `json:"type"`
+	PublicKey   string              `json:"public_key"`
+	Fingerprint string              `json:"fingerprint"`
+
+	// PrivateKey is the encrypted private key.
+	PrivateKey string `json:"-"`
+}
+
+// SignatureVerification is the result of the verification of a commit or tag signature.
+type SignatureVerification struct {
+	Verified bool                             `json:"verified"`
+	Reason   enum.SignatureVerificationReason `json:"reason"`
+
+	// KeyType and KeyID identify the key that verified the signature.
+	KeyType enum.SigningKeyType `json:"key_type,omitempty"`
+	KeyID   string              `json:"key_id,omitempty"`
+
+	// Signer is the principal that owns the key that verified the signature.
+	Signer *PrincipalInfo `json:"signer,omitempty"`
+}